@@ -140,6 +140,9 @@ func bidOnLot(c *cli.Context) error {
 		if canBid.BidOnLotDisabled {
 			fmt.Println("Bidding on lots is currently disabled.")
 		}
+		if canBid.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canBid.RevertReason)
+		}
 		return nil
 	}
 
@@ -106,6 +106,10 @@ func claimFromLot(c *cli.Context) error {
 		if err != nil {
 			return fmt.Errorf("Error checking if claiming lot %d is possible: %w", lot.Details.Index, err)
 		}
+		if canResponse.RevertReason != "" {
+			fmt.Printf("Cannot claim RPL from lot %d: the transaction would revert (%s).\n", lot.Details.Index, canResponse.RevertReason)
+			return nil
+		}
 		gasInfo = canResponse.GasInfo
 		totalGas += canResponse.GasInfo.EstGasLimit
 		totalSafeGas += canResponse.GasInfo.SafeGasLimit
@@ -38,6 +38,9 @@ func createLot(c *cli.Context) error {
 		if canCreate.CreateLotDisabled {
 			fmt.Println("Lot creation is currently disabled.")
 		}
+		if canCreate.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canCreate.RevertReason)
+		}
 		return nil
 	}
 
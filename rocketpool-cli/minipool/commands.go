@@ -96,6 +96,49 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "rotate-withdrawal-creds",
+				Aliases:   []string{"rwc"},
+				Usage:     "Change the withdrawal credentials of any of the node's validators still using BLS (0x00) credentials to their minipool addresses",
+				UsageText: "rocketpool minipool rotate-withdrawal-creds [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool to change the withdrawal credentials of (address or 'all')",
+					},
+					cli.StringFlag{
+						Name:  "mnemonic",
+						Usage: "Use this flag to provide the mnemonic for your validators' withdrawal keys instead of typing it interactively.",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm changing the withdrawal credentials",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+					if c.String("mnemonic") != "" {
+						if _, err := cliutils.ValidateWalletMnemonic("mnemonic", c.String("mnemonic")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return rotateWithdrawalCreds(c)
+
+				},
+			},
 			{
 				Name:      "import-key",
 				Aliases:   []string{"ik"},
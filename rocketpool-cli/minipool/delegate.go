@@ -94,6 +94,9 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 		if err != nil {
 			fmt.Printf("WARNING: Couldn't get gas price for upgrade transaction (%s)\n", err)
 			break
+		} else if canResponse.RevertReason != "" {
+			fmt.Printf("Cannot upgrade the delegate of minipool %s: the transaction would revert (%s).\n", minipool.Hex(), canResponse.RevertReason)
+			return nil
 		} else {
 			fmt.Printf("Minipool %s will upgrade to delegate contract %s.\n", minipool.Hex(), canResponse.LatestDelegateAddress.Hex())
 			gasInfo = canResponse.GasInfo
@@ -206,6 +209,9 @@ func delegateRollbackMinipools(c *cli.Context) error {
 		canResponse, err := rp.CanDelegateRollbackMinipool(minipool)
 		if err != nil {
 			return fmt.Errorf("error checking if minipool %s could be rolled back: %w", minipool.Hex(), err)
+		} else if canResponse.RevertReason != "" {
+			fmt.Printf("Cannot roll back the delegate of minipool %s: the transaction would revert (%s).\n", minipool.Hex(), canResponse.RevertReason)
+			return nil
 		} else {
 			fmt.Printf("Minipool %s will roll back to delegate contract %s.\n", minipool.Hex(), canResponse.RollbackAddress.Hex())
 			gasInfo = canResponse.GasInfo
@@ -339,6 +345,9 @@ func setUseLatestDelegateMinipools(c *cli.Context, setting bool) error {
 		canResponse, err := rp.CanSetUseLatestDelegateMinipool(minipool, setting)
 		if err != nil {
 			return fmt.Errorf("error checking if minipool %s could have its use-latest-delegate flag changed: %w", minipool.Hex(), err)
+		} else if canResponse.RevertReason != "" {
+			fmt.Printf("Cannot change the use-latest-delegate flag of minipool %s: the transaction would revert (%s).\n", minipool.Hex(), canResponse.RevertReason)
+			return nil
 		} else {
 			gasInfo = canResponse.GasInfo
 			totalGas += canResponse.GasInfo.EstGasLimit
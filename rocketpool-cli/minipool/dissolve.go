@@ -100,6 +100,9 @@ func dissolveMinipools(c *cli.Context) error {
 		if err != nil {
 			fmt.Printf("WARNING: Couldn't get gas price for dissolve transaction (%s)", err)
 			break
+		} else if canResponse.RevertReason != "" {
+			fmt.Printf("Cannot dissolve minipool %s: the transaction would revert (%s).\n", minipool.Address.Hex(), canResponse.RevertReason)
+			return nil
 		} else {
 			gasInfo = canResponse.GasInfo
 			totalGas += canResponse.GasInfo.EstGasLimit
@@ -172,6 +172,9 @@ func beginReduceBondAmount(c *cli.Context) error {
 				if canResponse.InvalidBeaconState {
 					fmt.Printf("The minipool's validator is not in a legal state on the Beacon Chain. It must be pending or active (current state: %s)\n", canResponse.BeaconState)
 				}
+				if canResponse.RevertReason != "" {
+					fmt.Printf("The transaction would revert: %s\n", canResponse.RevertReason)
+				}
 				return nil
 			}
 			gasInfo = canResponse.GasInfo
@@ -327,7 +330,12 @@ func reduceBondAmount(c *cli.Context) error {
 			return fmt.Errorf("error checking if minipool %s can have its bond reduced: %w", minipool.Address.Hex(), err)
 		} else if !canResponse.CanReduce {
 			fmt.Printf("Minipool %s cannot have its bond reduced:\n", minipool.Address.Hex())
-			fmt.Println("The minipool version is too low. Please run `rocketpool minipool delegate-upgrade` to update it.")
+			if canResponse.MinipoolVersion < 3 {
+				fmt.Println("The minipool version is too low. Please run `rocketpool minipool delegate-upgrade` to update it.")
+			}
+			if canResponse.RevertReason != "" {
+				fmt.Printf("The transaction would revert: %s\n", canResponse.RevertReason)
+			}
 			return nil
 		} else {
 			gasInfo = canResponse.GasInfo
@@ -99,6 +99,9 @@ func refundMinipools(c *cli.Context) error {
 		if err != nil {
 			fmt.Printf("WARNING: Couldn't get gas price for refund transaction (%s)", err.Error())
 			break
+		} else if canResponse.RevertReason != "" {
+			fmt.Printf("Cannot refund minipool %s: the transaction would revert (%s).\n", minipool.Address.Hex(), canResponse.RevertReason)
+			return nil
 		} else {
 			gasInfo = canResponse.GasInfo
 			totalGas += canResponse.GasInfo.EstGasLimit
@@ -0,0 +1,117 @@
+package minipool
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func rotateWithdrawalCreds(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the minipools that still use BLS withdrawal creds
+	eligible, err := rp.GetBlsCredsMinipools()
+	if err != nil {
+		return err
+	}
+	if len(eligible.Minipools) == 0 {
+		fmt.Println("None of the node's minipool validators are using BLS (0x00) withdrawal credentials.")
+		return nil
+	}
+
+	// Get selected minipools
+	var selectedMinipools []api.BlsCredsMinipool
+	if c.String("minipool") == "" {
+
+		// Prompt for minipool selection
+		options := make([]string, len(eligible.Minipools)+1)
+		options[0] = "All available minipools"
+		for mi, minipool := range eligible.Minipools {
+			options[mi+1] = fmt.Sprintf("%s (validator %d, pubkey %s)", minipool.Address.Hex(), minipool.ValidatorIndex, minipool.Pubkey.Hex())
+		}
+		selected, _ := cliutils.Select("Please select a minipool to change the withdrawal credentials of:", options)
+
+		// Get minipools
+		if selected == 0 {
+			selectedMinipools = eligible.Minipools
+		} else {
+			selectedMinipools = []api.BlsCredsMinipool{eligible.Minipools[selected-1]}
+		}
+
+	} else {
+
+		// Get matching minipools
+		if c.String("minipool") == "all" {
+			selectedMinipools = eligible.Minipools
+		} else {
+			selectedAddress := common.HexToAddress(c.String("minipool"))
+			for _, minipool := range eligible.Minipools {
+				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
+					selectedMinipools = []api.BlsCredsMinipool{minipool}
+					break
+				}
+			}
+			if selectedMinipools == nil {
+				return fmt.Errorf("The minipool %s is not using BLS withdrawal credentials.", selectedAddress.Hex())
+			}
+		}
+
+	}
+
+	fmt.Printf("%sThis will permanently change the withdrawal credentials of %d validator(s) from their BLS (0x00) withdrawal keys to their minipool addresses. This cannot be undone.%s\n\n", colorYellow, len(selectedMinipools), colorReset)
+
+	// Get the mnemonic
+	mnemonic := ""
+	if c.IsSet("mnemonic") {
+		mnemonic = c.String("mnemonic")
+	} else {
+		mnemonic = wallet.PromptMnemonic()
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to change the withdrawal credentials of %d validator(s)?", len(selectedMinipools)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Rotate the credentials
+	addresses := make([]common.Address, len(selectedMinipools))
+	for i, minipool := range selectedMinipools {
+		addresses[i] = minipool.Address
+	}
+	response, err := rp.RotateWithdrawalCredentials(addresses, mnemonic)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	for _, rotation := range response.Rotations {
+		if rotation.Rotated {
+			fmt.Printf("Submitted the withdrawal credentials change for minipool %s.\n", rotation.Address.Hex())
+		} else {
+			fmt.Printf("%sCould not change the withdrawal credentials for minipool %s: %s%s\n", colorRed, rotation.Address.Hex(), rotation.FailureReason, colorReset)
+		}
+	}
+	fmt.Println("\nThe changes will be processed by the Beacon Chain over the next few epochs; you can check them with `rocketpool minipool status`.")
+	return nil
+
+}
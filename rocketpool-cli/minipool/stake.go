@@ -97,6 +97,9 @@ func stakeMinipools(c *cli.Context) error {
 		if err != nil {
 			fmt.Printf("WARNING: Couldn't get gas price for stake transaction (%s)", err)
 			break
+		} else if canResponse.RevertReason != "" {
+			fmt.Printf("Cannot stake minipool %s: the transaction would revert (%s).\n", minipool.Address.Hex(), canResponse.RevertReason)
+			return nil
 		} else {
 			gasInfo = canResponse.GasInfo
 			totalGas += canResponse.GasInfo.EstGasLimit
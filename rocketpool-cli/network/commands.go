@@ -32,6 +32,48 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "deployment",
+				Usage:     "Show the Rocket Pool deployment the Smartnode is using and verify its contracts on-chain",
+				UsageText: "rocketpool network deployment",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDeploymentStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "queue-status",
+				Aliases:   []string{"q"},
+				Usage:     "Get the deposit pool balance, minipool queue lengths, deposit assignment settings, and recent rETH exchange rates",
+				UsageText: "rocketpool network queue-status [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "The number of days of rETH exchange rate history to show",
+						Value: defaultQueueStatusDays,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getQueueStatus(c)
+
+				},
+			},
+
 			{
 				Name:      "timezone-map",
 				Aliases:   []string{"t"},
@@ -86,6 +128,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "gas-oracle",
+				Usage:     "Get the slow, standard, and fast fees suggested from the recent fee history of your Execution client",
+				UsageText: "rocketpool network gas-oracle",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getGasOracle(c)
+
+				},
+			},
+
 			{
 				Name:      "generate-rewards-tree",
 				Aliases:   []string{"g"},
@@ -118,6 +177,82 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-rewards-tree",
+				Aliases:   []string{"v"},
+				Usage:     "Regenerate the rewards tree for the provided interval and check that its Merkle root matches the one submitted by the Oracle DAO.\nNote that this is an asynchronous process, so it will return before the tree is verified.\nRun this command again afterwards to see the result.",
+				UsageText: "rocketpool network verify-rewards-tree",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index",
+						Usage: "The index of the rewards interval you want to verify the tree for",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm any questions about tree verification",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyRewardsTree(c)
+
+				},
+			},
+
+			{
+				Name:      "compare-rewards-trees",
+				Usage:     "Compare two rewards tree files for the same interval, showing the nodes whose rewards differ and where their Merkle trees diverge.\nFiles can be in the JSON or binary format, or compressed as they are on IPFS.",
+				UsageText: "rocketpool network compare-rewards-trees file-a file-b",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					return compareRewardsTrees(c)
+
+				},
+			},
+
+			{
+				Name:      "minipool-performance",
+				Usage:     "Show a minipool's attestation performance and smoothing pool eligibility for the provided interval.\nThe interval's minipool performance file is downloaded if it isn't already on disk.",
+				UsageText: "rocketpool network minipool-performance index --minipool address",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The address of the minipool to show the performance of",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.String("minipool"))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return getMinipoolPerformance(c, index, minipoolAddress)
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
@@ -135,6 +270,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "diff-state-snapshots",
+				Usage:     "List the saved network state snapshots, or compare two of them to see which nodes and minipools changed",
+				UsageText: "rocketpool network diff-state-snapshots [from to]",
+				Action: func(c *cli.Context) error {
+
+					// Run
+					return diffStateSnapshots(c)
+
+				},
+			},
 		},
 	})
 }
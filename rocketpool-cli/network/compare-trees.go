@@ -0,0 +1,115 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+func compareRewardsTrees(c *cli.Context) error {
+
+	// Load the files
+	pathA := c.Args().Get(0)
+	pathB := c.Args().Get(1)
+	fileA, err := rprewards.LoadRewardsFile(pathA)
+	if err != nil {
+		return err
+	}
+	fileB, err := rprewards.LoadRewardsFile(pathB)
+	if err != nil {
+		return err
+	}
+	comparison := rprewards.CompareRewardsFiles(fileA, fileB)
+
+	// Print the files
+	fmt.Printf("%s=== Files ===%s\n", colorGreen, colorReset)
+	printRewardsFileSummary("A", pathA, fileA)
+	printRewardsFileSummary("B", pathB, fileB)
+
+	// Print the header fields that differ
+	fmt.Printf("%s=== Header ===%s\n", colorGreen, colorReset)
+	if len(comparison.FieldDifferences) == 0 {
+		fmt.Println("The header fields of both files match.")
+	}
+	for _, difference := range comparison.FieldDifferences {
+		fmt.Printf("%s:\n\tA: %s\n\tB: %s\n", difference.Field, difference.A, difference.B)
+	}
+	fmt.Println()
+
+	// Print where the Merkle trees diverge
+	fmt.Printf("%s=== Merkle Trees ===%s\n", colorGreen, colorReset)
+	if comparison.MerkleRootA != comparison.RebuiltMerkleRootA {
+		fmt.Printf("%sWARNING: file A records a root of %s, but its node rewards produce a root of %s. The file may have been edited or corrupted.%s\n", colorYellow, comparison.MerkleRootA.Hex(), comparison.RebuiltMerkleRootA.Hex(), colorReset)
+	}
+	if comparison.MerkleRootB != comparison.RebuiltMerkleRootB {
+		fmt.Printf("%sWARNING: file B records a root of %s, but its node rewards produce a root of %s. The file may have been edited or corrupted.%s\n", colorYellow, comparison.MerkleRootB.Hex(), comparison.RebuiltMerkleRootB.Hex(), colorReset)
+	}
+	if comparison.MerkleRootsMatch {
+		fmt.Printf("%sThe Merkle roots match.%s\n", colorGreen, colorReset)
+	} else if comparison.RebuiltMerkleRootA == comparison.RebuiltMerkleRootB {
+		fmt.Printf("%sThe node rewards produce the same tree, but the recorded Merkle roots differ.%s\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("%sThe Merkle roots do NOT match.%s\n", colorRed, colorReset)
+		subtree := comparison.MismatchedSubtree
+		if subtree == nil {
+			fmt.Println("The trees have a different number of levels, so their subtrees can't be lined up; see the mismatched leaves below.")
+		} else if subtree.Depth == 0 {
+			fmt.Printf("The mismatches are spread across both halves of the trees, covering leaves %d to %d.\n", subtree.FirstLeaf, subtree.EndLeaf-1)
+		} else {
+			fmt.Printf("Every mismatch is within the subtree at depth %d, position %d, covering leaves %d to %d.\n", subtree.Depth, subtree.Position, subtree.FirstLeaf, subtree.EndLeaf-1)
+			fmt.Printf("\tA: %s\n\tB: %s\n", subtree.HashA.Hex(), subtree.HashB.Hex())
+		}
+		printMerkleLeaves("Leaves only in A", comparison.MismatchedLeavesA)
+		printMerkleLeaves("Leaves only in B", comparison.MismatchedLeavesB)
+	}
+	fmt.Println()
+
+	// Print the node rewards that differ
+	fmt.Printf("%s=== Node Rewards ===%s\n", colorGreen, colorReset)
+	printAddresses("Nodes only in B", comparison.AddedNodes)
+	printAddresses("Nodes only in A", comparison.RemovedNodes)
+	fmt.Printf("Nodes with different rewards (B - A): %d\n", len(comparison.ChangedNodes))
+	for _, delta := range comparison.ChangedNodes {
+		fmt.Printf("\t%s:\n", delta.Node.Hex())
+		if delta.RewardNetworkA != delta.RewardNetworkB {
+			fmt.Printf("\t\tReward network:     %d -> %d\n", delta.RewardNetworkA, delta.RewardNetworkB)
+		}
+		printRewardsDelta("Collateral RPL:", delta.CollateralRpl, "RPL")
+		printRewardsDelta("Oracle DAO RPL:", delta.OracleDaoRpl, "RPL")
+		printRewardsDelta("Smoothing Pool ETH:", delta.SmoothingPoolEth, "ETH")
+	}
+
+	return nil
+
+}
+
+// Print the details of a rewards file
+func printRewardsFileSummary(label string, path string, rewardsFile *rprewards.RewardsFile) {
+	fmt.Printf("%s: %s%s%s\n", label, colorBlue, path, colorReset)
+	fmt.Printf("\tInterval:    %d\n", rewardsFile.Index)
+	fmt.Printf("\tRuleset:     v%d\n", rewardsFile.RulesetVersion)
+	fmt.Printf("\tMerkle root: %s\n", common.HexToHash(rewardsFile.MerkleRoot).Hex())
+	fmt.Printf("\tNodes:       %d\n", len(rewardsFile.NodeRewards))
+	fmt.Println()
+}
+
+// Print a labelled list of Merkle tree leaves
+func printMerkleLeaves(label string, leaves []rprewards.MerkleLeaf) {
+	fmt.Printf("%s: %d\n", label, len(leaves))
+	for _, leaf := range leaves {
+		fmt.Printf("\t%d: %s (%s)\n", leaf.Position, leaf.Node.Hex(), leaf.Hash.Hex())
+	}
+}
+
+// Print how one kind of a node's rewards changed, if it did
+func printRewardsDelta(label string, delta *big.Int, unit string) {
+	if delta.Sign() == 0 {
+		return
+	}
+	fmt.Printf("\t\t%-19s %+.6f %s (%s wei)\n", label, eth.WeiToEth(delta), unit, delta.String())
+}
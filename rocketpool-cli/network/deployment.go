@@ -0,0 +1,97 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getDeploymentStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the deployment status
+	response, err := rp.NetworkDeploymentStatus()
+	if err != nil {
+		return err
+	}
+
+	// Print the deployment
+	fmt.Printf("%s=== Deployment ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Network:         %s\n", response.Network)
+	if response.IsCustom {
+		fmt.Printf("Deployment file: %s (custom deployment)\n", response.DeploymentFile)
+	} else if response.DeploymentFile != "" {
+		fmt.Printf("Deployment file: %s\n", response.DeploymentFile)
+	} else {
+		fmt.Println("Deployment file: none (using the built-in deployment)")
+	}
+	fmt.Printf("RocketStorage:   %s\n", response.StorageAddress.Hex())
+	if response.ExecutionChainID == response.ChainID {
+		fmt.Printf("Chain ID:        %d\n\n", response.ChainID)
+	} else {
+		fmt.Printf("Chain ID:        %s%d (your Execution client is on chain %d)%s\n\n", colorRed, response.ChainID, response.ExecutionChainID, colorReset)
+	}
+
+	// Print the genesis checks
+	if len(response.GenesisChecks) > 0 {
+		fmt.Printf("%s=== Genesis ===%s\n", colorGreen, colorReset)
+		for _, check := range response.GenesisChecks {
+			if check.Error != "" {
+				fmt.Printf("%s: %s%s%s\n", check.Name, colorRed, check.Error, colorReset)
+			} else if check.Matches {
+				fmt.Printf("%s: %s [%sok%s]\n", check.Name, check.Actual, colorGreen, colorReset)
+			} else {
+				fmt.Printf("%s: %s [%smismatch%s]\n    the deployment expects %s\n", check.Name, check.Actual, colorRed, colorReset, check.Expected)
+			}
+		}
+		fmt.Println()
+	}
+
+	// Print the contracts
+	fmt.Printf("%s=== Contracts ===%s\n", colorGreen, colorReset)
+	for _, contract := range response.Contracts {
+		status := string(contract.ContractStatus)
+		switch contract.ContractStatus {
+		case api.DeploymentContractStatus_Ok:
+			status = colorGreen + status + colorReset
+		case api.DeploymentContractStatus_Unverified:
+			status = colorYellow + status + colorReset
+		default:
+			status = colorRed + status + colorReset
+		}
+		version := ""
+		if contract.HasVersion {
+			version = fmt.Sprintf(" v%d", contract.Version)
+		}
+		fmt.Printf("%-34s %s%s [%s]\n", contract.Name, contract.Address.Hex(), version, status)
+		if len(contract.Problems) > 0 {
+			fmt.Printf("    %s\n", strings.Join(contract.Problems, "; "))
+		}
+	}
+	fmt.Println()
+
+	if response.Verified {
+		fmt.Printf("%sEvery contract matches the deployment.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%sThe deployment could not be fully verified. Contracts marked unverified have no expected bytecode hash or version in the deployment; the others don't match it.%s\n", colorYellow, colorReset)
+	}
+	return nil
+
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getGasOracle(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the fee estimate
+	response, err := rp.GetGasOracle()
+	if err != nil {
+		return err
+	}
+	estimate := response.Estimate
+
+	// Print the base fee history
+	fmt.Printf("%s=== Base Fee ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Latest block:       %d\n", estimate.Block)
+	fmt.Printf("Next base fee:      %.2f gwei\n", eth.WeiToGwei(estimate.BaseFee))
+	if len(estimate.BaseFeeHistory) > 0 {
+		lowest := estimate.BaseFeeHistory[0]
+		highest := estimate.BaseFeeHistory[0]
+		for _, baseFee := range estimate.BaseFeeHistory {
+			if baseFee.Cmp(lowest) < 0 {
+				lowest = baseFee
+			}
+			if baseFee.Cmp(highest) > 0 {
+				highest = baseFee
+			}
+		}
+		totalGasUsedRatio := 0.0
+		for _, ratio := range estimate.GasUsedRatios {
+			totalGasUsedRatio += ratio
+		}
+		fmt.Printf("Last %d blocks:     %.2f to %.2f gwei\n", len(estimate.BaseFeeHistory), eth.WeiToGwei(lowest), eth.WeiToGwei(highest))
+		if len(estimate.GasUsedRatios) > 0 {
+			fmt.Printf("Average block fill: %.2f%%\n", totalGasUsedRatio/float64(len(estimate.GasUsedRatios))*100)
+		}
+	}
+	fmt.Println()
+
+	// Print the tiers
+	fmt.Printf("%s=== Suggested Fees ===%s\n", colorGreen, colorReset)
+	fmt.Println("   Tier   |  Max Fee   | Priority Fee")
+	for _, tier := range []oracle.FeeTier{oracle.FeeTier_Slow, oracle.FeeTier_Standard, oracle.FeeTier_Fast} {
+		suggestion, err := estimate.GetSuggestion(tier)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(" %-8s | %-10s | %.2f gwei\n", tier, fmt.Sprintf("%.2f gwei", eth.WeiToGwei(suggestion.MaxFee)), eth.WeiToGwei(suggestion.MaxPriorityFee))
+	}
+	fmt.Println()
+	fmt.Println("Each tier's max fee covers the base fee rising for 1 (slow), 3 (standard), or 6 (fast) full blocks in a row, plus the median priority fee paid at the 10th, 50th, or 90th percentile of recent blocks.")
+	return nil
+
+}
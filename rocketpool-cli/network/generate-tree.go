@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/urfave/cli"
@@ -11,6 +12,7 @@ import (
 
 const (
 	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
 	colorGreen  string = "\033[32m"
 	colorYellow string = "\033[33m"
 )
@@ -31,12 +33,7 @@ func generateRewardsTree(c *cli.Context) error {
 	}
 
 	// Print archive node info
-	archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
-	if archiveEcUrl == "" {
-		fmt.Printf("%sNOTE: in order to generate a Merkle rewards tree for a past rewards interval, you will likely need to have access to an Execution client with archival state.\nBy default, your Smartnode's Execution client will not provide this.\n\nPlease specify the URL of an archive-capable EC in the Smartnode section of the `rocketpool service config` Terminal UI.\nIf you need one, Alchemy provides a free service which you can use: https://www.alchemy.com/ethereum%s\n\n", colorYellow, colorReset)
-	} else {
-		fmt.Printf("%sYou have an archive EC specified at [%s]. This will be used for tree generation.%s\n\n", colorGreen, archiveEcUrl, colorReset)
-	}
+	printArchiveEcInfo(cfg)
 
 	// Get the index
 	var index uint64
@@ -58,6 +55,25 @@ func generateRewardsTree(c *cli.Context) error {
 	if canResponse.CurrentIndex <= index {
 		return fmt.Errorf("The current active rewards period is interval %d. You cannot generate the tree for interval %d until the active interval is past it.", canResponse.CurrentIndex, index)
 	}
+	if canResponse.ArchiveRequired {
+		fmt.Printf("%sInterval %d was snapshotted at execution block %d, but your Execution client doesn't have the state for that block anymore.%s\n", colorRed, index, canResponse.SnapshotBlock, colorReset)
+		if canResponse.EarliestBlockError != "" {
+			fmt.Printf("The earliest block it has the state for couldn't be determined: %s\n", canResponse.EarliestBlockError)
+		} else {
+			fmt.Printf("The earliest block your Execution client has the state for is %d.\n", canResponse.PrimaryEarliestBlock)
+		}
+		if canResponse.ArchiveEcUrl == "" {
+			fmt.Println("Please specify the URL of an archive-capable EC in the Smartnode section of the `rocketpool service config` Terminal UI and try again.")
+		} else {
+			if canResponse.EarliestBlockError == "" {
+				fmt.Printf("Your archive EC [%s] doesn't have it either; the earliest block it has the state for is %d.\n", canResponse.ArchiveEcUrl, canResponse.ArchiveEarliestBlock)
+			} else {
+				fmt.Printf("Your archive EC [%s] doesn't have it either.\n", canResponse.ArchiveEcUrl)
+			}
+			fmt.Println("Please use an EC with full archival state for the archive EC and try again.")
+		}
+		return nil
+	}
 
 	// Confirm file overwrite
 	if canResponse.TreeFileExists {
@@ -70,7 +86,7 @@ func generateRewardsTree(c *cli.Context) error {
 	}
 
 	// Create the generation request
-	_, err = rp.GenerateRewardsTree(index)
+	_, err = rp.RequestRewardsTree(index)
 	if err != nil {
 		return err
 	}
@@ -93,3 +109,13 @@ func generateRewardsTree(c *cli.Context) error {
 	return nil
 
 }
+
+// Print info about the archive EC used for generating trees of past intervals
+func printArchiveEcInfo(cfg *config.RocketPoolConfig) {
+	archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
+	if archiveEcUrl == "" {
+		fmt.Printf("%sNOTE: in order to generate a Merkle rewards tree for a past rewards interval, you will likely need to have access to an Execution client with archival state.\nBy default, your Smartnode's Execution client will not provide this.\n\nPlease specify the URL of an archive-capable EC in the Smartnode section of the `rocketpool service config` Terminal UI.\nIf you need one, Alchemy provides a free service which you can use: https://www.alchemy.com/ethereum%s\n\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("%sYou have an archive EC specified at [%s]. This will be used for tree generation.%s\n\n", colorGreen, archiveEcUrl, colorReset)
+	}
+}
@@ -0,0 +1,100 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The most missed attestation slots to print before summarizing the rest
+const maxMissingSlotsToPrint int = 20
+
+func getMinipoolPerformance(c *cli.Context, index uint64, minipoolAddress common.Address) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the performance
+	fmt.Printf("Getting the performance of minipool %s for interval %d; this may take a while if the minipool performance file has to be downloaded...\n", minipoolAddress.Hex(), index)
+	response, err := rp.GetMinipoolPerformance(index, minipoolAddress)
+	if err != nil {
+		return err
+	}
+	if response.Downloaded {
+		fmt.Printf("Downloaded the minipool performance file for interval %d.\n", index)
+	}
+	fmt.Println()
+
+	// Print the interval
+	fmt.Printf("%s=== Interval %d ===%s\n", colorGreen, response.Index, colorReset)
+	fmt.Printf("Start: %s\n", response.StartTime.Format(time.RFC822))
+	fmt.Printf("End:   %s\n\n", response.EndTime.Format(time.RFC822))
+
+	// Print the minipool's performance
+	fmt.Printf("%s=== Minipool %s ===%s\n", colorGreen, response.Minipool.Hex(), colorReset)
+	if !response.MinipoolFound {
+		fmt.Printf("%sThe minipool isn't in the minipool performance file for interval %d, so it wasn't eligible for smoothing pool rewards during the interval.%s\n", colorYellow, response.Index, colorReset)
+		return nil
+	}
+	performance := response.Performance
+	fmt.Printf("Validator pubkey:        %s\n", performance.Pubkey)
+	if performance.EndSlot > 0 {
+		fmt.Printf("Eligible slots:          %d to %d\n", performance.StartSlot, performance.EndSlot)
+	}
+	if performance.ActiveFraction > 0 {
+		fmt.Printf("Eligible for:            %.2f%% of the interval\n", performance.ActiveFraction*100)
+	}
+	fmt.Printf("Successful attestations: %d\n", performance.SuccessfulAttestations)
+	fmt.Printf("Missed attestations:     %d\n", performance.MissedAttestations)
+	fmt.Printf("Participation rate:      %.2f%%\n", performance.ParticipationRate*100)
+	fmt.Printf("ETH earned:              %.6f ETH\n", performance.EthEarned)
+
+	// Print the slots it missed attestations in
+	missingSlots := performance.MissingAttestationSlots
+	if len(missingSlots) > 0 {
+		printedSlots := missingSlots
+		if len(printedSlots) > maxMissingSlotsToPrint {
+			printedSlots = printedSlots[:maxMissingSlotsToPrint]
+		}
+		slotStrings := make([]string, len(printedSlots))
+		for i, slot := range printedSlots {
+			slotStrings[i] = fmt.Sprint(slot)
+		}
+		fmt.Printf("Missed attestation slots: %s", strings.Join(slotStrings, ", "))
+		if len(missingSlots) > len(printedSlots) {
+			fmt.Printf(" (and %d more)", len(missingSlots)-len(printedSlots))
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+
+	// Print the node's smoothing pool details
+	fmt.Printf("%s=== Node %s ===%s\n", colorGreen, response.NodeAddress.Hex(), colorReset)
+	if !response.NodeRewardsFound {
+		fmt.Printf("The node's smoothing pool rewards aren't available because the rewards file for interval %d isn't on disk, or the node has no rewards in it.\n", response.Index)
+		return nil
+	}
+	fmt.Printf("Smoothing pool eligibility: %.2f%% of the interval\n", response.NodeEligibilityRate*100)
+	if response.NodeSmoothingPoolEth != nil {
+		fmt.Printf("Smoothing pool rewards:     %.6f ETH\n", eth.WeiToEth(response.NodeSmoothingPoolEth))
+	}
+	return nil
+
+}
@@ -0,0 +1,71 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+const (
+	// The default number of days of rETH exchange rate history to show
+	defaultQueueStatusDays uint64 = 7
+
+	queueStatusTimeFormat = "2006-01-02, 15:04 -0700 MST"
+)
+
+func getQueueStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the history window
+	days := c.Uint64("days")
+
+	// Get the queue status
+	response, err := rp.NetworkQueueStatus(days)
+	if err != nil {
+		return err
+	}
+
+	// Print & return
+	fmt.Printf("%s=========== Deposit Pool ==========%s\n", colorGreen, colorReset)
+	fmt.Printf("Balance:                 %.6f ETH\n", math.RoundDown(eth.WeiToEth(response.DepositPoolBalance), 6))
+	fmt.Printf("Excess Balance:          %.6f ETH\n", math.RoundDown(eth.WeiToEth(response.DepositPoolExcessBalance), 6))
+	fmt.Printf("Assignments Enabled:     %t\n", response.AssignDepositsEnabled)
+	fmt.Printf("Max Assignments per Tx:  %d\n\n", response.MaximumDepositAssignments)
+
+	fmt.Printf("%s========== Minipool Queue =========%s\n", colorGreen, colorReset)
+	fmt.Printf("Total:                   %d\n", response.QueueLengths.Total)
+	fmt.Printf("    Full (16 ETH):       %d\n", response.QueueLengths.FullDeposit)
+	fmt.Printf("    Half (16 ETH):       %d\n", response.QueueLengths.HalfDeposit)
+	fmt.Printf("    Empty (unbonded):    %d\n", response.QueueLengths.EmptyDeposit)
+	fmt.Printf("    Variable:            %d\n\n", response.QueueLengths.VariableDeposit)
+
+	fmt.Printf("%s=============== rETH ==============%s\n", colorGreen, colorReset)
+	fmt.Printf("Current Exchange Rate:   %.6f ETH\n", response.RethExchangeRate)
+	if len(response.RethExchangeRateHistory) == 0 {
+		fmt.Printf("No network balance updates in the last %d days.\n", days)
+		return nil
+	}
+	fmt.Printf("History (last %d days):\n", days)
+	for _, snapshot := range response.RethExchangeRateHistory {
+		fmt.Printf("    %s (block %d): %.6f ETH\n", snapshot.Time.Format(queueStatusTimeFormat), snapshot.Block, snapshot.ExchangeRate)
+	}
+	return nil
+
+}
@@ -0,0 +1,111 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+const snapshotTimeFormat = "2006-01-02, 15:04 -0700 MST"
+
+func diffStateSnapshots(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// List the snapshots if none were provided
+	if c.NArg() == 0 {
+		response, err := rp.GetStateSnapshots()
+		if err != nil {
+			return err
+		}
+		if len(response.Snapshots) == 0 {
+			fmt.Println("There are no saved network state snapshots. You can enable them by setting the number of snapshots to keep in the Smartnode section of `rocketpool service config`.")
+			return nil
+		}
+		fmt.Println("Saved network state snapshots:")
+		for _, name := range response.Snapshots {
+			fmt.Printf("\t%s\n", name)
+		}
+		fmt.Println()
+		fmt.Println("Use `rocketpool network diff-state-snapshots <from> <to>` to compare two of them.")
+		return nil
+	}
+	if c.NArg() != 2 {
+		return fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText)
+	}
+
+	// Compare the snapshots
+	response, err := rp.DiffStateSnapshots(c.Args().Get(0), c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	// Print the summaries
+	fmt.Printf("%s=== Snapshots ===%s\n", colorGreen, colorReset)
+	printSnapshotSummary("From", response.From)
+	printSnapshotSummary("To", response.To)
+
+	// Print the changes
+	fmt.Printf("%s=== Changes ===%s\n", colorGreen, colorReset)
+	printAddresses("New nodes", response.NewNodes)
+	printAddresses("Removed nodes", response.RemovedNodes)
+	printAddresses("New minipools", response.NewMinipools)
+	printAddresses("Removed minipools", response.RemovedMinipools)
+
+	fmt.Printf("Minipool status transitions: %d\n", len(response.StatusTransitions))
+	for _, transition := range response.StatusTransitions {
+		fmt.Printf("\t%s -> %s: %d\n", transition.From, transition.To, len(transition.Minipools))
+		for _, address := range transition.Minipools {
+			fmt.Printf("\t\t%s\n", address.Hex())
+		}
+	}
+
+	fmt.Printf("RPL stake changes: %d\n", len(response.RplStakeChanges))
+	for _, change := range response.RplStakeChanges {
+		fmt.Printf("\t%s: %.6f RPL -> %.6f RPL\n", change.Node.Hex(), math.RoundDown(eth.WeiToEth(change.From), 6), math.RoundDown(eth.WeiToEth(change.To), 6))
+	}
+
+	return nil
+
+}
+
+// Print the totals for a snapshot
+func printSnapshotSummary(label string, summary api.StateSnapshotSummary) {
+	fmt.Printf("%s: %s%s%s (%s scope)\n", label, colorBlue, summary.Name, colorReset, summary.Scope)
+	fmt.Printf("\tTime:              %s\n", summary.Time.Format(snapshotTimeFormat))
+	fmt.Printf("\tEL block:          %d\n", summary.ElBlockNumber)
+	fmt.Printf("\tBeacon slot:       %d\n", summary.BeaconSlotNumber)
+	fmt.Printf("\tNodes:             %d\n", summary.NodeCount)
+	fmt.Printf("\tMinipools:         %d\n", summary.MinipoolCount)
+	statuses := make([]string, 0, len(summary.MinipoolStatuses))
+	for status := range summary.MinipoolStatuses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Printf("\t\t%-16s %d\n", status+":", summary.MinipoolStatuses[status])
+	}
+	fmt.Printf("\tTotal RPL staked:  %.6f RPL\n", math.RoundDown(eth.WeiToEth(summary.TotalRplStake), 6))
+	fmt.Printf("\tTotal ETH matched: %.6f ETH\n", math.RoundDown(eth.WeiToEth(summary.TotalEthMatched), 6))
+	fmt.Println()
+}
+
+// Print a labelled list of addresses
+func printAddresses(label string, addresses []common.Address) {
+	fmt.Printf("%s: %d\n", label, len(addresses))
+	for _, address := range addresses {
+		fmt.Printf("\t%s\n", address.Hex())
+	}
+}
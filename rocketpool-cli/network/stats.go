@@ -64,6 +64,13 @@ func getStats(c *cli.Context) error {
 
 	fmt.Printf("%s============== Tokens =============%s\n", colorGreen, colorReset)
 	fmt.Printf("rETH Price (ETH / rETH): %f ETH\n", response.RethPrice)
+	for _, marketPrice := range response.RethMarketPrices {
+		if marketPrice.Error != "" {
+			fmt.Printf("    %s: %sunavailable (%s)%s\n", marketPrice.Source, colorYellow, marketPrice.Error, colorReset)
+			continue
+		}
+		fmt.Printf("    %s: %f ETH (%+.2f%%)\n", marketPrice.Source, marketPrice.Price, marketPrice.Premium)
+	}
 	fmt.Printf("RPL Price (ETH / RPL):   %f ETH\n", response.RplPrice)
 	fmt.Printf("Total RPL staked:        %f RPL\n", response.TotalRplStaked)
 	fmt.Printf("Effective RPL staked:    %f RPL\n", response.EffectiveRplStaked)
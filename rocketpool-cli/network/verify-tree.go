@@ -0,0 +1,95 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+func verifyRewardsTree(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+
+	// Get the index
+	var index uint64
+	if c.IsSet("index") {
+		index = c.Uint64("index")
+	} else {
+		indexString := cliutils.Prompt("Which interval would you like to verify the Merkle rewards tree for?", "^\\d+$", "Invalid interval. Please provide a number.")
+		index, err = strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+		}
+	}
+
+	// Check if verification will work
+	canResponse, err := rp.CanVerifyRewardsTree(index)
+	if err != nil {
+		return err
+	}
+	if canResponse.CurrentIndex <= index {
+		return fmt.Errorf("The current active rewards period is interval %d. The tree for interval %d can't be verified until the Oracle DAO has submitted it.", canResponse.CurrentIndex, index)
+	}
+	if canResponse.VerificationRequested {
+		fmt.Printf("Verification of the rewards tree for interval %d has already been requested. You can follow its progress with %s`rocketpool service logs watchtower`%s.\n", index, colorGreen, colorReset)
+		return nil
+	}
+
+	// Print the result of the last verification
+	verification := canResponse.Verification
+	if verification != nil {
+		verifiedTime := verification.VerifiedTime.Local().Format(queueStatusTimeFormat)
+		if verification.Error != "" {
+			fmt.Printf("%sThe last verification of interval %d on %s failed: %s%s\n\n", colorYellow, index, verifiedTime, verification.Error, colorReset)
+		} else if verification.Matches {
+			fmt.Printf("%sThe rewards tree for interval %d was verified on %s. Its Merkle root of %s matches the canonical root.%s\n\n", colorGreen, index, verifiedTime, verification.GeneratedMerkleRoot, colorReset)
+		} else {
+			fmt.Printf("%sThe rewards tree for interval %d was verified on %s, but it did NOT match!\nRegenerated root: %s\nCanonical root:   %s%s\n\n", colorRed, index, verifiedTime, verification.GeneratedMerkleRoot, verification.CanonicalMerkleRoot, colorReset)
+		}
+		if !(c.Bool("yes") || cliutils.Confirm("Would you like to verify it again?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Print archive node info
+	printArchiveEcInfo(cfg)
+
+	// Create the verification request
+	_, err = rp.VerifyRewardsTree(index)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Your request to verify the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s, and check the result by running this command again once it's done.\n\n", index, colorGreen, colorReset)
+
+	if c.Bool("yes") || cliutils.Confirm("Would you like to restart the watchtower container now, so it starts verifying the tree immediately?") {
+		container := fmt.Sprintf("%s_watchtower", cfg.Smartnode.ProjectName.Value.(string))
+		response, err := rp.RestartContainer(container)
+		if err != nil {
+			return fmt.Errorf("Error restarting watchtower: %w", err)
+		}
+		if response != container {
+			return fmt.Errorf("Unexpected output while restarting watchtower: %s", response)
+		}
+
+		fmt.Println("Done!")
+	}
+
+	return nil
+
+}
@@ -41,7 +41,10 @@ func nodeBurn(c *cli.Context, amount float64, token string) error {
 			fmt.Printf("The node's %s balance is insufficient.\n", token)
 		}
 		if canBurn.InsufficientCollateral {
-			fmt.Printf("There is insufficient ETH collateral to trade %s for.\n", token)
+			fmt.Printf("There is insufficient ETH collateral to trade %s for: burning it requires %.6f ETH, but only %.6f ETH is available.\n", token, math.RoundDown(eth.WeiToEth(canBurn.EthValue), 6), math.RoundDown(eth.WeiToEth(canBurn.AvailableLiquidity), 6))
+		}
+		if canBurn.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canBurn.RevertReason)
 		}
 		return nil
 	}
@@ -53,7 +56,7 @@ func nodeBurn(c *cli.Context, amount float64, token string) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to burn %.6f %s for ETH?", math.RoundDown(eth.WeiToEth(amountWei), 6), token))) {
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to burn %.6f %s for %.6f ETH?", math.RoundDown(eth.WeiToEth(amountWei), 6), token, math.RoundDown(eth.WeiToEth(canBurn.EthValue), 6)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
@@ -81,7 +81,7 @@ func nodeClaimRewards(c *cli.Context) error {
 		// Download the files
 		for _, missingInterval := range missingIntervals {
 			fmt.Printf("Downloading interval %d file... ", missingInterval.Index)
-			err := rprewards.DownloadRewardsFile(cfg, missingInterval.Index, missingInterval.CID, false)
+			err := rprewards.DownloadRewardsFile(cfg, missingInterval.Index, missingInterval.CID, missingInterval.MerkleRoot, false)
 			if err != nil {
 				fmt.Println()
 				return err
@@ -90,7 +90,7 @@ func nodeClaimRewards(c *cli.Context) error {
 		}
 		for _, invalidInterval := range invalidIntervals {
 			fmt.Printf("Downloading interval %d file... ", invalidInterval.Index)
-			err := rprewards.DownloadRewardsFile(cfg, invalidInterval.Index, invalidInterval.CID, false)
+			err := rprewards.DownloadRewardsFile(cfg, invalidInterval.Index, invalidInterval.CID, invalidInterval.MerkleRoot, false)
 			if err != nil {
 				fmt.Println()
 				return err
@@ -213,6 +213,10 @@ func nodeClaimRewards(c *cli.Context) error {
 		if err != nil {
 			return err
 		}
+		if canClaim.RevertReason != "" {
+			fmt.Printf("Cannot claim rewards: the transaction would revert (%s).\n", canClaim.RevertReason)
+			return nil
+		}
 
 		// Assign max fees
 		err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
@@ -224,6 +228,10 @@ func nodeClaimRewards(c *cli.Context) error {
 		if err != nil {
 			return err
 		}
+		if canClaim.RevertReason != "" {
+			fmt.Printf("Cannot claim rewards: the transaction would revert (%s).\n", canClaim.RevertReason)
+			return nil
+		}
 
 		// Assign max fees
 		err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
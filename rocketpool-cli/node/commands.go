@@ -50,6 +50,121 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "tx-history",
+				Usage:     "List the transactions the node has submitted, newest first",
+				UsageText: "rocketpool node tx-history [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The maximum number of transactions to list (0 for all of them)",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getTxHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "tx-status",
+				Usage:     "Show the status of a transaction the node has submitted",
+				UsageText: "rocketpool node tx-status tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return getTxStatus(c, hash)
+
+				},
+			},
+
+			{
+				Name:  "tx-queue",
+				Usage: "Manage the transactions queued with --when-gas-below",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the queued transactions, newest first",
+						UsageText: "rocketpool node tx-queue list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getTxQueue(c)
+
+						},
+					},
+
+					{
+						Name:      "cancel",
+						Aliases:   []string{"c"},
+						Usage:     "Cancel a queued transaction that hasn't been submitted yet",
+						UsageText: "rocketpool node tx-queue cancel id",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							id, err := cliutils.ValidatePositiveUint("id", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return cancelQueuedTx(c, id)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "preflight",
+				Usage:     "Check every registration prerequisite (client sync, chain ID, wallet funding, gas, timezone) without touching the chain",
+				UsageText: "rocketpool node preflight [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "timezone, t",
+						Usage: "The timezone location to check registration with (in the format 'Country/City')",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return nodePreflight(c)
+
+				},
+			},
+
 			{
 				Name:      "register",
 				Aliases:   []string{"r"},
@@ -99,6 +214,35 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-report",
+				Usage:     "Export the RPL and ETH rewards you earned in each rewards interval, for tax and accounting",
+				UsageText: "rocketpool node rewards-report [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "intervals, i",
+						Usage: "The number of most recent intervals to include (0 for all of them)",
+						Value: 0,
+					},
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The output format: csv or json",
+						Value: "csv",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRewardsReport(c)
+
+				},
+			},
+
 			{
 				Name:      "set-withdrawal-address",
 				Aliases:   []string{"w"},
@@ -471,6 +615,61 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "burn-reth",
+				Aliases:   []string{"br"},
+				Usage:     "Burn rETH owned by the node account for ETH, if the rETH contract and deposit pool have enough liquidity",
+				UsageText: "rocketpool node burn-reth [options] amount",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the burn",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					amount, err := cliutils.ValidatePositiveEthAmount("burn amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return nodeBurn(c, amount, "reth")
+
+				},
+			},
+
+			{
+				Name:      "sweep",
+				Usage:     "Send all of the node account's ETH above a reserve (kept for gas) to the node's withdrawal address",
+				UsageText: "rocketpool node sweep [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "reserve, r",
+						Usage: "The amount of ETH to keep in the node account for gas (default 0.05)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the sweep",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return nodeSweep(c)
+
+				},
+			},
+
 			{
 				Name:      "set-voting-delegate",
 				Aliases:   []string{"sv"},
@@ -519,6 +718,49 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-voting-delegate",
+				Aliases:   []string{"gv"},
+				Usage:     "Show the address currently set for voting on Rocket Pool governance proposals on your node's behalf.",
+				UsageText: "rocketpool node get-voting-delegate",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return nodeGetVotingDelegate(c)
+
+				},
+			},
+			{
+				Name:      "sign-snapshot-vote",
+				Aliases:   []string{"ssv"},
+				Usage:     "Sign an off-chain Snapshot vote on a Rocket Pool governance proposal with your node wallet.",
+				UsageText: "rocketpool node sign-snapshot-vote proposal-id choice",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidateTxHash("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					choice, err := cliutils.ValidatePositiveUint("choice", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return nodeSignSnapshotVote(c, proposalId, choice)
+
+				},
+			},
+
 			{
 				Name:      "initialize-fee-distributor",
 				Aliases:   []string{"z"},
@@ -615,16 +857,43 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "send-raw-tx",
+				Usage:     "Broadcast an externally-signed, serialized transaction through the node's execution client",
+				UsageText: "rocketpool node send-raw-tx [options] tx-hex",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the broadcast",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return sendRawTransaction(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "sign-message",
 				Aliases:   []string{"sm"},
 				Usage:     "Sign an arbitrary message with the node's private key",
-				UsageText: "rocketpool node sign-message [-m message]",
+				UsageText: "rocketpool node sign-message [-m message | -t typed-data-file]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "message, m",
 						Usage: "The 'quoted message' to be signed",
 					},
+					cli.StringFlag{
+						Name:  "typed-data-file, t",
+						Usage: "The path to a JSON file containing an EIP-712 typed data payload to sign instead of a message",
+					},
 				},
 				Action: func(c *cli.Context) error {
 					// Run
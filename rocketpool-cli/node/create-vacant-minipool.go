@@ -186,6 +186,9 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 		if canDeposit.DepositDisabled {
 			fmt.Println("Vacant minipool deposits are currently disabled.")
 		}
+		if canDeposit.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canDeposit.RevertReason)
+		}
 		return nil
 	}
 
@@ -196,6 +196,9 @@ func nodeDeposit(c *cli.Context) error {
 		if !canDeposit.IsAtlasDeployed && !canDeposit.InConsensus {
 			fmt.Println("The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
 		}
+		if canDeposit.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canDeposit.RevertReason)
+		}
 		return nil
 	}
 
@@ -100,6 +100,10 @@ func distribute(c *cli.Context) error {
 		fmt.Printf("Your fee distributor does not have any ETH.")
 		return nil
 	}
+	if canDistributeResponse.RevertReason != "" {
+		fmt.Printf("Cannot distribute your fee distributor's balance: the transaction would revert (%s).\n", canDistributeResponse.RevertReason)
+		return nil
+	}
 
 	// Print info
 	nodeShare := (1 + canDistributeResponse.AverageNodeFee) * balance / 2
@@ -0,0 +1,51 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func nodePreflight(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for timezone location
+	var timezoneLocation string
+	if c.String("timezone") != "" {
+		timezoneLocation = c.String("timezone")
+	} else {
+		timezoneLocation = promptTimezone()
+	}
+
+	// Run the checks
+	response, err := rp.NodePreflight(timezoneLocation)
+	if err != nil {
+		return err
+	}
+
+	// Print the report
+	fmt.Printf("%s=== Registration Preflight ===%s\n", colorGreen, colorReset)
+	for _, check := range response.Checks {
+		if check.Passed {
+			fmt.Printf("%s[PASS]%s %-15s %s\n", colorGreen, colorReset, check.Name, check.Message)
+		} else {
+			fmt.Printf("%s[FAIL]%s %-15s %s\n", colorRed, colorReset, check.Name, check.Message)
+		}
+	}
+	fmt.Println()
+
+	if !response.Passed {
+		return fmt.Errorf("one or more preflight checks failed; please resolve them before registering the node")
+	}
+	fmt.Println("All preflight checks passed. The node is ready to be registered.")
+	return nil
+
+}
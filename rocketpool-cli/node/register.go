@@ -46,6 +46,9 @@ func registerNode(c *cli.Context) error {
 		if canRegister.RegistrationDisabled {
 			fmt.Println("Node registrations are currently disabled.")
 		}
+		if canRegister.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canRegister.RevertReason)
+		}
 		return nil
 	}
 
@@ -0,0 +1,140 @@
+package node
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const (
+	rewardsReportFormat_Csv  string = "csv"
+	rewardsReportFormat_Json string = "json"
+)
+
+// A row of the rewards report
+type rewardsReportRow struct {
+	Interval     uint64  `json:"interval"`
+	StartTime    string  `json:"startTime"`
+	EndTime      string  `json:"endTime"`
+	Rpl          float64 `json:"rpl"`
+	Eth          float64 `json:"eth"`
+	Claimed      bool    `json:"claimed"`
+	ClaimTxHash  string  `json:"claimTxHash,omitempty"`
+	ClaimTime    string  `json:"claimTime,omitempty"`
+	RplPrice     float64 `json:"rplPrice,omitempty"`
+	EthPrice     float64 `json:"ethPrice,omitempty"`
+	RplValue     float64 `json:"rplValue,omitempty"`
+	EthValue     float64 `json:"ethValue,omitempty"`
+	TotalValue   float64 `json:"totalValue,omitempty"`
+	FiatCurrency string  `json:"fiatCurrency,omitempty"`
+}
+
+func getRewardsReport(c *cli.Context) error {
+
+	// Check the format
+	format := c.String("format")
+	if format != rewardsReportFormat_Csv && format != rewardsReportFormat_Json {
+		return fmt.Errorf("Invalid format '%s'; must be '%s' or '%s'", format, rewardsReportFormat_Csv, rewardsReportFormat_Json)
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the report
+	response, err := rp.NodeRewardsReport(c.Uint64("intervals"))
+	if err != nil {
+		return err
+	}
+
+	// Build the rows; warnings go to stderr so the report can be redirected to a file
+	rows := []rewardsReportRow{}
+	for _, interval := range response.Intervals {
+		if !interval.TreeFileExists {
+			fmt.Fprintf(os.Stderr, "WARNING: the rewards file for interval %d hasn't been downloaded, so its rewards are reported as 0.\n", interval.Index)
+		}
+		if interval.PriceError != "" {
+			fmt.Fprintf(os.Stderr, "WARNING: couldn't get prices for interval %d: %s\n", interval.Index, interval.PriceError)
+		}
+		row := rewardsReportRow{
+			Interval:  interval.Index,
+			StartTime: interval.StartTime.UTC().Format(time.RFC3339),
+			EndTime:   interval.EndTime.UTC().Format(time.RFC3339),
+			Rpl:       eth.WeiToEth(interval.RplAmount),
+			Eth:       eth.WeiToEth(interval.EthAmount),
+			Claimed:   interval.Claimed,
+		}
+		if !interval.ClaimTime.IsZero() {
+			row.ClaimTxHash = interval.ClaimTxHash.Hex()
+			row.ClaimTime = interval.ClaimTime.UTC().Format(time.RFC3339)
+		}
+		if response.FiatCurrency != "" && interval.PriceError == "" {
+			row.FiatCurrency = response.FiatCurrency
+			row.RplPrice = interval.RplPriceFiat
+			row.EthPrice = interval.EthPriceFiat
+			row.RplValue = interval.RplValueFiat
+			row.EthValue = interval.EthValueFiat
+			row.TotalValue = interval.RplValueFiat + interval.EthValueFiat
+		}
+		rows = append(rows, row)
+	}
+
+	// Print it
+	if format == rewardsReportFormat_Json {
+		bytes, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Error serializing rewards report: %w", err)
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+
+	writer := csv.NewWriter(os.Stdout)
+	header := []string{"interval", "start_time", "end_time", "rpl", "eth", "claimed", "claim_tx_hash", "claim_time"}
+	if response.FiatCurrency != "" {
+		header = append(header, "fiat_currency", "rpl_price", "eth_price", "rpl_value", "eth_value", "total_value")
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	for _, row := range rows {
+		record := []string{
+			strconv.FormatUint(row.Interval, 10),
+			row.StartTime,
+			row.EndTime,
+			formatFloat(row.Rpl),
+			formatFloat(row.Eth),
+			strconv.FormatBool(row.Claimed),
+			row.ClaimTxHash,
+			row.ClaimTime,
+		}
+		if response.FiatCurrency != "" {
+			if row.FiatCurrency == "" {
+				// Leave the values blank instead of reporting a misleading 0
+				record = append(record, "", "", "", "", "", "")
+			} else {
+				record = append(record, row.FiatCurrency, formatFloat(row.RplPrice), formatFloat(row.EthPrice), formatFloat(row.RplValue), formatFloat(row.EthValue), formatFloat(row.TotalValue))
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+
+}
@@ -0,0 +1,55 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func sendRawTransaction(c *cli.Context, serializedTx string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to broadcast this signed transaction? This action cannot be undone!")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Broadcast the transaction
+	response, err := rp.SendRawTransaction(serializedTx)
+	if err != nil {
+		return err
+	}
+
+	toString := "<contract creation>"
+	if response.To != nil {
+		toString = response.To.Hex()
+	}
+	fmt.Printf("Broadcasting transaction from %s to %s (nonce %d, value %.6f ETH)...\n", response.From.Hex(), toString, response.Nonce, math.RoundDown(eth.WeiToEth(response.Value), 6))
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("Successfully broadcast the transaction.")
+	return nil
+
+}
@@ -42,6 +42,9 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddressOrENS strin
 		if canSend.InsufficientBalance {
 			fmt.Printf("The node's %s balance is insufficient.\n", token)
 		}
+		if canSend.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canSend.RevertReason)
+		}
 		return nil
 	}
 	var toAddress common.Address
@@ -38,6 +38,11 @@ func setTimezoneLocation(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if !canResponse.CanSet {
+		fmt.Println("Cannot set the node's timezone:")
+		fmt.Printf("The transaction would revert: %s\n", canResponse.RevertReason)
+		return nil
+	}
 
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"os"
 
 	"encoding/json"
 
@@ -14,6 +15,13 @@ import (
 
 const signatureVersion = 1
 
+type TypedDataSignature struct {
+	Address   common.Address  `json:"address"`
+	TypedData json.RawMessage `json:"typedData"`
+	Signature string          `json:"sig"`
+	Version   string          `json:"version"`
+}
+
 type PersonalSignature struct {
 	Address   common.Address `json:"address"`
 	Message   string         `json:"msg"`
@@ -41,6 +49,11 @@ func signMessage(c *cli.Context) error {
 		return nil
 	}
 
+	// Sign an EIP-712 payload if one was provided
+	if c.String("typed-data-file") != "" {
+		return signTypedData(rp, status.AccountAddress, c.String("typed-data-file"))
+	}
+
 	message := c.String("message")
 	for message == "" {
 		message = cliutils.Prompt("Please enter the message you want to sign: (EIP-191 personal_sign)", "^.+$", "Please enter the message you want to sign: (EIP-191 personal_sign)")
@@ -68,3 +81,38 @@ func signMessage(c *cli.Context) error {
 	return nil
 
 }
+
+// Sign the EIP-712 typed data payload stored in the provided file
+func signTypedData(rp *rocketpool.Client, address common.Address, path string) error {
+
+	// Read the payload
+	typedDataBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading typed data file [%s]: %w", path, err)
+	}
+	if !json.Valid(typedDataBytes) {
+		return fmt.Errorf("typed data file [%s] does not contain valid JSON", path)
+	}
+
+	response, err := rp.SignTypedData(string(typedDataBytes))
+	if err != nil {
+		return err
+	}
+
+	// Print the signature
+	formattedSignature := TypedDataSignature{
+		Address:   address,
+		TypedData: json.RawMessage(typedDataBytes),
+		Signature: response.SignedData,
+		Version:   fmt.Sprint(signatureVersion),
+	}
+	bytes, err := json.MarshalIndent(formattedSignature, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signed Typed Data:\n\n%s\n", string(bytes))
+
+	return nil
+
+}
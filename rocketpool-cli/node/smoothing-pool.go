@@ -49,6 +49,10 @@ func joinSmoothingPool(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if canResponse.RevertReason != "" {
+		fmt.Printf("Cannot join the Smoothing Pool: the transaction would revert (%s).\n", canResponse.RevertReason)
+		return nil
+	}
 
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
@@ -121,6 +125,10 @@ func leaveSmoothingPool(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if canResponse.RevertReason != "" {
+		fmt.Printf("Cannot leave the Smoothing Pool: the transaction would revert (%s).\n", canResponse.RevertReason)
+		return nil
+	}
 
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
@@ -5,11 +5,13 @@ import (
 	"math/big"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
-	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/txbundle"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -47,96 +49,14 @@ func nodeStakeRpl(c *cli.Context) error {
 		// Confirm swapping RPL
 		if c.Bool("swap") || cliutils.Confirm(fmt.Sprintf("The node has a balance of %.6f old RPL. Would you like to swap it for new RPL before staking?", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))) {
 
-			// Check allowance
-			allowance, err := rp.GetNodeSwapRplAllowance()
-			if err != nil {
-				return err
-			}
-
-			if allowance.Allowance.Cmp(status.AccountBalances.FixedSupplyRPL) < 0 {
-				fmt.Println("Before swapping legacy RPL for new RPL, you must first give the new RPL contract approval to interact with your legacy RPL.")
-				fmt.Println("This only needs to be done once for your node.")
-
-				// If a custom nonce is set, print the multi-transaction warning
-				if c.GlobalUint64("nonce") != 0 {
-					cliutils.PrintMultiTransactionNonceWarning()
-				}
-
-				// Calculate max uint256 value
-				maxApproval := big.NewInt(2)
-				maxApproval = maxApproval.Exp(maxApproval, big.NewInt(256), nil)
-				maxApproval = maxApproval.Sub(maxApproval, big.NewInt(1))
-
-				// Get approval gas
-				approvalGas, err := rp.NodeSwapRplApprovalGas(maxApproval)
-				if err != nil {
-					return err
-				}
-				// Assign max fees
-				err = gas.AssignMaxFeeAndLimit(approvalGas.GasInfo, rp, c.Bool("yes"))
-				if err != nil {
-					return err
-				}
-
-				// Prompt for confirmation
-				if !(c.Bool("yes") || cliutils.Confirm("Do you want to let the new RPL contract interact with your legacy RPL?")) {
-					fmt.Println("Cancelled.")
-					return nil
-				}
-
-				// Approve RPL for swapping
-				response, err := rp.NodeSwapRplApprove(maxApproval)
-				if err != nil {
-					return err
-				}
-				hash := response.ApproveTxHash
-				fmt.Printf("Approving legacy RPL for swapping...\n")
-				cliutils.PrintTransactionHash(rp, hash)
-				if _, err = rp.WaitForTransaction(hash); err != nil {
-					return err
-				}
-				fmt.Println("Successfully approved access to legacy RPL.")
-
-				// If a custom nonce is set, increment it for the next transaction
-				if c.GlobalUint64("nonce") != 0 {
-					rp.IncrementCustomNonce()
-				}
-			}
-
-			// Check RPL can be swapped
-			canSwap, err := rp.CanNodeSwapRpl(status.AccountBalances.FixedSupplyRPL)
-			if err != nil {
+			// Approve legacy RPL for swapping first if needed, then swap it, with a single confirmation
+			bundle := txbundle.NewBundle(rp)
+			if err := addSwapRplSteps(bundle, rp, status.AccountBalances.FixedSupplyRPL); err != nil {
 				return err
 			}
-			if !canSwap.CanSwap {
-				fmt.Println("Cannot swap RPL:")
-				if canSwap.InsufficientBalance {
-					fmt.Println("The node's old RPL balance is insufficient.")
-				}
-				return nil
-			}
 			fmt.Println("RPL Swap Gas Info:")
-			// Assign max fees
-			err = gas.AssignMaxFeeAndLimit(canSwap.GasInfo, rp, c.Bool("yes"))
-			if err != nil {
-				return err
-			}
-
-			// Prompt for confirmation
-			if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6)))) {
-				fmt.Println("Cancelled.")
-				return nil
-			}
-
-			// Swap RPL
-			swapResponse, err := rp.NodeSwapRpl(status.AccountBalances.FixedSupplyRPL)
-			if err != nil {
-				return err
-			}
-
-			fmt.Printf("Swapping old RPL for new RPL...\n")
-			cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
-			if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
+			swapped, err := bundle.Run(c, fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6)))
+			if err != nil || !swapped {
 				return err
 			}
 
@@ -144,11 +64,6 @@ func nodeStakeRpl(c *cli.Context) error {
 			fmt.Printf("Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))
 			fmt.Println("")
 
-			// If a custom nonce is set, increment it for the next transaction
-			if c.GlobalUint64("nonce") != 0 {
-				rp.IncrementCustomNonce()
-			}
-
 			// Get new account RPL balance
 			rplBalance.Add(status.AccountBalances.RPL, status.AccountBalances.FixedSupplyRPL)
 
@@ -261,94 +176,69 @@ func nodeStakeRpl(c *cli.Context) error {
 		return err
 	}
 
+	// Approve RPL for staking first if needed, then stake it, with a single confirmation
+	bundle := txbundle.NewBundle(rp)
 	if allowance.Allowance.Cmp(amountWei) < 0 {
 		fmt.Println("Before staking RPL, you must first give the staking contract approval to interact with your RPL.")
 		fmt.Println("This only needs to be done once for your node.")
 
-		// If a custom nonce is set, print the multi-transaction warning
-		if c.GlobalUint64("nonce") != 0 {
-			cliutils.PrintMultiTransactionNonceWarning()
-		}
-
 		// Calculate max uint256 value
 		maxApproval := big.NewInt(2)
 		maxApproval = maxApproval.Exp(maxApproval, big.NewInt(256), nil)
 		maxApproval = maxApproval.Sub(maxApproval, big.NewInt(1))
 
-		// Get approval gas
-		approvalGas, err := rp.NodeStakeRplApprovalGas(maxApproval)
-		if err != nil {
-			return err
-		}
-		// Assign max fees
-		err = gas.AssignMaxFeeAndLimit(approvalGas.GasInfo, rp, c.Bool("yes"))
-		if err != nil {
-			return err
-		}
-
-		// Prompt for confirmation
-		if !(c.Bool("yes") || cliutils.Confirm("Do you want to let the staking contract interact with your RPL?")) {
-			fmt.Println("Cancelled.")
-			return nil
-		}
-
-		// Approve RPL for staking
-		response, err := rp.NodeStakeRplApprove(maxApproval)
-		if err != nil {
-			return err
-		}
-		hash := response.ApproveTxHash
-		fmt.Printf("Approving RPL for staking...\n")
-		cliutils.PrintTransactionHash(rp, hash)
-		if _, err = rp.WaitForTransaction(hash); err != nil {
-			return err
-		}
-		fmt.Println("Successfully approved staking access to RPL.")
-
-		// If a custom nonce is set, increment it for the next transaction
-		if c.GlobalUint64("nonce") != 0 {
-			rp.IncrementCustomNonce()
-		}
-	}
-
-	// Check RPL can be staked
-	canStake, err := rp.CanNodeStakeRpl(amountWei)
-	if err != nil {
-		return err
-	}
-	if !canStake.CanStake {
-		fmt.Println("Cannot stake RPL:")
-		if canStake.InsufficientBalance {
-			fmt.Println("The node's RPL balance is insufficient.")
-		}
-		if !canStake.IsAtlasDeployed && !canStake.InConsensus {
-			fmt.Println("The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
-		}
-		return nil
+		bundle.Add(txbundle.Step{
+			Description: "Approving RPL for staking",
+			Check: func() (rocketpoolapi.GasInfo, error) {
+				approvalGas, err := rp.NodeStakeRplApprovalGas(maxApproval)
+				if err != nil {
+					return rocketpoolapi.GasInfo{}, err
+				}
+				return approvalGas.GasInfo, nil
+			},
+			Submit: func() (common.Hash, error) {
+				response, err := rp.NodeStakeRplApprove(maxApproval)
+				if err != nil {
+					return common.Hash{}, err
+				}
+				return response.ApproveTxHash, nil
+			},
+		})
 	}
+	bundle.Add(txbundle.Step{
+		Description: "Staking RPL",
+		Check: func() (rocketpoolapi.GasInfo, error) {
+			canStake, err := rp.CanNodeStakeRpl(amountWei)
+			if err != nil {
+				return rocketpoolapi.GasInfo{}, err
+			}
+			if !canStake.CanStake {
+				if canStake.InsufficientBalance {
+					return rocketpoolapi.GasInfo{}, fmt.Errorf("Cannot stake RPL: the node's RPL balance is insufficient.")
+				}
+				if !canStake.IsAtlasDeployed && !canStake.InConsensus {
+					return rocketpoolapi.GasInfo{}, fmt.Errorf("Cannot stake RPL: the RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
+				}
+				if canStake.RevertReason != "" {
+					return rocketpoolapi.GasInfo{}, fmt.Errorf("Cannot stake RPL: the transaction would revert: %s", canStake.RevertReason)
+				}
+				return rocketpoolapi.GasInfo{}, fmt.Errorf("Cannot stake RPL.")
+			}
+			return canStake.GasInfo, nil
+		},
+		Submit: func() (common.Hash, error) {
+			response, err := rp.NodeStakeRpl(amountWei)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return response.StakeTxHash, nil
+		},
+	})
 
+	// Run the bundle
 	fmt.Println("RPL Stake Gas Info:")
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canStake.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to stake %.6f RPL? You will not be able to unstake this RPL until you exit your validators and close your minipools, or reach over 150%% collateral!", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
-		fmt.Println("Cancelled.")
-		return nil
-	}
-
-	// Stake RPL
-	stakeResponse, err := rp.NodeStakeRpl(amountWei)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Staking RPL...\n")
-	cliutils.PrintTransactionHash(rp, stakeResponse.StakeTxHash)
-	if _, err = rp.WaitForTransaction(stakeResponse.StakeTxHash); err != nil {
+	staked, err := bundle.Run(c, fmt.Sprintf("Are you sure you want to stake %.6f RPL? You will not be able to unstake this RPL until you exit your validators and close your minipools, or reach over 150%% collateral!", math.RoundDown(eth.WeiToEth(amountWei), 6)))
+	if err != nil || !staked {
 		return err
 	}
 
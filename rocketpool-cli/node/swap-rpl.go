@@ -5,11 +5,13 @@ import (
 	"math/big"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
-	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/txbundle"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -75,6 +77,26 @@ func nodeSwapRpl(c *cli.Context) error {
 
 	}
 
+	// Approve legacy RPL for swapping first if needed, then swap it, with a single confirmation
+	bundle := txbundle.NewBundle(rp)
+	if err := addSwapRplSteps(bundle, rp, amountWei); err != nil {
+		return err
+	}
+	fmt.Println("RPL Swap Gas Info:")
+	swapped, err := bundle.Run(c, fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(amountWei), 6)))
+	if err != nil || !swapped {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+	return nil
+
+}
+
+// Add the transactions that swap legacy RPL for new RPL to a bundle, starting with an approval if the allowance is too low
+func addSwapRplSteps(bundle *txbundle.Bundle, rp *rocketpool.Client, amountWei *big.Int) error {
+
 	// Check allowance
 	allowance, err := rp.GetNodeSwapRplAllowance()
 	if err != nil {
@@ -85,91 +107,56 @@ func nodeSwapRpl(c *cli.Context) error {
 		fmt.Println("Before swapping legacy RPL for new RPL, you must first give the new RPL contract approval to interact with your legacy RPL.")
 		fmt.Println("This only needs to be done once for your node.")
 
-		// If a custom nonce is set, print the multi-transaction warning
-		if c.GlobalUint64("nonce") != 0 {
-			cliutils.PrintMultiTransactionNonceWarning()
-		}
-
 		// Calculate max uint256 value
 		maxApproval := big.NewInt(2)
 		maxApproval = maxApproval.Exp(maxApproval, big.NewInt(256), nil)
 		maxApproval = maxApproval.Sub(maxApproval, big.NewInt(1))
 
-		// Get approval gas
-		approvalGas, err := rp.NodeSwapRplApprovalGas(maxApproval)
-		if err != nil {
-			return err
-		}
-		// Assign max fees
-		err = gas.AssignMaxFeeAndLimit(approvalGas.GasInfo, rp, c.Bool("yes"))
-		if err != nil {
-			return err
-		}
-
-		// Prompt for confirmation
-		if !(c.Bool("yes") || cliutils.Confirm("Do you want to let the new RPL contract interact with your legacy RPL?")) {
-			fmt.Println("Cancelled.")
-			return nil
-		}
-
-		// Approve RPL for swapping
-		response, err := rp.NodeSwapRplApprove(maxApproval)
-		if err != nil {
-			return err
-		}
-		hash := response.ApproveTxHash
-		fmt.Printf("Approving legacy RPL for swapping...\n")
-		cliutils.PrintTransactionHash(rp, hash)
-		if _, err = rp.WaitForTransaction(hash); err != nil {
-			return err
-		}
-		fmt.Println("Successfully approved access to legacy RPL.")
-
-		// If a custom nonce is set, increment it for the next transaction
-		if c.GlobalUint64("nonce") != 0 {
-			rp.IncrementCustomNonce()
-		}
-	}
-
-	// Check RPL can be swapped
-	canSwap, err := rp.CanNodeSwapRpl(amountWei)
-	if err != nil {
-		return err
-	}
-	if !canSwap.CanSwap {
-		fmt.Println("Cannot swap RPL:")
-		if canSwap.InsufficientBalance {
-			fmt.Println("The node's old RPL balance is insufficient.")
-		}
-		return nil
-	}
-	fmt.Println("RPL Swap Gas Info:")
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canSwap.GasInfo, rp, c.Bool("yes"))
-	if err != nil {
-		return err
-	}
-
-	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
-		fmt.Println("Cancelled.")
-		return nil
+		bundle.Add(txbundle.Step{
+			Description: "Approving legacy RPL for swapping",
+			Check: func() (rocketpoolapi.GasInfo, error) {
+				approvalGas, err := rp.NodeSwapRplApprovalGas(maxApproval)
+				if err != nil {
+					return rocketpoolapi.GasInfo{}, err
+				}
+				return approvalGas.GasInfo, nil
+			},
+			Submit: func() (common.Hash, error) {
+				response, err := rp.NodeSwapRplApprove(maxApproval)
+				if err != nil {
+					return common.Hash{}, err
+				}
+				return response.ApproveTxHash, nil
+			},
+		})
 	}
 
-	// Swap RPL
-	swapResponse, err := rp.NodeSwapRpl(amountWei)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("Swapping old RPL for new RPL...\n")
-	cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
-	if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
-		return err
-	}
-
-	// Log & return
-	fmt.Printf("Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+	bundle.Add(txbundle.Step{
+		Description: "Swapping old RPL for new RPL",
+		Check: func() (rocketpoolapi.GasInfo, error) {
+			canSwap, err := rp.CanNodeSwapRpl(amountWei)
+			if err != nil {
+				return rocketpoolapi.GasInfo{}, err
+			}
+			if !canSwap.CanSwap {
+				if canSwap.InsufficientBalance {
+					return rocketpoolapi.GasInfo{}, fmt.Errorf("Cannot swap RPL: the node's old RPL balance is insufficient.")
+				}
+				if canSwap.RevertReason != "" {
+					return rocketpoolapi.GasInfo{}, fmt.Errorf("Cannot swap RPL: the transaction would revert: %s", canSwap.RevertReason)
+				}
+				return rocketpoolapi.GasInfo{}, fmt.Errorf("Cannot swap RPL.")
+			}
+			return canSwap.GasInfo, nil
+		},
+		Submit: func() (common.Hash, error) {
+			response, err := rp.NodeSwapRpl(amountWei)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return response.SwapTxHash, nil
+		},
+	})
 	return nil
 
 }
@@ -0,0 +1,96 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The default amount of ETH to keep in the node wallet for gas when sweeping
+const defaultSweepReserve float64 = 0.05
+
+func nodeSweep(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the reserve amount
+	reserve := defaultSweepReserve
+	if c.String("reserve") != "" {
+		reserve, err = cliutils.ValidateEthAmount("reserve amount", c.String("reserve"))
+		if err != nil {
+			return err
+		}
+	}
+	reserveWei := eth.EthToWei(reserve)
+
+	// Check the balance can be swept
+	canSweep, err := rp.CanNodeSweep(reserveWei)
+	if err != nil {
+		return err
+	}
+	if !canSweep.CanSweep {
+		fmt.Println("Cannot sweep the node's ETH balance:")
+		if canSweep.WithdrawalAddressIsNode {
+			fmt.Println("The node's withdrawal address is the node address itself.")
+		}
+		if canSweep.InsufficientBalance {
+			fmt.Printf("The node's ETH balance (%.6f ETH) does not exceed the reserve of %.6f ETH.\n", math.RoundDown(eth.WeiToEth(canSweep.Balance), 6), math.RoundDown(eth.WeiToEth(reserveWei), 6))
+		}
+		if canSweep.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canSweep.RevertReason)
+		}
+		return nil
+	}
+
+	// Print the preview
+	fmt.Printf("Node ETH balance:   %.6f ETH\n", math.RoundDown(eth.WeiToEth(canSweep.Balance), 6))
+	fmt.Printf("Reserved for gas:   %.6f ETH\n", math.RoundDown(eth.WeiToEth(canSweep.Reserve), 6))
+	fmt.Printf("Amount to sweep:    %.6f ETH\n", math.RoundDown(eth.WeiToEth(canSweep.Amount), 6))
+	fmt.Printf("Withdrawal address: %s%s%s\n\n", colorGreen, canSweep.WithdrawalAddress.Hex(), colorReset)
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canSweep.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to send %.6f ETH to your withdrawal address? This action cannot be undone!", math.RoundDown(eth.WeiToEth(canSweep.Amount), 6)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sweep the balance
+	response, err := rp.NodeSweep(reserveWei)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Sweeping ETH to %s...\n", response.WithdrawalAddress.Hex())
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully sent %.6f ETH to the node's withdrawal address.\n", math.RoundDown(eth.WeiToEth(response.Amount), 6))
+	return nil
+
+}
@@ -0,0 +1,77 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/txjournal"
+)
+
+func getTxHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the history
+	response, err := rp.NodeTxHistory(c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	if len(response.Transactions) == 0 {
+		fmt.Println("The node hasn't submitted any transactions yet.")
+		return nil
+	}
+
+	// Print it
+	for _, entry := range response.Transactions {
+		fmt.Printf("%s  %-9s %s %s/%s (nonce %d)\n", entry.SubmittedAt.Format("2006-01-02 15:04:05"), entry.Status, entry.Hash.Hex(), entry.Module, entry.Command, entry.Nonce)
+	}
+	return nil
+
+}
+
+func getTxStatus(c *cli.Context, hash common.Hash) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the status
+	response, err := rp.NodeTxStatus(hash)
+	if err != nil {
+		return err
+	}
+	if !response.Found {
+		fmt.Printf("Transaction %s isn't in the node's transaction journal.\n", hash.Hex())
+		return nil
+	}
+
+	// Print it
+	entry := response.Transaction
+	fmt.Printf("Transaction:      %s\n", entry.Hash.Hex())
+	fmt.Printf("Submitted by:     %s (%s/%s)\n", entry.Source, entry.Module, entry.Command)
+	fmt.Printf("Submitted at:     %s\n", entry.SubmittedAt.Format("2006-01-02 15:04:05 MST"))
+	fmt.Printf("Nonce:            %d\n", entry.Nonce)
+	fmt.Printf("Gas limit:        %d\n", entry.GasLimit)
+	if entry.MaxFee != nil && entry.MaxPriorityFee != nil {
+		fmt.Printf("Max fee:          %.2f gwei (priority fee %.2f gwei)\n", eth.WeiToGwei(entry.MaxFee), eth.WeiToGwei(entry.MaxPriorityFee))
+	}
+	fmt.Printf("Status:           %s\n", entry.Status)
+	if entry.Status != txjournal.Status_Pending {
+		fmt.Printf("Block:            %d\n", entry.BlockNumber)
+		fmt.Printf("Gas used:         %d\n", entry.GasUsed)
+	}
+	return nil
+
+}
@@ -0,0 +1,62 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
+)
+
+func getTxQueue(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the queue
+	response, err := rp.NodeTxQueue()
+	if err != nil {
+		return err
+	}
+	if len(response.Intents) == 0 {
+		fmt.Println("No transactions have been queued with --when-gas-below.")
+		return nil
+	}
+
+	// Print it
+	for _, intent := range response.Intents {
+		fmt.Printf("#%-4d %-9s below %.2f gwei until %s  %s\n", intent.ID, intent.Status, intent.MaxBaseFee, intent.Deadline.Format("2006-01-02 15:04:05"), intent.CommandLine())
+		switch intent.Status {
+		case txqueue.Status_Submitted:
+			fmt.Printf("      Submitted as %s\n", intent.TxHash.Hex())
+		case txqueue.Status_Failed:
+			fmt.Printf("      %s\n", intent.Error)
+		}
+	}
+	return nil
+
+}
+
+func cancelQueuedTx(c *cli.Context, id uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Cancel the intent
+	response, err := rp.NodeCancelQueuedTx(id)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Cancelled queued transaction #%d (%s).\n", response.Intent.ID, response.Intent.CommandLine())
+	return nil
+
+}
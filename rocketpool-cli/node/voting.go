@@ -1,6 +1,7 @@
 package node
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -128,3 +129,77 @@ func nodeClearVotingDelegate(c *cli.Context) error {
 	return nil
 
 }
+
+func nodeGetVotingDelegate(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the delegate
+	response, err := rp.GetSnapshotDelegate()
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	if response.HasDelegate {
+		fmt.Printf("The node's voting delegate is %s.\n", response.DelegateFormatted)
+	} else {
+		fmt.Println("The node does not have a voting delegate.")
+	}
+	return nil
+
+}
+
+func nodeSignSnapshotVote(c *cli.Context, proposalId common.Hash, choice uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Sign the vote
+	response, err := rp.SignSnapshotVote(proposalId, choice)
+	if err != nil {
+		return err
+	}
+
+	// Build the envelope Snapshot expects; the EIP712Domain type is implied by the domain
+	types := map[string]interface{}{}
+	for name, fields := range response.TypedData.Types {
+		if name != "EIP712Domain" {
+			types[name] = fields
+		}
+	}
+	envelope := map[string]interface{}{
+		"address": response.Address.Hex(),
+		"sig":     response.Signature,
+		"data": map[string]interface{}{
+			"domain":  response.TypedData.Domain,
+			"types":   types,
+			"message": response.TypedData.Message,
+		},
+	}
+	bytes, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing signed vote: %w", err)
+	}
+
+	// Log & return
+	fmt.Printf("Signed vote for proposal %s with choice %d:\n\n%s\n\n", proposalId.Hex(), choice, string(bytes))
+	fmt.Println("Submit this payload to the Snapshot hub to cast the vote.")
+	return nil
+
+}
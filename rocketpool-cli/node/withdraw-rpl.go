@@ -109,6 +109,9 @@ func nodeWithdrawRpl(c *cli.Context) error {
 		if !canWithdraw.IsAtlasDeployed && !canWithdraw.InConsensus {
 			fmt.Println("The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
 		}
+		if canWithdraw.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canWithdraw.RevertReason)
+		}
 		return nil
 	}
 
@@ -69,6 +69,11 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 	if err != nil {
 		return err
 	}
+	if !canResponse.CanSet {
+		fmt.Println("Cannot set the node's withdrawal address:")
+		fmt.Printf("The transaction would revert: %s\n", canResponse.RevertReason)
+		return nil
+	}
 
 	if confirm {
 		// Prompt for a test transaction
@@ -83,6 +88,10 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 			if err != nil {
 				return err
 			}
+			if canSendResponse.RevertReason != "" {
+				fmt.Printf("Cannot send the test transaction: the transaction would revert (%s).\n", canSendResponse.RevertReason)
+				return nil
+			}
 
 			// Assign max fees
 			err = gas.AssignMaxFeeAndLimit(canSendResponse.GasInfo, rp, c.Bool("yes"))
@@ -169,6 +178,11 @@ func confirmWithdrawalAddress(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if canResponse.RevertReason != "" {
+		fmt.Println("Cannot confirm the node's withdrawal address:")
+		fmt.Printf("The transaction would revert: %s\n", canResponse.RevertReason)
+		return nil
+	}
 
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
@@ -95,6 +95,10 @@ func cancelProposal(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if canResponse.RevertReason != "" {
+		fmt.Printf("Cannot cancel proposal %d: the transaction would revert (%s).\n", selectedProposal.ID, canResponse.RevertReason)
+		return nil
+	}
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
 	if err != nil {
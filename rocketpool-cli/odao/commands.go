@@ -50,6 +50,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "force-balance-submission",
+				Usage:     "Have the watchtower submit network balances that it withheld because they deviate from other oracle DAO members' submissions",
+				UsageText: "rocketpool odao force-balance-submission [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the forced submission",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return forceBalanceSubmission(c)
+
+				},
+			},
+
 			{
 				Name:      "member-settings",
 				Aliases:   []string{"b"},
@@ -145,6 +168,32 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 								},
 							},
 
+							{
+								Name:      "replace",
+								Aliases:   []string{"r"},
+								Usage:     "Propose replacing the node's position in the oracle DAO with a new member",
+								UsageText: "rocketpool odao propose member replace member-address member-id member-url",
+								Action: func(c *cli.Context) error {
+
+									// Validate args
+									if err := cliutils.ValidateArgCount(c, 3); err != nil {
+										return err
+									}
+									memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+									if err != nil {
+										return err
+									}
+									memberId, err := cliutils.ValidateDAOMemberID("member ID", c.Args().Get(1))
+									if err != nil {
+										return err
+									}
+
+									// Run
+									return proposeReplace(c, memberAddress, memberId, c.Args().Get(2))
+
+								},
+							},
+
 							{
 								Name:      "kick",
 								Aliases:   []string{"k"},
@@ -105,6 +105,9 @@ func executeProposal(c *cli.Context) error {
 		if err != nil {
 			fmt.Printf("WARNING: Couldn't get gas price for execute transaction (%s)", err)
 			break
+		} else if canResponse.RevertReason != "" {
+			fmt.Printf("Cannot execute proposal %d: the transaction would revert (%s).\n", proposal.ID, canResponse.RevertReason)
+			return nil
 		} else {
 			gasInfo = canResponse.GasInfo
 			totalGas += canResponse.GasInfo.EstGasLimit
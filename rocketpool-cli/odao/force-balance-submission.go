@@ -0,0 +1,44 @@
+package odao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func forceBalanceSubmission(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("This will make the watchtower submit its network balances for the current block even if they deviate from the balances submitted by other oracle DAO members. Only do this once you have reviewed the deviation in the watchtower logs. Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Create the request
+	response, err := rp.TNDAOForceBalanceSubmission()
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The watchtower will submit its balances for block %d on its next balance check, even if they deviate from the other oracle DAO members.\n", response.Block)
+	fmt.Println("You can follow its progress with `rocketpool service logs watchtower`.")
+	return nil
+
+}
@@ -111,6 +111,9 @@ func join(c *cli.Context) error {
 				if canSwap.InsufficientBalance {
 					fmt.Println("The node's old RPL balance is insufficient.")
 				}
+				if canSwap.RevertReason != "" {
+					fmt.Printf("The transaction would revert: %s\n", canSwap.RevertReason)
+				}
 				return nil
 			}
 			fmt.Println("RPL Swap Gas Info:")
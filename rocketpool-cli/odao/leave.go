@@ -76,6 +76,9 @@ func leave(c *cli.Context) error {
 		if canLeave.InsufficientMembers {
 			fmt.Println("There are not enough members in the oracle DAO to allow a member to leave.")
 		}
+		if canLeave.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canLeave.RevertReason)
+		}
 		return nil
 	}
 
@@ -39,6 +39,9 @@ func proposeInvite(c *cli.Context, memberAddress common.Address, memberId, membe
 		if canPropose.MemberAlreadyExists {
 			fmt.Printf("The node %s is already a member of the oracle DAO.\n", memberAddress.Hex())
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
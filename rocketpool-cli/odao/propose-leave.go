@@ -38,6 +38,9 @@ func proposeLeave(c *cli.Context) error {
 		if canPropose.InsufficientMembers {
 			fmt.Println("There are not enough members in the oracle DAO to allow a member to leave.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 	// Assign max fees
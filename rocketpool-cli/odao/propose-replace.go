@@ -0,0 +1,76 @@
+package odao
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func proposeReplace(c *cli.Context, memberAddress common.Address, memberId, memberUrl string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check if proposal can be made
+	canPropose, err := rp.CanProposeReplaceTNDAOMember(memberAddress, memberId, memberUrl)
+	if err != nil {
+		return err
+	}
+	if !canPropose.CanPropose {
+		fmt.Println("Cannot propose replacing the node with a new member:")
+		if canPropose.ProposalCooldownActive {
+			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+		}
+		if canPropose.MemberAlreadyExists {
+			fmt.Printf("The node %s is already a member of the oracle DAO.\n", memberAddress.Hex())
+		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit proposal
+	response, err := rp.ProposeReplaceTNDAOMember(memberAddress, memberId, memberUrl)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Proposing to replace the node with %s in the oracle DAO...\n", memberAddress.Hex())
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully submitted a replace proposal with ID %d for node %s.\n", response.ProposalId, memberAddress.Hex())
+	return nil
+
+}
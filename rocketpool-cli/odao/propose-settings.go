@@ -37,6 +37,9 @@ func proposeSettingMembersQuorum(c *cli.Context, quorumPercent float64) error {
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -95,6 +98,9 @@ func proposeSettingMembersRplBond(c *cli.Context, bondAmountEth float64) error {
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -153,6 +159,9 @@ func proposeSettingMinipoolUnbondedMax(c *cli.Context, unbondedMinipoolMax uint6
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -218,6 +227,9 @@ func proposeSettingProposalCooldown(c *cli.Context, proposalCooldownTimespan str
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -283,6 +295,9 @@ func proposeSettingProposalVoteTimespan(c *cli.Context, proposalVoteTimespan str
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -348,6 +363,9 @@ func proposeSettingProposalVoteDelayTimespan(c *cli.Context, proposalDelayTimesp
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -413,6 +431,9 @@ func proposeSettingProposalExecuteTimespan(c *cli.Context, proposalExecuteTimesp
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -478,6 +499,9 @@ func proposeSettingProposalActionTimespan(c *cli.Context, proposalActionTimespan
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -543,6 +567,9 @@ func proposeSettingScrubPeriod(c *cli.Context, scrubPeriod string) error {
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -608,6 +635,9 @@ func proposeSettingPromotionScrubPeriod(c *cli.Context, scrubPeriod string) erro
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -666,6 +696,9 @@ func proposeSettingScrubPenaltyEnabled(c *cli.Context, enabled bool) error {
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -731,6 +764,9 @@ func proposeSettingBondReductionWindowStart(c *cli.Context, windowStart string)
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -796,6 +832,9 @@ func proposeSettingBondReductionWindowLength(c *cli.Context, windowLength string
 		if canPropose.ProposalCooldownActive {
 			fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
 		}
+		if canPropose.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canPropose.RevertReason)
+		}
 		return nil
 	}
 
@@ -140,6 +140,9 @@ func voteOnProposal(c *cli.Context) error {
 		if canVote.JoinedAfterCreated {
 			fmt.Println("You cannot vote on proposals created before you joined the oracle DAO.")
 		}
+		if canVote.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canVote.RevertReason)
+		}
 		return nil
 	}
 
@@ -43,6 +43,9 @@ func processQueue(c *cli.Context) error {
 				fmt.Println("The deposit pool has an insufficient balance for assignment.")
 			}
 		}
+		if canProcess.RevertReason != "" {
+			fmt.Printf("The transaction would revert: %s\n", canProcess.RevertReason)
+		}
 		return nil
 	}
 
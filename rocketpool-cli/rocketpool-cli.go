@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/urfave/cli"
@@ -94,10 +96,24 @@ ______           _        _    ______           _
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.Float64Flag{
+			Name: "when-gas-below",
+			Usage: "Queue transactions for the node daemon to submit once the base fee drops below this value, in gwei, instead of submitting them now. " +
+				"Commands that submit several dependent transactions in a row only queue the first one",
+		},
+		cli.StringFlag{
+			Name:  "deadline",
+			Usage: "How long a transaction queued with --when-gas-below waits for the base fee to drop before it's dropped, such as 48h or 90m",
+			Value: rocketpool.DefaultTxQueueDeadline,
+		},
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
 		},
+		cli.BoolFlag{
+			Name:  "no-cache",
+			Usage: "Read everything directly from your clients instead of using the network state cached by the node daemon",
+		},
 		cli.BoolFlag{
 			Name: "secure-session, s",
 			Usage: "Some commands may print sensitive information to your terminal. " +
@@ -164,7 +180,15 @@ ______           _        _    ______           _
 	// Run application
 	fmt.Println("")
 	if err := app.Run(os.Args); err != nil {
-		cliutils.PrettyPrintError(err)
+		var queuedErr *rocketpool.TransactionQueuedError
+		if errors.As(err, &queuedErr) {
+			intent := queuedErr.Intent
+			fmt.Printf("The transaction was queued as #%d instead of being submitted.\n", intent.ID)
+			fmt.Printf("The node daemon will run `%s` once the base fee drops below %.2f gwei, or drop it if that doesn't happen by %s.\n", intent.CommandLine(), intent.MaxBaseFee, intent.Deadline.Format(time.RFC822))
+			fmt.Printf("Use `rocketpool node tx-queue list` to check on it, or `rocketpool node tx-queue cancel %d` to cancel it.\n", intent.ID)
+		} else {
+			cliutils.PrettyPrintError(err)
+		}
 	}
 	fmt.Println("")
 
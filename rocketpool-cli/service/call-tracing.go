@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Show or toggle contract call tracing
+func callTracing(c *cli.Context, state string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Print the current status if no state was given
+	if state == "" {
+		response, err := rp.GetCallTracing()
+		if err != nil {
+			return err
+		}
+		if response.Enabled {
+			fmt.Printf("Contract call tracing is %son%s. Calls are being logged to %s.\n", colorYellow, colorReset, response.LogPath)
+		} else {
+			fmt.Println("Contract call tracing is off.")
+		}
+		return nil
+	}
+
+	// Toggle tracing
+	response, err := rp.SetCallTracing(state == "on")
+	if err != nil {
+		return err
+	}
+	if response.Enabled {
+		fmt.Printf("Contract call tracing is now %son%s. Every contract call the Smartnode makes will be logged to %s within a few seconds.\n", colorYellow, colorReset, response.LogPath)
+		fmt.Println("This file can grow quickly; remember to turn tracing off with `rocketpool service call-tracing off` once you're done.")
+	} else {
+		fmt.Printf("%sContract call tracing is now off.%s\n", colorGreen, colorReset)
+	}
+	return nil
+
+}
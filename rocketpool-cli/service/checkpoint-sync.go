@@ -0,0 +1,86 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Verify a checkpoint sync provider against a second one, then save it to the config
+func checkpointSync(c *cli.Context, providerUrl string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
+		fmt.Println("You use an externally-managed Consensus client. Rocket Pool cannot configure checkpoint sync for it.")
+		return nil
+	}
+
+	// Get the URLs, falling back to the saved ones
+	if providerUrl == "" {
+		providerUrl = cfg.ConsensusCommon.CheckpointSyncProvider.Value.(string)
+	}
+	if providerUrl == "" {
+		return fmt.Errorf("You don't have a checkpoint sync URL configured. Please provide one to verify.")
+	}
+	verificationUrl := c.String("verification-url")
+	if verificationUrl == "" {
+		verificationUrl = cfg.ConsensusCommon.CheckpointSyncVerificationUrl.Value.(string)
+	}
+	if verificationUrl == "" {
+		return fmt.Errorf("You don't have a checkpoint sync verification URL configured. Please provide the URL of a second, independent provider with --verification-url.")
+	}
+
+	// Verify them
+	fmt.Println("Comparing the latest finalized state from both providers...")
+	response, err := rp.VerifyCheckpointSync(providerUrl, verificationUrl)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Finalized slot: %d\n", response.Slot)
+	fmt.Printf("Block root:     %s\n", response.BlockRoot.Hex())
+	fmt.Printf("State root:     %s (checkpoint sync provider)\n", response.StateRoot.Hex())
+	fmt.Printf("                %s (verification provider)\n\n", response.VerificationStateRoot.Hex())
+	if !response.Verified {
+		fmt.Printf("%sThe providers DO NOT agree on the finalized state. At least one of them is faulty or on a different chain.\nYour settings have not been changed; please choose a different checkpoint sync provider.%s\n", colorRed, colorReset)
+		return nil
+	}
+	fmt.Printf("%sBoth providers agree on the finalized state.%s\n", colorGreen, colorReset)
+
+	// Save the URLs if they changed
+	if providerUrl == cfg.ConsensusCommon.CheckpointSyncProvider.Value.(string) && verificationUrl == cfg.ConsensusCommon.CheckpointSyncVerificationUrl.Value.(string) {
+		return nil
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to save these as your checkpoint sync and verification URLs?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	cfg.ConsensusCommon.CheckpointSyncProvider.Value = providerUrl
+	cfg.ConsensusCommon.CheckpointSyncVerificationUrl.Value = verificationUrl
+	err = rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("Error saving settings: %w", err)
+	}
+
+	fmt.Println("Your settings have been saved. Your Consensus client will use the checkpoint sync URL the next time it syncs from scratch, such as after `rocketpool service resync-eth2`.")
+	fmt.Println("Please run `rocketpool service start` for the change to take effect.")
+	return nil
+
+}
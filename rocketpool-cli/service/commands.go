@@ -82,7 +82,16 @@ func createFlagsFromConfigParams(sectionName string, params []*cfgtypes.Paramete
 // Register commands
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 
-	configFlags := []cli.Flag{}
+	configFlags := []cli.Flag{
+		cli.BoolFlag{
+			Name:  "export-schema",
+			Usage: "Print a JSON schema of every configuration parameter (type, defaults, and constraints) and exit",
+		},
+		cli.StringFlag{
+			Name:  "validate",
+			Usage: "Check the settings file at this path against the configuration schema, print any errors, and exit",
+		},
+	}
 	cfgTemplate := config.NewRocketPoolConfig("", false)
 	network := cfgTemplate.Smartnode.Network.Value.(cfgtypes.Network)
 
@@ -168,6 +177,34 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return configureService(c)
 
 				},
+				Subcommands: []cli.Command{
+					{
+						Name:      "migrate",
+						Usage:     "Upgrade your settings file to this version of the Smartnode, showing what will change first",
+						UsageText: "rocketpool service config migrate [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "dry-run, d",
+								Usage: "Print the changes without saving them",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm saving the changes",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return migrateConfig(c)
+
+						},
+					},
+				},
 			},
 
 			{
@@ -266,7 +303,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "logs",
 				Aliases:   []string{"l"},
-				Usage:     "View the Rocket Pool service logs",
+				Usage:     "View the Rocket Pool service logs. Filtering by task, module, time, or level searches the node and watchtower daemons' log files instead.",
 				UsageText: "rocketpool service logs [options] [services...]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
@@ -274,6 +311,22 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Usage: "The number of lines to show from the end of the logs (number or \"all\")",
 						Value: "100",
 					},
+					cli.StringFlag{
+						Name:  "task",
+						Usage: "Only show the node or watchtower daemon's log entries for this task, such as submit-rewards-tree",
+					},
+					cli.StringFlag{
+						Name:  "module, m",
+						Usage: "Only show the node or watchtower daemon's log entries for this module, such as rewards",
+					},
+					cli.StringFlag{
+						Name:  "since, s",
+						Usage: "Only show the node or watchtower daemon's log entries written after this time, as a duration before now (e.g. 1h) or an RFC 3339 time",
+					},
+					cli.StringFlag{
+						Name:  "level, l",
+						Usage: "Only show the node or watchtower daemon's log entries at this level or above (debug, info, warn, or error)",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -354,6 +407,92 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "prune-rewards",
+				Usage:     "Deletes or archives rewards tree and minipool performance files that are older than a number of intervals, freeing up disk space",
+				UsageText: "rocketpool service prune-rewards [options]",
+				Flags: []cli.Flag{
+					cli.UintFlag{
+						Name:  "retention, r",
+						Usage: "The number of past intervals to keep rewards files for (defaults to the Rewards File Retention setting)",
+					},
+					cli.StringFlag{
+						Name:  "mode, m",
+						Usage: "What to do with the old files - 'delete' or 'archive' (defaults to the Rewards File Pruning setting)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm pruning the files",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("mode") != "" {
+						if _, err := cliutils.ValidateRewardsPruneMode("mode", c.String("mode")); err != nil {
+							return err
+						}
+					}
+
+					// Run command
+					return pruneRewardsFiles(c)
+
+				},
+			},
+
+			{
+				Name:      "call-tracing",
+				Usage:     "Shows or toggles contract call tracing, which logs every contract call the Smartnode makes to a file for troubleshooting",
+				UsageText: "rocketpool service call-tracing [on|off]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.NArg() > 1 {
+						return cliutils.ValidateArgCount(c, 1)
+					}
+					state := c.Args().Get(0)
+					if state != "" && state != "on" && state != "off" {
+						return fmt.Errorf("Invalid state '%s' - valid options are 'on' and 'off'", state)
+					}
+
+					// Run command
+					return callTracing(c, state)
+
+				},
+			},
+
+			{
+				Name:      "checkpoint-sync",
+				Usage:     "Verify a checkpoint sync URL against a second, independent provider and save it as your Consensus client's checkpoint sync provider",
+				UsageText: "rocketpool service checkpoint-sync [options] [url]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "verification-url, v",
+						Usage: "The URL of a second Beacon node or checkpoint provider to verify against; defaults to the saved Checkpoint Sync Verification URL",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm saving the verified URLs",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.NArg() > 1 {
+						return cliutils.ValidateArgCount(c, 1)
+					}
+
+					// Run command
+					return checkpointSync(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "install-update-tracker",
 				Aliases:   []string{"d"},
@@ -422,6 +561,206 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "native",
+				Usage:     "Manage the systemd services that run the Smartnode and your clients in Native Mode",
+				UsageText: "rocketpool service native command [options]",
+				Subcommands: []cli.Command{
+					{
+						Name:      "install",
+						Aliases:   []string{"i"},
+						Usage:     "Create and enable systemd services for the node and watchtower daemons, and for each client with a command in the Native Mode settings",
+						UsageText: "rocketpool service native install [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the installation",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return installNativeServices(c)
+
+						},
+					},
+					{
+						Name:      "start",
+						Aliases:   []string{"s"},
+						Usage:     "Start the Native Mode systemd services",
+						UsageText: "rocketpool service native start",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return startNativeServices(c)
+
+						},
+					},
+					{
+						Name:      "stop",
+						Aliases:   []string{"o"},
+						Usage:     "Stop the Native Mode systemd services",
+						UsageText: "rocketpool service native stop [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm stopping the services",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return stopNativeServices(c)
+
+						},
+					},
+					{
+						Name:      "status",
+						Aliases:   []string{"u"},
+						Usage:     "View the state of the Native Mode systemd services",
+						UsageText: "rocketpool service native status",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return nativeServiceStatus(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "reload-config",
+				Usage:     "Make the node and watchtower daemons pick up changes to settings like gas thresholds, watchtower intervals, and alert sinks without restarting them",
+				UsageText: "rocketpool service reload-config",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return reloadConfig(c)
+
+				},
+			},
+
+			{
+				Name:      "log-level",
+				Usage:     "View the log levels of the node and watchtower daemons, or change them without restarting",
+				UsageText: "rocketpool service log-level [options] [level]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "module, m",
+						Usage: "The module to view or change the level of, such as 'rewards'; defaults to every module without a level of its own",
+					},
+					cli.BoolFlag{
+						Name:  "reset, r",
+						Usage: "Return the module, or every module if none is given, to the log levels in your config",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.NArg() > 1 {
+						return cliutils.ValidateArgCount(c, 1)
+					}
+
+					// Run command
+					return logLevel(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "effective-config",
+				Usage:     "Show the config the Smartnode daemon is running with, after environment variable and flag overrides, and where each value came from",
+				UsageText: "rocketpool service effective-config [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "overrides-only, o",
+						Usage: "Only show parameters that aren't using their default value",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return getEffectiveConfig(c)
+
+				},
+			},
+
+			{
+				Name:      "secrets",
+				Usage:     "Show how each secret in your configuration (API tokens, passwords, webhook URLs, and RPC auth headers) is stored",
+				UsageText: "rocketpool service secrets",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return listSecrets(c)
+
+				},
+			},
+
+			{
+				Name:      "set-secret",
+				Usage:     "Set a secret in your configuration, encrypting it or storing a reference to a file or environment variable instead of the plaintext value",
+				UsageText: "rocketpool service set-secret [options] section parameter",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "mode, m",
+						Usage: "How to store the secret: 'encrypted' (with a key kept in your data folder), 'file' (read from the file given by --source), 'env' (read from the environment variable given by --source), or 'plaintext'",
+						Value: "encrypted",
+					},
+					cli.StringFlag{
+						Name:  "source, s",
+						Usage: "The file path or environment variable name to read the secret from, for the 'file' and 'env' modes. Secrets used by the Smartnode daemons are read where they run (inside their containers in Docker mode); secrets passed to other containers are read when you run `rocketpool service start`.",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run command
+					return setSecret(c, c.Args().Get(0), c.Args().Get(1))
+
+				},
+			},
+
 			{
 				Name:      "export-eth1-data",
 				Usage:     "Exports the execution client (eth1) chain data to an external folder. Use this if you want to back up your chain data before switching execution clients.",
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The settings backup made before a migration, by the version it migrates from
+const migrationBackupFileFormat string = "user-settings-%s.bak.yml"
+
+// Upgrade the settings file to the current version of the Smartnode, showing what will change first
+func migrateConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the settings as they're saved
+	settings, err := rp.LoadSettings()
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	before := migration.CopySettings(settings)
+	oldVersion := before["root"]["version"]
+
+	// Get the upgrades that apply
+	upgrades, err := migration.GetPendingUpgrades(migration.CopySettings(settings))
+	if err != nil {
+		return fmt.Errorf("error checking for config upgrades: %w", err)
+	}
+
+	// Upgrade the settings and fill in anything new with its default, just as saving the config would
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error upgrading settings: %w", err)
+	}
+	after := cfg.Serialize()
+	changes := migration.DiffSettings(before, after)
+
+	// Print the upgrades
+	fmt.Printf("Your settings file is from Smartnode %s; this is Smartnode v%s.\n\n", oldVersion, shared.RocketPoolVersion)
+	if len(upgrades) > 0 {
+		fmt.Printf("%s=== Upgrades ===%s\n", colorGreen, colorReset)
+		for _, upgrade := range upgrades {
+			fmt.Printf("v%s: %s\n", upgrade.Version.String(), upgrade.Description)
+		}
+		fmt.Println()
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("Your settings file is already up to date.")
+		return nil
+	}
+
+	// Print the diff, without revealing any secrets
+	secrets := map[string]bool{}
+	for _, secret := range cfg.GetSecretParameters() {
+		secrets[secret.Section+"."+secret.Parameter.ID] = true
+	}
+	formatValue := func(section string, id string, value string) string {
+		if secrets[section+"."+id] && value != "" {
+			return "<redacted>"
+		}
+		return fmt.Sprintf("%q", value)
+	}
+	fmt.Printf("%s=== Changes ===%s\n", colorGreen, colorReset)
+	for _, change := range changes {
+		name := change.Section + "." + change.ID
+		switch change.Kind {
+		case migration.SettingChangeKind_Added:
+			fmt.Printf("%s+ %s: %s%s\n", colorGreen, name, formatValue(change.Section, change.ID, change.NewValue), colorReset)
+		case migration.SettingChangeKind_Removed:
+			fmt.Printf("%s- %s: %s%s\n", colorRed, name, formatValue(change.Section, change.ID, change.OldValue), colorReset)
+		case migration.SettingChangeKind_Changed:
+			fmt.Printf("%s~ %s: %s -> %s%s\n", colorYellow, name, formatValue(change.Section, change.ID, change.OldValue), formatValue(change.Section, change.ID, change.NewValue), colorReset)
+		}
+	}
+	fmt.Println()
+
+	if c.Bool("dry-run") {
+		fmt.Println("This was a dry run; your settings file has not been changed.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to save these changes to your settings file?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Back up the old settings, then save the new ones
+	backupPath, err := rp.BackupSettings(fmt.Sprintf(migrationBackupFileFormat, oldVersion))
+	if err != nil {
+		return err
+	}
+	err = rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving settings: %w", err)
+	}
+
+	fmt.Printf("%sYour settings file has been upgraded.%s Your old settings were backed up to %s.\n", colorGreen, colorReset, backupPath)
+	fmt.Println("Please run `rocketpool service start` for the changes to take effect.")
+	return nil
+
+}
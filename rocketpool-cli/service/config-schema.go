@@ -0,0 +1,53 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Print the schema of every config parameter as JSON
+func exportConfigSchema() error {
+	cfg := config.NewRocketPoolConfig("", false)
+	bytes, err := json.MarshalIndent(cfg.GetSchema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing config schema: %w", err)
+	}
+	fmt.Println(string(bytes))
+	return nil
+}
+
+// Check a settings file against the config schema, printing each problem on its own line and exiting with an error code if there are any
+func validateConfigFile(path string) error {
+	expandedPath, err := homedir.Expand(path)
+	if err != nil {
+		return fmt.Errorf("error expanding settings file path [%s]: %w", path, err)
+	}
+	bytes, err := os.ReadFile(expandedPath)
+	if err != nil {
+		return fmt.Errorf("error reading settings file [%s]: %w", expandedPath, err)
+	}
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(bytes, &settings); err != nil {
+		return fmt.Errorf("error parsing settings file [%s]: %w", expandedPath, err)
+	}
+
+	configErrors := config.ValidateSettings(settings)
+	if len(configErrors) == 0 {
+		fmt.Printf("%s is valid.\n", expandedPath)
+		return nil
+	}
+	for _, configError := range configErrors {
+		fmt.Println(configError.Error())
+	}
+	fmt.Printf("%s has %d error(s).\n", expandedPath, len(configErrors))
+
+	// Exit with an error code so provisioning tools can tell the file is invalid
+	os.Exit(1)
+	return nil
+}
@@ -201,7 +201,7 @@ func createParameterizedStringField(param *cfgtypes.Parameter) *parameterizedFor
 		}
 	})
 	item.SetAcceptanceFunc(func(textToCheck string, lastChar rune) bool {
-		if param.MaxLength > 0 {
+		if param.MaxLength > 0 && !(param.IsSecret && cfgtypes.IsSecretReference(textToCheck)) {
 			if len(textToCheck) > param.MaxLength {
 				return false
 			}
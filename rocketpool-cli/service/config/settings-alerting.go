@@ -0,0 +1,80 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// The page wrapper for the alerting config
+type AlertingConfigPage struct {
+	home   *settingsHome
+	page   *page
+	layout *standardLayout
+}
+
+// Creates a new page for the alerting settings
+func NewAlertingConfigPage(home *settingsHome) *AlertingConfigPage {
+
+	configPage := &AlertingConfigPage{
+		home: home,
+	}
+
+	configPage.createContent()
+	configPage.page = newPage(
+		home.homePage,
+		"settings-alerting",
+		"Alerting",
+		"Select this to configure alerts for problems with your node, such as a low wallet balance, clients falling out of sync, or validators missing attestations. Alerts can be sent to a webhook, Discord, Telegram, or email.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *AlertingConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the alerting settings page
+func (configPage *AlertingConfigPage) createContent() {
+
+	// Create the layout
+	masterConfig := configPage.home.md.Config
+	layout := newStandardLayout()
+	configPage.layout = layout
+	layout.createForm(&masterConfig.Smartnode.Network, "Alerting Settings")
+
+	// Return to the home page after pressing Escape
+	layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			// Close all dropdowns and break if one was open
+			for _, param := range configPage.layout.parameters {
+				dropDown, ok := param.item.(*DropDown)
+				if ok && dropDown.open {
+					dropDown.CloseList(configPage.home.md.app)
+					return nil
+				}
+			}
+
+			// Return to the home page
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	formItems := createParameterizedFormItems(masterConfig.Alerting.GetParameters(), layout.descriptionBox)
+	for _, formItem := range formItems {
+		layout.form.AddFormItem(formItem.item)
+		layout.parameters[formItem.item] = formItem
+	}
+	layout.refresh()
+
+}
+
+// Handle a bulk redraw request
+func (configPage *AlertingConfigPage) handleLayoutChanged() {
+	configPage.layout.refresh()
+}
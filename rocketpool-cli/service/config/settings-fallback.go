@@ -15,6 +15,9 @@ type FallbackConfigPage struct {
 	masterConfig        *config.RocketPoolConfig
 	useFallbackBox      *parameterizedFormItem
 	reconnectDelay      *parameterizedFormItem
+	fallbackTxBox       *parameterizedFormItem
+	poolUrls            *parameterizedFormItem
+	routingMode         *parameterizedFormItem
 	fallbackNormalItems []*parameterizedFormItem
 	fallbackPrysmItems  []*parameterizedFormItem
 }
@@ -74,11 +77,14 @@ func (configPage *FallbackConfigPage) createContent() {
 	// Set up the form items
 	configPage.useFallbackBox = createParameterizedCheckbox(&configPage.masterConfig.UseFallbackClients)
 	configPage.reconnectDelay = createParameterizedStringField(&configPage.masterConfig.ReconnectDelay)
+	configPage.fallbackTxBox = createParameterizedCheckbox(&configPage.masterConfig.FallbackTransactions)
+	configPage.poolUrls = createParameterizedStringField(&configPage.masterConfig.BeaconNodePoolUrls)
+	configPage.routingMode = createParameterizedDropDown(&configPage.masterConfig.BeaconNodeRouting, configPage.layout.descriptionBox)
 	configPage.fallbackNormalItems = createParameterizedFormItems(configPage.masterConfig.FallbackNormal.GetParameters(), configPage.layout.descriptionBox)
 	configPage.fallbackPrysmItems = createParameterizedFormItems(configPage.masterConfig.FallbackPrysm.GetParameters(), configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
-	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay)
+	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay, configPage.fallbackTxBox, configPage.poolUrls, configPage.routingMode)
 	configPage.layout.mapParameterizedFormItems(configPage.fallbackNormalItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.fallbackPrysmItems...)
 
@@ -105,6 +111,9 @@ func (configPage *FallbackConfigPage) handleUseFallbackChanged() {
 		return
 	}
 	configPage.layout.form.AddFormItem(configPage.reconnectDelay.item)
+	configPage.layout.form.AddFormItem(configPage.fallbackTxBox.item)
+	configPage.layout.form.AddFormItem(configPage.poolUrls.item)
+	configPage.layout.form.AddFormItem(configPage.routingMode.item)
 
 	cc, _ := configPage.masterConfig.GetSelectedConsensusClient()
 	switch cc {
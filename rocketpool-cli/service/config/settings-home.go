@@ -20,6 +20,7 @@ type settingsHome struct {
 	ccPage           *ConsensusConfigPage
 	mevBoostPage     *MevBoostConfigPage
 	metricsPage      *MetricsConfigPage
+	alertingPage     *AlertingConfigPage
 	addonsPage       *AddonsPage
 	categoryList     *tview.List
 	settingsSubpages []settingsPage
@@ -45,6 +46,7 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.fallbackPage = NewFallbackConfigPage(home)
 	home.mevBoostPage = NewMevBoostConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
+	home.alertingPage = NewAlertingConfigPage(home)
 	home.addonsPage = NewAddonsPage(home)
 	settingsSubpages := []settingsPage{
 		home.smartnodePage,
@@ -53,6 +55,7 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.fallbackPage,
 		home.mevBoostPage,
 		home.metricsPage,
+		home.alertingPage,
 		home.addonsPage,
 	}
 	home.settingsSubpages = settingsSubpages
@@ -234,4 +237,8 @@ func (home *settingsHome) refresh() {
 	if home.metricsPage != nil {
 		home.metricsPage.layout.refresh()
 	}
+
+	if home.alertingPage != nil {
+		home.alertingPage.layout.refresh()
+	}
 }
@@ -15,6 +15,8 @@ type MetricsConfigPage struct {
 	masterConfig               *config.RocketPoolConfig
 	enableMetricsBox           *parameterizedFormItem
 	enableOdaoMetricsBox       *parameterizedFormItem
+	disabledCollectorsBox      *parameterizedFormItem
+	diskAlertDaysBox           *parameterizedFormItem
 	ecMetricsPortBox           *parameterizedFormItem
 	bnMetricsPortBox           *parameterizedFormItem
 	vcMetricsPortBox           *parameterizedFormItem
@@ -24,6 +26,8 @@ type MetricsConfigPage struct {
 	grafanaItems               []*parameterizedFormItem
 	prometheusItems            []*parameterizedFormItem
 	exporterItems              []*parameterizedFormItem
+	metricsPushItems           []*parameterizedFormItem
+	tracingItems               []*parameterizedFormItem
 	enableBitflyNodeMetricsBox *parameterizedFormItem
 	bitflyNodeMetricsItems     []*parameterizedFormItem
 }
@@ -83,6 +87,8 @@ func (configPage *MetricsConfigPage) createContent() {
 	// Set up the form items
 	configPage.enableMetricsBox = createParameterizedCheckbox(&configPage.masterConfig.EnableMetrics)
 	configPage.enableOdaoMetricsBox = createParameterizedCheckbox(&configPage.masterConfig.EnableODaoMetrics)
+	configPage.disabledCollectorsBox = createParameterizedStringField(&configPage.masterConfig.DisabledCollectors)
+	configPage.diskAlertDaysBox = createParameterizedUintField(&configPage.masterConfig.DiskAlertDays)
 	configPage.ecMetricsPortBox = createParameterizedUint16Field(&configPage.masterConfig.EcMetricsPort)
 	configPage.bnMetricsPortBox = createParameterizedUint16Field(&configPage.masterConfig.BnMetricsPort)
 	configPage.vcMetricsPortBox = createParameterizedUint16Field(&configPage.masterConfig.VcMetricsPort)
@@ -92,14 +98,18 @@ func (configPage *MetricsConfigPage) createContent() {
 	configPage.grafanaItems = createParameterizedFormItems(configPage.masterConfig.Grafana.GetParameters(), configPage.layout.descriptionBox)
 	configPage.prometheusItems = createParameterizedFormItems(configPage.masterConfig.Prometheus.GetParameters(), configPage.layout.descriptionBox)
 	configPage.exporterItems = createParameterizedFormItems(configPage.masterConfig.Exporter.GetParameters(), configPage.layout.descriptionBox)
+	configPage.metricsPushItems = createParameterizedFormItems(configPage.masterConfig.MetricsPush.GetParameters(), configPage.layout.descriptionBox)
+	configPage.tracingItems = createParameterizedFormItems(configPage.masterConfig.Tracing.GetParameters(), configPage.layout.descriptionBox)
 	configPage.enableBitflyNodeMetricsBox = createParameterizedCheckbox(&configPage.masterConfig.EnableBitflyNodeMetrics)
 	configPage.bitflyNodeMetricsItems = createParameterizedFormItems(configPage.masterConfig.BitflyNodeMetrics.GetParameters(), configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
-	configPage.layout.mapParameterizedFormItems(configPage.enableMetricsBox, configPage.enableOdaoMetricsBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox)
+	configPage.layout.mapParameterizedFormItems(configPage.enableMetricsBox, configPage.enableOdaoMetricsBox, configPage.disabledCollectorsBox, configPage.diskAlertDaysBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox)
 	configPage.layout.mapParameterizedFormItems(configPage.grafanaItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.prometheusItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.exporterItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.metricsPushItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.tracingItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.enableBitflyNodeMetricsBox)
 	configPage.layout.mapParameterizedFormItems(configPage.bitflyNodeMetricsItems...)
 
@@ -129,11 +139,13 @@ func (configPage *MetricsConfigPage) handleLayoutChanged() {
 	configPage.layout.form.AddFormItem(configPage.enableMetricsBox.item)
 
 	if configPage.masterConfig.EnableMetrics.Value == true {
-		configPage.layout.addFormItems([]*parameterizedFormItem{configPage.enableOdaoMetricsBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox})
+		configPage.layout.addFormItems([]*parameterizedFormItem{configPage.enableOdaoMetricsBox, configPage.disabledCollectorsBox, configPage.diskAlertDaysBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox})
 		configPage.layout.addFormItems(configPage.grafanaItems)
 		configPage.layout.addFormItems(configPage.prometheusItems)
 		configPage.layout.addFormItems(configPage.exporterItems)
+		configPage.layout.addFormItems(configPage.metricsPushItems)
 	}
+	configPage.layout.addFormItems(configPage.tracingItems)
 
 	switch configPage.masterConfig.ConsensusClient.Value.(cfgtypes.ConsensusClient) {
 	case cfgtypes.ConsensusClient_Teku, cfgtypes.ConsensusClient_Lighthouse:
@@ -14,6 +14,9 @@ type NativeFallbackConfigPage struct {
 	masterConfig   *config.RocketPoolConfig
 	useFallbackBox *parameterizedFormItem
 	reconnectDelay *parameterizedFormItem
+	fallbackTxBox  *parameterizedFormItem
+	poolUrls       *parameterizedFormItem
+	routingMode    *parameterizedFormItem
 	fallbackItems  []*parameterizedFormItem
 }
 
@@ -72,10 +75,13 @@ func (configPage *NativeFallbackConfigPage) createContent() {
 	// Set up the form items
 	configPage.useFallbackBox = createParameterizedCheckbox(&configPage.masterConfig.UseFallbackClients)
 	configPage.reconnectDelay = createParameterizedStringField(&configPage.masterConfig.ReconnectDelay)
+	configPage.fallbackTxBox = createParameterizedCheckbox(&configPage.masterConfig.FallbackTransactions)
+	configPage.poolUrls = createParameterizedStringField(&configPage.masterConfig.BeaconNodePoolUrls)
+	configPage.routingMode = createParameterizedDropDown(&configPage.masterConfig.BeaconNodeRouting, configPage.layout.descriptionBox)
 	configPage.fallbackItems = createParameterizedFormItems(configPage.masterConfig.FallbackNormal.GetParameters(), configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
-	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay)
+	configPage.layout.mapParameterizedFormItems(configPage.useFallbackBox, configPage.reconnectDelay, configPage.fallbackTxBox, configPage.poolUrls, configPage.routingMode)
 	configPage.layout.mapParameterizedFormItems(configPage.fallbackItems...)
 
 	// Set up the setting callbacks
@@ -101,6 +107,9 @@ func (configPage *NativeFallbackConfigPage) handleUseFallbackChanged() {
 		return
 	}
 	configPage.layout.form.AddFormItem(configPage.reconnectDelay.item)
+	configPage.layout.form.AddFormItem(configPage.fallbackTxBox.item)
+	configPage.layout.form.AddFormItem(configPage.poolUrls.item)
+	configPage.layout.form.AddFormItem(configPage.routingMode.item)
 	configPage.layout.addFormItems(configPage.fallbackItems)
 
 	configPage.layout.refresh()
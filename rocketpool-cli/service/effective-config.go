@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Print the config the daemon is running with and where each value came from
+func getEffectiveConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the effective config
+	response, err := rp.GetEffectiveConfig()
+	if err != nil {
+		return err
+	}
+
+	overridesOnly := c.Bool("overrides-only")
+	section := ""
+	for _, setting := range response.Settings {
+		if overridesOnly && setting.Source == string(config.ConfigSource_Default) {
+			continue
+		}
+		if setting.Section != section {
+			section = setting.Section
+			fmt.Printf("\n%s=== %s ===%s\n", colorGreen, section, colorReset)
+		}
+		source := setting.Source
+		switch config.ConfigSource(setting.Source) {
+		case config.ConfigSource_Environment:
+			source = fmt.Sprintf("%s%s (%s)%s", colorYellow, source, setting.EnvVar, colorReset)
+		case config.ConfigSource_Flag:
+			source = fmt.Sprintf("%s%s%s", colorYellow, source, colorReset)
+		}
+		fmt.Printf("%-40s %s [%s]\n", setting.ID, setting.Value, source)
+	}
+	fmt.Println()
+	fmt.Println("Any parameter can be overridden with an environment variable named RP_<SECTION>_<PARAMETER> (for example RP_SMARTNODE_MANUALMAXFEE), or with the daemon's --set section.parameter=value flag, which takes precedence over both the environment and the settings file.")
+	return nil
+
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// View or change the log levels of the daemons while they're running
+func logLevel(c *cli.Context, level string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	module := c.String("module")
+	if module == "" && !c.Bool("reset") {
+		module = log.DefaultModule
+	}
+
+	// Reset the overrides
+	if c.Bool("reset") {
+		if level != "" {
+			return fmt.Errorf("A level can't be given with --reset.")
+		}
+		_, err = rp.ResetLogLevels(module)
+		if err != nil {
+			return err
+		}
+		if module == "" {
+			fmt.Println("All modules are back to the log levels in your config.")
+		} else {
+			fmt.Printf("The %s module is back to its log level in your config.\n", module)
+		}
+		fmt.Println("The daemons will apply the change within a few seconds.")
+		return nil
+	}
+
+	// Set a level
+	if level != "" {
+		_, err = rp.SetLogLevel(module, level)
+		if err != nil {
+			return err
+		}
+		if module == log.DefaultModule {
+			fmt.Printf("Modules without a level of their own will log at %s%s%s.\n", colorGreen, level, colorReset)
+		} else {
+			fmt.Printf("The %s module will log at %s%s%s.\n", module, colorGreen, level, colorReset)
+		}
+		fmt.Printf("The daemons will apply the change within a few seconds. It lasts until you run %s`rocketpool service log-level --reset`%s.\n", colorGreen, colorReset)
+		return nil
+	}
+
+	// Print the levels
+	response, err := rp.GetLogLevels()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sFormat:%s %s\n", colorBold, colorReset, response.Format)
+	fmt.Printf("%sDefault level:%s %s", colorBold, colorReset, response.DefaultLevel)
+	if override, exists := response.Overrides[log.DefaultModule]; exists {
+		fmt.Printf(" (%schanged to %s at runtime%s)", colorYellow, override, colorReset)
+	}
+	fmt.Println()
+	fmt.Println()
+
+	modules := response.Modules
+	sort.Strings(modules)
+	for _, module := range modules {
+		level, color, source := response.DefaultLevel, "", "default"
+		if configLevel, exists := response.ModuleLevels[module]; exists {
+			level, color, source = configLevel, colorGreen, "config"
+		} else if override, exists := response.Overrides[log.DefaultModule]; exists {
+			level, color, source = override, colorYellow, "runtime"
+		}
+		if override, exists := response.Overrides[module]; exists {
+			level, color, source = override, colorYellow, "runtime"
+		}
+		fmt.Printf("%-24s %s%-6s%s (%s)\n", module, color, level, colorReset, source)
+	}
+	return nil
+
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The format of the entry times
+const logEntryTimeFormat string = "2006-01-02 15:04:05"
+
+// Check if the logs command was given any of the filters that need the daemons' structured log files
+func isLogQuery(c *cli.Context) bool {
+	return c.String("task") != "" || c.String("module") != "" || c.String("since") != "" || c.String("level") != ""
+}
+
+// Print the entries from the daemons' log files that match the filters, instead of the raw container output
+func queryServiceLogs(c *cli.Context, rp *rocketpool.Client, serviceNames ...string) error {
+
+	// Get the daemon to read the logs of
+	process := ""
+	switch len(serviceNames) {
+	case 0:
+	case 1:
+		process = serviceNames[0]
+	default:
+		return fmt.Errorf("Only one service (node or watchtower) can be given when filtering logs.")
+	}
+
+	// Get the number of entries to show
+	var limit uint64
+	if tail := c.String("tail"); tail != "all" {
+		var err error
+		limit, err = strconv.ParseUint(tail, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid tail '%s'; it must be a number or \"all\".", tail)
+		}
+	}
+
+	// Get the entries
+	response, err := rp.QueryLogs(process, c.String("task"), c.String("module"), c.String("since"), c.String("level"), limit)
+	if err != nil {
+		return err
+	}
+	if len(response.Entries) == 0 {
+		fmt.Println("No log entries matched.")
+		return nil
+	}
+
+	// Print them
+	for _, entry := range response.Entries {
+		color := ""
+		switch entry.Level {
+		case log.LevelError.String():
+			color = colorRed
+		case log.LevelWarn.String():
+			color = colorYellow
+		}
+		source := entry.Module
+		if entry.Task != "" {
+			source = entry.Task
+		}
+		fmt.Printf("%s %-10s %s%-5s%s [%s] %s\n", entry.Time.Local().Format(logEntryTimeFormat), entry.Process, color, entry.Level, colorReset, source, entry.Message)
+	}
+	return nil
+
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/native"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Get the RP client and the config for a Native mode command
+func getNativeClient(c *cli.Context) (*rocketpool.Client, *config.RocketPoolConfig, error) {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		rp.Close()
+		return nil, nil, err
+	}
+	if isNew {
+		rp.Close()
+		return nil, nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if !cfg.IsNativeMode {
+		rp.Close()
+		return nil, nil, fmt.Errorf("This command is only available in Native Mode (with the '--daemon-path' option specified).")
+	}
+
+	return rp, cfg, nil
+
+}
+
+// Create and enable the systemd services for Native mode
+func installNativeServices(c *cli.Context) error {
+
+	// Get RP client and config
+	rp, cfg, err := getNativeClient(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Show what will be installed
+	services, err := rp.GetNativeServices(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Println("The following systemd services will be created and enabled, so they start automatically when your machine boots:")
+	for _, service := range services {
+		fmt.Printf("\t%s%s%s (%s): %s\n", colorGreen, native.GetUnitName(service.ID), colorReset, service.Description, service.Command)
+	}
+	fmt.Printf("They will run as the '%s' user.\n", cfg.Native.ServiceUser.Value.(string))
+	if cfg.Native.EcCommand.Value.(string) == "" || cfg.Native.BnCommand.Value.(string) == "" || cfg.Native.VcCommand.Value.(string) == "" {
+		fmt.Printf("%sClients without a command in the Native Mode settings won't be managed by the Smartnode; you'll need to run them yourself.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("This requires sudo access. Would you like to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Install the services
+	paths, err := rp.InstallNativeServices(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%sInstalled %d systemd services.%s The unit files were installed in %s.\n", colorGreen, len(paths), colorReset, filepath.Dir(paths[0]))
+	fmt.Println("Run `rocketpool service start` to start them.")
+	return nil
+
+}
+
+// Print the state of the Native mode systemd services
+func printNativeServiceStatus(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+
+	services, err := rp.GetNativeServices(cfg)
+	if err != nil {
+		return err
+	}
+	status, err := rp.GetNativeServiceStatus(cfg)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		unit := native.GetUnitName(service.ID)
+		state := status[unit]
+		color := colorYellow
+		switch state {
+		case "active":
+			color = colorGreen
+		case "failed":
+			color = colorRed
+		}
+		fmt.Printf("%-26s %-30s %s%s%s\n", unit, service.Description, color, state, colorReset)
+	}
+	return nil
+
+}
+
+// View the state of the Native mode systemd services
+func nativeServiceStatus(c *cli.Context) error {
+
+	// Get RP client and config
+	rp, cfg, err := getNativeClient(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	return printNativeServiceStatus(rp, cfg)
+
+}
+
+// Start the Native mode systemd services
+func startNativeServices(c *cli.Context) error {
+
+	// Get RP client and config
+	rp, cfg, err := getNativeClient(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	return rp.StartNativeServices(cfg)
+
+}
+
+// Stop the Native mode systemd services
+func stopNativeServices(c *cli.Context) error {
+
+	// Get RP client and config
+	rp, cfg, err := getNativeClient(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to stop the Rocket Pool services? Any staking minipools will be penalized!")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	return rp.StopNativeServices(cfg)
+
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Delete or archive old rewards files
+func pruneRewardsFiles(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the retention and mode, falling back to the configured ones
+	retention := cfg.Smartnode.RewardsRetentionIntervals.Value.(uint64)
+	if c.IsSet("retention") {
+		retention = uint64(c.Uint("retention"))
+	}
+	if retention == 0 {
+		return fmt.Errorf("Please specify the number of intervals to keep rewards files for with the `--retention` flag, or set the Rewards File Retention setting in `rocketpool service config`.")
+	}
+	mode := cfg.Smartnode.RewardsPruneMode.Value.(cfgtypes.RewardsPruneMode)
+	if c.String("mode") != "" {
+		mode, err = cliutils.ValidateRewardsPruneMode("mode", c.String("mode"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Get the files that will be pruned
+	canResponse, err := rp.CanPruneRewardsFiles(retention)
+	if err != nil {
+		return err
+	}
+	if len(canResponse.Files) == 0 {
+		fmt.Printf("There are no rewards files older than %d intervals to prune.\n", retention)
+		return nil
+	}
+
+	// Print them
+	fmt.Printf("The current rewards interval is %d. The following rewards files are older than %d intervals:\n", canResponse.CurrentIndex, retention)
+	for _, file := range canResponse.Files {
+		fmt.Printf("\t%s (%s)\n", file.Path, humanize.IBytes(file.Size))
+	}
+	fmt.Println()
+	fmt.Println("Rewards tree files for intervals you haven't claimed yet will be kept, since they're required to claim.")
+	fmt.Println()
+
+	// Prompt for confirmation
+	var prompt string
+	switch mode {
+	case cfgtypes.RewardsPruneMode_Archive:
+		prompt = fmt.Sprintf("Are you sure you want to archive these %d files (%s)? They will be compressed and moved into the `%s` folder.", len(canResponse.Files), humanize.IBytes(canResponse.TotalSize), cfg.Smartnode.GetRewardsArchiveFolder(false))
+	default:
+		prompt = fmt.Sprintf("%sAre you sure you want to delete these %d files (%s)? This cannot be undone!%s", colorYellow, len(canResponse.Files), humanize.IBytes(canResponse.TotalSize), colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(prompt)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Prune the files
+	response, err := rp.PruneRewardsFiles(retention, mode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pruned %d rewards files, freeing up %s.\n", response.PrunedFiles, humanize.IBytes(response.PrunedSize))
+	return nil
+
+}
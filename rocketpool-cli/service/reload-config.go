@@ -0,0 +1,31 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Ask the daemons to reload their config
+func reloadConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Request the reload
+	_, err = rp.ReloadConfig()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("The node and watchtower daemons will reload their config before their next task pass; running tasks won't be interrupted.")
+	fmt.Printf("They'll log which settings were applied. Other settings, such as your clients and network, still need %s`rocketpool service start`%s to take effect.\n", colorGreen, colorReset)
+	return nil
+
+}
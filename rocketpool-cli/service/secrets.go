@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Show how each secret parameter is kept in the settings file
+func listSecrets(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	plaintextCount := 0
+	for _, secret := range cfg.GetSecretParameters() {
+		value, _ := secret.Parameter.Value.(string)
+		storage := config.GetSecretStorage(value)
+		description := string(storage)
+		switch storage {
+		case config.SecretStorage_Plaintext:
+			plaintextCount++
+			description = colorYellow + description + colorReset
+		case config.SecretStorage_File, config.SecretStorage_Env:
+			description = fmt.Sprintf("%s (%s)", description, value)
+		case config.SecretStorage_Encrypted:
+			description = colorGreen + description + colorReset
+		}
+		fmt.Printf("%-40s %s\n", secret.Section+"."+secret.Parameter.ID, description)
+	}
+	fmt.Println()
+
+	if plaintextCount > 0 {
+		fmt.Printf("%d secret(s) are stored in plaintext in your settings file. You can encrypt them or move them into a file or an environment variable with `rocketpool service set-secret`.\n", plaintextCount)
+	}
+	return nil
+
+}
+
+// Set a secret parameter, storing it encrypted or as a reference to a file or environment variable
+func setSecret(c *cli.Context, section string, id string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Find the parameter
+	var param *cfgtypes.Parameter
+	for _, secret := range cfg.GetSecretParameters() {
+		if secret.Section == section && secret.Parameter.ID == id {
+			param = secret.Parameter
+			break
+		}
+	}
+	if param == nil {
+		return fmt.Errorf("%s.%s is not a secret parameter. Run `rocketpool service secrets` to see the secret parameters.", section, id)
+	}
+
+	// Get the new value
+	var value string
+	mode := config.SecretStorage(c.String("mode"))
+	switch mode {
+	case config.SecretStorage_Encrypted, config.SecretStorage_Plaintext:
+		secret := cliutils.PromptPassword(fmt.Sprintf("Please enter the value of %s:", param.Name), "^.+$", "The value cannot be blank.")
+		if mode == config.SecretStorage_Plaintext {
+			value = secret
+		} else {
+			value, err = cfg.EncryptSecret(secret, false)
+			if err != nil {
+				return err
+			}
+		}
+
+	case config.SecretStorage_File:
+		if c.String("source") == "" {
+			return fmt.Errorf("Please provide the path of the file that holds the secret with --source.")
+		}
+		value = cfgtypes.SecretPrefix_File + c.String("source")
+
+	case config.SecretStorage_Env:
+		if c.String("source") == "" {
+			return fmt.Errorf("Please provide the name of the environment variable that holds the secret with --source.")
+		}
+		value = cfgtypes.SecretPrefix_Env + c.String("source")
+
+	default:
+		return fmt.Errorf("Invalid mode '%s'; it must be encrypted, file, env, or plaintext.", mode)
+	}
+
+	// Save the config
+	param.Value = value
+	err = rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("Error saving settings: %w", err)
+	}
+
+	fmt.Printf("%sSaved %s.%s as %s.%s\n", colorGreen, section, id, mode, colorReset)
+	if mode == config.SecretStorage_Encrypted {
+		fmt.Printf("It was encrypted with the key in %s; keep that file safe, since the secret can't be recovered without it.\n", cfg.Smartnode.GetSecretsKeyPath(false))
+	}
+	fmt.Println("Please run `rocketpool service start` for the change to take effect.")
+	return nil
+
+}
@@ -214,6 +214,15 @@ func serviceStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Print the systemd service status in Native mode
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if !isNew && cfg.IsNativeMode {
+		return printNativeServiceStatus(rp, cfg)
+	}
+
 	// Print service status
 	return rp.PrintServiceStatus(getComposeFiles(c))
 
@@ -222,6 +231,14 @@ func serviceStatus(c *cli.Context) error {
 // Configure the service
 func configureService(c *cli.Context) error {
 
+	// Handle the schema tools, which don't touch the current config
+	if c.Bool("export-schema") {
+		return exportConfigSchema()
+	}
+	if c.IsSet("validate") {
+		return validateConfigFile(c.String("validate"))
+	}
+
 	// Make sure the config directory exists first
 	configPath := c.GlobalString("config-path")
 	path, err := homedir.Expand(configPath)
@@ -544,6 +561,11 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		fmt.Printf("%sYou are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.%s\n", colorRed, colorReset)
 	}
 
+	// Remind the user about the trust assumptions of remote providers
+	if cfg.IsRemoteExecutionProvider() || cfg.IsRemoteConsensusProvider() {
+		fmt.Printf("%sNOTE: Your node is using a remote provider for its Execution and/or Consensus client.\nYou are trusting that provider to give your node correct information about the chain; a faulty or malicious provider can cause you to miss duties or act on false data.\nConsider switching to clients you run yourself once your hardware allows it.%s\n\n", colorYellow, colorReset)
+	}
+
 	isMigration := false
 	if isNew {
 		// Look for a legacy config to migrate
@@ -602,6 +624,15 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		return nil
 	}
 
+	// Native mode runs the services with systemd instead of Docker
+	if cfg.IsNativeMode {
+		err = rp.StartNativeServices(cfg)
+		if err != nil {
+			return err
+		}
+		return rp.RemoveUpgradeFlagFile()
+	}
+
 	if !c.Bool("ignore-slash-timer") {
 		// Do the client swap check
 		err := checkForValidatorChange(rp, cfg)
@@ -1197,6 +1228,11 @@ func pauseService(c *cli.Context) error {
 		return nil
 	}
 
+	// Stop the systemd services in Native mode
+	if cfg.IsNativeMode {
+		return rp.StopNativeServices(cfg)
+	}
+
 	// Pause service
 	return rp.PauseService(getComposeFiles(c))
 
@@ -1233,6 +1269,11 @@ func serviceLogs(c *cli.Context, serviceNames ...string) error {
 	}
 	defer rp.Close()
 
+	// Search the daemons' log files if filters were given
+	if isLogQuery(c) {
+		return queryServiceLogs(c, rp, serviceNames...)
+	}
+
 	// Print service logs
 	return rp.PrintServiceLogs(getComposeFiles(c), c.String("tail"), serviceNames...)
 
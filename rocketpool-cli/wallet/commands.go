@@ -34,6 +34,30 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "unlock",
+				Aliases:   []string{"u"},
+				Usage:     "Unlock the node wallet after a restart, if its password is only kept in memory",
+				UsageText: "rocketpool wallet unlock [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The node wallet's password",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return unlockWallet(c)
+
+				},
+			},
+
 			{
 				Name:      "init",
 				Aliases:   []string{"i"},
@@ -104,6 +128,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Smartnode and don't know the derivation path or index of it, enter the address here. The Smartnode will search through its library of paths and indices to try to find it.",
 					},
+					cli.StringFlag{
+						Name:  "validator-pubkeys, v",
+						Usage: "Only recover the validator keys with these pubkeys (comma-separated)",
+					},
+					cli.StringFlag{
+						Name:  "minipools, n",
+						Usage: "Only recover the validator keys for these minipool addresses (comma-separated)",
+					},
+					cli.UintFlag{
+						Name:  "search-depth, s",
+						Usage: "The number of validator key indices to search through when recovering validator keys (defaults to 2000)",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -123,6 +159,16 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("validator-pubkeys") != "" {
+						if _, err := cliutils.ValidatePubkeys("validator-pubkeys", c.String("validator-pubkeys")); err != nil {
+							return err
+						}
+					}
+					if c.String("minipools") != "" {
+						if _, err := cliutils.ValidateAddresses("minipools", c.String("minipools")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return recoverWallet(c)
@@ -130,6 +176,48 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "import-keystore",
+				Usage:     "Initialize the node wallet from an existing keystore or private key instead of a mnemonic phrase",
+				UsageText: "rocketpool wallet import-keystore [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The password to secure the wallet with (if not already set)",
+					},
+					cli.StringFlag{
+						Name:  "keystore, k",
+						Usage: "The path to an EIP-2335 or Web3 Secret Storage keystore file holding the node private key; if omitted, you will be prompted for the private key",
+					},
+					cli.StringFlag{
+						Name:  "keystore-password, s",
+						Usage: "The keystore's password (you will be prompted for it if omitted)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("password") != "" {
+						if _, err := cliutils.ValidateNodePassword("password", c.String("password")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return importKeystore(c)
+
+				},
+			},
+
 			{
 				Name:      "rebuild",
 				Aliases:   []string{"b"},
@@ -151,13 +239,17 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "test-recovery",
 				Aliases:   []string{"t"},
-				Usage:     "Test recovering a node wallet without actually generating any of the node wallet or validator key files to ensure the process works as expected",
+				Usage:     "Test recovering a node wallet without actually generating any of the node wallet or validator key files, checking that the regenerated keys match the node's on-chain registrations to ensure your backup works",
 				UsageText: "rocketpool wallet test-recovery [options]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "mnemonic, m",
 						Usage: "The mnemonic phrase to recover the wallet from",
 					},
+					cli.StringFlag{
+						Name:  "mnemonic-file, f",
+						Usage: "The path of a file containing the mnemonic phrase to recover the wallet from",
+					},
 					cli.BoolFlag{
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
@@ -231,6 +323,188 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "pending",
+				Usage:     "Show the node wallet's nonce, its pending transactions, and any nonce gaps",
+				UsageText: "rocketpool wallet pending",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getPendingTransactions(c)
+
+				},
+			},
+
+			{
+				Name:      "clear-stuck",
+				Usage:     "Replace a stuck nonce with a zero-value transaction to the node itself",
+				UsageText: "rocketpool wallet clear-stuck [options] nonce",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm clearing the nonce",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return clearStuckNonce(c, nonce)
+
+				},
+			},
+
+			{
+				Name:      "delete-validator-key",
+				Usage:     "Delete a validator key from all of the validator clients' keystores and restart them; the validator must have exited the Beacon Chain",
+				UsageText: "rocketpool wallet delete-validator-key [options] pubkey",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "force, f",
+						Usage: fmt.Sprintf("%sDelete the key even if the validator hasn't exited the Beacon Chain%s", colorRed, colorReset),
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm deleting the key",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return deleteValidatorKey(c, pubkey)
+
+				},
+			},
+
+			{
+				Name:      "profiles",
+				Usage:     "List the wallet profiles; the active one is marked with a *",
+				UsageText: "rocketpool wallet profiles",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getProfiles(c)
+
+				},
+			},
+
+			{
+				Name:      "switch-profile",
+				Usage:     "Make a wallet profile the active one, creating it if it doesn't exist. Each profile has its own wallet, password, and validator keys.",
+				UsageText: "rocketpool wallet switch-profile [options] name",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the switch",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return switchProfile(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "web3signer-keys",
+				Usage:     "List the validator keys stored in Web3Signer",
+				UsageText: "rocketpool wallet web3signer-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getWeb3SignerKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "web3signer-import",
+				Usage:     "Import the node wallet's validator keys into Web3Signer",
+				UsageText: "rocketpool wallet web3signer-import [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "slashing-protection, s",
+						Usage: "The path of an EIP-3076 slashing protection file to import along with the keys; it must be readable by the Smartnode daemon",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return importWeb3SignerKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "web3signer-remove",
+				Usage:     "Remove validator keys from Web3Signer, saving their slashing protection data",
+				UsageText: "rocketpool wallet web3signer-remove [options] pubkeys",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the removal",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return removeWeb3SignerKeys(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     fmt.Sprintf("%sDeletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!%s", colorRed, colorReset),
@@ -0,0 +1,64 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func deleteValidatorKey(c *cli.Context, pubkey types.ValidatorPubkey) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check & warn if the validator hasn't exited
+	force := c.Bool("force")
+	if !force {
+		canDelete, err := rp.CanDeleteValidatorKey(pubkey)
+		if err != nil {
+			return err
+		}
+		if !canDelete.CanDelete {
+			fmt.Println("The validator key cannot be deleted:")
+			if !canDelete.ValidatorExists {
+				fmt.Printf("The validator %s does not exist on the Beacon Chain.\n", pubkey.Hex())
+			} else if canDelete.ValidatorActive {
+				fmt.Printf("The validator %s has not exited the Beacon Chain yet (its state is %s).\n", pubkey.Hex(), canDelete.BeaconState)
+			}
+			fmt.Println("Use --force to delete it anyway.")
+			return nil
+		}
+	} else {
+		fmt.Printf("%sWARNING: --force skips the check that the validator has exited the Beacon Chain. If it is still active, it will stop attesting and proposing with this machine and start losing ETH.%s\n\n", colorRed, colorReset)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to delete the key for validator %s from all of the Validator Client keystores and restart the Validator Client?", pubkey.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Delete the key
+	if _, err := rp.DeleteValidatorKey(pubkey, force); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted the key for validator %s.\n", pubkey.Hex())
+
+	// Restart the VC so it stops loading the key
+	fmt.Print("Restarting Validator Client... ")
+	if _, err := rp.RestartVc(); err != nil {
+		fmt.Printf("failed!\n%sWARNING: error restarting validator client: %s\n\nPlease restart it manually so it stops using the deleted key.%s\n", colorYellow, err.Error(), colorReset)
+		return nil
+	}
+	fmt.Println("done!")
+	return nil
+
+}
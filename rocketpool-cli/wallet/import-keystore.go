@@ -0,0 +1,135 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The fields of a keystore file needed to tell which format it's in
+type keystoreHeader struct {
+	Version uint                   `json:"version"`
+	Crypto  map[string]interface{} `json:"crypto"`
+}
+
+func importKeystore(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletInitialized {
+		fmt.Println("The node wallet is already initialized.")
+		return nil
+	}
+
+	// Warn about not having a mnemonic
+	fmt.Printf("%sWARNING:\nThis will create a node wallet from an existing private key instead of a mnemonic phrase.\nThere will be no mnemonic to recover the wallet with: the private key (or the keystore and its password) is the only backup of your node account.\nYour validator keys will be derived from the private key, so you will need it to rebuild them as well.\nIf you lose it, you will lose access to your node account and validator keys.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to import the node wallet from a private key?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Load the private key
+	var privateKey *ecdsa.PrivateKey
+	if c.String("keystore") != "" {
+		privateKey, err = loadKeystore(c.String("keystore"), c.String("keystore-password"))
+	} else {
+		privateKey, err = cliutils.ValidateNodePrivateKey("private key", cliutils.PromptPassword("Please enter the node account's private key:", "^.+$", "Please enter the private key."))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Importing the key for node account %s.\n\n", crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+
+	// Set password if not set
+	if !status.PasswordSet {
+		var password string
+		if c.String("password") != "" {
+			password = c.String("password")
+		} else {
+			password = promptPassword()
+		}
+		if _, err := rp.SetPassword(password); err != nil {
+			return err
+		}
+	}
+
+	// Import the key
+	response, err := rp.ImportNodeKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("The node wallet was successfully initialized.")
+	fmt.Printf("Node account: %s\n", response.AccountAddress.Hex())
+	return nil
+
+}
+
+// Decrypt the node private key from an EIP-2335 or Web3 Secret Storage (geth) keystore file
+func loadKeystore(path string, password string) (*ecdsa.PrivateKey, error) {
+
+	// Read the keystore
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding keystore path: %w", err)
+	}
+	keystoreBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keystore: %w", err)
+	}
+	var header keystoreHeader
+	if err := json.Unmarshal(keystoreBytes, &header); err != nil {
+		return nil, fmt.Errorf("error decoding keystore: %w", err)
+	}
+
+	// Get the keystore password
+	if password == "" {
+		password = cliutils.PromptPassword("Please enter the keystore's password:", "^.*$", "")
+	}
+
+	// Decrypt the key
+	switch header.Version {
+	case 3:
+		key, err := keystore.DecryptKey(keystoreBytes, password)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting keystore: %w", err)
+		}
+		return key.PrivateKey, nil
+
+	case 4:
+		keyBytes, err := eth2ks.New().Decrypt(header.Crypto, password)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting keystore: %w", err)
+		}
+		privateKey, err := crypto.ToECDSA(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("the keystore doesn't hold a valid node private key: %w", err)
+		}
+		return privateKey, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported keystore version %d; only EIP-2335 (version 4) and Web3 Secret Storage (version 3) keystores can be imported", header.Version)
+	}
+
+}
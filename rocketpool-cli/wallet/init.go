@@ -76,7 +76,7 @@ func initWallet(c *cli.Context) error {
 	}
 
 	// Do a recover to save the wallet
-	recoverResponse, err := rp.RecoverWallet(response.Mnemonic, true, derivationPath, 0)
+	recoverResponse, err := rp.RecoverWallet(response.Mnemonic, true, derivationPath, 0, nil, nil, 0)
 	if err != nil {
 		return fmt.Errorf("error saving wallet: %w", err)
 	}
@@ -0,0 +1,131 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getPendingTransactions(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the pending transactions
+	response, err := rp.WalletPending()
+	if err != nil {
+		return err
+	}
+
+	// Print the nonces
+	fmt.Printf("Node account:  %s\n", response.AccountAddress.Hex())
+	fmt.Printf("Chain nonce:   %d\n", response.LatestNonce)
+	fmt.Printf("Pending nonce: %d\n\n", response.PendingNonce)
+	if response.PendingNonce > response.LatestNonce {
+		fmt.Printf("The execution client has %d transaction(s) from the node waiting to be included in a block.\n\n", response.PendingNonce-response.LatestNonce)
+	}
+
+	// Print the pending transactions
+	if len(response.PendingTransactions) == 0 {
+		fmt.Println("The node has no pending transactions in its transaction journal.")
+	} else {
+		fmt.Println("Pending transactions:")
+		for _, entry := range response.PendingTransactions {
+			fees := ""
+			if entry.MaxFee != nil && entry.MaxPriorityFee != nil {
+				fees = fmt.Sprintf(", max fee %.2f gwei, priority fee %.2f gwei", eth.WeiToGwei(entry.MaxFee), eth.WeiToGwei(entry.MaxPriorityFee))
+			}
+			fmt.Printf("Nonce %d: %s %s/%s (submitted %s%s)\n", entry.Nonce, entry.Hash.Hex(), entry.Module, entry.Command, entry.SubmittedAt.Format("2006-01-02 15:04:05"), fees)
+		}
+	}
+
+	// Print the gaps
+	if len(response.NonceGaps) > 0 {
+		fmt.Printf("\n%sThe following nonces have no pending transaction, so the transactions after them can't be included until they're filled:%s\n", colorYellow, colorReset)
+		for _, nonce := range response.NonceGaps {
+			fmt.Printf("Nonce %d\n", nonce)
+		}
+		fmt.Println("You can fill a gap with `rocketpool wallet clear-stuck <nonce>`.")
+	}
+	return nil
+
+}
+
+func clearStuckNonce(c *cli.Context, nonce uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the nonce can be cleared
+	canClear, err := rp.CanClearStuckNonce(nonce)
+	if err != nil {
+		return err
+	}
+	if !canClear.CanClear {
+		fmt.Printf("Nonce %d can't be cleared:\n", nonce)
+		if canClear.NonceAlreadyUsed {
+			fmt.Println("A transaction with this nonce has already been included in a block.")
+		}
+		if canClear.NonceTooHigh {
+			fmt.Println("The node doesn't have any transactions waiting on this nonce.")
+		}
+		return nil
+	}
+
+	// Show what's being replaced; the replacement needs higher fees than it to be accepted
+	if len(canClear.StuckTransactions) > 0 {
+		fmt.Printf("This will replace the following transaction(s) with nonce %d:\n", nonce)
+		for _, entry := range canClear.StuckTransactions {
+			fees := ""
+			if entry.MaxFee != nil && entry.MaxPriorityFee != nil {
+				fees = fmt.Sprintf(" (max fee %.2f gwei, priority fee %.2f gwei)", eth.WeiToGwei(entry.MaxFee), eth.WeiToGwei(entry.MaxPriorityFee))
+			}
+			fmt.Printf("%s %s/%s%s\n", entry.Hash.Hex(), entry.Module, entry.Command, fees)
+		}
+		fmt.Printf("%sNOTE: the execution client will only accept the replacement if both its max fee and priority fee are at least 10%% higher than the transaction it replaces.%s\n\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("This will send a zero-value transaction to the node itself with nonce %d.\n\n", nonce)
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canClear.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to clear nonce %d?", nonce))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Clear the nonce
+	response, err := rp.ClearStuckNonce(nonce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Clearing nonce %d...\n", nonce)
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Nonce %d was successfully cleared.\n", nonce)
+	return nil
+
+}
@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getProfiles(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the profiles
+	response, err := rp.WalletProfiles()
+	if err != nil {
+		return err
+	}
+
+	// Print them
+	for _, profile := range response.Profiles {
+		marker := " "
+		if profile.Active {
+			marker = "*"
+		}
+		status := "not initialized"
+		if profile.WalletInitialized {
+			status = "initialized"
+		} else if profile.PasswordSet {
+			status = "password set, wallet not initialized"
+		}
+		fmt.Printf("%s %s (%s)\n", marker, profile.Name, status)
+	}
+	return nil
+
+}
+
+func switchProfile(c *cli.Context, profile string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The node and watchtower daemons will restart to use the '%s' wallet profile, and every command will use it until you switch again. Are you sure you want to switch?", profile))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Switch profiles
+	response, err := rp.SwitchWalletProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Switched to the '%s' wallet profile.\n", profile)
+	if response.WalletInitialized {
+		fmt.Printf("Node account: %s\n", response.AccountAddress.Hex())
+	} else {
+		fmt.Println("This profile doesn't have a node wallet yet; run `rocketpool wallet init` or `rocketpool wallet recover` to create one.")
+	}
+	fmt.Printf("%sNOTE: This profile's validator keys have been moved into the validator keychain in your data folder, and the previous profile's keys have been moved out of it. Restart the validator client with `rocketpool service start` so it loads them.%s\n", colorYellow, colorReset)
+	return nil
+
+}
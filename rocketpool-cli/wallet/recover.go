@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
@@ -142,6 +143,26 @@ func recoverWallet(c *cli.Context) error {
 			fmt.Printf("Using a custom wallet index (%d).\n", walletIndex)
 		}
 
+		// Get the validator keys to recover, if only some of them should be
+		var validatorPubkeys []types.ValidatorPubkey
+		if c.String("validator-pubkeys") != "" {
+			validatorPubkeys, err = cliutils.ValidatePubkeys("validator-pubkeys", c.String("validator-pubkeys"))
+			if err != nil {
+				return err
+			}
+		}
+		var minipools []common.Address
+		if c.String("minipools") != "" {
+			minipools, err = cliutils.ValidateAddresses("minipools", c.String("minipools"))
+			if err != nil {
+				return err
+			}
+		}
+		searchDepth := c.Uint("search-depth")
+		if searchDepth != 0 {
+			fmt.Printf("Searching through %d validator key indices.\n", searchDepth)
+		}
+
 		fmt.Println()
 
 		// Log
@@ -153,11 +174,15 @@ func recoverWallet(c *cli.Context) error {
 			if err != nil {
 				return err
 			}
-			fmt.Println("Recovering node wallet and validator keys...")
+			if len(validatorPubkeys) > 0 || len(minipools) > 0 {
+				fmt.Println("Recovering node wallet and the selected validator keys...")
+			} else {
+				fmt.Println("Recovering node wallet and validator keys...")
+			}
 		}
 
 		// Recover wallet
-		response, err := rp.RecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex)
+		response, err := rp.RecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex, validatorPubkeys, minipools, searchDepth)
 		if err != nil {
 			return err
 		}
@@ -174,6 +199,12 @@ func recoverWallet(c *cli.Context) error {
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
+			if len(response.MissingValidatorKeys) > 0 {
+				fmt.Printf("%sWARNING: the following validator keys could not be recovered within the search depth:%s\n", colorYellow, colorReset)
+				for _, key := range response.MissingValidatorKeys {
+					fmt.Println(key.Hex())
+				}
+			}
 		}
 	}
 
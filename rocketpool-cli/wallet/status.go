@@ -5,6 +5,7 @@ import (
 
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
@@ -30,8 +31,15 @@ func getStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Print the wallet profile if it isn't the default one
+	if status.Profile != "" && status.Profile != config.DefaultWalletProfile {
+		fmt.Printf("Wallet profile: %s\n", status.Profile)
+	}
+
 	// Print status & return
-	if status.WalletInitialized {
+	if status.Locked {
+		fmt.Println("The node wallet is locked. Run `rocketpool wallet unlock` to unlock it.")
+	} else if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
 	} else {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
@@ -41,6 +42,12 @@ func testRecovery(c *cli.Context) error {
 	var mnemonic string
 	if c.String("mnemonic") != "" {
 		mnemonic = c.String("mnemonic")
+	} else if c.String("mnemonic-file") != "" {
+		bytes, err := os.ReadFile(c.String("mnemonic-file"))
+		if err != nil {
+			return fmt.Errorf("error reading mnemonic file: %w", err)
+		}
+		mnemonic = string(bytes)
 	} else {
 		mnemonic = PromptMnemonic()
 	}
@@ -102,14 +109,7 @@ func testRecovery(c *cli.Context) error {
 		fmt.Printf("Wallet index:    %d\n", response.Index)
 		fmt.Printf("Node account:    %s\n", response.AccountAddress.Hex())
 		if !skipValidatorKeyRecovery {
-			if len(response.ValidatorKeys) > 0 {
-				fmt.Println("Validator keys:")
-				for _, key := range response.ValidatorKeys {
-					fmt.Println(key.Hex())
-				}
-			} else {
-				fmt.Println("No validator keys were found.")
-			}
+			return printRecoveryAudit(response.NodeRegistered, response.ValidatorKeys, response.MissingValidatorKeys)
 		}
 
 	} else {
@@ -150,17 +150,38 @@ func testRecovery(c *cli.Context) error {
 		fmt.Println("The node wallet was successfully found - recovery is possible.")
 		fmt.Printf("Node account: %s\n", response.AccountAddress.Hex())
 		if !skipValidatorKeyRecovery {
-			if len(response.ValidatorKeys) > 0 {
-				fmt.Println("Validator keys:")
-				for _, key := range response.ValidatorKeys {
-					fmt.Println(key.Hex())
-				}
-			} else {
-				fmt.Println("No validator keys were found.")
-			}
+			return printRecoveryAudit(response.NodeRegistered, response.ValidatorKeys, response.MissingValidatorKeys)
 		}
 	}
 
 	return nil
 
 }
+
+// Print how the keys regenerated from a mnemonic compare to the node's on-chain registrations, returning an error if any are missing
+func printRecoveryAudit(nodeRegistered bool, validatorKeys []types.ValidatorPubkey, missingValidatorKeys []types.ValidatorPubkey) error {
+	if nodeRegistered {
+		fmt.Println("The node account is registered with Rocket Pool.")
+	} else {
+		fmt.Printf("%sThe node account is not registered with Rocket Pool. If you expected it to be, this is not the mnemonic (or derivation path) for your node.%s\n", colorYellow, colorReset)
+	}
+
+	if len(validatorKeys) > 0 {
+		fmt.Println("Validator keys:")
+		for _, key := range validatorKeys {
+			fmt.Println(key.Hex())
+		}
+	} else if len(missingValidatorKeys) == 0 {
+		fmt.Println("No validator keys were found.")
+	}
+
+	if len(missingValidatorKeys) > 0 {
+		fmt.Printf("%sThe keys for %d of the node's %d minipool validators could not be regenerated:%s\n", colorRed, len(missingValidatorKeys), len(validatorKeys)+len(missingValidatorKeys), colorReset)
+		for _, key := range missingValidatorKeys {
+			fmt.Println(key.Hex())
+		}
+		return fmt.Errorf("%d validator keys could not be regenerated from this mnemonic", len(missingValidatorKeys))
+	}
+	fmt.Printf("%sAll %d of the node's minipool validator keys were regenerated - your backup works.%s\n", colorGreen, len(validatorKeys), colorReset)
+	return nil
+}
@@ -0,0 +1,50 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func unlockWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.Locked {
+		fmt.Println("The node wallet isn't locked.")
+		return nil
+	}
+
+	// Get the password
+	password := c.String("password")
+	if password == "" {
+		password = cliutils.PromptPassword("Please enter the node wallet's password:", "^.+$", "")
+	}
+
+	// Unlock the wallet
+	response, err := rp.UnlockWallet(password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("The node wallet was unlocked.")
+	if len(response.UnlockedDaemons) > 0 {
+		fmt.Printf("Unlocked daemons: %s\n", strings.Join(response.UnlockedDaemons, ", "))
+	}
+	return nil
+
+}
@@ -0,0 +1,121 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getWeb3SignerKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the keys
+	response, err := rp.GetWeb3SignerKeys()
+	if err != nil {
+		return err
+	}
+	if len(response.Keys) == 0 {
+		fmt.Println("Web3Signer doesn't have any validator keys.")
+		return nil
+	}
+
+	// Print them
+	fmt.Printf("Web3Signer has %d validator key(s):\n\n", len(response.Keys))
+	for _, key := range response.Keys {
+		fmt.Printf("0x%s", key.Pubkey.Hex())
+		if key.DerivationPath != "" {
+			fmt.Printf(" (%s)", key.DerivationPath)
+		}
+		if key.ReadOnly {
+			fmt.Print(" [read-only]")
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
+
+func importWeb3SignerKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Warn about slashing protection
+	slashingProtectionPath := c.String("slashing-protection")
+	if slashingProtectionPath == "" {
+		fmt.Printf("%sWARNING: You haven't provided any slashing protection data for these keys.\nIf they have been used for validating anywhere else, you MUST make sure that has stopped for good and import the slashing protection data it exported with `--slashing-protection`, or YOUR VALIDATORS MAY BE SLASHED.%s\n\n", colorYellow, colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to import the node wallet's validator keys into Web3Signer?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Import the keys
+	response, err := rp.ImportWeb3SignerKeys(slashingProtectionPath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d validator key(s) into Web3Signer:\n", len(response.ValidatorKeys))
+	for _, key := range response.ValidatorKeys {
+		fmt.Printf("- 0x%s\n", key.Hex())
+	}
+	return nil
+
+}
+
+func removeWeb3SignerKeys(c *cli.Context, pubkeysString string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Parse the pubkeys
+	pubkeys, err := cliutils.ValidatePubkeys("pubkeys", pubkeysString)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sWARNING: Web3Signer will stop signing for these %d validator key(s), so they will stop attesting until they're imported somewhere else.%s\nAre you sure you want to remove them?", colorYellow, len(pubkeys), colorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remove the keys
+	response, err := rp.RemoveWeb3SignerKeys(pubkeys)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Removed %d validator key(s) from Web3Signer.\n", len(pubkeys))
+	if response.SlashingProtectionPath != "" {
+		fmt.Printf("Their slashing protection data was saved to %s.\nImport it along with the keys wherever you validate with them next.\n", response.SlashingProtectionPath)
+	}
+	return nil
+
+}
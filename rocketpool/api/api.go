@@ -12,7 +12,9 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/api/network"
 	"github.com/rocket-pool/smartnode/rocketpool/api/node"
 	"github.com/rocket-pool/smartnode/rocketpool/api/odao"
+	"github.com/rocket-pool/smartnode/rocketpool/api/pdao"
 	"github.com/rocket-pool/smartnode/rocketpool/api/queue"
+	"github.com/rocket-pool/smartnode/rocketpool/api/security"
 	apiservice "github.com/rocket-pool/smartnode/rocketpool/api/service"
 	"github.com/rocket-pool/smartnode/rocketpool/api/wallet"
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -64,7 +66,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 	network.RegisterSubcommands(&command, "network", []string{"e"})
 	node.RegisterSubcommands(&command, "node", []string{"n"})
 	odao.RegisterSubcommands(&command, "odao", []string{"o"})
+	pdao.RegisterSubcommands(&command, "pdao", []string{"p"})
 	queue.RegisterSubcommands(&command, "queue", []string{"q"})
+	security.RegisterSubcommands(&command, "security", []string{"c"})
 	wallet.RegisterSubcommands(&command, "wallet", []string{"w"})
 	apiservice.RegisterSubcommands(&command, "service", []string{"s"})
 	debug.RegisterSubcommands(&command, "debug", []string{"d"})
@@ -86,11 +90,76 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			}
 
 			// Run
-			api.PrintResponse(waitForTransaction(c, hash))
+			api.GetResponsePrinter(c).PrintResponse(waitForTransaction(c, hash))
 			return nil
 		},
 	})
 
+	// Append the batch command, which runs several commands in one invocation
+	command.Subcommands = append(command.Subcommands, cli.Command{
+		Name:      "batch",
+		Usage:     "Run a JSON array of API commands in order and return an array of their responses. Consecutive read-only commands run concurrently. Each command is an object with a `command` path (such as [\"node\", \"status\"]) and optional `args`, `flags`, `globalFlags`, and the `version` of the command the request was built for.",
+		UsageText: "rocketpool api batch commands-json",
+		Action: func(c *cli.Context) error {
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 1); err != nil {
+				return err
+			}
+
+			// Run
+			api.GetResponsePrinter(c).PrintResponse(runBatch(c, app, &command, c.Args().Get(0)))
+			return nil
+		},
+	})
+
+	// Append the server command, which serves the other commands over HTTP
+	command.Subcommands = append(command.Subcommands, cli.Command{
+		Name:      "server",
+		Usage:     "Serve the API commands over HTTP on a Unix socket and / or a localhost port",
+		UsageText: "rocketpool api server [options]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "socket",
+				Usage: "The Unix socket `path` to serve the API on (defaults to the socket in the data folder)",
+			},
+			cli.UintFlag{
+				Name:  "port",
+				Usage: "The localhost `port` to serve the API on (0 to disable)",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 0); err != nil {
+				return err
+			}
+
+			// Run
+			return runServer(c, app, &command)
+		},
+	})
+
+	// Record submitted transactions in the journal, and refresh the wallet's gas settings for each command when running several in one process.
+	// Read-only commands can run concurrently in one process, so they skip both.
+	// The journal writer wraps the writer of this invocation's app, which the command's app inherits, so each invocation has its own.
+	command.Before = func(c *cli.Context) error {
+		args := c.Args()
+		if isReadOnlyArgs(&command, args) {
+			return nil
+		}
+		if len(args) >= 2 && !inProcessExcludedCommands[args[0]] {
+			c.App.Writer = &txJournalWriter{
+				c:       c,
+				next:    c.App.Writer,
+				module:  args[0],
+				command: args[1],
+			}
+		}
+		if !isRunningInProcess(c) {
+			return nil
+		}
+		return services.UpdateWalletGasSettings(c)
+	}
+
 	// Register CLI command
 	app.Commands = append(app.Commands, command)
 
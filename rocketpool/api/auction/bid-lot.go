@@ -85,6 +85,7 @@ func canBidOnLot(c *cli.Context, lotIndex uint64, amountWei *big.Int) (*api.CanB
 		if err == nil {
 			response.GasInfo = gasInfo
 		}
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -94,7 +95,7 @@ func canBidOnLot(c *cli.Context, lotIndex uint64, amountWei *big.Int) (*api.CanB
 	}
 
 	// Update & return response
-	response.CanBid = !(response.DoesNotExist || response.BiddingEnded || response.RPLExhausted || response.BidOnLotDisabled)
+	response.CanBid = !(response.DoesNotExist || response.BiddingEnded || response.RPLExhausted || response.BidOnLotDisabled || response.RevertReason != "")
 	return &response, nil
 
 }
@@ -78,6 +78,7 @@ func canClaimFromLot(c *cli.Context, lotIndex uint64) (*api.CanClaimFromLotRespo
 		if err == nil {
 			response.GasInfo = gasInfo
 		}
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -87,7 +88,7 @@ func canClaimFromLot(c *cli.Context, lotIndex uint64) (*api.CanClaimFromLotRespo
 	}
 
 	// Update & return response
-	response.CanClaim = !(response.DoesNotExist || response.NoBidFromAddress || response.NotCleared)
+	response.CanClaim = !(response.DoesNotExist || response.NoBidFromAddress || response.NotCleared || response.RevertReason != "")
 	return &response, nil
 
 }
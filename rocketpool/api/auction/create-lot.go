@@ -65,6 +65,7 @@ func canCreateLot(c *cli.Context) (*api.CanCreateLotResponse, error) {
 		if err == nil {
 			response.GasInfo = gasInfo
 		}
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -74,7 +75,7 @@ func canCreateLot(c *cli.Context) (*api.CanCreateLotResponse, error) {
 	}
 
 	// Update & return response
-	response.CanCreate = !(response.InsufficientBalance || response.CreateLotDisabled)
+	response.CanCreate = !(response.InsufficientBalance || response.CreateLotDisabled || response.RevertReason != "")
 	return &response, nil
 
 }
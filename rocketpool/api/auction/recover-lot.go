@@ -83,6 +83,7 @@ func canRecoverRplFromLot(c *cli.Context, lotIndex uint64) (*api.CanRecoverRPLFr
 		if err == nil {
 			response.GasInfo = gasInfo
 		}
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -92,7 +93,7 @@ func canRecoverRplFromLot(c *cli.Context, lotIndex uint64) (*api.CanRecoverRPLFr
 	}
 
 	// Update & return response
-	response.CanRecover = !(response.DoesNotExist || response.BiddingNotEnded || response.NoUnclaimedRPL || response.RPLAlreadyRecovered)
+	response.CanRecover = !(response.DoesNotExist || response.BiddingNotEnded || response.NoUnclaimedRPL || response.RPLAlreadyRecovered || response.RevertReason != "")
 	return &response, nil
 
 }
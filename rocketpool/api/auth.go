@@ -0,0 +1,76 @@
+package api
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A token that grants API server clients a role
+type roleToken struct {
+	token string
+	role  cfgtypes.ApiRole
+}
+
+// Parse the API role tokens setting, which is a comma-separated list of role=token pairs
+func parseRoleTokens(setting string) ([]roleToken, error) {
+	tokens := []roleToken{}
+	for _, entry := range strings.Split(setting, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		elements := strings.SplitN(entry, "=", 2)
+		if len(elements) != 2 || strings.TrimSpace(elements[1]) == "" {
+			return nil, fmt.Errorf("invalid API role token '%s': expected role=token", entry)
+		}
+		role := cfgtypes.ApiRole(strings.ToLower(strings.TrimSpace(elements[0])))
+		if role != cfgtypes.ApiRole_Monitor && role != cfgtypes.ApiRole_Admin {
+			return nil, fmt.Errorf("invalid API role '%s': expected monitor or admin", role)
+		}
+		tokens = append(tokens, roleToken{
+			token: strings.TrimSpace(elements[1]),
+			role:  role,
+		})
+	}
+	return tokens, nil
+}
+
+// Get the role of a request's client, and whether its token (if it provided one) was valid.
+// The token is a bearer token, or a query parameter for websocket clients that can't set headers.
+func (s *apiServer) getRole(r *http.Request) (cfgtypes.ApiRole, bool) {
+	token := r.URL.Query().Get("token")
+	header := r.Header.Get("Authorization")
+	if strings.HasPrefix(header, "Bearer ") {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+	if token == "" {
+		return s.unauthenticatedRole, true
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+		return cfgtypes.ApiRole_Admin, true
+	}
+	for _, roleToken := range s.roleTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(roleToken.token)) == 1 {
+			return roleToken.role, true
+		}
+	}
+	return cfgtypes.ApiRole_None, false
+}
+
+// Check if a role can run the command with the given full path (or subscribe to events, if path is nil)
+func isRoleAllowed(role cfgtypes.ApiRole, path []string) bool {
+	switch role {
+	case cfgtypes.ApiRole_Admin:
+		return true
+	case cfgtypes.ApiRole_Monitor:
+		return path == nil || apitypes.IsReadOnlyCommand(path)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/urfave/cli"
+
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of read-only commands a batch runs at once
+const MaxConcurrentBatchCommands int = 8
+
+// Run a batch of API commands in one process, so services are only initialized once
+func runBatch(c *cli.Context, app *cli.App, command *cli.Command, commandsJson string) (*apitypes.BatchResponse, error) {
+
+	// Read the commands from stdin if requested
+	if commandsJson == "-" {
+		commandsBytes, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("error reading commands from stdin: %w", err)
+		}
+		commandsJson = string(commandsBytes)
+	}
+
+	// Parse the commands
+	var requests []commandRequest
+	if err := json.Unmarshal([]byte(commandsJson), &requests); err != nil {
+		return nil, fmt.Errorf("error parsing commands: %w", err)
+	}
+
+	// Response
+	response := apitypes.BatchResponse{
+		Responses: make([]json.RawMessage, len(requests)),
+	}
+
+	// Build the command lines
+	commandLines := make([][]string, len(requests))
+	readOnly := make([]bool, len(requests))
+	for i, request := range requests {
+		var args []string
+		var err error
+		path, exists := resolveCommand(command, request.Command)
+		if !exists {
+			err = fmt.Errorf("unknown command %v", request.Command)
+		} else if err = checkCommandVersion(path, request); err == nil {
+			args, err = buildCommandLine(app, command, path, request)
+		}
+		if err != nil {
+			response.Responses[i] = getErrorResponse(err)
+			continue
+		}
+		commandLines[i] = args
+		readOnly[i] = apitypes.IsReadOnlyCommand(path)
+	}
+
+	// Run the commands in order; consecutive read-only commands run concurrently, and every other command runs on its own
+	for i := 0; i < len(requests); {
+		if commandLines[i] == nil {
+			i++
+			continue
+		}
+		if !readOnly[i] {
+			response.Responses[i] = runCommandInProcess(app, commandLines[i])
+			i++
+			continue
+		}
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, MaxConcurrentBatchCommands)
+		for ; i < len(requests) && (commandLines[i] == nil || readOnly[i]); i++ {
+			if commandLines[i] == nil {
+				continue
+			}
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(i int) {
+				defer wg.Done()
+				response.Responses[i] = runCommandInProcess(app, commandLines[i])
+				<-slots
+			}(i)
+		}
+		wg.Wait()
+	}
+	for i, request := range requests {
+		if len(response.Responses[i]) == 0 {
+			response.Responses[i] = getErrorResponse(fmt.Errorf("command %v did not return a response", request.Command))
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the encoded error response for a command that couldn't be run
+func getErrorResponse(err error) json.RawMessage {
+	response, _ := json.Marshal(apitypes.APIResponse{
+		Status: "error",
+		Error:  err.Error(),
+	})
+	return response
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	EventPollInterval      = 15 * time.Second
+	EventWriteTimeout      = 10 * time.Second
+	EventSubscriberBacklog = 64
+)
+
+var eventUpgrader = websocket.Upgrader{}
+
+// Fans events out to the API server's event stream subscribers
+type eventHub struct {
+	subscribers map[chan apitypes.Event]struct{}
+	lock        sync.Mutex
+}
+
+// Create a new event hub
+func newEventHub() *eventHub {
+	return &eventHub{
+		subscribers: map[chan apitypes.Event]struct{}{},
+	}
+}
+
+// Add a subscriber
+func (h *eventHub) subscribe() chan apitypes.Event {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	events := make(chan apitypes.Event, EventSubscriberBacklog)
+	h.subscribers[events] = struct{}{}
+	return events
+}
+
+// Remove a subscriber
+func (h *eventHub) unsubscribe(events chan apitypes.Event) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.subscribers, events)
+}
+
+// Publish an event to all subscribers; subscribers that have fallen too far behind miss it
+func (h *eventHub) publish(eventType apitypes.EventType, data interface{}) {
+	event := apitypes.Event{
+		Type: eventType,
+		Time: time.Now(),
+		Data: data,
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for events := range h.subscribers {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// Serve the event stream to a websocket client
+func (s *apiServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+
+	conn, err := eventUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	events := s.events.subscribe()
+	defer s.events.unsubscribe(events)
+
+	// Read from the client so closes are noticed
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event := <-events:
+			_ = conn.SetWriteDeadline(time.Now().Add(EventWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+
+}
+
+// Publish a confirmation event once the transaction in a command response (if there is one) has been mined
+func (s *apiServer) watchResponseTransaction(response []byte) {
+
+	txHash, exists := api.GetResponseTxHash(response)
+	if !exists {
+		return
+	}
+
+	go func() {
+		s.lock.RLock()
+		rp, err := services.GetRocketPool(s.c)
+		s.lock.RUnlock()
+		if err != nil {
+			s.log.Printlnf("Error watching transaction %s: %s", txHash.Hex(), err.Error())
+			return
+		}
+		event := apitypes.TransactionConfirmedEvent{
+			TxHash: txHash,
+		}
+		receipt, err := utils.WaitForTransaction(rp.Client, txHash)
+		if err != nil {
+			event.Error = err.Error()
+		} else {
+			event.BlockNumber = receipt.BlockNumber.Uint64()
+			event.Success = (receipt.Status == types.ReceiptStatusSuccessful)
+		}
+		s.events.publish(apitypes.EventType_TransactionConfirmed, event)
+	}()
+
+}
+
+// Polls the node and network state, publishing an event for each change
+type eventPoller struct {
+	c      *cli.Context
+	events *eventHub
+	log    log.ColorLogger
+
+	// The server's command lock; commands that change state also change the shared services while they run, so polls
+	// can't overlap them
+	lock *sync.RWMutex
+
+	initialized      bool
+	minipoolStatuses map[common.Address]string
+	rewardsIndex     uint64
+	ecSynced         bool
+	bcSynced         bool
+}
+
+// Run the poller until the process exits
+func (p *eventPoller) run() {
+	p.minipoolStatuses = map[common.Address]string{}
+	for {
+		p.lock.RLock()
+		err := p.poll()
+		p.lock.RUnlock()
+		if err != nil {
+			p.log.Printlnf("Error checking for events: %s", err.Error())
+		}
+		p.initialized = true
+		time.Sleep(EventPollInterval)
+	}
+}
+
+// Check for changes since the last poll
+func (p *eventPoller) poll() error {
+
+	// Get services
+	cfg, err := services.GetConfig(p.c)
+	if err != nil {
+		return err
+	}
+	ecMgr, err := services.GetEthClient(p.c)
+	if err != nil {
+		return err
+	}
+	bcMgr, err := services.GetBeaconClient(p.c)
+	if err != nil {
+		return err
+	}
+
+	// Check the sync status
+	ecStatus := ecMgr.CheckStatus(cfg)
+	bcStatus := bcMgr.CheckStatus()
+	ecSynced := isClientManagerSynced(ecStatus)
+	bcSynced := isClientManagerSynced(bcStatus)
+	if !p.initialized || ecSynced != p.ecSynced || bcSynced != p.bcSynced {
+		p.events.publish(apitypes.EventType_SyncStatus, apitypes.SyncStatusEvent{
+			EcStatus: *ecStatus,
+			BcStatus: *bcStatus,
+		})
+		p.ecSynced = ecSynced
+		p.bcSynced = bcSynced
+	}
+	if !ecSynced {
+		return nil
+	}
+
+	rp, err := services.GetRocketPool(p.c)
+	if err != nil {
+		return err
+	}
+
+	// Check the rewards interval
+	rewardsIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return fmt.Errorf("error getting rewards index: %w", err)
+	}
+	rewardsIndex := rewardsIndexBig.Uint64()
+	if p.initialized && rewardsIndex != p.rewardsIndex {
+		p.events.publish(apitypes.EventType_RewardsInterval, apitypes.RewardsIntervalEvent{
+			PreviousIndex: p.rewardsIndex,
+			Index:         rewardsIndex,
+		})
+	}
+	p.rewardsIndex = rewardsIndex
+
+	// Check the minipool statuses, if the node has been registered
+	w, err := services.GetWallet(p.c)
+	if err != nil {
+		return err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting node minipool addresses: %w", err)
+	}
+	for _, address := range addresses {
+		mp, err := minipool.NewMinipool(rp, address, nil)
+		if err != nil {
+			return fmt.Errorf("error creating binding for minipool %s: %w", address.Hex(), err)
+		}
+		status, err := mp.GetStatus(nil)
+		if err != nil {
+			return fmt.Errorf("error getting status of minipool %s: %w", address.Hex(), err)
+		}
+		previousStatus, exists := p.minipoolStatuses[address]
+		if p.initialized && (!exists || previousStatus != status.String()) {
+			p.events.publish(apitypes.EventType_MinipoolStatus, apitypes.MinipoolStatusEvent{
+				Address:        address,
+				PreviousStatus: previousStatus,
+				Status:         status.String(),
+			})
+		}
+		p.minipoolStatuses[address] = status.String()
+	}
+
+	return nil
+
+}
+
+// Check if the active client of a manager is synced
+func isClientManagerSynced(status *apitypes.ClientManagerStatus) bool {
+	if status.PrimaryClientStatus.IsWorking {
+		return status.PrimaryClientStatus.IsSynced
+	}
+	return status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced
+}
@@ -28,7 +28,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					api.GetResponsePrinter(c).PrintResponse(getStatus(c))
 					return nil
 
 				},
@@ -46,7 +46,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canWithdrawRpl(c))
+					api.GetResponsePrinter(c).PrintResponse(canWithdrawRpl(c))
 					return nil
 
 				},
@@ -64,7 +64,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(withdrawRpl(c))
+					api.GetResponsePrinter(c).PrintResponse(withdrawRpl(c))
 					return nil
 
 				},
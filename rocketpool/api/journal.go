@@ -0,0 +1,47 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+// The source API transactions are recorded under in the transaction journal
+const journalSource string = "api"
+
+// Passes API responses through to the next writer, recording any transactions they submitted in the transaction journal
+type txJournalWriter struct {
+	c       *cli.Context
+	next    io.Writer
+	module  string
+	command string
+}
+
+func (w *txJournalWriter) Write(p []byte) (int, error) {
+	if txHash, exists := api.GetResponseTxHash(p); exists {
+		if err := w.record(txHash); err != nil {
+			// The transaction has already been submitted, so don't fail the command because it couldn't be journaled
+			fmt.Fprintf(os.Stderr, "WARNING: couldn't record transaction %s in the journal: %s\n", txHash.Hex(), err.Error())
+		}
+	}
+	return w.next.Write(p)
+}
+
+// Record a transaction in the journal
+func (w *txJournalWriter) record(hash common.Hash) error {
+	journal, err := services.GetTxJournal(w.c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(w.c)
+	if err != nil {
+		return err
+	}
+	return journal.RecordSubmission(rp.Client, journalSource, w.module, w.command, hash)
+}
@@ -52,9 +52,11 @@ func canDelegateUpgrade(c *cli.Context, minipoolAddress common.Address) (*api.Ca
 		return nil, err
 	}
 	gasInfo, err := mp.EstimateDelegateUpgradeGas(opts)
-	if err == nil {
-		response.GasInfo = gasInfo
+	response.RevertReason, err = services.GetRevertReason(err)
+	if err != nil {
+		return nil, err
 	}
+	response.GasInfo = gasInfo
 
 	// Return response
 	return &response, nil
@@ -165,9 +167,11 @@ func canDelegateRollback(c *cli.Context, minipoolAddress common.Address) (*api.C
 		return nil, err
 	}
 	gasInfo, err := mp.EstimateDelegateRollbackGas(opts)
-	if err == nil {
-		response.GasInfo = gasInfo
+	response.RevertReason, err = services.GetRevertReason(err)
+	if err != nil {
+		return nil, err
 	}
+	response.GasInfo = gasInfo
 
 	// Return response
 	return &response, nil
@@ -306,9 +310,11 @@ func canSetUseLatestDelegate(c *cli.Context, minipoolAddress common.Address, set
 		return nil, err
 	}
 	gasInfo, err := mp.EstimateSetUseLatestDelegateGas(setting, opts)
-	if err == nil {
-		response.GasInfo = gasInfo
+	response.RevertReason, err = services.GetRevertReason(err)
+	if err != nil {
+		return nil, err
 	}
+	response.GasInfo = gasInfo
 
 	// Return response
 	return &response, nil
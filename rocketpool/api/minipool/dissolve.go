@@ -31,25 +31,25 @@ func canDissolveMinipool(c *cli.Context, minipoolAddress common.Address) (*api.C
 	// Response
 	response := api.CanDissolveMinipoolResponse{}
 
-	// Create minipool
-	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Validate minipool owner
+	// Get the minipool and validate its owner
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
 		return nil, err
 	}
-	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+	mp, mpd, _, err := getNodeMinipool(c, rp, nodeAccount.Address, minipoolAddress)
+	if err != nil {
 		return nil, err
 	}
 
 	// Check minipool status
-	status, err := mp.GetStatus(nil)
-	if err != nil {
-		return nil, err
+	var status types.MinipoolStatus
+	if mpd != nil {
+		status = mpd.Status
+	} else {
+		status, err = mp.GetStatus(nil)
+		if err != nil {
+			return nil, err
+		}
 	}
 	response.InvalidStatus = !(status == types.Initialized || status == types.Prelaunch)
 
@@ -59,12 +59,14 @@ func canDissolveMinipool(c *cli.Context, minipoolAddress common.Address) (*api.C
 		return nil, err
 	}
 	gasInfo, err := mp.EstimateDissolveGas(opts)
-	if err == nil {
-		response.GasInfo = gasInfo
+	response.RevertReason, err = services.GetRevertReason(err)
+	if err != nil {
+		return nil, err
 	}
+	response.GasInfo = gasInfo
 
 	// Update & return response
-	response.CanDissolve = !response.InvalidStatus
+	response.CanDissolve = !(response.InvalidStatus || response.RevertReason != "")
 	return &response, nil
 
 }
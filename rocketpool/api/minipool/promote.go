@@ -35,42 +35,51 @@ func canPromoteMinipool(c *cli.Context, minipoolAddress common.Address) (*api.Ca
 		CanPromote: false,
 	}
 
-	// Create minipool
-	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Validate minipool owner
+	// Get the minipool and validate its owner
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
 		return nil, err
 	}
-	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
-		return nil, err
-	}
-
-	// Check the minipool's status
-	status, err := mp.GetStatusDetails(nil)
+	mp, mpd, cachedState, err := getNodeMinipool(c, rp, nodeAccount.Address, minipoolAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	if status.IsVacant {
-
-		// Get the scrub period
-		scrubPeriodSeconds, err := trustednode.GetPromotionScrubPeriod(rp, nil)
+	// Check the minipool's status
+	var status minipool.StatusDetails
+	if mpd != nil {
+		status.Status = mpd.Status
+		status.StatusBlock = mpd.StatusBlock.Uint64()
+		status.StatusTime = time.Unix(mpd.StatusTime.Int64(), 0)
+		status.IsVacant = mpd.IsVacant
+	} else {
+		status, err = mp.GetStatusDetails(nil)
 		if err != nil {
 			return nil, err
 		}
-		scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
+	}
 
-		// Get the time of the latest block
-		latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
-		if err != nil {
-			return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+	if status.IsVacant {
+
+		// Get the scrub period and the time of the latest block; the cached state is recent enough to use the current time instead
+		var scrubPeriod time.Duration
+		var latestBlockTime time.Time
+		if cachedState != nil {
+			scrubPeriod = cachedState.NetworkDetails.PromotionScrubPeriod
+			latestBlockTime = time.Now()
+		} else {
+			scrubPeriodSeconds, err := trustednode.GetPromotionScrubPeriod(rp, nil)
+			if err != nil {
+				return nil, err
+			}
+			scrubPeriod = time.Duration(scrubPeriodSeconds) * time.Second
+
+			latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+			}
+			latestBlockTime = time.Unix(int64(latestEth1Block.Time), 0)
 		}
-		latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
 		creationTime := status.StatusTime
 		remainingTime := creationTime.Add(scrubPeriod).Sub(latestBlockTime)
@@ -99,10 +108,12 @@ func canPromoteMinipool(c *cli.Context, minipoolAddress common.Address) (*api.Ca
 
 		// Get the gas limit
 		gasInfo, err := mpv3.EstimatePromoteGas(opts)
+		response.RevertReason, err = services.GetRevertReason(err)
 		if err != nil {
 			return nil, fmt.Errorf("Could not estimate the gas required to promote the minipool: %w", err)
 		}
 		response.GasInfo = gasInfo
+		response.CanPromote = (response.RevertReason == "")
 
 	}
 
@@ -114,9 +114,12 @@ func canBeginReduceBondAmount(c *cli.Context, minipoolAddress common.Address, ne
 		return nil, err
 	}
 	gasInfo, err := minipool.EstimateBeginReduceBondAmountGas(rp, minipoolAddress, newBondAmountWei, opts)
-	if err == nil {
-		response.GasInfo = gasInfo
+	response.RevertReason, err = services.GetRevertReason(err)
+	if err != nil {
+		return nil, err
 	}
+	response.GasInfo = gasInfo
+	response.CanReduce = (response.CanReduce && response.RevertReason == "")
 
 	// Update & return response
 	return &response, nil
@@ -193,12 +196,14 @@ func canReduceBondAmount(c *cli.Context, minipoolAddress common.Address) (*api.C
 			return nil, err
 		}
 		gasInfo, err := mpv3.EstimateReduceBondAmountGas(opts)
-		if err == nil {
-			response.GasInfo = gasInfo
+		response.RevertReason, err = services.GetRevertReason(err)
+		if err != nil {
+			return nil, err
 		}
+		response.GasInfo = gasInfo
 	}
 
-	response.CanReduce = success
+	response.CanReduce = (success && response.RevertReason == "")
 
 	// Update & return response
 	return &response, nil
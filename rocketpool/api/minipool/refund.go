@@ -31,25 +31,25 @@ func canRefundMinipool(c *cli.Context, minipoolAddress common.Address) (*api.Can
 	// Response
 	response := api.CanRefundMinipoolResponse{}
 
-	// Create minipool
-	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Validate minipool owner
+	// Get the minipool and validate its owner
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
 		return nil, err
 	}
-	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+	mp, mpd, _, err := getNodeMinipool(c, rp, nodeAccount.Address, minipoolAddress)
+	if err != nil {
 		return nil, err
 	}
 
 	// Check node refund balance
-	refundBalance, err := mp.GetNodeRefundBalance(nil)
-	if err != nil {
-		return nil, err
+	var refundBalance *big.Int
+	if mpd != nil {
+		refundBalance = mpd.NodeRefundBalance
+	} else {
+		refundBalance, err = mp.GetNodeRefundBalance(nil)
+		if err != nil {
+			return nil, err
+		}
 	}
 	response.InsufficientRefundBalance = (refundBalance.Cmp(big.NewInt(0)) == 0)
 
@@ -59,12 +59,14 @@ func canRefundMinipool(c *cli.Context, minipoolAddress common.Address) (*api.Can
 		return nil, err
 	}
 	gasInfo, err := mp.EstimateRefundGas(opts)
-	if err == nil {
-		response.GasInfo = gasInfo
+	response.RevertReason, err = services.GetRevertReason(err)
+	if err != nil {
+		return nil, err
 	}
+	response.GasInfo = gasInfo
 
 	// Update & return response
-	response.CanRefund = !response.InsufficientRefundBalance
+	response.CanRefund = !(response.InsufficientRefundBalance || response.RevertReason != "")
 	return &response, nil
 
 }
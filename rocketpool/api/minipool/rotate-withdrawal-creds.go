@@ -0,0 +1,223 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func getBlsCredsMinipools(c *cli.Context) (*api.GetBlsCredsMinipoolsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetBlsCredsMinipoolsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's minipools and their validators
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node minipool addresses: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, 0, len(addresses))
+	pubkeyAddresses := map[types.ValidatorPubkey]common.Address{}
+	for _, address := range addresses {
+		pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting pubkey for minipool %s: %w", address.Hex(), err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+		pubkeyAddresses[pubkey] = address
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+
+	// Find the ones still using BLS withdrawal credentials
+	response.Minipools = []api.BlsCredsMinipool{}
+	for _, pubkey := range pubkeys {
+		status, exists := statuses[pubkey]
+		if !exists || !status.Exists || status.WithdrawalCredentials[0] != 0x00 {
+			continue
+		}
+		response.Minipools = append(response.Minipools, api.BlsCredsMinipool{
+			Address:               pubkeyAddresses[pubkey],
+			Pubkey:                pubkey,
+			ValidatorIndex:        status.Index,
+			WithdrawalCredentials: status.WithdrawalCredentials,
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func rotateWithdrawalCreds(c *cli.Context, mnemonic string, minipoolAddresses []common.Address) (*api.RotateWithdrawalCredsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RotateWithdrawalCredsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the signature domain, which always uses the genesis fork version
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	signatureDomain, err := bc.GetDomainData(eth2types.DomainBlsToExecutionChange[:], head.Epoch, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive the mnemonic's validator keys once for every minipool
+	keyIndices, err := getValidatorKeyIndices(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rotate each minipool's credentials, recording why any of them couldn't be
+	response.Rotations = make([]api.WithdrawalCredsRotation, len(minipoolAddresses))
+	for i, address := range minipoolAddresses {
+		rotation := &response.Rotations[i]
+		rotation.Address = address
+		rotation.Pubkey, err = rotateMinipoolWithdrawalCreds(rp, bc, nodeAccount.Address, address, mnemonic, keyIndices, signatureDomain)
+		if err != nil {
+			rotation.FailureReason = err.Error()
+			continue
+		}
+		rotation.Rotated = true
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Change a minipool validator's BLS withdrawal credentials to the minipool address, returning the validator's pubkey
+func rotateMinipoolWithdrawalCreds(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, minipoolAddress common.Address, mnemonic string, keyIndices map[types.ValidatorPubkey]uint, signatureDomain []byte) (types.ValidatorPubkey, error) {
+
+	// Validate minipool owner
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return types.ValidatorPubkey{}, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAddress); err != nil {
+		return types.ValidatorPubkey{}, err
+	}
+
+	// Check the validator's current creds
+	pubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return types.ValidatorPubkey{}, fmt.Errorf("couldn't get the pubkey for minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	status, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return pubkey, fmt.Errorf("error getting Beacon status for minipool %s (pubkey %s): %w", minipoolAddress.Hex(), pubkey.Hex(), err)
+	}
+	if !status.Exists {
+		return pubkey, fmt.Errorf("minipool %s (pubkey %s) doesn't have a validator on the Beacon Chain yet", minipoolAddress.Hex(), pubkey.Hex())
+	}
+	if status.WithdrawalCredentials[0] != 0x00 {
+		return pubkey, fmt.Errorf("minipool %s (pubkey %s) is already using the withdrawal credentials %s", minipoolAddress.Hex(), pubkey.Hex(), status.WithdrawalCredentials.Hex())
+	}
+
+	// Get the withdrawal key for the validator and make sure it matches what's on Beacon
+	index, exists := keyIndices[pubkey]
+	if !exists {
+		return pubkey, fmt.Errorf("couldn't find the validator key for minipool %s (pubkey %s) in this mnemonic after %d tries", minipoolAddress.Hex(), pubkey.Hex(), validatorLimit)
+	}
+	withdrawalKey, err := validator.GetWithdrawalKey(mnemonic, index, validator.ValidatorKeyPath)
+	if err != nil {
+		return pubkey, err
+	}
+	withdrawalPubkeyHash := common.BytesToHash(util.SHA256(withdrawalKey.PublicKey().Marshal())) // Withdrawal creds use sha256, *not* Keccak
+	withdrawalPubkeyHash[0] = 0x00                                                               // BLS prefix
+	if status.WithdrawalCredentials != withdrawalPubkeyHash {
+		return pubkey, fmt.Errorf("withdrawal credentials mismatch for minipool %s (pubkey %s): should be %s but matching index %d provided %s", minipoolAddress.Hex(), pubkey.Hex(), status.WithdrawalCredentials.Hex(), index, withdrawalPubkeyHash.Hex())
+	}
+
+	// Sign and broadcast the change message
+	signature, err := validator.GetSignedWithdrawalCredsChangeMessage(withdrawalKey, status.Index, minipoolAddress, signatureDomain)
+	if err != nil {
+		return pubkey, err
+	}
+	withdrawalPubkey := types.BytesToValidatorPubkey(withdrawalKey.PublicKey().Marshal())
+	if err := bc.ChangeWithdrawalCredentials(status.Index, withdrawalPubkey, minipoolAddress, signature); err != nil {
+		return pubkey, fmt.Errorf("error broadcasting the withdrawal credentials change for minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	return pubkey, nil
+
+}
+
+// Get the index of each validator key a mnemonic derives, up to the validator limit
+func getValidatorKeyIndices(mnemonic string) (map[types.ValidatorPubkey]uint, error) {
+	indices := make(map[types.ValidatorPubkey]uint, validatorLimit)
+	for index := uint(0); index < validatorLimit; index++ {
+		key, err := validator.GetPrivateKey(mnemonic, index, validator.ValidatorKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving key for index %d: %w", index, err)
+		}
+		indices[types.BytesToValidatorPubkey(key.PublicKey().Marshal())] = index
+	}
+	return indices, nil
+}
@@ -42,42 +42,51 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 		CanStake: false,
 	}
 
-	// Create minipool
-	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Validate minipool owner
+	// Get the minipool and validate its owner
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
 		return nil, err
 	}
-	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
-		return nil, err
-	}
-
-	// Check the minipool's status
-	status, err := mp.GetStatusDetails(nil)
+	mp, mpd, cachedState, err := getNodeMinipool(c, rp, nodeAccount.Address, minipoolAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	if status.Status == rptypes.Prelaunch {
-
-		// Get the scrub period
-		scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
+	// Check the minipool's status
+	var status minipool.StatusDetails
+	if mpd != nil {
+		status.Status = mpd.Status
+		status.StatusBlock = mpd.StatusBlock.Uint64()
+		status.StatusTime = time.Unix(mpd.StatusTime.Int64(), 0)
+		status.IsVacant = mpd.IsVacant
+	} else {
+		status, err = mp.GetStatusDetails(nil)
 		if err != nil {
 			return nil, err
 		}
-		scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
+	}
 
-		// Get the time of the latest block
-		latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
-		if err != nil {
-			return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+	if status.Status == rptypes.Prelaunch {
+
+		// Get the scrub period and the time of the latest block; the cached state is recent enough to use the current time instead
+		var scrubPeriod time.Duration
+		var latestBlockTime time.Time
+		if cachedState != nil {
+			scrubPeriod = cachedState.NetworkDetails.ScrubPeriod
+			latestBlockTime = time.Now()
+		} else {
+			scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
+			if err != nil {
+				return nil, err
+			}
+			scrubPeriod = time.Duration(scrubPeriodSeconds) * time.Second
+
+			latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+			}
+			latestBlockTime = time.Unix(int64(latestEth1Block.Time), 0)
 		}
-		latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
 		creationTime := status.StatusTime
 		remainingTime := creationTime.Add(scrubPeriod).Sub(latestBlockTime)
@@ -93,37 +102,45 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 			return nil, err
 		}
 
-		// Get minipool withdrawal credentials
-		withdrawalCredentials, err := minipool.GetMinipoolWithdrawalCredentials(rp, mp.GetAddress(), nil)
-		if err != nil {
-			return nil, err
+		// Get minipool withdrawal credentials, validator pubkey, and type
+		var withdrawalCredentials common.Hash
+		var validatorPubkey rptypes.ValidatorPubkey
+		var depositType rptypes.MinipoolDeposit
+		if mpd != nil {
+			withdrawalCredentials = mpd.WithdrawalCredentials
+			validatorPubkey = mpd.Pubkey
+			depositType = mpd.DepositType
+		} else {
+			withdrawalCredentials, err = minipool.GetMinipoolWithdrawalCredentials(rp, mp.GetAddress(), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			validatorPubkey, err = minipool.GetMinipoolPubkey(rp, mp.GetAddress(), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			isAtlasDeployed, err := state.IsAtlasDeployed(rp, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error checking if Atlas is deployed: %w", err)
+			}
+			if !isAtlasDeployed {
+				depositType, err = mp.GetDepositType(nil)
+			} else {
+				depositType, err = minipool.GetMinipoolDepositType(rp, mp.GetAddress(), nil)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error getting deposit type for minipool %s: %w", mp.GetAddress().Hex(), err)
+			}
 		}
 
 		// Get the validator key for the minipool
-		validatorPubkey, err := minipool.GetMinipoolPubkey(rp, mp.GetAddress(), nil)
-		if err != nil {
-			return nil, err
-		}
 		validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
 		if err != nil {
 			return nil, err
 		}
 
-		// Get the minipool type
-		var depositType rptypes.MinipoolDeposit
-		isAtlasDeployed, err := state.IsAtlasDeployed(rp, nil)
-		if err != nil {
-			return nil, fmt.Errorf("error checking if Atlas is deployed: %w", err)
-		}
-		if !isAtlasDeployed {
-			depositType, err = mp.GetDepositType(nil)
-		} else {
-			depositType, err = minipool.GetMinipoolDepositType(rp, mp.GetAddress(), nil)
-		}
-		if err != nil {
-			return nil, fmt.Errorf("error getting deposit type for minipool %s: %w", mp.GetAddress().Hex(), err)
-		}
-
 		var depositAmount uint64
 		switch depositType {
 		case rptypes.Full, rptypes.Half, rptypes.Empty:
@@ -149,9 +166,12 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 		// Get the gas limit
 		signature := rptypes.BytesToValidatorSignature(depositData.Signature)
 		gasInfo, err := mp.EstimateStakeGas(signature, depositDataRoot, opts)
-		if err == nil {
-			response.GasInfo = gasInfo
+		response.RevertReason, err = services.GetRevertReason(err)
+		if err != nil {
+			return nil, err
 		}
+		response.GasInfo = gasInfo
+		response.CanStake = (response.RevertReason == "")
 	}
 
 	// Return response
@@ -16,9 +16,13 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
@@ -39,6 +43,33 @@ func validateMinipoolOwner(mp minipool.Minipool, nodeAddress common.Address) err
 	return nil
 }
 
+// Get a minipool that belongs to the node, along with its details from the node's cached network state if it's fresh.
+// The details are nil if the state isn't available, in which case callers must read everything from the chain.
+func getNodeMinipool(c *cli.Context, rp *rocketpool.RocketPool, nodeAddress common.Address, minipoolAddress common.Address) (minipool.Minipool, *rpstate.NativeMinipoolDetails, *state.NetworkState, error) {
+	cachedState := services.GetCachedNodeState(c, nodeAddress)
+	if cachedState != nil {
+		// The node's state only contains its own minipools, so being in it is proof of ownership
+		mpd, exists := cachedState.MinipoolDetailsByAddress[minipoolAddress]
+		if exists {
+			mp, err := minipool.NewMinipoolFromVersion(rp, minipoolAddress, mpd.Version, nil)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return mp, mpd, cachedState, nil
+		}
+	}
+
+	// Read it from the chain
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAddress); err != nil {
+		return nil, nil, nil, err
+	}
+	return mp, nil, nil, nil
+}
+
 // Get all node minipool details
 func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, isAtlasDeployed bool, legacyMinipoolQueueAddress *common.Address) ([]api.MinipoolDetails, error) {
 
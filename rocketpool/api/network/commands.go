@@ -28,7 +28,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getNodeFee(c))
+					api.GetResponsePrinter(c).PrintResponse(getNodeFee(c))
 					return nil
 
 				},
@@ -47,7 +47,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getRplPrice(c))
+					api.GetResponsePrinter(c).PrintResponse(getRplPrice(c))
 					return nil
 
 				},
@@ -66,7 +66,66 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStats(c))
+					api.GetResponsePrinter(c).PrintResponse(getStats(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "queue-status",
+				Usage:     "Get the deposit pool balance, minipool queue lengths per deposit type, deposit assignment settings, and the rETH exchange rate history",
+				UsageText: "rocketpool api network queue-status days",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					days, err := cliutils.ValidateUint("days", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getQueueStatus(c, days))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "deployment-status",
+				Usage:     "Get the loaded Rocket Pool deployment and verify the deployed contracts against it",
+				UsageText: "rocketpool api network deployment-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getDeploymentStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "gas-oracle",
+				Aliases:   []string{"g"},
+				Usage:     "Get the slow, standard, and fast EIP-1559 fees suggested from the recent fee history of the Execution client",
+				UsageText: "rocketpool api network gas-oracle",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getGasOracle(c))
 					return nil
 
 				},
@@ -85,7 +144,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getTimezones(c))
+					api.GetResponsePrinter(c).PrintResponse(getTimezones(c))
 					return nil
 
 				},
@@ -108,15 +167,38 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canGenerateRewardsTree(c, index))
+					api.GetResponsePrinter(c).PrintResponse(canGenerateRewardsTree(c, index))
 					return nil
 
 				},
 			},
 
 			{
-				Name:      "generate-rewards-tree",
+				Name:      "request-rewards-tree",
 				Usage:     "Set a request marker for the watchtower to generate the rewards tree for the given interval",
+				UsageText: "rocketpool api network request-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(requestRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "generate-rewards-tree",
+				Usage:     "Generate and save the rewards tree for the given interval, waiting until it's done. Uses the archive EC if the primary EC doesn't have the state for the interval.",
 				UsageText: "rocketpool api network generate-rewards-tree index",
 				Action: func(c *cli.Context) error {
 
@@ -131,7 +213,80 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(generateRewardsTree(c, index))
+					api.GetResponsePrinter(c).PrintResponse(generateRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-verify-rewards-tree",
+				Usage:     "Check if the rewards tree for the provided interval can be verified, and get the result of its last verification",
+				UsageText: "rocketpool api network can-verify-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canVerifyRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "verify-rewards-tree",
+				Usage:     "Set a request marker for the watchtower to regenerate the rewards tree for the given interval and compare it against the canonical one",
+				UsageText: "rocketpool api network verify-rewards-tree index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(verifyRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-minipool-performance",
+				Usage:     "Get a minipool's attestation performance and smoothing pool eligibility for the given interval from its minipool performance file, downloading the file if needed",
+				UsageText: "rocketpool api network get-minipool-performance index minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getMinipoolPerformance(c, index, minipoolAddress))
 					return nil
 
 				},
@@ -150,7 +305,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getActiveDAOProposals(c))
+					api.GetResponsePrinter(c).PrintResponse(getActiveDAOProposals(c))
 					return nil
 
 				},
@@ -174,7 +329,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(downloadRewardsFile(c, interval))
+					api.GetResponsePrinter(c).PrintResponse(downloadRewardsFile(c, interval))
 					return nil
 
 				},
@@ -193,7 +348,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(isAtlasDeployed(c))
+					api.GetResponsePrinter(c).PrintResponse(isAtlasDeployed(c))
 					return nil
 
 				},
@@ -211,7 +366,43 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getLatestDelegate(c))
+					api.GetResponsePrinter(c).PrintResponse(getLatestDelegate(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "state-snapshots",
+				Usage:     "List the network state snapshots saved by the daemons",
+				UsageText: "rocketpool api network state-snapshots",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getStateSnapshots(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "diff-state-snapshots",
+				Usage:     "Compare two network state snapshots",
+				UsageText: "rocketpool api network diff-state-snapshots from to",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(diffStateSnapshots(c, c.Args().Get(0), c.Args().Get(1)))
 					return nil
 
 				},
@@ -0,0 +1,247 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The Rocket Pool contracts whose addresses are looked up in RocketStorage and verified
+var deploymentContractNames = []string{
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketDepositPool",
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketMinipoolFactory",
+	"rocketMinipoolDelegate",
+	"rocketNodeManager",
+	"rocketNodeDeposit",
+	"rocketNodeStaking",
+	"rocketNodeDistributorFactory",
+	"rocketNetworkBalances",
+	"rocketNetworkPrices",
+	"rocketNetworkFees",
+	"rocketRewardsPool",
+	"rocketMerkleDistributorMainnet",
+	"rocketSmoothingPool",
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedActions",
+	"rocketDAOProtocolSettingsNetwork",
+}
+
+// The selector of the version() function every Rocket Pool contract has
+var versionSelector = crypto.Keccak256([]byte("version()"))[:4]
+
+func getDeploymentStatus(c *cli.Context) (*api.NetworkDeploymentStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := cfg.Smartnode.GetDeployment()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkDeploymentStatusResponse{
+		Network:        string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		DeploymentFile: cfg.Smartnode.GetDeploymentFile(),
+		ChainID:        deployment.ChainID,
+		StorageAddress: common.HexToAddress(deployment.StorageAddress),
+		IsCustom:       cfg.Smartnode.IsCustomDeployment(),
+	}
+	ecStatus := ec.CheckStatus(cfg)
+	if ecStatus.PrimaryClientStatus.IsWorking {
+		response.ExecutionChainID = ecStatus.PrimaryClientStatus.NetworkId
+	} else if ecStatus.FallbackEnabled && ecStatus.FallbackClientStatus.IsWorking {
+		response.ExecutionChainID = ecStatus.FallbackClientStatus.NetworkId
+	}
+
+	// Check the clients are on the deployment's chain, if it says which one that is
+	response.GenesisChecks = []api.DeploymentCheck{}
+	if deployment.GenesisBlockHash != "" {
+		check := api.DeploymentCheck{
+			Name:     "Execution genesis block",
+			Expected: common.HexToHash(deployment.GenesisBlockHash).Hex(),
+		}
+		genesis, err := ec.HeaderByNumber(context.Background(), big.NewInt(0))
+		if err != nil {
+			check.Error = fmt.Sprintf("error getting the genesis block: %s", err.Error())
+		} else {
+			check.Actual = genesis.Hash().Hex()
+			check.Matches = (check.Actual == check.Expected)
+		}
+		response.GenesisChecks = append(response.GenesisChecks, check)
+	}
+	if deployment.BeaconGenesisTime != 0 {
+		check := api.DeploymentCheck{
+			Name:     "Beacon Chain genesis time",
+			Expected: strconv.FormatUint(deployment.BeaconGenesisTime, 10),
+		}
+		bc, err := services.GetBeaconClient(c)
+		if err == nil {
+			var eth2Config beacon.Eth2Config
+			eth2Config, err = bc.GetEth2Config()
+			if err == nil {
+				check.Actual = strconv.FormatUint(eth2Config.GenesisTime, 10)
+				check.Matches = (eth2Config.GenesisTime == deployment.BeaconGenesisTime)
+			}
+		}
+		if err != nil {
+			check.Error = fmt.Sprintf("error getting the Beacon Chain config: %s", err.Error())
+		}
+		response.GenesisChecks = append(response.GenesisChecks, check)
+	}
+
+	// Check the contracts with fixed addresses in the deployment
+	fixedContracts := []struct {
+		name    string
+		address string
+	}{
+		{"rocketStorage", deployment.StorageAddress},
+		{"rplFaucet", deployment.RplFaucetAddress},
+		{"oneInchOracle", deployment.OneInchOracleAddress},
+		{"snapshotDelegation", deployment.SnapshotDelegationAddress},
+		{"rplTwapPool", deployment.RplTwapPoolAddress},
+		{"balancerVault", deployment.BalancerVaultAddress},
+		{"multicall", deployment.MulticallAddress},
+		{"balanceBatcher", deployment.BalanceBatcherAddress},
+		{"optimismPriceMessenger", deployment.OptimismPriceMessengerAddress},
+		{"polygonPriceMessenger", deployment.PolygonPriceMessengerAddress},
+		{"arbitrumPriceMessenger", deployment.ArbitrumPriceMessengerAddress},
+	}
+	for _, fixed := range fixedContracts {
+		if fixed.address == "" {
+			continue
+		}
+		address := common.HexToAddress(fixed.address)
+		contract, err := checkDeploymentContract(rp, deployment, fixed.name, address, address, false)
+		if err != nil {
+			return nil, err
+		}
+		response.Contracts = append(response.Contracts, contract)
+	}
+
+	// Check the Rocket Pool contracts registered in RocketStorage against the deployment
+	deploymentAddresses := map[string]string{
+		"rocketTokenRETH": deployment.RethAddress,
+		"rocketTokenRPL":  deployment.RplTokenAddress,
+	}
+	for _, name := range deploymentContractNames {
+		address, err := rp.RocketStorage.GetAddress(nil, crypto.Keccak256Hash([]byte("contract.address"+name)))
+		if err != nil {
+			return nil, fmt.Errorf("error getting the address of %s from RocketStorage: %w", name, err)
+		}
+		deploymentAddress := address
+		if declared, exists := deploymentAddresses[name]; exists {
+			deploymentAddress = common.HexToAddress(declared)
+		}
+		contract, err := checkDeploymentContract(rp, deployment, name, address, deploymentAddress, true)
+		if err != nil {
+			return nil, err
+		}
+		response.Contracts = append(response.Contracts, contract)
+	}
+
+	// The deployment is verified if every contract matched and there was something to match it against
+	response.Verified = response.ExecutionChainID == response.ChainID
+	for _, check := range response.GenesisChecks {
+		if !check.Matches {
+			response.Verified = false
+		}
+	}
+	for _, contract := range response.Contracts {
+		if contract.ContractStatus != api.DeploymentContractStatus_Ok {
+			response.Verified = false
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Compare a deployed contract to the deployment
+func checkDeploymentContract(rp *rocketpool.RocketPool, deployment *config.NetworkDeployment, name string, address common.Address, deploymentAddress common.Address, hasVersion bool) (api.DeploymentContract, error) {
+	contract := api.DeploymentContract{
+		Name:              name,
+		Address:           address,
+		DeploymentAddress: deploymentAddress,
+		ExpectedCodeHash:  deployment.ExpectedCodeHashes[name],
+		ExpectedVersion:   deployment.ExpectedContractVersions[name],
+		Problems:          []string{},
+	}
+
+	code, err := rp.Client.CodeAt(context.Background(), address, nil)
+	if err != nil {
+		return api.DeploymentContract{}, fmt.Errorf("error getting the bytecode of %s: %w", name, err)
+	}
+	contract.HasCode = len(code) > 0
+	if !contract.HasCode {
+		contract.ContractStatus = api.DeploymentContractStatus_Missing
+		contract.Problems = append(contract.Problems, fmt.Sprintf("there is no contract at %s", address.Hex()))
+		return contract, nil
+	}
+	contract.CodeHash = crypto.Keccak256Hash(code)
+
+	// Rocket Pool contracts report their own version
+	if hasVersion {
+		result, err := rp.Client.CallContract(context.Background(), ethereum.CallMsg{To: &address, Data: versionSelector}, nil)
+		if err == nil && len(result) == common.HashLength {
+			contract.HasVersion = true
+			contract.Version = result[common.HashLength-1]
+		}
+	}
+
+	verified := false
+	if address != deploymentAddress {
+		contract.Problems = append(contract.Problems, fmt.Sprintf("the deployment has it at %s", deploymentAddress.Hex()))
+	}
+	if contract.ExpectedCodeHash != (common.Hash{}) {
+		verified = true
+		if contract.CodeHash != contract.ExpectedCodeHash {
+			contract.Problems = append(contract.Problems, fmt.Sprintf("its bytecode hash is %s but the deployment expects %s", contract.CodeHash.Hex(), contract.ExpectedCodeHash.Hex()))
+		}
+	}
+	if contract.ExpectedVersion != 0 {
+		verified = true
+		if !contract.HasVersion {
+			contract.Problems = append(contract.Problems, fmt.Sprintf("it doesn't report a version but the deployment expects version %d", contract.ExpectedVersion))
+		} else if contract.Version != contract.ExpectedVersion {
+			contract.Problems = append(contract.Problems, fmt.Sprintf("it is version %d but the deployment expects version %d", contract.Version, contract.ExpectedVersion))
+		}
+	}
+
+	if len(contract.Problems) > 0 {
+		contract.ContractStatus = api.DeploymentContractStatus_Mismatch
+	} else if verified {
+		contract.ContractStatus = api.DeploymentContractStatus_Ok
+	} else {
+		contract.ContractStatus = api.DeploymentContractStatus_Unverified
+	}
+	return contract, nil
+}
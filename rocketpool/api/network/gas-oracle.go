@@ -0,0 +1,32 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the fee tiers suggested by the gas oracle for the next block
+func getGasOracle(c *cli.Context) (*api.NetworkGasOracleResponse, error) {
+
+	// Get services
+	feeOracle, err := services.GetFeeOracle(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkGasOracleResponse{}
+
+	// Get the fee estimate
+	estimate, err := feeOracle.GetFeeEstimate()
+	if err != nil {
+		return nil, err
+	}
+	response.Estimate = estimate
+
+	// Return response
+	return &response, nil
+
+}
@@ -1,13 +1,19 @@
 package network
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/archive"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
@@ -47,11 +53,33 @@ func canGenerateRewardsTree(c *cli.Context, index uint64) (*api.CanNetworkGenera
 		response.TreeFileExists = true
 	}
 
+	// Make sure the state for the interval's snapshot block is available before the watchtower starts generating it
+	if index < response.CurrentIndex {
+		rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, index)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting event for interval %d: %w", index, err)
+		}
+		response.SnapshotBlock = rewardsEvent.ExecutionBlock.Uint64()
+		_, err = archive.GetClientForBlock(rp, cfg, func(string) {}, rewardsEvent.ExecutionBlock)
+		var archiveErr *archive.ArchiveRequiredError
+		if errors.As(err, &archiveErr) {
+			response.ArchiveRequired = true
+			response.ArchiveEcUrl = archiveErr.ArchiveEcUrl
+			response.PrimaryEarliestBlock = archiveErr.PrimaryEarliestBlock
+			response.ArchiveEarliestBlock = archiveErr.ArchiveEarliestBlock
+			if archiveErr.SearchError != nil {
+				response.EarliestBlockError = archiveErr.SearchError.Error()
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
 	return &response, nil
 
 }
 
-func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
+func requestRewardsTree(c *cli.Context, index uint64) (*api.NetworkRequestRewardsTreeResponse, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -60,7 +88,7 @@ func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewa
 	}
 
 	// Response
-	response := api.NetworkGenerateRewardsTreeResponse{}
+	response := api.NetworkRequestRewardsTreeResponse{}
 
 	// Create the generation request
 	requestPath := cfg.Smartnode.GetRegenerateRewardsTreeRequestPath(index, true)
@@ -75,3 +103,77 @@ func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewa
 	return &response, nil
 
 }
+
+func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkGenerateRewardsTreeResponse{}
+
+	// Make sure the interval has been submitted
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if index >= currentIndexBig.Uint64() {
+		return nil, fmt.Errorf("The current active rewards period is interval %d. You cannot generate the tree for interval %d until the active interval is past it.", currentIndexBig.Uint64(), index)
+	}
+
+	// Generate the rewards file; progress is logged to stderr so it doesn't interfere with the response
+	logger := log.NewModuleLogger(log.ModuleRewards, log.LevelInfo, NormalLogger)
+	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating network state manager: %w", err)
+	}
+	profiler := rprewards.NewProfiler(cfg, index)
+	defer profiler.SaveReport(logger, generationPrefix)
+	rewardsFile, rewardsEvent, err := rprewards.GenerateRewardsFileForInterval(logger, generationPrefix, rp, cfg, bc, m, index, profiler)
+	if err != nil {
+		return nil, err
+	}
+	response.GeneratedMerkleRoot = common.BytesToHash(rewardsFile.MerkleTree.Root())
+	response.CanonicalMerkleRoot = rewardsEvent.MerkleRoot
+	response.Matches = (response.GeneratedMerkleRoot == response.CanonicalMerkleRoot)
+
+	// Write the files
+	rewardsFile.MinipoolPerformanceFileCID = "---"
+	minipoolPerformancePath := cfg.Smartnode.GetMinipoolPerformancePath(index, true)
+	response.TreeFilePath = cfg.Smartnode.GetRewardsTreePath(index, true)
+	err = profiler.TimePhase(rprewards.ProfilePhase_Serialization, func() error {
+		err := rprewards.SaveMinipoolPerformanceFile(cfg, minipoolPerformancePath, &rewardsFile.MinipoolPerformanceFile)
+		if err != nil {
+			return fmt.Errorf("Error saving minipool performance file to %s: %w", minipoolPerformancePath, err)
+		}
+		err = rprewards.SaveRewardsFile(cfg, response.TreeFilePath, rewardsFile)
+		if err != nil {
+			return fmt.Errorf("Error saving rewards file to %s: %w", response.TreeFilePath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+
+}
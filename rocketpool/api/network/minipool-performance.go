@@ -0,0 +1,90 @@
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/urfave/cli"
+)
+
+func getMinipoolPerformance(c *cli.Context, index uint64, minipoolAddress common.Address) (*api.NetworkMinipoolPerformanceResponse, error) {
+
+	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkMinipoolPerformanceResponse{
+		Index:    index,
+		Minipool: minipoolAddress,
+	}
+
+	// Make sure the interval has been submitted
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if index >= currentIndexBig.Uint64() {
+		return nil, fmt.Errorf("interval %d hasn't been submitted yet; the latest submitted interval is %d", index, currentIndexBig.Uint64()-1)
+	}
+
+	// Get the performance file, downloading it if needed
+	performanceFile, downloaded, err := rprewards.GetMinipoolPerformanceFile(rp, cfg, index)
+	if err != nil {
+		return nil, err
+	}
+	if performanceFile.Index != index {
+		return nil, fmt.Errorf("the minipool performance file for interval %d is for interval %d instead", index, performanceFile.Index)
+	}
+	response.Downloaded = downloaded
+	response.StartTime = performanceFile.StartTime
+	response.EndTime = performanceFile.EndTime
+
+	// Get the minipool's performance
+	response.Performance, response.MinipoolFound = performanceFile.MinipoolPerformance[minipoolAddress]
+	if !response.MinipoolFound {
+		return &response, nil
+	}
+
+	// Get the smoothing pool details of the minipool's node from the rewards file, if it's there
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress, err = mp.GetNodeAddress(nil)
+	if err != nil {
+		return nil, err
+	}
+	rewardsPath := cfg.Smartnode.GetRewardsTreePath(index, true)
+	if _, err := os.Stat(rewardsPath); err != nil {
+		return &response, nil
+	}
+	rewardsFile, err := rprewards.LoadRewardsFile(rewardsPath)
+	if err != nil {
+		return nil, err
+	}
+	nodeRewards, exists := rewardsFile.NodeRewards[response.NodeAddress]
+	if exists {
+		response.NodeRewardsFound = true
+		response.NodeEligibilityRate = nodeRewards.SmoothingPoolEligibilityRate
+		if nodeRewards.SmoothingPoolEth != nil {
+			response.NodeSmoothingPoolEth = &nodeRewards.SmoothingPoolEth.Int
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
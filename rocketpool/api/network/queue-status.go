@@ -0,0 +1,209 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	v110_minipool "github.com/rocket-pool/rocketpool-go/legacy/v1.1.0/minipool"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Used to convert the requested history window into a starting block
+const approxBlocksPerDay uint64 = 7200
+
+func getQueueStatus(c *cli.Context, days uint64) (*api.NetworkQueueStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkQueueStatusResponse{}
+
+	isAtlasDeployed, err := state.IsAtlasDeployed(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if Atlas has been deployed: %w", err)
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, fmt.Errorf("error getting event log interval: %w", err)
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get the deposit pool details
+	wg.Go(func() error {
+		var err error
+		response.DepositPoolBalance, err = deposit.GetBalance(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.DepositPoolExcessBalance, err = deposit.GetExcessBalance(rp, nil)
+		return err
+	})
+
+	// Get the current assignment settings
+	wg.Go(func() error {
+		var err error
+		response.AssignDepositsEnabled, err = protocol.GetAssignDepositsEnabled(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MaximumDepositAssignments, err = protocol.GetMaximumDepositAssignments(rp, nil)
+		return err
+	})
+
+	// Get the queue lengths per deposit type
+	if isAtlasDeployed {
+		wg.Go(func() error {
+			var err error
+			response.QueueLengths.Total, err = minipool.GetQueueTotalLength(rp, nil)
+			return err
+		})
+		wg.Go(func() error {
+			var err error
+			response.QueueLengths.FullDeposit, response.QueueLengths.HalfDeposit, response.QueueLengths.EmptyDeposit, err = getLegacyQueueLengths(rp)
+			return err
+		})
+	} else {
+		legacyMinipoolQueueAddress := cfg.Smartnode.GetV110MinipoolQueueAddress()
+		wg.Go(func() error {
+			lengths, err := v110_minipool.GetQueueLengths(rp, nil, &legacyMinipoolQueueAddress)
+			if err != nil {
+				return err
+			}
+			response.QueueLengths.Total = lengths.Total
+			response.QueueLengths.FullDeposit = lengths.FullDeposit
+			response.QueueLengths.HalfDeposit = lengths.HalfDeposit
+			response.QueueLengths.EmptyDeposit = lengths.EmptyDeposit
+			return nil
+		})
+	}
+
+	// Get the current rETH exchange rate
+	wg.Go(func() error {
+		var err error
+		response.RethExchangeRate, err = tokens.GetRETHExchangeRate(rp, nil)
+		return err
+	})
+
+	// Get the rETH exchange rate history
+	wg.Go(func() error {
+		var err error
+		response.RethExchangeRateHistory, err = getRethExchangeRateHistory(rp, eventLogInterval, days)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// The rest of the queue is made up of variable-bond minipools
+	if isAtlasDeployed {
+		legacyLength := response.QueueLengths.FullDeposit + response.QueueLengths.HalfDeposit + response.QueueLengths.EmptyDeposit
+		response.QueueLengths.VariableDeposit = response.QueueLengths.Total - legacyLength
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the lengths of the legacy queues that are still being drained after Atlas
+func getLegacyQueueLengths(rp *rocketpool.RocketPool) (uint64, uint64, uint64, error) {
+	rocketMinipoolQueue, err := rp.GetContract("rocketMinipoolQueue", nil)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	depositTypes := []rptypes.MinipoolDeposit{rptypes.Full, rptypes.Half, rptypes.Empty}
+	lengths := make([]uint64, len(depositTypes))
+	for i, depositType := range depositTypes {
+		length := new(*big.Int)
+		if err := rocketMinipoolQueue.Call(nil, length, "getLengthLegacy", uint8(depositType)); err != nil {
+			return 0, 0, 0, fmt.Errorf("error getting legacy minipool queue length for deposit type %s: %w", depositType.String(), err)
+		}
+		lengths[i] = (*length).Uint64()
+	}
+	return lengths[0], lengths[1], lengths[2], nil
+}
+
+// Get the rETH exchange rate at each network balances update over the last few days
+func getRethExchangeRateHistory(rp *rocketpool.RocketPool, eventLogInterval int, days uint64) ([]api.RethExchangeRateSnapshot, error) {
+	history := []api.RethExchangeRateSnapshot{}
+	if days == 0 {
+		return history, nil
+	}
+
+	// Get the block range to scan
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	var fromBlock uint64
+	if days*approxBlocksPerDay < latestBlock {
+		fromBlock = latestBlock - days*approxBlocksPerDay
+	}
+
+	// Get the balances update events
+	rocketNetworkBalances, err := rp.GetContract("rocketNetworkBalances", nil)
+	if err != nil {
+		return nil, err
+	}
+	addressFilter := []common.Address{*rocketNetworkBalances.Address}
+	topicFilter := [][]common.Hash{{rocketNetworkBalances.ABI.Events["BalancesUpdated"].ID}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, big.NewInt(int64(eventLogInterval)), big.NewInt(int64(fromBlock)), big.NewInt(int64(latestBlock)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network balances update events: %w", err)
+	}
+
+	// Calculate the exchange rate from each update
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		err := rocketNetworkBalances.Contract.UnpackLogIntoMap(values, "BalancesUpdated", log)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding network balances update event: %w", err)
+		}
+		totalEth := values["totalEth"].(*big.Int)
+		rethSupply := values["rethSupply"].(*big.Int)
+		snapshot := api.RethExchangeRateSnapshot{
+			Block: values["block"].(*big.Int).Uint64(),
+			Time:  time.Unix(values["time"].(*big.Int).Int64(), 0),
+		}
+		if rethSupply.Sign() > 0 {
+			snapshot.ExchangeRate = eth.WeiToEth(totalEth) / eth.WeiToEth(rethSupply)
+		} else {
+			snapshot.ExchangeRate = 1
+		}
+		history = append(history, snapshot)
+	}
+	return history, nil
+}
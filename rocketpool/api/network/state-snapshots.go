@@ -0,0 +1,175 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// List the saved network state snapshots
+func getStateSnapshots(c *cli.Context) (*api.StateSnapshotsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.StateSnapshotsResponse{}
+	response.Snapshots, err = state.ListSnapshots(cfg.Smartnode.GetStateSnapshotPath(true))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Compare two network state snapshots
+func diffStateSnapshots(c *cli.Context, fromName string, toName string) (*api.StateSnapshotDiffResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the snapshots
+	folder := cfg.Smartnode.GetStateSnapshotPath(true)
+	from, err := state.LoadSnapshot(folder, fromName)
+	if err != nil {
+		return nil, err
+	}
+	to, err := state.LoadSnapshot(folder, toName)
+	if err != nil {
+		return nil, err
+	}
+	if from.Scope != to.Scope {
+		return nil, fmt.Errorf("snapshot %s has a %s scope but %s has a %s scope; only snapshots with the same scope can be compared", from.Name, from.Scope, to.Name, to.Scope)
+	}
+
+	// Response
+	response := api.StateSnapshotDiffResponse{
+		From:              summarizeSnapshot(from),
+		To:                summarizeSnapshot(to),
+		NewNodes:          []common.Address{},
+		RemovedNodes:      []common.Address{},
+		NewMinipools:      []common.Address{},
+		RemovedMinipools:  []common.Address{},
+		StatusTransitions: []api.MinipoolStatusTransition{},
+		RplStakeChanges:   []api.NodeRplStakeChange{},
+	}
+
+	// Compare the nodes
+	fromStakes := map[common.Address]*big.Int{}
+	for _, node := range from.NodeDetails {
+		fromStakes[node.NodeAddress] = node.RplStake
+	}
+	toNodes := map[common.Address]bool{}
+	for _, node := range to.NodeDetails {
+		toNodes[node.NodeAddress] = true
+		fromStake, exists := fromStakes[node.NodeAddress]
+		if !exists {
+			response.NewNodes = append(response.NewNodes, node.NodeAddress)
+			continue
+		}
+		if getBigIntValue(fromStake).Cmp(getBigIntValue(node.RplStake)) != 0 {
+			response.RplStakeChanges = append(response.RplStakeChanges, api.NodeRplStakeChange{
+				Node: node.NodeAddress,
+				From: getBigIntValue(fromStake),
+				To:   getBigIntValue(node.RplStake),
+			})
+		}
+	}
+	for _, node := range from.NodeDetails {
+		if !toNodes[node.NodeAddress] {
+			response.RemovedNodes = append(response.RemovedNodes, node.NodeAddress)
+		}
+	}
+
+	// Compare the minipools, grouping the status changes by transition
+	fromStatuses := map[common.Address]string{}
+	for _, mp := range from.MinipoolDetails {
+		fromStatuses[mp.MinipoolAddress] = mp.Status.String()
+	}
+	toMinipools := map[common.Address]bool{}
+	transitions := map[string]*api.MinipoolStatusTransition{}
+	for _, mp := range to.MinipoolDetails {
+		toMinipools[mp.MinipoolAddress] = true
+		fromStatus, exists := fromStatuses[mp.MinipoolAddress]
+		if !exists {
+			response.NewMinipools = append(response.NewMinipools, mp.MinipoolAddress)
+			continue
+		}
+		toStatus := mp.Status.String()
+		if fromStatus == toStatus {
+			continue
+		}
+		key := fromStatus + "->" + toStatus
+		transition, exists := transitions[key]
+		if !exists {
+			transition = &api.MinipoolStatusTransition{
+				From: fromStatus,
+				To:   toStatus,
+			}
+			transitions[key] = transition
+		}
+		transition.Minipools = append(transition.Minipools, mp.MinipoolAddress)
+	}
+	for _, mp := range from.MinipoolDetails {
+		if !toMinipools[mp.MinipoolAddress] {
+			response.RemovedMinipools = append(response.RemovedMinipools, mp.MinipoolAddress)
+		}
+	}
+	for _, transition := range transitions {
+		response.StatusTransitions = append(response.StatusTransitions, *transition)
+	}
+	sort.Slice(response.StatusTransitions, func(i, j int) bool {
+		return len(response.StatusTransitions[i].Minipools) > len(response.StatusTransitions[j].Minipools)
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the totals for a snapshot
+func summarizeSnapshot(snapshot *state.NetworkStateSnapshot) api.StateSnapshotSummary {
+	summary := api.StateSnapshotSummary{
+		Name:             snapshot.Name,
+		Scope:            snapshot.Scope,
+		Time:             snapshot.Time,
+		ElBlockNumber:    snapshot.ElBlockNumber,
+		BeaconSlotNumber: snapshot.BeaconSlotNumber,
+		NodeCount:        len(snapshot.NodeDetails),
+		MinipoolCount:    len(snapshot.MinipoolDetails),
+		MinipoolStatuses: map[string]int{},
+		TotalRplStake:    big.NewInt(0),
+		TotalEthMatched:  big.NewInt(0),
+	}
+	for _, node := range snapshot.NodeDetails {
+		summary.TotalRplStake.Add(summary.TotalRplStake, getBigIntValue(node.RplStake))
+		summary.TotalEthMatched.Add(summary.TotalEthMatched, getBigIntValue(node.EthMatched))
+	}
+	for _, mp := range snapshot.MinipoolDetails {
+		summary.MinipoolStatuses[mp.Status.String()]++
+	}
+	return summary
+}
+
+// Treat missing values in a snapshot as zero
+func getBigIntValue(value *big.Int) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 func getStats(c *cli.Context) (*api.NetworkStatsResponse, error) {
@@ -165,6 +166,27 @@ func getStats(c *cli.Context) (*api.NetworkStatsResponse, error) {
 		return err
 	})
 
+	// Get the secondary-market rETH prices
+	rethPriceSources, err := rputils.GetRethPriceSources(cfg)
+	if err != nil {
+		return nil, err
+	}
+	response.RethMarketPrices = make([]api.RethMarketPrice, len(rethPriceSources))
+	for i, source := range rethPriceSources {
+		i, source := i, source
+		response.RethMarketPrices[i].Source = source.Name
+		wg.Go(func() error {
+			// A market that can't be read shouldn't prevent the rest of the stats from being shown
+			price, err := source.GetPrice(rp.Client, nil)
+			if err != nil {
+				response.RethMarketPrices[i].Error = err.Error()
+			} else {
+				response.RethMarketPrices[i].Price = eth.WeiToEth(price)
+			}
+			return nil
+		})
+	}
+
 	// Get smoothing pool status
 	wg.Go(func() error {
 		smoothingPoolNodes, err := node.GetSmoothingPoolRegisteredNodeCount(rp, nil)
@@ -197,6 +219,13 @@ func getStats(c *cli.Context) (*api.NetworkStatsResponse, error) {
 		return nil, err
 	}
 
+	// Get the premium of each rETH market over the exchange rate
+	for i, marketPrice := range response.RethMarketPrices {
+		if marketPrice.Error == "" {
+			response.RethMarketPrices[i].Premium = rputils.GetRethPremium(marketPrice.Price, response.RethPrice)
+		}
+	}
+
 	// Get the TVL
 	activeMinipools := response.InitializedMinipoolCount +
 		response.PrelaunchMinipoolCount +
@@ -0,0 +1,73 @@
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/urfave/cli"
+)
+
+func canVerifyRewardsTree(c *cli.Context, index uint64) (*api.CanNetworkVerifyRewardsTreeResponse, error) {
+
+	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanNetworkVerifyRewardsTreeResponse{}
+
+	// Get the current interval
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.CurrentIndex = currentIndexBig.Uint64()
+
+	// Check for a pending request
+	_, err = os.Stat(cfg.Smartnode.GetVerifyRewardsTreeRequestPath(index, true))
+	response.VerificationRequested = (err == nil)
+
+	// Get the result of the last verification
+	response.Verification, err = rprewards.LoadRewardsTreeVerification(cfg.Smartnode.GetRewardsTreeVerificationPath(index, true))
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+
+}
+
+func verifyRewardsTree(c *cli.Context, index uint64) (*api.NetworkVerifyRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkVerifyRewardsTreeResponse{}
+
+	// Create the verification request
+	requestPath := cfg.Smartnode.GetVerifyRewardsTreeRequestPath(index, true)
+	requestFile, err := os.Create(requestPath)
+	if requestFile != nil {
+		requestFile.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error creating request marker: %w", err)
+	}
+
+	return &response, nil
+
+}
@@ -64,12 +64,18 @@ func canNodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 		switch token {
 		case "reth":
 
-			// Check rETH collateral
+			// Check rETH collateral against the ETH value of the burn
 			rethTotalCollateral, err := tokens.GetRETHTotalCollateral(rp, nil)
 			if err != nil {
 				return err
 			}
-			response.InsufficientCollateral = (amountWei.Cmp(rethTotalCollateral) > 0)
+			ethValue, err := tokens.GetETHValueOfRETH(rp, amountWei, nil)
+			if err != nil {
+				return err
+			}
+			response.AvailableLiquidity = rethTotalCollateral
+			response.EthValue = ethValue
+			response.InsufficientCollateral = (ethValue.Cmp(rethTotalCollateral) > 0)
 
 		}
 		return nil
@@ -87,6 +93,7 @@ func canNodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 			if err == nil {
 				response.GasInfo = gasInfo
 			}
+			response.RevertReason, err = services.GetRevertReason(err)
 			return err
 		}
 		return err
@@ -98,7 +105,7 @@ func canNodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 	}
 
 	// Update & return response
-	response.CanBurn = !(response.InsufficientBalance || response.InsufficientCollateral)
+	response.CanBurn = !(response.InsufficientBalance || response.InsufficientCollateral || response.RevertReason != "")
 	return &response, nil
 
 }
@@ -243,6 +243,7 @@ func canClaimRewards(c *cli.Context, indicesString string) (*api.CanNodeClaimRew
 		return nil, err
 	}
 	gasInfo, err := rewards.EstimateClaimGas(rp, nodeAccount.Address, indices, amountRPL, amountETH, merkleProofs, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
@@ -349,6 +350,7 @@ func canClaimAndStakeRewards(c *cli.Context, indicesString string, stakeAmount *
 		return nil, err
 	}
 	gasInfo, err := rewards.EstimateClaimAndStakeGas(rp, nodeAccount.Address, indices, amountRPL, amountETH, merkleProofs, stakeAmount, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
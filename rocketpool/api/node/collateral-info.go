@@ -0,0 +1,153 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The standard collateral targets, as percentages of the node's borrowed ETH
+var standardCollateralTargets = []float64{10, 15}
+
+func getCollateralInfo(c *cli.Context, customTarget float64) (*api.NodeCollateralInfoResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeCollateralInfoResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if Atlas is deployed
+	isAtlasDeployed, err := state.IsAtlasDeployed(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if Atlas is deployed: %w", err)
+	}
+
+	// Sync
+	var wg errgroup.Group
+	var ethMatched *big.Int
+	var pendingMatchAmount *big.Int
+
+	// Get the node's stake details
+	wg.Go(func() error {
+		var err error
+		response.RplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.EffectiveRplStake, err = node.GetNodeEffectiveRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.RplPrice, err = network.GetRPLPrice(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.ActiveMinipools, err = minipool.GetNodeActiveMinipoolCount(rp, nodeAccount.Address, nil)
+		return err
+	})
+	if isAtlasDeployed {
+		wg.Go(func() error {
+			var err error
+			ethMatched, _, pendingMatchAmount, err = rputils.CheckCollateral(rp, nodeAccount.Address, nil)
+			return err
+		})
+	}
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the borrowed and bonded ETH, including pending bond reductions
+	totalEth := eth.EthToWei(32)
+	totalEth.Mul(totalEth, big.NewInt(int64(response.ActiveMinipools)))
+	if isAtlasDeployed {
+		response.BorrowedEth = big.NewInt(0).Add(ethMatched, pendingMatchAmount)
+	} else {
+		// Legacy behavior
+		response.BorrowedEth = big.NewInt(0).Div(totalEth, big.NewInt(2))
+	}
+	response.BondedEth = big.NewInt(0).Sub(totalEth, response.BorrowedEth)
+
+	// Get the current collateral ratios
+	rplStakeValue := eth.WeiToEth(response.RplPrice) * eth.WeiToEth(response.RplStake)
+	if response.BorrowedEth.Sign() > 0 {
+		response.BorrowedCollateralRatio = rplStakeValue / eth.WeiToEth(response.BorrowedEth)
+	} else {
+		response.BorrowedCollateralRatio = -1
+	}
+	if response.BondedEth.Sign() > 0 {
+		response.BondedCollateralRatio = rplStakeValue / eth.WeiToEth(response.BondedEth)
+	} else {
+		response.BondedCollateralRatio = -1
+	}
+
+	// Get the RPL required for each collateral target
+	targets := standardCollateralTargets
+	if customTarget > 0 {
+		targets = append(targets, customTarget)
+	}
+	response.Targets = make([]api.CollateralTarget, len(targets))
+	for i, target := range targets {
+		response.Targets[i] = getCollateralTarget(target, response.BorrowedEth, response.RplPrice, response.RplStake)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the RPL stake required to reach the given collateral percentage of the borrowed ETH, and how much more RPL the node needs to stake to get there
+func getCollateralTarget(targetPercent float64, borrowedEth *big.Int, rplPrice *big.Int, rplStake *big.Int) api.CollateralTarget {
+
+	target := api.CollateralTarget{
+		TargetPercent:       targetPercent,
+		RequiredRplStake:    big.NewInt(0),
+		AdditionalRplNeeded: big.NewInt(0),
+	}
+	if rplPrice.Sign() == 0 {
+		return target
+	}
+
+	// Required stake = borrowed ETH * target / RPL price
+	targetFraction := eth.EthToWei(targetPercent / 100)
+	target.RequiredRplStake.Mul(borrowedEth, targetFraction)
+	target.RequiredRplStake.Div(target.RequiredRplStake, rplPrice)
+
+	if target.RequiredRplStake.Cmp(rplStake) > 0 {
+		target.AdditionalRplNeeded.Sub(target.RequiredRplStake, rplStake)
+	}
+	return target
+
+}
@@ -28,7 +28,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					api.GetResponsePrinter(c).PrintResponse(getStatus(c))
 					return nil
 
 				},
@@ -47,7 +47,135 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getSyncProgress(c))
+					api.GetResponsePrinter(c).PrintResponse(getSyncProgress(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "tx-history",
+				Usage:     "Get the transactions the daemon has submitted, newest first",
+				UsageText: "rocketpool api node tx-history limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidateUint("limit", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getTxHistory(c, limit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "queue-tx",
+				Usage:     "Queue a state-changing API command for the node daemon to run once the base fee drops below the given max base fee (in gwei), using the priority fee and gas limit this command is called with",
+				UsageText: "rocketpool api node queue-tx max-base-fee deadline module command [args...]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateMinArgCount(c, 4); err != nil {
+						return err
+					}
+					maxBaseFee, err := cliutils.ValidatePositiveEthAmount("max base fee", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					deadline, err := cliutils.ValidatePositiveDuration("deadline", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(queueTx(c, maxBaseFee, deadline, c.Args().Get(2), c.Args().Get(3), c.Args()[4:]))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-tx-queue",
+				Usage:     "Get the API commands queued to run once the base fee drops, newest first",
+				UsageText: "rocketpool api node get-tx-queue",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getTxQueue(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "cancel-queued-tx",
+				Usage:     "Cancel a queued API command that hasn't run yet",
+				UsageText: "rocketpool api node cancel-queued-tx id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					id, err := cliutils.ValidatePositiveUint("id", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(cancelQueuedTx(c, id))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "tx-status",
+				Usage:     "Get the status of a transaction the daemon has submitted",
+				UsageText: "rocketpool api node tx-status tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getTxStatus(c, hash))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "preflight",
+				Usage:     "Run every registration prerequisite check and report which ones pass",
+				UsageText: "rocketpool api node preflight timezone-location",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getPreflightStatus(c, c.Args().Get(0)))
 					return nil
 
 				},
@@ -69,7 +197,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canRegisterNode(c, timezoneLocation))
+					api.GetResponsePrinter(c).PrintResponse(canRegisterNode(c, timezoneLocation))
 					return nil
 
 				},
@@ -91,7 +219,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(registerNode(c, timezoneLocation))
+					api.GetResponsePrinter(c).PrintResponse(registerNode(c, timezoneLocation))
 					return nil
 
 				},
@@ -118,7 +246,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSetWithdrawalAddress(c, withdrawalAddress, confirm))
+					api.GetResponsePrinter(c).PrintResponse(canSetWithdrawalAddress(c, withdrawalAddress, confirm))
 					return nil
 
 				},
@@ -145,7 +273,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setWithdrawalAddress(c, withdrawalAddress, confirm))
+					api.GetResponsePrinter(c).PrintResponse(setWithdrawalAddress(c, withdrawalAddress, confirm))
 					return nil
 
 				},
@@ -163,7 +291,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canConfirmWithdrawalAddress(c))
+					api.GetResponsePrinter(c).PrintResponse(canConfirmWithdrawalAddress(c))
 					return nil
 
 				},
@@ -180,7 +308,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(confirmWithdrawalAddress(c))
+					api.GetResponsePrinter(c).PrintResponse(confirmWithdrawalAddress(c))
 					return nil
 
 				},
@@ -202,7 +330,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSetTimezoneLocation(c, timezoneLocation))
+					api.GetResponsePrinter(c).PrintResponse(canSetTimezoneLocation(c, timezoneLocation))
 					return nil
 
 				},
@@ -224,7 +352,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setTimezoneLocation(c, timezoneLocation))
+					api.GetResponsePrinter(c).PrintResponse(setTimezoneLocation(c, timezoneLocation))
 					return nil
 
 				},
@@ -246,7 +374,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeSwapRpl(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(canNodeSwapRpl(c, amountWei))
 					return nil
 
 				},
@@ -268,7 +396,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(approveFsRpl(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(approveFsRpl(c, amountWei))
 					return nil
 
 				},
@@ -294,7 +422,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(waitForApprovalAndSwapFsRpl(c, amountWei, hash))
+					api.GetResponsePrinter(c).PrintResponse(waitForApprovalAndSwapFsRpl(c, amountWei, hash))
 					return nil
 
 				},
@@ -315,7 +443,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getSwapApprovalGas(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(getSwapApprovalGas(c, amountWei))
 					return nil
 
 				},
@@ -332,7 +460,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(allowanceFsRpl(c))
+					api.GetResponsePrinter(c).PrintResponse(allowanceFsRpl(c))
 					return nil
 
 				},
@@ -354,7 +482,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(swapRpl(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(swapRpl(c, amountWei))
 					return nil
 
 				},
@@ -376,7 +504,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeStakeRpl(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(canNodeStakeRpl(c, amountWei))
 					return nil
 
 				},
@@ -398,7 +526,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(approveRpl(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(approveRpl(c, amountWei))
 					return nil
 
 				},
@@ -424,7 +552,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(waitForApprovalAndStakeRpl(c, amountWei, hash))
+					api.GetResponsePrinter(c).PrintResponse(waitForApprovalAndStakeRpl(c, amountWei, hash))
 					return nil
 
 				},
@@ -445,7 +573,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStakeApprovalGas(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(getStakeApprovalGas(c, amountWei))
 					return nil
 
 				},
@@ -462,7 +590,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(allowanceRpl(c))
+					api.GetResponsePrinter(c).PrintResponse(allowanceRpl(c))
 					return nil
 
 				},
@@ -484,7 +612,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(stakeRpl(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(stakeRpl(c, amountWei))
 					return nil
 
 				},
@@ -506,7 +634,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeWithdrawRpl(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(canNodeWithdrawRpl(c, amountWei))
 					return nil
 
 				},
@@ -528,7 +656,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeWithdrawRpl(c, amountWei))
+					api.GetResponsePrinter(c).PrintResponse(nodeWithdrawRpl(c, amountWei))
 					return nil
 
 				},
@@ -558,7 +686,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeDeposit(c, amountWei, minNodeFee, salt))
+					api.GetResponsePrinter(c).PrintResponse(canNodeDeposit(c, amountWei, minNodeFee, salt))
 					return nil
 
 				},
@@ -598,7 +726,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					// Run
 					response, err := nodeDeposit(c, amountWei, minNodeFee, salt, useCreditBalance, submit)
 					if submit {
-						api.PrintResponse(response, err)
+						api.GetResponsePrinter(c).PrintResponse(response, err)
 					} // else nodeDeposit already printed the encoded transaction
 					return nil
 
@@ -625,7 +753,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeSend(c, amountWei, token))
+					api.GetResponsePrinter(c).PrintResponse(canNodeSend(c, amountWei, token))
 					return nil
 
 				},
@@ -655,7 +783,50 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeSend(c, amountWei, token, toAddress))
+					api.GetResponsePrinter(c).PrintResponse(nodeSend(c, amountWei, token, toAddress))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-sweep",
+				Usage:     "Check whether the node can sweep its ETH balance above a reserve to its withdrawal address",
+				UsageText: "rocketpool api node can-sweep reserve-wei",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					reserveWei, err := cliutils.ValidatePositiveOrZeroWeiAmount("reserve amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canNodeSweep(c, reserveWei))
+					return nil
+
+				},
+			},
+			{
+				Name:      "sweep",
+				Usage:     "Send the node's ETH balance above a reserve to its withdrawal address",
+				UsageText: "rocketpool api node sweep reserve-wei",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					reserveWei, err := cliutils.ValidatePositiveOrZeroWeiAmount("reserve amount", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(nodeSweep(c, reserveWei))
 					return nil
 
 				},
@@ -681,7 +852,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeBurn(c, amountWei, token))
+					api.GetResponsePrinter(c).PrintResponse(canNodeBurn(c, amountWei, token))
 					return nil
 
 				},
@@ -707,7 +878,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeBurn(c, amountWei, token))
+					api.GetResponsePrinter(c).PrintResponse(nodeBurn(c, amountWei, token))
 					return nil
 
 				},
@@ -725,7 +896,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canNodeClaimRpl(c))
+					api.GetResponsePrinter(c).PrintResponse(canNodeClaimRpl(c))
 					return nil
 
 				},
@@ -742,7 +913,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeClaimRpl(c))
+					api.GetResponsePrinter(c).PrintResponse(nodeClaimRpl(c))
 					return nil
 
 				},
@@ -760,7 +931,29 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getRewards(c))
+					api.GetResponsePrinter(c).PrintResponse(getRewards(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "rewards-report",
+				Usage:     "Get the RPL and ETH rewards the node earned in each rewards interval",
+				UsageText: "rocketpool api node rewards-report intervals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					intervals, err := cliutils.ValidateUint("intervals", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getRewardsReport(c, intervals))
 					return nil
 
 				},
@@ -778,7 +971,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getDepositContractInfo(c))
+					api.GetResponsePrinter(c).PrintResponse(getDepositContractInfo(c))
 					return nil
 
 				},
@@ -798,7 +991,26 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					data := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(sign(c, data))
+					api.GetResponsePrinter(c).PrintResponse(sign(c, data))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "send-raw-tx",
+				Usage:     "Broadcast an externally-signed, serialized transaction to the network",
+				UsageText: "rocketpool api node send-raw-tx tx-hex",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					data := c.Args().Get(0)
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(sendRawTransaction(c, data))
 					return nil
 
 				},
@@ -818,7 +1030,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					message := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(signMessage(c, message))
+					api.GetResponsePrinter(c).PrintResponse(signMessage(c, message))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "sign-typed-data",
+				Usage:     "Signs an EIP-712 typed data payload with the node's private key.",
+				UsageText: "rocketpool api node sign-typed-data 'typed-data-json'",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					typedData := c.Args().Get(0)
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(signTypedData(c, typedData))
 					return nil
 
 				},
@@ -841,7 +1073,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(estimateSetSnapshotDelegateGas(c, delegate))
+					api.GetResponsePrinter(c).PrintResponse(estimateSetSnapshotDelegateGas(c, delegate))
 					return nil
 
 				},
@@ -863,7 +1095,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setSnapshotDelegate(c, delegate))
+					api.GetResponsePrinter(c).PrintResponse(setSnapshotDelegate(c, delegate))
 					return nil
 
 				},
@@ -881,7 +1113,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(estimateClearSnapshotDelegateGas(c))
+					api.GetResponsePrinter(c).PrintResponse(estimateClearSnapshotDelegateGas(c))
 					return nil
 
 				},
@@ -898,16 +1130,15 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(clearSnapshotDelegate(c))
+					api.GetResponsePrinter(c).PrintResponse(clearSnapshotDelegate(c))
 					return nil
 
 				},
 			},
-
 			{
-				Name:      "is-fee-distributor-initialized",
-				Usage:     "Check if the fee distributor contract for this node is initialized and deployed",
-				UsageText: "rocketpool api node is-fee-distributor-initialized",
+				Name:      "get-snapshot-delegate",
+				Usage:     "Get the node's current voting snapshot delegate",
+				UsageText: "rocketpool api node get-snapshot-delegate",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -916,54 +1147,58 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(isFeeDistributorInitialized(c))
+					api.GetResponsePrinter(c).PrintResponse(getSnapshotDelegate(c))
 					return nil
 
 				},
 			},
 			{
-				Name:      "get-initialize-fee-distributor-gas",
-				Usage:     "Estimate the cost of initializing the fee distributor",
-				UsageText: "rocketpool api node get-initialize-fee-distributor-gas",
+				Name:      "sign-snapshot-vote",
+				Usage:     "Sign an off-chain Snapshot vote on a Rocket Pool governance proposal with the node's private key",
+				UsageText: "rocketpool api node sign-snapshot-vote proposal-id choice",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidateTxHash("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					choice, err := cliutils.ValidatePositiveUint("choice", c.Args().Get(1))
+					if err != nil {
 						return err
 					}
 
 					// Run
-					api.PrintResponse(getInitializeFeeDistributorGas(c))
+					api.GetResponsePrinter(c).PrintResponse(signSnapshotVote(c, proposalId, choice))
 					return nil
+
 				},
 			},
 
 			{
-				Name:      "estimate-set-snapshot-delegate-gas",
-				Usage:     "Estimate the gas required to set a voting snapshot delegate",
-				UsageText: "rocketpool api node estimate-set-snapshot-delegate-gas address",
+				Name:      "is-fee-distributor-initialized",
+				Usage:     "Check if the fee distributor contract for this node is initialized and deployed",
+				UsageText: "rocketpool api node is-fee-distributor-initialized",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-
-					delegate, err := cliutils.ValidateAddress("delegate", c.Args().Get(0))
-					if err != nil {
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
 						return err
 					}
 
 					// Run
-					api.PrintResponse(estimateSetSnapshotDelegateGas(c, delegate))
+					api.GetResponsePrinter(c).PrintResponse(isFeeDistributorInitialized(c))
 					return nil
 
 				},
 			},
 			{
-				Name:      "initialize-fee-distributor",
-				Usage:     "Initialize and deploy the fee distributor contract for this node",
-				UsageText: "rocketpool api node initialize-fee-distributor",
+				Name:      "get-initialize-fee-distributor-gas",
+				Usage:     "Estimate the cost of initializing the fee distributor",
+				UsageText: "rocketpool api node get-initialize-fee-distributor-gas",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -972,16 +1207,15 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(initializeFeeDistributor(c))
+					api.GetResponsePrinter(c).PrintResponse(getInitializeFeeDistributorGas(c))
 					return nil
-
 				},
 			},
 
 			{
-				Name:      "can-distribute",
-				Usage:     "Check if distributing ETH from the node's fee distributor is possible",
-				UsageText: "rocketpool api node can-distribute",
+				Name:      "initialize-fee-distributor",
+				Usage:     "Initialize and deploy the fee distributor contract for this node",
+				UsageText: "rocketpool api node initialize-fee-distributor",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -990,29 +1224,25 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canDistribute(c))
+					api.GetResponsePrinter(c).PrintResponse(initializeFeeDistributor(c))
 					return nil
 
 				},
 			},
+
 			{
-				Name:      "set-snapshot-delegate",
-				Usage:     "Set a voting snapshot delegate for the node",
-				UsageText: "rocketpool api node set-snapshot-delegate address",
+				Name:      "can-distribute",
+				Usage:     "Check if distributing ETH from the node's fee distributor is possible",
+				UsageText: "rocketpool api node can-distribute",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-
-					delegate, err := cliutils.ValidateAddress("delegate", c.Args().Get(0))
-					if err != nil {
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
 						return err
 					}
 
 					// Run
-					api.PrintResponse(setSnapshotDelegate(c, delegate))
+					api.GetResponsePrinter(c).PrintResponse(canDistribute(c))
 					return nil
 
 				},
@@ -1029,7 +1259,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(distribute(c))
+					api.GetResponsePrinter(c).PrintResponse(distribute(c))
 					return nil
 
 				},
@@ -1046,7 +1276,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(nodeClaimRpl(c))
+					api.GetResponsePrinter(c).PrintResponse(nodeClaimRpl(c))
 					return nil
 
 				},
@@ -1064,7 +1294,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getRewardsInfo(c))
+					api.GetResponsePrinter(c).PrintResponse(getRewardsInfo(c))
 					return nil
 
 				},
@@ -1082,7 +1312,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					indicesString := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(canClaimRewards(c, indicesString))
+					api.GetResponsePrinter(c).PrintResponse(canClaimRewards(c, indicesString))
 					return nil
 
 				},
@@ -1100,7 +1330,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					indicesString := c.Args().Get(0)
 
 					// Run
-					api.PrintResponse(claimRewards(c, indicesString))
+					api.GetResponsePrinter(c).PrintResponse(claimRewards(c, indicesString))
 					return nil
 
 				},
@@ -1123,7 +1353,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canClaimAndStakeRewards(c, indicesString, stakeAmount))
+					api.GetResponsePrinter(c).PrintResponse(canClaimAndStakeRewards(c, indicesString, stakeAmount))
 					return nil
 
 				},
@@ -1146,7 +1376,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(claimAndStakeRewards(c, indicesString, stakeAmount))
+					api.GetResponsePrinter(c).PrintResponse(claimAndStakeRewards(c, indicesString, stakeAmount))
 					return nil
 
 				},
@@ -1164,7 +1394,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getSmoothingPoolRegistrationStatus(c))
+					api.GetResponsePrinter(c).PrintResponse(getSmoothingPoolRegistrationStatus(c))
 					return nil
 
 				},
@@ -1185,7 +1415,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canSetSmoothingPoolStatus(c, status))
+					api.GetResponsePrinter(c).PrintResponse(canSetSmoothingPoolStatus(c, status))
 					return nil
 
 				},
@@ -1206,7 +1436,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setSmoothingPoolStatus(c, status))
+					api.GetResponsePrinter(c).PrintResponse(setSmoothingPoolStatus(c, status))
 					return nil
 
 				},
@@ -1223,7 +1453,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(resolveEnsName(c, c.Args().Get(0)))
+					api.GetResponsePrinter(c).PrintResponse(resolveEnsName(c, c.Args().Get(0)))
 					return nil
 
 				},
@@ -1244,7 +1474,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						return err
 					}
 					// Run
-					api.PrintResponse(reverseResolveEnsName(c, address))
+					api.GetResponsePrinter(c).PrintResponse(reverseResolveEnsName(c, address))
 					return nil
 
 				},
@@ -1278,7 +1508,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canCreateVacantMinipool(c, amountWei, minNodeFee, salt, pubkey))
+					api.GetResponsePrinter(c).PrintResponse(canCreateVacantMinipool(c, amountWei, minNodeFee, salt, pubkey))
 					return nil
 
 				},
@@ -1311,7 +1541,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(createVacantMinipool(c, amountWei, minNodeFee, salt, pubkey))
+					api.GetResponsePrinter(c).PrintResponse(createVacantMinipool(c, amountWei, minNodeFee, salt, pubkey))
 					return nil
 
 				},
@@ -1329,7 +1559,29 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(checkCollateral(c))
+					api.GetResponsePrinter(c).PrintResponse(checkCollateral(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-collateral-info",
+				Usage:     "Get the node's borrowed and bonded ETH, RPL stake, and the RPL required to reach the 10%, 15%, and a custom (0 for none) collateral target",
+				UsageText: "rocketpool api node get-collateral-info custom-target-percent",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					customTarget, err := cliutils.ValidatePercentage("custom collateral target", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getCollateralInfo(c, customTarget))
 					return nil
 
 				},
@@ -1347,7 +1599,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getNodeEthBalance(c))
+					api.GetResponsePrinter(c).PrintResponse(getNodeEthBalance(c))
 					return nil
 
 				},
@@ -144,10 +144,12 @@ func canCreateVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee floa
 
 	// Run the deposit gas estimator
 	gasInfo, err := node.EstimateCreateVacantMinipoolGas(rp, amountWei, minNodeFee, pubkey, salt, minipoolAddress, balanceWei, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 	response.GasInfo = gasInfo
+	response.CanDeposit = (response.RevertReason == "")
 
 	return &response, nil
 
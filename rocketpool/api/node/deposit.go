@@ -257,17 +257,20 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 	// Run the deposit gas estimator
 	if response.CanUseCredit {
 		gasInfo, err := node.EstimateDepositWithCreditGas(rp, amountWei, minNodeFee, pubKey, signature, depositDataRoot, salt, minipoolAddress, opts)
+		response.RevertReason, err = services.GetRevertReason(err)
 		if err != nil {
 			return nil, err
 		}
 		response.GasInfo = gasInfo
 	} else {
 		gasInfo, err := node.EstimateDepositGas(rp, amountWei, minNodeFee, pubKey, signature, depositDataRoot, salt, minipoolAddress, opts)
+		response.RevertReason, err = services.GetRevertReason(err)
 		if err != nil {
 			return nil, err
 		}
 		response.GasInfo = gasInfo
 	}
+	response.CanDeposit = (response.RevertReason == "")
 
 	return &response, nil
 
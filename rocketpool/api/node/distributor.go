@@ -198,6 +198,7 @@ func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 		}
 		gasInfo, err := distributor.EstimateDistributeGas(opts)
 		response.GasInfo = gasInfo
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -0,0 +1,197 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Names of the preflight checks
+const (
+	preflightCheck_EcSynced      string = "ec-synced"
+	preflightCheck_BcSynced      string = "bc-synced"
+	preflightCheck_ChainID       string = "chain-id"
+	preflightCheck_Wallet        string = "wallet"
+	preflightCheck_Registration  string = "registration"
+	preflightCheck_RegisterGas   string = "register-gas"
+	preflightCheck_WalletFunded  string = "wallet-funded"
+	preflightCheck_TimezoneValid string = "timezone-valid"
+)
+
+func getPreflightStatus(c *cli.Context, timezoneLocation string) (*api.NodePreflightResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ecMgr, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bcMgr, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodePreflightResponse{}
+	addCheck := func(name string, passed bool, message string) {
+		response.Checks = append(response.Checks, api.NodePreflightCheck{
+			Name:    name,
+			Passed:  passed,
+			Message: message,
+		})
+	}
+
+	// Check the EC sync status
+	ecStatus := ecMgr.CheckStatus(cfg)
+	ecSynced := ecStatus.PrimaryClientStatus.IsSynced || (ecStatus.FallbackEnabled && ecStatus.FallbackClientStatus.IsSynced)
+	if ecSynced {
+		addCheck(preflightCheck_EcSynced, true, "The execution client is synced.")
+	} else {
+		addCheck(preflightCheck_EcSynced, false, fmt.Sprintf("The execution client is not synced (%.2f%%). %s", ecStatus.PrimaryClientStatus.SyncProgress*100, ecStatus.PrimaryClientStatus.Error))
+	}
+
+	// Check the BC sync status
+	bcStatus := bcMgr.CheckStatus()
+	bcSynced := bcStatus.PrimaryClientStatus.IsSynced || (bcStatus.FallbackEnabled && bcStatus.FallbackClientStatus.IsSynced)
+	if bcSynced {
+		addCheck(preflightCheck_BcSynced, true, "The beacon node is synced.")
+	} else {
+		addCheck(preflightCheck_BcSynced, false, fmt.Sprintf("The beacon node is not synced (%.2f%%). %s", bcStatus.PrimaryClientStatus.SyncProgress*100, bcStatus.PrimaryClientStatus.Error))
+	}
+
+	// Check that both clients are on the configured chain
+	expectedChainID := cfg.Smartnode.GetChainID()
+	chainIdErrors := []string{}
+	if ecStatus.PrimaryClientStatus.IsWorking && ecStatus.PrimaryClientStatus.NetworkId != expectedChainID {
+		chainIdErrors = append(chainIdErrors, fmt.Sprintf("the execution client is on chain ID %d", ecStatus.PrimaryClientStatus.NetworkId))
+	}
+	depositContract, err := bcMgr.GetEth2DepositContract()
+	if err != nil {
+		chainIdErrors = append(chainIdErrors, fmt.Sprintf("the beacon node's chain ID could not be checked (%s)", err.Error()))
+	} else if depositContract.ChainID != uint64(expectedChainID) {
+		chainIdErrors = append(chainIdErrors, fmt.Sprintf("the beacon node is on chain ID %d", depositContract.ChainID))
+	}
+	if len(chainIdErrors) == 0 {
+		addCheck(preflightCheck_ChainID, true, fmt.Sprintf("Both clients are on the configured chain (ID %d).", expectedChainID))
+	} else {
+		addCheck(preflightCheck_ChainID, false, fmt.Sprintf("Expected chain ID %d but %s.", expectedChainID, strings.Join(chainIdErrors, " and ")))
+	}
+
+	// Check the timezone
+	if _, err := time.LoadLocation(timezoneLocation); err != nil || timezoneLocation == "" {
+		addCheck(preflightCheck_TimezoneValid, false, fmt.Sprintf("'%s' is not a valid timezone location; it must be in the format 'Country/City'.", timezoneLocation))
+	} else {
+		addCheck(preflightCheck_TimezoneValid, true, fmt.Sprintf("'%s' is a valid timezone location.", timezoneLocation))
+	}
+
+	// The remaining checks need a node wallet and a working EC
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	if !w.IsInitialized() {
+		addCheck(preflightCheck_Wallet, false, "The node wallet has not been initialized.")
+		return finishPreflight(&response), nil
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		addCheck(preflightCheck_Wallet, false, fmt.Sprintf("The node account could not be loaded: %s", err.Error()))
+		return finishPreflight(&response), nil
+	}
+	addCheck(preflightCheck_Wallet, true, fmt.Sprintf("The node wallet is initialized with address %s.", nodeAccount.Address.Hex()))
+	if !ecSynced {
+		return finishPreflight(&response), nil
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		addCheck(preflightCheck_Registration, false, err.Error())
+		return finishPreflight(&response), nil
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that the node can be registered
+	exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	registrationEnabled, err := protocol.GetNodeRegistrationEnabled(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		addCheck(preflightCheck_Registration, false, "The node is already registered with Rocket Pool.")
+	} else if !registrationEnabled {
+		addCheck(preflightCheck_Registration, false, "Node registrations are currently disabled.")
+	} else {
+		addCheck(preflightCheck_Registration, true, "The node can be registered with Rocket Pool.")
+	}
+
+	// Estimate the registration gas
+	gasCost := big.NewInt(0)
+	gasEstimated := false
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := node.EstimateRegisterNodeGas(rp, timezoneLocation, opts)
+	if err != nil {
+		addCheck(preflightCheck_RegisterGas, false, fmt.Sprintf("The registration gas could not be estimated: %s", err.Error()))
+	} else {
+		feeOracle, err := services.GetFeeOracle(c)
+		if err != nil {
+			return nil, err
+		}
+		gasPrice, err := feeOracle.GetMaxFeeWei(oracle.FeeTier_Standard, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the suggested max fee: %w", err)
+		}
+		gasCost.Mul(gasPrice, big.NewInt(int64(gasInfo.SafeGasLimit)))
+		response.GasInfo = gasInfo
+		gasEstimated = true
+		addCheck(preflightCheck_RegisterGas, true, fmt.Sprintf("Registration will use about %d gas (~%.6f ETH at current prices).", gasInfo.EstGasLimit, eth.WeiToEth(gasCost)))
+	}
+
+	// Check that the wallet can pay for registration
+	balance, err := ecMgr.BalanceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ETH balance of node %s: %w", nodeAccount.Address.Hex(), err)
+	}
+	if !gasEstimated {
+		addCheck(preflightCheck_WalletFunded, false, fmt.Sprintf("The node wallet has %.6f ETH, but the registration cost is unknown because its gas could not be estimated.", eth.WeiToEth(balance)))
+	} else if balance.Sign() == 0 || balance.Cmp(gasCost) < 0 {
+		addCheck(preflightCheck_WalletFunded, false, fmt.Sprintf("The node wallet has %.6f ETH, which is not enough to pay for registration.", eth.WeiToEth(balance)))
+	} else {
+		addCheck(preflightCheck_WalletFunded, true, fmt.Sprintf("The node wallet has %.6f ETH.", eth.WeiToEth(balance)))
+	}
+
+	return finishPreflight(&response), nil
+
+}
+
+// Set the overall result of the preflight checks
+func finishPreflight(response *api.NodePreflightResponse) *api.NodePreflightResponse {
+	response.Passed = true
+	for _, check := range response.Checks {
+		if !check.Passed {
+			response.Passed = false
+			break
+		}
+	}
+	return response
+}
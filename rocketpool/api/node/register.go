@@ -70,6 +70,7 @@ func canRegisterNode(c *cli.Context, timezoneLocation string) (*api.CanRegisterN
 		if err == nil {
 			response.GasInfo = gasInfo
 		}
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -79,7 +80,7 @@ func canRegisterNode(c *cli.Context, timezoneLocation string) (*api.CanRegisterN
 	}
 
 	// Update & return response
-	response.CanRegister = !(response.AlreadyRegistered || response.RegistrationDisabled)
+	response.CanRegister = !(response.AlreadyRegistered || response.RegistrationDisabled || response.RevertReason != "")
 	return &response, nil
 
 }
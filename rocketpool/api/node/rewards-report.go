@@ -0,0 +1,188 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The transaction a rewards interval was claimed in
+type intervalClaim struct {
+	txHash common.Hash
+	time   time.Time
+}
+
+func getRewardsReport(c *cli.Context, intervalCount uint64) (*api.NodeRewardsReportResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardsReportResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the intervals to report on, newest last
+	unclaimed, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	isClaimed := map[uint64]bool{}
+	for _, interval := range claimed {
+		isClaimed[interval] = true
+	}
+	intervalCountTotal := uint64(len(unclaimed) + len(claimed))
+	firstInterval := uint64(0)
+	if intervalCount > 0 && intervalCount < intervalCountTotal {
+		firstInterval = intervalCountTotal - intervalCount
+	}
+
+	// Find the transactions the intervals were claimed in
+	var claims map[uint64]intervalClaim
+	if len(claimed) > 0 {
+		claims, err = getIntervalClaims(rp, cfg, nodeAccount.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Set up fiat valuation if it's enabled
+	var priceClient *prices.PriceClient
+	priceApiUrl := cfg.Smartnode.PriceApiUrl.Value.(string)
+	if priceApiUrl != "" {
+		response.FiatCurrency = cfg.Smartnode.FiatCurrency.Value.(string)
+		priceClient = prices.NewPriceClient(priceApiUrl, response.FiatCurrency)
+	}
+
+	for interval := firstInterval; interval < intervalCountTotal; interval++ {
+		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, interval)
+		if err != nil {
+			return nil, fmt.Errorf("error getting info for rewards interval %d: %w", interval, err)
+		}
+		report := api.NodeRewardsReportInterval{
+			Index:          interval,
+			StartTime:      intervalInfo.StartTime,
+			EndTime:        intervalInfo.EndTime,
+			TreeFileExists: intervalInfo.TreeFileExists || intervalInfo.IsPruned,
+			RplAmount:      big.NewInt(0),
+			EthAmount:      big.NewInt(0),
+			Claimed:        isClaimed[interval],
+		}
+		if claim, exists := claims[interval]; exists {
+			report.ClaimTxHash = claim.txHash
+			report.ClaimTime = claim.time
+		}
+		if (intervalInfo.TreeFileExists || intervalInfo.IsPruned) && intervalInfo.NodeExists {
+			report.RplAmount.Add(&intervalInfo.CollateralRplAmount.Int, &intervalInfo.ODaoRplAmount.Int)
+			report.EthAmount.Set(&intervalInfo.SmoothingPoolEthAmount.Int)
+		}
+
+		// Value the rewards at the prices of the day the interval ended, when they became claimable
+		if priceClient != nil && (report.RplAmount.Sign() > 0 || report.EthAmount.Sign() > 0) {
+			rplPrice, err := priceClient.GetHistoricalPrice(prices.CoinId_Rpl, intervalInfo.EndTime)
+			if err != nil {
+				report.PriceError = err.Error()
+			} else {
+				ethPrice, err := priceClient.GetHistoricalPrice(prices.CoinId_Eth, intervalInfo.EndTime)
+				if err != nil {
+					report.PriceError = err.Error()
+				} else {
+					report.RplPriceFiat = rplPrice
+					report.EthPriceFiat = ethPrice
+					report.RplValueFiat = eth.WeiToEth(report.RplAmount) * rplPrice
+					report.EthValueFiat = eth.WeiToEth(report.EthAmount) * ethPrice
+				}
+			}
+		}
+		response.Intervals = append(response.Intervals, report)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the transaction each of the node's claimed intervals was claimed in
+func getIntervalClaims(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address) (map[uint64]intervalClaim, error) {
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the claim events for the node
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards distributor contract: %w", err)
+	}
+	claimEvent, exists := distributor.ABI.Events["RewardsClaimed"]
+	if !exists {
+		return nil, fmt.Errorf("rewards distributor contract does not have a RewardsClaimed event")
+	}
+	addressFilter := []common.Address{*distributor.Address}
+	topicFilter := [][]common.Hash{{claimEvent.ID}, {nodeAddress.Hash()}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, big.NewInt(int64(eventLogInterval)), nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claim events: %w", err)
+	}
+
+	// Map each claimed interval to its transaction
+	claims := map[uint64]intervalClaim{}
+	blockTimes := map[uint64]time.Time{}
+	for _, claimLog := range logs {
+		values := make(map[string]interface{})
+		err = claimEvent.Inputs.UnpackIntoMap(values, claimLog.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding rewards claim event in transaction %s: %w", claimLog.TxHash.Hex(), err)
+		}
+		blockTime, exists := blockTimes[claimLog.BlockNumber]
+		if !exists {
+			header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(claimLog.BlockNumber))
+			if err != nil {
+				return nil, fmt.Errorf("error getting header for block %d: %w", claimLog.BlockNumber, err)
+			}
+			blockTime = time.Unix(int64(header.Time), 0)
+			blockTimes[claimLog.BlockNumber] = blockTime
+		}
+		indices, _ := values["rewardIndex"].([]*big.Int)
+		for _, index := range indices {
+			claims[index.Uint64()] = intervalClaim{
+				txHash: claimLog.TxHash,
+				time:   blockTime,
+			}
+		}
+	}
+	return claims, nil
+
+}
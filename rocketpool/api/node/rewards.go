@@ -142,7 +142,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 			if err != nil {
 				return err
 			}
-			if !intervalInfo.TreeFileExists {
+			if !intervalInfo.TreeFileExists && !intervalInfo.IsPruned {
 				return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist but interval %d was claimed", intervalInfo.TreeFilePath, claimedInterval)
 			}
 			rplRewards.Add(rplRewards, &intervalInfo.CollateralRplAmount.Int)
@@ -339,7 +339,7 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 				if err != nil {
 					return err
 				}
-				if !intervalInfo.TreeFileExists {
+				if !intervalInfo.TreeFileExists && !intervalInfo.IsPruned {
 					return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist but interval %d was claimed", intervalInfo.TreeFilePath, claimedInterval)
 				}
 				rplRewards.Add(rplRewards, &intervalInfo.ODaoRplAmount.Int)
@@ -0,0 +1,67 @@
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+func sendRawTransaction(c *cli.Context, serializedTx string) (*api.NodeSendRawTransactionResponse, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSendRawTransactionResponse{}
+
+	// Deserialize the signed TX
+	serializedTx = hexutils.RemovePrefix(serializedTx)
+	bytes, err := hex.DecodeString(serializedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing TX bytes [%s]: %w", serializedTx, err)
+	}
+	tx := new(types.Transaction)
+	err = tx.UnmarshalBinary(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error unmarshalling TX: %w", err)
+	}
+
+	// Make sure it was signed for the network the daemon is connected to
+	chainID := w.GetChainID()
+	if tx.ChainId().Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("TX was signed for chain ID %s but the node is on chain ID %s", tx.ChainId().String(), chainID.String())
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("Error recovering TX sender: %w", err)
+	}
+
+	// Broadcast it
+	err = ec.SendTransaction(context.Background(), tx)
+	if err != nil {
+		return nil, fmt.Errorf("Error sending TX: %w", err)
+	}
+
+	// Return response
+	response.From = sender
+	response.To = tx.To()
+	response.Nonce = tx.Nonce()
+	response.Value = tx.Value()
+	response.TxHash = tx.Hash()
+	return &response, nil
+
+}
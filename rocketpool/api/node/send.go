@@ -60,6 +60,7 @@ func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 		}
 		response.InsufficientBalance = (amountWei.Cmp(ethBalanceWei) > 0)
 		gasInfo, err := eth.EstimateSendTransactionGas(ec, nodeAccount.Address, opts)
+		response.RevertReason, err = services.GetRevertReason(err)
 		if err != nil {
 			return nil, err
 		}
@@ -78,6 +79,7 @@ func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 		}
 		response.InsufficientBalance = (amountWei.Cmp(rplBalanceWei) > 0)
 		gasInfo, err := tokens.EstimateTransferRPLGas(rp, nodeAccount.Address, amountWei, opts)
+		response.RevertReason, err = services.GetRevertReason(err)
 		if err != nil {
 			return nil, err
 		}
@@ -96,6 +98,7 @@ func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 		}
 		response.InsufficientBalance = (amountWei.Cmp(fixedSupplyRplBalanceWei) > 0)
 		gasInfo, err := tokens.EstimateTransferFixedSupplyRPLGas(rp, nodeAccount.Address, amountWei, opts)
+		response.RevertReason, err = services.GetRevertReason(err)
 		if err != nil {
 			return nil, err
 		}
@@ -114,6 +117,7 @@ func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 		}
 		response.InsufficientBalance = (amountWei.Cmp(rethBalanceWei) > 0)
 		gasInfo, err := tokens.EstimateTransferRETHGas(rp, nodeAccount.Address, amountWei, opts)
+		response.RevertReason, err = services.GetRevertReason(err)
 		if err != nil {
 			return nil, err
 		}
@@ -122,7 +126,7 @@ func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 	}
 
 	// Update & return response
-	response.CanSend = !response.InsufficientBalance
+	response.CanSend = !(response.InsufficientBalance || response.RevertReason != "")
 	return &response, nil
 
 }
@@ -37,10 +37,13 @@ func canSetTimezoneLocation(c *cli.Context, timezoneLocation string) (*api.CanSe
 	}
 	gasInfo, err := node.EstimateSetTimezoneLocationGas(rp, timezoneLocation, opts)
 	if err != nil {
-		return nil, err
+		response.RevertReason, err = services.GetRevertReason(err)
+		if err != nil {
+			return nil, err
+		}
 	}
 	response.GasInfo = gasInfo
-	response.CanSet = true
+	response.CanSet = (response.RevertReason == "")
 	return &response, nil
 
 }
@@ -2,9 +2,11 @@ package node
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	_ "time/tzdata"
 
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -30,3 +32,29 @@ func signMessage(c *cli.Context, message string) (*api.NodeSignResponse, error)
 	return &response, nil
 
 }
+
+func signTypedData(c *cli.Context, typedDataJson string) (*api.NodeSignResponse, error) {
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the EIP-712 payload
+	var typedData apitypes.TypedData
+	err = json.Unmarshal([]byte(typedDataJson), &typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing EIP-712 typed data: %w", err)
+	}
+
+	// Response
+	response := api.NodeSignResponse{}
+	signedBytes, err := w.SignTypedData(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing typed data: %w", err)
+	}
+	response.SignedData = hexutils.AddPrefix(hex.EncodeToString(signedBytes))
+
+	// Return response
+	return &response, nil
+
+}
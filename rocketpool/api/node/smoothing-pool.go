@@ -106,11 +106,13 @@ func canSetSmoothingPoolStatus(c *cli.Context, status bool) (*api.CanSetSmoothin
 		return nil, err
 	}
 	gasInfo, err := node.EstimateSetSmoothingPoolRegistrationStateGas(rp, status, opts)
-	if err == nil {
-		response.GasInfo = gasInfo
+	response.RevertReason, err = services.GetRevertReason(err)
+	if err != nil {
+		return nil, err
 	}
+	response.GasInfo = gasInfo
 
-	return &response, err
+	return &response, nil
 
 }
 
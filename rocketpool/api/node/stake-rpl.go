@@ -74,6 +74,7 @@ func canNodeStakeRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeStakeRplRe
 		return nil, err
 	}
 	gasInfo, err := node.EstimateStakeGas(rp, amountWei, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
@@ -81,9 +82,9 @@ func canNodeStakeRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeStakeRplRe
 
 	// Update & return response
 	if !isAtlasDeployed {
-		response.CanStake = !(response.InsufficientBalance || !response.InConsensus)
+		response.CanStake = !(response.InsufficientBalance || !response.InConsensus || response.RevertReason != "")
 	} else {
-		response.CanStake = !(response.InsufficientBalance)
+		response.CanStake = !(response.InsufficientBalance || response.RevertReason != "")
 	}
 	return &response, nil
 
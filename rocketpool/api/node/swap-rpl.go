@@ -54,13 +54,14 @@ func canNodeSwapRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeSwapRplResp
 		return nil, err
 	}
 	gasInfo, err := tokens.EstimateSwapFixedSupplyRPLForRPLGas(rp, amountWei, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 	response.GasInfo = gasInfo
 
 	// Update & return response
-	response.CanSwap = !response.InsufficientBalance
+	response.CanSwap = !(response.InsufficientBalance || response.RevertReason != "")
 	return &response, nil
 
 }
@@ -0,0 +1,166 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canNodeSweep(c *cli.Context, reserveWei *big.Int) (*api.CanNodeSweepResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanNodeSweepResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the sweep details
+	withdrawalAddress, balance, amount, err := getSweepDetails(rp, ec, nodeAccount.Address, reserveWei)
+	if err != nil {
+		return nil, err
+	}
+	response.WithdrawalAddress = withdrawalAddress
+	response.Balance = balance
+	response.Reserve = reserveWei
+	response.Amount = amount
+	response.WithdrawalAddressIsNode = (withdrawalAddress == nodeAccount.Address)
+	response.InsufficientBalance = (amount.Sign() <= 0)
+
+	// Get gas estimate
+	if !response.InsufficientBalance && !response.WithdrawalAddressIsNode {
+		opts, err := w.GetNodeAccountTransactor()
+		if err != nil {
+			return nil, err
+		}
+		opts.Value = amount
+		gasInfo, err := eth.EstimateSendTransactionGas(ec, withdrawalAddress, opts)
+		response.RevertReason, err = services.GetRevertReason(err)
+		if err != nil {
+			return nil, err
+		}
+		response.GasInfo = gasInfo
+	}
+
+	// Update & return response
+	response.CanSweep = !(response.InsufficientBalance || response.WithdrawalAddressIsNode || response.RevertReason != "")
+	return &response, nil
+
+}
+
+func nodeSweep(c *cli.Context, reserveWei *big.Int) (*api.NodeSweepResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSweepResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the sweep details again, since the balance may have changed since the check
+	withdrawalAddress, _, amount, err := getSweepDetails(rp, ec, nodeAccount.Address, reserveWei)
+	if err != nil {
+		return nil, err
+	}
+	if withdrawalAddress == nodeAccount.Address {
+		return nil, fmt.Errorf("The node's withdrawal address is the node address, so there is nothing to sweep.")
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("The node's ETH balance does not exceed the reserve of %s wei.", reserveWei.String())
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Transfer ETH
+	opts.Value = amount
+	hash, err := eth.SendTransaction(ec, withdrawalAddress, w.GetChainID(), opts)
+	if err != nil {
+		return nil, err
+	}
+	response.Amount = amount
+	response.WithdrawalAddress = withdrawalAddress
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the node's withdrawal address, its ETH balance, and the amount that can be swept after keeping the reserve
+func getSweepDetails(rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, nodeAddress common.Address, reserveWei *big.Int) (common.Address, *big.Int, *big.Int, error) {
+
+	withdrawalAddress, err := storage.GetNodeWithdrawalAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return common.Address{}, nil, nil, fmt.Errorf("error getting withdrawal address for node %s: %w", nodeAddress.Hex(), err)
+	}
+
+	balance, err := ec.BalanceAt(context.Background(), nodeAddress, nil)
+	if err != nil {
+		return common.Address{}, nil, nil, fmt.Errorf("error getting ETH balance of node %s: %w", nodeAddress.Hex(), err)
+	}
+
+	amount := big.NewInt(0).Sub(balance, reserveWei)
+	if amount.Sign() < 0 {
+		amount.SetUint64(0)
+	}
+	return withdrawalAddress, balance, amount, nil
+
+}
@@ -0,0 +1,58 @@
+package node
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getTxHistory(c *cli.Context, limit uint64) (*api.NodeTxHistoryResponse, error) {
+
+	// Get services
+	journal, err := services.GetTxJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTxHistoryResponse{}
+
+	// Get the history
+	response.Transactions, err = journal.GetHistory(int(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func getTxStatus(c *cli.Context, hash common.Hash) (*api.NodeTxStatusResponse, error) {
+
+	// Get services
+	journal, err := services.GetTxJournal(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTxStatusResponse{}
+
+	// Get the status, updating it from the chain if it's still pending
+	response.Transaction, err = journal.GetStatus(rp.Client, hash)
+	if err != nil {
+		return nil, err
+	}
+	response.Found = (response.Transaction != nil)
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,84 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func queueTx(c *cli.Context, maxBaseFee float64, deadline time.Duration, module string, command string, args []string) (*api.NodeQueueTxResponse, error) {
+
+	// Get services
+	queue, err := services.GetTxQueue(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeQueueTxResponse{}
+
+	// Read-only commands don't submit transactions, so there's nothing to wait for
+	if api.IsReadOnlyCommand([]string{module, command}) {
+		return nil, fmt.Errorf("%s %s doesn't submit a transaction, so it can't be queued", module, command)
+	}
+
+	// Queue the command with the gas settings it was called with
+	intent, err := queue.Add(module, command, args, maxBaseFee, c.GlobalFloat64("maxPrioFee"), c.GlobalUint64("gasLimit"), time.Now().Add(deadline))
+	if err != nil {
+		return nil, err
+	}
+	response.Intent = intent
+
+	// Return response
+	return &response, nil
+
+}
+
+func getTxQueue(c *cli.Context) (*api.NodeTxQueueResponse, error) {
+
+	// Get services
+	queue, err := services.GetTxQueue(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTxQueueResponse{}
+
+	// Get the queue
+	response.Intents, err = queue.List()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func cancelQueuedTx(c *cli.Context, id uint64) (*api.NodeCancelQueuedTxResponse, error) {
+
+	// Get services
+	queue, err := services.GetTxQueue(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeCancelQueuedTxResponse{}
+
+	// Cancel the intent
+	intent, err := queue.Cancel(id)
+	if err != nil {
+		return nil, err
+	}
+	response.Intent = *intent
+
+	// Return response
+	return &response, nil
+
+}
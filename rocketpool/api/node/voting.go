@@ -1,9 +1,11 @@
 package node
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -20,6 +23,14 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// The EIP-712 domain Snapshot uses for vote messages
+const (
+	snapshotDomainName    string = "snapshot"
+	snapshotDomainVersion string = "0.1.4"
+	snapshotVoteApp       string = "smartnode"
 )
 
 func estimateSetSnapshotDelegateGas(c *cli.Context, address common.Address) (*api.EstimateSetSnapshotDelegateGasResponse, error) {
@@ -250,6 +261,131 @@ func clearSnapshotDelegate(c *cli.Context) (*api.ClearSnapshotDelegateResponse,
 
 }
 
+func getSnapshotDelegate(c *cli.Context) (*api.GetSnapshotDelegateResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	s, err := services.GetSnapshotDelegation(c)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("voting is not enabled on network [%s]", cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	}
+
+	// Response
+	response := api.GetSnapshotDelegateResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the delegate
+	idHash := cfg.Smartnode.GetVotingSnapshotID()
+	response.Delegate, err = s.Delegation(nil, nodeAccount.Address, idHash)
+	if err != nil {
+		return nil, err
+	}
+	response.HasDelegate = (response.Delegate != common.Address{})
+	if response.HasDelegate {
+		response.DelegateFormatted = formatResolvedAddress(c, response.Delegate)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func signSnapshotVote(c *cli.Context, proposalId common.Hash, choice uint64) (*api.SignSnapshotVoteResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the choice
+	if choice > math.MaxUint32 {
+		return nil, fmt.Errorf("Invalid choice %d", choice)
+	}
+
+	// Response
+	response := api.SignSnapshotVoteResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the vote message; numbers are stored as float64 so the payload matches its JSON form
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+			},
+			"Vote": []apitypes.Type{
+				{Name: "from", Type: "address"},
+				{Name: "space", Type: "string"},
+				{Name: "timestamp", Type: "uint64"},
+				{Name: "proposal", Type: "bytes32"},
+				{Name: "choice", Type: "uint32"},
+				{Name: "reason", Type: "string"},
+				{Name: "app", Type: "string"},
+				{Name: "metadata", Type: "string"},
+			},
+		},
+		PrimaryType: "Vote",
+		Domain: apitypes.TypedDataDomain{
+			Name:    snapshotDomainName,
+			Version: snapshotDomainVersion,
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":      nodeAccount.Address.Hex(),
+			"space":     cfg.Smartnode.GetSnapshotID(),
+			"timestamp": float64(time.Now().Unix()),
+			"proposal":  proposalId.Hex(),
+			"choice":    float64(choice),
+			"reason":    "",
+			"app":       snapshotVoteApp,
+			"metadata":  "{}",
+		},
+	}
+
+	// Sign the vote
+	signedBytes, err := w.SignTypedData(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing Snapshot vote: %w", err)
+	}
+	response.Address = nodeAccount.Address
+	response.Signature = hexutils.AddPrefix(hex.EncodeToString(signedBytes))
+	response.TypedData = typedData
+
+	// Return response
+	return &response, nil
+
+}
+
 func getHttpClientWithTimeout() *http.Client {
 	return &http.Client{
 		Timeout: time.Second * 5,
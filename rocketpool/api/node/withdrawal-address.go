@@ -44,13 +44,14 @@ func canSetWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address, c
 
 	// Check withdrawal address setting
 	gasInfo, err := storage.EstimateSetWithdrawalAddressGas(rp, nodeAccount.Address, withdrawalAddress, confirm, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 	response.GasInfo = gasInfo
 
 	// Return response
-	response.CanSet = true
+	response.CanSet = (response.RevertReason == "")
 	return &response, nil
 }
 
@@ -150,13 +151,14 @@ func canConfirmWithdrawalAddress(c *cli.Context) (*api.CanConfirmNodeWithdrawalA
 
 	// Check withdrawal address setting
 	gasInfo, err := storage.EstimateConfirmWithdrawalAddressGas(rp, nodeAccount.Address, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 	response.GasInfo = gasInfo
 
 	// Return response
-	response.CanConfirm = (pendingAddress != nodeAccount.Address)
+	response.CanConfirm = (pendingAddress != nodeAccount.Address && response.RevertReason == "")
 	return &response, nil
 }
 
@@ -77,6 +77,7 @@ func canCancelProposal(c *cli.Context, proposalId uint64) (*api.CanCancelTNDAOPr
 		if err == nil {
 			response.GasInfo = gasInfo
 		}
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -86,7 +87,7 @@ func canCancelProposal(c *cli.Context, proposalId uint64) (*api.CanCancelTNDAOPr
 	}
 
 	// Update & return response
-	response.CanCancel = !(response.DoesNotExist || response.InvalidState || response.InvalidProposer)
+	response.CanCancel = !(response.DoesNotExist || response.InvalidState || response.InvalidProposer || response.RevertReason != "")
 	return &response, nil
 
 }
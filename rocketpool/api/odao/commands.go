@@ -28,7 +28,61 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					api.GetResponsePrinter(c).PrintResponse(getStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "duty-status",
+				Usage:     "Get the status of the watchtower's oracle DAO duties",
+				UsageText: "rocketpool api odao duty-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getDutyStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "submission-records",
+				Usage:     "Get the values the watchtower has submitted, and any it calculated afterwards that contradict them",
+				UsageText: "rocketpool api odao submission-records",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getSubmissionRecords(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "force-balance-submission",
+				Usage:     "Have the watchtower submit network balances for the current block even if they deviate from other oracle DAO members' submissions",
+				UsageText: "rocketpool api odao force-balance-submission",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(forceBalanceSubmission(c))
 					return nil
 
 				},
@@ -47,7 +101,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getMembers(c))
+					api.GetResponsePrinter(c).PrintResponse(getMembers(c))
 					return nil
 
 				},
@@ -66,7 +120,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getProposals(c))
+					api.GetResponsePrinter(c).PrintResponse(getProposals(c))
 					return nil
 
 				},
@@ -90,7 +144,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getProposal(c, id))
+					api.GetResponsePrinter(c).PrintResponse(getProposal(c, id))
 					return nil
 
 				},
@@ -116,7 +170,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeInvite(c, memberAddress, memberId, c.Args().Get(2)))
+					api.GetResponsePrinter(c).PrintResponse(canProposeInvite(c, memberAddress, memberId, c.Args().Get(2)))
 					return nil
 
 				},
@@ -142,7 +196,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeInvite(c, memberAddress, memberId, c.Args().Get(2)))
+					api.GetResponsePrinter(c).PrintResponse(proposeInvite(c, memberAddress, memberId, c.Args().Get(2)))
 					return nil
 
 				},
@@ -160,7 +214,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeLeave(c))
+					api.GetResponsePrinter(c).PrintResponse(canProposeLeave(c))
 					return nil
 
 				},
@@ -178,7 +232,59 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeLeave(c))
+					api.GetResponsePrinter(c).PrintResponse(proposeLeave(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-propose-replace",
+				Usage:     "Check whether the node can propose replacing its position with a new member",
+				UsageText: "rocketpool api odao can-propose-replace member-address member-id member-url",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					memberId, err := cliutils.ValidateDAOMemberID("member ID", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canProposeReplace(c, memberAddress, memberId, c.Args().Get(2)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "propose-replace",
+				Aliases:   []string{"r"},
+				Usage:     "Propose replacing the node's position with a new member",
+				UsageText: "rocketpool api odao propose-replace member-address member-id member-url",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					memberId, err := cliutils.ValidateDAOMemberID("member ID", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(proposeReplace(c, memberAddress, memberId, c.Args().Get(2)))
 					return nil
 
 				},
@@ -204,7 +310,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeKick(c, memberAddress, fineAmountWei))
+					api.GetResponsePrinter(c).PrintResponse(canProposeKick(c, memberAddress, fineAmountWei))
 					return nil
 
 				},
@@ -230,7 +336,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeKick(c, memberAddress, fineAmountWei))
+					api.GetResponsePrinter(c).PrintResponse(proposeKick(c, memberAddress, fineAmountWei))
 					return nil
 
 				},
@@ -252,7 +358,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canCancelProposal(c, proposalId))
+					api.GetResponsePrinter(c).PrintResponse(canCancelProposal(c, proposalId))
 					return nil
 
 				},
@@ -274,7 +380,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(cancelProposal(c, proposalId))
+					api.GetResponsePrinter(c).PrintResponse(cancelProposal(c, proposalId))
 					return nil
 
 				},
@@ -296,7 +402,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canVoteOnProposal(c, proposalId))
+					api.GetResponsePrinter(c).PrintResponse(canVoteOnProposal(c, proposalId))
 					return nil
 
 				},
@@ -322,7 +428,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(voteOnProposal(c, proposalId, support))
+					api.GetResponsePrinter(c).PrintResponse(voteOnProposal(c, proposalId, support))
 					return nil
 
 				},
@@ -344,7 +450,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canExecuteProposal(c, proposalId))
+					api.GetResponsePrinter(c).PrintResponse(canExecuteProposal(c, proposalId))
 					return nil
 
 				},
@@ -366,7 +472,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(executeProposal(c, proposalId))
+					api.GetResponsePrinter(c).PrintResponse(executeProposal(c, proposalId))
 					return nil
 
 				},
@@ -384,7 +490,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canJoin(c))
+					api.GetResponsePrinter(c).PrintResponse(canJoin(c))
 					return nil
 
 				},
@@ -402,7 +508,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(approveRpl(c))
+					api.GetResponsePrinter(c).PrintResponse(approveRpl(c))
 					return nil
 
 				},
@@ -424,7 +530,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(waitForApprovalAndJoin(c, hash))
+					api.GetResponsePrinter(c).PrintResponse(waitForApprovalAndJoin(c, hash))
 					return nil
 
 				},
@@ -442,7 +548,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canLeave(c))
+					api.GetResponsePrinter(c).PrintResponse(canLeave(c))
 					return nil
 
 				},
@@ -464,7 +570,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(leave(c, bondRefundAddress))
+					api.GetResponsePrinter(c).PrintResponse(leave(c, bondRefundAddress))
 					return nil
 
 				},
@@ -486,7 +592,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingMembersQuorum(c, quorum))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingMembersQuorum(c, quorum))
 					return nil
 
 				},
@@ -507,7 +613,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingMembersQuorum(c, quorum))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingMembersQuorum(c, quorum))
 					return nil
 
 				},
@@ -529,7 +635,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingMembersRplBond(c, bondAmountWei))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingMembersRplBond(c, bondAmountWei))
 					return nil
 
 				},
@@ -550,7 +656,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingMembersRplBond(c, bondAmountWei))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingMembersRplBond(c, bondAmountWei))
 					return nil
 
 				},
@@ -572,7 +678,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax))
 					return nil
 
 				},
@@ -593,7 +699,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingMinipoolUnbondedMax(c, unbondedMinipoolMax))
 					return nil
 
 				},
@@ -615,7 +721,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalCooldown(c, proposalCooldownBlocks))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingProposalCooldown(c, proposalCooldownBlocks))
 					return nil
 
 				},
@@ -636,7 +742,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalCooldown(c, proposalCooldownBlocks))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingProposalCooldown(c, proposalCooldownBlocks))
 					return nil
 
 				},
@@ -658,7 +764,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalVoteTimespan(c, proposalVoteTimespan))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingProposalVoteTimespan(c, proposalVoteTimespan))
 					return nil
 
 				},
@@ -679,7 +785,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalVoteTimespan(c, proposalVoteTimespan))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingProposalVoteTimespan(c, proposalVoteTimespan))
 					return nil
 
 				},
@@ -701,7 +807,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan))
 					return nil
 
 				},
@@ -722,7 +828,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingProposalVoteDelayTimespan(c, proposalDelayTimespan))
 					return nil
 
 				},
@@ -744,7 +850,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan))
 					return nil
 
 				},
@@ -765,7 +871,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingProposalExecuteTimespan(c, proposalExecuteTimespan))
 					return nil
 
 				},
@@ -787,7 +893,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingProposalActionTimespan(c, proposalActionTimespan))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingProposalActionTimespan(c, proposalActionTimespan))
 					return nil
 
 				},
@@ -808,7 +914,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingProposalActionTimespan(c, proposalActionTimespan))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingProposalActionTimespan(c, proposalActionTimespan))
 					return nil
 
 				},
@@ -830,7 +936,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingScrubPeriod(c, scrubPeriod))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingScrubPeriod(c, scrubPeriod))
 					return nil
 
 				},
@@ -851,7 +957,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingScrubPeriod(c, scrubPeriod))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingScrubPeriod(c, scrubPeriod))
 					return nil
 
 				},
@@ -873,7 +979,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingPromotionScrubPeriod(c, scrubPeriod))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingPromotionScrubPeriod(c, scrubPeriod))
 					return nil
 
 				},
@@ -894,7 +1000,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingPromotionScrubPeriod(c, scrubPeriod))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingPromotionScrubPeriod(c, scrubPeriod))
 					return nil
 
 				},
@@ -916,7 +1022,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingScrubPenaltyEnabled(c, enabled))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingScrubPenaltyEnabled(c, enabled))
 					return nil
 
 				},
@@ -937,7 +1043,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingScrubPenaltyEnabled(c, enabled))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingScrubPenaltyEnabled(c, enabled))
 					return nil
 
 				},
@@ -959,7 +1065,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingBondReductionWindowStart(c, windowStart))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingBondReductionWindowStart(c, windowStart))
 					return nil
 
 				},
@@ -980,7 +1086,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingBondReductionWindowStart(c, windowStart))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingBondReductionWindowStart(c, windowStart))
 					return nil
 
 				},
@@ -1002,7 +1108,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(canProposeSettingBondReductionWindowLength(c, windowLength))
+					api.GetResponsePrinter(c).PrintResponse(canProposeSettingBondReductionWindowLength(c, windowLength))
 					return nil
 
 				},
@@ -1023,7 +1129,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(proposeSettingBondReductionWindowLength(c, windowLength))
+					api.GetResponsePrinter(c).PrintResponse(proposeSettingBondReductionWindowLength(c, windowLength))
 					return nil
 
 				},
@@ -1036,7 +1142,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Action: func(c *cli.Context) error {
 
 					// Run
-					api.PrintResponse(getMemberSettings(c))
+					api.GetResponsePrinter(c).PrintResponse(getMemberSettings(c))
 					return nil
 
 				},
@@ -1048,7 +1154,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Action: func(c *cli.Context) error {
 
 					// Run
-					api.PrintResponse(getProposalSettings(c))
+					api.GetResponsePrinter(c).PrintResponse(getProposalSettings(c))
 					return nil
 
 				},
@@ -1060,7 +1166,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Action: func(c *cli.Context) error {
 
 					// Run
-					api.PrintResponse(getMinipoolSettings(c))
+					api.GetResponsePrinter(c).PrintResponse(getMinipoolSettings(c))
 					return nil
 
 				},
@@ -0,0 +1,47 @@
+package odao
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getDutyStatus(c *cli.Context) (*api.TNDAODutyStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAODutyStatusResponse{}
+
+	// Read the status file written by the watchtower
+	path := cfg.Smartnode.GetWatchtowerDutyStatusPath(true)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		// The watchtower hasn't run any duties yet
+		return &response, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading watchtower duty status file %s: %w", path, err)
+	}
+
+	var statusFile api.WatchtowerDutyStatusFile
+	err = json.Unmarshal(bytes, &statusFile)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing watchtower duty status file %s: %w", path, err)
+	}
+	response.StatusFileExists = true
+	response.UpdatedTime = statusFile.UpdatedTime
+	response.Duties = statusFile.Duties
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,64 @@
+package odao
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func forceBalanceSubmission(c *cli.Context) (*api.TNDAOForceBalanceSubmissionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOForceBalanceSubmissionResponse{}
+
+	// Get the block that balances are currently being reported for
+	reportableBlock, err := network.GetLatestReportableBalancesBlock(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest reportable balances block: %w", err)
+	}
+	balancesBlock, err := network.GetBalancesBlock(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest balances block: %w", err)
+	}
+	if reportableBlock.Uint64() <= balancesBlock {
+		return nil, fmt.Errorf("balances for block %d have already been agreed upon; there is no pending balance submission", balancesBlock)
+	}
+	response.Block = reportableBlock.Uint64()
+
+	// Create the request marker for the watchtower
+	requestPath := cfg.Smartnode.GetForceBalanceSubmissionRequestPath(response.Block, true)
+	err = os.MkdirAll(filepath.Dir(requestPath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating watchtower folder: %w", err)
+	}
+	requestFile, err := os.Create(requestPath)
+	if requestFile != nil {
+		requestFile.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating request marker: %w", err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -66,6 +66,7 @@ func canLeave(c *cli.Context) (*api.CanLeaveTNDAOResponse, error) {
 		if err == nil {
 			response.GasInfo = gasInfo
 		}
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -75,7 +76,7 @@ func canLeave(c *cli.Context) (*api.CanLeaveTNDAOResponse, error) {
 	}
 
 	// Update & return response
-	response.CanLeave = !(response.ProposalExpired || response.InsufficientMembers)
+	response.CanLeave = !(response.ProposalExpired || response.InsufficientMembers || response.RevertReason != "")
 	return &response, nil
 
 }
@@ -0,0 +1,179 @@
+package odao
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canProposeReplace(c *cli.Context, memberAddress common.Address, memberId, memberUrl string) (*api.CanProposeTNDAOReplaceResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanProposeTNDAOReplaceResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Check if proposal cooldown is active
+	wg.Go(func() error {
+		proposalCooldownActive, err := getProposalCooldownActive(rp, nodeAccount.Address)
+		if err == nil {
+			response.ProposalCooldownActive = proposalCooldownActive
+		}
+		return err
+	})
+
+	// Check if the new member exists
+	wg.Go(func() error {
+		memberExists, err := trustednode.GetMemberExists(rp, memberAddress, nil)
+		if err == nil {
+			response.MemberAlreadyExists = memberExists
+		}
+		return err
+	})
+
+	// Get gas estimate
+	wg.Go(func() error {
+		opts, err := w.GetNodeAccountTransactor()
+		if err != nil {
+			return err
+		}
+		message, err := getReplaceProposalMessage(c, nodeAccount.Address, memberId, memberUrl)
+		if err != nil {
+			return err
+		}
+		gasInfo, err := trustednode.EstimateProposeReplaceMemberGas(rp, message, nodeAccount.Address, memberAddress, memberId, memberUrl, opts)
+		if err == nil {
+			response.GasInfo = gasInfo
+		}
+		response.RevertReason, err = services.GetRevertReason(err)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Update & return response
+	response.CanPropose = !(response.ProposalCooldownActive || response.MemberAlreadyExists || response.RevertReason != "")
+	return &response, nil
+
+}
+
+func proposeReplace(c *cli.Context, memberAddress common.Address, memberId, memberUrl string) (*api.ProposeTNDAOReplaceResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ProposeTNDAOReplaceResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the proposal message
+	message, err := getReplaceProposalMessage(c, nodeAccount.Address, memberId, memberUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Submit proposal
+	proposalId, hash, err := trustednode.ProposeReplaceMember(rp, message, nodeAccount.Address, memberAddress, memberId, memberUrl, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.ProposalId = proposalId
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the message for a proposal to replace the node with a new member
+func getReplaceProposalMessage(c *cli.Context, nodeAddress common.Address, memberId, memberUrl string) (string, error) {
+
+	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return "", err
+	}
+
+	// Data
+	var wg errgroup.Group
+	var nodeMemberId string
+	var nodeMemberUrl string
+
+	// Get node member details
+	wg.Go(func() error {
+		var err error
+		nodeMemberId, err = trustednode.GetMemberID(rp, nodeAddress, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		nodeMemberUrl, err = trustednode.GetMemberUrl(rp, nodeAddress, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return "", err
+	}
+
+	// Return
+	return fmt.Sprintf("replace %s (%s) with %s (%s)", nodeMemberId, nodeMemberUrl, memberId, memberUrl), nil
+
+}
@@ -64,11 +64,13 @@ func canProposeSettingMembersQuorum(c *cli.Context, quorum float64) (*api.CanPro
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeQuorumGas(rp, quorum, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -142,11 +144,13 @@ func canProposeSettingMembersRplBond(c *cli.Context, bondAmountWei *big.Int) (*a
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeRPLBondGas(rp, bondAmountWei, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -220,11 +224,13 @@ func canProposeSettingMinipoolUnbondedMax(c *cli.Context, unbondedMinipoolMax ui
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeMinipoolUnbondedMaxGas(rp, unbondedMinipoolMax, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -298,11 +304,13 @@ func canProposeSettingProposalCooldown(c *cli.Context, proposalCooldownTimespan
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeProposalCooldownTimeGas(rp, proposalCooldownTimespan, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -376,11 +384,13 @@ func canProposeSettingProposalVoteTimespan(c *cli.Context, proposalVoteTimespan
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeProposalVoteTimeGas(rp, proposalVoteTimespan, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -454,11 +464,13 @@ func canProposeSettingProposalVoteDelayTimespan(c *cli.Context, proposalDelayTim
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeProposalVoteDelayTimeGas(rp, proposalDelayTimespan, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -532,11 +544,13 @@ func canProposeSettingProposalExecuteTimespan(c *cli.Context, proposalExecuteTim
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeProposalExecuteTimeGas(rp, proposalExecuteTimespan, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -610,11 +624,13 @@ func canProposeSettingProposalActionTimespan(c *cli.Context, proposalActionTimes
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeProposalActionTimeGas(rp, proposalActionTimespan, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -688,11 +704,13 @@ func canProposeSettingScrubPeriod(c *cli.Context, scrubPeriod uint64) (*api.CanP
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeScrubPeriodGas(rp, scrubPeriod, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -766,11 +784,13 @@ func canProposeSettingPromotionScrubPeriod(c *cli.Context, promotionScrubPeriod
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposePromotionScrubPeriodGas(rp, promotionScrubPeriod, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -844,11 +864,13 @@ func canProposeSettingScrubPenaltyEnabled(c *cli.Context, enabled bool) (*api.Ca
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeScrubPenaltyEnabledGas(rp, enabled, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -922,11 +944,13 @@ func canProposeSettingBondReductionWindowStart(c *cli.Context, bondReductionWind
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeBondReductionWindowStartGas(rp, bondReductionWindowStart, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -1000,11 +1024,13 @@ func canProposeSettingBondReductionWindowLength(c *cli.Context, bondReductionWin
 		return nil, err
 	}
 	gasInfo, err := trustednode.EstimateProposeBondReductionWindowLengthGas(rp, bondReductionWindowLength, opts)
+	response.RevertReason, err = services.GetRevertReason(err)
 	if err != nil {
 		return nil, err
 	}
 
 	response.GasInfo = gasInfo
+	response.CanPropose = (response.CanPropose && response.RevertReason == "")
 	return response, nil
 
 }
@@ -0,0 +1,46 @@
+package odao
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getSubmissionRecords(c *cli.Context) (*api.TNDAOSubmissionRecordsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOSubmissionRecordsResponse{}
+
+	// Read the record file written by the watchtower
+	path := cfg.Smartnode.GetWatchtowerSubmissionsPath(true)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		// The watchtower hasn't submitted anything yet
+		return &response, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading watchtower submission record file %s: %w", path, err)
+	}
+
+	var recordFile api.WatchtowerSubmissionRecordFile
+	err = json.Unmarshal(bytes, &recordFile)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing watchtower submission record file %s: %w", path, err)
+	}
+	response.Records = recordFile.Records
+	response.Discrepancies = recordFile.Discrepancies
+
+	// Return response
+	return &response, nil
+
+}
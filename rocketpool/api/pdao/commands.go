@@ -0,0 +1,314 @@
+package pdao
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+	command.Subcommands = append(command.Subcommands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Manage the node's participation in the Rocket Pool protocol DAO",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Get the node's protocol DAO voting status",
+				UsageText: "rocketpool api pdao status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "proposals",
+				Aliases:   []string{"p"},
+				Usage:     "Get the protocol DAO proposals",
+				UsageText: "rocketpool api pdao proposals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getProposals(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "proposal-details",
+				Aliases:   []string{"d"},
+				Usage:     "Get details of a protocol DAO proposal",
+				UsageText: "rocketpool api pdao proposal-details proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getProposal(c, proposalId))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-initialize-voting",
+				Usage:     "Check whether the node can initialize its on-chain voting power",
+				UsageText: "rocketpool api pdao can-initialize-voting",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canInitializeVoting(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "initialize-voting",
+				Aliases:   []string{"iv"},
+				Usage:     "Initialize the node's on-chain voting power, which is required before it can propose or vote",
+				UsageText: "rocketpool api pdao initialize-voting",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(initializeVoting(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-propose-setting",
+				Usage:     "Check whether the node can propose changing a protocol DAO setting",
+				UsageText: "rocketpool api pdao can-propose-setting contract-name setting-name setting-type value",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					value, err := parseSettingValue(c.Args().Get(2), c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canProposeSetting(c, c.Args().Get(0), c.Args().Get(1), value))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "propose-setting",
+				Aliases:   []string{"ps"},
+				Usage:     "Propose changing a protocol DAO setting; the setting type is 'uint', 'bool', or 'address'",
+				UsageText: "rocketpool api pdao propose-setting contract-name setting-name setting-type value",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					value, err := parseSettingValue(c.Args().Get(2), c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(proposeSetting(c, c.Args().Get(0), c.Args().Get(1), value))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-vote-proposal",
+				Usage:     "Check whether the node can vote on a protocol DAO proposal",
+				UsageText: "rocketpool api pdao can-vote-proposal proposal-id direction",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					direction, err := pdao.ParseVoteDirection(c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canVoteOnProposal(c, proposalId, direction))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "vote-proposal",
+				Aliases:   []string{"v"},
+				Usage:     "Vote on a protocol DAO proposal with the voting power delegated to the node; the direction is 'abstain', 'for', 'against', or 'veto'",
+				UsageText: "rocketpool api pdao vote-proposal proposal-id direction",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					direction, err := pdao.ParseVoteDirection(c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(voteOnProposal(c, proposalId, direction))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-override-vote",
+				Usage:     "Check whether the node can override its delegate's vote on a protocol DAO proposal",
+				UsageText: "rocketpool api pdao can-override-vote proposal-id direction",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					direction, err := pdao.ParseVoteDirection(c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canOverrideVote(c, proposalId, direction))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "override-vote",
+				Aliases:   []string{"o"},
+				Usage:     "Override the node's delegate's vote on a protocol DAO proposal with the node's own voting power; the direction is 'abstain', 'for', 'against', or 'veto'",
+				UsageText: "rocketpool api pdao override-vote proposal-id direction",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					direction, err := pdao.ParseVoteDirection(c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(overrideVote(c, proposalId, direction))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-execute-proposal",
+				Usage:     "Check whether the node can execute a protocol DAO proposal",
+				UsageText: "rocketpool api pdao can-execute-proposal proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canExecuteProposal(c, proposalId))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "execute-proposal",
+				Aliases:   []string{"x"},
+				Usage:     "Execute a protocol DAO proposal that passed",
+				UsageText: "rocketpool api pdao execute-proposal proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(executeProposal(c, proposalId))
+					return nil
+
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,117 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canExecuteProposal(c *cli.Context, proposalId uint64) (*api.CanExecutePDAOProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanExecutePDAOProposalResponse{}
+
+	// Check proposal exists
+	proposalCount, err := pdao.GetProposalCount(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.DoesNotExist = (proposalId == 0 || proposalId > proposalCount)
+	if response.DoesNotExist {
+		return &response, nil
+	}
+
+	// Check proposal state
+	proposalState, err := pdao.GetProposalState(rp, proposalId, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.InvalidState = (proposalState != pdao.ProposalState_Succeeded)
+
+	// Update response
+	response.CanExecute = !(response.DoesNotExist || response.InvalidState)
+	if !response.CanExecute {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := pdao.EstimateExecuteProposalGas(rp, proposalId, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func executeProposal(c *cli.Context, proposalId uint64) (*api.ExecutePDAOProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExecutePDAOProposalResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Execute proposal
+	hash, err := pdao.ExecuteProposal(rp, proposalId, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,115 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canInitializeVoting(c *cli.Context) (*api.CanInitializePDAOVotingResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanInitializePDAOVotingResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Check if voting is already initialized
+	wg.Go(func() error {
+		initialized, err := pdao.GetVotingInitialized(rp, nodeAccount.Address, nil)
+		if err == nil {
+			response.AlreadyInitialized = initialized
+		}
+		return err
+	})
+
+	// Get gas estimate
+	wg.Go(func() error {
+		opts, err := w.GetNodeAccountTransactor()
+		if err != nil {
+			return err
+		}
+		gasInfo, err := pdao.EstimateInitializeVotingGas(rp, opts)
+		if err == nil {
+			response.GasInfo = gasInfo
+		}
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Update & return response
+	response.CanInitialize = !response.AlreadyInitialized
+	return &response, nil
+
+}
+
+func initializeVoting(c *cli.Context) (*api.InitializePDAOVotingResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.InitializePDAOVotingResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Initialize voting
+	hash, err := pdao.InitializeVoting(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,167 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canOverrideVote(c *cli.Context, proposalId uint64, direction pdao.VoteDirection) (*api.CanOverrideDelegateVoteOnPDAOProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanOverrideDelegateVoteOnPDAOProposalResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check proposal exists
+	proposalCount, err := pdao.GetProposalCount(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.DoesNotExist = (proposalId == 0 || proposalId > proposalCount)
+	if response.DoesNotExist {
+		return &response, nil
+	}
+
+	// Get the proposal's block
+	blockNumber, err := pdao.GetProposalBlock(rp, proposalId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Check proposal state; delegate votes can only be overridden in the second phase
+	wg.Go(func() error {
+		proposalState, err := pdao.GetProposalState(rp, proposalId, nil)
+		if err == nil {
+			response.InvalidState = (proposalState != pdao.ProposalState_ActivePhase2)
+		}
+		return err
+	})
+
+	// Check if the node has already voted
+	wg.Go(func() error {
+		voteDirection, err := pdao.GetProposalVoteDirection(rp, proposalId, nodeAccount.Address, nil)
+		if err == nil {
+			response.AlreadyVoted = (voteDirection != pdao.VoteDirection_NoVote)
+		}
+		return err
+	})
+
+	// Get the node's own voting power at the proposal's block
+	wg.Go(func() error {
+		var err error
+		response.VotingPower, err = pdao.GetVotingPower(rp, nodeAccount.Address, blockNumber, nil)
+		return err
+	})
+
+	// Get the node's delegate at the proposal's block
+	wg.Go(func() error {
+		var err error
+		response.Delegate, err = pdao.GetDelegate(rp, nodeAccount.Address, blockNumber, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Check data
+	response.NotDelegated = (response.Delegate == nodeAccount.Address)
+	response.InsufficientPower = (response.VotingPower.Sign() == 0)
+	if !response.NotDelegated {
+		response.DelegateVote, err = pdao.GetProposalVoteDirection(rp, proposalId, response.Delegate, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Update response
+	response.CanOverride = !(response.DoesNotExist || response.InvalidState || response.AlreadyVoted || response.NotDelegated || response.InsufficientPower)
+	if !response.CanOverride {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := pdao.EstimateOverrideVoteGas(rp, proposalId, direction, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func overrideVote(c *cli.Context, proposalId uint64, direction pdao.VoteDirection) (*api.OverrideDelegateVoteOnPDAOProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.OverrideDelegateVoteOnPDAOProposalResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Override the delegate's vote
+	hash, err := pdao.OverrideVote(rp, proposalId, direction, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,98 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getProposals(c *cli.Context) (*api.PDAOProposalsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOProposalsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get proposals
+	proposals, err := pdao.GetProposals(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.Proposals = proposals
+
+	// Return response
+	return &response, nil
+
+}
+
+func getProposal(c *cli.Context, id uint64) (*api.PDAOProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOProposalResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the proposal exists
+	proposalCount, err := pdao.GetProposalCount(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if id == 0 || id > proposalCount {
+		return nil, fmt.Errorf("Protocol DAO proposal %d does not exist", id)
+	}
+
+	// Get the proposal
+	proposal, err := pdao.GetProposalDetails(rp, id, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.Proposal = proposal
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,177 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canProposeSetting(c *cli.Context, contractName string, settingName string, value interface{}) (*api.CanProposePDAOSettingResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanProposePDAOSettingResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Data
+	var wg errgroup.Group
+	var rplStake *big.Int
+	var rplLocked *big.Int
+
+	// Check if voting is initialized
+	wg.Go(func() error {
+		initialized, err := pdao.GetVotingInitialized(rp, nodeAccount.Address, nil)
+		if err == nil {
+			response.VotingNotInitialized = !initialized
+		}
+		return err
+	})
+
+	// Get the proposal bond
+	wg.Go(func() error {
+		var err error
+		response.ProposalBond, err = pdao.GetProposalBond(rp, nil)
+		return err
+	})
+
+	// Get the node's staked and locked RPL
+	wg.Go(func() error {
+		var err error
+		rplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rplLocked, err = pdao.GetNodeRPLLocked(rp, nodeAccount.Address, nil)
+		return err
+	})
+
+	// Get the block to snapshot voting power at
+	wg.Go(func() error {
+		var err error
+		response.BlockNumber, err = getProposalBlock(rp)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Check data
+	rplUnlocked := big.NewInt(0).Sub(rplStake, rplLocked)
+	response.InsufficientRpl = (rplUnlocked.Cmp(response.ProposalBond) < 0)
+
+	// Update response
+	response.CanPropose = !(response.VotingNotInitialized || response.InsufficientRpl)
+	if !response.CanPropose {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	payload, err := pdao.GetSettingProposalPayload(rp, contractName, settingName, value)
+	if err != nil {
+		return nil, err
+	}
+	pollard, err := getProposalPollard(rp, response.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	message := fmt.Sprintf("set %s.%s", contractName, settingName)
+	gasInfo, err := pdao.EstimateProposalGas(rp, message, payload, response.BlockNumber, pollard, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func proposeSetting(c *cli.Context, contractName string, settingName string, value interface{}) (*api.ProposePDAOSettingResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ProposePDAOSettingResponse{}
+
+	// Get the payload and the pollard of the voting tree at the latest finalized block
+	payload, err := pdao.GetSettingProposalPayload(rp, contractName, settingName, value)
+	if err != nil {
+		return nil, err
+	}
+	response.BlockNumber, err = getProposalBlock(rp)
+	if err != nil {
+		return nil, err
+	}
+	pollard, err := getProposalPollard(rp, response.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Submit proposal
+	message := fmt.Sprintf("set %s.%s", contractName, settingName)
+	proposalId, hash, err := pdao.SubmitProposal(rp, message, payload, response.BlockNumber, pollard, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.ProposalId = proposalId
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
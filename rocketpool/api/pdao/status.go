@@ -0,0 +1,78 @@
+package pdao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getStatus(c *cli.Context) (*api.PDAOStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOStatusResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the latest block
+	blockNumber, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the latest block: %w", err)
+	}
+	response.BlockNumber = uint32(blockNumber)
+
+	// Data
+	var wg errgroup.Group
+
+	// Check if voting is initialized
+	wg.Go(func() error {
+		var err error
+		response.VotingInitialized, err = pdao.GetVotingInitialized(rp, nodeAccount.Address, nil)
+		return err
+	})
+
+	// Get the node's own voting power
+	wg.Go(func() error {
+		var err error
+		response.VotingPower, err = pdao.GetVotingPower(rp, nodeAccount.Address, response.BlockNumber, nil)
+		return err
+	})
+
+	// Get the node's delegate
+	wg.Go(func() error {
+		var err error
+		response.Delegate, err = pdao.GetDelegate(rp, nodeAccount.Address, response.BlockNumber, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,90 @@
+package pdao
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Get the block to snapshot voting power at for a new proposal.
+// This is the latest finalized block, so anyone challenging the proposal's voting tree builds it from the same state.
+func getProposalBlock(rp *rocketpool.RocketPool) (uint32, error) {
+	header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		return 0, fmt.Errorf("Error getting the latest finalized block: %w", err)
+	}
+	return uint32(header.Number.Uint64()), nil
+}
+
+// Build the network voting tree at a block, and get the addresses of the nodes its leaves belong to
+func getVotingTree(rp *rocketpool.RocketPool, blockNumber uint32) (*pdao.VotingTree, []common.Address, error) {
+
+	// Data
+	var wg errgroup.Group
+	var nodeAddresses []common.Address
+	var votingPowers []*big.Int
+	var depthPerRound uint64
+
+	// Get the voting power delegated to each node
+	wg.Go(func() error {
+		var err error
+		nodeAddresses, votingPowers, err = pdao.GetNodeVotingPowers(rp, blockNumber)
+		return err
+	})
+
+	// Get the depth of the pollard
+	wg.Go(func() error {
+		var err error
+		depthPerRound, err = pdao.GetDepthPerRound(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return pdao.NewVotingTree(votingPowers, depthPerRound), nodeAddresses, nil
+
+}
+
+// Get the index of a node's leaf in the voting tree
+func getNodeIndex(nodeAddresses []common.Address, nodeAddress common.Address) (uint64, bool) {
+	for i, address := range nodeAddresses {
+		if address == nodeAddress {
+			return uint64(i), true
+		}
+	}
+	return 0, false
+}
+
+// Parse the value of a setting proposal by the setting's type
+func parseSettingValue(settingType string, value string) (interface{}, error) {
+	switch settingType {
+	case "uint":
+		return cliutils.ValidateBigInt("setting value", value)
+	case "bool":
+		return cliutils.ValidateBool("setting value", value)
+	case "address":
+		return cliutils.ValidateAddress("setting value", value)
+	default:
+		return nil, fmt.Errorf("Invalid setting type '%s'; must be 'uint', 'bool', or 'address'", settingType)
+	}
+}
+
+// Get the pollard of the network voting tree at a block that a new proposal is submitted with
+func getProposalPollard(rp *rocketpool.RocketPool, blockNumber uint32) ([]pdao.Node, error) {
+	tree, _, err := getVotingTree(rp, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return tree.GetPollard(1)
+}
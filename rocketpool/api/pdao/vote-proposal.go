@@ -0,0 +1,195 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canVoteOnProposal(c *cli.Context, proposalId uint64, direction pdao.VoteDirection) (*api.CanVoteOnPDAOProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanVoteOnPDAOProposalResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check proposal exists
+	proposalCount, err := pdao.GetProposalCount(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.DoesNotExist = (proposalId == 0 || proposalId > proposalCount)
+	if response.DoesNotExist {
+		return &response, nil
+	}
+
+	// Sync
+	var wg errgroup.Group
+	var votingPower *big.Int
+	var nodeIndex uint64
+	var witness []pdao.Node
+
+	// Check proposal state
+	wg.Go(func() error {
+		proposalState, err := pdao.GetProposalState(rp, proposalId, nil)
+		if err == nil {
+			response.InvalidState = (proposalState != pdao.ProposalState_ActivePhase1)
+		}
+		return err
+	})
+
+	// Check if the node has already voted
+	wg.Go(func() error {
+		voteDirection, err := pdao.GetProposalVoteDirection(rp, proposalId, nodeAccount.Address, nil)
+		if err == nil {
+			response.AlreadyVoted = (voteDirection != pdao.VoteDirection_NoVote)
+		}
+		return err
+	})
+
+	// Get the node's delegated voting power and its proof
+	wg.Go(func() error {
+		var err error
+		votingPower, nodeIndex, witness, err = getVotingPowerProof(rp, proposalId, nodeAccount.Address)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Check data
+	response.VotingPower = votingPower
+	response.InsufficientPower = (votingPower.Sign() == 0)
+
+	// Update response
+	response.CanVote = !(response.DoesNotExist || response.InvalidState || response.AlreadyVoted || response.InsufficientPower)
+	if !response.CanVote {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := pdao.EstimateVoteOnProposalGas(rp, proposalId, direction, votingPower, nodeIndex, witness, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func voteOnProposal(c *cli.Context, proposalId uint64, direction pdao.VoteDirection) (*api.VoteOnPDAOProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VoteOnPDAOProposalResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's delegated voting power and its proof
+	votingPower, nodeIndex, witness, err := getVotingPowerProof(rp, proposalId, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Vote on proposal
+	hash, err := pdao.VoteOnProposal(rp, proposalId, direction, votingPower, nodeIndex, witness, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the voting power delegated to a node at a proposal's block, along with the node's index and the witness proving
+// its leaf against the proposal's voting tree
+func getVotingPowerProof(rp *rocketpool.RocketPool, proposalId uint64, nodeAddress common.Address) (*big.Int, uint64, []pdao.Node, error) {
+
+	// Build the voting tree at the proposal's block
+	blockNumber, err := pdao.GetProposalBlock(rp, proposalId, nil)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	tree, nodeAddresses, err := getVotingTree(rp, blockNumber)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	// Nodes registered after the proposal was made have no voting power on it
+	nodeIndex, exists := getNodeIndex(nodeAddresses, nodeAddress)
+	if !exists {
+		return big.NewInt(0), 0, nil, nil
+	}
+
+	// Get the proof
+	witness, err := tree.GetProof(nodeIndex)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	return tree.GetLeaf(nodeIndex).Sum, nodeIndex, witness, nil
+
+}
@@ -89,6 +89,7 @@ func canProcessQueue(c *cli.Context) (*api.CanProcessQueueResponse, error) {
 		if err == nil {
 			response.GasInfo = gasInfo
 		}
+		response.RevertReason, err = services.GetRevertReason(err)
 		return err
 	})
 
@@ -103,9 +104,9 @@ func canProcessQueue(c *cli.Context) (*api.CanProcessQueueResponse, error) {
 		response.InsufficientDepositBalance = (depositPoolBalance.Cmp(nextMinipoolCapacity) < 0)
 
 		// Update & return response
-		response.CanProcess = !(response.AssignDepositsDisabled || response.NoMinipoolsAvailable || response.InsufficientDepositBalance)
+		response.CanProcess = !(response.AssignDepositsDisabled || response.NoMinipoolsAvailable || response.InsufficientDepositBalance || response.RevertReason != "")
 	} else {
-		response.CanProcess = !response.AssignDepositsDisabled
+		response.CanProcess = !(response.AssignDepositsDisabled || response.RevertReason != "")
 	}
 	return &response, nil
 
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+// Global flags that can be set when running a command in-process.
+// Only flags that are read on every command are allowed; flags that configure the clients (such as use-protected-api,
+// ignore-sync-check, and force-fallbacks) only take effect when the shared clients are first created, so they're rejected.
+var inProcessGlobalFlags = map[string]bool{
+	"maxFee":     true,
+	"maxPrioFee": true,
+	"gasLimit":   true,
+	"nonce":      true,
+	"no-cache":   true,
+}
+
+// Commands that can't be run in-process
+var inProcessExcludedCommands = map[string]bool{
+	"server": true,
+	"batch":  true,
+}
+
+// The key of the app metadata that's set on commands run in-process, so they can refresh per-command state
+const inProcessMetadataKey string = "inProcess"
+
+// A request to run an API command in-process
+type commandRequest struct {
+	Command     []string          `json:"command"`
+	Version     uint64            `json:"version,omitempty"`
+	Args        []string          `json:"args"`
+	Flags       map[string]string `json:"flags"`
+	GlobalFlags map[string]string `json:"globalFlags"`
+}
+
+// Check if a path matches a runnable API command
+func hasCommand(command *cli.Command, path []string) bool {
+	_, exists := resolveCommand(command, path)
+	return exists
+}
+
+// Get the full path of the runnable API command that a path (which may use aliases) matches
+func resolveCommand(command *cli.Command, path []string) ([]string, bool) {
+	commands := command.Subcommands
+	resolved := []string{}
+	for i, name := range path {
+		var match *cli.Command
+		for j := range commands {
+			if commands[j].HasName(name) {
+				match = &commands[j]
+				break
+			}
+		}
+		if match == nil || inProcessExcludedCommands[match.Name] {
+			return nil, false
+		}
+		resolved = append(resolved, match.Name)
+		if i == len(path)-1 {
+			return resolved, match.Action != nil && len(match.Subcommands) == 0
+		}
+		commands = match.Subcommands
+	}
+	return nil, false
+}
+
+// Check if the args of an API command (which may use aliases) start with the path of a read-only command
+func isReadOnlyArgs(command *cli.Command, args []string) bool {
+	for _, length := range []int{1, 2} {
+		if len(args) < length {
+			break
+		}
+		if path, exists := resolveCommand(command, args[:length]); exists {
+			return apitypes.IsReadOnlyCommand(path)
+		}
+	}
+	return false
+}
+
+// Make sure a request was built for the version of the command this daemon runs; requests without a version are always accepted
+func checkCommandVersion(path []string, request commandRequest) error {
+	version := apitypes.GetCommandVersion(path)
+	if request.Version != 0 && request.Version != version {
+		return fmt.Errorf("command %s is at version %d but the request was built for version %d; update your client to match this node's Smartnode version", strings.Join(path, " "), version, request.Version)
+	}
+	return nil
+}
+
+// Get the versions of all of the runnable API commands, by their full path
+func getCommandVersions(command *cli.Command) map[string]uint64 {
+	versions := map[string]uint64{}
+	var addCommands func(commands []cli.Command, parent []string)
+	addCommands = func(commands []cli.Command, parent []string) {
+		for _, subcommand := range commands {
+			if inProcessExcludedCommands[subcommand.Name] {
+				continue
+			}
+			path := append(append([]string{}, parent...), subcommand.Name)
+			if len(subcommand.Subcommands) > 0 {
+				addCommands(subcommand.Subcommands, path)
+			} else if subcommand.Action != nil {
+				versions[strings.Join(path, " ")] = apitypes.GetCommandVersion(path)
+			}
+		}
+	}
+	addCommands(command.Subcommands, nil)
+	return versions
+}
+
+// Build the command line for running an API command in-process
+func buildCommandLine(app *cli.App, command *cli.Command, path []string, request commandRequest) ([]string, error) {
+	args := []string{app.Name}
+	for name, value := range request.GlobalFlags {
+		if !inProcessGlobalFlags[name] {
+			return nil, fmt.Errorf("global flag %s cannot be set for this command", name)
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", name, value))
+	}
+	args = append(args, command.Name)
+	args = append(args, path...)
+	for name, value := range request.Flags {
+		args = append(args, fmt.Sprintf("--%s=%s", name, value))
+	}
+	args = append(args, request.Args...)
+	return args, nil
+}
+
+// Run an API command in-process and return its response.
+// Each command runs on its own copy of the app, with its own response writer and command tree, so read-only commands
+// can run at once; callers must not run a command that changes state at the same time as any other command.
+func runCommandInProcess(app *cli.App, args []string) []byte {
+	var response bytes.Buffer
+	commandApp := *app
+	commandApp.Writer = &response
+	commandApp.Commands = copyCommands(app.Commands)
+	commandApp.Metadata = map[string]interface{}{
+		inProcessMetadataKey: true,
+	}
+	if err := commandApp.Run(args); err != nil {
+		api.NewResponsePrinter(&response).PrintErrorResponse(err)
+	}
+	return bytes.TrimSpace(response.Bytes())
+}
+
+// Check if a command is being run in-process
+func isRunningInProcess(c *cli.Context) bool {
+	inProcess, _ := c.App.Metadata[inProcessMetadataKey].(bool)
+	return inProcess
+}
+
+// Copy a command tree, so running a command doesn't change the shared one.
+// Running a command appends the help command and flag to the slices of its app and sets the name paths of its
+// subcommands, which would race between commands running at once.
+func copyCommands(commands []cli.Command) []cli.Command {
+	if commands == nil {
+		return nil
+	}
+	copied := make([]cli.Command, len(commands))
+	for i, command := range commands {
+		command.Flags = append([]cli.Flag(nil), command.Flags...)
+		command.Subcommands = copyCommands(command.Subcommands)
+		copied[i] = command
+	}
+	return copied
+}
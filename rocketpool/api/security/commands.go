@@ -0,0 +1,303 @@
+package security
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+	command.Subcommands = append(command.Subcommands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Manage the node's membership in the Rocket Pool security council",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Get the node's security council status",
+				UsageText: "rocketpool api security status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "proposals",
+				Aliases:   []string{"p"},
+				Usage:     "Get the security council proposals",
+				UsageText: "rocketpool api security proposals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getProposals(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-join",
+				Usage:     "Check whether the node can join the security council",
+				UsageText: "rocketpool api security can-join",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canJoin(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "join",
+				Aliases:   []string{"j"},
+				Usage:     "Join the security council (requires an executed invite from the protocol DAO)",
+				UsageText: "rocketpool api security join",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(join(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-request-leave",
+				Usage:     "Check whether the node can request to leave the security council",
+				UsageText: "rocketpool api security can-request-leave",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canRequestLeave(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "request-leave",
+				Aliases:   []string{"r"},
+				Usage:     "Request to leave the security council",
+				UsageText: "rocketpool api security request-leave",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(requestLeave(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-leave",
+				Usage:     "Check whether the node can leave the security council",
+				UsageText: "rocketpool api security can-leave",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canLeave(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "leave",
+				Aliases:   []string{"l"},
+				Usage:     "Leave the security council after requesting to and waiting for the leave window",
+				UsageText: "rocketpool api security leave",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(leave(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-propose-disable-setting",
+				Usage:     "Check whether the node can propose disabling a boolean protocol setting",
+				UsageText: "rocketpool api security can-propose-disable-setting contract-name setting-name",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canProposeDisableSetting(c, c.Args().Get(0), c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "propose-disable-setting",
+				Aliases:   []string{"d"},
+				Usage:     "Propose disabling a boolean protocol setting, such as deposits, in an emergency",
+				UsageText: "rocketpool api security propose-disable-setting contract-name setting-name",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(proposeDisableSetting(c, c.Args().Get(0), c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-vote-proposal",
+				Usage:     "Check whether the node can vote on a security council proposal",
+				UsageText: "rocketpool api security can-vote-proposal proposal-id support",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					support, err := cliutils.ValidateBool("support", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canVoteOnProposal(c, proposalId, support))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "vote-proposal",
+				Aliases:   []string{"v"},
+				Usage:     "Vote on a security council proposal",
+				UsageText: "rocketpool api security vote-proposal proposal-id support",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					support, err := cliutils.ValidateBool("support", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(voteOnProposal(c, proposalId, support))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-execute-proposal",
+				Usage:     "Check whether the node can execute a security council proposal",
+				UsageText: "rocketpool api security can-execute-proposal proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canExecuteProposal(c, proposalId))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "execute-proposal",
+				Aliases:   []string{"x"},
+				Usage:     "Execute a security council proposal",
+				UsageText: "rocketpool api security execute-proposal proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(executeProposal(c, proposalId))
+					return nil
+
+				},
+			},
+		},
+	})
+}
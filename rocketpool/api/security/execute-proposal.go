@@ -0,0 +1,113 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canExecuteProposal(c *cli.Context, proposalId uint64) (*api.CanExecuteSecurityProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanExecuteSecurityProposalResponse{}
+
+	// Check proposal exists
+	exists, err := getProposalExists(c, proposalId)
+	if err != nil {
+		return nil, err
+	}
+	response.DoesNotExist = !exists
+	if response.DoesNotExist {
+		return &response, nil
+	}
+
+	// Check proposal state
+	proposalState, err := dao.GetProposalState(rp, proposalId, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.InvalidState = (proposalState != rptypes.Succeeded)
+
+	// Update response
+	response.CanExecute = !response.InvalidState
+	if !response.CanExecute {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := pdao.EstimateExecuteSecurityProposalGas(rp, proposalId, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func executeProposal(c *cli.Context, proposalId uint64) (*api.ExecuteSecurityProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExecuteSecurityProposalResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Execute proposal
+	hash, err := pdao.ExecuteSecurityProposal(rp, proposalId, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,166 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canRequestLeave(c *cli.Context) (*api.CanRequestLeaveSecurityCouncilResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanRequestLeaveSecurityCouncilResponse{}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := pdao.EstimateRequestLeaveSecurityCouncilGas(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Update & return response
+	response.CanRequestLeave = true
+	return &response, nil
+
+}
+
+func requestLeave(c *cli.Context) (*api.RequestLeaveSecurityCouncilResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RequestLeaveSecurityCouncilResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Request to leave
+	hash, err := pdao.RequestLeaveSecurityCouncil(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+func canLeave(c *cli.Context) (*api.CanLeaveSecurityCouncilResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanLeaveSecurityCouncilResponse{}
+
+	// Get gas estimate; this fails if the node hasn't requested to leave or its wait hasn't passed
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := pdao.EstimateLeaveSecurityCouncilGas(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Update & return response
+	response.CanLeave = true
+	return &response, nil
+
+}
+
+func leave(c *cli.Context) (*api.LeaveSecurityCouncilResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.LeaveSecurityCouncilResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Leave
+	hash, err := pdao.LeaveSecurityCouncil(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
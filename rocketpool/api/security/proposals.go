@@ -0,0 +1,66 @@
+package security
+
+import (
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getProposals(c *cli.Context) (*api.SecurityProposalsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SecurityProposalsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get proposals
+	proposals, err := dao.GetDAOProposalsWithMember(rp, pdao.SecurityCouncilDAOName, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.Proposals = proposals
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check whether a proposal exists and belongs to the security council; proposal IDs are shared with the oracle DAO
+func getProposalExists(c *cli.Context, proposalId uint64) (bool, error) {
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return false, err
+	}
+	proposalCount, err := dao.GetProposalCount(rp, nil)
+	if err != nil {
+		return false, err
+	}
+	if proposalId == 0 || proposalId > proposalCount {
+		return false, nil
+	}
+	daoName, err := dao.GetProposalDAO(rp, proposalId, nil)
+	if err != nil {
+		return false, err
+	}
+	return (daoName == pdao.SecurityCouncilDAOName), nil
+}
@@ -0,0 +1,102 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canProposeDisableSetting(c *cli.Context, contractName string, settingName string) (*api.CanProposeSecurityDisableSettingResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanProposeSecurityDisableSettingResponse{}
+
+	// Get gas estimate; the contract rejects settings the security council isn't allowed to change
+	payload, err := pdao.GetSecurityDisableSettingPayload(rp, contractName, settingName)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	message := fmt.Sprintf("disable %s.%s", contractName, settingName)
+	gasInfo, err := pdao.EstimateSecurityProposalGas(rp, message, payload, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Update & return response
+	response.CanPropose = true
+	return &response, nil
+
+}
+
+func proposeDisableSetting(c *cli.Context, contractName string, settingName string) (*api.ProposeSecurityDisableSettingResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ProposeSecurityDisableSettingResponse{}
+
+	// Get the payload
+	payload, err := pdao.GetSecurityDisableSettingPayload(rp, contractName, settingName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Submit proposal
+	message := fmt.Sprintf("disable %s.%s", contractName, settingName)
+	proposalId, hash, err := pdao.SubmitSecurityProposal(rp, message, payload, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.ProposalId = proposalId
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
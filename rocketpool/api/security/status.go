@@ -0,0 +1,70 @@
+package security
+
+import (
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getStatus(c *cli.Context) (*api.SecurityStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SecurityStatusResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Check membership
+	wg.Go(func() error {
+		var err error
+		response.IsMember, err = pdao.GetSecurityMemberExists(rp, nodeAccount.Address, nil)
+		return err
+	})
+
+	// Check for an invite
+	wg.Go(func() error {
+		invitedTime, err := pdao.GetSecurityMemberProposalExecutedTime(rp, "invited", nodeAccount.Address, nil)
+		if err == nil {
+			response.IsInvited = (invitedTime > 0)
+		}
+		return err
+	})
+
+	// Get the member count
+	wg.Go(func() error {
+		var err error
+		response.TotalMembers, err = pdao.GetSecurityMemberCount(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,126 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/dao"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canVoteOnProposal(c *cli.Context, proposalId uint64, support bool) (*api.CanVoteOnSecurityProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanVoteOnSecurityProposalResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check proposal exists
+	exists, err := getProposalExists(c, proposalId)
+	if err != nil {
+		return nil, err
+	}
+	response.DoesNotExist = !exists
+	if response.DoesNotExist {
+		return &response, nil
+	}
+
+	// Check proposal state
+	proposalState, err := dao.GetProposalState(rp, proposalId, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.InvalidState = (proposalState != rptypes.Active)
+
+	// Check if member has already voted
+	hasVoted, err := dao.GetProposalMemberVoted(rp, proposalId, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.AlreadyVoted = hasVoted
+
+	// Update response
+	response.CanVote = !(response.InvalidState || response.AlreadyVoted)
+	if !response.CanVote {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := pdao.EstimateVoteOnSecurityProposalGas(rp, proposalId, support, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func voteOnProposal(c *cli.Context, proposalId uint64, support bool) (*api.VoteOnSecurityProposalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeSecurityMember(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VoteOnSecurityProposalResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Vote on proposal
+	hash, err := pdao.VoteOnSecurityProposal(rp, proposalId, support, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	ApiServerColor             = color.FgHiCyan
+	ApiTokenLength       int   = 32
+	EventsPath                 = "/events"
+	VersionPath                = "/version"
+	MaxRequestBodyLength int64 = 1024 * 1024
+)
+
+// The API server, which runs API commands in-process on behalf of HTTP clients
+type apiServer struct {
+	c                   *cli.Context
+	app                 *cli.App
+	command             *cli.Command
+	token               string
+	roleTokens          []roleToken
+	unauthenticatedRole cfgtypes.ApiRole
+	events              *eventHub
+	log                 log.ColorLogger
+	lock                sync.RWMutex
+}
+
+// Run the API server
+func runServer(c *cli.Context, app *cli.App, command *cli.Command) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the listener settings
+	socketPath := c.String("socket")
+	if socketPath == "" {
+		socketPath = cfg.Smartnode.GetApiSocketPath(true)
+	}
+	port := c.Uint("port")
+
+	// Load the API token
+	token, err := loadApiToken(cfg.Smartnode.GetApiTokenPath(true))
+	if err != nil {
+		return err
+	}
+
+	roleTokens, err := parseRoleTokens(cfg.Smartnode.ApiRoleTokens.Value.(string))
+	if err != nil {
+		return err
+	}
+
+	server := &apiServer{
+		c:                   c,
+		app:                 app,
+		command:             command,
+		token:               token,
+		roleTokens:          roleTokens,
+		unauthenticatedRole: cfg.Smartnode.ApiUnauthenticatedRole.Value.(cfgtypes.ApiRole),
+		events:              newEventHub(),
+		log:                 log.NewModuleLogger(log.ModuleApiServer, log.LevelInfo, ApiServerColor),
+	}
+
+	// Start polling for events
+	poller := &eventPoller{
+		c:      c,
+		events: server.events,
+		log:    server.log,
+		lock:   &server.lock,
+	}
+	go poller.run()
+
+	// Start the listeners
+	errs := make(chan error, 2)
+	if socketPath != "" {
+		_ = os.Remove(socketPath)
+		listener, err := net.Listen("unix", socketPath)
+		if err != nil {
+			return fmt.Errorf("error listening on API socket %s: %w", socketPath, err)
+		}
+		if err := os.Chmod(socketPath, 0660); err != nil {
+			return fmt.Errorf("error setting permissions on API socket %s: %w", socketPath, err)
+		}
+		server.log.Printlnf("Serving the API on %s", socketPath)
+		go func() {
+			errs <- http.Serve(listener, server)
+		}()
+	}
+	if port != 0 {
+		address := fmt.Sprintf("127.0.0.1:%d", port)
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return fmt.Errorf("error listening on API port %s: %w", address, err)
+		}
+		server.log.Printlnf("Serving the API on %s", address)
+		go func() {
+			errs <- http.Serve(listener, server)
+		}()
+	}
+
+	return <-errs
+
+}
+
+// Handle an HTTP request by running the API command matching its path
+func (s *apiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set(apitypes.ResponseSchemaVersionHeader, strconv.FormatUint(apitypes.ResponseSchemaVersion, 10))
+
+	// Check the authentication
+	role, valid := s.getRole(r)
+	if !valid || role == cfgtypes.ApiRole_None {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Handle version requests
+	if r.URL.Path == VersionPath {
+		s.serveVersion(w)
+		return
+	}
+
+	// Handle event stream subscriptions
+	if r.URL.Path == EventsPath {
+		if !isRoleAllowed(role, nil) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.serveEvents(w, r)
+		return
+	}
+
+	// Check the method
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Get the command path
+	path, exists := resolveCommand(s.command, strings.Split(strings.Trim(r.URL.Path, "/"), "/"))
+	if !exists {
+		http.Error(w, fmt.Sprintf("unknown command %s", r.URL.Path), http.StatusNotFound)
+		return
+	}
+	if !isRoleAllowed(role, path) {
+		http.Error(w, fmt.Sprintf("the %s role can't run %s", role, r.URL.Path), http.StatusForbidden)
+		return
+	}
+
+	// Decode the request body
+	var request commandRequest
+	body := http.MaxBytesReader(w, r.Body, MaxRequestBodyLength)
+	if err := json.NewDecoder(body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, fmt.Sprintf("invalid request body: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	// Check the command version
+	if err := checkCommandVersion(path, request); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.Header().Set(apitypes.CommandVersionHeader, strconv.FormatUint(apitypes.GetCommandVersion(path), 10))
+
+	// Build the command line
+	args, err := buildCommandLine(s.app, s.command, path, request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Run the command; read-only commands can run concurrently, but commands that change state run on their own
+	var response []byte
+	if apitypes.IsReadOnlyCommand(path) {
+		s.lock.RLock()
+		response = runCommandInProcess(s.app, args)
+		s.lock.RUnlock()
+	} else {
+		s.lock.Lock()
+		response = runCommandInProcess(s.app, args)
+		s.lock.Unlock()
+	}
+	s.watchResponseTransaction(response)
+
+	// Write the response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(response)
+
+}
+
+// Report the API server's versions
+func (s *apiServer) serveVersion(w http.ResponseWriter) {
+	var response bytes.Buffer
+	api.NewResponsePrinter(&response).PrintResponse(&apitypes.ApiVersionResponse{
+		SchemaVersion:    apitypes.ResponseSchemaVersion,
+		SmartnodeVersion: shared.RocketPoolVersion,
+		CommandVersions:  getCommandVersions(s.command),
+	}, nil)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(response.Bytes())
+}
+
+// Load the API token, creating it if it doesn't exist yet
+func loadApiToken(path string) (string, error) {
+	tokenBytes, err := os.ReadFile(path)
+	if err == nil {
+		return strings.TrimSpace(string(tokenBytes)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("error reading API token %s: %w", path, err)
+	}
+
+	buffer := make([]byte, ApiTokenLength)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", fmt.Errorf("error generating API token: %w", err)
+	}
+	token := hex.EncodeToString(buffer)
+	if err := os.WriteFile(path, []byte(token), 0600); err != nil {
+		return "", fmt.Errorf("error saving API token %s: %w", path, err)
+	}
+	return token, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/calltrace"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Check whether contract call tracing is enabled
+func getCallTracing(c *cli.Context) (*api.CallTracingStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CallTracingStatusResponse{
+		Enabled: calltrace.IsEnabled(cfg.Smartnode.GetCallTraceFlagPath(true)),
+		LogPath: cfg.Smartnode.GetCallTraceLogPath(false),
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Turn contract call tracing on or off; the daemons pick up the change within a few seconds
+func setCallTracing(c *cli.Context, enabled bool) (*api.CallTracingStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write or remove the flag the daemons check
+	err = calltrace.SetEnabled(cfg.Smartnode.GetCallTraceFlagPath(true), enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CallTracingStatusResponse{
+		Enabled: enabled,
+		LogPath: cfg.Smartnode.GetCallTraceLogPath(false),
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Checks that two independent checkpoint sync providers agree on the latest finalized state
+func verifyCheckpointSync(c *cli.Context, providerUrl string, verificationUrl string) (*api.VerifyCheckpointSyncResponse, error) {
+
+	providerUrl = strings.TrimRight(providerUrl, "/")
+	verificationUrl = strings.TrimRight(verificationUrl, "/")
+	if providerUrl == verificationUrl {
+		return nil, fmt.Errorf("the checkpoint sync URL and the verification URL are the same; please use a verification URL from a different provider")
+	}
+
+	// Response
+	response := api.VerifyCheckpointSyncResponse{}
+
+	// Get the finalized block from the provider
+	provider := client.NewStandardHttpClient(providerUrl)
+	header, exists, err := provider.GetBeaconBlockHeader("finalized")
+	if err != nil {
+		return nil, fmt.Errorf("error getting the finalized block from the checkpoint sync provider: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("the checkpoint sync provider doesn't have a finalized block")
+	}
+	response.Slot = header.Slot
+	response.BlockRoot = header.Root
+	response.StateRoot = header.StateRoot
+
+	// Get the same block from the verification source
+	verifier := client.NewStandardHttpClient(verificationUrl)
+	verificationHeader, exists, err := verifier.GetBeaconBlockHeader(header.Root.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("error getting block %s from the verification provider: %w", header.Root.Hex(), err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("the verification provider doesn't have the checkpoint sync provider's finalized block %s (slot %d); it may not have finalized it yet, or one of the providers may be on a different chain", header.Root.Hex(), header.Slot)
+	}
+	response.VerificationStateRoot = verificationHeader.StateRoot
+
+	// Both have to agree on the state at the checkpoint
+	response.Verified = (verificationHeader.Slot == header.Slot && verificationHeader.StateRoot == header.StateRoot)
+
+	// Return response
+	return &response, nil
+
+}
@@ -27,7 +27,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(terminateDataFolder(c))
+					api.GetResponsePrinter(c).PrintResponse(terminateDataFolder(c))
 					return nil
 
 				},
@@ -46,7 +46,234 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getClientStatus(c))
+					api.GetResponsePrinter(c).PrintResponse(getClientStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-effective-config",
+				Usage:     "Gets every config parameter's value after environment variable and flag overrides, and where it came from",
+				UsageText: "rocketpool api service get-effective-config",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getEffectiveConfig(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "prepare-shutdown",
+				Usage:     "Stop the node and watchtower daemons from starting new tasks until they're restarted or the shutdown is cancelled, so they can be shut down cleanly",
+				UsageText: "rocketpool api service prepare-shutdown",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(prepareShutdown(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "cancel-shutdown",
+				Usage:     "Cancel a prepared shutdown, so the node and watchtower daemons resume starting new tasks",
+				UsageText: "rocketpool api service cancel-shutdown",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(cancelShutdown(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "verify-checkpoint-sync",
+				Usage:     "Check that a checkpoint sync provider and a second, independent provider agree on the latest finalized state",
+				UsageText: "rocketpool api service verify-checkpoint-sync url verification-url",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(verifyCheckpointSync(c, c.Args().Get(0), c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "reload-config",
+				Usage:     "Make the node and watchtower daemons reload the settings that can change while they're running before their next task pass",
+				UsageText: "rocketpool api service reload-config",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(reloadConfig(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "query-logs",
+				Usage:     "Get the recent entries from the node and watchtower daemons' log files, filtered by task, module, time, and level",
+				UsageText: "rocketpool api service query-logs [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "process, p",
+						Usage: "Only read the logs of this daemon (node or watchtower)",
+					},
+					cli.StringFlag{
+						Name:  "task, t",
+						Usage: "Only get the entries of this task, such as submit-rewards-tree",
+					},
+					cli.StringFlag{
+						Name:  "module, m",
+						Usage: "Only get the entries of this module, such as rewards",
+					},
+					cli.StringFlag{
+						Name:  "since, s",
+						Usage: "Only get entries written after this time, as a duration before now (e.g. 1h) or an RFC 3339 time",
+					},
+					cli.StringFlag{
+						Name:  "level, l",
+						Usage: "Only get entries at this level or above",
+					},
+					cli.Uint64Flag{
+						Name:  "limit",
+						Usage: "The maximum number of entries to get, starting from the most recent; 0 for no limit",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(queryLogs(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-log-levels",
+				Usage:     "Get the log levels of the node and watchtower daemons",
+				UsageText: "rocketpool api service get-log-levels",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getLogLevels(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "set-log-level",
+				Usage:     "Change the log level of a module, or of every module without its own level if the module is '*', while the daemons are running",
+				UsageText: "rocketpool api service set-log-level module level",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(setLogLevel(c, c.Args().Get(0), c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "reset-log-levels",
+				Usage:     "Return a module, or every module if none is given, to the log levels in the config",
+				UsageText: "rocketpool api service reset-log-levels [module]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.NArg() > 1 {
+						return cliutils.ValidateArgCount(c, 1)
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(resetLogLevels(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-call-tracing",
+				Usage:     "Checks whether contract call tracing is enabled",
+				UsageText: "rocketpool api service get-call-tracing",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getCallTracing(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "set-call-tracing",
+				Usage:     "Turns contract call tracing on or off for the Smartnode's daemons",
+				UsageText: "rocketpool api service set-call-tracing enabled",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					enabled, err := cliutils.ValidateBool("enabled", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(setCallTracing(c, enabled))
 					return nil
 
 				},
@@ -64,7 +291,54 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(restartVc(c))
+					api.GetResponsePrinter(c).PrintResponse(restartVc(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-prune-rewards",
+				Usage:     "Lists the rewards files that are older than the provided number of intervals and can be pruned",
+				UsageText: "rocketpool api service can-prune-rewards retention",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					retention, err := cliutils.ValidatePositiveUint("retention", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canPruneRewardsFiles(c, retention))
+					return nil
+
+				},
+			},
+			{
+				Name:      "prune-rewards",
+				Usage:     "Deletes or archives the rewards files that are older than the provided number of intervals",
+				UsageText: "rocketpool api service prune-rewards retention mode",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					retention, err := cliutils.ValidatePositiveUint("retention", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					mode, err := cliutils.ValidateRewardsPruneMode("mode", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(pruneRewardsFiles(c, retention, mode))
 					return nil
 
 				},
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the config the daemon is actually running with, after environment variable and flag overrides, and where each value came from
+func getEffectiveConfig(c *cli.Context) (*api.GetEffectiveConfigResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetEffectiveConfigResponse{
+		Settings: []api.EffectiveConfigSetting{},
+	}
+
+	// Sort the sections so the output is repeatable
+	sections := cfg.GetSections()
+	sectionNames := []string{}
+	for name := range sections {
+		sectionNames = append(sectionNames, name)
+	}
+	sort.Strings(sectionNames)
+
+	for _, section := range sectionNames {
+		for _, param := range sections[section] {
+			setting := api.EffectiveConfigSetting{
+				Section: section,
+				ID:      param.ID,
+				Value:   fmt.Sprint(param.Value),
+				Source:  string(cfg.GetSettingSource(section, param.ID)),
+				EnvVar:  config.GetOverrideEnvVar(section, param.ID),
+			}
+			if param.IsSecret && setting.Value != "" {
+				setting.Value = "<redacted>"
+				setting.Redacted = true
+			}
+			response.Settings = append(response.Settings, setting)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Get the log levels from the config, along with the ones changed at runtime
+func getLogLevels(c *cli.Context) (*api.GetLogLevelsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetLogLevelsResponse{
+		Format:       string(cfg.Smartnode.LogFormat.Value.(cfgtypes.LogFormat)),
+		DefaultLevel: string(cfg.Smartnode.LogLevel.Value.(cfgtypes.LogLevel)),
+		Modules:      log.GetModules(),
+	}
+
+	// Get the module levels from the config
+	moduleLevels, err := log.ParseModuleLevels(cfg.Smartnode.ModuleLogLevels.Value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the module log levels: %w", err)
+	}
+	response.ModuleLevels = getLevelNames(moduleLevels)
+
+	// Get the overrides
+	overrides, err := log.LoadLevelOverrides(cfg.Smartnode.GetLogLevelOverridesPath(true))
+	if err != nil {
+		return nil, err
+	}
+	response.Overrides = getLevelNames(overrides)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Change the level of a module, or of every module without its own level, while the daemons are running
+func setLogLevel(c *cli.Context, module string, levelName string) (*api.SetLogLevelResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetLogLevelResponse{}
+
+	// Check the module and level
+	if err := validateModule(module); err != nil {
+		return nil, err
+	}
+	level, err := log.ParseLevel(levelName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the override for the daemons to pick up
+	path := cfg.Smartnode.GetLogLevelOverridesPath(true)
+	overrides, err := log.LoadLevelOverrides(path)
+	if err != nil {
+		return nil, err
+	}
+	overrides[module] = level
+	err = log.SaveLevelOverrides(path, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Clear the runtime level of a module, or of every module if none is given, so they go back to the levels in the config
+func resetLogLevels(c *cli.Context, module string) (*api.SetLogLevelResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetLogLevelResponse{}
+
+	// Remove the overrides
+	path := cfg.Smartnode.GetLogLevelOverridesPath(true)
+	overrides := map[string]log.Level{}
+	if module != "" {
+		if err := validateModule(module); err != nil {
+			return nil, err
+		}
+		overrides, err = log.LoadLevelOverrides(path)
+		if err != nil {
+			return nil, err
+		}
+		delete(overrides, module)
+	}
+	err = log.SaveLevelOverrides(path, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check that a module is one the daemons log as
+func validateModule(module string) error {
+	if module == log.DefaultModule {
+		return nil
+	}
+	for _, knownModule := range log.GetModules() {
+		if module == knownModule {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown log module '%s'", module)
+}
+
+// Convert a map of module levels to their names
+func getLevelNames(levels map[string]log.Level) map[string]string {
+	names := map[string]string{}
+	for module, level := range levels {
+		names[module] = level.String()
+	}
+	return names
+}
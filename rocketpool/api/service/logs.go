@@ -0,0 +1,109 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Read the recent entries from the daemons' log files that match the given filters
+func queryLogs(c *cli.Context) (*api.QueryLogsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.QueryLogsResponse{
+		Entries: []api.LogEntry{},
+	}
+
+	// Build the filter
+	filter := log.EntryFilter{
+		Module:   c.String("module"),
+		Task:     c.String("task"),
+		MinLevel: log.LevelDebug,
+		Limit:    int(c.Uint64("limit")),
+	}
+	if since := c.String("since"); since != "" {
+		filter.Since, err = parseSince(since)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if level := c.String("level"); level != "" {
+		filter.MinLevel, err = log.ParseLevel(level)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get the daemons to read the logs of
+	processes := []string{health.Process_Node, health.Process_Watchtower}
+	if process := c.String("process"); process != "" {
+		if process != health.Process_Node && process != health.Process_Watchtower {
+			return nil, fmt.Errorf("invalid process '%s'; it must be '%s' or '%s'", process, health.Process_Node, health.Process_Watchtower)
+		}
+		processes = []string{process}
+	}
+
+	// Read the entries
+	for _, process := range processes {
+		paths, err := log.GetLogFiles(cfg.Smartnode.GetDaemonLogPath(true, process))
+		if err != nil {
+			return nil, err
+		}
+		entries, err := log.ReadEntries(paths, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			response.Entries = append(response.Entries, api.LogEntry{
+				Time:    entry.Time,
+				Process: process,
+				Level:   entry.Level,
+				Module:  entry.Module,
+				Task:    entry.Task,
+				Message: entry.Message,
+			})
+		}
+	}
+
+	// Merge the daemons' entries and apply the limit across all of them
+	sortLogEntries(response.Entries)
+	if filter.Limit > 0 && len(response.Entries) > filter.Limit {
+		response.Entries = response.Entries[len(response.Entries)-filter.Limit:]
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Parse the start of a log query, either as a duration before now (e.g. 1h) or as an RFC 3339 time
+func parseSince(since string) (time.Time, error) {
+	if duration, err := time.ParseDuration(since); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	start, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start time '%s'; it must be a duration like 1h or a time like 2006-01-02T15:04:05Z", since)
+	}
+	return start, nil
+}
+
+// Sort log entries by time
+func sortLogEntries(entries []api.LogEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/shutdown"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Stops the daemons from starting new tasks until they're restarted or the shutdown is cancelled
+func prepareShutdown(c *cli.Context) (*api.PrepareShutdownResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PrepareShutdownResponse{}
+
+	// Write the marker the daemons check before starting each task
+	err = shutdown.PrepareShutdown(cfg.Smartnode.GetPrepareShutdownPath(true))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Cancels a prepared shutdown, so the daemons resume starting new tasks
+func cancelShutdown(c *cli.Context) (*api.CancelShutdownResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CancelShutdownResponse{}
+
+	// Remove the marker the daemons check before starting each task
+	err = shutdown.CancelShutdown(cfg.Smartnode.GetPrepareShutdownPath(true))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
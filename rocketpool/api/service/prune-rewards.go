@@ -0,0 +1,107 @@
+package service
+
+import (
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func canPruneRewardsFiles(c *cli.Context, retention uint64) (*api.CanPruneRewardsFilesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanPruneRewardsFilesResponse{}
+
+	// Get the current interval
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.CurrentIndex = currentIndexBig.Uint64()
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the files that would be pruned
+	response.Files, err = rprewards.GetPrunableRewardsFiles(rp, cfg, nodeAccount.Address, retention, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range response.Files {
+		response.TotalSize += file.Size
+	}
+
+	return &response, nil
+
+}
+
+func pruneRewardsFiles(c *cli.Context, retention uint64, mode cfgtypes.RewardsPruneMode) (*api.PruneRewardsFilesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PruneRewardsFilesResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the files to prune
+	files, err := rprewards.GetPrunableRewardsFiles(rp, cfg, nodeAccount.Address, retention, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prune them
+	err = rprewards.PruneRewardsFiles(cfg, files, nodeAccount.Address, mode, true)
+	if err != nil {
+		return nil, err
+	}
+	response.PrunedFiles = len(files)
+	for _, file := range files {
+		response.PrunedSize += file.Size
+	}
+
+	return &response, nil
+
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/reload"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Asks the daemons to reload their config before their next task pass
+func reloadConfig(c *cli.Context) (*api.ReloadConfigResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ReloadConfigResponse{}
+
+	// Write the marker the daemons check before each task pass
+	err = reload.RequestReload(cfg.Smartnode.GetReloadConfigPath(true))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
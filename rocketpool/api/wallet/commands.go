@@ -5,6 +5,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
 // Register subcommands
@@ -28,7 +29,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(getStatus(c))
+					api.GetResponsePrinter(c).PrintResponse(getStatus(c))
 					return nil
 
 				},
@@ -51,7 +52,26 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setPassword(c, password))
+					api.GetResponsePrinter(c).PrintResponse(setPassword(c, password))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "unlock",
+				Aliases:   []string{"u"},
+				Usage:     "Unlock the node wallet with its password, if the password is only kept in memory",
+				UsageText: "rocketpool api wallet unlock password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(unlockWallet(c, c.Args().Get(0)))
 					return nil
 
 				},
@@ -76,7 +96,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(initWallet(c))
+					api.GetResponsePrinter(c).PrintResponse(initWallet(c))
 					return nil
 
 				},
@@ -101,6 +121,19 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Usage: "Specify the index to use with the derivation path when recovering your wallet",
 						Value: 0,
 					},
+					cli.StringFlag{
+						Name:  "validator-pubkeys, v",
+						Usage: "A comma-separated list of validator pubkeys to recover, instead of all of the node's minipool keys",
+					},
+					cli.StringFlag{
+						Name:  "minipools, m",
+						Usage: "A comma-separated list of minipool addresses to recover the validator keys for, instead of all of the node's minipool keys",
+					},
+					cli.UintFlag{
+						Name:  "search-depth, s",
+						Usage: "The number of derivation indices to search for validator keys",
+						Value: walletutils.DefaultKeySearchDepth,
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -114,7 +147,29 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(recoverWallet(c, mnemonic))
+					api.GetResponsePrinter(c).PrintResponse(recoverWallet(c, mnemonic))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "import-keystore",
+				Usage:     "Initialize the node wallet from an existing node private key instead of a mnemonic",
+				UsageText: "rocketpool api wallet import-keystore private-key",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					privateKey, err := cliutils.ValidateNodePrivateKey("private key", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(importNodeKey(c, privateKey))
 					return nil
 
 				},
@@ -147,7 +202,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(searchAndRecoverWallet(c, mnemonic, address))
+					api.GetResponsePrinter(c).PrintResponse(searchAndRecoverWallet(c, mnemonic, address))
 					return nil
 
 				},
@@ -166,7 +221,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(rebuildWallet(c))
+					api.GetResponsePrinter(c).PrintResponse(rebuildWallet(c))
 					return nil
 
 				},
@@ -204,7 +259,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(testRecoverWallet(c, mnemonic))
+					api.GetResponsePrinter(c).PrintResponse(testRecoverWallet(c, mnemonic))
 					return nil
 
 				},
@@ -237,7 +292,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(testSearchAndRecoverWallet(c, mnemonic, address))
+					api.GetResponsePrinter(c).PrintResponse(testSearchAndRecoverWallet(c, mnemonic, address))
 					return nil
 
 				},
@@ -256,7 +311,107 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(exportWallet(c))
+					api.GetResponsePrinter(c).PrintResponse(exportWallet(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "profiles",
+				Usage:     "List the wallet profiles",
+				UsageText: "rocketpool api wallet profiles",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getProfiles(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "switch-profile",
+				Usage:     "Make a wallet profile the active one, creating it if it doesn't exist",
+				UsageText: "rocketpool api wallet switch-profile name",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(switchProfile(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "web3signer-keys",
+				Usage:     "List the validator keys stored in Web3Signer",
+				UsageText: "rocketpool api wallet web3signer-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getWeb3SignerKeys(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "web3signer-import",
+				Usage:     "Import the node wallet's validator keys into Web3Signer",
+				UsageText: "rocketpool api wallet web3signer-import [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "slashing-protection, s",
+						Usage: "The path of an EIP-3076 slashing protection file to import along with the keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(importWeb3SignerKeys(c, c.String("slashing-protection")))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "web3signer-remove",
+				Usage:     "Remove validator keys from Web3Signer, saving their slashing protection data",
+				UsageText: "rocketpool api wallet web3signer-remove pubkeys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkeys, err := cliutils.ValidatePubkeys("pubkeys", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(removeWeb3SignerKeys(c, pubkeys))
 					return nil
 
 				},
@@ -274,7 +429,7 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setEnsName(c, c.Args().Get(0), true))
+					api.GetResponsePrinter(c).PrintResponse(setEnsName(c, c.Args().Get(0), true))
 					return nil
 
 				},
@@ -291,7 +446,115 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setEnsName(c, c.Args().Get(0), false))
+					api.GetResponsePrinter(c).PrintResponse(setEnsName(c, c.Args().Get(0), false))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "pending",
+				Usage:     "Get the node wallet's nonces, pending transactions, and nonce gaps",
+				UsageText: "rocketpool api wallet pending",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(getPendingTransactions(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-clear-stuck",
+				Usage:     "Check whether a stuck nonce can be replaced with a zero-value transaction to the node itself",
+				UsageText: "rocketpool api wallet can-clear-stuck nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canClearStuckNonce(c, nonce))
+					return nil
+
+				},
+			},
+			{
+				Name:      "clear-stuck",
+				Usage:     "Replace a stuck nonce with a zero-value transaction to the node itself",
+				UsageText: "rocketpool api wallet clear-stuck nonce",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(clearStuckNonce(c, nonce))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-delete-validator-key",
+				Usage:     "Check whether a validator key can be deleted from the validator keystores",
+				UsageText: "rocketpool api wallet can-delete-validator-key pubkey",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(canDeleteValidatorKey(c, pubkey))
+					return nil
+
+				},
+			},
+			{
+				Name:      "delete-validator-key",
+				Usage:     "Delete a validator key from the validator keystores",
+				UsageText: "rocketpool api wallet delete-validator-key pubkey",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "force, f",
+						Usage: "Delete the key even if the validator hasn't exited the Beacon Chain",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.GetResponsePrinter(c).PrintResponse(deleteValidatorKey(c, pubkey))
 					return nil
 
 				},
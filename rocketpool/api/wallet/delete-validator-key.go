@@ -0,0 +1,88 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func canDeleteValidatorKey(c *cli.Context, pubkey types.ValidatorPubkey) (*api.CanDeleteValidatorKeyResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanDeleteValidatorKeyResponse{}
+
+	// Get the validator's status
+	status, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting status of validator %s: %w", pubkey.Hex(), err)
+	}
+	response.ValidatorExists = status.Exists
+	response.BeaconState = status.Status
+
+	// Only exited validators are safe to delete; anything else could still be called on to attest or propose
+	switch status.Status {
+	case beacon.ValidatorState_ExitedUnslashed,
+		beacon.ValidatorState_ExitedSlashed,
+		beacon.ValidatorState_WithdrawalPossible,
+		beacon.ValidatorState_WithdrawalDone:
+	default:
+		response.ValidatorActive = true
+	}
+
+	// Update & return response
+	response.CanDelete = (response.ValidatorExists && !response.ValidatorActive)
+	return &response, nil
+
+}
+
+func deleteValidatorKey(c *cli.Context, pubkey types.ValidatorPubkey) (*api.DeleteValidatorKeyResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the validator's status unless the deletion is forced
+	if !c.Bool("force") {
+		canDelete, err := canDeleteValidatorKey(c, pubkey)
+		if err != nil {
+			return nil, err
+		}
+		if !canDelete.CanDelete {
+			return nil, fmt.Errorf("Validator %s has not exited the Beacon Chain (state: %s); refusing to delete its key without --force", pubkey.Hex(), canDelete.BeaconState)
+		}
+	}
+
+	// Response
+	response := api.DeleteValidatorKeyResponse{}
+
+	// Delete the key from all of the validator keystores
+	if err := w.DeleteValidatorKey(pubkey); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
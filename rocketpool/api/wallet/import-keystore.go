@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func importNodeKey(c *cli.Context, privateKey *ecdsa.PrivateKey) (*api.ImportNodeKeyResponse, error) {
+
+	// Get services
+	if err := services.RequireNodePassword(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ImportNodeKeyResponse{}
+
+	// Check if wallet is already initialized
+	if w.IsInitialized() {
+		return nil, errors.New("the wallet is already initialized")
+	}
+
+	// Import the key
+	if err := w.ImportNodeKey(privateKey); err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Save wallet
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
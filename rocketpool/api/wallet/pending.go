@@ -0,0 +1,213 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/txjournal"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getPendingTransactions(c *cli.Context) (*api.WalletPendingResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := services.GetTxJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.WalletPendingResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Get the nonces
+	response.LatestNonce, err = ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest nonce: %w", err)
+	}
+	response.PendingNonce, err = ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pending nonce: %w", err)
+	}
+
+	// Get the pending transactions and the gaps before them
+	response.PendingTransactions, err = getPendingJournalEntries(ec, journal, response.LatestNonce)
+	if err != nil {
+		return nil, err
+	}
+	response.NonceGaps = getNonceGaps(response.PendingNonce, response.PendingTransactions)
+
+	// Return response
+	return &response, nil
+
+}
+
+func canClearStuckNonce(c *cli.Context, nonce uint64) (*api.CanClearStuckNonceResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := services.GetTxJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanClearStuckNonceResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the nonce hasn't been mined and isn't past the transactions the node is waiting on
+	latestNonce, err := ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest nonce: %w", err)
+	}
+	pendingNonce, err := ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pending nonce: %w", err)
+	}
+	pending, err := getPendingJournalEntries(ec, journal, latestNonce)
+	if err != nil {
+		return nil, err
+	}
+	maxNonce := pendingNonce
+	for _, entry := range pending {
+		if entry.Nonce > maxNonce {
+			maxNonce = entry.Nonce
+		}
+		if entry.Nonce == nonce {
+			response.StuckTransactions = append(response.StuckTransactions, entry)
+		}
+	}
+	response.NonceAlreadyUsed = (nonce < latestNonce)
+	response.NonceTooHigh = (nonce > maxNonce)
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo, err = eth.EstimateSendTransactionGas(ec, nodeAccount.Address, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update & return response
+	response.CanClear = !(response.NonceAlreadyUsed || response.NonceTooHigh)
+	return &response, nil
+
+}
+
+func clearStuckNonce(c *cli.Context, nonce uint64) (*api.ClearStuckNonceResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ClearStuckNonceResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	opts.Nonce = big.NewInt(0).SetUint64(nonce)
+	opts.Value = big.NewInt(0)
+
+	// Replace the transaction with a zero-value send to the node itself
+	hash, err := eth.SendTransaction(ec, nodeAccount.Address, w.GetChainID(), opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the journaled transactions that are still pending; ones whose nonce has been used by another transaction will never be mined, so they're left out
+func getPendingJournalEntries(ec rocketpool.ExecutionClient, journal *txjournal.Journal, latestNonce uint64) ([]txjournal.Entry, error) {
+	entries, err := journal.GetPending(ec)
+	if err != nil {
+		return nil, err
+	}
+	pending := []txjournal.Entry{}
+	for _, entry := range entries {
+		if entry.Nonce >= latestNonce {
+			pending = append(pending, entry)
+		}
+	}
+	return pending, nil
+}
+
+// Get the nonces that nothing is pending for between the client's next nonce and the highest pending transaction, which hold up everything after them
+func getNonceGaps(pendingNonce uint64, pending []txjournal.Entry) []uint64 {
+	used := map[uint64]bool{}
+	maxNonce := uint64(0)
+	for _, entry := range pending {
+		used[entry.Nonce] = true
+		if entry.Nonce > maxNonce {
+			maxNonce = entry.Nonce
+		}
+	}
+	gaps := []uint64{}
+	for nonce := pendingNonce; nonce < maxNonce; nonce++ {
+		if !used[nonce] {
+			gaps = append(gaps, nonce)
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getProfiles(c *cli.Context) (*api.WalletProfilesResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.WalletProfilesResponse{}
+
+	// Get the profiles
+	profiles, err := services.GetWalletProfiles(c)
+	if err != nil {
+		return nil, err
+	}
+	activeProfile := cfg.Smartnode.GetActiveWalletProfile()
+	response.Profiles = make([]api.WalletProfile, len(profiles))
+	for i, profile := range profiles {
+		profilePath := os.ExpandEnv(cfg.Smartnode.GetWalletProfilePath(profile))
+		response.Profiles[i] = api.WalletProfile{
+			Name:              profile,
+			Active:            profile == activeProfile,
+			PasswordSet:       fileExists(filepath.Join(profilePath, "password")),
+			WalletInitialized: fileExists(filepath.Join(profilePath, "wallet")),
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func switchProfile(c *cli.Context, profile string) (*api.SwitchWalletProfileResponse, error) {
+
+	// Switch profiles
+	if err := services.SetActiveWalletProfile(c, profile); err != nil {
+		return nil, err
+	}
+
+	// Get services
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SwitchWalletProfileResponse{}
+
+	// Get the new profile's status
+	response.PasswordSet = pm.IsPasswordSet()
+	response.WalletInitialized = w.IsInitialized()
+	if response.WalletInitialized {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		response.AccountAddress = nodeAccount.Address
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check if a file exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
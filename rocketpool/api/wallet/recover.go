@@ -5,12 +5,15 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
 
@@ -74,7 +77,27 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 	response.AccountAddress = nodeAccount.Address
 
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+		searchDepth := c.Uint("search-depth")
+		if searchDepth == 0 {
+			searchDepth = walletutils.DefaultKeySearchDepth
+		}
+		pubkeys, err := getValidatorKeysToRecover(c, rp)
+		if err != nil {
+			return nil, err
+		}
+		if pubkeys == nil {
+			// Recover all of the node's minipool keys
+			response.ValidatorKeys, err = walletutils.RecoverMinipoolKeysWithDepth(c, rp, nodeAccount.Address, w, searchDepth, false)
+		} else {
+			// Only recover the requested keys
+			response.MissingValidatorKeys, err = walletutils.RecoverValidatorKeys(c, pubkeys, w, searchDepth, false)
+			response.ValidatorKeys = []types.ValidatorPubkey{}
+			for _, pubkey := range pubkeys {
+				if !containsPubkey(response.MissingValidatorKeys, pubkey) {
+					response.ValidatorKeys = append(response.ValidatorKeys, pubkey)
+				}
+			}
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -90,6 +113,48 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 
 }
 
+// Get the validator keys a recovery was limited to with the validator-pubkeys and minipools flags; returns nil if it wasn't limited
+func getValidatorKeysToRecover(c *cli.Context, rp *rocketpool.RocketPool) ([]types.ValidatorPubkey, error) {
+	if c.String("validator-pubkeys") == "" && c.String("minipools") == "" {
+		return nil, nil
+	}
+	pubkeys := []types.ValidatorPubkey{}
+	if c.String("validator-pubkeys") != "" {
+		validatorPubkeys, err := cliutils.ValidatePubkeys("validator pubkeys", c.String("validator-pubkeys"))
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, validatorPubkeys...)
+	}
+	if c.String("minipools") != "" {
+		minipoolAddresses, err := cliutils.ValidateAddresses("minipool addresses", c.String("minipools"))
+		if err != nil {
+			return nil, err
+		}
+		for _, address := range minipoolAddresses {
+			pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting validator pubkey for minipool %s: %w", address.Hex(), err)
+			}
+			if pubkey == (types.ValidatorPubkey{}) {
+				return nil, fmt.Errorf("%s is not a minipool with a validator", address.Hex())
+			}
+			pubkeys = append(pubkeys, pubkey)
+		}
+	}
+	return pubkeys, nil
+}
+
+// Check if a list of pubkeys contains one
+func containsPubkey(pubkeys []types.ValidatorPubkey, pubkey types.ValidatorPubkey) bool {
+	for _, candidate := range pubkeys {
+		if candidate == pubkey {
+			return true
+		}
+	}
+	return false
+}
+
 func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Address) (*api.SearchAndRecoverWalletResponse, error) {
 
 	// Get services
@@ -10,6 +10,10 @@ import (
 func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 
 	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	pm, err := services.GetPasswordManager(c)
 	if err != nil {
 		return nil, err
@@ -24,7 +28,9 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 
 	// Get wallet status
 	response.PasswordSet = pm.IsPasswordSet()
+	response.Locked = pm.IsLocked()
 	response.WalletInitialized = w.IsInitialized()
+	response.Profile = cfg.Smartnode.GetActiveWalletProfile()
 
 	// Get accounts if initialized
 	if response.WalletInitialized {
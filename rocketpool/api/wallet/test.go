@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/urfave/cli"
 
@@ -67,8 +68,13 @@ func testRecoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletRespo
 	}
 	response.AccountAddress = nodeAccount.Address
 
+	// Check the node's registration and regenerate its validator keys in memory
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, true)
+		response.NodeRegistered, err = node.GetNodeExists(rp, nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		response.ValidatorKeys, response.MissingValidatorKeys, err = walletutils.AuditMinipoolKeys(c, rp, nodeAccount.Address, w)
 		if err != nil {
 			return nil, err
 		}
@@ -159,8 +165,13 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 	}
 	response.AccountAddress = nodeAccount.Address
 
+	// Check the node's registration and regenerate its validator keys in memory
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, true)
+		response.NodeRegistered, err = node.GetNodeExists(rp, nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		response.ValidatorKeys, response.MissingValidatorKeys, err = walletutils.AuditMinipoolKeys(c, rp, nodeAccount.Address, w)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,50 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func unlockWallet(c *cli.Context, password string) (*api.UnlockWalletResponse, error) {
+
+	// Get services
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.UnlockWalletResponse{}
+
+	// Check the password provider
+	if pm.GetProvider() != cfgtypes.WalletPasswordProvider_Prompt {
+		return nil, fmt.Errorf("The wallet password comes from the %s provider, so the wallet doesn't need to be unlocked", pm.GetProvider())
+	}
+
+	// Unlock the wallet in this process, which checks the password
+	if err := services.UnlockWallet(c, password); err != nil {
+		return nil, err
+	}
+
+	// Unlock the daemons that are waiting for it
+	response.UnlockedDaemons = []string{}
+	for _, process := range []string{health.Process_Node, health.Process_Watchtower} {
+		unlocked, err := services.UnlockDaemonWallet(c, process, password)
+		if err != nil {
+			return nil, err
+		}
+		if unlocked {
+			response.UnlockedDaemons = append(response.UnlockedDaemons, process)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,122 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getWeb3SignerKeys(c *cli.Context) (*api.Web3SignerKeysResponse, error) {
+
+	// Get services
+	ks, err := getWeb3SignerKeystore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.Web3SignerKeysResponse{}
+
+	// Get the keys
+	response.Keys, err = ks.GetRemoteKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func importWeb3SignerKeys(c *cli.Context, slashingProtectionPath string) (*api.ImportWeb3SignerKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ks, err := getWeb3SignerKeystore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ImportWeb3SignerKeysResponse{}
+
+	// Read the slashing protection data
+	slashingProtection := ""
+	if slashingProtectionPath != "" {
+		bytes, err := os.ReadFile(slashingProtectionPath)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read slashing protection data from %s: %w", slashingProtectionPath, err)
+		}
+		slashingProtection = string(bytes)
+	}
+
+	// Regenerate the wallet's validator keys
+	count, err := w.GetValidatorKeyCount()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := w.GetValidatorKeys(0, count)
+	if err != nil {
+		return nil, err
+	}
+
+	// Import them
+	response.ValidatorKeys = make([]types.ValidatorPubkey, 0, len(keys))
+	for _, key := range keys {
+		if err := ks.ImportValidatorKey(key.PrivateKey, key.DerivationPath, slashingProtection); err != nil {
+			return nil, err
+		}
+		response.ValidatorKeys = append(response.ValidatorKeys, key.PublicKey)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func removeWeb3SignerKeys(c *cli.Context, pubkeys []types.ValidatorPubkey) (*api.RemoveWeb3SignerKeysResponse, error) {
+
+	// Get services
+	ks, err := getWeb3SignerKeystore(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RemoveWeb3SignerKeysResponse{}
+
+	// Remove the keys
+	response.SlashingProtectionPath, err = ks.RemoveValidatorKeys(pubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the Web3Signer keystore from the config
+func getWeb3SignerKeystore(c *cli.Context) (*w3skeystore.Keystore, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	url := cfg.Smartnode.Web3SignerUrl.Value.(string)
+	if url == "" {
+		return nil, fmt.Errorf("The Web3Signer URL isn't set; set it in the Smartnode settings with `rocketpool service config` first.")
+	}
+	return w3skeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), url), nil
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register health command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Check the health of the node or watchtower daemon, exiting with an error if the check fails; suitable for container healthchecks",
+		UsageText: "rocketpool health [options] node|watchtower",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "ready, r",
+				Usage: "Check if the daemon is ready (wallet, clients, contracts, and a recent task loop pass) instead of only checking that its task loop isn't stuck",
+			},
+			cli.DurationFlag{
+				Name:  "max-age, m",
+				Usage: "The longest a task loop pass can run, or go without completing, before the check fails",
+				Value: health.DefaultMaxTaskLoopAge,
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 1); err != nil {
+				return err
+			}
+			process := c.Args().Get(0)
+			if process != health.Process_Node && process != health.Process_Watchtower {
+				return fmt.Errorf("Invalid process '%s' - valid options are '%s' and '%s'", process, health.Process_Node, health.Process_Watchtower)
+			}
+
+			// Run
+			return checkHealth(c, process, c.Bool("ready"))
+
+		},
+	})
+}
+
+// Print the health report and return an error if the check failed
+func checkHealth(c *cli.Context, process string, readiness bool) error {
+
+	response, err := health.Check(c, process, c.Duration("max-age"))
+	if err != nil {
+		return err
+	}
+	response.Status = "success"
+	bytes, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("Could not encode health report: %w", err)
+	}
+	fmt.Println(string(bytes))
+
+	if readiness && !response.Ready {
+		return fmt.Errorf("The %s daemon is not ready", process)
+	}
+	if !readiness && !response.Healthy {
+		return fmt.Errorf("The %s daemon is not healthy", process)
+	}
+	return nil
+
+}
@@ -0,0 +1,142 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Alert keys
+const (
+	lowBalanceAlertKey  string = "low-balance"
+	balanceDropAlertKey string = "validator-balance-drop"
+)
+
+// The balance of a validator with no pending rewards, in gwei
+const fullValidatorBalanceGwei uint64 = 32e9
+
+// Check node health task
+type checkNodeHealth struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	w       *wallet.Wallet
+	alerter *alerting.Alerter
+
+	// The validator balances from the previous check
+	lastBalances map[types.ValidatorPubkey]uint64
+}
+
+// Create check node health task
+func newCheckNodeHealth(c *cli.Context, logger log.ColorLogger) (*checkNodeHealth, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	alerter, err := services.GetAlerter(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkNodeHealth{
+		c:            c,
+		log:          logger,
+		cfg:          cfg,
+		w:            w,
+		alerter:      alerter,
+		lastBalances: map[types.ValidatorPubkey]uint64{},
+	}, nil
+
+}
+
+// Check the node's balance and validator performance, and send alerts if anything is wrong
+func (t *checkNodeHealth) run(state *state.NetworkState) error {
+
+	// Skip the checks if there's nowhere to send the alerts
+	if !t.alerter.IsEnabled() {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking node health...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists {
+		return fmt.Errorf("node %s was not found in the network state", nodeAccount.Address.Hex())
+	}
+
+	// Check the node wallet balance
+	threshold := eth.EthToWei(t.cfg.Alerting.LowBalanceThreshold.Value.(float64))
+	if nodeDetails.BalanceETH.Cmp(threshold) < 0 {
+		err = t.alerter.Publish(alerting.Alert{
+			Key:      lowBalanceAlertKey,
+			Severity: alerting.Severity_Warning,
+			Title:    "Low node wallet balance",
+			Message:  fmt.Sprintf("The node wallet only has %.6f ETH, which is below the alert threshold of %.6f ETH. It may not be able to pay for transactions.", eth.WeiToEth(nodeDetails.BalanceETH), eth.WeiToEth(threshold)),
+		})
+		if err != nil {
+			t.log.Println(err)
+		}
+	} else {
+		t.alerter.Resolve(lowBalanceAlertKey)
+	}
+
+	// Check for active validators that lost balance since the last check, which means they missed attestations
+	balances := map[types.ValidatorPubkey]uint64{}
+	droppedPubkeys := []string{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || validator.Status != beacon.ValidatorState_ActiveOngoing {
+			continue
+		}
+		balances[mpd.Pubkey] = validator.Balance
+
+		lastBalance, exists := t.lastBalances[mpd.Pubkey]
+		if !exists || validator.Balance >= lastBalance {
+			continue
+		}
+		// Ignore drops caused by withdrawal sweeps of the rewards above 32 ETH
+		if lastBalance > fullValidatorBalanceGwei && validator.Balance >= fullValidatorBalanceGwei {
+			continue
+		}
+		droppedPubkeys = append(droppedPubkeys, mpd.Pubkey.Hex())
+	}
+	t.lastBalances = balances
+
+	if len(droppedPubkeys) > 0 {
+		err = t.alerter.Publish(alerting.Alert{
+			Key:      balanceDropAlertKey,
+			Severity: alerting.Severity_Warning,
+			Title:    "Validators are missing attestations",
+			Message:  fmt.Sprintf("%d of your validators lost balance since the last check, which usually means they are missing attestations: %v", len(droppedPubkeys), droppedPubkeys),
+		})
+		if err != nil {
+			t.log.Println(err)
+		}
+	}
+
+	return nil
+
+}
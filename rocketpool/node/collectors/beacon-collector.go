@@ -39,6 +39,9 @@ type BeaconCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new BeaconCollector instance
@@ -66,6 +69,11 @@ func NewBeaconCollector(rp *rocketpool.RocketPool, bc beacon.Client, ec rocketpo
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *BeaconCollector) GetName() string {
+	return "beacon"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *BeaconCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.activeSyncCommittee
@@ -184,4 +192,5 @@ func (collector *BeaconCollector) Collect(channel chan<- prometheus.Metric) {
 // Log error messages
 func (collector *BeaconCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
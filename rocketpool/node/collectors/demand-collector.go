@@ -32,6 +32,9 @@ type DemandCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new DemandCollector instance
@@ -60,6 +63,11 @@ func NewDemandCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *De
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *DemandCollector) GetName() string {
+	return "demand"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *DemandCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.depositPoolBalance
@@ -94,4 +102,5 @@ func (collector *DemandCollector) Collect(channel chan<- prometheus.Metric) {
 // Log error messages
 func (collector *DemandCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
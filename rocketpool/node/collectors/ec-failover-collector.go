@@ -0,0 +1,78 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// Represents the collector for Execution client failover metrics
+type EcFailoverCollector struct {
+	// Whether the Smartnode is currently using the fallback Execution client
+	usingFallback *prometheus.Desc
+
+	// The number of times the Smartnode has failed over to the fallback Execution client
+	failovers *prometheus.Desc
+
+	// The time of the most recent failover
+	lastFailoverTime *prometheus.Desc
+
+	// The Execution client manager
+	ec *services.ExecutionClientManager
+
+	// Counts the errors the collector runs into
+	errorCounter
+}
+
+// Create a new EcFailoverCollector instance
+func NewEcFailoverCollector(ec *services.ExecutionClientManager) *EcFailoverCollector {
+	subsystem := "ec"
+	return &EcFailoverCollector{
+		usingFallback: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "using_fallback"),
+			"Whether the Smartnode is currently using the fallback Execution client",
+			nil, nil,
+		),
+		failovers: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failovers_total"),
+			"The number of times the Smartnode has failed over to the fallback Execution client",
+			nil, nil,
+		),
+		lastFailoverTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_failover_timestamp"),
+			"The Unix time of the most recent failover to the fallback Execution client",
+			nil, nil,
+		),
+		ec: ec,
+	}
+}
+
+// Get the name used to enable or disable the collector in the config
+func (collector *EcFailoverCollector) GetName() string {
+	return "ec_failover"
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *EcFailoverCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.usingFallback
+	channel <- collector.failovers
+	channel <- collector.lastFailoverTime
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *EcFailoverCollector) Collect(channel chan<- prometheus.Metric) {
+	stats := collector.ec.GetFailoverStats()
+
+	usingFallback := float64(0)
+	if stats.UsingFallback {
+		usingFallback = 1
+	}
+	lastFailoverTime := float64(0)
+	if !stats.LastFailoverTime.IsZero() {
+		lastFailoverTime = float64(stats.LastFailoverTime.Unix())
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.usingFallback, prometheus.GaugeValue, usingFallback)
+	channel <- prometheus.MustNewConstMetric(
+		collector.failovers, prometheus.CounterValue, float64(stats.FailoverCount))
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastFailoverTime, prometheus.GaugeValue, lastFailoverTime)
+}
@@ -0,0 +1,123 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The number of consecutive failed fee recipient checks before the mismatch alert is raised
+const feeRecipientMismatchAlertThreshold uint64 = 2
+
+// Thread-safe record of the fee recipient enforcement results
+type FeeRecipientStatus struct {
+	checked               bool
+	expectedFeeRecipient  common.Address
+	consecutiveMismatches uint64
+
+	// Internal fields
+	lock *sync.Mutex
+}
+
+// Create a new FeeRecipientStatus instance
+func NewFeeRecipientStatus() *FeeRecipientStatus {
+	return &FeeRecipientStatus{
+		lock: &sync.Mutex{},
+	}
+}
+
+// Record the result of a fee recipient check
+func (s *FeeRecipientStatus) Update(expectedFeeRecipient common.Address, isCorrect bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.checked = true
+	s.expectedFeeRecipient = expectedFeeRecipient
+	if isCorrect {
+		s.consecutiveMismatches = 0
+	} else {
+		s.consecutiveMismatches++
+	}
+}
+
+// Get the latest fee recipient check result
+func (s *FeeRecipientStatus) Get() (bool, common.Address, uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.checked, s.expectedFeeRecipient, s.consecutiveMismatches
+}
+
+// Represents the collector for fee recipient enforcement metrics
+type FeeRecipientCollector struct {
+	// Whether the validator client's fee recipient is currently wrong
+	mismatch *prometheus.Desc
+
+	// The number of consecutive checks that found the wrong fee recipient
+	consecutiveMismatches *prometheus.Desc
+
+	// Whether the mismatch has persisted long enough to raise an alert
+	mismatchAlert *prometheus.Desc
+
+	// The thread-safe fee recipient status
+	status *FeeRecipientStatus
+
+	// Counts the errors the collector runs into
+	errorCounter
+}
+
+// Create a new FeeRecipientCollector instance
+func NewFeeRecipientCollector(status *FeeRecipientStatus) *FeeRecipientCollector {
+	subsystem := "fee_recipient"
+	return &FeeRecipientCollector{
+		mismatch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "mismatch"),
+			"Whether the validator client's fee recipient does not match the expected address",
+			[]string{"expected"}, nil,
+		),
+		consecutiveMismatches: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "consecutive_mismatches"),
+			"The number of consecutive checks that found the wrong fee recipient",
+			nil, nil,
+		),
+		mismatchAlert: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "mismatch_alert"),
+			"Whether the fee recipient mismatch has persisted and could not be corrected automatically",
+			nil, nil,
+		),
+		status: status,
+	}
+}
+
+// Get the name used to enable or disable the collector in the config
+func (collector *FeeRecipientCollector) GetName() string {
+	return "fee_recipient"
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *FeeRecipientCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.mismatch
+	channel <- collector.consecutiveMismatches
+	channel <- collector.mismatchAlert
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *FeeRecipientCollector) Collect(channel chan<- prometheus.Metric) {
+	// Get the latest status
+	checked, expectedFeeRecipient, consecutiveMismatches := collector.status.Get()
+	if !checked {
+		return
+	}
+
+	mismatch := float64(0)
+	if consecutiveMismatches > 0 {
+		mismatch = 1
+	}
+	mismatchAlert := float64(0)
+	if consecutiveMismatches >= feeRecipientMismatchAlertThreshold {
+		mismatchAlert = 1
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.mismatch, prometheus.GaugeValue, mismatch, expectedFeeRecipient.Hex())
+	channel <- prometheus.MustNewConstMetric(
+		collector.consecutiveMismatches, prometheus.GaugeValue, float64(consecutiveMismatches))
+	channel <- prometheus.MustNewConstMetric(
+		collector.mismatchAlert, prometheus.GaugeValue, mismatchAlert)
+}
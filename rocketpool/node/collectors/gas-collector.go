@@ -0,0 +1,126 @@
+package collectors
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/txjournal"
+)
+
+// Represents the collector for the gas the node has spent on its transactions
+type GasCollector struct {
+	// The total gas used by the node's transactions
+	gasUsed *prometheus.Desc
+
+	// The total ETH spent on gas by the node's transactions
+	gasSpent *prometheus.Desc
+
+	// The total number of the node's transactions that have been mined
+	transactions *prometheus.Desc
+
+	// The execution client
+	ec rocketpool.ExecutionClient
+
+	// The transaction journal
+	journal *txjournal.Journal
+
+	// Prefix for logging
+	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
+}
+
+// Create a new GasCollector instance
+func NewGasCollector(ec rocketpool.ExecutionClient, journal *txjournal.Journal) *GasCollector {
+	subsystem := "gas"
+	return &GasCollector{
+		gasUsed: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "used_total"),
+			"The total gas used by the node's transactions, by command category",
+			[]string{"category"}, nil,
+		),
+		gasSpent: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "spent_eth_total"),
+			"The total ETH spent on gas by the node's transactions, by command category",
+			[]string{"category"}, nil,
+		),
+		transactions: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "transactions_total"),
+			"The total number of the node's transactions that have been mined, by command category and status",
+			[]string{"category", "status"}, nil,
+		),
+		ec:        ec,
+		journal:   journal,
+		logPrefix: "Gas Collector",
+	}
+}
+
+// Get the name used to enable or disable the collector in the config
+func (collector *GasCollector) GetName() string {
+	return "gas"
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *GasCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.gasUsed
+	channel <- collector.gasSpent
+	channel <- collector.transactions
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *GasCollector) Collect(channel chan<- prometheus.Metric) {
+	// Update any transactions that have been mined since the last check
+	_, err := collector.journal.GetPending(collector.ec)
+	if err != nil {
+		collector.logError(fmt.Errorf("Error updating pending transactions: %w", err))
+		return
+	}
+	history, err := collector.journal.GetHistory(0)
+	if err != nil {
+		collector.logError(fmt.Errorf("Error getting transaction history: %w", err))
+		return
+	}
+
+	// Add up the gas for each category; every category is reported so the series exist before the first transaction
+	categories := []string{txjournal.Category_Minipool, txjournal.Category_Claims, txjournal.Category_Watchtower, txjournal.Category_Other}
+	gasUsed := map[string]uint64{}
+	gasSpent := map[string]*big.Int{}
+	successes := map[string]float64{}
+	failures := map[string]float64{}
+	for _, category := range categories {
+		gasSpent[category] = big.NewInt(0)
+	}
+	for _, entry := range history {
+		if entry.Status == txjournal.Status_Pending {
+			continue
+		}
+		category := entry.Category()
+		gasUsed[category] += entry.GasUsed
+		if entry.GasCost != nil {
+			gasSpent[category].Add(gasSpent[category], entry.GasCost)
+		}
+		if entry.Status == txjournal.Status_Success {
+			successes[category]++
+		} else {
+			failures[category]++
+		}
+	}
+
+	for _, category := range categories {
+		channel <- prometheus.MustNewConstMetric(
+			collector.gasUsed, prometheus.CounterValue, float64(gasUsed[category]), category)
+		channel <- prometheus.MustNewConstMetric(
+			collector.gasSpent, prometheus.CounterValue, eth.WeiToEth(gasSpent[category]), category)
+		channel <- prometheus.MustNewConstMetric(
+			collector.transactions, prometheus.CounterValue, successes[category], category, string(txjournal.Status_Success))
+		channel <- prometheus.MustNewConstMetric(
+			collector.transactions, prometheus.CounterValue, failures[category], category, string(txjournal.Status_Failed))
+	}
+}
+
+// Log error messages
+func (collector *GasCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
+}
@@ -0,0 +1,355 @@
+package collectors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	// How often to check the disks, since measuring the volumes is expensive
+	healthDiskRefreshInterval time.Duration = 10 * time.Minute
+
+	// How far back chain data sizes are kept to measure its growth rate
+	healthGrowthWindow time.Duration = 24 * time.Hour
+
+	// The shortest span of samples a growth rate will be reported for
+	healthMinGrowthSpan time.Duration = time.Hour
+
+	// How long to wait for the Docker API
+	healthDockerTimeout time.Duration = time.Minute
+
+	// Where the client containers keep their chain data
+	healthClientDataPath string = "/ethclient"
+
+	healthExecutionContainerSuffix string = "_eth1"
+	healthConsensusContainerSuffix string = "_eth2"
+)
+
+// A chain data size at a point in time
+type chainDataSample struct {
+	time time.Time
+	size uint64
+}
+
+// The latest disk measurements of a client
+type clientDiskStatus struct {
+	freeBytes      uint64
+	chainDataBytes uint64
+	hasChainData   bool
+	samples        []chainDataSample
+}
+
+// Represents the collector for the health of the node's machine and containers
+type HealthCollector struct {
+	// The free space on the disk holding each client's data
+	diskFree *prometheus.Desc
+
+	// The size of each client's chain data
+	chainDataSize *prometheus.Desc
+
+	// How fast each client's chain data is growing
+	chainDataGrowth *prometheus.Desc
+
+	// How long until the disk holding each client's data fills up
+	daysUntilFull *prometheus.Desc
+
+	// Whether or not the disk holding each client's data will fill up sooner than the alert threshold
+	diskExhaustionAlert *prometheus.Desc
+
+	// The number of times each container has been restarted
+	containerRestarts *prometheus.Desc
+
+	// The Docker client
+	d *client.Client
+
+	// The Rocket Pool config
+	cfg *config.RocketPoolConfig
+
+	// The latest disk measurements of each client, and when they were taken
+	disks          map[string]*clientDiskStatus
+	diskUpdateTime time.Time
+
+	// Guards the disk measurements, since Prometheus can collect concurrently
+	lock sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
+}
+
+// Create a new HealthCollector instance
+func NewHealthCollector(d *client.Client, cfg *config.RocketPoolConfig) *HealthCollector {
+	subsystem := "health"
+	return &HealthCollector{
+		diskFree: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "disk_free_bytes"),
+			"The free space on the disk holding each client's chain data",
+			[]string{"client"}, nil,
+		),
+		chainDataSize: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "chaindata_bytes"),
+			"The size of each client's chain data volume",
+			[]string{"client"}, nil,
+		),
+		chainDataGrowth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "chaindata_growth_bytes_per_day"),
+			"How fast each client's chain data has grown over the last day",
+			[]string{"client"}, nil,
+		),
+		daysUntilFull: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "disk_days_until_full"),
+			"The projected number of days until the disk holding each client's chain data fills up",
+			[]string{"client"}, nil,
+		),
+		diskExhaustionAlert: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "disk_exhaustion_alert"),
+			"1 if the disk holding a client's chain data is projected to fill up sooner than the configured number of days, 0 otherwise",
+			[]string{"client"}, nil,
+		),
+		containerRestarts: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "container_restarts"),
+			"The number of times each Rocket Pool container has been restarted by Docker",
+			[]string{"container"}, nil,
+		),
+		d:         d,
+		cfg:       cfg,
+		disks:     map[string]*clientDiskStatus{},
+		logPrefix: "Health Collector",
+	}
+}
+
+// Get the name used to enable or disable the collector in the config
+func (collector *HealthCollector) GetName() string {
+	return "health"
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *HealthCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.diskFree
+	channel <- collector.chainDataSize
+	channel <- collector.chainDataGrowth
+	channel <- collector.daysUntilFull
+	channel <- collector.diskExhaustionAlert
+	channel <- collector.containerRestarts
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *HealthCollector) Collect(channel chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthDockerTimeout)
+	defer cancel()
+	projectName := collector.cfg.Smartnode.ProjectName.Value.(string)
+
+	// Get the container restart counts
+	containers, err := collector.d.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		collector.logError(fmt.Errorf("Error getting containers: %w", err))
+	} else {
+		for _, container := range containers {
+			if len(container.Names) == 0 {
+				continue
+			}
+			name := strings.TrimPrefix(container.Names[0], "/")
+			if !strings.HasPrefix(name, projectName+"_") {
+				continue
+			}
+			details, err := collector.d.ContainerInspect(ctx, container.ID)
+			if err != nil {
+				collector.logError(fmt.Errorf("Error inspecting container %s: %w", name, err))
+				continue
+			}
+			channel <- prometheus.MustNewConstMetric(
+				collector.containerRestarts, prometheus.GaugeValue, float64(details.RestartCount), name)
+		}
+	}
+
+	// Refresh the disk measurements if they're stale
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	if time.Since(collector.diskUpdateTime) > healthDiskRefreshInterval {
+		collector.updateDisks(ctx, projectName)
+		collector.diskUpdateTime = time.Now()
+	}
+
+	alertDays := float64(collector.cfg.DiskAlertDays.Value.(uint64))
+	for clientName, disk := range collector.disks {
+		channel <- prometheus.MustNewConstMetric(
+			collector.diskFree, prometheus.GaugeValue, float64(disk.freeBytes), clientName)
+		if !disk.hasChainData {
+			continue
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.chainDataSize, prometheus.GaugeValue, float64(disk.chainDataBytes), clientName)
+
+		// The growth rate is only meaningful once there's enough history
+		growth, ok := getChainDataGrowth(disk.samples)
+		if !ok {
+			continue
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.chainDataGrowth, prometheus.GaugeValue, growth, clientName)
+		if growth <= 0 {
+			channel <- prometheus.MustNewConstMetric(
+				collector.diskExhaustionAlert, prometheus.GaugeValue, 0, clientName)
+			continue
+		}
+		daysUntilFull := float64(disk.freeBytes) / growth
+		alert := float64(0)
+		if alertDays > 0 && daysUntilFull < alertDays {
+			alert = 1
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.daysUntilFull, prometheus.GaugeValue, daysUntilFull, clientName)
+		channel <- prometheus.MustNewConstMetric(
+			collector.diskExhaustionAlert, prometheus.GaugeValue, alert, clientName)
+	}
+}
+
+// Measure the free space and chain data size of each locally-managed client
+func (collector *HealthCollector) updateDisks(ctx context.Context, projectName string) {
+	clients := map[string]string{}
+	if collector.cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		clients["execution"] = projectName + healthExecutionContainerSuffix
+	}
+	if collector.cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		clients["consensus"] = projectName + healthConsensusContainerSuffix
+	}
+
+	// Get the size of every volume; Docker only reports them all at once
+	var volumeSizes map[string]int64
+	usage, err := collector.d.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject}})
+	if err != nil {
+		collector.logError(fmt.Errorf("Error getting volume sizes: %w", err))
+	} else {
+		volumeSizes = map[string]int64{}
+		for _, volume := range usage.Volumes {
+			if volume.UsageData != nil {
+				volumeSizes[volume.Name] = volume.UsageData.Size
+			}
+		}
+	}
+
+	now := time.Now()
+	for clientName, containerName := range clients {
+		freeBytes, err := collector.getFreeSpace(ctx, containerName)
+		if err != nil {
+			collector.logError(fmt.Errorf("Error getting free space for the %s client: %w", clientName, err))
+			delete(collector.disks, clientName)
+			continue
+		}
+		disk, exists := collector.disks[clientName]
+		if !exists {
+			disk = &clientDiskStatus{}
+			collector.disks[clientName] = disk
+		}
+		disk.freeBytes = freeBytes
+
+		// Chain data stored in a host folder instead of a Docker volume doesn't have a size
+		disk.hasChainData = false
+		volumeName, err := collector.getDataVolumeName(ctx, containerName)
+		if err != nil {
+			collector.logError(fmt.Errorf("Error getting the data volume of the %s client: %w", clientName, err))
+			continue
+		}
+		size, exists := volumeSizes[volumeName]
+		if volumeName == "" || !exists || size < 0 {
+			continue
+		}
+		disk.chainDataBytes = uint64(size)
+		disk.hasChainData = true
+
+		// Record the sample and drop the ones that have aged out of the window
+		disk.samples = append(disk.samples, chainDataSample{time: now, size: uint64(size)})
+		for len(disk.samples) > 0 && now.Sub(disk.samples[0].time) > healthGrowthWindow {
+			disk.samples = disk.samples[1:]
+		}
+	}
+}
+
+// Get the name of the volume a client container keeps its chain data in, or an empty string if it's a host folder
+func (collector *HealthCollector) getDataVolumeName(ctx context.Context, containerName string) (string, error) {
+	details, err := collector.d.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return "", err
+	}
+	for _, mount := range details.Mounts {
+		if mount.Destination == healthClientDataPath {
+			return mount.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// Get the free space on the disk holding a client container's chain data by running df inside of it
+func (collector *HealthCollector) getFreeSpace(ctx context.Context, containerName string) (uint64, error) {
+	exec, err := collector.d.ContainerExecCreate(ctx, containerName, types.ExecConfig{
+		Cmd:          []string{"df", "-P", "-k", healthClientDataPath},
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error creating df command: %w", err)
+	}
+	response, err := collector.d.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return 0, fmt.Errorf("error running df command: %w", err)
+	}
+	defer response.Close()
+
+	var stdout, stderr bytes.Buffer
+	_, err = stdcopy.StdCopy(&stdout, &stderr, response.Reader)
+	if err != nil {
+		return 0, fmt.Errorf("error reading df output: %w", err)
+	}
+	inspect, err := collector.d.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting df result: %w", err)
+	}
+	if inspect.ExitCode != 0 {
+		return 0, fmt.Errorf("df exited with code %d: %s", inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	// The POSIX format is a header line followed by "filesystem blocks used available capacity mountpoint"
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) < 2 {
+		return 0, fmt.Errorf("unexpected df output: %s", stdout.String())
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return 0, fmt.Errorf("unexpected df output: %s", stdout.String())
+	}
+	availableKb, err := strconv.ParseUint(fields[3], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing available space [%s]: %w", fields[3], err)
+	}
+	return availableKb * 1024, nil
+}
+
+// Get how fast chain data has grown across the samples, in bytes per day
+func getChainDataGrowth(samples []chainDataSample) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	first := samples[0]
+	last := samples[len(samples)-1]
+	span := last.time.Sub(first.time)
+	if span < healthMinGrowthSpan {
+		return 0, false
+	}
+	growth := float64(last.size) - float64(first.size)
+	return growth / span.Hours() * 24, true
+}
+
+// Log error messages
+func (collector *HealthCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
+}
@@ -95,6 +95,9 @@ type NodeCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new NodeCollector instance
@@ -176,6 +179,11 @@ func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress c
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *NodeCollector) GetName() string {
+	return "node"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *NodeCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.totalStakedRpl
@@ -254,7 +262,7 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 				if err != nil {
 					return err
 				}
-				if !intervalInfo.TreeFileExists {
+				if !intervalInfo.TreeFileExists && !intervalInfo.IsPruned {
 					return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist but interval %d was claimed", intervalInfo.TreeFilePath, claimedInterval)
 				}
 
@@ -399,4 +407,5 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 // Log error messages
 func (collector *NodeCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
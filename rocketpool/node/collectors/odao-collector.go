@@ -29,6 +29,9 @@ type OdaoCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new DemandCollector instance
@@ -57,6 +60,11 @@ func NewOdaoCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *Odao
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *OdaoCollector) GetName() string {
+	return "odao"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *OdaoCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.currentEth1Block
@@ -91,4 +99,5 @@ func (collector *OdaoCollector) Collect(channel chan<- prometheus.Metric) {
 // Log error messages
 func (collector *OdaoCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
@@ -36,6 +36,9 @@ type PerformanceCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new PerformanceCollector instance
@@ -72,6 +75,11 @@ func NewPerformanceCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *PerformanceCollector) GetName() string {
+	return "performance"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *PerformanceCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.ethUtilizationRate
@@ -114,4 +122,5 @@ func (collector *PerformanceCollector) Collect(channel chan<- prometheus.Metric)
 // Log error messages
 func (collector *PerformanceCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
@@ -0,0 +1,179 @@
+package collectors
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// A collector that can be managed by the collector registry
+type NamedCollector interface {
+	prometheus.Collector
+
+	// Get the name used to enable or disable the collector in the config
+	GetName() string
+
+	// Get the number of errors the collector has run into
+	GetErrorCount() uint64
+}
+
+// Counts the errors a collector runs into; collectors embed this to report them to the registry
+type errorCounter struct {
+	errorCount atomic.Uint64
+}
+
+// Record an error
+func (c *errorCounter) countError() {
+	c.errorCount.Add(1)
+}
+
+// Get the number of errors recorded so far
+func (c *errorCounter) GetErrorCount() uint64 {
+	return c.errorCount.Load()
+}
+
+// Registers the enabled collectors with Prometheus, and reports how long each one takes to scrape and how many errors it has run into
+type CollectorRegistry struct {
+	// Whether or not each collector is enabled
+	collectorEnabled *prometheus.Desc
+
+	// How long each collector took to scrape the last time it ran
+	scrapeDuration *prometheus.Desc
+
+	// The number of errors each collector has run into
+	scrapeErrors *prometheus.Desc
+
+	// The Prometheus registry
+	registry *prometheus.Registry
+
+	// The names of the collectors that have been disabled in the config
+	disabled map[string]bool
+
+	// Every collector that's been added, including disabled ones
+	collectors []NamedCollector
+
+	// How long each enabled collector took to scrape the last time it ran
+	durations map[string]time.Duration
+
+	// Guards the durations
+	lock sync.Mutex
+}
+
+// Create a new collector registry; disabledCollectors is a comma-separated list of collector names
+func NewCollectorRegistry(registry *prometheus.Registry, disabledCollectors string) *CollectorRegistry {
+	disabled := map[string]bool{}
+	for _, name := range strings.Split(disabledCollectors, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			disabled[name] = true
+		}
+	}
+
+	subsystem := "collector"
+	r := &CollectorRegistry{
+		collectorEnabled: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "enabled"),
+			"Whether or not each metrics collector is enabled",
+			[]string{"collector"}, nil,
+		),
+		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "scrape_duration_seconds"),
+			"How long each metrics collector took to scrape the last time it ran",
+			[]string{"collector"}, nil,
+		),
+		scrapeErrors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "errors_total"),
+			"The number of errors each metrics collector has run into",
+			[]string{"collector"}, nil,
+		),
+		registry:  registry,
+		disabled:  disabled,
+		durations: map[string]time.Duration{},
+	}
+	registry.MustRegister(r)
+	return r
+}
+
+// Register a collector with Prometheus, unless it has been disabled; returns whether or not it was registered
+func (r *CollectorRegistry) Register(collector NamedCollector) bool {
+	r.collectors = append(r.collectors, collector)
+	if r.disabled[collector.GetName()] {
+		return false
+	}
+	r.registry.MustRegister(&timedCollector{
+		NamedCollector: collector,
+		registry:       r,
+	})
+	return true
+}
+
+// Get the names of the collectors that aren't known to the registry, so typos in the config can be reported
+func (r *CollectorRegistry) GetUnknownDisabledCollectors() []string {
+	known := map[string]bool{}
+	for _, collector := range r.collectors {
+		known[collector.GetName()] = true
+	}
+	unknown := []string{}
+	for name := range r.disabled {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Write metric descriptions to the Prometheus channel
+func (r *CollectorRegistry) Describe(channel chan<- *prometheus.Desc) {
+	channel <- r.collectorEnabled
+	channel <- r.scrapeDuration
+	channel <- r.scrapeErrors
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (r *CollectorRegistry) Collect(channel chan<- prometheus.Metric) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, collector := range r.collectors {
+		name := collector.GetName()
+		enabled := float64(1)
+		if r.disabled[name] {
+			enabled = 0
+		}
+		channel <- prometheus.MustNewConstMetric(
+			r.collectorEnabled, prometheus.GaugeValue, enabled, name)
+		if enabled == 0 {
+			continue
+		}
+
+		// Collectors that haven't run yet don't have a duration
+		if duration, exists := r.durations[name]; exists {
+			channel <- prometheus.MustNewConstMetric(
+				r.scrapeDuration, prometheus.GaugeValue, duration.Seconds(), name)
+		}
+		channel <- prometheus.MustNewConstMetric(
+			r.scrapeErrors, prometheus.CounterValue, float64(collector.GetErrorCount()), name)
+	}
+}
+
+// Record how long a collector took to scrape
+func (r *CollectorRegistry) setDuration(name string, duration time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.durations[name] = duration
+}
+
+// Wraps a collector to time its scrapes
+type timedCollector struct {
+	NamedCollector
+	registry *CollectorRegistry
+}
+
+// Collect the latest metric values and pass them to Prometheus, recording how long it took
+func (c *timedCollector) Collect(channel chan<- prometheus.Metric) {
+	start := time.Now()
+	c.NamedCollector.Collect(channel)
+	c.registry.setDuration(c.GetName(), time.Since(start))
+}
@@ -0,0 +1,117 @@
+package collectors
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Represents the collector for rETH peg metrics
+type RethCollector struct {
+	// The protocol's rETH/ETH exchange rate
+	exchangeRate *prometheus.Desc
+
+	// The rETH/ETH price on each secondary market
+	marketPrice *prometheus.Desc
+
+	// The premium (or discount, if negative) of each secondary market over the exchange rate
+	premium *prometheus.Desc
+
+	// The execution client
+	ec rocketpool.ExecutionClient
+
+	// The Rocket Pool config
+	cfg *config.RocketPoolConfig
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// Prefix for logging
+	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
+}
+
+// Create a new RethCollector instance
+func NewRethCollector(ec rocketpool.ExecutionClient, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *RethCollector {
+	subsystem := "reth"
+	return &RethCollector{
+		exchangeRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "exchange_rate"),
+			"The protocol's rETH/ETH exchange rate",
+			nil, nil,
+		),
+		marketPrice: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "market_price"),
+			"The rETH/ETH price on a secondary market",
+			[]string{"source"}, nil,
+		),
+		premium: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "premium_percent"),
+			"The premium of a secondary market's rETH/ETH price over the exchange rate, in percent (negative for a discount)",
+			[]string{"source"}, nil,
+		),
+		ec:          ec,
+		cfg:         cfg,
+		stateLocker: stateLocker,
+		logPrefix:   "rETH Collector",
+	}
+}
+
+// Get the name used to enable or disable the collector in the config
+func (collector *RethCollector) GetName() string {
+	return "reth"
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *RethCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.exchangeRate
+	channel <- collector.marketPrice
+	channel <- collector.premium
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *RethCollector) Collect(channel chan<- prometheus.Metric) {
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	exchangeRate := state.NetworkDetails.RETHExchangeRate
+	channel <- prometheus.MustNewConstMetric(
+		collector.exchangeRate, prometheus.GaugeValue, exchangeRate)
+
+	sources, err := rp.GetRethPriceSources(collector.cfg)
+	if err != nil {
+		collector.logError(fmt.Errorf("Error getting rETH price sources: %w", err))
+		return
+	}
+
+	// Read the markets at the same block as the state so they line up with the exchange rate
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	for _, source := range sources {
+		priceWei, err := source.GetPrice(collector.ec, opts)
+		if err != nil {
+			collector.logError(fmt.Errorf("Error getting rETH price from %s: %w", source.Name, err))
+			continue
+		}
+		price := eth.WeiToEth(priceWei)
+		channel <- prometheus.MustNewConstMetric(
+			collector.marketPrice, prometheus.GaugeValue, price, source.Name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.premium, prometheus.GaugeValue, rp.GetRethPremium(price, exchangeRate), source.Name)
+	}
+}
+
+// Log error messages
+func (collector *RethCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
+}
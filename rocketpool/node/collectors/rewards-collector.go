@@ -0,0 +1,223 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+// Represents the collector for the node's rewards, broken down by interval
+type RewardsCollector struct {
+	// The RPL rewards the node has not claimed yet for each interval
+	unclaimedRpl *prometheus.Desc
+
+	// The smoothing pool ETH rewards the node has not claimed yet for each interval
+	unclaimedEth *prometheus.Desc
+
+	// The total RPL rewards the node has claimed
+	claimedRpl *prometheus.Desc
+
+	// The total smoothing pool ETH rewards the node has claimed
+	claimedEth *prometheus.Desc
+
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The event log interval for the current eth1 client
+	eventLogInterval *big.Int
+
+	// The Rocket Pool config
+	cfg *config.RocketPoolConfig
+
+	// The node's rewards for each interval, loaded from the rewards files; they don't change once the interval is over
+	intervalRewards map[uint64]rprewards.IntervalInfo
+
+	// The next block to start from when looking for claim events
+	nextClaimStartBlock *big.Int
+
+	// The cumulative amounts claimed so far
+	cumulativeClaimedRpl *big.Int
+	cumulativeClaimedEth *big.Int
+
+	// Guards the cached values, since Prometheus can collect concurrently
+	lock sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
+}
+
+// Create a new RewardsCollector instance
+func NewRewardsCollector(rp *rocketpool.RocketPool, nodeAddress common.Address, cfg *config.RocketPoolConfig) *RewardsCollector {
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		log.Printf("Error getting event log interval: %s\n", err.Error())
+		return nil
+	}
+
+	subsystem := "rewards"
+	return &RewardsCollector{
+		unclaimedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unclaimed_rpl"),
+			"The RPL rewards the node has not claimed yet for each interval",
+			[]string{"interval"}, nil,
+		),
+		unclaimedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unclaimed_eth"),
+			"The smoothing pool ETH rewards the node has not claimed yet for each interval",
+			[]string{"interval"}, nil,
+		),
+		claimedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "claimed_rpl_total"),
+			"The total RPL rewards the node has claimed",
+			nil, nil,
+		),
+		claimedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "claimed_eth_total"),
+			"The total smoothing pool ETH rewards the node has claimed",
+			nil, nil,
+		),
+		rp:                   rp,
+		nodeAddress:          nodeAddress,
+		eventLogInterval:     big.NewInt(int64(eventLogInterval)),
+		cfg:                  cfg,
+		intervalRewards:      map[uint64]rprewards.IntervalInfo{},
+		cumulativeClaimedRpl: big.NewInt(0),
+		cumulativeClaimedEth: big.NewInt(0),
+		logPrefix:            "Rewards Collector",
+	}
+}
+
+// Get the name used to enable or disable the collector in the config
+func (collector *RewardsCollector) GetName() string {
+	return "rewards"
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *RewardsCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.unclaimedRpl
+	channel <- collector.unclaimedEth
+	channel <- collector.claimedRpl
+	channel <- collector.claimedEth
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *RewardsCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Get the unclaimed intervals
+	unclaimed, _, err := rprewards.GetClaimStatus(collector.rp, collector.nodeAddress)
+	if err != nil {
+		collector.logError(fmt.Errorf("Error getting rewards claim status: %w", err))
+		return
+	}
+
+	// Get the node's rewards for each of them from the rewards files
+	for _, interval := range unclaimed {
+		intervalInfo, exists := collector.intervalRewards[interval]
+		if !exists {
+			intervalInfo, err = rprewards.GetIntervalInfo(collector.rp, collector.cfg, collector.nodeAddress, interval)
+			if err != nil {
+				collector.logError(fmt.Errorf("Error getting info for rewards interval %d: %w", interval, err))
+				return
+			}
+			if !intervalInfo.TreeFileExists {
+				// Skip it until the file has been downloaded
+				continue
+			}
+			collector.intervalRewards[interval] = intervalInfo
+		}
+		if !intervalInfo.NodeExists {
+			continue
+		}
+
+		rplAmount := big.NewInt(0).Add(&intervalInfo.CollateralRplAmount.Int, &intervalInfo.ODaoRplAmount.Int)
+		intervalLabel := strconv.FormatUint(interval, 10)
+		channel <- prometheus.MustNewConstMetric(
+			collector.unclaimedRpl, prometheus.GaugeValue, eth.WeiToEth(rplAmount), intervalLabel)
+		channel <- prometheus.MustNewConstMetric(
+			collector.unclaimedEth, prometheus.GaugeValue, eth.WeiToEth(&intervalInfo.SmoothingPoolEthAmount.Int), intervalLabel)
+	}
+
+	// Add up the claims made since the last check
+	err = collector.updateClaimedRewards()
+	if err != nil {
+		collector.logError(err)
+		return
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.claimedRpl, prometheus.CounterValue, eth.WeiToEth(collector.cumulativeClaimedRpl))
+	channel <- prometheus.MustNewConstMetric(
+		collector.claimedEth, prometheus.CounterValue, eth.WeiToEth(collector.cumulativeClaimedEth))
+}
+
+// Scan the rewards distributor's claim events for the node since the last check
+func (collector *RewardsCollector) updateClaimedRewards() error {
+	header, err := collector.rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("Error getting latest block header: %w", err)
+	}
+	if collector.nextClaimStartBlock != nil && collector.nextClaimStartBlock.Cmp(header.Number) > 0 {
+		return nil
+	}
+
+	// Get the claim events for the node
+	distributor, err := collector.rp.GetContract("rocketMerkleDistributorMainnet", nil)
+	if err != nil {
+		return fmt.Errorf("Error getting rewards distributor contract: %w", err)
+	}
+	claimEvent, exists := distributor.ABI.Events["RewardsClaimed"]
+	if !exists {
+		return fmt.Errorf("Rewards distributor contract does not have a RewardsClaimed event")
+	}
+	addressFilter := []common.Address{*distributor.Address}
+	topicFilter := [][]common.Hash{{claimEvent.ID}, {collector.nodeAddress.Hash()}}
+	logs, err := eth.GetLogs(collector.rp, addressFilter, topicFilter, collector.eventLogInterval, collector.nextClaimStartBlock, header.Number, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting rewards claim events: %w", err)
+	}
+
+	// Add up the claimed amounts
+	claimedRpl := big.NewInt(0)
+	claimedEth := big.NewInt(0)
+	for _, claimLog := range logs {
+		values := make(map[string]interface{})
+		err = claimEvent.Inputs.UnpackIntoMap(values, claimLog.Data)
+		if err != nil {
+			return fmt.Errorf("Error decoding rewards claim event in transaction %s: %w", claimLog.TxHash.Hex(), err)
+		}
+		rplAmounts, _ := values["amountRPL"].([]*big.Int)
+		for _, amount := range rplAmounts {
+			claimedRpl.Add(claimedRpl, amount)
+		}
+		ethAmounts, _ := values["amountETH"].([]*big.Int)
+		for _, amount := range ethAmounts {
+			claimedEth.Add(claimedEth, amount)
+		}
+	}
+
+	collector.cumulativeClaimedRpl.Add(collector.cumulativeClaimedRpl, claimedRpl)
+	collector.cumulativeClaimedEth.Add(collector.cumulativeClaimedEth, claimedEth)
+	collector.nextClaimStartBlock = big.NewInt(0).Add(header.Number, big.NewInt(1))
+	return nil
+}
+
+// Log error messages
+func (collector *RewardsCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
+}
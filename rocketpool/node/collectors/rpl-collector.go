@@ -34,6 +34,9 @@ type RplCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new RplCollector instance
@@ -63,6 +66,11 @@ func NewRplCollector(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, st
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *RplCollector) GetName() string {
+	return "rpl"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *RplCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.rplPrice
@@ -99,4 +107,5 @@ func (collector *RplCollector) Collect(channel chan<- prometheus.Metric) {
 // Log error messages
 func (collector *RplCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
@@ -2,11 +2,30 @@ package collectors
 
 import (
 	"fmt"
+	"math/big"
+	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/rocketpool-go/utils/multicall"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Settings
+const (
+	// How often to recount the minipools that are eligible for the Smoothing Pool across the whole network
+	smoothingPoolEligibilityRefreshInterval time.Duration = time.Hour
+
+	// The number of nodes to get the active minipool count of in each multicall
+	smoothingPoolNodeBatchSize int = 500
 )
 
 // Represents the collector for Smoothing Pool metrics
@@ -14,37 +33,100 @@ type SmoothingPoolCollector struct {
 	// the ETH balance on the smoothing pool
 	ethBalanceOnSmoothingPool *prometheus.Desc
 
+	// The number of minipools across the network that are eligible for Smoothing Pool rewards
+	eligibleMinipools *prometheus.Desc
+
+	// The number of the node's minipools that are eligible for Smoothing Pool rewards
+	nodeEligibleMinipools *prometheus.Desc
+
+	// How far through the current rewards interval the network is
+	intervalProgress *prometheus.Desc
+
+	// The node's estimated share of the current Smoothing Pool balance
+	nodeEstimatedShare *prometheus.Desc
+
+	// The node's projected share of the Smoothing Pool balance at the end of the interval
+	nodeProjectedShare *prometheus.Desc
+
 	// The Rocket Pool contract manager
 	rp *rocketpool.RocketPool
 
 	// The EC client
 	ec *services.ExecutionClientManager
 
+	// The node's address
+	nodeAddress common.Address
+
+	// The Rocket Pool config
+	cfg *config.RocketPoolConfig
+
 	// The thread-safe locker for the network state
 	stateLocker *StateLocker
 
+	// The number of eligible minipools across the network, and when it was last counted
+	networkEligibleMinipools uint64
+	eligibilityUpdateTime    time.Time
+
+	// Guards the network eligibility count, since Prometheus can collect concurrently
+	lock sync.Mutex
+
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new SmoothingPoolCollector instance
-func NewSmoothingPoolCollector(rp *rocketpool.RocketPool, ec *services.ExecutionClientManager, stateLocker *StateLocker) *SmoothingPoolCollector {
+func NewSmoothingPoolCollector(rp *rocketpool.RocketPool, ec *services.ExecutionClientManager, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *SmoothingPoolCollector {
 	subsystem := "smoothing_pool"
 	return &SmoothingPoolCollector{
 		ethBalanceOnSmoothingPool: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eth_balance"),
 			"The ETH balance on the smoothing pool",
 			nil, nil,
 		),
+		eligibleMinipools: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eligible_minipools"),
+			"The approximate number of minipools across the network that are eligible for smoothing pool rewards",
+			nil, nil,
+		),
+		nodeEligibleMinipools: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_eligible_minipools"),
+			"The number of the node's minipools that are eligible for smoothing pool rewards",
+			nil, nil,
+		),
+		intervalProgress: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "interval_progress"),
+			"How far through the current rewards interval the network is, from 0 to 1",
+			nil, nil,
+		),
+		nodeEstimatedShare: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_estimated_share_eth"),
+			"The node's estimated share of the current smoothing pool balance",
+			nil, nil,
+		),
+		nodeProjectedShare: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_projected_share_eth"),
+			"The node's projected share of the smoothing pool balance at the end of the rewards interval",
+			nil, nil,
+		),
 		rp:          rp,
 		ec:          ec,
+		nodeAddress: nodeAddress,
+		cfg:         cfg,
 		stateLocker: stateLocker,
 		logPrefix:   "SP Collector",
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *SmoothingPoolCollector) GetName() string {
+	return "smoothing_pool"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *SmoothingPoolCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.ethBalanceOnSmoothingPool
+	channel <- collector.eligibleMinipools
+	channel <- collector.nodeEligibleMinipools
+	channel <- collector.intervalProgress
+	channel <- collector.nodeEstimatedShare
+	channel <- collector.nodeProjectedShare
 }
 
 // Collect the latest metric values and pass them to Prometheus
@@ -59,9 +141,145 @@ func (collector *SmoothingPoolCollector) Collect(channel chan<- prometheus.Metri
 
 	channel <- prometheus.MustNewConstMetric(
 		collector.ethBalanceOnSmoothingPool, prometheus.GaugeValue, ethBalanceOnSmoothingPool)
+
+	// Get the progress through the interval
+	now := time.Now()
+	intervalStart := state.NetworkDetails.IntervalStart
+	intervalDuration := state.NetworkDetails.IntervalDuration
+	intervalProgress := float64(0)
+	if intervalDuration > 0 {
+		intervalProgress = float64(now.Sub(intervalStart)) / float64(intervalDuration)
+	}
+	if intervalProgress < 0 {
+		intervalProgress = 0
+	} else if intervalProgress > 1 {
+		intervalProgress = 1
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.intervalProgress, prometheus.GaugeValue, intervalProgress)
+
+	// Count the eligible minipools across the network; this changes slowly, so it isn't recounted on every state refresh
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	if time.Since(collector.eligibilityUpdateTime) > smoothingPoolEligibilityRefreshInterval {
+		count, err := collector.getNetworkEligibleMinipoolCount(state)
+		if err != nil {
+			collector.logError(fmt.Errorf("Error counting eligible minipools: %w", err))
+			return
+		}
+		collector.networkEligibleMinipools = count
+		collector.eligibilityUpdateTime = time.Now()
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.eligibleMinipools, prometheus.GaugeValue, float64(collector.networkEligibleMinipools))
+
+	// Get the node's eligible minipools, weighted by the portion of their rewards that go to the node operator
+	nodeEligibleMinipools := float64(0)
+	nodeScore := float64(0)
+	nodeDetails, exists := state.NodeDetailsByAddress[collector.nodeAddress]
+	if exists && nodeDetails.SmoothingPoolRegistrationState {
+		for _, mpd := range state.MinipoolDetailsByNode[collector.nodeAddress] {
+			if mpd.Status != types.Staking || mpd.Finalised {
+				continue
+			}
+			nodeEligibleMinipools++
+			bond := eth.WeiToEth(mpd.NodeDepositBalance)
+			capital := bond + eth.WeiToEth(mpd.UserDepositBalance)
+			if capital == 0 {
+				continue
+			}
+			fee := eth.WeiToEth(mpd.NodeFee)
+			nodeScore += (bond + (capital-bond)*fee) / capital
+		}
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeEligibleMinipools, prometheus.GaugeValue, nodeEligibleMinipools)
+
+	// Estimate the node's share, assuming every eligible minipool performs equally well
+	estimatedShare := float64(0)
+	projectedShare := float64(0)
+	if nodeScore > 0 && collector.networkEligibleMinipools > 0 {
+		// Only the part of the interval the node was opted in for counts
+		eligibleStart := intervalStart
+		registrationChanged := time.Unix(nodeDetails.SmoothingPoolRegistrationChanged.Int64(), 0)
+		if registrationChanged.After(eligibleStart) {
+			eligibleStart = registrationChanged
+		}
+		elapsed := now.Sub(intervalStart)
+		if elapsed > 0 && now.After(eligibleStart) {
+			currentFraction := float64(now.Sub(eligibleStart)) / float64(elapsed)
+			estimatedShare = ethBalanceOnSmoothingPool * nodeScore * currentFraction / float64(collector.networkEligibleMinipools)
+		}
+
+		// Extrapolate the balance to the end of the interval
+		intervalEnd := intervalStart.Add(intervalDuration)
+		if intervalProgress > 0 && intervalEnd.After(eligibleStart) {
+			projectedFraction := float64(intervalEnd.Sub(eligibleStart)) / float64(intervalDuration)
+			projectedBalance := ethBalanceOnSmoothingPool / intervalProgress
+			projectedShare = projectedBalance * nodeScore * projectedFraction / float64(collector.networkEligibleMinipools)
+		}
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeEstimatedShare, prometheus.GaugeValue, estimatedShare)
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeProjectedShare, prometheus.GaugeValue, projectedShare)
+}
+
+// Count the active minipools of every node that's opted into the Smoothing Pool
+func (collector *SmoothingPoolCollector) getNetworkEligibleMinipoolCount(state *state.NetworkState) (uint64, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	multicallerAddress := common.HexToAddress(collector.cfg.Smartnode.GetMulticallAddress())
+	balanceBatcherAddress := common.HexToAddress(collector.cfg.Smartnode.GetBalanceBatcherAddress())
+	contracts, err := rpstate.NewNetworkContracts(collector.rp, multicallerAddress, balanceBatcherAddress, state.IsAtlasDeployed, opts)
+	if err != nil {
+		return 0, fmt.Errorf("error getting network contracts: %w", err)
+	}
+	nodes, err := rpstate.GetAllNativeNodeDetails(collector.rp, contracts, state.IsAtlasDeployed)
+	if err != nil {
+		return 0, fmt.Errorf("error getting node details: %w", err)
+	}
+
+	// Get the opted-in nodes
+	optedIn := []common.Address{}
+	for _, node := range nodes {
+		if node.SmoothingPoolRegistrationState && node.MinipoolCount.Sign() > 0 {
+			optedIn = append(optedIn, node.NodeAddress)
+		}
+	}
+
+	// Get their active minipool counts
+	counts := make([]*big.Int, len(optedIn))
+	for i := 0; i < len(optedIn); i += smoothingPoolNodeBatchSize {
+		max := i + smoothingPoolNodeBatchSize
+		if max > len(optedIn) {
+			max = len(optedIn)
+		}
+		mc, err := multicall.NewMultiCaller(collector.rp.Client, contracts.Multicaller.ContractAddress)
+		if err != nil {
+			return 0, err
+		}
+		for j := i; j < max; j++ {
+			mc.AddCall(contracts.RocketMinipoolManager, &counts[j], "getNodeActiveMinipoolCount", optedIn[j])
+		}
+		_, err = mc.FlexibleCall(true, opts)
+		if err != nil {
+			return 0, fmt.Errorf("error executing multicall: %w", err)
+		}
+	}
+
+	total := uint64(0)
+	for _, count := range counts {
+		if count != nil {
+			total += count.Uint64()
+		}
+	}
+	return total, nil
 }
 
 // Log error messages
 func (collector *SmoothingPoolCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
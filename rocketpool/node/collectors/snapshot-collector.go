@@ -57,6 +57,9 @@ type SnapshotCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new SnapshotCollector instance
@@ -94,6 +97,11 @@ func NewSnapshotCollector(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfi
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *SnapshotCollector) GetName() string {
+	return "snapshot"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *SnapshotCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.activeProposals
@@ -217,4 +225,5 @@ func (collector *SnapshotCollector) Collect(channel chan<- prometheus.Metric) {
 // Log error messages
 func (collector *SnapshotCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
@@ -35,6 +35,9 @@ type SupplyCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new PerformanceCollector instance
@@ -67,6 +70,11 @@ func NewSupplyCollector(rp *rocketpool.RocketPool, stateLocker *StateLocker) *Su
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *SupplyCollector) GetName() string {
+	return "supply"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *SupplyCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.nodeCount
@@ -159,4 +167,5 @@ func (collector *SupplyCollector) Collect(channel chan<- prometheus.Metric) {
 // Log error messages
 func (collector *SupplyCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
@@ -66,6 +66,9 @@ type TrustedNodeCollector struct {
 
 	// Prefix for logging
 	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
 }
 
 // Create a new NodeCollector instance
@@ -114,6 +117,11 @@ func NewTrustedNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAd
 	}
 }
 
+// Get the name used to enable or disable the collector in the config
+func (collector *TrustedNodeCollector) GetName() string {
+	return "trusted_node"
+}
+
 // Write metric descriptions to the Prometheus channel
 func (collector *TrustedNodeCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.proposalCount
@@ -337,4 +345,5 @@ func (collector *TrustedNodeCollector) Collect(channel chan<- prometheus.Metric)
 // Log error messages
 func (collector *TrustedNodeCollector) logError(err error) {
 	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
 }
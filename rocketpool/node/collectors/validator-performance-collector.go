@@ -0,0 +1,492 @@
+package collectors
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"golang.org/x/sync/errgroup"
+)
+
+// Settings
+const (
+	// The number of epochs the performance metrics are calculated over (roughly one day)
+	validatorPerformanceWindowEpochs uint64 = 225
+
+	// The most epochs to scan during a single collection, so a scrape doesn't time out while catching up
+	validatorPerformanceEpochsPerCollect uint64 = 4
+
+	// Attestations can be included until the end of the epoch after the one they were made in
+	validatorPerformanceInclusionEpochs uint64 = 1
+)
+
+// A validator's duties and how it performed them during a single epoch
+type validatorEpochPerformance struct {
+	attestationDuty   bool
+	attested          bool
+	inclusionDistance uint64
+	optimalDistance   uint64
+	proposalDuties    uint64
+	proposals         uint64
+	syncDuties        uint64
+	syncParticipation uint64
+}
+
+// The position of a validator in an attestation committee
+type committeePosition struct {
+	validatorIndex uint64
+	position       uint64
+}
+
+// Represents the collector for the performance of the node's validators
+type ValidatorPerformanceCollector struct {
+	// The number of attestations each validator was assigned
+	attestationDuties *prometheus.Desc
+
+	// The number of attestations each validator missed
+	missedAttestations *prometheus.Desc
+
+	// The average distance between the slot each validator attested to and the slot its attestation was included in
+	inclusionDistance *prometheus.Desc
+
+	// The average ratio of the best possible inclusion distance to the actual inclusion distance for each validator
+	attestationEffectiveness *prometheus.Desc
+
+	// The number of blocks each validator proposed
+	proposals *prometheus.Desc
+
+	// The number of block proposals each validator missed
+	missedProposals *prometheus.Desc
+
+	// The number of sync committee signatures each validator was assigned
+	syncDuties *prometheus.Desc
+
+	// The number of sync committee signatures each validator missed
+	missedSyncDuties *prometheus.Desc
+
+	// The beacon client
+	bc beacon.Client
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The Rocket Pool config
+	cfg *config.RocketPoolConfig
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// The performance of the node's validators in each scanned epoch, by validator index
+	epochs map[uint64]map[uint64]*validatorEpochPerformance
+
+	// The next epoch to scan
+	nextEpoch uint64
+
+	// Whether or not any epochs have been scanned yet
+	started bool
+
+	// The blocks that have been downloaded but are still needed for the next epoch's scan, by slot (nil for missed slots)
+	blocks map[uint64]*beacon.BeaconBlock
+
+	// The sync committee for each sync committee period that has been downloaded
+	syncCommittees map[uint64][]uint64
+
+	// Guards the scanned data, since Prometheus can collect concurrently
+	lock sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+
+	// Counts the errors the collector runs into
+	errorCounter
+}
+
+// Create a new ValidatorPerformanceCollector instance
+func NewValidatorPerformanceCollector(bc beacon.Client, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *ValidatorPerformanceCollector {
+	subsystem := "validator"
+	labels := []string{"minipool"}
+	return &ValidatorPerformanceCollector{
+		attestationDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestation_duties"),
+			"The number of attestations the validator was assigned in the performance window",
+			labels, nil,
+		),
+		missedAttestations: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_attestations"),
+			"The number of attestations the validator missed in the performance window",
+			labels, nil,
+		),
+		inclusionDistance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "inclusion_distance"),
+			"The average number of slots between the validator's attestations and the blocks they were included in",
+			labels, nil,
+		),
+		attestationEffectiveness: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestation_effectiveness"),
+			"The average ratio of the best possible inclusion distance to the actual inclusion distance of the validator's attestations",
+			labels, nil,
+		),
+		proposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposals"),
+			"The number of blocks the validator proposed in the performance window",
+			labels, nil,
+		),
+		missedProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_proposals"),
+			"The number of block proposals the validator missed in the performance window",
+			labels, nil,
+		),
+		syncDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "sync_duties"),
+			"The number of sync committee signatures the validator was assigned in the performance window",
+			labels, nil,
+		),
+		missedSyncDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_sync_duties"),
+			"The number of sync committee signatures the validator missed in the performance window",
+			labels, nil,
+		),
+		bc:             bc,
+		nodeAddress:    nodeAddress,
+		cfg:            cfg,
+		stateLocker:    stateLocker,
+		epochs:         map[uint64]map[uint64]*validatorEpochPerformance{},
+		blocks:         map[uint64]*beacon.BeaconBlock{},
+		syncCommittees: map[uint64][]uint64{},
+		logPrefix:      "Validator Performance Collector",
+	}
+}
+
+// Get the name used to enable or disable the collector in the config
+func (collector *ValidatorPerformanceCollector) GetName() string {
+	return "validator_performance"
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ValidatorPerformanceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.attestationDuties
+	channel <- collector.missedAttestations
+	channel <- collector.inclusionDistance
+	channel <- collector.attestationEffectiveness
+	channel <- collector.proposals
+	channel <- collector.missedProposals
+	channel <- collector.syncDuties
+	channel <- collector.missedSyncDuties
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ValidatorPerformanceCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	// Get the node's validators
+	minipools := map[uint64]common.Address{}
+	for _, mpd := range state.MinipoolDetailsByNode[collector.nodeAddress] {
+		validator := state.ValidatorDetails[mpd.Pubkey]
+		if validator.Exists {
+			minipools[validator.Index] = mpd.MinipoolAddress
+		}
+	}
+	if len(minipools) == 0 {
+		return
+	}
+
+	// Scan the epochs that have finished since the last collection
+	err := collector.updateEpochs(state.BeaconConfig, minipools)
+	if err != nil {
+		// Report what has been scanned so far anyway
+		collector.logError(err)
+	}
+
+	// Add up the performance of each validator over the window
+	for index, minipoolAddress := range minipools {
+		attestationDuties := float64(0)
+		attestations := float64(0)
+		inclusionDistance := float64(0)
+		effectiveness := float64(0)
+		proposalDuties := float64(0)
+		proposals := float64(0)
+		syncDuties := float64(0)
+		syncParticipation := float64(0)
+		for _, performance := range collector.epochs {
+			validatorPerformance, exists := performance[index]
+			if !exists {
+				continue
+			}
+			if validatorPerformance.attestationDuty {
+				attestationDuties++
+			}
+			if validatorPerformance.attested {
+				attestations++
+				inclusionDistance += float64(validatorPerformance.inclusionDistance)
+				effectiveness += float64(validatorPerformance.optimalDistance) / float64(validatorPerformance.inclusionDistance)
+			}
+			proposalDuties += float64(validatorPerformance.proposalDuties)
+			proposals += float64(validatorPerformance.proposals)
+			syncDuties += float64(validatorPerformance.syncDuties)
+			syncParticipation += float64(validatorPerformance.syncParticipation)
+		}
+
+		missedProposals := float64(0)
+		if proposalDuties > proposals {
+			missedProposals = proposalDuties - proposals
+		}
+		if attestations > 0 {
+			inclusionDistance /= attestations
+			effectiveness /= attestations
+		}
+
+		label := minipoolAddress.Hex()
+		channel <- prometheus.MustNewConstMetric(
+			collector.attestationDuties, prometheus.GaugeValue, attestationDuties, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.missedAttestations, prometheus.GaugeValue, attestationDuties-attestations, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.inclusionDistance, prometheus.GaugeValue, inclusionDistance, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.attestationEffectiveness, prometheus.GaugeValue, effectiveness, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposals, prometheus.GaugeValue, proposals, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.missedProposals, prometheus.GaugeValue, missedProposals, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.syncDuties, prometheus.GaugeValue, syncDuties, label)
+		channel <- prometheus.MustNewConstMetric(
+			collector.missedSyncDuties, prometheus.GaugeValue, syncDuties-syncParticipation, label)
+	}
+}
+
+// Scan the epochs that have finished since the last collection, and drop the ones that have left the window
+func (collector *ValidatorPerformanceCollector) updateEpochs(beaconConfig beacon.Eth2Config, minipools map[uint64]common.Address) error {
+	head, err := collector.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("Error getting Beacon chain head: %w", err)
+	}
+
+	// An epoch can only be scanned once every block that could include its attestations has been proposed
+	if head.Epoch < validatorPerformanceInclusionEpochs+1 {
+		return nil
+	}
+	latestEpoch := head.Epoch - validatorPerformanceInclusionEpochs - 1
+	if !collector.started {
+		// Start at the head and let the window fill up over time
+		collector.nextEpoch = latestEpoch
+		collector.started = true
+	}
+	if latestEpoch >= validatorPerformanceWindowEpochs && collector.nextEpoch < latestEpoch-validatorPerformanceWindowEpochs+1 {
+		// Skip the epochs that have already left the window
+		collector.nextEpoch = latestEpoch - validatorPerformanceWindowEpochs + 1
+	}
+
+	for scanned := uint64(0); collector.nextEpoch <= latestEpoch && scanned < validatorPerformanceEpochsPerCollect; scanned++ {
+		performance, err := collector.scanEpoch(beaconConfig, collector.nextEpoch, minipools)
+		if err != nil {
+			return fmt.Errorf("Error scanning epoch %d: %w", collector.nextEpoch, err)
+		}
+		collector.epochs[collector.nextEpoch] = performance
+		collector.nextEpoch++
+	}
+
+	// Drop the data that's no longer needed
+	for epoch := range collector.epochs {
+		if epoch+validatorPerformanceWindowEpochs < collector.nextEpoch {
+			delete(collector.epochs, epoch)
+		}
+	}
+	for slot := range collector.blocks {
+		if slot < collector.nextEpoch*beaconConfig.SlotsPerEpoch {
+			delete(collector.blocks, slot)
+		}
+	}
+	currentPeriod := collector.nextEpoch / beaconConfig.EpochsPerSyncCommitteePeriod
+	for period := range collector.syncCommittees {
+		if period < currentPeriod {
+			delete(collector.syncCommittees, period)
+		}
+	}
+
+	return nil
+}
+
+// Get the performance of the node's validators during an epoch
+func (collector *ValidatorPerformanceCollector) scanEpoch(beaconConfig beacon.Eth2Config, epoch uint64, minipools map[uint64]common.Address) (map[uint64]*validatorEpochPerformance, error) {
+	slotsPerEpoch := beaconConfig.SlotsPerEpoch
+	firstSlot := epoch * slotsPerEpoch
+	endSlot := (epoch + validatorPerformanceInclusionEpochs + 1) * slotsPerEpoch
+
+	indices := make([]uint64, 0, len(minipools))
+	for index := range minipools {
+		indices = append(indices, index)
+	}
+
+	// Get the duties and any blocks that haven't been downloaded yet
+	var wg errgroup.Group
+	wg.SetLimit(collector.getConcurrency())
+	var committees []beacon.Committee
+	var proposalDuties map[uint64]uint64
+	wg.Go(func() error {
+		var err error
+		committees, err = collector.bc.GetCommitteesForEpoch(&epoch)
+		if err != nil {
+			return fmt.Errorf("Error getting committees: %w", err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		proposalDuties, err = collector.bc.GetValidatorProposerDuties(indices, epoch)
+		if err != nil {
+			return fmt.Errorf("Error getting proposer duties: %w", err)
+		}
+		return nil
+	})
+	period := epoch / beaconConfig.EpochsPerSyncCommitteePeriod
+	syncCommittee, exists := collector.syncCommittees[period]
+	if !exists {
+		wg.Go(func() error {
+			var err error
+			syncCommittee, err = collector.bc.GetSyncCommitteeForEpoch(epoch)
+			if err != nil {
+				return fmt.Errorf("Error getting sync committee: %w", err)
+			}
+			return nil
+		})
+	}
+	newBlocks := make([]*beacon.BeaconBlock, endSlot-firstSlot)
+	for slot := firstSlot; slot < endSlot; slot++ {
+		if _, exists := collector.blocks[slot]; exists {
+			continue
+		}
+		slot := slot
+		wg.Go(func() error {
+			block, exists, err := collector.bc.GetBeaconBlock(fmt.Sprint(slot))
+			if err != nil {
+				return fmt.Errorf("Error getting block %d: %w", slot, err)
+			}
+			if exists {
+				newBlocks[slot-firstSlot] = &block
+			}
+			return nil
+		})
+	}
+	err := wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	for slot := firstSlot; slot < endSlot; slot++ {
+		if _, exists := collector.blocks[slot]; !exists {
+			collector.blocks[slot] = newBlocks[slot-firstSlot]
+		}
+	}
+	collector.syncCommittees[period] = syncCommittee
+
+	// Set up the records for each validator
+	performance := map[uint64]*validatorEpochPerformance{}
+	for _, index := range indices {
+		performance[index] = &validatorEpochPerformance{
+			proposalDuties: proposalDuties[index],
+		}
+	}
+
+	// Find the attestation duties of the validators
+	positions := map[uint64]map[uint64][]committeePosition{}
+	for _, committee := range committees {
+		for position, index := range committee.Validators {
+			validatorPerformance, exists := performance[index]
+			if !exists {
+				continue
+			}
+			validatorPerformance.attestationDuty = true
+			slotPositions, exists := positions[committee.Slot]
+			if !exists {
+				slotPositions = map[uint64][]committeePosition{}
+				positions[committee.Slot] = slotPositions
+			}
+			slotPositions[committee.Index] = append(slotPositions[committee.Index], committeePosition{
+				validatorIndex: index,
+				position:       uint64(position),
+			})
+		}
+	}
+
+	// Find the attestations in each block, in order, so the earliest inclusion of each one is recorded
+	for slot := firstSlot + 1; slot < endSlot; slot++ {
+		block := collector.blocks[slot]
+		if block == nil {
+			continue
+		}
+		for _, attestation := range block.Attestations {
+			committeePositions, exists := positions[attestation.SlotIndex][attestation.CommitteeIndex]
+			if !exists {
+				continue
+			}
+			for _, position := range committeePositions {
+				if !attestation.AggregationBits.BitAt(position.position) {
+					continue
+				}
+				validatorPerformance := performance[position.validatorIndex]
+				if validatorPerformance.attested {
+					continue
+				}
+				validatorPerformance.attested = true
+				validatorPerformance.inclusionDistance = slot - attestation.SlotIndex
+				validatorPerformance.optimalDistance = collector.getOptimalInclusionDistance(attestation.SlotIndex, slot)
+			}
+		}
+	}
+
+	// Check the proposals and sync committee signatures in each block of the epoch
+	syncPositions := map[uint64][]uint64{}
+	for position, index := range syncCommittee {
+		if _, exists := performance[index]; exists {
+			syncPositions[index] = append(syncPositions[index], uint64(position))
+		}
+	}
+	for slot := firstSlot; slot < firstSlot+slotsPerEpoch; slot++ {
+		block := collector.blocks[slot]
+		if block == nil {
+			// Missed slots don't count against the sync committee
+			continue
+		}
+		if validatorPerformance, exists := performance[block.ProposerIndex]; exists {
+			validatorPerformance.proposals++
+		}
+		for index, indexPositions := range syncPositions {
+			validatorPerformance := performance[index]
+			for _, position := range indexPositions {
+				validatorPerformance.syncDuties++
+				if block.SyncAggregateBits.BitAt(position) {
+					validatorPerformance.syncParticipation++
+				}
+			}
+		}
+	}
+
+	return performance, nil
+}
+
+// Get the best possible inclusion distance for an attestation, which is the distance to the first block after the slot it attested to
+func (collector *ValidatorPerformanceCollector) getOptimalInclusionDistance(attestationSlot uint64, inclusionSlot uint64) uint64 {
+	for slot := attestationSlot + 1; slot < inclusionSlot; slot++ {
+		if collector.blocks[slot] != nil {
+			return slot - attestationSlot
+		}
+	}
+	return inclusionSlot - attestationSlot
+}
+
+// Get the number of concurrent Beacon API requests to use when scanning an epoch
+func (collector *ValidatorPerformanceCollector) getConcurrency() int {
+	concurrency := int(collector.cfg.Smartnode.AttestationScanConcurrency.Value.(uint64))
+	if concurrency < 1 {
+		return 1
+	}
+	return concurrency
+}
+
+// Log error messages
+func (collector *ValidatorPerformanceCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+	collector.countError()
+}
@@ -17,7 +17,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
-	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -31,6 +31,7 @@ type distributeMinipools struct {
 	cfg                 *config.RocketPoolConfig
 	w                   *wallet.Wallet
 	rp                  *rocketpool.RocketPool
+	feeOracle           *oracle.FeeOracle
 	bc                  beacon.Client
 	d                   *client.Client
 	gasThreshold        float64
@@ -58,6 +59,10 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	if err != nil {
 		return nil, err
 	}
+	feeOracle, err := services.GetFeeOracle(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -107,6 +112,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		cfg:                 cfg,
 		w:                   w,
 		rp:                  rp,
+		feeOracle:           feeOracle,
 		bc:                  bc,
 		d:                   d,
 		gasThreshold:        gasThreshold,
@@ -241,7 +247,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = t.feeOracle.GetMaxFeeWei(oracle.FeeTier_Fast, t.maxPriorityFee)
 		if err != nil {
 			return false, err
 		}
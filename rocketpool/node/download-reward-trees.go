@@ -2,7 +2,6 @@ package node
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/docker/docker/client"
 	"github.com/rocket-pool/rocketpool-go/rewards"
@@ -28,6 +27,9 @@ type downloadRewardsTrees struct {
 	rp  *rocketpool.RocketPool
 	d   *client.Client
 	bc  beacon.Client
+
+	// Intervals whose local rewards files have already been verified against their RewardSnapshot events
+	verifiedIntervals map[uint64]bool
 }
 
 // Create manage fee recipient task
@@ -64,6 +66,8 @@ func newDownloadRewardsTrees(c *cli.Context, logger log.ColorLogger) (*downloadR
 		rp:  rp,
 		d:   d,
 		bc:  bc,
+
+		verifiedIntervals: map[uint64]bool{},
 	}, nil
 
 }
@@ -102,37 +106,41 @@ func (d *downloadRewardsTrees) run(state *state.NetworkState) error {
 		currentIndex = state.NetworkDetails.RewardIndex
 	}
 
-	// Check for missing intervals
-	missingIntervals := []uint64{}
-	for i := uint64(0); i < currentIndex; i++ {
-		// Check if the tree file exists
-		treeFilePath := d.cfg.Smartnode.GetRewardsTreePath(i, true)
-		_, err = os.Stat(treeFilePath)
-		if os.IsNotExist(err) {
-			d.log.Printlnf("You are missing the rewards tree file for interval %d.", i)
-			missingIntervals = append(missingIntervals, i)
-		} else if err != nil {
-			return fmt.Errorf("error checking if rewards interval %d file exists: %w", i, err)
-		}
+	// Get the intervals whose rewards trees are pruned by the retention policy, so they aren't downloaded again
+	prunableIntervals, err := rprewards.GetPrunableIntervals(d.rp, nodeAccount.Address, d.cfg.Smartnode.RewardsRetentionIntervals.Value.(uint64))
+	if err != nil {
+		return fmt.Errorf("error getting prunable intervals: %w", err)
 	}
 
-	if len(missingIntervals) == 0 {
-		return nil
-	}
+	// Check each interval that hasn't been verified yet for a missing or invalid file
+	for i := uint64(0); i < currentIndex; i++ {
+		if d.verifiedIntervals[i] || prunableIntervals[i] {
+			continue
+		}
 
-	// Download missing intervals
-	for _, missingInterval := range missingIntervals {
-		fmt.Printf("Downloading interval %d file... ", missingInterval)
-		intervalInfo, err := rprewards.GetIntervalInfo(d.rp, d.cfg, nodeAccount.Address, missingInterval)
+		// Get the RewardSnapshot event details and the local file status for this interval
+		intervalInfo, err := rprewards.GetIntervalInfo(d.rp, d.cfg, nodeAccount.Address, i)
 		if err != nil {
-			return fmt.Errorf("error getting interval %d info: %w", missingInterval, err)
+			return fmt.Errorf("error getting interval %d info: %w", i, err)
 		}
-		err = rprewards.DownloadRewardsFile(d.cfg, missingInterval, intervalInfo.CID, true)
+		if intervalInfo.TreeFileExists && intervalInfo.MerkleRootValid {
+			d.verifiedIntervals[i] = true
+			continue
+		}
+		if !intervalInfo.TreeFileExists {
+			d.log.Printlnf("You are missing the rewards tree file for interval %d.", i)
+		} else {
+			d.log.Printlnf("Your local copy of the rewards tree file for interval %d does not match the canonical one.", i)
+		}
+
+		// Download, decompress, and verify the file
+		d.log.Printlnf("Downloading interval %d file...", i)
+		err = rprewards.DownloadRewardsFile(d.cfg, i, intervalInfo.CID, intervalInfo.MerkleRoot, true)
 		if err != nil {
-			fmt.Println()
-			return err
+			return fmt.Errorf("error downloading interval %d file: %w", i, err)
 		}
-		fmt.Println("done!")
+		d.log.Printlnf("Downloaded and verified the rewards tree file for interval %d.", i)
+		d.verifiedIntervals[i] = true
 	}
 
 	return nil
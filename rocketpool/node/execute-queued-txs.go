@@ -0,0 +1,191 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
+	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The response fields every transaction API command returns
+type queuedTxResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// Execute queued transactions task
+type executeQueuedTxs struct {
+	c                  *cli.Context
+	log                log.ColorLogger
+	queue              *txqueue.Queue
+	feeOracle          *oracle.FeeOracle
+	defaultPriorityFee float64
+	globalArgs         []string
+}
+
+// Create execute queued transactions task
+func newExecuteQueuedTxs(c *cli.Context, logger log.ColorLogger) (*executeQueuedTxs, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	queue, err := services.GetTxQueue(c)
+	if err != nil {
+		return nil, err
+	}
+	feeOracle, err := services.GetFeeOracle(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the priority fee for intents that didn't request one
+	priorityFee := cfg.Smartnode.PriorityFee.Value.(float64)
+	if priorityFee == 0 {
+		priorityFee = 2
+	}
+
+	// Return task
+	return &executeQueuedTxs{
+		c:                  c,
+		log:                logger,
+		queue:              queue,
+		feeOracle:          feeOracle,
+		defaultPriorityFee: priorityFee,
+		globalArgs:         getDaemonGlobalArgs(c),
+	}, nil
+
+}
+
+// Submit the queued transactions whose base fee threshold has been reached
+func (t *executeQueuedTxs) run(state *state.NetworkState) error {
+
+	// Get the queued intents
+	intents, err := t.queue.GetQueued()
+	if err != nil {
+		return err
+	}
+	if len(intents) == 0 {
+		return nil
+	}
+
+	// Get the base fee of the next block
+	estimate, err := t.feeOracle.GetFeeEstimate()
+	if err != nil {
+		return err
+	}
+	baseFee := eth.WeiToGwei(estimate.BaseFee)
+
+	// Check each intent
+	for _, intent := range intents {
+		if time.Now().After(intent.Deadline) {
+			t.log.Printlnf("Queued transaction #%d (%s) expired before the base fee dropped below %.2f gwei.", intent.ID, intent.CommandLine(), intent.MaxBaseFee)
+			intent.Status = txqueue.Status_Expired
+			if err := t.queue.Update(intent); err != nil {
+				return err
+			}
+			continue
+		}
+		if baseFee >= intent.MaxBaseFee {
+			continue
+		}
+
+		// Mark it as running first so it can't be cancelled or submitted twice
+		t.log.Printlnf("The base fee is %.2f gwei, submitting queued transaction #%d (%s)...", baseFee, intent.ID, intent.CommandLine())
+		intent.Status = txqueue.Status_Running
+		if err := t.queue.Update(intent); err != nil {
+			return err
+		}
+
+		txHash, err := t.execute(intent)
+		if err != nil {
+			t.log.Printlnf("Queued transaction #%d failed: %s", intent.ID, err.Error())
+			intent.Status = txqueue.Status_Failed
+			intent.Error = err.Error()
+		} else {
+			t.log.Printlnf("Queued transaction #%d was submitted as %s.", intent.ID, txHash.Hex())
+			intent.Status = txqueue.Status_Submitted
+			intent.TxHash = txHash
+		}
+		if err := t.queue.Update(intent); err != nil {
+			return err
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Run an intent's API command with its gas settings and get the hash of the transaction it submitted
+func (t *executeQueuedTxs) execute(intent txqueue.Intent) (common.Hash, error) {
+
+	priorityFee := intent.PriorityFee
+	if priorityFee == 0 {
+		priorityFee = t.defaultPriorityFee
+	}
+	args := append([]string{}, t.globalArgs...)
+	args = append(args,
+		"--maxFee", strconv.FormatFloat(intent.MaxBaseFee+priorityFee, 'f', -1, 64),
+		"--maxPrioFee", strconv.FormatFloat(priorityFee, 'f', -1, 64),
+	)
+	if intent.GasLimit != 0 {
+		args = append(args, "--gasLimit", strconv.FormatUint(intent.GasLimit, 10))
+	}
+	args = append(append(args, "api", intent.Module, intent.Command), intent.Args...)
+
+	// Run the command
+	executable, err := os.Executable()
+	if err != nil {
+		executable = os.Args[0]
+	}
+	cmd := exec.Command(executable, args...)
+	cmd.Stderr = os.Stderr
+	output, runErr := cmd.Output()
+
+	// The API reports errors in its response, so only fall back to the exit status if there isn't one
+	var response queuedTxResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		if runErr != nil {
+			return common.Hash{}, fmt.Errorf("error running %s: %w", intent.CommandLine(), runErr)
+		}
+		return common.Hash{}, fmt.Errorf("could not decode the response of %s: %w", intent.CommandLine(), err)
+	}
+	if response.Error != "" {
+		return common.Hash{}, errors.New(response.Error)
+	}
+	txHash, exists := apiutils.GetResponseTxHash(output)
+	if !exists {
+		return common.Hash{}, fmt.Errorf("%s didn't report a transaction hash", intent.CommandLine())
+	}
+	return txHash, nil
+
+}
+
+// Get the global flags the daemon was started with, which are the arguments before its command
+func getDaemonGlobalArgs(c *cli.Context) []string {
+	names := append([]string{c.Command.Name}, c.Command.Aliases...)
+	for i, arg := range os.Args[1:] {
+		for _, name := range names {
+			if arg == name {
+				return os.Args[1 : i+1]
+			}
+		}
+	}
+	return []string{}
+}
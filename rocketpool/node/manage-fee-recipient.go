@@ -1,6 +1,7 @@
 package node
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/docker/docker/client"
@@ -8,6 +9,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -21,17 +23,18 @@ import (
 
 // Manage fee recipient task
 type manageFeeRecipient struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	d   *client.Client
-	bc  beacon.Client
+	c      *cli.Context
+	log    log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	w      *wallet.Wallet
+	rp     *rocketpool.RocketPool
+	d      *client.Client
+	bc     beacon.Client
+	status *collectors.FeeRecipientStatus
 }
 
 // Create manage fee recipient task
-func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRecipient, error) {
+func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger, status *collectors.FeeRecipientStatus) (*manageFeeRecipient, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -57,13 +60,14 @@ func newManageFeeRecipient(c *cli.Context, logger log.ColorLogger) (*manageFeeRe
 
 	// Return task
 	return &manageFeeRecipient{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		rp:  rp,
-		d:   d,
-		bc:  bc,
+		c:      c,
+		log:    logger,
+		cfg:    cfg,
+		w:      w,
+		rp:     rp,
+		d:      d,
+		bc:     bc,
+		status: status,
 	}, nil
 
 }
@@ -115,13 +119,38 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 	} else if !correctAddress {
 		m.log.Printlnf("WARNING: Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
 	} else {
-		// Files are all correct, return.
+		// Files are all correct, so check that the VC actually picked them up
+		vcCorrect, err := m.checkValidatorClient(nodeAccount.Address, correctFeeRecipient, state)
+		if err != nil {
+			m.log.Printlnf("WARNING: Couldn't check the validator client's fee recipient: %s", err.Error())
+			vcCorrect = true
+		}
+		if vcCorrect {
+			m.status.Update(correctFeeRecipient, true)
+			return nil
+		}
+
+		// The VC is still using an old fee recipient, so restart it to reload the files
+		m.status.Update(correctFeeRecipient, false)
+		m.log.Println("Restarting validator client so it loads the correct fee recipient...")
+		err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
+		if err != nil {
+			return fmt.Errorf("error restarting validator client: %w", err)
+		}
+		m.log.Println("Successfully restarted.")
 		return nil
 	}
 
-	// Regenerate the fee recipient files
+	// Regenerate the fee recipient files and make sure the new contents stuck
 	err = rpsvc.UpdateFeeRecipientFile(correctFeeRecipient, m.cfg)
+	if err == nil {
+		_, correctAddress, err = rpsvc.CheckFeeRecipientFile(correctFeeRecipient, m.cfg)
+		if err == nil && !correctAddress {
+			err = fmt.Errorf("fee recipient file still does not contain %s after being rewritten", correctFeeRecipient.Hex())
+		}
+	}
 	if err != nil {
+		m.status.Update(correctFeeRecipient, false)
 		m.log.Println("***ERROR***")
 		m.log.Printlnf("Error updating fee recipient files: %s", err.Error())
 		m.log.Println("Shutting down the validator client for safety to prevent you from being penalized...")
@@ -133,8 +162,10 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return nil
 	}
 
-	// Restart the VC
+	// Restart the VC so it picks up the new fee recipient
 	m.log.Println("Fee recipient files updated successfully! Restarting validator client...")
+	// This still counts as a mismatch; the next check clears it once the VC is confirmed to be using the new files
+	m.status.Update(correctFeeRecipient, false)
 	err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
 	if err != nil {
 		return fmt.Errorf("error restarting validator client: %w", err)
@@ -145,3 +176,35 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 	return nil
 
 }
+
+// Ask the VC which fee recipient it's using for each of the node's validators through its keymanager API, if it's configured.
+// Returns true if every validator it has loaded uses the correct fee recipient.
+func (m *manageFeeRecipient) checkValidatorClient(nodeAddress common.Address, correctFeeRecipient common.Address, state *state.NetworkState) (bool, error) {
+	url := m.cfg.Smartnode.VcKeymanagerUrl.Value.(string)
+	if url == "" {
+		return true, nil
+	}
+	keymanager, err := validator.NewKeymanagerClient(url, m.cfg.Smartnode.VcKeymanagerTokenPath.Value.(string))
+	if err != nil {
+		return false, err
+	}
+
+	isCorrect := true
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.Finalised {
+			continue
+		}
+		feeRecipient, err := keymanager.GetFeeRecipient(mpd.Pubkey)
+		if errors.Is(err, validator.ErrValidatorNotLoaded) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if feeRecipient != correctFeeRecipient {
+			m.log.Printlnf("WARNING: The validator client is using a fee recipient of %s for validator %s instead of %s.", feeRecipient.Hex(), mpd.Pubkey.Hex(), correctFeeRecipient.Hex())
+			isCorrect = false
+		}
+	}
+	return isCorrect, nil
+}
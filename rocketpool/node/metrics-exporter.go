@@ -10,11 +10,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/taskmetrics"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, feeRecipientStatus *collectors.FeeRecipientStatus, taskCollector *taskmetrics.TaskCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -41,6 +42,10 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	if err != nil {
 		return err
 	}
+	journal, err := services.GetTxJournal(c)
+	if err != nil {
+		return err
+	}
 
 	// Return if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
@@ -63,21 +68,35 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	rplCollector := collectors.NewRplCollector(rp, cfg, stateLocker)
 	odaoCollector := collectors.NewOdaoCollector(rp, stateLocker)
 	nodeCollector := collectors.NewNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
+	rewardsCollector := collectors.NewRewardsCollector(rp, nodeAccount.Address, cfg)
 	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
 	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker)
-	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, stateLocker)
+	validatorPerformanceCollector := collectors.NewValidatorPerformanceCollector(bc, nodeAccount.Address, cfg, stateLocker)
+	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, nodeAccount.Address, cfg, stateLocker)
+	feeRecipientCollector := collectors.NewFeeRecipientCollector(feeRecipientStatus)
+	ecFailoverCollector := collectors.NewEcFailoverCollector(ec)
+	gasCollector := collectors.NewGasCollector(ec, journal)
+	rethCollector := collectors.NewRethCollector(ec, cfg, stateLocker)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(demandCollector)
-	registry.MustRegister(performanceCollector)
-	registry.MustRegister(supplyCollector)
-	registry.MustRegister(rplCollector)
-	registry.MustRegister(odaoCollector)
-	registry.MustRegister(nodeCollector)
-	registry.MustRegister(trustedNodeCollector)
-	registry.MustRegister(beaconCollector)
-	registry.MustRegister(smoothingPoolCollector)
+	collectorRegistry := collectors.NewCollectorRegistry(registry, cfg.DisabledCollectors.Value.(string))
+	collectorRegistry.Register(demandCollector)
+	collectorRegistry.Register(performanceCollector)
+	collectorRegistry.Register(supplyCollector)
+	collectorRegistry.Register(rplCollector)
+	collectorRegistry.Register(odaoCollector)
+	collectorRegistry.Register(nodeCollector)
+	collectorRegistry.Register(rewardsCollector)
+	collectorRegistry.Register(trustedNodeCollector)
+	collectorRegistry.Register(beaconCollector)
+	collectorRegistry.Register(validatorPerformanceCollector)
+	collectorRegistry.Register(smoothingPoolCollector)
+	collectorRegistry.Register(feeRecipientCollector)
+	collectorRegistry.Register(ecFailoverCollector)
+	collectorRegistry.Register(gasCollector)
+	collectorRegistry.Register(rethCollector)
+	collectorRegistry.Register(taskCollector)
 
 	// Set up snapshot checking if enabled
 	votingId := cfg.Smartnode.GetVotingSnapshotID()
@@ -87,8 +106,23 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 			return fmt.Errorf("Error getting node delegate: %w", err)
 		}
 		snapshotCollector := collectors.NewSnapshotCollector(rp, cfg, nodeAccount.Address, votingDelegate)
-		registry.MustRegister(snapshotCollector)
+		collectorRegistry.Register(snapshotCollector)
+	}
+	// Set up the machine health checks; they rely on the Docker containers, so they aren't available in Native mode
+	if !cfg.IsNativeMode {
+		d, err := services.GetDocker(c)
+		if err != nil {
+			return fmt.Errorf("Error getting Docker client: %w", err)
+		}
+		healthCollector := collectors.NewHealthCollector(d, cfg)
+		collectorRegistry.Register(healthCollector)
 	}
+	for _, name := range collectorRegistry.GetUnknownDisabledCollectors() {
+		logger.Printlnf("WARNING: unknown metrics collector '%s' is set to be disabled.", name)
+	}
+
+	// Push the metrics to a remote service if enabled
+	go runMetricsPusher(cfg, registry, logger)
 
 	// Start the HTTP server
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
@@ -0,0 +1,212 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	metricsPushTimeout         time.Duration = 30 * time.Second
+	remoteWriteVersion         string        = "0.1.0"
+	remoteWriteContentType     string        = "application/x-protobuf"
+	remoteWriteContentEncoding string        = "snappy"
+)
+
+// A label on a remote-write time series
+type remoteWriteLabel struct {
+	name  string
+	value string
+}
+
+// A single sample of a remote-write time series
+type remoteWriteSeries struct {
+	labels    []remoteWriteLabel
+	value     float64
+	timestamp int64
+}
+
+// Push the metrics in the registry to the configured remote service on a schedule, until the process exits
+func runMetricsPusher(cfg *config.RocketPoolConfig, registry *prometheus.Registry, logger log.ColorLogger) {
+	mode := cfg.MetricsPush.Mode.Value.(cfgtypes.MetricsPushMode)
+	if mode == cfgtypes.MetricsPushMode_Disabled {
+		return
+	}
+	url := cfg.MetricsPush.Url.Value.(string)
+	if url == "" {
+		logger.Println("WARNING: metrics pushing is enabled but no URL is set, so metrics will not be pushed.")
+		return
+	}
+	interval := time.Duration(cfg.MetricsPush.Interval.Value.(uint64)) * time.Second
+	if interval == 0 {
+		interval = time.Minute
+	}
+	job := cfg.MetricsPush.Job.Value.(string)
+	username := cfg.MetricsPush.Username.Value.(string)
+	password := cfg.MetricsPush.Password.Value.(string)
+	client := &http.Client{
+		Timeout: metricsPushTimeout,
+	}
+
+	var pushMetrics func() error
+	switch mode {
+	case cfgtypes.MetricsPushMode_Pushgateway:
+		pusher := push.New(url, job).Gatherer(registry).Client(client)
+		if username != "" || password != "" {
+			pusher = pusher.BasicAuth(username, password)
+		}
+		pushMetrics = pusher.Push
+	case cfgtypes.MetricsPushMode_RemoteWrite:
+		pushMetrics = func() error {
+			return remoteWrite(client, url, job, username, password, registry)
+		}
+	default:
+		logger.Printlnf("WARNING: unknown metrics push mode '%s', metrics will not be pushed.", mode)
+		return
+	}
+
+	logger.Printlnf("Pushing metrics to %s every %s (%s mode).", url, interval, mode)
+	for {
+		err := pushMetrics()
+		if err != nil {
+			logger.Printlnf("WARNING: error pushing metrics: %s", err.Error())
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Send the metrics in the registry to a remote-write endpoint
+func remoteWrite(client *http.Client, url string, job string, username string, password string, registry *prometheus.Registry) error {
+	families, err := registry.Gather()
+	if err != nil {
+		return fmt.Errorf("error gathering metrics: %w", err)
+	}
+	series := getRemoteWriteSeries(families, job, time.Now().UnixMilli())
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+
+	ctx, cancel := context.WithTimeout(context.Background(), metricsPushTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating remote-write request: %w", err)
+	}
+	request.Header.Set("Content-Type", remoteWriteContentType)
+	request.Header.Set("Content-Encoding", remoteWriteContentEncoding)
+	request.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+	if username != "" || password != "" {
+		request.SetBasicAuth(username, password)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error sending remote-write request: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("remote-write endpoint returned HTTP status %d; response body: '%s'", response.StatusCode, string(responseBody))
+	}
+	return nil
+}
+
+// Flatten gathered metric families into remote-write time series, splitting summaries and histograms the same way Prometheus does when it scrapes them
+func getRemoteWriteSeries(families []*dto.MetricFamily, job string, timestamp int64) []remoteWriteSeries {
+	series := []remoteWriteSeries{}
+	for _, family := range families {
+		name := family.GetName()
+		for _, metric := range family.GetMetric() {
+			// The job label is set to the push job; a metric's own job label is kept as exported_job, the same way Prometheus
+			// handles a scraped label that conflicts with a target label
+			labels := []remoteWriteLabel{{name: "job", value: job}}
+			for _, label := range metric.GetLabel() {
+				labelName := label.GetName()
+				if labelName == "job" {
+					labelName = "exported_job"
+				}
+				labels = append(labels, remoteWriteLabel{name: labelName, value: label.GetValue()})
+			}
+			addSeries := func(suffix string, value float64, extraLabels ...remoteWriteLabel) {
+				seriesLabels := append([]remoteWriteLabel{{name: "__name__", value: name + suffix}}, labels...)
+				seriesLabels = append(seriesLabels, extraLabels...)
+				sort.Slice(seriesLabels, func(i, j int) bool {
+					return seriesLabels[i].name < seriesLabels[j].name
+				})
+				series = append(series, remoteWriteSeries{
+					labels:    seriesLabels,
+					value:     value,
+					timestamp: timestamp,
+				})
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				addSeries("", metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				addSeries("", metric.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				addSeries("", metric.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				for _, quantile := range summary.GetQuantile() {
+					addSeries("", quantile.GetValue(), remoteWriteLabel{name: "quantile", value: fmt.Sprint(quantile.GetQuantile())})
+				}
+				addSeries("_sum", summary.GetSampleSum())
+				addSeries("_count", float64(summary.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				for _, bucket := range histogram.GetBucket() {
+					addSeries("_bucket", float64(bucket.GetCumulativeCount()), remoteWriteLabel{name: "le", value: fmt.Sprint(bucket.GetUpperBound())})
+				}
+				addSeries("_bucket", float64(histogram.GetSampleCount()), remoteWriteLabel{name: "le", value: "+Inf"})
+				addSeries("_sum", histogram.GetSampleSum())
+				addSeries("_count", float64(histogram.GetSampleCount()))
+			}
+		}
+	}
+	return series
+}
+
+// Encode time series as a remote-write WriteRequest protobuf message
+func encodeWriteRequest(series []remoteWriteSeries) []byte {
+	request := []byte{}
+	for _, s := range series {
+		timeSeries := []byte{}
+		for _, label := range s.labels {
+			encodedLabel := []byte{}
+			encodedLabel = protowire.AppendTag(encodedLabel, 1, protowire.BytesType)
+			encodedLabel = protowire.AppendString(encodedLabel, label.name)
+			encodedLabel = protowire.AppendTag(encodedLabel, 2, protowire.BytesType)
+			encodedLabel = protowire.AppendString(encodedLabel, label.value)
+			timeSeries = protowire.AppendTag(timeSeries, 1, protowire.BytesType)
+			timeSeries = protowire.AppendBytes(timeSeries, encodedLabel)
+		}
+
+		sample := []byte{}
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(s.timestamp))
+		timeSeries = protowire.AppendTag(timeSeries, 2, protowire.BytesType)
+		timeSeries = protowire.AppendBytes(timeSeries, sample)
+
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, timeSeries)
+	}
+	return request
+}
@@ -15,8 +15,13 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/reload"
+	"github.com/rocket-pool/smartnode/shared/services/shutdown"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/taskmetrics"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
@@ -33,22 +38,58 @@ const (
 
 	StakePrelaunchMinipoolsColor = color.FgBlue
 	DownloadRewardsTreesColor    = color.FgGreen
+	PruneRewardsFilesColor       = color.FgHiMagenta
 	MetricsColor                 = color.FgHiYellow
 	ManageFeeRecipientColor      = color.FgHiCyan
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
+	CheckNodeHealthColor         = color.FgCyan
 	DistributeMinipoolsColor     = color.FgHiGreen
+	SubmitVotingTreesColor       = color.FgHiBlack
+	ExecuteQueuedTxsColor        = color.FgHiRed
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
 )
 
+// Names of the tasks, which tag their log entries and metrics
+const (
+	taskName_UpdateNetworkState      string = "update-network-state"
+	taskName_ManageFeeRecipient      string = "manage-fee-recipient"
+	taskName_DownloadRewardsTrees    string = "download-rewards-trees"
+	taskName_PruneRewardsFiles       string = "prune-rewards-files"
+	taskName_StakePrelaunchMinipools string = "stake-prelaunch-minipools"
+	taskName_DistributeMinipools     string = "distribute-minipools"
+	taskName_ReduceBonds             string = "reduce-bonds"
+	taskName_PromoteMinipools        string = "promote-minipools"
+	taskName_CheckNodeHealth         string = "check-node-health"
+	taskName_SubmitVotingTrees       string = "submit-voting-trees"
+	taskName_ExecuteQueuedTxs        string = "execute-queued-txs"
+)
+
+// A task run on each pass of the task loop
+type nodeTask struct {
+	name string
+	run  func(*state.NetworkState) error
+}
+
 // Register node command
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 	app.Commands = append(app.Commands, cli.Command{
 		Name:    name,
 		Aliases: aliases,
 		Usage:   "Run Rocket Pool node activity daemon",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "health-address",
+				Usage: "Address to serve the /healthz and /readyz checks on if enabled",
+				Value: "0.0.0.0",
+			},
+			cli.UintFlag{
+				Name:  "health-port",
+				Usage: "Port to serve the /healthz and /readyz checks on; leave at 0 to disable them",
+			},
+		},
 		Action: func(c *cli.Context) error {
 			return run(c)
 		},
@@ -72,6 +113,19 @@ func run(c *cli.Context) error {
 
 	// Configure
 	configureHTTP()
+	logSettingsLog := log.NewModuleLogger(log.ModuleNode, log.LevelInfo, UpdateColor)
+	if err := services.ConfigureLogging(c, health.Process_Node, &logSettingsLog); err != nil {
+		return err
+	}
+
+	// Listen for the wallet to be unlocked if its password is only kept in memory
+	go func() {
+		unlockLog := log.NewModuleLogger(log.ModuleNode, log.LevelInfo, UpdateColor)
+		err := services.ServeWalletUnlock(c, health.Process_Node, &unlockLog)
+		if err != nil {
+			unlockLog.Println(err)
+		}
+	}()
 
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
@@ -91,10 +145,18 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return err
 	}
+	alerter, err := services.GetAlerter(c)
+	if err != nil {
+		return err
+	}
 
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
@@ -102,136 +164,266 @@ func run(c *cli.Context) error {
 	}
 
 	// Initialize loggers
-	errorLog := log.NewColorLogger(ErrorColor)
-	updateLog := log.NewColorLogger(UpdateColor)
+	errorLog := log.NewModuleLogger(log.ModuleNode, log.LevelError, ErrorColor)
+	updateLog := log.NewModuleLogger(log.ModuleNode, log.LevelInfo, UpdateColor)
+
+	// Warn about the trust assumptions of remote providers
+	if cfg.IsRemoteExecutionProvider() {
+		errorLog.Println("WARNING: The primary Execution client is a remote provider. Your node trusts it to report the chain correctly.")
+	}
+	if cfg.IsRemoteConsensusProvider() {
+		errorLog.Println("WARNING: The primary Consensus client is a remote provider. Your node trusts it to report the chain correctly.")
+	}
 
 	// Create the state manager
 	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
 	if err != nil {
 		return err
 	}
+	m.EnableSnapshots()
+	m.EnableStateCache()
 	stateLocker := collectors.NewStateLocker()
+	feeRecipientStatus := collectors.NewFeeRecipientStatus()
 
 	// Initialize tasks
-	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor))
+	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewModuleLogger(log.ModuleFeeRecipient, log.LevelInfo, ManageFeeRecipientColor).WithTask(taskName_ManageFeeRecipient), feeRecipientStatus)
 	if err != nil {
 		return err
 	}
-	distributeMinipools, err := newDistributeMinipools(c, log.NewColorLogger(DistributeMinipoolsColor))
+	downloadRewardsTrees, err := newDownloadRewardsTrees(c, log.NewModuleLogger(log.ModuleRewards, log.LevelInfo, DownloadRewardsTreesColor).WithTask(taskName_DownloadRewardsTrees))
 	if err != nil {
 		return err
 	}
-	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
+	pruneRewardsFiles, err := newPruneRewardsFiles(c, log.NewModuleLogger(log.ModuleRewards, log.LevelInfo, PruneRewardsFilesColor).WithTask(taskName_PruneRewardsFiles))
 	if err != nil {
 		return err
 	}
-	promoteMinipools, err := newPromoteMinipools(c, log.NewColorLogger(PromoteMinipoolsColor))
+	checkNodeHealth, err := newCheckNodeHealth(c, log.NewModuleLogger(log.ModuleNodeHealth, log.LevelInfo, CheckNodeHealthColor).WithTask(taskName_CheckNodeHealth))
 	if err != nil {
 		return err
 	}
-	downloadRewardsTrees, err := newDownloadRewardsTrees(c, log.NewColorLogger(DownloadRewardsTreesColor))
+	executeQueuedTxs, err := newExecuteQueuedTxs(c, log.NewModuleLogger(log.ModuleTxQueue, log.LevelInfo, ExecuteQueuedTxsColor).WithTask(taskName_ExecuteQueuedTxs))
 	if err != nil {
 		return err
 	}
-	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor))
+
+	// Tasks run in this order on each pass of the task loop.
+	// The transaction tasks read their gas settings when they're created, so they're recreated when the config is reloaded.
+	createTasks := func() ([]nodeTask, error) {
+		distributeMinipools, err := newDistributeMinipools(c, log.NewModuleLogger(log.ModuleDistributeMinipools, log.LevelInfo, DistributeMinipoolsColor).WithTask(taskName_DistributeMinipools))
+		if err != nil {
+			return nil, err
+		}
+		stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewModuleLogger(log.ModuleStakeMinipools, log.LevelInfo, StakePrelaunchMinipoolsColor).WithTask(taskName_StakePrelaunchMinipools))
+		if err != nil {
+			return nil, err
+		}
+		promoteMinipools, err := newPromoteMinipools(c, log.NewModuleLogger(log.ModulePromoteMinipools, log.LevelInfo, PromoteMinipoolsColor).WithTask(taskName_PromoteMinipools))
+		if err != nil {
+			return nil, err
+		}
+		reduceBonds, err := newReduceBonds(c, log.NewModuleLogger(log.ModuleReduceBonds, log.LevelInfo, ReduceBondAmountColor).WithTask(taskName_ReduceBonds))
+		if err != nil {
+			return nil, err
+		}
+		submitVotingTrees, err := newSubmitVotingTrees(c, log.NewModuleLogger(log.ModuleVotingTrees, log.LevelInfo, SubmitVotingTreesColor).WithTask(taskName_SubmitVotingTrees))
+		if err != nil {
+			return nil, err
+		}
+		return []nodeTask{
+			// Manage the fee recipient for the node
+			{taskName_ManageFeeRecipient, manageFeeRecipient.run},
+			// Run the rewards download check
+			{taskName_DownloadRewardsTrees, downloadRewardsTrees.run},
+			// Prune old rewards files
+			{taskName_PruneRewardsFiles, pruneRewardsFiles.run},
+			// Run the minipool stake check
+			{taskName_StakePrelaunchMinipools, txTask(cfg, taskName_StakePrelaunchMinipools, stakePrelaunchMinipools.run, &updateLog)},
+			// Run the balance distribution check
+			{taskName_DistributeMinipools, txTask(cfg, taskName_DistributeMinipools, distributeMinipools.run, &updateLog)},
+			// Run the reduce bond check
+			{taskName_ReduceBonds, txTask(cfg, taskName_ReduceBonds, reduceBonds.run, &updateLog)},
+			// Run the minipool promotion check
+			{taskName_PromoteMinipools, txTask(cfg, taskName_PromoteMinipools, promoteMinipools.run, &updateLog)},
+			// Run the node health check
+			{taskName_CheckNodeHealth, checkNodeHealth.run},
+			// Answer challenges to the node's protocol DAO proposals
+			{taskName_SubmitVotingTrees, txTask(cfg, taskName_SubmitVotingTrees, submitVotingTrees.run, &updateLog)},
+			// Submit the transactions the user queued once the base fee has dropped enough; they approved each one, so the wallet policy doesn't apply
+			{taskName_ExecuteQueuedTxs, executeQueuedTxs.run},
+		}, nil
+	}
+	tasks, err := createTasks()
+	if err != nil {
+		return err
+	}
+
+	// Stop starting new tasks when the daemon is told to shut down
+	coordinator := shutdown.NewCoordinator(cfg.Smartnode.GetPrepareShutdownPath(true), &updateLog)
+
+	// Reload the config between task passes when asked to
+	reloadWatcher := reload.NewWatcher(cfg.Smartnode.GetReloadConfigPath(true), &updateLog)
+
+	// Restart when the wallet profile is switched, since the node account is part of the daemon's state
+	walletProfile := cfg.Smartnode.GetActiveWalletProfile()
+	walletProfileSwitched := false
+
+	// Record the task loop's progress for health checks
+	heartbeat := health.NewHeartbeat(cfg.Smartnode.GetTaskLoopHeartbeatPath(true, health.Process_Node), &errorLog)
+
+	// Record how long each task takes and whether it succeeds, and trace each pass if enabled
+	traceExporter, err := taskmetrics.NewTraceExporter(cfg, health.Process_Node, &errorLog)
 	if err != nil {
 		return err
 	}
+	taskRecorder := taskmetrics.NewRecorder(health.Process_Node, traceExporter)
+	taskRecorder.OnPanic(func(err *taskmetrics.PanicError) {
+		reportTaskPanic(cfg, alerter, &errorLog, health.Process_Node, err)
+	})
 
-	// Wait group to handle the various threads
+	// Wait group to handle the task loop
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(1)
 
 	// Run task loop
 	isAtlasDeployedMasterFlag := false
 	go func() {
-		for {
+		for !coordinator.IsStopping() {
+			// Wait while a shutdown is being prepared
+			if !coordinator.CanStartTasks() {
+				coordinator.Sleep(taskCooldown)
+				continue
+			}
+			if activeProfile := cfg.Smartnode.GetActiveWalletProfile(); activeProfile != walletProfile {
+				updateLog.Printlnf("The wallet profile was switched from '%s' to '%s', restarting to load it.", walletProfile, activeProfile)
+				walletProfileSwitched = true
+				break
+			}
+			if reloadWatcher.IsRequested() {
+				result, err := services.ReloadConfig(c)
+				if err != nil {
+					errorLog.Printlnf("Error reloading the config, keeping the current one: %s", err.Error())
+				} else {
+					reload.LogResult(result, &updateLog)
+					if len(result.Applied) > 0 {
+						newTasks, err := createTasks()
+						if err != nil {
+							errorLog.Printlnf("Error recreating the tasks with the new config: %s", err.Error())
+						} else {
+							tasks = newTasks
+						}
+					}
+				}
+			}
+
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
 			if err != nil {
 				errorLog.Println(err)
-				time.Sleep(taskCooldown)
+				alertClientSyncFailure(alerter, &errorLog, alerting.ExecutionClientSyncAlertKey, "Execution client is not ready", err)
+				coordinator.Sleep(taskCooldown)
 				continue
 			}
+			alerter.Resolve(alerting.ExecutionClientSyncAlertKey)
+			alertClientFailover(alerter, &errorLog, ec)
 
 			// Check the BC status
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
 			if err != nil {
 				errorLog.Println(err)
-				time.Sleep(taskCooldown)
+				alertClientSyncFailure(alerter, &errorLog, alerting.BeaconClientSyncAlertKey, "Beacon node is not ready", err)
+				coordinator.Sleep(taskCooldown)
 				continue
 			}
+			alerter.Resolve(alerting.BeaconClientSyncAlertKey)
 
 			// Update the network state
-			state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAccount.Address)
+			pass := taskRecorder.StartPass()
+			var networkState *state.NetworkState
+			var totalEffectiveStake *big.Int
+			err = pass.RunTask(taskName_UpdateNetworkState, func() error {
+				var err error
+				networkState, totalEffectiveStake, err = updateNetworkState(m, &updateLog, nodeAccount.Address)
+				return err
+			})
 			if err != nil {
 				errorLog.Println(err)
-				time.Sleep(taskCooldown)
+				pass.End()
+				coordinator.Sleep(taskCooldown)
 				continue
 			}
-			stateLocker.UpdateState(state, totalEffectiveStake)
+			stateLocker.UpdateState(networkState, totalEffectiveStake)
 
 			// Check for Atlas
-			if !isAtlasDeployedMasterFlag && state.IsAtlasDeployed {
+			if !isAtlasDeployedMasterFlag && networkState.IsAtlasDeployed {
 				printAtlasMessage(&updateLog)
 				isAtlasDeployedMasterFlag = true
 			}
 
-			// Manage the fee recipient for the node
-			if err := manageFeeRecipient.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			// Run the rewards download check
-			if err := downloadRewardsTrees.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			// Run the minipool stake check
-			if err := stakePrelaunchMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			// Run the balance distribution check
-			if err := distributeMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
-				errorLog.Println(err)
+			// Run the tasks, unless the daemon is shutting down
+			heartbeat.RecordPassStart()
+			for i, task := range tasks {
+				if i > 0 {
+					coordinator.Sleep(taskCooldown)
+				}
+				if !coordinator.CanStartTasks() {
+					break
+				}
+				err := pass.RunTask(task.name, func() error {
+					return task.run(networkState)
+				})
+				if err != nil {
+					taskLog := errorLog.WithTask(task.name)
+					taskLog.Println(err)
+				}
 			}
+			heartbeat.RecordPassCompletion()
+			pass.End()
 
-			time.Sleep(tasksInterval)
+			coordinator.Sleep(tasksInterval)
 		}
 		wg.Done()
 	}()
 
+	// Serve the health checks if enabled
+	if healthPort := c.Uint("health-port"); healthPort != 0 {
+		go func() {
+			err := health.RunServer(c, fmt.Sprintf("%s:%d", c.String("health-address"), healthPort), health.Process_Node, &updateLog)
+			if err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewModuleLogger(log.ModuleMetrics, log.LevelInfo, MetricsColor), stateLocker, feeRecipientStatus, taskRecorder.GetCollector())
 		if err != nil {
 			errorLog.Println(err)
 		}
-		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Wait for the task loop to stop
 	wg.Wait()
+	if walletProfileSwitched {
+		return fmt.Errorf("restarting to load wallet profile '%s'", cfg.Smartnode.GetActiveWalletProfile())
+	}
+	updateLog.Println("Task loop stopped, shutting down.")
 	return nil
 
 }
 
+// Get a task that submits transactions, or one that does nothing if the wallet policy doesn't let it run automatically
+func txTask(cfg *config.RocketPoolConfig, name string, task func(*state.NetworkState) error, logger *log.ColorLogger) func(*state.NetworkState) error {
+	if cfg.Smartnode.IsAutoTxTaskApproved(name) {
+		return task
+	}
+	logger.Printlnf("The wallet policy doesn't let the %s task submit transactions automatically, so it won't run.", name)
+	return func(*state.NetworkState) error {
+		return nil
+	}
+}
+
 // Configure HTTP transport settings
 func configureHTTP() {
 
@@ -312,7 +504,7 @@ func removeLegacyFeeRecipientFiles(c *cli.Context) error {
 
 // Check if Atlas has been deployed yet
 func printAtlasMessage(log *log.ColorLogger) {
-	log.Println(`
+	log.Print(`
 *       .
 *      / \
 *     |.'.|
@@ -342,3 +534,52 @@ func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, node
 	}
 	return state, totalEffectiveStake, nil
 }
+
+// Send an alert while the Execution client manager is using the fallback client, and resolve it once the primary is back
+func alertClientFailover(alerter *alerting.Alerter, errorLog *log.ColorLogger, ec *services.ExecutionClientManager) {
+	stats := ec.GetFailoverStats()
+	if !stats.UsingFallback {
+		alerter.Resolve(alerting.ExecutionClientFailoverAlertKey)
+		return
+	}
+	alertErr := alerter.Publish(alerting.Alert{
+		Key:      alerting.ExecutionClientFailoverAlertKey,
+		Severity: alerting.Severity_Warning,
+		Title:    "Using the fallback Execution client",
+		Message:  fmt.Sprintf("The primary Execution client failed at %s (%s); the Smartnode is using the fallback client until it recovers.", stats.LastFailoverTime.Format(time.RFC1123), stats.LastFailoverReason),
+	})
+	if alertErr != nil {
+		errorLog.Println(alertErr)
+	}
+}
+
+// Send an alert when a client fails its sync check
+func alertClientSyncFailure(alerter *alerting.Alerter, errorLog *log.ColorLogger, key string, title string, err error) {
+	alertErr := alerter.Publish(alerting.Alert{
+		Key:      key,
+		Severity: alerting.Severity_Critical,
+		Title:    title,
+		Message:  err.Error(),
+	})
+	if alertErr != nil {
+		errorLog.Println(alertErr)
+	}
+}
+
+// Log the stack trace of a task that crashed, and send an alert about it if enabled
+func reportTaskPanic(cfg *config.RocketPoolConfig, alerter *alerting.Alerter, errorLog *log.ColorLogger, process string, err *taskmetrics.PanicError) {
+	taskLog := errorLog.WithTask(err.Task)
+	taskLog.Printlnf("The %s task crashed, recovering and continuing with the other tasks. Stack trace:\n%s", err.Task, err.Stack)
+	if cfg.Alerting.AlertOnTaskPanics.Value != true {
+		return
+	}
+	alertErr := alerter.Publish(alerting.Alert{
+		Key:      alerting.TaskPanicAlertKeyPrefix + err.Task,
+		Severity: alerting.Severity_Critical,
+		Title:    fmt.Sprintf("The %s task crashed", err.Task),
+		Message:  fmt.Sprintf("The %s task of the %s daemon crashed with [%v]; the daemon is still running, but the task will likely keep failing. Check the daemon's logs for the stack trace.", err.Task, process, err.Value),
+	})
+	if alertErr != nil {
+		errorLog.Println(alertErr)
+	}
+}
@@ -18,7 +18,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
-	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -32,6 +32,7 @@ type promoteMinipools struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	feeOracle      *oracle.FeeOracle
 	d              *client.Client
 	gasThreshold   float64
 	maxFee         *big.Int
@@ -55,6 +56,10 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 	if err != nil {
 		return nil, err
 	}
+	feeOracle, err := services.GetFeeOracle(c)
+	if err != nil {
+		return nil, err
+	}
 	d, err := services.GetDocker(c)
 	if err != nil {
 		return nil, err
@@ -89,6 +94,7 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		feeOracle:      feeOracle,
 		d:              d,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
@@ -217,7 +223,7 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = t.feeOracle.GetMaxFeeWei(oracle.FeeTier_Fast, t.maxPriorityFee)
 		if err != nil {
 			return false, err
 		}
@@ -0,0 +1,120 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Prune rewards files task
+type pruneRewardsFiles struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	rp  *rocketpool.RocketPool
+
+	// The reward index files were last pruned at, so pruning only happens once per interval
+	lastPrunedIndex *uint64
+}
+
+// Create prune rewards files task
+func newPruneRewardsFiles(c *cli.Context, logger log.ColorLogger) (*pruneRewardsFiles, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &pruneRewardsFiles{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	}, nil
+
+}
+
+// Prune rewards files
+func (p *pruneRewardsFiles) run(state *state.NetworkState) error {
+
+	// Check if pruning is enabled
+	retention := p.cfg.Smartnode.RewardsRetentionIntervals.Value.(uint64)
+	if retention == 0 {
+		return nil
+	}
+
+	// Get the current interval
+	var currentIndex uint64
+	if !state.IsAtlasDeployed {
+		currentIndexBig, err := rewards.GetRewardIndex(p.rp, nil)
+		if err != nil {
+			return err
+		}
+		currentIndex = currentIndexBig.Uint64()
+	} else {
+		currentIndex = state.NetworkDetails.RewardIndex
+	}
+
+	// Only prune once per interval
+	if p.lastPrunedIndex != nil && *p.lastPrunedIndex == currentIndex {
+		return nil
+	}
+
+	// Log
+	p.log.Printlnf("Checking for rewards files older than %d intervals to prune...", retention)
+
+	// Get node account
+	nodeAccount, err := p.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the files to prune
+	files, err := rprewards.GetPrunableRewardsFiles(p.rp, p.cfg, nodeAccount.Address, retention, true)
+	if err != nil {
+		return fmt.Errorf("error getting rewards files to prune: %w", err)
+	}
+
+	if len(files) > 0 {
+		var size uint64
+		for _, file := range files {
+			size += file.Size
+		}
+
+		mode := p.cfg.Smartnode.RewardsPruneMode.Value.(cfgtypes.RewardsPruneMode)
+		err = rprewards.PruneRewardsFiles(p.cfg, files, nodeAccount.Address, mode, true)
+		if err != nil {
+			return fmt.Errorf("error pruning rewards files: %w", err)
+		}
+		p.log.Printlnf("Pruned %d rewards files (%.2f MiB, mode: %s).", len(files), math.RoundDown(float64(size)/1024/1024, 2), mode)
+	} else {
+		p.log.Println("No rewards files need to be pruned.")
+	}
+
+	p.lastPrunedIndex = &currentIndex
+	return nil
+
+}
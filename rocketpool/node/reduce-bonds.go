@@ -18,7 +18,7 @@ import (
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
-	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -35,6 +35,7 @@ type reduceBonds struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	feeOracle      *oracle.FeeOracle
 	d              *client.Client
 	gasThreshold   float64
 	maxFee         *big.Int
@@ -67,6 +68,10 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 	if err != nil {
 		return nil, err
 	}
+	feeOracle, err := services.GetFeeOracle(c)
+	if err != nil {
+		return nil, err
+	}
 	d, err := services.GetDocker(c)
 	if err != nil {
 		return nil, err
@@ -101,6 +106,7 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		feeOracle:      feeOracle,
 		d:              d,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
@@ -250,7 +256,7 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = t.feeOracle.GetMaxFeeWei(oracle.FeeTier_Fast, t.maxPriorityFee)
 		if err != nil {
 			return false, err
 		}
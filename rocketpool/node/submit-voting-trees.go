@@ -0,0 +1,395 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/storage"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Submit voting trees task
+type submitVotingTrees struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	gasThreshold   float64
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// Create submit voting trees task
+func newSubmitVotingTrees(c *cli.Context, logger log.ColorLogger) (*submitVotingTrees, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the gas threshold
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &submitVotingTrees{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		gasThreshold:   gasThreshold,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Keep the voting trees of the node's pending protocol DAO proposals and answer challenges to them.
+// A proposer that doesn't answer a challenge in time loses its proposal bond, so answers are sent even if the network
+// fee is over the auto transaction threshold.
+func (t *submitVotingTrees) run(state *state.NetworkState) error {
+
+	// Check if the protocol DAO has been deployed yet
+	proposalAddress, err := t.rp.GetAddress("rocketDAOProtocolProposal", nil)
+	if err != nil {
+		return err
+	}
+	if *proposalAddress == (common.Address{}) {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for challenges to the node's protocol DAO proposals...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the node's pending proposals; challenges can only be made before voting starts
+	proposalIds, err := t.getPendingProposals(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+
+	// Defend each one
+	for _, proposalId := range proposalIds {
+		if err := t.defendProposal(proposalId); err != nil {
+			t.log.Println(fmt.Errorf("Could not defend protocol DAO proposal %d: %w", proposalId, err))
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the IDs of the pending proposals the node made
+func (t *submitVotingTrees) getPendingProposals(nodeAddress common.Address) ([]uint64, error) {
+
+	// Get proposal count
+	proposalCount, err := pdao.GetProposalCount(t.rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the proposer and state of each proposal
+	proposers := make([]common.Address, proposalCount)
+	states := make([]pdao.ProposalState, proposalCount)
+	for bsi := uint64(0); bsi < proposalCount; bsi += pdao.ProposalDetailsBatchSize {
+
+		// Get batch start & end index
+		psi := bsi
+		pei := bsi + pdao.ProposalDetailsBatchSize
+		if pei > proposalCount {
+			pei = proposalCount
+		}
+
+		// Load details
+		var wg errgroup.Group
+		for pi := psi; pi < pei; pi++ {
+			pi := pi
+			wg.Go(func() error {
+				var err error
+				proposers[pi], err = pdao.GetProposalProposer(t.rp, pi+1, nil) // Proposals are 1-indexed
+				return err
+			})
+			wg.Go(func() error {
+				var err error
+				states[pi], err = pdao.GetProposalState(t.rp, pi+1, nil)
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+
+	}
+
+	// Filter the pending proposals the node made
+	proposalIds := []uint64{}
+	for i := range proposers {
+		if proposers[i] == nodeAddress && states[i] == pdao.ProposalState_Pending {
+			proposalIds = append(proposalIds, uint64(i+1))
+		}
+	}
+	return proposalIds, nil
+
+}
+
+// Answer any open challenges to a proposal's voting tree
+func (t *submitVotingTrees) defendProposal(proposalId uint64) error {
+
+	// Get the tree, generating it if it hasn't been saved yet
+	votingTree, blockNumber, err := t.getVotingTree(proposalId)
+	if err != nil {
+		return err
+	}
+
+	// Get the challenged indices
+	eventLogInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return err
+	}
+	indices, err := pdao.GetChallengedIndices(t.rp, proposalId, big.NewInt(int64(blockNumber)), big.NewInt(int64(eventLogInterval)))
+	if err != nil {
+		return err
+	}
+
+	// Answer the ones that are still open
+	for _, index := range indices {
+		challengeState, err := pdao.GetChallengeState(t.rp, proposalId, index, nil)
+		if err != nil {
+			return err
+		}
+		if challengeState != pdao.ChallengeState_Challenged {
+			continue
+		}
+		if err := t.submitRoot(proposalId, index, votingTree); err != nil {
+			return err
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the voting tree of a proposal from its saved file if it's valid, or generate, save, and upload it otherwise
+func (t *submitVotingTrees) getVotingTree(proposalId uint64) (*pdao.VotingTree, uint32, error) {
+
+	// Get the proposal's block
+	blockNumber, err := pdao.GetProposalBlock(t.rp, proposalId, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Check if we can reuse an existing file for this proposal
+	votingTreePath := t.cfg.Smartnode.GetVotingTreePath(proposalId, true)
+	if votingTree := t.getExistingVotingTree(votingTreePath, proposalId, blockNumber); votingTree != nil {
+		return votingTree, blockNumber, nil
+	}
+
+	// Generate the tree
+	t.log.Printlnf("Generating the voting tree for proposal %d at block %d...", proposalId, blockNumber)
+	nodeAddresses, votingPowers, err := pdao.GetNodeVotingPowers(t.rp, blockNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+	depthPerRound, err := pdao.GetDepthPerRound(t.rp, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	votingTree := pdao.NewVotingTree(votingPowers, depthPerRound)
+	file := pdao.VotingTreeFile{
+		Network:       string(t.cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		ProposalID:    proposalId,
+		BlockNumber:   blockNumber,
+		DepthPerRound: depthPerRound,
+		Root:          votingTree.Root(),
+		NodeAddresses: nodeAddresses,
+		VotingPowers:  votingPowers,
+	}
+
+	// Save it
+	fileBytes, err := json.Marshal(file)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Error serializing voting tree: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(votingTreePath), 0755); err != nil {
+		return nil, 0, fmt.Errorf("Error creating voting tree folder: %w", err)
+	}
+	if err := os.WriteFile(votingTreePath, fileBytes, 0644); err != nil {
+		return nil, 0, fmt.Errorf("Error saving voting tree to %s: %w", votingTreePath, err)
+	}
+	t.log.Printlnf("Saved the voting tree for proposal %d to %s.", proposalId, votingTreePath)
+
+	// Upload it so the tree can be checked without regenerating it; challenges are answered from the saved file either way
+	cid, err := storage.UploadFile(t.cfg, fileBytes, votingTreePath+config.RewardsTreeIpfsExtension, "voting tree", t.printMessage)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't upload the voting tree for proposal %d: %s", proposalId, err.Error())
+	} else {
+		t.log.Printlnf("Uploaded the voting tree for proposal %d with CID %s.", proposalId, cid)
+	}
+
+	return votingTree, blockNumber, nil
+
+}
+
+// Load the saved voting tree of a proposal, if it exists and is for the proposal's block
+func (t *submitVotingTrees) getExistingVotingTree(votingTreePath string, proposalId uint64, blockNumber uint32) *pdao.VotingTree {
+
+	_, err := os.Stat(votingTreePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	// The file already exists, attempt to read it
+	fileBytes, err := os.ReadFile(votingTreePath)
+	if err != nil {
+		t.log.Printlnf("WARNING: failed to read %s: %s\nRegenerating file...\n", votingTreePath, err.Error())
+		return nil
+	}
+	var file pdao.VotingTreeFile
+	if err := json.Unmarshal(fileBytes, &file); err != nil {
+		t.log.Printlnf("WARNING: failed to deserialize %s: %s\nRegenerating file...\n", votingTreePath, err.Error())
+		return nil
+	}
+
+	// Make sure it's for this proposal's block
+	if file.ProposalID != proposalId || file.BlockNumber != blockNumber {
+		t.log.Printlnf("Existing voting tree file for proposal %d was for block %d but the proposal is for block %d, regenerating file...\n", proposalId, file.BlockNumber, blockNumber)
+		return nil
+	}
+
+	// Rebuild the tree
+	votingTree, err := file.GetTree()
+	if err != nil {
+		t.log.Printlnf("WARNING: %s\nRegenerating file...\n", err.Error())
+		return nil
+	}
+	return votingTree
+
+}
+
+// Print a message from the storage uploader
+func (t *submitVotingTrees) printMessage(message string) {
+	t.log.Println(message)
+}
+
+// Answer a challenge to a node of a proposal's voting tree with the pollard below it
+func (t *submitVotingTrees) submitRoot(proposalId uint64, index uint64, votingTree *pdao.VotingTree) error {
+
+	// Log
+	t.log.Printlnf("Answering the challenge to index %d of proposal %d...", index, proposalId)
+
+	// Get the pollard
+	pollard, err := votingTree.GetPollard(index)
+	if err != nil {
+		return err
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := pdao.EstimateSubmitRootGas(t.rp, proposalId, index, pollard, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to answer the challenge: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Print the gas info; the answer is sent regardless of the threshold
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, t.log, maxFee, t.gasLimit) {
+		t.log.Println("NOTICE: Challenges must be answered before they time out, so this will be sent at the current gas price.")
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Submit the pollard
+	hash, err := pdao.SubmitRoot(t.rp, proposalId, index, pollard, opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully answered the challenge to index %d of proposal %d.", index, proposalId)
+
+	// Return
+	return nil
+
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool/api"
+	"github.com/rocket-pool/smartnode/rocketpool/health"
 	"github.com/rocket-pool/smartnode/rocketpool/node"
+	"github.com/rocket-pool/smartnode/rocketpool/treeworker"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower"
 	"github.com/rocket-pool/smartnode/shared"
+	rptreeworker "github.com/rocket-pool/smartnode/shared/services/treeworker"
 	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
 )
 
@@ -120,28 +123,40 @@ func main() {
 			Name:  "force-fallbacks",
 			Usage: "Set this to true if you know the primary EC or CC is offline and want to bypass its health checks, and just use the fallback EC and CC instead",
 		},
+		cli.BoolFlag{
+			Name:  "no-cache",
+			Usage: "Set this to true to read everything for this command from the chain, instead of using the network state cached by the node daemon",
+		},
 		cli.BoolFlag{
 			Name:  "use-protected-api",
 			Usage: "Set this to true to use the Flashbots Protect RPC instead of your local Execution Client. Useful to ensure your transactions aren't front-run.",
 		},
+		cli.StringSliceFlag{
+			Name:  "set",
+			Usage: "Override a config parameter for this process in the form section.parameter=value (e.g. smartnode.manualMaxFee=20); takes precedence over the settings file and RP_<SECTION>_<PARAMETER> environment variables",
+		},
 	}
 
 	// Register commands
 	api.RegisterCommands(app, "api", []string{"a"})
 	node.RegisterCommands(app, "node", []string{"n"})
 	watchtower.RegisterCommands(app, "watchtower", []string{"w"})
+	health.RegisterCommands(app, "health", []string{"hc"})
+	treeworker.RegisterCommands(app, rptreeworker.WorkerCommand, []string{})
 
-	// Get command being run
+	// Get command being run; API commands run in-process (possibly concurrently) rerun the app, so only the first run sets it
 	var commandName string
 	app.Before = func(c *cli.Context) error {
-		commandName = c.Args().First()
+		if commandName == "" {
+			commandName = c.Args().First()
+		}
 		return nil
 	}
 
 	// Run application
 	if err := app.Run(os.Args); err != nil {
 		if commandName == "api" {
-			apiutils.PrintErrorResponse(err)
+			apiutils.NewResponsePrinter(app.Writer).PrintErrorResponse(err)
 		} else {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
@@ -0,0 +1,73 @@
+package treeworker
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	rptreeworker "github.com/rocket-pool/smartnode/shared/services/treeworker"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register tree worker command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Generate a rewards tree for the watchtower in a separate process; this is started by the watchtower and isn't meant to be run manually",
+		UsageText: "rocketpool tree-worker [options] interval|snapshot",
+		Hidden:    true,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "output",
+				Usage: "The folder containing the watchtower's socket, where the generated files will be saved",
+			},
+			cli.StringFlag{
+				Name:  "log-prefix",
+				Usage: "The prefix for the generation's log messages",
+			},
+			cli.Uint64Flag{
+				Name:  "index",
+				Usage: "The rewards interval to generate the tree for",
+			},
+			cli.Int64Flag{
+				Name:  "start-time",
+				Usage: "The start time of the interval as a Unix timestamp, in snapshot mode",
+			},
+			cli.Int64Flag{
+				Name:  "end-time",
+				Usage: "The end time of the interval as a Unix timestamp, in snapshot mode",
+			},
+			cli.Uint64Flag{
+				Name:  "consensus-block",
+				Usage: "The Beacon slot of the snapshot, in snapshot mode",
+			},
+			cli.Uint64Flag{
+				Name:  "execution-block",
+				Usage: "The EL block of the snapshot, in snapshot mode",
+			},
+			cli.Uint64Flag{
+				Name:  "intervals-passed",
+				Usage: "The number of intervals that have passed since the last submission, in snapshot mode",
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 1); err != nil {
+				return err
+			}
+			mode := c.Args().Get(0)
+			if mode != rptreeworker.Mode_Interval && mode != rptreeworker.Mode_Snapshot {
+				return fmt.Errorf("Invalid mode '%s' - valid options are '%s' and '%s'", mode, rptreeworker.Mode_Interval, rptreeworker.Mode_Snapshot)
+			}
+			if c.String("output") == "" {
+				return fmt.Errorf("The output folder must be set with --output")
+			}
+
+			// Run
+			return rptreeworker.RunWorker(c, mode)
+
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package watchtower
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Append a record to one of the watchtower's audit logs, one JSON record per line
+func appendAuditRecord(path string, record interface{}) error {
+
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error serializing audit record: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating watchtower directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening audit log %s: %w", path, err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing to audit log %s: %w", path, err)
+	}
+	return nil
+
+}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -35,10 +36,11 @@ type cancelBondReductions struct {
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
+	nonces           *nonceManager
 }
 
 // Create cancel bond reductions task
-func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*cancelBondReductions, error) {
+func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, nonces *nonceManager) (*cancelBondReductions, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -71,6 +73,7 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 		lock:             lock,
 		isRunning:        false,
 		generationPrefix: "[Bond Reduction]",
+		nonces:           nonces,
 	}, nil
 
 }
@@ -104,7 +107,9 @@ func (t *cancelBondReductions) run(state *state.NetworkState, isAtlasDeployed bo
 	t.lock.Unlock()
 
 	// Run the check
+	backgroundTasks.Add(1)
 	go func() {
+		defer backgroundTasks.Done()
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -204,7 +209,7 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 	}
 
 	// Print the gas info
-	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -215,13 +220,15 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 	opts.GasLimit = gasInfo.SafeGasLimit
 
 	// Cancel the reduction
-	hash, err := minipool.VoteCancelReduction(t.rp, address, opts)
+	hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return minipool.VoteCancelReduction(t.rp, address, opts)
+	})
 	if err != nil {
 		return err
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	_, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 	if err != nil {
 		return err
 	}
@@ -40,10 +40,11 @@ type checkSoloMigrations struct {
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
+	nonces           *nonceManager
 }
 
 // Create check solo migrations task
-func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*checkSoloMigrations, error) {
+func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, nonces *nonceManager) (*checkSoloMigrations, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -81,6 +82,7 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 		lock:             lock,
 		isRunning:        false,
 		generationPrefix: "[Solo Migration]",
+		nonces:           nonces,
 	}, nil
 
 }
@@ -114,7 +116,9 @@ func (t *checkSoloMigrations) run(state *state.NetworkState, isAtlasDeployed boo
 	t.lock.Unlock()
 
 	// Run the check
+	backgroundTasks.Add(1)
 	go func() {
+		defer backgroundTasks.Done()
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -245,7 +249,7 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 	}
 
 	// Print the gas info
-	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -256,13 +260,13 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 	opts.GasLimit = gasInfo.SafeGasLimit
 
 	// Cancel the reduction
-	hash, err := mp.VoteScrub(opts)
+	hash, err := t.nonces.send(opts, mp.VoteScrub)
 	if err != nil {
 		return err
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	_, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 	if err != nil {
 		return err
 	}
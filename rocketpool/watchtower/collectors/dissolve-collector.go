@@ -0,0 +1,104 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the timed-out minipool dissolution metrics
+type DissolveCollector struct {
+
+	// The number of prelaunch minipools that had timed out during the latest check
+	timedOutMinipoolsDesc *prometheus.Desc
+
+	// The total number of minipools that were successfully dissolved
+	dissolvedMinipoolsDesc *prometheus.Desc
+
+	// The total number of dissolutions that failed to be submitted or reverted
+	failedDissolvesDesc *prometheus.Desc
+
+	// The number of dissolutions that were deferred during the latest check because of the gas ceiling
+	deferredDissolvesDesc *prometheus.Desc
+
+	// The total number of dissolution batches that were submitted
+	batchesDesc *prometheus.Desc
+
+	// The time of the latest block that the check was run against
+	latestBlockTimeDesc *prometheus.Desc
+
+	// Counters
+	TimedOutMinipools  float64
+	DissolvedMinipools float64
+	FailedDissolves    float64
+	DeferredDissolves  float64
+	Batches            float64
+	LatestBlockTime    float64
+
+	// Mutex
+	UpdateLock sync.Mutex
+}
+
+// Create a new DissolveCollector instance
+func NewDissolveCollector() *DissolveCollector {
+	subsystem := "dissolve"
+	return &DissolveCollector{
+		timedOutMinipoolsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "timed_out_minipools"),
+			"The number of prelaunch minipools that had timed out during the latest check",
+			nil, nil,
+		),
+		dissolvedMinipoolsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "dissolved_minipools"),
+			"The total number of minipools that were successfully dissolved",
+			nil, nil,
+		),
+		failedDissolvesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failed_dissolves"),
+			"The total number of dissolutions that failed to be submitted or reverted",
+			nil, nil,
+		),
+		deferredDissolvesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deferred_dissolves"),
+			"The number of dissolutions that were deferred during the latest check because of the gas ceiling",
+			nil, nil,
+		),
+		batchesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "batches"),
+			"The total number of dissolution batches that were submitted",
+			nil, nil,
+		),
+		latestBlockTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latest_block_time"),
+			"The time of the latest block that the check was run against",
+			nil, nil,
+		),
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *DissolveCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.timedOutMinipoolsDesc
+	channel <- collector.dissolvedMinipoolsDesc
+	channel <- collector.failedDissolvesDesc
+	channel <- collector.deferredDissolvesDesc
+	channel <- collector.batchesDesc
+	channel <- collector.latestBlockTimeDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *DissolveCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.timedOutMinipoolsDesc, prometheus.GaugeValue, collector.TimedOutMinipools)
+	channel <- prometheus.MustNewConstMetric(
+		collector.dissolvedMinipoolsDesc, prometheus.CounterValue, collector.DissolvedMinipools)
+	channel <- prometheus.MustNewConstMetric(
+		collector.failedDissolvesDesc, prometheus.CounterValue, collector.FailedDissolves)
+	channel <- prometheus.MustNewConstMetric(
+		collector.deferredDissolvesDesc, prometheus.GaugeValue, collector.DeferredDissolves)
+	channel <- prometheus.MustNewConstMetric(
+		collector.batchesDesc, prometheus.CounterValue, collector.Batches)
+	channel <- prometheus.MustNewConstMetric(
+		collector.latestBlockTimeDesc, prometheus.GaugeValue, collector.LatestBlockTime)
+
+}
@@ -0,0 +1,157 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The health of a single watchtower duty's submissions
+type dutyMetrics struct {
+	lastSubmissionTime  time.Time
+	consecutiveFailures float64
+	gasUsed             float64
+	gasCost             float64
+	consensusMatch      *bool
+}
+
+// Represents the collector for the watchtower duty submission metrics
+type DutyCollector struct {
+
+	// The time of the duty's last successful submission
+	lastSubmissionTimeDesc *prometheus.Desc
+
+	// The number of times in a row the duty has failed
+	consecutiveFailuresDesc *prometheus.Desc
+
+	// The amount of gas used by the duty's last submission
+	gasUsedDesc *prometheus.Desc
+
+	// The cost of the duty's last submission, in ETH
+	gasCostDesc *prometheus.Desc
+
+	// Whether the duty's last submission matched the value the Oracle DAO reached consensus on
+	consensusMatchDesc *prometheus.Desc
+
+	// The metrics for each duty
+	duties map[string]*dutyMetrics
+
+	// Mutex
+	lock sync.Mutex
+}
+
+// Create a new DutyCollector instance for the provided duties
+func NewDutyCollector(dutyNames []string) *DutyCollector {
+	subsystem := "duty"
+	duties := map[string]*dutyMetrics{}
+	for _, name := range dutyNames {
+		duties[name] = &dutyMetrics{}
+	}
+	return &DutyCollector{
+		lastSubmissionTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_submission_time"),
+			"The time of the duty's last successful submission",
+			[]string{"duty"}, nil,
+		),
+		consecutiveFailuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "consecutive_failures"),
+			"The number of times in a row the duty has failed",
+			[]string{"duty"}, nil,
+		),
+		gasUsedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_submission_gas_used"),
+			"The amount of gas used by the duty's last submission",
+			[]string{"duty"}, nil,
+		),
+		gasCostDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_submission_cost_eth"),
+			"The cost of the duty's last submission, in ETH",
+			[]string{"duty"}, nil,
+		),
+		consensusMatchDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "consensus_match"),
+			"Whether the duty's last submission matched the value the Oracle DAO reached consensus on (1 if it did, 0 if it didn't)",
+			[]string{"duty"}, nil,
+		),
+		duties: duties,
+	}
+}
+
+// Record a failed run or submission of a duty
+func (collector *DutyCollector) RecordFailure(duty string) {
+	collector.update(duty, func(metrics *dutyMetrics) {
+		metrics.consecutiveFailures++
+	})
+}
+
+// Record a successful run or submission of a duty
+func (collector *DutyCollector) RecordSuccess(duty string) {
+	collector.update(duty, func(metrics *dutyMetrics) {
+		metrics.consecutiveFailures = 0
+	})
+}
+
+// Record a transaction submitted for a duty
+func (collector *DutyCollector) RecordSubmission(duty string, submissionTime time.Time, gasUsed uint64, gasCost float64) {
+	collector.update(duty, func(metrics *dutyMetrics) {
+		metrics.lastSubmissionTime = submissionTime
+		metrics.gasUsed = float64(gasUsed)
+		metrics.gasCost = gasCost
+	})
+}
+
+// Record whether a duty's submission matched the Oracle DAO's consensus
+func (collector *DutyCollector) RecordConsensus(duty string, match bool) {
+	collector.update(duty, func(metrics *dutyMetrics) {
+		metrics.consensusMatch = &match
+	})
+}
+
+// Apply an update to a duty's metrics
+func (collector *DutyCollector) update(duty string, updater func(metrics *dutyMetrics)) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	metrics, exists := collector.duties[duty]
+	if !exists {
+		metrics = &dutyMetrics{}
+		collector.duties[duty] = metrics
+	}
+	updater(metrics)
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *DutyCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.lastSubmissionTimeDesc
+	channel <- collector.consecutiveFailuresDesc
+	channel <- collector.gasUsedDesc
+	channel <- collector.gasCostDesc
+	channel <- collector.consensusMatchDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *DutyCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	// Update all of the metrics
+	for duty, metrics := range collector.duties {
+		if !metrics.lastSubmissionTime.IsZero() {
+			channel <- prometheus.MustNewConstMetric(
+				collector.lastSubmissionTimeDesc, prometheus.GaugeValue, float64(metrics.lastSubmissionTime.Unix()), duty)
+			channel <- prometheus.MustNewConstMetric(
+				collector.gasUsedDesc, prometheus.GaugeValue, metrics.gasUsed, duty)
+			channel <- prometheus.MustNewConstMetric(
+				collector.gasCostDesc, prometheus.GaugeValue, metrics.gasCost, duty)
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.consecutiveFailuresDesc, prometheus.GaugeValue, metrics.consecutiveFailures, duty)
+		if metrics.consensusMatch != nil {
+			match := float64(0)
+			if *metrics.consensusMatch {
+				match = 1
+			}
+			channel <- prometheus.MustNewConstMetric(
+				collector.consensusMatchDesc, prometheus.GaugeValue, match, duty)
+		}
+	}
+
+}
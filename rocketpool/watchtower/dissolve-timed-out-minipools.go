@@ -6,12 +6,15 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
@@ -25,16 +28,19 @@ const MinipoolStatusBatchSize = 20
 
 // Dissolve timed out minipools task
 type dissolveTimedOutMinipools struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	ec  rocketpool.ExecutionClient
-	rp  *rocketpool.RocketPool
+	c          *cli.Context
+	log        log.ColorLogger
+	cfg        *config.RocketPoolConfig
+	w          *wallet.Wallet
+	ec         rocketpool.ExecutionClient
+	rp         *rocketpool.RocketPool
+	coll       *collectors.DissolveCollector
+	dutyStatus *dutyStatusTracker
+	nonces     *nonceManager
 }
 
 // Create dissolve timed out minipools task
-func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*dissolveTimedOutMinipools, error) {
+func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger, coll *collectors.DissolveCollector, dutyStatus *dutyStatusTracker, nonces *nonceManager) (*dissolveTimedOutMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -56,12 +62,15 @@ func newDissolveTimedOutMinipools(c *cli.Context, logger log.ColorLogger) (*diss
 
 	// Return task
 	return &dissolveTimedOutMinipools{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		ec:  ec,
-		rp:  rp,
+		c:          c,
+		log:        logger,
+		cfg:        cfg,
+		w:          w,
+		ec:         ec,
+		rp:         rp,
+		coll:       coll,
+		dutyStatus: dutyStatus,
+		nonces:     nonces,
 	}, nil
 
 }
@@ -77,10 +86,15 @@ func (t *dissolveTimedOutMinipools) run(state *state.NetworkState, isAtlasDeploy
 	t.log.Println("Checking for timed out minipools to dissolve...")
 
 	// Get timed out minipools
-	minipools, err := t.getTimedOutMinipools(state)
+	minipools, blockTime, err := t.getTimedOutMinipools(state)
 	if err != nil {
 		return err
 	}
+	t.coll.UpdateLock.Lock()
+	t.coll.TimedOutMinipools = float64(len(minipools))
+	t.coll.DeferredDissolves = 0
+	t.coll.LatestBlockTime = float64(blockTime.Unix())
+	t.coll.UpdateLock.Unlock()
 	if len(minipools) == 0 {
 		return nil
 	}
@@ -88,10 +102,15 @@ func (t *dissolveTimedOutMinipools) run(state *state.NetworkState, isAtlasDeploy
 	// Log
 	t.log.Printlnf("%d minipool(s) have timed out and will be dissolved...", len(minipools))
 
-	// Dissolve minipools
-	for _, mp := range minipools {
-		if err := t.dissolveMinipool(mp); err != nil {
-			t.log.Println(fmt.Errorf("Could not dissolve minipool %s: %w", mp.GetAddress().Hex(), err))
+	// Dissolve minipools in batches
+	for len(minipools) > 0 {
+		var submitted int
+		submitted, minipools, err = t.dissolveBatch(minipools)
+		if err != nil {
+			return err
+		}
+		if submitted == 0 {
+			break
 		}
 	}
 
@@ -101,7 +120,7 @@ func (t *dissolveTimedOutMinipools) run(state *state.NetworkState, isAtlasDeploy
 }
 
 // Get timed out minipools
-func (t *dissolveTimedOutMinipools) getTimedOutMinipools(state *state.NetworkState) ([]minipool.Minipool, error) {
+func (t *dissolveTimedOutMinipools) getTimedOutMinipools(state *state.NetworkState) ([]minipool.Minipool, time.Time, error) {
 
 	opts := &bind.CallOpts{
 		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
@@ -120,62 +139,121 @@ func (t *dissolveTimedOutMinipools) getTimedOutMinipools(state *state.NetworkSta
 		if mpd.Status == rptypes.Prelaunch && blockTime.Sub(statusTime) >= launchTimeout {
 			mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, opts)
 			if err != nil {
-				return nil, fmt.Errorf("error creating binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+				return nil, time.Time{}, fmt.Errorf("error creating binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
 			}
 			timedOutMinipools = append(timedOutMinipools, mp)
 		}
 	}
 
 	// Return
-	return timedOutMinipools, nil
+	return timedOutMinipools, blockTime, nil
 
 }
 
-// Dissolve a minipool
-func (t *dissolveTimedOutMinipools) dissolveMinipool(mp minipool.Minipool) error {
-
-	// Log
-	t.log.Printlnf("Dissolving minipool %s...", mp.GetAddress().Hex())
+// Submit dissolutions for as many minipools as the batch size and gas ceiling allow without waiting for each one to be
+// included in a block, then wait for all of them. Returns the number of dissolutions submitted and the minipools left over.
+func (t *dissolveTimedOutMinipools) dissolveBatch(minipools []minipool.Minipool) (int, []minipool.Minipool, error) {
 
-	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
-	if err != nil {
-		return err
+	batchSize := int(t.cfg.Smartnode.WatchtowerDissolveBatchSize.Value.(uint64))
+	if batchSize < 1 {
+		batchSize = 1
 	}
+	gasCeiling := t.cfg.Smartnode.WatchtowerDissolveGasCeiling.Value.(uint64)
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
+	prioFee := eth.GweiToWei(getWatchtowerPrioFee(t.cfg))
+
+	// Submit the batch
+	hashes := map[common.Address]common.Hash{}
+	remaining := []minipool.Minipool{}
+	var batchGas uint64
+	var failed, deferred float64
+	for i, mp := range minipools {
+		if len(hashes) >= batchSize {
+			remaining = minipools[i:]
+			break
+		}
 
-	// Get the gas limit
-	gasInfo, err := mp.EstimateDissolveGas(opts)
-	if err != nil {
-		return fmt.Errorf("Could not estimate the gas required to dissolve the minipool: %w", err)
-	}
+		// Get the gas limit
+		opts, err := t.w.GetNodeAccountTransactor()
+		if err != nil {
+			return 0, nil, err
+		}
+		gasInfo, err := mp.EstimateDissolveGas(opts)
+		if err != nil {
+			t.log.Println(fmt.Errorf("Could not estimate the gas required to dissolve minipool %s: %w", mp.GetAddress().Hex(), err))
+			failed++
+			continue
+		}
 
-	// Print the gas info
-	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
-	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-		return nil
-	}
+		// Check the gas ceiling
+		if gasCeiling > 0 {
+			if gasInfo.SafeGasLimit > gasCeiling {
+				t.log.Printlnf("Dissolving minipool %s requires up to %d gas, which is more than the gas ceiling of %d; skipping it.", mp.GetAddress().Hex(), gasInfo.SafeGasLimit, gasCeiling)
+				deferred++
+				continue
+			}
+			if batchGas+gasInfo.SafeGasLimit > gasCeiling {
+				remaining = minipools[i:]
+				break
+			}
+		}
 
-	// Set the gas settings
-	opts.GasFeeCap = maxFee
-	opts.GasTipCap = eth.GweiToWei(getWatchtowerPrioFee(t.cfg))
-	opts.GasLimit = gasInfo.SafeGasLimit
+		// Print the gas info
+		t.log.Printlnf("Dissolving minipool %s...", mp.GetAddress().Hex())
+		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
+			deferred++
+			continue
+		}
 
-	// Dissolve
-	hash, err := mp.Dissolve(opts)
-	if err != nil {
-		return err
+		// Set the gas settings
+		opts.GasFeeCap = maxFee
+		opts.GasTipCap = prioFee
+		opts.GasLimit = gasInfo.SafeGasLimit
+
+		// Dissolve
+		hash, err := t.nonces.send(opts, mp.Dissolve)
+		if err != nil {
+			t.log.Println(fmt.Errorf("Could not dissolve minipool %s: %w", mp.GetAddress().Hex(), err))
+			failed++
+			continue
+		}
+		t.log.Printlnf("Transaction has been submitted with hash %s.", hash.Hex())
+		hashes[mp.GetAddress()] = hash
+		batchGas += gasInfo.SafeGasLimit
 	}
 
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
-	if err != nil {
-		return err
+	// Wait for the batch to be included in a block
+	var dissolved float64
+	if len(hashes) > 0 {
+		t.log.Printlnf("Waiting for %d dissolution(s) to be validated...", len(hashes))
+	}
+	for address, hash := range hashes {
+		receipt, err := t.nonces.waitForTransaction(hash, t.log)
+		if err != nil {
+			t.log.Println(fmt.Errorf("Error waiting for the dissolution of minipool %s: %w", address.Hex(), err))
+			failed++
+			continue
+		}
+		if receipt.Status == types.ReceiptStatusFailed {
+			t.log.Printlnf("Dissolving minipool %s failed; transaction %s reverted.", address.Hex(), hash.Hex())
+			failed++
+			continue
+		}
+		t.log.Printlnf("Successfully dissolved minipool %s.", address.Hex())
+		t.dutyStatus.recordTransaction(dutyName_DissolveTimedOutMinipools, t.ec, receipt.TxHash)
+		dissolved++
 	}
 
-	// Log
-	t.log.Printlnf("Successfully dissolved minipool %s.", mp.GetAddress().Hex())
+	// Update the metrics
+	t.coll.UpdateLock.Lock()
+	t.coll.DissolvedMinipools += dissolved
+	t.coll.FailedDissolves += failed
+	t.coll.DeferredDissolves += deferred
+	if len(hashes) > 0 {
+		t.coll.Batches++
+	}
+	t.coll.UpdateLock.Unlock()
 
-	// Return
-	return nil
+	return len(hashes), remaining, nil
 
 }
@@ -0,0 +1,217 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Names of the duties that are tracked
+const (
+	dutyName_SubmitRplPrice            string = "submit-rpl-price"
+	dutyName_SubmitNetworkBalances     string = "submit-network-balances"
+	dutyName_SubmitRewardsTree         string = "submit-rewards-tree"
+	dutyName_SubmitScrubMinipools      string = "submit-scrub-minipools"
+	dutyName_DissolveTimedOutMinipools string = "dissolve-timed-out-minipools"
+)
+
+// A value a duty submitted that the Oracle DAO hasn't reached consensus on yet
+type pendingConsensus struct {
+	block uint64
+	value string
+}
+
+// Records the results of each watchtower duty and saves them to disk so the API can report them
+type dutyStatusTracker struct {
+	path    string
+	errLog  log.ColorLogger
+	duties  []*api.WatchtowerDutyStatus
+	pending map[string]pendingConsensus
+	coll    *collectors.DutyCollector
+	lock    *sync.Mutex
+}
+
+// Create a new duty status tracker
+func newDutyStatusTracker(cfg *config.RocketPoolConfig, errorLogger log.ColorLogger) *dutyStatusTracker {
+	names := []string{
+		dutyName_SubmitRplPrice,
+		dutyName_SubmitNetworkBalances,
+		dutyName_SubmitRewardsTree,
+		dutyName_SubmitScrubMinipools,
+		dutyName_DissolveTimedOutMinipools,
+	}
+	duties := make([]*api.WatchtowerDutyStatus, len(names))
+	for i, name := range names {
+		duties[i] = &api.WatchtowerDutyStatus{
+			Name: name,
+		}
+	}
+
+	return &dutyStatusTracker{
+		path:    cfg.Smartnode.GetWatchtowerDutyStatusPath(true),
+		errLog:  errorLogger,
+		duties:  duties,
+		pending: map[string]pendingConsensus{},
+		coll:    collectors.NewDutyCollector(names),
+		lock:    &sync.Mutex{},
+	}
+}
+
+// Record the result of a duty's run in the task loop
+func (t *dutyStatusTracker) recordRun(name string, err error, submissionPending bool) {
+	if err != nil {
+		t.coll.RecordFailure(name)
+	} else if !submissionPending {
+		t.coll.RecordSuccess(name)
+	}
+	t.update(name, func(duty *api.WatchtowerDutyStatus, now time.Time) {
+		duty.LastRunTime = now
+		duty.SubmissionPending = submissionPending
+		if err != nil {
+			duty.LastError = err.Error()
+			duty.LastErrorTime = now
+		} else if !submissionPending {
+			duty.LastSuccessTime = now
+		}
+	})
+}
+
+// Record the successful completion of a duty's background submission
+func (t *dutyStatusTracker) recordSubmissionSuccess(name string) {
+	t.coll.RecordSuccess(name)
+	t.update(name, func(duty *api.WatchtowerDutyStatus, now time.Time) {
+		duty.LastSuccessTime = now
+		duty.SubmissionPending = false
+	})
+}
+
+// Record the failure of a duty's background submission
+func (t *dutyStatusTracker) recordSubmissionError(name string, err error) {
+	t.coll.RecordFailure(name)
+	t.update(name, func(duty *api.WatchtowerDutyStatus, now time.Time) {
+		duty.LastError = err.Error()
+		duty.LastErrorTime = now
+		duty.SubmissionPending = false
+	})
+}
+
+// Record the gas spent by a transaction a duty submitted, once it has been included in a block
+func (t *dutyStatusTracker) recordTransaction(name string, ec rocketpool.ExecutionClient, hash common.Hash) {
+	receipt, err := ec.TransactionReceipt(context.Background(), hash)
+	if err != nil {
+		t.errLog.Println(fmt.Errorf("error getting the receipt of %s transaction %s: %w", name, hash.Hex(), err))
+		return
+	}
+	tx, _, err := ec.TransactionByHash(context.Background(), hash)
+	if err != nil {
+		t.errLog.Println(fmt.Errorf("error getting %s transaction %s: %w", name, hash.Hex(), err))
+		return
+	}
+	header, err := ec.HeaderByNumber(context.Background(), receipt.BlockNumber)
+	if err != nil {
+		t.errLog.Println(fmt.Errorf("error getting block %s for %s transaction %s: %w", receipt.BlockNumber.String(), name, hash.Hex(), err))
+		return
+	}
+
+	// The price paid per gas is the base fee plus the tip the transaction could afford on top of it
+	gasPrice := tx.GasPrice()
+	if header.BaseFee != nil {
+		gasPrice = big.NewInt(0).Add(header.BaseFee, tx.EffectiveGasTipValue(header.BaseFee))
+	}
+	gasCost := big.NewInt(0).Mul(gasPrice, big.NewInt(0).SetUint64(receipt.GasUsed))
+	t.coll.RecordSubmission(name, time.Now(), receipt.GasUsed, eth.WeiToEth(gasCost))
+}
+
+// Record the value a duty submitted for a block (or interval), so it can be compared with the Oracle DAO's consensus once it's reached
+func (t *dutyStatusTracker) recordSubmittedValue(name string, block uint64, value string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.pending[name] = pendingConsensus{
+		block: block,
+		value: value,
+	}
+}
+
+// Get the block (or interval) of the value a duty submitted that hasn't been compared with the Oracle DAO's consensus yet
+func (t *dutyStatusTracker) getPendingConsensusBlock(name string) (uint64, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	pending, exists := t.pending[name]
+	return pending.block, exists
+}
+
+// Compare the value a duty submitted with the value the Oracle DAO reached consensus on for a block (or interval).
+// Submissions for earlier blocks that never reached consensus are dropped without being recorded.
+func (t *dutyStatusTracker) checkConsensus(name string, block uint64, value string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	pending, exists := t.pending[name]
+	if !exists || block < pending.block {
+		return
+	}
+	if block == pending.block {
+		match := (value == pending.value)
+		t.coll.RecordConsensus(name, match)
+		if !match {
+			t.errLog.Printlnf("WARNING: the Oracle DAO reached consensus on %s for %s at %d, but this node submitted %s.", value, name, block, pending.value)
+		}
+	}
+	delete(t.pending, name)
+}
+
+// Apply an update to a duty and save the status file
+func (t *dutyStatusTracker) update(name string, updater func(duty *api.WatchtowerDutyStatus, now time.Time)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	for _, duty := range t.duties {
+		if duty.Name == name {
+			updater(duty, now)
+			break
+		}
+	}
+
+	if err := t.save(now); err != nil {
+		t.errLog.Println(fmt.Errorf("error saving watchtower duty status: %w", err))
+	}
+}
+
+// Save the status of all duties to disk
+func (t *dutyStatusTracker) save(now time.Time) error {
+	statusFile := api.WatchtowerDutyStatusFile{
+		UpdatedTime: now,
+		Duties:      make([]api.WatchtowerDutyStatus, len(t.duties)),
+	}
+	for i, duty := range t.duties {
+		statusFile.Duties[i] = *duty
+	}
+
+	bytes, err := json.Marshal(statusFile)
+	if err != nil {
+		return fmt.Errorf("error serializing duty status: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(t.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating watchtower folder: %w", err)
+	}
+	err = os.WriteFile(t.path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", t.path, err)
+	}
+	return nil
+}
@@ -1,19 +1,46 @@
 package watchtower
 
-import "github.com/rocket-pool/smartnode/shared/services/config"
+import (
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
 
 const (
-	minWatchtowerMaxFee      float64 = 200
-	minWatchtowerPriorityFee float64 = 3
+	minWatchtowerMaxFee      float64 = float64(config.WatchtowerMaxFeeDefault)
+	minWatchtowerPriorityFee float64 = float64(config.WatchtowerPrioFeeDefault)
 )
 
 // Get the max fee for watchtower transactions
-func getWatchtowerMaxFee(cfg *config.RocketPoolConfig) float64 {
-	setting := cfg.Smartnode.WatchtowerMaxFeeOverride.Value.(float64)
-	if setting < minWatchtowerMaxFee {
+func getWatchtowerMaxFee(c *cli.Context, cfg *config.RocketPoolConfig, logger log.ColorLogger) float64 {
+	maxFee := cfg.Smartnode.WatchtowerMaxFeeOverride.Value.(float64)
+	if maxFee < minWatchtowerMaxFee {
+		maxFee = minWatchtowerMaxFee
+	}
+	if cfg.Smartnode.WatchtowerGasMode.Value.(cfgtypes.WatchtowerGasMode) != cfgtypes.WatchtowerGasMode_Dynamic {
+		return maxFee
+	}
+
+	// Use the gas oracle's suggestion, between the minimum and the configured max fee
+	suggestedFee, err := getSuggestedFee(c, cfg)
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't get the suggested max fee from the gas oracle, using the configured max fee of %.2f gwei: %s", maxFee, err.Error())
+		return maxFee
+	}
+	if suggestedFee > maxFee {
+		return maxFee
+	}
+	if suggestedFee < minWatchtowerMaxFee {
 		return minWatchtowerMaxFee
 	}
-	return setting
+	return suggestedFee
 }
 
 // Get the priority fee for watchtower transactions
@@ -24,3 +51,48 @@ func getWatchtowerPrioFee(cfg *config.RocketPoolConfig) float64 {
 	}
 	return setting
 }
+
+// Get the max fee (in gwei) suggested by the gas oracle for the fast tier, using the watchtower's priority fee
+func getSuggestedFee(c *cli.Context, cfg *config.RocketPoolConfig) (float64, error) {
+	feeOracle, err := services.GetFeeOracle(c)
+	if err != nil {
+		return 0, err
+	}
+	suggestedFee, err := feeOracle.GetMaxFeeWei(oracle.FeeTier_Fast, eth.GweiToWei(getWatchtowerPrioFee(cfg)))
+	if err != nil {
+		return 0, err
+	}
+	return eth.WeiToGwei(suggestedFee), nil
+}
+
+// Check if a non-urgent submission that became due at the provided time should be deferred until the network fee drops.
+// Submissions are never deferred in static gas mode, or once the max deferral time has passed.
+func shouldDeferSubmission(c *cli.Context, cfg *config.RocketPoolConfig, logger log.ColorLogger, dueTime time.Time) bool {
+	if cfg.Smartnode.WatchtowerGasMode.Value.(cfgtypes.WatchtowerGasMode) != cfgtypes.WatchtowerGasMode_Dynamic {
+		return false
+	}
+	deferralFee := cfg.Smartnode.WatchtowerDeferralFee.Value.(float64)
+	if deferralFee <= 0 {
+		return false
+	}
+
+	// Check the deadline
+	maxDeferral := time.Duration(cfg.Smartnode.WatchtowerMaxDeferral.Value.(uint64)) * time.Hour
+	deadline := dueTime.Add(maxDeferral)
+	if !time.Now().Before(deadline) {
+		return false
+	}
+
+	// Check the network fee
+	suggestedFee, err := getSuggestedFee(c, cfg)
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't get the suggested max fee from the gas oracle, submitting without deferral: %s", err.Error())
+		return false
+	}
+	if suggestedFee < deferralFee {
+		return false
+	}
+
+	logger.Printlnf("The suggested max fee of %.2f gwei is not lower than the deferral fee of %.2f gwei; deferring the submission until fees drop or %s, whichever comes first.", suggestedFee, deferralFee, deadline.Format(time.RFC822))
+	return true
+}
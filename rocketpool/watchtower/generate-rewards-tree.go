@@ -1,8 +1,6 @@
 package watchtower
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,34 +9,35 @@ import (
 	"sync"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/treeworker"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
+// Alert keys
+const rewardsTreeMismatchAlertKey string = "watchtower-rewards-tree-mismatch"
+
 // Generate rewards Merkle Tree task
 type generateRewardsTree struct {
-	c         *cli.Context
-	log       log.ColorLogger
-	errLog    log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	rp        *rocketpool.RocketPool
-	ec        rocketpool.ExecutionClient
-	bc        beacon.Client
-	lock      *sync.Mutex
-	isRunning bool
-	m         *state.NetworkStateManager
+	c          *cli.Context
+	log        log.ColorLogger
+	errLog     log.ColorLogger
+	cfg        *config.RocketPoolConfig
+	rp         *rocketpool.RocketPool
+	bc         beacon.Client
+	alerter    *alerting.Alerter
+	lock       *sync.Mutex
+	isRunning  bool
+	m          *state.NetworkStateManager
+	treeWorker *treeworker.Spawner
 }
 
 // Create generate rewards Merkle Tree task
@@ -49,39 +48,44 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
-	ec, err := services.GetEthClient(c)
+	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
-	bc, err := services.GetBeaconClient(c)
+	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
-	rp, err := services.GetRocketPool(c)
+	alerter, err := services.GetAlerter(c)
+	if err != nil {
+		return nil, err
+	}
+	treeWorker, err := treeworker.NewSpawner(c)
 	if err != nil {
 		return nil, err
 	}
 
 	lock := &sync.Mutex{}
 	generator := &generateRewardsTree{
-		c:         c,
-		log:       logger,
-		errLog:    errorLogger,
-		cfg:       cfg,
-		ec:        ec,
-		bc:        bc,
-		rp:        rp,
-		lock:      lock,
-		isRunning: false,
-		m:         m,
+		c:          c,
+		log:        logger,
+		errLog:     errorLogger,
+		cfg:        cfg,
+		bc:         bc,
+		rp:         rp,
+		alerter:    alerter,
+		lock:       lock,
+		isRunning:  false,
+		m:          m,
+		treeWorker: treeWorker,
 	}
 
 	return generator, nil
 }
 
-// Check for generation requests
+// Check for generation and verification requests
 func (t *generateRewardsTree) run() error {
-	t.log.Println("Checking for manual rewards tree generation requests...")
+	t.log.Println("Checking for manual rewards tree generation and verification requests...")
 
 	// Check if rewards generation is already running
 	t.lock.Lock()
@@ -107,30 +111,52 @@ func (t *generateRewardsTree) run() error {
 
 	for _, file := range files {
 		filename := file.Name()
-		if strings.HasSuffix(filename, config.RegenerateRewardsTreeRequestSuffix) && !file.IsDir() {
-			// Get the index
-			indexString := strings.TrimSuffix(filename, config.RegenerateRewardsTreeRequestSuffix)
-			index, err := strconv.ParseUint(indexString, 0, 64)
-			if err != nil {
-				return fmt.Errorf("Error parsing index from [%s]: %w", filename, err)
-			}
+		if file.IsDir() {
+			continue
+		}
 
-			// Delete the file
-			path := filepath.Join(requestDir, filename)
-			err = os.Remove(path)
-			if err != nil {
-				return fmt.Errorf("Error removing request file [%s]: %w", path, err)
-			}
+		// Check the request type
+		var suffix string
+		isVerification := false
+		if strings.HasSuffix(filename, config.RegenerateRewardsTreeRequestSuffix) {
+			suffix = config.RegenerateRewardsTreeRequestSuffix
+		} else if strings.HasSuffix(filename, config.VerifyRewardsTreeRequestSuffix) {
+			suffix = config.VerifyRewardsTreeRequestSuffix
+			isVerification = true
+		} else {
+			continue
+		}
 
-			// Generate the rewards tree
-			t.lock.Lock()
-			t.isRunning = true
-			t.lock.Unlock()
-			go t.generateRewardsTree(index)
+		// Get the index
+		indexString := strings.TrimSuffix(filename, suffix)
+		index, err := strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("Error parsing index from [%s]: %w", filename, err)
+		}
 
-			// Return after the first request, do others at other intervals
-			return nil
+		// Delete the file
+		path := filepath.Join(requestDir, filename)
+		err = os.Remove(path)
+		if err != nil {
+			return fmt.Errorf("Error removing request file [%s]: %w", path, err)
 		}
+
+		// Generate or verify the rewards tree
+		t.lock.Lock()
+		t.isRunning = true
+		t.lock.Unlock()
+		backgroundTasks.Add(1)
+		go func() {
+			defer backgroundTasks.Done()
+			if isVerification {
+				t.verifyRewardsTree(index)
+			} else {
+				t.generateRewardsTree(index)
+			}
+		}()
+
+		// Return after the first request, do others at other intervals
+		return nil
 	}
 
 	return nil
@@ -142,144 +168,119 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
 	t.log.Printlnf("%s Starting generation of Merkle rewards tree for interval %d.", generationPrefix, index)
 
-	// Find the event for this interval
-	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(t.rp, t.cfg, index)
-	if err != nil {
-		t.handleError(fmt.Errorf("%s Error getting event for interval %d: %w", generationPrefix, index, err))
-		return
-	}
-	t.log.Printlnf("%s Found snapshot event: Beacon block %s, execution block %s", generationPrefix, rewardsEvent.ConsensusBlock.String(), rewardsEvent.ExecutionBlock.String())
-
-	// Get the EL block
-	elBlockHeader, err := t.ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	// Generate the rewards file
+	profiler := rprewards.NewProfiler(t.cfg, index)
+	defer profiler.SaveReport(t.log, generationPrefix)
+	rewardsFile, canonicalRoot, err := t.generateRewardsFile(index, generationPrefix, profiler)
 	if err != nil {
-		t.handleError(fmt.Errorf("%s Error getting execution block: %w", generationPrefix, err))
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
 		return
 	}
 
-	// Try getting the rETH address as a canary to see if the block is available
-	client := t.rp
-	opts := &bind.CallOpts{
-		BlockNumber: elBlockHeader.Number,
+	// Validate the Merkle root
+	root := common.HexToHash(rewardsFile.MerkleRoot)
+	if root != canonicalRoot {
+		t.log.Printlnf("%s WARNING: your Merkle tree had a root of %s, but the canonical Merkle tree's root was %s. This file will not be usable for claiming rewards.", generationPrefix, root.Hex(), canonicalRoot.Hex())
+	} else {
+		t.log.Printlnf("%s Your Merkle tree's root of %s matches the canonical root! You will be able to use this file for claiming rewards.", generationPrefix, rewardsFile.MerkleRoot)
 	}
-	address, err := client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
-	if err != nil {
-		errMessage := err.Error()
-		t.log.Printlnf("%s Error getting state for block %d: %s", generationPrefix, elBlockHeader.Number.Uint64(), errMessage)
-		if strings.Contains(errMessage, "missing trie node") || // Geth
-			strings.Contains(errMessage, "No state available for block") || // Nethermind
-			strings.Contains(errMessage, "Internal error") { // Besu
-
-			// The state was missing so fall back to the archive node
-			archiveEcUrl := t.cfg.Smartnode.ArchiveECUrl.Value.(string)
-			if archiveEcUrl != "" {
-				t.log.Printlnf("%s Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", generationPrefix, elBlockHeader.Number.Uint64(), archiveEcUrl)
-				ec, err := ethclient.Dial(archiveEcUrl)
-				if err != nil {
-					t.handleError(fmt.Errorf("Error connecting to archive EC: %w", err))
-					return
-				}
-				client, err = rocketpool.NewRocketPool(ec, common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
-				if err != nil {
-					t.handleError(fmt.Errorf("%s Error creating Rocket Pool client connected to archive EC: %w", err))
-					return
-				}
-
-				// Get the rETH address from the archive EC
-				address, err = client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
-				if err != nil {
-					t.handleError(fmt.Errorf("%s Error verifying rETH address with Archive EC: %w", err))
-					return
-				}
-			} else {
-				// No archive node specified
-				t.handleError(fmt.Errorf("***ERROR*** Primary EC cannot retrieve state for historical block %d and the Archive EC is not specified.", elBlockHeader.Number.Uint64()))
-				return
-			}
 
+	// Write the files
+	rewardsFile.MinipoolPerformanceFileCID = "---"
+	t.log.Printlnf("%s Saving files...", generationPrefix)
+	path := t.cfg.Smartnode.GetRewardsTreePath(index, true)
+	minipoolPerformancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(index, true)
+	err = profiler.TimePhase(rprewards.ProfilePhase_Serialization, func() error {
+		err := rprewards.SaveMinipoolPerformanceFile(t.cfg, minipoolPerformancePath, &rewardsFile.MinipoolPerformanceFile)
+		if err != nil {
+			return fmt.Errorf("Error saving minipool performance file to %s: %w", minipoolPerformancePath, err)
 		}
-	}
-
-	// Sanity check the rETH address to make sure the client is working right
-	if address != t.cfg.Smartnode.GetRethAddress() {
-		t.handleError(fmt.Errorf("***ERROR*** Your Primary EC provided %s as the rETH address, but it should have been %s!", address.Hex(), t.cfg.Smartnode.GetRethAddress().Hex()))
-		return
-	}
-
-	// Get the state for the target slot
-	state, err := t.m.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+		err = rprewards.SaveRewardsFile(t.cfg, path, rewardsFile)
+		if err != nil {
+			return fmt.Errorf("Error saving rewards file to %s: %w", path, err)
+		}
+		return nil
+	})
 	if err != nil {
-		t.handleError(fmt.Errorf("%s error getting state for beacon slot %d: %w", generationPrefix, rewardsEvent.ConsensusBlock.Uint64(), err))
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
 		return
 	}
 
-	// Generate the tree
-	t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, state)
+	t.log.Printlnf("%s Merkle tree generation complete!", generationPrefix)
+	t.lock.Lock()
+	t.isRunning = false
+	t.lock.Unlock()
+
 }
 
-// Implementation for rewards tree generation using a viable EC
-func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
+// Regenerate the rewards tree for an interval and compare its Merkle root against the canonical one, without replacing the saved files
+func (t *generateRewardsTree) verifyRewardsTree(index uint64) {
 
-	// Generate the rewards file
-	start := time.Now()
-	treegen, err := rprewards.NewTreeGenerator(t.log, generationPrefix, rp, t.cfg, t.bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state)
-	if err != nil {
-		t.handleError(fmt.Errorf("%s Error creating Merkle tree generator: %w", generationPrefix, err))
-		return
+	verificationPrefix := fmt.Sprintf("[Interval %d Verification]", index)
+	t.log.Printlnf("%s Starting verification of the Merkle rewards tree for interval %d.", verificationPrefix, index)
+
+	// Generate the rewards file and compare the roots
+	verification := rprewards.RewardsTreeVerification{
+		Index: index,
 	}
-	rewardsFile, err := treegen.GenerateTree()
+	profiler := rprewards.NewProfiler(t.cfg, index)
+	rewardsFile, canonicalRoot, err := t.generateRewardsFile(index, verificationPrefix, profiler)
+	profiler.SaveReport(t.log, verificationPrefix)
 	if err != nil {
-		t.handleError(fmt.Errorf("%s Error generating Merkle tree: %w", generationPrefix, err))
-		return
-	}
-	for address, network := range rewardsFile.InvalidNetworkNodes {
-		t.log.Printlnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", generationPrefix, address.Hex(), network)
-	}
-	t.log.Printlnf("%s Finished in %s", generationPrefix, time.Since(start).String())
-
-	// Validate the Merkle root
-	root := common.BytesToHash(rewardsFile.MerkleTree.Root())
-	if root != rewardsEvent.MerkleRoot {
-		t.log.Printlnf("%s WARNING: your Merkle tree had a root of %s, but the canonical Merkle tree's root was %s. This file will not be usable for claiming rewards.", generationPrefix, root.Hex(), rewardsEvent.MerkleRoot.Hex())
+		t.errLog.Printlnf("%s %s", verificationPrefix, err.Error())
+		verification.Error = err.Error()
 	} else {
-		t.log.Printlnf("%s Your Merkle tree's root of %s matches the canonical root! You will be able to use this file for claiming rewards.", generationPrefix, rewardsFile.MerkleRoot)
+		root := common.HexToHash(rewardsFile.MerkleRoot)
+		verification.CanonicalMerkleRoot = canonicalRoot.Hex()
+		verification.GeneratedMerkleRoot = root.Hex()
+		verification.Matches = (root == canonicalRoot)
 	}
+	verification.VerifiedTime = time.Now()
 
-	// Create the JSON files
-	rewardsFile.MinipoolPerformanceFileCID = "---"
-	t.log.Printlnf("%s Saving JSON files...", generationPrefix)
-	minipoolPerformanceBytes, err := json.Marshal(rewardsFile.MinipoolPerformanceFile)
-	if err != nil {
-		t.handleError(fmt.Errorf("%s Error serializing minipool performance file into JSON: %w", generationPrefix, err))
-		return
-	}
-	wrapperBytes, err := json.Marshal(rewardsFile)
-	if err != nil {
-		t.handleError(fmt.Errorf("%s Error serializing proof wrapper into JSON: %w", generationPrefix, err))
-		return
+	// Record the result
+	path := t.cfg.Smartnode.GetRewardsTreeVerificationPath(index, true)
+	saveErr := rprewards.SaveRewardsTreeVerification(path, &verification)
+	if saveErr != nil {
+		t.errLog.Printlnf("%s %s", verificationPrefix, saveErr.Error())
 	}
 
-	// Write the files
-	path := t.cfg.Smartnode.GetRewardsTreePath(index, true)
-	minipoolPerformancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(index, true)
-	err = os.WriteFile(minipoolPerformancePath, minipoolPerformanceBytes, 0644)
-	if err != nil {
-		t.handleError(fmt.Errorf("%s Error saving minipool performance file to %s: %w", generationPrefix, minipoolPerformancePath, err))
-		return
-	}
-	err = os.WriteFile(path, wrapperBytes, 0644)
-	if err != nil {
-		t.handleError(fmt.Errorf("%s Error saving rewards file to %s: %w", generationPrefix, path, err))
-		return
+	if verification.Error != "" {
+		t.errLog.Println("*** Rewards tree verification failed. ***")
+	} else if verification.Matches {
+		t.log.Printlnf("%s Your Merkle tree's root of %s matches the canonical root.", verificationPrefix, verification.GeneratedMerkleRoot)
+		t.alerter.Resolve(rewardsTreeMismatchAlertKey)
+	} else {
+		message := fmt.Sprintf("Your node regenerated the Merkle rewards tree for interval %d with a root of %s, but the root submitted by the Oracle DAO was %s.", index, verification.GeneratedMerkleRoot, verification.CanonicalMerkleRoot)
+		t.errLog.Printlnf("%s WARNING: %s", verificationPrefix, message)
+		err = t.alerter.Publish(alerting.Alert{
+			Key:      rewardsTreeMismatchAlertKey,
+			Severity: alerting.Severity_Critical,
+			Title:    fmt.Sprintf("Rewards tree for interval %d does not match", index),
+			Message:  message,
+		})
+		if err != nil {
+			t.errLog.Printlnf("%s Error sending alert: %s", verificationPrefix, err.Error())
+		}
 	}
 
-	t.log.Printlnf("%s Merkle tree generation complete!", generationPrefix)
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
 
 }
 
+// Generate the rewards file for an interval, in a worker process if enabled, and get the Merkle root of the canonical tree
+func (t *generateRewardsTree) generateRewardsFile(index uint64, logPrefix string, profiler *rprewards.Profiler) (*rprewards.RewardsFile, common.Hash, error) {
+	if t.treeWorker.IsEnabled() {
+		return t.treeWorker.GenerateInterval(index, logPrefix, &t.log, profiler)
+	}
+	rewardsFile, rewardsEvent, err := rprewards.GenerateRewardsFileForInterval(t.log, logPrefix, t.rp, t.cfg, t.bc, t.m, index, profiler)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	return rewardsFile, rewardsEvent.MerkleRoot, nil
+}
+
 func (t *generateRewardsTree) handleError(err error) {
 	t.errLog.Println(err)
 	t.errLog.Println("*** Rewards tree generation failed. ***")
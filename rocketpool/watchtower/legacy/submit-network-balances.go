@@ -234,7 +234,7 @@ func (t *SubmitNetworkBalances) Run() error {
 		return err
 	}
 	if hasSubmitted {
-		t.log.Printlnf("Have previously submitted out-of-date balances for block $d, trying again...", blockNumber)
+		t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", blockNumber)
 	}
 
 	// Log
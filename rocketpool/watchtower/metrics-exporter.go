@@ -8,11 +8,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/taskmetrics"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, dissolveCollector *collectors.DissolveCollector, dutyCollector *collectors.DutyCollector, taskCollector *taskmetrics.TaskCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -28,6 +29,9 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(scrubCollector)
+	registry.MustRegister(dissolveCollector)
+	registry.MustRegister(dutyCollector)
+	registry.MustRegister(taskCollector)
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -0,0 +1,309 @@
+package watchtower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/txjournal"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	transactionPollInterval      time.Duration = 5 * time.Second
+	stuckTransactionTimeout      time.Duration = 5 * time.Minute
+	maxTransactionReplacements   int           = 3
+	replacementFeeBumpPercent    int64         = 25
+	minReplacementFeeBumpPercent int64         = 10
+	droppedTransactionThreshold  int           = 6
+	journalSource                string        = "watchtower"
+)
+
+// Returned when a transaction can't be replaced because the replacement's fees would go over the watchtower's max fee
+var errReplacementFeeCapped = errors.New("the replacement fee would be over the max fee")
+
+// Hands out the node account's nonces to the watchtower tasks so concurrent submissions never collide,
+// and replaces transactions that get stuck in the mempool with copies that pay a higher fee
+type nonceManager struct {
+	c           *cli.Context
+	cfg         *config.RocketPoolConfig
+	w           *wallet.Wallet
+	ec          rocketpool.ExecutionClient
+	journal     *txjournal.Journal
+	submissions *submissionLedger
+	log         log.ColorLogger
+	nextNonce   uint64
+	isSynced    bool
+	lock        *sync.Mutex
+}
+
+// Create a new nonce manager
+func newNonceManager(c *cli.Context, cfg *config.RocketPoolConfig, w *wallet.Wallet, ec rocketpool.ExecutionClient, journal *txjournal.Journal, submissions *submissionLedger, logger log.ColorLogger) *nonceManager {
+	return &nonceManager{
+		c:           c,
+		cfg:         cfg,
+		w:           w,
+		ec:          ec,
+		journal:     journal,
+		submissions: submissions,
+		log:         logger,
+		lock:        &sync.Mutex{},
+	}
+}
+
+// Send a transaction with the next available nonce. The send function must submit the transaction with the provided transactor.
+func (m *nonceManager) send(opts *bind.TransactOpts, send func(opts *bind.TransactOpts) (common.Hash, error)) (common.Hash, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Get the next nonce, catching up with the client if something else has used the account
+	pendingNonce, err := m.ec.PendingNonceAt(context.Background(), opts.From)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting the node's pending nonce: %w", err)
+	}
+	if !m.isSynced || pendingNonce > m.nextNonce {
+		m.nextNonce = pendingNonce
+		m.isSynced = true
+	}
+	opts.Nonce = big.NewInt(0).SetUint64(m.nextNonce)
+
+	// Send it
+	hash, err := send(opts)
+	if err != nil {
+		// The client may or may not have accepted the nonce, so resync it before the next transaction
+		m.isSynced = false
+		return common.Hash{}, err
+	}
+	m.nextNonce++
+	m.recordInJournal(hash, "submit")
+	return hash, nil
+}
+
+// Print a transaction's details to the logger and wait for it to be included in a block, replacing it if it gets stuck.
+// Returns the hash of the transaction that was included, which will differ from the original if it was replaced.
+func (m *nonceManager) printAndWaitForTransaction(hash common.Hash, logger log.ColorLogger) (common.Hash, error) {
+	txWatchUrl := m.cfg.Smartnode.GetTxWatchUrl()
+	logger.Printlnf("Transaction has been submitted with hash %s.", hash.Hex())
+	if txWatchUrl != "" {
+		logger.Printlnf("You may follow its progress by visiting:")
+		logger.Printlnf("%s/%s\n", txWatchUrl, hash.Hex())
+	}
+	logger.Println("Waiting for the transaction to be validated...")
+
+	receipt, err := m.waitForTransaction(hash, logger)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Error waiting for transaction: %w", err)
+	}
+	return receipt.TxHash, nil
+}
+
+// Wait for a transaction to be included in a block. If it's still pending after the stuck transaction timeout, it will be
+// replaced with a copy that pays a higher fee. Returns the receipt of whichever version of the transaction was included.
+func (m *nonceManager) waitForTransaction(hash common.Hash, logger log.ColorLogger) (*types.Receipt, error) {
+
+	// Get the transaction, waiting for it to propagate if necessary
+	var tx *types.Transaction
+	var err error
+	for i := 0; i < droppedTransactionThreshold; i++ {
+		tx, _, err = m.ec.TransactionByHash(context.Background(), hash)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("error getting transaction %s: %w", hash.Hex(), err)
+		}
+		time.Sleep(transactionPollInterval)
+	}
+	if tx == nil {
+		m.resync()
+		return nil, fmt.Errorf("transaction %s was not found; it may have been dropped from the mempool", hash.Hex())
+	}
+
+	// Wait for any of its versions to be included
+	versions := []*types.Transaction{tx}
+	lastSubmission := time.Now()
+	canReplace := true
+	for {
+		receipt, err := m.getIncludedReceipt(versions)
+		if receipt != nil || err != nil {
+			return receipt, err
+		}
+
+		// Make sure the nonce wasn't used by a transaction outside of the watchtower
+		latestNonce, err := m.ec.NonceAt(context.Background(), m.getSender(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the node's latest nonce: %w", err)
+		}
+		if latestNonce > tx.Nonce() {
+			// One of the versions may have been included since the receipts were checked, so check them once more
+			receipt, err := m.getIncludedReceipt(versions)
+			if receipt != nil || err != nil {
+				return receipt, err
+			}
+			m.resync()
+			return nil, fmt.Errorf("nonce %d of transaction %s was used by a different transaction", tx.Nonce(), hash.Hex())
+		}
+
+		// Replace the latest version if it's stuck
+		if canReplace && time.Since(lastSubmission) >= stuckTransactionTimeout && len(versions) <= maxTransactionReplacements {
+			latest := versions[len(versions)-1]
+			replacement, err := m.replaceTransaction(latest)
+			if errors.Is(err, errReplacementFeeCapped) {
+				logger.Printlnf("WARNING: transaction %s has been pending for %s and won't be replaced again: %s", latest.Hash().Hex(), stuckTransactionTimeout, err.Error())
+				canReplace = false
+			} else if err != nil {
+				logger.Printlnf("WARNING: transaction %s has been pending for %s but couldn't be replaced: %s", latest.Hash().Hex(), stuckTransactionTimeout, err.Error())
+			} else {
+				logger.Printlnf("Transaction %s has been pending for %s; replaced it with transaction %s (max fee %.2f gwei, priority fee %.2f gwei).",
+					latest.Hash().Hex(), stuckTransactionTimeout, replacement.Hash().Hex(), eth.WeiToGwei(replacement.GasFeeCap()), eth.WeiToGwei(replacement.GasTipCap()))
+				versions = append(versions, replacement)
+			}
+			lastSubmission = time.Now()
+		}
+
+		time.Sleep(transactionPollInterval)
+	}
+
+}
+
+// Get the receipt of whichever version of a transaction was included in a block, or nil if none of them have been yet
+func (m *nonceManager) getIncludedReceipt(versions []*types.Transaction) (*types.Receipt, error) {
+	for _, version := range versions {
+		receipt, err := m.ec.TransactionReceipt(context.Background(), version.Hash())
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("error getting the receipt for transaction %s: %w", version.Hash().Hex(), err)
+		}
+		if receipt.Status == types.ReceiptStatusFailed {
+			return receipt, fmt.Errorf("transaction %s failed with status 0", version.Hash().Hex())
+		}
+		return receipt, nil
+	}
+	return nil, nil
+}
+
+// Re-send a pending transaction with the same nonce and a higher fee.
+// Transactions are usually dynamic fee ones, but hardware wallets that can't sign those send legacy transactions instead.
+func (m *nonceManager) replaceTransaction(tx *types.Transaction) (*types.Transaction, error) {
+	opts, err := m.w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(m.c, m.cfg, m.log))
+	var replacementTx *types.Transaction
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		tipCap, feeCap, err := getReplacementFees(tx.GasTipCap(), tx.GasFeeCap(), maxFee)
+		if err != nil {
+			return nil, err
+		}
+		replacementTx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tipCap,
+			GasFeeCap:  feeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+	case types.LegacyTxType:
+		// The gas price is both the priority fee and the max fee
+		_, gasPrice, err := getReplacementFees(tx.GasPrice(), tx.GasPrice(), maxFee)
+		if err != nil {
+			return nil, err
+		}
+		replacementTx = types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: gasPrice,
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		})
+	default:
+		return nil, fmt.Errorf("transactions of type %d can't be replaced", tx.Type())
+	}
+
+	replacement, err := opts.Signer(opts.From, replacementTx)
+	if err != nil {
+		return nil, fmt.Errorf("error signing replacement transaction: %w", err)
+	}
+
+	err = m.ec.SendTransaction(context.Background(), replacement)
+	if err != nil {
+		return nil, fmt.Errorf("error sending replacement transaction: %w", err)
+	}
+	m.recordInJournal(replacement.Hash(), "replace")
+	m.submissions.replaceTransaction(tx.Hash(), replacement.Hash())
+	return replacement, nil
+}
+
+// Get the priority fee and max fee for a replacement of a transaction.
+// Both fees are bumped, but the max fee is capped at the watchtower's max fee and the priority fee at the max fee. Clients
+// require both fees to be bumped by at least 10% to accept a replacement, so it fails if the cap doesn't leave room for that.
+func getReplacementFees(tipCap *big.Int, feeCap *big.Int, maxFee *big.Int) (*big.Int, *big.Int, error) {
+	bumpFee := func(fee *big.Int, percent int64) *big.Int {
+		bumped := big.NewInt(0).Mul(fee, big.NewInt(100+percent))
+		return bumped.Div(bumped, big.NewInt(100))
+	}
+
+	newFeeCap := bumpFee(feeCap, replacementFeeBumpPercent)
+	if newFeeCap.Cmp(maxFee) > 0 {
+		newFeeCap = maxFee
+	}
+	if newFeeCap.Cmp(bumpFee(feeCap, minReplacementFeeBumpPercent)) < 0 {
+		return nil, nil, fmt.Errorf("%w: the max fee of %.2f gwei can't be raised by %d%% without going over the watchtower's max fee of %.2f gwei",
+			errReplacementFeeCapped, eth.WeiToGwei(feeCap), minReplacementFeeBumpPercent, eth.WeiToGwei(maxFee))
+	}
+
+	newTipCap := bumpFee(tipCap, replacementFeeBumpPercent)
+	if newTipCap.Cmp(newFeeCap) > 0 {
+		newTipCap = newFeeCap
+	}
+	if newTipCap.Cmp(bumpFee(tipCap, minReplacementFeeBumpPercent)) < 0 {
+		return nil, nil, fmt.Errorf("%w: the priority fee of %.2f gwei can't be raised by %d%% without going over the replacement's max fee of %.2f gwei",
+			errReplacementFeeCapped, eth.WeiToGwei(tipCap), minReplacementFeeBumpPercent, eth.WeiToGwei(newFeeCap))
+	}
+	return newTipCap, newFeeCap, nil
+}
+
+// Record a submitted transaction in the transaction journal
+func (m *nonceManager) recordInJournal(hash common.Hash, command string) {
+	err := m.journal.RecordSubmission(m.ec, journalSource, "nonce-manager", command, hash)
+	if err != nil {
+		// The transaction has already been submitted, so journaling it is best-effort
+		m.log.Printlnf("WARNING: couldn't record transaction %s in the journal: %s", hash.Hex(), err.Error())
+	}
+}
+
+// Get the address of the node account
+func (m *nonceManager) getSender() common.Address {
+	nodeAccount, _ := m.w.GetNodeAccount()
+	return nodeAccount.Address
+}
+
+// Force the next nonce to be retrieved from the client
+func (m *nonceManager) resync() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.isSynced = false
+}
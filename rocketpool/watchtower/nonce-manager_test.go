@@ -0,0 +1,49 @@
+package watchtower
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Replacement fees are bumped, but never over the watchtower's max fee, and a replacement is refused if the cap leaves
+// no room for the minimum bump clients accept
+func TestReplacementFeesAreCappedAtMaxFee(t *testing.T) {
+	tests := []struct {
+		name          string
+		tipCap        float64
+		feeCap        float64
+		maxFee        float64
+		expectedTip   float64
+		expectedFee   float64
+		expectsCapped bool
+	}{
+		{name: "under the max fee", tipCap: 2, feeCap: 20, maxFee: 100, expectedTip: 2.5, expectedFee: 25},
+		{name: "capped at the max fee", tipCap: 2, feeCap: 20, maxFee: 23, expectedTip: 2.5, expectedFee: 23},
+		{name: "priority fee capped at the max fee", tipCap: 20, feeCap: 20, maxFee: 23, expectedTip: 23, expectedFee: 23},
+		{name: "max fee too low for the minimum bump", tipCap: 2, feeCap: 20, maxFee: 21, expectsCapped: true},
+		{name: "max fee below the current fee", tipCap: 2, feeCap: 20, maxFee: 10, expectsCapped: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tipCap, feeCap, err := getReplacementFees(eth.GweiToWei(test.tipCap), eth.GweiToWei(test.feeCap), eth.GweiToWei(test.maxFee))
+			if test.expectsCapped {
+				if !errors.Is(err, errReplacementFeeCapped) {
+					t.Fatalf("expected the replacement to be refused, got fees %s / %s and error %v", tipCap, feeCap, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if tipCap.Cmp(eth.GweiToWei(test.expectedTip)) != 0 {
+				t.Errorf("expected a priority fee of %.2f gwei, got %.2f gwei", test.expectedTip, eth.WeiToGwei(tipCap))
+			}
+			if feeCap.Cmp(eth.GweiToWei(test.expectedFee)) != 0 {
+				t.Errorf("expected a max fee of %.2f gwei, got %.2f gwei", test.expectedFee, eth.WeiToGwei(feeCap))
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package watchtower
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reasons a block's fee recipient was found to be illegal
+const (
+	penaltyReason_SmoothingPoolTheft string = "smoothing-pool-theft"
+	penaltyReason_LateOptOut         string = "late-smoothing-pool-opt-out"
+	penaltyReason_IllegalRecipient   string = "illegal-fee-recipient"
+)
+
+// The evidence for a penalty, which is kept so it can be reviewed if the penalty is disputed
+type penaltyEvidence struct {
+	DetectedTime         time.Time      `json:"detectedTime"`
+	Reason               string         `json:"reason"`
+	Slot                 uint64         `json:"slot"`
+	ProposerIndex        uint64         `json:"proposerIndex"`
+	ExecutionBlockNumber uint64         `json:"executionBlockNumber"`
+	BlockHash            common.Hash    `json:"blockHash"`
+	Minipool             common.Address `json:"minipool"`
+	Node                 common.Address `json:"node"`
+	ExpectedFeeRecipient common.Address `json:"expectedFeeRecipient"`
+	BlockFeeRecipient    common.Address `json:"blockFeeRecipient"`
+	ActualFeeRecipient   common.Address `json:"actualFeeRecipient"`
+	SafeOptOutTime       *time.Time     `json:"safeOptOutTime,omitempty"`
+	OptOutTime           *time.Time     `json:"optOutTime,omitempty"`
+	AlreadyPenalized     bool           `json:"alreadyPenalized"`
+	TxHash               *common.Hash   `json:"txHash,omitempty"`
+	Error                string         `json:"error,omitempty"`
+}
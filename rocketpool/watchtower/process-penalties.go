@@ -2,6 +2,7 @@ package watchtower
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
 	"os"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/minipool"
@@ -21,7 +23,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
-	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
@@ -43,7 +45,8 @@ type processPenalties struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
-	ec             rocketpool.ExecutionClient
+	feeOracle      *oracle.FeeOracle
+	ec             *services.ExecutionClientManager
 	bc             beacon.Client
 	lock           *sync.Mutex
 	isRunning      bool
@@ -53,6 +56,7 @@ type processPenalties struct {
 	beaconConfig   beacon.Eth2Config
 	m              *state.NetworkStateManager
 	s              *state.NetworkState
+	nonces         *nonceManager
 }
 
 type penaltyState struct {
@@ -60,7 +64,7 @@ type penaltyState struct {
 }
 
 // Create process penalties task
-func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*processPenalties, error) {
+func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, nonces *nonceManager) (*processPenalties, error) {
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -78,6 +82,10 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	if err != nil {
 		return nil, err
 	}
+	feeOracle, err := services.GetFeeOracle(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -119,6 +127,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 		ec:             ec,
 		bc:             bc,
 		rp:             rp,
+		feeOracle:      feeOracle,
 		lock:           lock,
 		isRunning:      false,
 		maxFee:         maxFee,
@@ -126,6 +135,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 		gasLimit:       0,
 		beaconConfig:   beaconConfig,
 		m:              m,
+		nonces:         nonces,
 	}, nil
 }
 
@@ -177,7 +187,7 @@ func (s *penaltyState) saveState(path string) error {
 }
 
 // Process penalties
-func (t *processPenalties) run(isAtlasDeployed bool) error {
+func (t *processPenalties) run() error {
 
 	// Wait for eth clients to sync
 	if err := services.WaitEthClientSynced(t.c, true); err != nil {
@@ -215,7 +225,9 @@ func (t *processPenalties) run(isAtlasDeployed bool) error {
 	t.lock.Unlock()
 
 	// Run the check
+	backgroundTasks.Add(1)
 	go func() {
+		defer backgroundTasks.Done()
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -393,27 +405,39 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 		return isIllegalFeeRecipient, nil
 	}
 
+	// MEV-Boost blocks use the builder as the fee recipient, and pay the proposer in the block's last transaction
+	feeRecipient := block.FeeRecipient
+	if feeRecipient != distributorAddress {
+		feeRecipient, err = t.getProposerPaymentRecipient(block)
+		if err != nil {
+			return isIllegalFeeRecipient, err
+		}
+		if (smoothingPoolAddress != emptyAddress && feeRecipient == smoothingPoolAddress) || feeRecipient == rethAddress {
+			return isIllegalFeeRecipient, nil
+		}
+	}
+
 	// Check if the user was opted into the smoothing pool for this block
 	opts := bind.CallOpts{
 		BlockNumber: big.NewInt(int64(block.ExecutionBlockNumber)),
 	}
 	isOptedIn, err := node.GetSmoothingPoolRegistrationState(t.rp, nodeAddress, &opts)
 	if err != nil {
-		t.log.Printlnf("*** WARNING: Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
-		isOptedIn = false
+		return isIllegalFeeRecipient, fmt.Errorf("error checking if node %s was opted into the smoothing pool for slot %d (execution block %d): %w", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
 	}
 
 	// Check for smoothing pool theft
-	if isOptedIn && block.FeeRecipient != smoothingPoolAddress {
+	if isOptedIn && feeRecipient != smoothingPoolAddress {
 		t.log.Println("=== SMOOTHING POOL THEFT DETECTED ===")
 		t.log.Printlnf("Beacon Block:  %d", block.Slot)
 		t.log.Printlnf("Minipool:      %s", minipoolAddress.Hex())
 		t.log.Printlnf("Node:          %s", nodeAddress.Hex())
-		t.log.Printlnf("FEE RECIPIENT: %s", block.FeeRecipient.Hex())
+		t.log.Printlnf("FEE RECIPIENT: %s", feeRecipient.Hex())
 		t.log.Println("=====================================")
 
 		isIllegalFeeRecipient = true
-		err = t.submitPenalty(minipoolAddress, block)
+		evidence := newPenaltyEvidence(penaltyReason_SmoothingPoolTheft, block, minipoolAddress, nodeAddress, smoothingPoolAddress, feeRecipient)
+		err = t.submitAndRecordPenalty(evidence, block)
 		return isIllegalFeeRecipient, err
 	}
 
@@ -422,8 +446,9 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 		// Get the opt out time
 		optOutTime, err := node.GetSmoothingPoolRegistrationChanged(t.rp, nodeAddress, &opts)
 		if err != nil {
-			t.log.Printlnf("*** WARNING: Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
-		} else if optOutTime != time.Unix(0, 0) {
+			return isIllegalFeeRecipient, fmt.Errorf("error checking when node %s opted out of the smoothing pool for slot %d (execution block %d): %w", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
+		}
+		if optOutTime != time.Unix(0, 0) {
 			// Get the time of the epoch before this one
 			blockEpoch := block.Slot / t.beaconConfig.SlotsPerEpoch
 			previousEpoch := blockEpoch - 1
@@ -438,28 +463,32 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 				t.log.Printlnf("ACTUAL OPT OUT TIME:  %s", optOutTime)
 				t.log.Printlnf("Minipool:             %s", minipoolAddress.Hex())
 				t.log.Printlnf("Node:                 %s", nodeAddress.Hex())
-				t.log.Printlnf("FEE RECIPIENT:        %s", block.FeeRecipient.Hex())
+				t.log.Printlnf("FEE RECIPIENT:        %s", feeRecipient.Hex())
 				t.log.Println("=====================================")
 
 				isIllegalFeeRecipient = true
-				err = t.submitPenalty(minipoolAddress, block)
+				evidence := newPenaltyEvidence(penaltyReason_LateOptOut, block, minipoolAddress, nodeAddress, distributorAddress, feeRecipient)
+				evidence.SafeOptOutTime = &epochStartTime
+				evidence.OptOutTime = &optOutTime
+				err = t.submitAndRecordPenalty(evidence, block)
 				return isIllegalFeeRecipient, err
 			}
 		}
 	}
 
 	// Check for distributor address theft
-	if !isOptedIn && block.FeeRecipient != distributorAddress {
+	if !isOptedIn && feeRecipient != distributorAddress {
 		t.log.Println("=== ILLEGAL FEE RECIPIENT DETECTED ===")
 		t.log.Printlnf("Beacon Block:  %d", block.Slot)
 		t.log.Printlnf("Minipool:      %s", minipoolAddress.Hex())
 		t.log.Printlnf("Node:          %s", nodeAddress.Hex())
 		t.log.Printlnf("Distributor:   %s", distributorAddress.Hex())
-		t.log.Printlnf("FEE RECIPIENT: %s", block.FeeRecipient.Hex())
+		t.log.Printlnf("FEE RECIPIENT: %s", feeRecipient.Hex())
 		t.log.Println("======================================")
 
 		isIllegalFeeRecipient = true
-		err = t.submitPenalty(minipoolAddress, block)
+		evidence := newPenaltyEvidence(penaltyReason_IllegalRecipient, block, minipoolAddress, nodeAddress, distributorAddress, feeRecipient)
+		err = t.submitAndRecordPenalty(evidence, block)
 		return isIllegalFeeRecipient, err
 	}
 
@@ -468,7 +497,59 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 
 }
 
-func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *beacon.BeaconBlock) error {
+// Get the address that the proposer of a block was actually paid at.
+// For MEV-Boost blocks, the fee recipient is the builder, which pays the proposer in the block's last transaction;
+// otherwise, the proposer is paid at the fee recipient directly.
+func (t *processPenalties) getProposerPaymentRecipient(block *beacon.BeaconBlock) (common.Address, error) {
+
+	ethBlock, err := t.ec.BlockByNumber(context.Background(), big.NewInt(0).SetUint64(block.ExecutionBlockNumber))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting execution block %d for slot %d: %w", block.ExecutionBlockNumber, block.Slot, err)
+	}
+	txs := ethBlock.Transactions()
+	if len(txs) == 0 {
+		return block.FeeRecipient, nil
+	}
+
+	// Check if the last transaction is a payment from the fee recipient
+	lastTx := txs[len(txs)-1]
+	if lastTx.To() == nil || *lastTx.To() == block.FeeRecipient {
+		return block.FeeRecipient, nil
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(lastTx.ChainId()), lastTx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting the sender of the last transaction in execution block %d: %w", block.ExecutionBlockNumber, err)
+	}
+	if sender != block.FeeRecipient {
+		return block.FeeRecipient, nil
+	}
+	return *lastTx.To(), nil
+
+}
+
+// Submit a penalty, and record its evidence whether or not the submission succeeded
+func (t *processPenalties) submitAndRecordPenalty(evidence *penaltyEvidence, block *beacon.BeaconBlock) error {
+
+	err := t.submitPenalty(evidence, block)
+	if err != nil {
+		evidence.Error = err.Error()
+	}
+
+	evidencePath := t.cfg.Smartnode.GetPenaltyEvidencePath(true)
+	saveErr := appendAuditRecord(evidencePath, evidence)
+	if saveErr != nil {
+		t.errLog.Printlnf("WARNING: couldn't record the evidence for the penalty against %s on block %d: %s", evidence.Minipool.Hex(), block.Slot, saveErr.Error())
+	} else {
+		t.log.Printlnf("Recorded the evidence for the penalty against %s on block %d in %s.", evidence.Minipool.Hex(), block.Slot, evidencePath)
+	}
+
+	return err
+
+}
+
+func (t *processPenalties) submitPenalty(evidence *penaltyEvidence, block *beacon.BeaconBlock) error {
+
+	minipoolAddress := evidence.Minipool
 
 	// Check if this penalty has already been applied
 	blockNumberBuf := make([]byte, 32)
@@ -480,6 +561,7 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	}
 	if penaltyExecuted {
 		t.log.Printlnf("NOTE: Minipool %s was already penalized on block %d, skipping...", minipoolAddress.Hex(), block.Slot)
+		evidence.AlreadyPenalized = true
 		return nil
 	}
 
@@ -504,7 +586,7 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = t.feeOracle.GetMaxFeeWei(oracle.FeeTier_Fast, t.maxPriorityFee)
 		if err != nil {
 			return err
 		}
@@ -519,20 +601,41 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	opts.GasTipCap = t.maxPriorityFee
 	opts.GasLimit = gas.Uint64()
 
-	hash, err := network.SubmitPenalty(t.rp, minipoolAddress, slotBig, opts)
+	hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return network.SubmitPenalty(t.rp, minipoolAddress, slotBig, opts)
+	})
 	if err != nil {
 		return fmt.Errorf("Error submitting penalty against %s for block %d: %w", minipoolAddress.Hex(), block.Slot, err)
 	}
+	evidence.TxHash = &hash
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	includedHash, err := t.nonces.printAndWaitForTransaction(hash, t.log)
 	if err != nil {
 		return err
 	}
+	evidence.TxHash = &includedHash
 
 	// Log result
-	t.log.Printlnf("Submitted penalty against %s with fee recipient %s on block %d with tx %s", minipoolAddress.Hex(), block.FeeRecipient.Hex(), block.Slot, hash.Hex())
+	t.log.Printlnf("Submitted penalty against %s with fee recipient %s on block %d with tx %s", minipoolAddress.Hex(), evidence.ActualFeeRecipient.Hex(), block.Slot, includedHash.Hex())
 
 	return nil
 
 }
+
+// Create the evidence for a penalty against a block's proposer
+func newPenaltyEvidence(reason string, block *beacon.BeaconBlock, minipoolAddress common.Address, nodeAddress common.Address, expectedFeeRecipient common.Address, actualFeeRecipient common.Address) *penaltyEvidence {
+	return &penaltyEvidence{
+		DetectedTime:         time.Now(),
+		Reason:               reason,
+		Slot:                 block.Slot,
+		ProposerIndex:        block.ProposerIndex,
+		ExecutionBlockNumber: block.ExecutionBlockNumber,
+		BlockHash:            block.ExecutionBlockHash,
+		Minipool:             minipoolAddress,
+		Node:                 nodeAddress,
+		ExpectedFeeRecipient: expectedFeeRecipient,
+		BlockFeeRecipient:    block.FeeRecipient,
+		ActualFeeRecipient:   actualFeeRecipient,
+	}
+}
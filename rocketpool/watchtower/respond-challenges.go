@@ -3,6 +3,8 @@ package watchtower
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -18,16 +20,17 @@ import (
 
 // Respond to challenges task
 type respondChallenges struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	m   *state.NetworkStateManager
+	c      *cli.Context
+	log    log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	w      *wallet.Wallet
+	rp     *rocketpool.RocketPool
+	m      *state.NetworkStateManager
+	nonces *nonceManager
 }
 
 // Create respond to challenges task
-func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.NetworkStateManager) (*respondChallenges, error) {
+func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.NetworkStateManager, nonces *nonceManager) (*respondChallenges, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -45,12 +48,13 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.Netwo
 
 	// Return task
 	return &respondChallenges{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		rp:  rp,
-		m:   m,
+		c:      c,
+		log:    logger,
+		cfg:    cfg,
+		w:      w,
+		rp:     rp,
+		m:      m,
+		nonces: nonces,
 	}, nil
 
 }
@@ -97,7 +101,7 @@ func (t *respondChallenges) run(isAtlasDeployed bool) error {
 	}
 
 	// Print the gas info
-	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -108,13 +112,15 @@ func (t *respondChallenges) run(isAtlasDeployed bool) error {
 	opts.GasLimit = gasInfo.SafeGasLimit
 
 	// Respond to challenge
-	hash, err := trustednode.DecideChallenge(t.rp, nodeAccount.Address, opts)
+	hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return trustednode.DecideChallenge(t.rp, nodeAccount.Address, opts)
+	})
 	if err != nil {
 		return err
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	_, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 	if err != nil {
 		return err
 	}
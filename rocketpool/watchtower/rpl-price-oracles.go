@@ -0,0 +1,278 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+const (
+	ChainlinkAggregatorAbi string = `[
+		{
+		"inputs": [],
+		"name": "decimals",
+		"outputs": [
+			{
+			"internalType": "uint8",
+			"name": "",
+			"type": "uint8"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		},
+		{
+		"inputs": [],
+		"name": "latestRoundData",
+		"outputs": [
+			{
+			"internalType": "uint80",
+			"name": "roundId",
+			"type": "uint80"
+			},
+			{
+			"internalType": "int256",
+			"name": "answer",
+			"type": "int256"
+			},
+			{
+			"internalType": "uint256",
+			"name": "startedAt",
+			"type": "uint256"
+			},
+			{
+			"internalType": "uint256",
+			"name": "updatedAt",
+			"type": "uint256"
+			},
+			{
+			"internalType": "uint80",
+			"name": "answeredInRound",
+			"type": "uint80"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		}
+	]`
+
+	rplPriceOracle_OneInch         string = "1inch"
+	rplPriceOracle_ChainlinkPrefix string = "chainlink:"
+
+	rplPriceDivergenceAlertKey string = "watchtower-rpl-price-divergence"
+
+	// The default heartbeat of a Chainlink feed; rounds older than this are rejected as stale
+	defaultChainlinkHeartbeat time.Duration = 24 * time.Hour
+)
+
+type chainlinkRoundDataResponse struct {
+	RoundId         *big.Int `abi:"roundId"`
+	Answer          *big.Int `abi:"answer"`
+	StartedAt       *big.Int `abi:"startedAt"`
+	UpdatedAt       *big.Int `abi:"updatedAt"`
+	AnsweredInRound *big.Int `abi:"answeredInRound"`
+}
+
+// A secondary source of the RPL/ETH price
+type rplPriceOracle struct {
+	name     string
+	getPrice func(client *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error)
+}
+
+// Get the secondary RPL price oracles from the Smartnode config
+func getRplPriceOracles(cfg *config.RocketPoolConfig) ([]rplPriceOracle, error) {
+	oracles := []rplPriceOracle{}
+	for _, entry := range splitTaskList(cfg.Smartnode.WatchtowerPriceOracles.Value.(string)) {
+		switch {
+		case strings.EqualFold(entry, rplPriceOracle_OneInch):
+			oracleAddress := common.HexToAddress(cfg.Smartnode.GetOneInchOracleAddress())
+			rplAddress := common.HexToAddress(cfg.Smartnode.GetRplTokenAddress())
+			oracles = append(oracles, rplPriceOracle{
+				name: entry,
+				getPrice: func(client *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+					return getOneInchRplPrice(client, oracleAddress, rplAddress, opts)
+				},
+			})
+
+		case strings.HasPrefix(strings.ToLower(entry), rplPriceOracle_ChainlinkPrefix):
+			// The entry is the feed address, optionally followed by its heartbeat (e.g. chainlink:<address>:1h)
+			addressString, heartbeatString, hasHeartbeat := strings.Cut(strings.TrimSpace(entry[len(rplPriceOracle_ChainlinkPrefix):]), ":")
+			addressString = strings.TrimSpace(addressString)
+			if !common.IsHexAddress(addressString) {
+				return nil, fmt.Errorf("invalid Chainlink price feed address [%s]", addressString)
+			}
+			heartbeat := defaultChainlinkHeartbeat
+			if hasHeartbeat {
+				var err error
+				heartbeat, err = time.ParseDuration(strings.TrimSpace(heartbeatString))
+				if err != nil || heartbeat <= 0 {
+					return nil, fmt.Errorf("invalid Chainlink price feed heartbeat [%s]", heartbeatString)
+				}
+			}
+			feedAddress := common.HexToAddress(addressString)
+			oracles = append(oracles, rplPriceOracle{
+				name: entry,
+				getPrice: func(client *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+					return getChainlinkRplPrice(client, feedAddress, heartbeat, opts)
+				},
+			})
+
+		default:
+			return nil, fmt.Errorf("unknown RPL price oracle [%s]", entry)
+		}
+	}
+	return oracles, nil
+}
+
+// Get the RPL price from the 1inch off-chain oracle
+func getOneInchRplPrice(client *rocketpool.RocketPool, oracleAddress common.Address, rplAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	oio, err := contracts.NewOneInchOracle(oracleAddress, client.Client)
+	if err != nil {
+		return nil, err
+	}
+	return oio.GetRateToEth(opts, rplAddress, true)
+}
+
+// Get the RPL price from a Chainlink-compatible price feed, scaled to 18 decimals.
+// Rounds that were last updated more than one heartbeat before the target block are rejected as stale.
+func getChainlinkRplPrice(client *rocketpool.RocketPool, feedAddress common.Address, heartbeat time.Duration, opts *bind.CallOpts) (*big.Int, error) {
+
+	// Construct the feed contract instance
+	parsed, err := abi.JSON(strings.NewReader(ChainlinkAggregatorAbi))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Chainlink aggregator ABI: %w", err)
+	}
+	feedContract := bind.NewBoundContract(feedAddress, parsed, client.Client, client.Client, client.Client)
+	feed := rocketpool.Contract{
+		Contract: feedContract,
+		Address:  &feedAddress,
+		ABI:      &parsed,
+		Client:   client.Client,
+	}
+
+	// Get the latest answer
+	decimals := new(uint8)
+	err = feed.Call(opts, decimals, "decimals")
+	if err != nil {
+		return nil, fmt.Errorf("error getting price feed decimals: %w", err)
+	}
+	response := chainlinkRoundDataResponse{}
+	err = feed.Call(opts, &response, "latestRoundData")
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest price feed round: %w", err)
+	}
+	if response.Answer == nil || response.Answer.Sign() <= 0 {
+		return nil, fmt.Errorf("price feed returned an invalid answer")
+	}
+
+	// Make sure the round is fresh
+	if response.UpdatedAt == nil || response.UpdatedAt.Sign() == 0 {
+		return nil, fmt.Errorf("price feed round %s is incomplete", response.RoundId)
+	}
+	if response.AnsweredInRound != nil && response.RoundId != nil && response.AnsweredInRound.Cmp(response.RoundId) < 0 {
+		return nil, fmt.Errorf("price feed round %s was answered in an older round (%s)", response.RoundId, response.AnsweredInRound)
+	}
+	header, err := client.Client.HeaderByNumber(context.Background(), opts.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the header of the target block: %w", err)
+	}
+	updatedAt := time.Unix(response.UpdatedAt.Int64(), 0)
+	blockTime := time.Unix(int64(header.Time), 0)
+	if age := blockTime.Sub(updatedAt); age > heartbeat {
+		return nil, fmt.Errorf("price feed round %s is stale: it was last updated at %s, %s before the target block (the heartbeat is %s)", response.RoundId, updatedAt, age, heartbeat)
+	}
+
+	// Scale it to 18 decimals
+	price := big.NewInt(0).Set(response.Answer)
+	if *decimals < 18 {
+		price.Mul(price, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(18-*decimals)), nil))
+	} else if *decimals > 18 {
+		price.Div(price, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(*decimals-18)), nil))
+	}
+	return price, nil
+
+}
+
+// Check the RPL price against each of the secondary oracles at the block. Returns false if any of them deviate from it by more than
+// the tolerance, or if none of them could be queried. Returns true if no secondary oracles are configured.
+func (t *submitRplPrice) checkRplPriceOracles(blockNumber uint64, rplPrice *big.Int) (bool, error) {
+
+	oracles, err := getRplPriceOracles(t.cfg)
+	if err != nil {
+		return false, err
+	}
+	if len(oracles) == 0 {
+		return true, nil
+	}
+
+	// Get a client with the block number available
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(int64(blockNumber)),
+	}
+	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, opts.BlockNumber)
+	if err != nil {
+		return false, err
+	}
+
+	// Compare against each oracle
+	tolerance := t.cfg.Smartnode.WatchtowerPriceTolerance.Value.(float64)
+	deviations := []string{}
+	checked := 0
+	for _, oracle := range oracles {
+		price, err := oracle.getPrice(client, opts)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't get the RPL price from %s at block %d: %s", oracle.name, blockNumber, err.Error())
+			continue
+		}
+		checked++
+		deviation := getDeviationPercent(price, rplPrice)
+		t.log.Printlnf("RPL price from %s: %.6f ETH (%.4f%% deviation)", oracle.name, mathutils.RoundDown(eth.WeiToEth(price), 6), deviation)
+		if deviation > tolerance {
+			deviations = append(deviations, fmt.Sprintf("%s (%.6f ETH, %.4f%%)", oracle.name, mathutils.RoundDown(eth.WeiToEth(price), 6), deviation))
+		}
+	}
+
+	// Make sure they all agree
+	var message string
+	if checked == 0 {
+		message = fmt.Sprintf("None of the secondary RPL price oracles could be queried for block %d, so the RPL price of %.6f ETH couldn't be cross-validated.", blockNumber, mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
+	} else if len(deviations) > 0 {
+		t.log.Println("=== RPL PRICE DIVERGENCE DETECTED ===")
+		t.log.Printlnf("RPL price for block %d: %.6f ETH", blockNumber, mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
+		for _, deviation := range deviations {
+			t.log.Printlnf("\t%s", deviation)
+		}
+		t.log.Println("=====================================")
+		message = fmt.Sprintf("The RPL price of %.6f ETH for block %d deviates from secondary oracles by more than %.4f%%: %v", mathutils.RoundDown(eth.WeiToEth(rplPrice), 6), blockNumber, tolerance, deviations)
+	} else {
+		t.log.Printlnf("RPL price is consistent with %d secondary oracle(s).", checked)
+		t.alerter.Resolve(rplPriceDivergenceAlertKey)
+		return true, nil
+	}
+
+	err = t.alerter.Publish(alerting.Alert{
+		Key:      rplPriceDivergenceAlertKey,
+		Severity: alerting.Severity_Critical,
+		Title:    "RPL price could not be cross-validated",
+		Message:  message,
+	})
+	if err != nil {
+		t.errLog.Println(err)
+	}
+	return false, nil
+
+}
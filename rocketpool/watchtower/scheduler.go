@@ -0,0 +1,250 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/taskmetrics"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Names of the tasks that aren't tracked as duties
+const (
+	taskName_GenerateRewardsTree  string = "generate-rewards-tree"
+	taskName_RespondChallenges    string = "respond-challenges"
+	taskName_CancelBondReductions string = "cancel-bond-reductions"
+	taskName_CheckSoloMigrations  string = "check-solo-migrations"
+	taskName_VerifyRewardsTrees   string = "verify-rewards-trees"
+	taskName_ProcessPenalties     string = "process-penalties"
+	taskName_VoteOnProposals      string = "vote-on-proposals"
+
+	// Not a scheduled task, but its duration is recorded like one
+	taskName_UpdateNetworkState string = "update-network-state"
+)
+
+// The information about the chain that is provided to each task when it runs
+type taskContext struct {
+	isOnOdao        bool
+	latestBlock     beacon.BeaconBlock
+	isAtlasDeployed bool
+
+	// Only set if the node is on the Oracle DAO
+	state *state.NetworkState
+}
+
+// A task that the scheduler runs periodically
+type scheduledTask struct {
+	name     string
+	interval time.Duration
+
+	// The interval the task was added with, before any override
+	defaultInterval time.Duration
+
+	// True if the task should only run when the node is on the Oracle DAO
+	odaoOnly bool
+
+	// True if the task needs the network state or the Atlas flag
+	usesNetworkState bool
+
+	run     func(ctx *taskContext) error
+	nextRun time.Time
+}
+
+// Runs the watchtower tasks on their own intervals, with a random delay after each run
+type taskScheduler struct {
+	log               *log.ColorLogger
+	jitter            time.Duration
+	intervalOverrides map[string]time.Duration
+	disabledTasks     map[string]bool
+	tasks             []*scheduledTask
+
+	// The source of the jitter, seeded for each scheduler so nodes started at the same time don't run tasks in lockstep
+	random *rand.Rand
+}
+
+// Create a new task scheduler from the Smartnode config
+func newTaskScheduler(cfg *config.RocketPoolConfig, logger *log.ColorLogger) *taskScheduler {
+	s := &taskScheduler{
+		log:           logger,
+		disabledTasks: map[string]bool{},
+		tasks:         []*scheduledTask{},
+		random:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	s.loadTiming(cfg)
+
+	// Parse the disabled tasks
+	for _, name := range splitTaskList(cfg.Smartnode.WatchtowerDisabledTasks.Value.(string)) {
+		s.disabledTasks[name] = true
+	}
+
+	return s
+}
+
+// Load the jitter and the interval overrides from the Smartnode config
+func (s *taskScheduler) loadTiming(cfg *config.RocketPoolConfig) {
+	s.jitter = time.Duration(cfg.Smartnode.WatchtowerTaskJitter.Value.(uint64)) * time.Second
+	s.intervalOverrides = map[string]time.Duration{}
+
+	// Parse the interval overrides
+	for _, entry := range splitTaskList(cfg.Smartnode.WatchtowerTaskIntervals.Value.(string)) {
+		name, intervalString, found := strings.Cut(entry, "=")
+		if !found {
+			s.log.Printlnf("WARNING: ignoring watchtower task interval [%s] because it isn't in the form task=interval.", entry)
+			continue
+		}
+		name = strings.TrimSpace(name)
+		interval, err := time.ParseDuration(strings.TrimSpace(intervalString))
+		if err != nil || interval <= 0 {
+			s.log.Printlnf("WARNING: ignoring watchtower task interval [%s] because [%s] isn't a valid duration.", entry, intervalString)
+			continue
+		}
+		s.intervalOverrides[name] = interval
+	}
+}
+
+// Apply the jitter and interval overrides from a reloaded config. Tasks that now have a shorter interval are rescheduled
+// so they don't wait out the rest of their old one.
+func (s *taskScheduler) reload(cfg *config.RocketPoolConfig) {
+	s.loadTiming(cfg)
+	now := time.Now()
+	for _, task := range s.tasks {
+		task.interval = task.defaultInterval
+		if override, exists := s.intervalOverrides[task.name]; exists {
+			task.interval = override
+		}
+		if latest := now.Add(task.interval); task.nextRun.After(latest) {
+			task.nextRun = latest
+		}
+	}
+	s.checkTaskNames()
+}
+
+// Add a task to the scheduler, unless it has been disabled. It will be due immediately.
+func (s *taskScheduler) addTask(name string, interval time.Duration, odaoOnly bool, usesNetworkState bool, run func(ctx *taskContext) error) {
+	defaultInterval := interval
+	if s.disabledTasks[name] {
+		s.log.Printlnf("NOTE: the %s task has been disabled and will not be run.", name)
+		return
+	}
+	if override, exists := s.intervalOverrides[name]; exists {
+		interval = override
+	}
+
+	s.tasks = append(s.tasks, &scheduledTask{
+		name:             name,
+		interval:         interval,
+		defaultInterval:  defaultInterval,
+		odaoOnly:         odaoOnly,
+		usesNetworkState: usesNetworkState,
+		run:              run,
+		nextRun:          time.Now(),
+	})
+}
+
+// Warn about any tasks in the config that don't exist, which are usually typos
+func (s *taskScheduler) checkTaskNames() {
+	known := map[string]bool{}
+	for _, task := range s.tasks {
+		known[task.name] = true
+	}
+	for name := range s.intervalOverrides {
+		if !known[name] && !s.disabledTasks[name] {
+			s.log.Printlnf("WARNING: an interval was set for the unknown watchtower task [%s].", name)
+		}
+	}
+	for name := range s.disabledTasks {
+		if !known[name] && !isKnownDisabledTask(name) {
+			s.log.Printlnf("WARNING: the unknown watchtower task [%s] was disabled.", name)
+		}
+	}
+}
+
+// Get the tasks that are due to run
+func (s *taskScheduler) getDueTasks(isOnOdao bool) []*scheduledTask {
+	now := time.Now()
+	dueTasks := []*scheduledTask{}
+	for _, task := range s.tasks {
+		if task.odaoOnly && !isOnOdao {
+			continue
+		}
+		if !now.Before(task.nextRun) {
+			dueTasks = append(dueTasks, task)
+		}
+	}
+	return dueTasks
+}
+
+// Run a task as part of a pass of the task loop and schedule its next run
+func (s *taskScheduler) runTask(task *scheduledTask, ctx *taskContext, pass *taskmetrics.Pass) {
+	err := pass.RunTask(task.name, func() error {
+		return task.run(ctx)
+	})
+	if err != nil {
+		taskLog := s.log.WithTask(task.name)
+		taskLog.Println(fmt.Errorf("error running %s task: %w", task.name, err))
+	}
+	task.nextRun = time.Now().Add(task.interval + s.getJitter())
+}
+
+// Get the amount of time until the next task is due, which will be at least the given minimum
+func (s *taskScheduler) getTimeUntilNextRun(minimum time.Duration) time.Duration {
+	if len(s.tasks) == 0 {
+		return minimum
+	}
+	next := s.tasks[0].nextRun
+	for _, task := range s.tasks[1:] {
+		if task.nextRun.Before(next) {
+			next = task.nextRun
+		}
+	}
+	wait := time.Until(next)
+	if wait < minimum {
+		return minimum
+	}
+	return wait
+}
+
+// Get a random delay to add to a task's interval
+func (s *taskScheduler) getJitter() time.Duration {
+	if s.jitter <= 0 {
+		return 0
+	}
+	return time.Duration(s.random.Int63n(int64(s.jitter) + 1))
+}
+
+// Split a comma-separated list of tasks from the config
+func splitTaskList(list string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Check if a disabled task name refers to a real task that was skipped when it was added
+func isKnownDisabledTask(name string) bool {
+	switch name {
+	case dutyName_SubmitRplPrice,
+		dutyName_SubmitNetworkBalances,
+		dutyName_SubmitRewardsTree,
+		dutyName_SubmitScrubMinipools,
+		dutyName_DissolveTimedOutMinipools,
+		taskName_GenerateRewardsTree,
+		taskName_RespondChallenges,
+		taskName_CancelBondReductions,
+		taskName_CheckSoloMigrations,
+		taskName_VerifyRewardsTrees,
+		taskName_ProcessPenalties,
+		taskName_VoteOnProposals:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The number of records kept for each duty
+const submissionLedgerRecordsPerDuty int = 64
+
+// The number of discrepancies kept in total
+const submissionLedgerMaxDiscrepancies int = 256
+
+// Records the values the watchtower has submitted for each period and saves them to disk, so a restarted watchtower
+// doesn't submit the same value twice or contradict a value it has already submitted
+type submissionLedger struct {
+	path          string
+	ec            rocketpool.ExecutionClient
+	errLog        log.ColorLogger
+	records       []api.WatchtowerSubmissionRecord
+	discrepancies []api.WatchtowerSubmissionDiscrepancy
+	lock          *sync.Mutex
+}
+
+// Create a new submission ledger, loading the records from previous runs
+func newSubmissionLedger(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, errorLogger log.ColorLogger) (*submissionLedger, error) {
+	l := &submissionLedger{
+		path:   cfg.Smartnode.GetWatchtowerSubmissionsPath(true),
+		ec:     ec,
+		errLog: errorLogger,
+		lock:   &sync.Mutex{},
+	}
+
+	bytes, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return l, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading watchtower submission records %s: %w", l.path, err)
+	}
+	var recordFile api.WatchtowerSubmissionRecordFile
+	err = json.Unmarshal(bytes, &recordFile)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing watchtower submission records %s: %w", l.path, err)
+	}
+	l.records = recordFile.Records
+	l.discrepancies = recordFile.Discrepancies
+	return l, nil
+}
+
+// Check a value against the one previously submitted for the same period. Returns true if the same value has already been
+// submitted (or is still pending) so it shouldn't be submitted again, and an error if it contradicts a previous submission.
+// A previous submission whose transaction failed or was dropped doesn't prevent a new one.
+func (l *submissionLedger) check(duty string, period uint64, value string) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var previous *api.WatchtowerSubmissionRecord
+	for i := len(l.records) - 1; i >= 0; i-- {
+		if l.records[i].Duty == duty && l.records[i].Period == period {
+			previous = &l.records[i]
+			break
+		}
+	}
+	if previous == nil {
+		return false, nil
+	}
+
+	isLive, err := l.isTransactionLive(previous.TxHash)
+	if err != nil {
+		return false, fmt.Errorf("error checking the status of previous %s submission %s: %w", duty, previous.TxHash.Hex(), err)
+	}
+	if previous.Value == value {
+		return isLive, nil
+	}
+
+	// The value has changed since it was submitted
+	l.recordDiscrepancy(duty, period, previous, value, isLive)
+	if isLive {
+		return false, fmt.Errorf("the %s value calculated for %d (%s) contradicts the value already submitted in transaction %s (%s), refusing to submit it", duty, period, value, previous.TxHash.Hex(), previous.Value)
+	}
+	return false, nil
+}
+
+// Record a value that was submitted for a period, as soon as its transaction has been sent
+func (l *submissionLedger) record(duty string, period uint64, value string, txHash common.Hash) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.records = append(l.records, api.WatchtowerSubmissionRecord{
+		Duty:          duty,
+		Period:        period,
+		Value:         value,
+		ValueHash:     crypto.Keccak256Hash([]byte(value)),
+		TxHash:        txHash,
+		SubmittedTime: time.Now(),
+	})
+
+	// Only keep the latest records for each duty
+	counts := map[string]int{}
+	kept := []api.WatchtowerSubmissionRecord{}
+	for i := len(l.records) - 1; i >= 0; i-- {
+		record := l.records[i]
+		if counts[record.Duty] < submissionLedgerRecordsPerDuty {
+			kept = append([]api.WatchtowerSubmissionRecord{record}, kept...)
+			counts[record.Duty]++
+		}
+	}
+	l.records = kept
+
+	if err := l.save(); err != nil {
+		l.errLog.Println(fmt.Errorf("error saving watchtower submission records: %w", err))
+	}
+}
+
+// Point the records of a submission at the transaction that replaced it, so a restarted watchtower checks the replacement
+// instead of the original (which will have been dropped)
+func (l *submissionLedger) replaceTransaction(originalHash common.Hash, replacementHash common.Hash) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	replaced := false
+	for i := range l.records {
+		if l.records[i].TxHash == originalHash {
+			l.records[i].TxHash = replacementHash
+			replaced = true
+		}
+	}
+	if !replaced {
+		return
+	}
+
+	if err := l.save(); err != nil {
+		l.errLog.Println(fmt.Errorf("error saving watchtower submission records: %w", err))
+	}
+}
+
+// Record a value that differs from the one submitted for the same period; repeats of the same discrepancy are only recorded once
+func (l *submissionLedger) recordDiscrepancy(duty string, period uint64, previous *api.WatchtowerSubmissionRecord, value string, blocked bool) {
+	for _, discrepancy := range l.discrepancies {
+		if discrepancy.Duty == duty && discrepancy.Period == period && discrepancy.SubmittedTxHash == previous.TxHash && discrepancy.CalculatedValue == value {
+			return
+		}
+	}
+	l.errLog.Printlnf("WARNING: the %s value calculated for %d (%s) differs from the value submitted in transaction %s (%s).", duty, period, value, previous.TxHash.Hex(), previous.Value)
+	l.discrepancies = append(l.discrepancies, api.WatchtowerSubmissionDiscrepancy{
+		Duty:              duty,
+		Period:            period,
+		SubmittedValue:    previous.Value,
+		SubmittedTxHash:   previous.TxHash,
+		CalculatedValue:   value,
+		DetectedTime:      time.Now(),
+		SubmissionBlocked: blocked,
+	})
+	if len(l.discrepancies) > submissionLedgerMaxDiscrepancies {
+		l.discrepancies = l.discrepancies[len(l.discrepancies)-submissionLedgerMaxDiscrepancies:]
+	}
+
+	if err := l.save(); err != nil {
+		l.errLog.Println(fmt.Errorf("error saving watchtower submission records: %w", err))
+	}
+}
+
+// Check whether a submission's transaction succeeded or is still waiting to be included in a block
+func (l *submissionLedger) isTransactionLive(txHash common.Hash) (bool, error) {
+	receipt, err := l.ec.TransactionReceipt(context.Background(), txHash)
+	if err == nil {
+		return receipt.Status == types.ReceiptStatusSuccessful, nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return false, err
+	}
+
+	// It hasn't been included in a block; it's only live if it's still in the mempool
+	_, _, err = l.ec.TransactionByHash(context.Background(), txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Save the records to disk
+func (l *submissionLedger) save() error {
+	recordFile := api.WatchtowerSubmissionRecordFile{
+		Records:       l.records,
+		Discrepancies: l.discrepancies,
+	}
+	bytes, err := json.Marshal(recordFile)
+	if err != nil {
+		return fmt.Errorf("error serializing submission records: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(l.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating watchtower folder: %w", err)
+	}
+	err = os.WriteFile(l.path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", l.path, err)
+	}
+	return nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"os"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -20,6 +22,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/legacy"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
@@ -32,19 +35,26 @@ import (
 
 // Submit network balances task
 type submitNetworkBalances struct {
-	c          *cli.Context
-	log        log.ColorLogger
-	errLog     log.ColorLogger
-	cfg        *config.RocketPoolConfig
-	w          *wallet.Wallet
-	ec         rocketpool.ExecutionClient
-	rp         *rocketpool.RocketPool
-	bc         beacon.Client
-	lock       *sync.Mutex
-	isRunning  bool
-	legacyImpl *legacy.SubmitNetworkBalances
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	w           *wallet.Wallet
+	ec          rocketpool.ExecutionClient
+	rp          *rocketpool.RocketPool
+	bc          beacon.Client
+	lock        *sync.Mutex
+	isRunning   bool
+	legacyImpl  *legacy.SubmitNetworkBalances
+	dutyStatus  *dutyStatusTracker
+	submissions *submissionLedger
+	alerter     *alerting.Alerter
+	nonces      *nonceManager
 }
 
+// Alert keys
+const balanceDeviationAlertKey string = "watchtower-balance-deviation"
+
 // Network balance info
 type networkBalances struct {
 	Block                 uint64
@@ -57,13 +67,22 @@ type networkBalances struct {
 	RETHSupply            *big.Int
 	NodeCreditBalance     *big.Int
 }
+
+// Balances submitted for a block by another Oracle DAO member
+type submittedBalances struct {
+	Member     common.Address
+	TotalEth   *big.Int
+	StakingEth *big.Int
+	RETHSupply *big.Int
+}
+
 type minipoolBalanceDetails struct {
 	IsStaking   bool
 	UserBalance *big.Int
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, dutyStatus *dutyStatusTracker, submissions *submissionLedger, nonces *nonceManager) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -86,9 +105,13 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	if err != nil {
 		return nil, err
 	}
+	alerter, err := services.GetAlerter(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Legacy implementation for prior to the changeover
-	legacyImpl, err := legacy.NewSubmitNetworkBalances(c, logger, getWatchtowerMaxFee(cfg), getWatchtowerPrioFee(cfg))
+	legacyImpl, err := legacy.NewSubmitNetworkBalances(c, logger, getWatchtowerMaxFee(c, cfg, logger), getWatchtowerPrioFee(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("error creating legacy balance reporting implementation: %w", err)
 	}
@@ -96,17 +119,21 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitNetworkBalances{
-		c:          c,
-		log:        logger,
-		errLog:     errorLogger,
-		cfg:        cfg,
-		w:          w,
-		ec:         ec,
-		rp:         rp,
-		bc:         bc,
-		lock:       lock,
-		isRunning:  false,
-		legacyImpl: legacyImpl,
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		cfg:         cfg,
+		w:           w,
+		ec:          ec,
+		rp:          rp,
+		bc:          bc,
+		lock:        lock,
+		isRunning:   false,
+		legacyImpl:  legacyImpl,
+		dutyStatus:  dutyStatus,
+		submissions: submissions,
+		alerter:     alerter,
+		nonces:      nonces,
 	}, nil
 
 }
@@ -141,6 +168,8 @@ func (t *submitNetworkBalances) run(state *state.NetworkState, isAtlasDeployed b
 	blockNumber := blockNumberBig.Uint64()
 
 	// Check if a submission needs to be made
+	consensusBalances := getBalancesConsensusValue(state.NetworkDetails.TotalETHBalance, state.NetworkDetails.StakingETHBalance, state.NetworkDetails.TotalRETHSupply)
+	t.dutyStatus.checkConsensus(dutyName_SubmitNetworkBalances, state.NetworkDetails.BalancesBlock.Uint64(), consensusBalances)
 	if blockNumber <= state.NetworkDetails.BalancesBlock.Uint64() {
 		return nil
 	}
@@ -188,7 +217,9 @@ func (t *submitNetworkBalances) run(state *state.NetworkState, isAtlasDeployed b
 	}
 	t.lock.Unlock()
 
+	backgroundTasks.Add(1)
 	go func() {
+		defer backgroundTasks.Done()
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -238,6 +269,26 @@ func (t *submitNetworkBalances) run(state *state.NetworkState, isAtlasDeployed b
 			t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", blockNumber)
 		}
 
+		// Compare the balances with the ones other Oracle DAO members have already submitted
+		isConsistent, err := t.checkBalanceConsensus(nodeAccount.Address, blockNumber, balances)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s %w", logPrefix, err))
+			return
+		}
+		forceRequestPath := t.cfg.Smartnode.GetForceBalanceSubmissionRequestPath(blockNumber, true)
+		if !isConsistent && t.cfg.Smartnode.WatchtowerWithholdDeviatingBalances.Value == true {
+			_, err = os.Stat(forceRequestPath)
+			if os.IsNotExist(err) {
+				t.log.Printlnf("%s Withholding balances for block %d until they are reviewed. Run `rocketpool odao force-balance-submission` to submit them anyway.", logPrefix, blockNumber)
+				t.dutyStatus.recordSubmissionError(dutyName_SubmitNetworkBalances, fmt.Errorf("balances for block %d deviate from other Oracle DAO members and are being withheld for review", blockNumber))
+				t.lock.Lock()
+				t.isRunning = false
+				t.lock.Unlock()
+				return
+			}
+			t.log.Printlnf("%s Found a request to force the submission of balances for block %d, submitting them anyway.", logPrefix, blockNumber)
+		}
+
 		// Log
 		t.log.Println("Submitting balances...")
 
@@ -247,8 +298,15 @@ func (t *submitNetworkBalances) run(state *state.NetworkState, isAtlasDeployed b
 			return
 		}
 
+		// Clean up the force request now that it's been used
+		err = os.Remove(forceRequestPath)
+		if err != nil && !os.IsNotExist(err) {
+			t.log.Printlnf("%s WARNING: couldn't remove the force submission request for block %d: %s", logPrefix, blockNumber, err.Error())
+		}
+
 		// Log and return
 		t.log.Printlnf("%s Balance report complete.", logPrefix)
+		t.dutyStatus.recordSubmissionSuccess(dutyName_SubmitNetworkBalances)
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
@@ -262,11 +320,19 @@ func (t *submitNetworkBalances) run(state *state.NetworkState, isAtlasDeployed b
 func (t *submitNetworkBalances) handleError(err error) {
 	t.errLog.Println(err)
 	t.errLog.Println("*** Balance report failed. ***")
+	t.dutyStatus.recordSubmissionError(dutyName_SubmitNetworkBalances, err)
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
 }
 
+// Check if a balance report is running in the background
+func (t *submitNetworkBalances) isPending() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.isRunning
+}
+
 // Check whether balances for a block has already been submitted by the node
 func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Address, blockNumber uint64) (bool, error) {
 
@@ -280,13 +346,7 @@ func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Add
 func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress common.Address, blockNumber uint64, balances networkBalances) (bool, error) {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Sub(totalEth, balances.NodeCreditBalance)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := getTotalEth(balances)
 
 	blockNumberBuf := make([]byte, 32)
 	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
@@ -304,6 +364,136 @@ func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress co
 
 }
 
+// Check if the balances are within the tolerance of at least one of the submissions other Oracle DAO members have made for the same block.
+// Returns true if there aren't any other submissions yet.
+func (t *submitNetworkBalances) checkBalanceConsensus(nodeAddress common.Address, blockNumber uint64, balances networkBalances) (bool, error) {
+
+	submissions, err := t.getSubmittedBalances(nodeAddress, blockNumber)
+	if err != nil {
+		return false, fmt.Errorf("error getting balances submitted by other Oracle DAO members: %w", err)
+	}
+	if len(submissions) == 0 {
+		t.log.Printlnf("No other Oracle DAO members have submitted balances for block %d yet.", blockNumber)
+		return true, nil
+	}
+
+	// Compare against each submission
+	tolerance := t.cfg.Smartnode.WatchtowerBalanceTolerance.Value.(float64)
+	totalEth := getTotalEth(balances)
+	deviations := []string{}
+	for _, submission := range submissions {
+		totalEthDeviation := getDeviationPercent(totalEth, submission.TotalEth)
+		stakingEthDeviation := getDeviationPercent(balances.MinipoolsStaking, submission.StakingEth)
+		rethSupplyDeviation := getDeviationPercent(balances.RETHSupply, submission.RETHSupply)
+		if totalEthDeviation <= tolerance && stakingEthDeviation <= tolerance && rethSupplyDeviation <= tolerance {
+			t.log.Printlnf("Balances are consistent with the ones submitted by %s.", submission.Member.Hex())
+			t.alerter.Resolve(balanceDeviationAlertKey)
+			return true, nil
+		}
+		deviations = append(deviations, fmt.Sprintf("%s (total ETH %.4f%%, staking ETH %.4f%%, rETH supply %.4f%%)", submission.Member.Hex(), totalEthDeviation, stakingEthDeviation, rethSupplyDeviation))
+	}
+
+	// None of the submissions agree
+	t.log.Println("=== BALANCE DEVIATION DETECTED ===")
+	t.log.Printlnf("Balances for block %d deviate from every other Oracle DAO submission by more than %.4f%%:", blockNumber, tolerance)
+	for _, deviation := range deviations {
+		t.log.Printlnf("\t%s", deviation)
+	}
+	t.log.Println("==================================")
+	err = t.alerter.Publish(alerting.Alert{
+		Key:      balanceDeviationAlertKey,
+		Severity: alerting.Severity_Critical,
+		Title:    "Network balances deviate from other Oracle DAO members",
+		Message:  fmt.Sprintf("The balances calculated for block %d deviate from every other Oracle DAO submission by more than %.4f%%: %v", blockNumber, tolerance, deviations),
+	})
+	if err != nil {
+		t.errLog.Println(err)
+	}
+	return false, nil
+
+}
+
+// Get the balances that other Oracle DAO members have submitted for a block
+func (t *submitNetworkBalances) getSubmittedBalances(nodeAddress common.Address, blockNumber uint64) ([]submittedBalances, error) {
+
+	rocketNetworkBalances, err := t.rp.GetContract("rocketNetworkBalances", nil)
+	if err != nil {
+		return nil, err
+	}
+	balancesSubmittedEvent := rocketNetworkBalances.ABI.Events["BalancesSubmitted"]
+	eventLogInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	// Submissions for the block can only be made after it
+	addressFilter := []common.Address{*rocketNetworkBalances.Address}
+	topicFilter := [][]common.Hash{{balancesSubmittedEvent.ID}}
+	logs, err := eth.GetLogs(t.rp, addressFilter, topicFilter, big.NewInt(int64(eventLogInterval)), big.NewInt(0).SetUint64(blockNumber), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions := []submittedBalances{}
+	for _, log := range logs {
+		// Topic 0 is the event, topic 1 is the "from" address
+		member := common.BytesToAddress(log.Topics[1].Bytes())
+		if member == nodeAddress {
+			continue
+		}
+
+		values := make(map[string]interface{})
+		err = balancesSubmittedEvent.Inputs.UnpackIntoMap(values, log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding balance submission from %s in transaction %s: %w", member.Hex(), log.TxHash.Hex(), err)
+		}
+		block, blockOk := values["block"].(*big.Int)
+		totalEth, totalEthOk := values["totalEth"].(*big.Int)
+		stakingEth, stakingEthOk := values["stakingEth"].(*big.Int)
+		rethSupply, rethSupplyOk := values["rethSupply"].(*big.Int)
+		if !blockOk || !totalEthOk || !stakingEthOk || !rethSupplyOk {
+			return nil, fmt.Errorf("balance submission from %s in transaction %s is missing fields", member.Hex(), log.TxHash.Hex())
+		}
+		if block.Uint64() != blockNumber {
+			continue
+		}
+		submissions = append(submissions, submittedBalances{
+			Member:     member,
+			TotalEth:   totalEth,
+			StakingEth: stakingEth,
+			RETHSupply: rethSupply,
+		})
+	}
+	return submissions, nil
+
+}
+
+// Get the difference between a value and a reference value, as a percentage of the reference value
+func getDeviationPercent(value *big.Int, reference *big.Int) float64 {
+	if reference.Sign() == 0 {
+		if value.Sign() == 0 {
+			return 0
+		}
+		return 100
+	}
+	diff := big.NewInt(0).Sub(value, reference)
+	diff.Abs(diff)
+	deviation, _ := big.NewFloat(0).Quo(new(big.Float).SetInt(diff), new(big.Float).SetInt(reference)).Float64()
+	return deviation * 100
+}
+
+// Calculate the total ETH balance to submit
+func getTotalEth(balances networkBalances) *big.Int {
+	totalEth := big.NewInt(0)
+	totalEth.Sub(totalEth, balances.NodeCreditBalance)
+	totalEth.Add(totalEth, balances.DepositPool)
+	totalEth.Add(totalEth, balances.MinipoolsTotal)
+	totalEth.Add(totalEth, balances.RETHContract)
+	totalEth.Add(totalEth, balances.DistributorShareTotal)
+	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	return totalEth
+}
+
 // Prints a message to the log
 func (t *submitNetworkBalances) printMessage(message string) {
 	t.log.Println(message)
@@ -506,13 +696,7 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(mpd *rpstate.NativeMin
 func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Sub(totalEth, balances.NodeCreditBalance)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := getTotalEth(balances)
 
 	ratio := eth.WeiToEth(totalEth) / eth.WeiToEth(balances.RETHSupply)
 	t.log.Printlnf("Total ETH = %s\n", totalEth)
@@ -521,6 +705,17 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	// Log
 	t.log.Printlnf("Submitting network balances for block %d...", balances.Block)
 
+	// Make sure these balances haven't been submitted already and don't contradict a previous submission
+	value := getBalancesConsensusValue(totalEth, balances.MinipoolsStaking, balances.RETHSupply)
+	alreadySubmitted, err := t.submissions.check(dutyName_SubmitNetworkBalances, balances.Block, value)
+	if err != nil {
+		return err
+	}
+	if alreadySubmitted {
+		t.log.Printlnf("Network balances for block %d have already been submitted.", balances.Block)
+		return nil
+	}
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -534,7 +729,7 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	}
 
 	// Print the gas info
-	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -545,16 +740,21 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	opts.GasLimit = gasInfo.SafeGasLimit
 
 	// Submit balances
-	hash, err := network.SubmitBalances(t.rp, balances.Block, totalEth, balances.MinipoolsStaking, balances.RETHSupply, opts)
+	hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return network.SubmitBalances(t.rp, balances.Block, totalEth, balances.MinipoolsStaking, balances.RETHSupply, opts)
+	})
 	if err != nil {
 		return fmt.Errorf("error submitting balances: %w", err)
 	}
+	t.submissions.record(dutyName_SubmitNetworkBalances, balances.Block, value, hash)
 
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Print TX info and wait for it to be included in a block; if it's replaced, the nonce manager updates its record
+	hash, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 	if err != nil {
 		return fmt.Errorf("error waiting for transaction: %w", err)
 	}
+	t.dutyStatus.recordTransaction(dutyName_SubmitNetworkBalances, t.ec, hash)
+	t.dutyStatus.recordSubmittedValue(dutyName_SubmitNetworkBalances, balances.Block, value)
 
 	// Log
 	t.log.Printlnf("Successfully submitted network balances for block %d.", balances.Block)
@@ -563,3 +763,8 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	return nil
 
 }
+
+// Get the representation of a set of balances used to compare a submission with the Oracle DAO's consensus
+func getBalancesConsensusValue(totalEth *big.Int, stakingEth *big.Int, rethSupply *big.Int) string {
+	return fmt.Sprintf("%s/%s/%s", totalEth.String(), stakingEth.String(), rethSupply.String())
+}
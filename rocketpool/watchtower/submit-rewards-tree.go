@@ -11,10 +11,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/klauspost/compress/zstd"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -22,7 +22,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/storage"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/treeworker"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -30,7 +32,6 @@ import (
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
-	"github.com/web3-storage/go-w3s-client"
 )
 
 // Submit rewards Merkle Tree task
@@ -47,10 +48,14 @@ type submitRewardsTree struct {
 	isRunning        bool
 	generationPrefix string
 	m                *state.NetworkStateManager
+	dutyStatus       *dutyStatusTracker
+	submissions      *submissionLedger
+	nonces           *nonceManager
+	treeWorker       *treeworker.Spawner
 }
 
 // Create submit rewards Merkle Tree task
-func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*submitRewardsTree, error) {
+func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, dutyStatus *dutyStatusTracker, submissions *submissionLedger, nonces *nonceManager) (*submitRewardsTree, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -73,6 +78,10 @@ func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger lo
 	if err != nil {
 		return nil, err
 	}
+	treeWorker, err := treeworker.NewSpawner(c)
+	if err != nil {
+		return nil, err
+	}
 
 	lock := &sync.Mutex{}
 	generator := &submitRewardsTree{
@@ -88,6 +97,10 @@ func newSubmitRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger lo
 		isRunning:        false,
 		generationPrefix: "[Merkle Tree]",
 		m:                m,
+		dutyStatus:       dutyStatus,
+		submissions:      submissions,
+		nonces:           nonces,
+		treeWorker:       treeWorker,
 	}
 
 	return generator, nil
@@ -158,6 +171,11 @@ func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, bea
 	currentIndex := state.NetworkDetails.RewardIndex
 	currentIndexBig := big.NewInt(0).SetUint64(currentIndex)
 
+	// Check if this node's last tree matched the one the Oracle DAO reached consensus on
+	if nodeTrusted {
+		t.checkTreeConsensus(currentIndex)
+	}
+
 	// Check if rewards generation is already running
 	t.lock.Lock()
 	if t.isRunning {
@@ -189,6 +207,11 @@ func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, bea
 			return nil
 		}
 
+		// Wait for the network fee to drop if the submission can be deferred
+		if shouldDeferSubmission(t.c, t.cfg, t.log, endTime) {
+			return nil
+		}
+
 		t.log.Printlnf("Merkle rewards tree for interval %d already exists at %s, attempting to resubmit...", currentIndex, rewardsTreePath)
 
 		// Deserialize the file
@@ -197,16 +220,23 @@ func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, bea
 			return fmt.Errorf("Error reading rewards tree file: %w", err)
 		}
 
-		proofWrapper := new(rprewards.RewardsFile)
-		err = json.Unmarshal(wrapperBytes, proofWrapper)
+		proofWrapper, err := rprewards.ParseRewardsFile(wrapperBytes)
 		if err != nil {
 			return fmt.Errorf("Error deserializing rewards tree file: %w", err)
 		}
 
+		// The uploaded file must always be JSON, so convert it if it was saved in the binary format
+		if rprewards.IsBinaryRewardsFile(wrapperBytes) {
+			wrapperBytes, err = json.Marshal(proofWrapper)
+			if err != nil {
+				return fmt.Errorf("Error serializing proof wrapper into JSON: %w", err)
+			}
+		}
+
 		// Upload the file
-		cid, err := t.uploadFileToWeb3Storage(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
+		cid, err := t.uploadFile(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
 		if err != nil {
-			return fmt.Errorf("Error uploading Merkle tree to Web3.Storage: %w", err)
+			return fmt.Errorf("Error uploading Merkle tree: %w", err)
 		}
 		t.log.Printlnf("Uploaded Merkle tree with CID %s", cid)
 
@@ -231,11 +261,19 @@ func (t *submitRewardsTree) run(nodeTrusted bool, state *state.NetworkState, bea
 func (t *submitRewardsTree) handleError(err error) {
 	t.errLog.Println(fmt.Errorf("%s %w", t.generationPrefix, err))
 	t.errLog.Println("*** Rewards tree generation failed. ***")
+	t.dutyStatus.recordSubmissionError(dutyName_SubmitRewardsTree, err)
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
 }
 
+// Check if a rewards tree is being generated in the background
+func (t *submitRewardsTree) isPending() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.isRunning
+}
+
 // Print a message from the tree generation goroutine
 func (t *submitRewardsTree) printMessage(message string) {
 	t.log.Printlnf("%s %s", t.generationPrefix, message)
@@ -247,14 +285,13 @@ func (t *submitRewardsTree) isExistingFileValid(rewardsTreePath string, interval
 	_, err := os.Stat(rewardsTreePath)
 	if !os.IsNotExist(err) {
 		// The file already exists, attempt to read it
-		var proofWrapper rprewards.RewardsFile
 		fileBytes, err := os.ReadFile(rewardsTreePath)
 		if err != nil {
 			t.log.Printlnf("WARNING: failed to read %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
 		}
 
-		err = json.Unmarshal(fileBytes, &proofWrapper)
+		proofWrapper, err := rprewards.ParseRewardsFile(fileBytes)
 		if err != nil {
 			t.log.Printlnf("WARNING: failed to deserialize %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
@@ -277,7 +314,9 @@ func (t *submitRewardsTree) isExistingFileValid(rewardsTreePath string, interval
 // Kick off the tree generation goroutine
 func (t *submitRewardsTree) generateTree(intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) {
 
+	backgroundTasks.Add(1)
 	go func() {
+		defer backgroundTasks.Done()
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -293,6 +332,8 @@ func (t *submitRewardsTree) generateTree(intervalsPassed time.Duration, nodeTrus
 		err = t.generateTreeImpl(client, intervalsPassed, nodeTrusted, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
 		if err != nil {
 			t.handleError(err)
+		} else {
+			t.dutyStatus.recordSubmissionSuccess(dutyName_SubmitRewardsTree)
 		}
 
 		t.lock.Lock()
@@ -311,77 +352,103 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 	}
 	t.log.Printlnf("Rewards checkpoint has passed, starting Merkle tree generation for interval %d in the background.\n%s Snapshot Beacon block = %d, EL block = %d, running from %s to %s", currentIndex, t.generationPrefix, snapshotBeaconBlock, elBlockIndex, startTime, endTime)
 
-	// Create a new state gen manager
-	mgr, err := state.NewNetworkStateManager(rp, t.cfg, rp.Client, t.bc, &t.log)
-	if err != nil {
-		return fmt.Errorf("error creating network state manager for EL block %d, Beacon slot %d: %w", elBlockIndex, snapshotBeaconBlock, err)
-	}
-
-	// Create a new state for the target block
-	state, err := mgr.GetStateForSlot(snapshotBeaconBlock)
-	if err != nil {
-		return fmt.Errorf("couldn't get network state for EL block %d, Beacon slot %d: %w", elBlockIndex, snapshotBeaconBlock, err)
-	}
-
-	// Generate the rewards file
-	treegen, err := rprewards.NewTreeGenerator(t.log, t.generationPrefix, rp, t.cfg, t.bc, currentIndex, startTime, endTime, snapshotBeaconBlock, snapshotElBlockHeader, uint64(intervalsPassed), state)
-	if err != nil {
-		return fmt.Errorf("Error creating Merkle tree generator: %w", err)
-	}
-	rewardsFile, err := treegen.GenerateTree()
-	if err != nil {
-		return fmt.Errorf("Error generating Merkle tree: %w", err)
-	}
-	for address, network := range rewardsFile.InvalidNetworkNodes {
-		t.printMessage(fmt.Sprintf("WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", address.Hex(), network))
-	}
-
-	// Serialize the minipool performance file
-	minipoolPerformanceBytes, err := json.Marshal(rewardsFile.MinipoolPerformanceFile)
-	if err != nil {
-		return fmt.Errorf("Error serializing minipool performance file into JSON: %w", err)
+	// Generate the rewards file, in a worker process if enabled so running out of memory can't take down the other duties
+	profiler := rprewards.NewProfiler(t.cfg, currentIndex)
+	defer profiler.SaveReport(t.log, t.generationPrefix)
+	var rewardsFile *rprewards.RewardsFile
+	var err error
+	if t.treeWorker.IsEnabled() {
+		rewardsFile, err = t.treeWorker.GenerateSnapshot(treeworker.Snapshot{
+			Index:           currentIndex,
+			StartTime:       startTime,
+			EndTime:         endTime,
+			ConsensusBlock:  snapshotBeaconBlock,
+			ExecutionBlock:  elBlockIndex,
+			IntervalsPassed: uint64(intervalsPassed),
+		}, t.generationPrefix, &t.log, profiler)
+	} else {
+		rewardsFile, err = rprewards.GenerateRewardsFileForSnapshot(t.log, t.generationPrefix, rp, t.cfg, t.bc, currentIndex, startTime, endTime, snapshotBeaconBlock, snapshotElBlockHeader, uint64(intervalsPassed), profiler)
 	}
-
-	// Write it to disk
-	err = os.WriteFile(minipoolPerformancePath, minipoolPerformanceBytes, 0644)
 	if err != nil {
-		return fmt.Errorf("Error saving minipool performance file to %s: %w", minipoolPerformancePath, err)
+		return err
 	}
 
-	// Upload it if this is an Oracle DAO node
+	// Save the minipool performance file, and upload it if this is an Oracle DAO node
 	if nodeTrusted {
-		t.printMessage("Uploading minipool performance file to Web3.Storage...")
-		minipoolPerformanceCid, err := t.uploadFileToWeb3Storage(minipoolPerformanceBytes, compressedMinipoolPerformancePath, "compressed minipool performance")
+		// Uploaded files are always JSON
+		var minipoolPerformanceBytes []byte
+		err = profiler.TimePhase(rprewards.ProfilePhase_Serialization, func() error {
+			minipoolPerformanceBytes, err = json.Marshal(rewardsFile.MinipoolPerformanceFile)
+			if err != nil {
+				return fmt.Errorf("Error serializing minipool performance file into JSON: %w", err)
+			}
+			err = t.saveFile(minipoolPerformancePath, minipoolPerformanceBytes, func() error {
+				return rprewards.SaveMinipoolPerformanceFileBinary(minipoolPerformancePath, &rewardsFile.MinipoolPerformanceFile)
+			})
+			if err != nil {
+				return fmt.Errorf("Error saving minipool performance file to %s: %w", minipoolPerformancePath, err)
+			}
+			return nil
+		})
 		if err != nil {
-			return fmt.Errorf("Error uploading minipool performance file to Web3.Storage: %w", err)
+			return err
+		}
+
+		t.printMessage("Uploading minipool performance file...")
+		minipoolPerformanceCid, err := t.uploadFile(minipoolPerformanceBytes, compressedMinipoolPerformancePath, "compressed minipool performance")
+		if err != nil {
+			return fmt.Errorf("Error uploading minipool performance file: %w", err)
 		}
 		t.printMessage(fmt.Sprintf("Uploaded minipool performance file with CID %s", minipoolPerformanceCid))
 		rewardsFile.MinipoolPerformanceFileCID = minipoolPerformanceCid
 	} else {
+		err = profiler.TimePhase(rprewards.ProfilePhase_Serialization, func() error {
+			return rprewards.SaveMinipoolPerformanceFile(t.cfg, minipoolPerformancePath, &rewardsFile.MinipoolPerformanceFile)
+		})
+		if err != nil {
+			return fmt.Errorf("Error saving minipool performance file to %s: %w", minipoolPerformancePath, err)
+		}
 		t.printMessage("Saved minipool performance file.")
 		rewardsFile.MinipoolPerformanceFileCID = "---"
 	}
 
-	// Serialize the rewards tree to JSON
-	wrapperBytes, err := json.Marshal(rewardsFile)
-	if err != nil {
-		return fmt.Errorf("Error serializing proof wrapper into JSON: %w", err)
-	}
+	// Save the rewards tree, serializing it to JSON if it's going to be uploaded
 	t.printMessage("Generation complete! Saving tree...")
-
-	// Write the rewards tree to disk
-	err = os.WriteFile(rewardsTreePath, wrapperBytes, 0644)
+	var wrapperBytes []byte
+	err = profiler.TimePhase(rprewards.ProfilePhase_Serialization, func() error {
+		if nodeTrusted {
+			wrapperBytes, err = json.Marshal(rewardsFile)
+			if err != nil {
+				return fmt.Errorf("Error serializing proof wrapper into JSON: %w", err)
+			}
+			err = t.saveFile(rewardsTreePath, wrapperBytes, func() error {
+				return rprewards.SaveRewardsFileBinary(rewardsTreePath, rewardsFile)
+			})
+		} else {
+			err = rprewards.SaveRewardsFile(t.cfg, rewardsTreePath, rewardsFile)
+		}
+		if err != nil {
+			return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
+		return err
 	}
 
 	// Only do the upload and submission process if this is an Oracle DAO node
 	if nodeTrusted {
+		// Wait for the network fee to drop if the submission can be deferred; the saved file will be submitted on a later run
+		if shouldDeferSubmission(t.c, t.cfg, t.log, endTime) {
+			t.printMessage(fmt.Sprintf("Saved the rewards snapshot for interval %d; its submission has been deferred.", currentIndex))
+			return nil
+		}
+
 		// Upload the rewards tree file
-		t.printMessage("Uploading to Web3.Storage and submitting results to the contracts...")
-		cid, err := t.uploadFileToWeb3Storage(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
+		t.printMessage("Uploading rewards tree and submitting results to the contracts...")
+		cid, err := t.uploadFile(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
 		if err != nil {
-			return fmt.Errorf("Error uploading Merkle tree to Web3.Storage: %w", err)
+			return fmt.Errorf("Error uploading Merkle tree: %w", err)
 		}
 		t.printMessage(fmt.Sprintf("Uploaded Merkle tree with CID %s", cid))
 
@@ -409,6 +476,17 @@ func (t *submitRewardsTree) submitRewardsSnapshot(index *big.Int, consensusBlock
 	}
 	treeRoot := common.BytesToHash(treeRootBytes)
 
+	// Make sure this tree hasn't been submitted already and doesn't contradict a previous submission
+	value := treeRoot.Hex()
+	alreadySubmitted, err := t.submissions.check(dutyName_SubmitRewardsTree, index.Uint64(), value)
+	if err != nil {
+		return err
+	}
+	if alreadySubmitted {
+		t.log.Printlnf("Rewards tree for interval %d has already been submitted.", index.Uint64())
+		return nil
+	}
+
 	// Create the arrays of rewards per network
 	collateralRplRewards := []*big.Int{}
 	oDaoRplRewards := []*big.Int{}
@@ -457,7 +535,7 @@ func (t *submitRewardsTree) submitRewardsSnapshot(index *big.Int, consensusBlock
 	}
 
 	// Print the gas info
-	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -467,64 +545,51 @@ func (t *submitRewardsTree) submitRewardsSnapshot(index *big.Int, consensusBlock
 	opts.GasLimit = gasInfo.SafeGasLimit
 
 	// Submit RPL price
-	hash, err := rewards.SubmitRewardSnapshot(t.rp, submission, opts)
+	hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return rewards.SubmitRewardSnapshot(t.rp, submission, opts)
+	})
 	if err != nil {
 		return err
 	}
+	t.submissions.record(dutyName_SubmitRewardsTree, index.Uint64(), value, hash)
 
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Print TX info and wait for it to be included in a block; if it's replaced, the nonce manager updates its record
+	hash, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 	if err != nil {
 		return err
 	}
+	t.dutyStatus.recordTransaction(dutyName_SubmitRewardsTree, t.ec, hash)
+	t.dutyStatus.recordSubmittedValue(dutyName_SubmitRewardsTree, index.Uint64(), value)
 
 	// Return
 	return nil
 }
 
-// Compress and upload a file to Web3.Storage and get the CID for it
-func (t *submitRewardsTree) uploadFileToWeb3Storage(wrapperBytes []byte, compressedPath string, description string) (string, error) {
-
-	// Get the API token
-	apiToken := t.cfg.Smartnode.Web3StorageApiToken.Value.(string)
-	if apiToken == "" {
-		return "", fmt.Errorf("***ERROR***\nYou have not configured your Web3.Storage API token yet, so you cannot submit Merkle rewards trees.\nPlease get an API token from https://web3.storage and enter it in the Smartnode section of the `service config` TUI (or use `--smartnode-web3StorageApiToken` if you configure your system headlessly).")
+// Compare the tree this node submitted for a previous interval with the Merkle root the Oracle DAO reached consensus on
+func (t *submitRewardsTree) checkTreeConsensus(currentIndex uint64) {
+	index, exists := t.dutyStatus.getPendingConsensusBlock(dutyName_SubmitRewardsTree)
+	if !exists || index >= currentIndex {
+		return
 	}
-
-	// Create the client
-	w3sClient, err := w3s.NewClient(w3s.WithToken(apiToken))
+	root, err := rewards.MerkleRoots(t.rp, big.NewInt(0).SetUint64(index), nil)
 	if err != nil {
-		return "", fmt.Errorf("Error creating new Web3.Storage client: %w", err)
-	}
-
-	// Compress the file
-	encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
-	compressedBytes := encoder.EncodeAll(wrapperBytes, make([]byte, 0, len(wrapperBytes)))
-
-	// Create the compressed tree file
-	compressedFile, err := os.Create(compressedPath)
-	if err != nil {
-		return "", fmt.Errorf("Error creating %s file [%s]: %w", description, compressedPath, err)
-	}
-	defer compressedFile.Close()
-
-	// Write the compressed data to the file
-	_, err = compressedFile.Write(compressedBytes)
-	if err != nil {
-		return "", fmt.Errorf("Error writing %s to %s: %w", description, compressedPath, err)
+		t.log.Printlnf("WARNING: couldn't get the Merkle root for interval %d: %s", index, err.Error())
+		return
 	}
+	t.dutyStatus.checkConsensus(dutyName_SubmitRewardsTree, index, common.BytesToHash(root).Hex())
+}
 
-	// Rewind it to the start
-	compressedFile.Seek(0, 0)
+// Compress and upload a file to the configured storage backend and get the CID for it
+func (t *submitRewardsTree) uploadFile(wrapperBytes []byte, compressedPath string, description string) (string, error) {
+	return storage.UploadFile(t.cfg, wrapperBytes, compressedPath, description, t.printMessage)
+}
 
-	// Upload it
-	cid, err := w3sClient.Put(context.Background(), compressedFile)
-	if err != nil {
-		return "", fmt.Errorf("Error uploading %s: %w", description, err)
+// Save a file that has already been serialized to JSON, using the binary format instead if it's been selected
+func (t *submitRewardsTree) saveFile(path string, jsonBytes []byte, saveBinary func() error) error {
+	if t.cfg.Smartnode.RewardsFileFormat.Value.(cfgtypes.RewardsFileFormat) == cfgtypes.RewardsFileFormat_Binary {
+		return saveBinary()
 	}
-
-	return cid.String(), nil
-
+	return os.WriteFile(path, jsonBytes, 0644)
 }
 
 // Get the first finalized, successful consensus block that occurred after the given target time
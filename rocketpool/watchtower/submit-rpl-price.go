@@ -24,10 +24,11 @@ import (
 
 	v110_network "github.com/rocket-pool/rocketpool-go/legacy/v1.1.0/network"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
-	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -162,21 +163,25 @@ type poolObserveResponse struct {
 
 // Submit RPL price task
 type submitRplPrice struct {
-	c         *cli.Context
-	log       log.ColorLogger
-	errLog    log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	ec        rocketpool.ExecutionClient
-	w         *wallet.Wallet
-	rp        *rocketpool.RocketPool
-	oio       *contracts.OneInchOracle
-	bc        beacon.Client
-	lock      *sync.Mutex
-	isRunning bool
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	ec          rocketpool.ExecutionClient
+	w           *wallet.Wallet
+	rp          *rocketpool.RocketPool
+	oio         *contracts.OneInchOracle
+	bc          beacon.Client
+	lock        *sync.Mutex
+	isRunning   bool
+	dutyStatus  *dutyStatusTracker
+	submissions *submissionLedger
+	alerter     *alerting.Alerter
+	nonces      *nonceManager
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, dutyStatus *dutyStatusTracker, submissions *submissionLedger, nonces *nonceManager) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -203,20 +208,28 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 	if err != nil {
 		return nil, err
 	}
+	alerter, err := services.GetAlerter(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitRplPrice{
-		c:      c,
-		log:    logger,
-		errLog: errorLogger,
-		cfg:    cfg,
-		ec:     ec,
-		w:      w,
-		rp:     rp,
-		oio:    oio,
-		bc:     bc,
-		lock:   lock,
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		cfg:         cfg,
+		ec:          ec,
+		w:           w,
+		rp:          rp,
+		oio:         oio,
+		bc:          bc,
+		lock:        lock,
+		dutyStatus:  dutyStatus,
+		submissions: submissions,
+		alerter:     alerter,
+		nonces:      nonces,
 	}, nil
 
 }
@@ -269,6 +282,7 @@ func (t *submitRplPrice) run(state *state.NetworkState, isAtlasDeployed bool) er
 
 	// Check if a submission needs to be made
 	pricesBlock := state.NetworkDetails.PricesBlock
+	t.dutyStatus.checkConsensus(dutyName_SubmitRplPrice, pricesBlock, state.NetworkDetails.RplPrice.String())
 	if blockNumber <= pricesBlock {
 		return nil
 	}
@@ -307,7 +321,9 @@ func (t *submitRplPrice) run(state *state.NetworkState, isAtlasDeployed bool) er
 	}
 	t.lock.Unlock()
 
+	backgroundTasks.Add(1)
 	go func() {
+		defer backgroundTasks.Done()
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -330,6 +346,17 @@ func (t *submitRplPrice) run(state *state.NetworkState, isAtlasDeployed bool) er
 			return
 		}
 
+		// Cross-validate it against the secondary oracles
+		pricesAgree, err := t.checkRplPriceOracles(blockNumber, rplPrice)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s error checking secondary RPL price oracles: %w", logPrefix, err))
+			return
+		}
+		if !pricesAgree {
+			t.handleError(fmt.Errorf("%s RPL price for block %d could not be cross-validated against the secondary oracles, refusing to submit it", logPrefix, blockNumber))
+			return
+		}
+
 		// Calculate the total effective RPL stake on the network
 		zero := new(big.Int).SetUint64(0)
 		var effectiveRplStake *big.Int
@@ -385,6 +412,7 @@ func (t *submitRplPrice) run(state *state.NetworkState, isAtlasDeployed bool) er
 
 		// Log and return
 		t.log.Printlnf("%s Price report complete.", logPrefix)
+		t.dutyStatus.recordSubmissionSuccess(dutyName_SubmitRplPrice)
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
@@ -398,11 +426,19 @@ func (t *submitRplPrice) run(state *state.NetworkState, isAtlasDeployed bool) er
 func (t *submitRplPrice) handleError(err error) {
 	t.errLog.Println(err)
 	t.errLog.Println("*** Price report failed. ***")
+	t.dutyStatus.recordSubmissionError(dutyName_SubmitRplPrice, err)
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
 }
 
+// Check if a price report is running in the background
+func (t *submitRplPrice) isPending() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.isRunning
+}
+
 // Check whether prices for a block has already been submitted by the node
 func (t *submitRplPrice) hasSubmittedBlockPrices(nodeAddress common.Address, blockNumber uint64) (bool, error) {
 
@@ -458,14 +494,8 @@ func (t *submitRplPrice) getRplPrice(blockNumber uint64) (*big.Int, error) {
 		return nil, err
 	}
 
-	// Generate an OIO wrapper using the client
-	oio, err := contracts.NewOneInchOracle(common.HexToAddress(t.cfg.Smartnode.GetOneInchOracleAddress()), client.Client)
-	if err != nil {
-		return nil, err
-	}
-
 	// Get RPL price
-	rplPrice, err := oio.GetRateToEth(opts, rplAddress, true)
+	rplPrice, err := getOneInchRplPrice(client, common.HexToAddress(t.cfg.Smartnode.GetOneInchOracleAddress()), rplAddress, opts)
 	if err != nil {
 		return nil, fmt.Errorf("could not get RPL price at block %d: %w", blockNumber, err)
 	}
@@ -583,6 +613,17 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 	// Log
 	t.log.Printlnf("Submitting RPL price for block %d...", blockNumber)
 
+	// Make sure this price hasn't been submitted already and doesn't contradict a previous submission
+	value := rplPrice.String()
+	alreadySubmitted, err := t.submissions.check(dutyName_SubmitRplPrice, blockNumber, value)
+	if err != nil {
+		return err
+	}
+	if alreadySubmitted {
+		t.log.Printlnf("RPL price for block %d has already been submitted.", blockNumber)
+		return nil
+	}
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -598,7 +639,7 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 		}
 
 		// Print the gas info
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 			return nil
 		}
@@ -609,10 +650,13 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 		opts.GasLimit = gasInfo.SafeGasLimit
 
 		// Submit RPL price
-		hash, err = network.SubmitPrices(t.rp, blockNumber, rplPrice, opts)
+		hash, err = t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+			return network.SubmitPrices(t.rp, blockNumber, rplPrice, opts)
+		})
 		if err != nil {
 			return err
 		}
+		t.submissions.record(dutyName_SubmitRplPrice, blockNumber, value, hash)
 	} else {
 		legacyNetworkPricesAddress := t.cfg.Smartnode.GetV110NetworkPricesAddress()
 		// Get the gas limit
@@ -622,7 +666,7 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 		}
 
 		// Print the gas info
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 			return nil
 		}
@@ -633,17 +677,22 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, rplPrice, effectiveR
 		opts.GasLimit = gasInfo.SafeGasLimit
 
 		// Submit RPL price
-		hash, err = v110_network.SubmitPrices(t.rp, blockNumber, rplPrice, effectiveRplStake, opts, &legacyNetworkPricesAddress)
+		hash, err = t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+			return v110_network.SubmitPrices(t.rp, blockNumber, rplPrice, effectiveRplStake, opts, &legacyNetworkPricesAddress)
+		})
 		if err != nil {
 			return err
 		}
+		t.submissions.record(dutyName_SubmitRplPrice, blockNumber, value, hash)
 	}
 
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Print TX info and wait for it to be included in a block; if it's replaced, the nonce manager updates its record
+	hash, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 	if err != nil {
 		return err
 	}
+	t.dutyStatus.recordTransaction(dutyName_SubmitRplPrice, t.ec, hash)
+	t.dutyStatus.recordSubmittedValue(dutyName_SubmitRplPrice, blockNumber, rplPrice.String())
 
 	// Log
 	t.log.Printlnf("Successfully submitted RPL price for block %d.", blockNumber)
@@ -761,7 +810,7 @@ func (t *submitRplPrice) submitOptimismPrice() error {
 		}
 
 		// Print the gas info
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 			return nil
 		}
@@ -774,13 +823,19 @@ func (t *submitRplPrice) submitOptimismPrice() error {
 		t.log.Println("Submitting rate to Optimism...")
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate")
+		hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+			tx, err := priceMessenger.Transact(opts, "submitRate")
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit rate: %q", err)
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		_, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 		if err != nil {
 			return err
 		}
@@ -901,7 +956,7 @@ func (t *submitRplPrice) submitPolygonPrice() error {
 		}
 
 		// Print the gas info
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 			return nil
 		}
@@ -914,13 +969,19 @@ func (t *submitRplPrice) submitPolygonPrice() error {
 		t.log.Println("Submitting rate to Polygon...")
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate")
+		hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+			tx, err := priceMessenger.Transact(opts, "submitRate")
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit rate to Polygon: %q", err)
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		_, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 		if err != nil {
 			return err
 		}
@@ -1010,7 +1071,11 @@ func (t *submitRplPrice) submitArbitrumPrice() error {
 	if index == indexToSubmit {
 
 		// Get the current network recommended max fee
-		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei()
+		feeOracle, err := services.GetFeeOracle(t.c)
+		if err != nil {
+			return err
+		}
+		suggestedMaxFee, err := feeOracle.GetMaxFeeWei(oracle.FeeTier_Fast, nil)
 		if err != nil {
 			return fmt.Errorf("error getting recommended base fee from the network for Arbitrum price submission: %w", err)
 		}
@@ -1066,7 +1131,7 @@ func (t *submitRplPrice) submitArbitrumPrice() error {
 		}
 
 		// Print the gas info
-		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+		maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 			return nil
 		}
@@ -1079,13 +1144,19 @@ func (t *submitRplPrice) submitArbitrumPrice() error {
 		t.log.Println("Submitting rate to Arbitrum...")
 
 		// Submit rates
-		tx, err := priceMessenger.Transact(opts, "submitRate", maxSubmissionCost, arbitrumGasLimit, arbitrumMaxFeePerGas)
+		hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+			tx, err := priceMessenger.Transact(opts, "submitRate", maxSubmissionCost, arbitrumGasLimit, arbitrumMaxFeePerGas)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			return tx.Hash(), nil
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to submit Arbitrum rate: %q", err)
 		}
 
 		// Print TX info and wait for it to be included in a block
-		err = api.PrintAndWaitForTransaction(t.cfg, tx.Hash(), t.rp.Client, t.log)
+		_, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 		if err != nil {
 			return err
 		}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -29,28 +30,32 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"golang.org/x/sync/errgroup"
 )
 
 // Settings
 const MinipoolBatchSize = 20
+const ScrubWorkerCount = 8
 const BlockStartOffset = 100000
 const ScrubSafetyDivider = 2
 const MinScrubSafetyTime = time.Duration(0) * time.Hour
 
 // Submit scrub minipools task
 type submitScrubMinipools struct {
-	c         *cli.Context
-	log       log.ColorLogger
-	errLog    log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	w         *wallet.Wallet
-	rp        *rocketpool.RocketPool
-	ec        rocketpool.ExecutionClient
-	bc        beacon.Client
-	it        *iterationData
-	coll      *collectors.ScrubCollector
-	lock      *sync.Mutex
-	isRunning bool
+	c          *cli.Context
+	log        log.ColorLogger
+	errLog     log.ColorLogger
+	cfg        *config.RocketPoolConfig
+	w          *wallet.Wallet
+	rp         *rocketpool.RocketPool
+	ec         rocketpool.ExecutionClient
+	bc         beacon.Client
+	it         *iterationData
+	coll       *collectors.ScrubCollector
+	dutyStatus *dutyStatusTracker
+	lock       *sync.Mutex
+	isRunning  bool
+	nonces     *nonceManager
 }
 
 type iterationData struct {
@@ -68,6 +73,9 @@ type iterationData struct {
 	// Minipool info
 	minipools map[minipool.Minipool]*minipoolDetails
 
+	// Prefix for progress logging
+	logPrefix string
+
 	// ETH1 search artifacts
 	startBlock       *big.Int
 	eventLogInterval *big.Int
@@ -81,7 +89,7 @@ type minipoolDetails struct {
 }
 
 // Create submit scrub minipools task
-func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector) (*submitScrubMinipools, error) {
+func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector, dutyStatus *dutyStatusTracker, nonces *nonceManager) (*submitScrubMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -108,17 +116,19 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 	// Return task
 	lock := &sync.Mutex{}
 	return &submitScrubMinipools{
-		c:         c,
-		log:       logger,
-		errLog:    errorLogger,
-		cfg:       cfg,
-		w:         w,
-		rp:        rp,
-		ec:        ec,
-		bc:        bc,
-		coll:      coll,
-		lock:      lock,
-		isRunning: false,
+		c:          c,
+		log:        logger,
+		errLog:     errorLogger,
+		cfg:        cfg,
+		w:          w,
+		rp:         rp,
+		ec:         ec,
+		bc:         bc,
+		coll:       coll,
+		dutyStatus: dutyStatus,
+		lock:       lock,
+		isRunning:  false,
+		nonces:     nonces,
 	}, nil
 
 }
@@ -147,7 +157,9 @@ func (t *submitScrubMinipools) run(state *state.NetworkState, isAtlasDeployed bo
 	t.lock.Unlock()
 
 	// Run the check
+	backgroundTasks.Add(1)
 	go func() {
+		defer backgroundTasks.Done()
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -155,6 +167,7 @@ func (t *submitScrubMinipools) run(state *state.NetworkState, isAtlasDeployed bo
 		t.log.Printlnf("%s Starting scrub check in a separate thread.", checkPrefix)
 
 		t.it = new(iterationData)
+		t.it.logPrefix = checkPrefix
 
 		// Get minipools in prelaunch status
 		prelaunchMinipools := []rpstate.NativeMinipoolDetails{}
@@ -258,27 +271,77 @@ func (t *submitScrubMinipools) handleError(err error) {
 
 // Get the correct withdrawal credentials and pubkeys for each minipool
 func (t *submitScrubMinipools) initializeMinipoolDetails(minipools []rpstate.NativeMinipoolDetails, opts *bind.CallOpts) {
+
+	// Ignore vacant minipools - they have the wrong withdrawal creds (temporarily) by design
+	candidates := make([]rpstate.NativeMinipoolDetails, 0, len(minipools))
 	for _, mpd := range minipools {
-		// Ignore vacant minipools - they have the wrong withdrawal creds (temporarily) by design
-		if mpd.IsVacant {
-			continue
+		if !mpd.IsVacant {
+			candidates = append(candidates, mpd)
 		}
+	}
 
-		// Create a minipool contract wrapper for the given address
+	// Create a minipool contract wrapper for each candidate
+	wrappers := make([]minipool.Minipool, len(candidates))
+	t.runConcurrently("Loading minipool contracts", len(candidates), func(i int) {
+		mpd := candidates[i]
 		mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, opts)
 		if err != nil {
-			t.log.Printf("Error creating minipool wrapper for %s: %s", mpd.MinipoolAddress.Hex(), err.Error())
-			continue
+			t.log.Printlnf("Error creating minipool wrapper for %s: %s", mpd.MinipoolAddress.Hex(), err.Error())
+			return
 		}
+		wrappers[i] = mp
+	})
 
-		// Create a new details entry for this minipool
+	// Create a new details entry for each minipool
+	for i, mp := range wrappers {
+		if mp == nil {
+			continue
+		}
 		t.it.minipools[mp] = &minipoolDetails{
-			expectedWithdrawalCredentials: mpd.WithdrawalCredentials,
-			pubkey:                        mpd.Pubkey,
+			expectedWithdrawalCredentials: candidates[i].WithdrawalCredentials,
+			pubkey:                        candidates[i].Pubkey,
 		}
 	}
 }
 
+// The result of checking a minipool's prestake event
+type prestakeResult struct {
+	checked      bool
+	signatureErr error
+}
+
+// Get the minipools that haven't been resolved by a previous step yet
+func (t *submitScrubMinipools) getRemainingMinipools() []minipool.Minipool {
+	minipools := make([]minipool.Minipool, 0, len(t.it.minipools))
+	for minipool := range t.it.minipools {
+		minipools = append(minipools, minipool)
+	}
+	return minipools
+}
+
+// Run a worker for each of the given number of minipools on a limited pool of goroutines, logging progress after each batch
+func (t *submitScrubMinipools) runConcurrently(action string, count int, worker func(i int)) {
+	if count == 0 {
+		return
+	}
+
+	var completed int64
+	var wg errgroup.Group
+	wg.SetLimit(ScrubWorkerCount)
+	for i := 0; i < count; i++ {
+		i := i
+		wg.Go(func() error {
+			worker(i)
+			done := atomic.AddInt64(&completed, 1)
+			if done%MinipoolBatchSize == 0 || done == int64(count) {
+				t.log.Printlnf("%s %s... (%d/%d)", t.it.logPrefix, action, done, count)
+			}
+			return nil
+		})
+	}
+	_ = wg.Wait()
+}
+
 // Step 1: Verify the Beacon Chain credentials for a minipool if they're present
 func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.NetworkState) error {
 	minipoolsToScrub := []minipool.Minipool{}
@@ -367,14 +430,20 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 
 	minipoolsToScrub := []minipool.Minipool{}
 
+	// Get the events and validate their signatures concurrently, since each one requires its own log scan
+	candidates := t.getRemainingMinipools()
+	results := make([]prestakeResult, len(candidates))
 	weiPerGwei := big.NewInt(int64(eth.WeiPerGwei))
-	for minipool := range t.it.minipools {
+	t.runConcurrently("Checking prestake events", len(candidates), func(i int) {
+		minipool := candidates[i]
+
 		// Get the MinipoolPrestaked event
 		prestakeData, err := minipool.GetPrestakeEvent(t.it.eventLogInterval, nil)
 		if err != nil {
 			t.log.Printlnf("Error getting prestake event for minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
-			continue
+			return
 		}
+		results[i].checked = true
 
 		// Convert the amount to gwei
 		prestakeData.Amount.Div(prestakeData.Amount, weiPerGwei)
@@ -387,12 +456,20 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 		depositData.Signature = prestakeData.Signature.Bytes()
 
 		// Validate the signature
-		err = prdeposit.VerifyDepositSignature(depositData, t.it.depositDomain)
-		if err != nil {
+		results[i].signatureErr = prdeposit.VerifyDepositSignature(depositData, t.it.depositDomain)
+	})
+
+	// Process the results
+	for i, minipool := range candidates {
+		result := results[i]
+		if !result.checked {
+			continue
+		}
+		if result.signatureErr != nil {
 			// The signature is illegal
 			t.log.Println("=== SCRUB DETECTED ON PRESTAKE EVENT ===")
 			t.log.Printlnf("Invalid prestake data for minipool %s:", minipool.GetAddress().Hex())
-			t.log.Printlnf("\tError: %s", err.Error())
+			t.log.Printlnf("\tError: %s", result.signatureErr.Error())
 			t.log.Println("========================================")
 
 			// Remove this minipool from the list of things to process in the next step
@@ -432,19 +509,13 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 		return err
 	}
 
-	// Check each minipool's deposit data
-	for minipool, details := range t.it.minipools {
-
-		// Get the deposit list for this minipool
-		deposits, exists := depositMap[details.pubkey]
-		if !exists || len(deposits) == 0 {
-			// Somehow this minipool doesn't have a deposit?
-			t.it.unknownMinipools++
-			continue
-		}
-
-		// Go through each deposit for this minipool and find the first one that's valid
-		for depositIndex, deposit := range deposits {
+	// Find the first deposit with a valid signature for each minipool concurrently, since signature checks are expensive
+	candidates := t.getRemainingMinipools()
+	validDepositIndices := make([]int, len(candidates))
+	t.runConcurrently("Checking deposit signatures", len(candidates), func(i int) {
+		minipool := candidates[i]
+		validDepositIndices[i] = -1
+		for depositIndex, deposit := range depositMap[t.it.minipools[minipool].pubkey] {
 			depositData := new(ethpb.Deposit_Data)
 			depositData.Amount = deposit.Amount
 			depositData.PublicKey = deposit.Pubkey.Bytes()
@@ -459,28 +530,48 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 				t.log.Printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
 				t.log.Printlnf("\tError: %s", err.Error())
 			} else {
-				// This is a valid deposit
-				expectedCreds := details.expectedWithdrawalCredentials
-				actualCreds := deposit.WithdrawalCredentials
-				if actualCreds != expectedCreds {
-					t.log.Println("=== SCRUB DETECTED ON DEPOSIT CONTRACT ===")
-					t.log.Printlnf("\tTX Hash: %s", deposit.TxHash.Hex())
-					t.log.Printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
-					t.log.Printlnf("\tMinipool: %s", minipool.GetAddress().Hex())
-					t.log.Printlnf("\tExpected creds: %s", expectedCreds.Hex())
-					t.log.Printlnf("\tActual creds: %s", actualCreds.Hex())
-					t.log.Println("==========================================")
-					minipoolsToScrub = append(minipoolsToScrub, minipool)
-					t.it.badOnDepositContract++
-				} else {
-					t.it.goodOnDepositContract++
-				}
-
-				// Remove this minipool from the list of things to process in the next step
-				delete(t.it.minipools, minipool)
-				break
+				validDepositIndices[i] = depositIndex
+				return
 			}
 		}
+	})
+
+	// Check each minipool's deposit data
+	for i, minipool := range candidates {
+		details := t.it.minipools[minipool]
+
+		// Get the deposit list for this minipool
+		deposits, exists := depositMap[details.pubkey]
+		if !exists || len(deposits) == 0 {
+			// Somehow this minipool doesn't have a deposit?
+			t.it.unknownMinipools++
+			continue
+		}
+
+		// Check the first valid deposit for this minipool
+		depositIndex := validDepositIndices[i]
+		if depositIndex < 0 {
+			continue
+		}
+		deposit := deposits[depositIndex]
+		expectedCreds := details.expectedWithdrawalCredentials
+		actualCreds := deposit.WithdrawalCredentials
+		if actualCreds != expectedCreds {
+			t.log.Println("=== SCRUB DETECTED ON DEPOSIT CONTRACT ===")
+			t.log.Printlnf("\tTX Hash: %s", deposit.TxHash.Hex())
+			t.log.Printlnf("\tBlock: %d, TX Index: %d, Deposit Index: %d", deposit.BlockNumber, deposit.TxIndex, depositIndex)
+			t.log.Printlnf("\tMinipool: %s", minipool.GetAddress().Hex())
+			t.log.Printlnf("\tExpected creds: %s", expectedCreds.Hex())
+			t.log.Printlnf("\tActual creds: %s", actualCreds.Hex())
+			t.log.Println("==========================================")
+			minipoolsToScrub = append(minipoolsToScrub, minipool)
+			t.it.badOnDepositContract++
+		} else {
+			t.it.goodOnDepositContract++
+		}
+
+		// Remove this minipool from the list of things to process in the next step
+		delete(t.it.minipools, minipool)
 	}
 
 	// Scrub the offending minipools
@@ -524,7 +615,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 
 		// Verify this is actually a prelaunch minipool
 		if mpd.Status != types.Prelaunch {
-			t.log.Printlnf("\tMinipool %s is under review but is in %d status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
+			t.log.Printlnf("\tMinipool %s is under review but is in %s status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
 			continue
 		}
 
@@ -574,7 +665,7 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	}
 
 	// Print the gas info
-	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.cfg))
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
 		return nil
 	}
@@ -585,16 +676,17 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	opts.GasLimit = gasInfo.SafeGasLimit
 
 	// Dissolve
-	hash, err := mp.VoteScrub(opts)
+	hash, err := t.nonces.send(opts, mp.VoteScrub)
 	if err != nil {
 		return fmt.Errorf("error voting to scrub minipool %s: %w", mp.GetAddress().Hex(), err)
 	}
 
 	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	hash, err = t.nonces.printAndWaitForTransaction(hash, t.log)
 	if err != nil {
 		return err
 	}
+	t.dutyStatus.recordTransaction(dutyName_SubmitScrubMinipools, t.ec, hash)
 
 	// Log
 	t.log.Printlnf("Successfully voted to scrub the minipool %s.", mp.GetAddress().Hex())
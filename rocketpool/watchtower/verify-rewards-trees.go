@@ -0,0 +1,89 @@
+package watchtower
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/urfave/cli"
+)
+
+// Verify rewards trees task
+type verifyRewardsTrees struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	rp  *rocketpool.RocketPool
+}
+
+// Create verify rewards trees task
+func newVerifyRewardsTrees(c *cli.Context, logger log.ColorLogger) (*verifyRewardsTrees, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &verifyRewardsTrees{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		rp:  rp,
+	}, nil
+
+}
+
+// Request verification of the latest submitted rewards tree if it hasn't been verified yet.
+// The verification itself is done by the generate rewards tree task.
+func (t *verifyRewardsTrees) run() error {
+
+	// Get the latest interval with a submitted tree
+	currentIndexBig, err := rewards.GetRewardIndex(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("error getting current rewards index: %w", err)
+	}
+	currentIndex := currentIndexBig.Uint64()
+	if currentIndex == 0 {
+		return nil
+	}
+	index := currentIndex - 1
+
+	// Check if it's already been verified or requested
+	verificationPath := t.cfg.Smartnode.GetRewardsTreeVerificationPath(index, true)
+	_, err = os.Stat(verificationPath)
+	if err == nil {
+		return nil
+	}
+	requestPath := t.cfg.Smartnode.GetVerifyRewardsTreeRequestPath(index, true)
+	_, err = os.Stat(requestPath)
+	if err == nil {
+		return nil
+	}
+
+	// Create the verification request
+	t.log.Printlnf("The rewards tree for interval %d has been submitted, requesting verification...", index)
+	err = os.MkdirAll(t.cfg.Smartnode.GetWatchtowerFolder(true), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating watchtower storage directory: %w", err)
+	}
+	requestFile, err := os.Create(requestPath)
+	if requestFile != nil {
+		requestFile.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("error creating request marker: %w", err)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,341 @@
+package watchtower
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The name of the Oracle DAO's proposals contract
+const trustedNodeProposalsDao string = "rocketDAONodeTrustedProposals"
+
+// Types of Oracle DAO proposals that vote rules can match
+const (
+	proposalType_Invite  string = "invite"
+	proposalType_Leave   string = "leave"
+	proposalType_Replace string = "replace"
+	proposalType_Kick    string = "kick"
+	proposalType_Setting string = "setting"
+	proposalType_Upgrade string = "upgrade"
+)
+
+// The proposal type for each of the proposals contract's payload methods
+var proposalTypesByMethod = map[string]string{
+	"proposalInvite":      proposalType_Invite,
+	"proposalLeave":       proposalType_Leave,
+	"proposalReplace":     proposalType_Replace,
+	"proposalKick":        proposalType_Kick,
+	"proposalSettingUint": proposalType_Setting,
+	"proposalSettingBool": proposalType_Setting,
+	"proposalUpgrade":     proposalType_Upgrade,
+}
+
+// A rule for automatically voting on Oracle DAO proposals
+type proposalVoteRule struct {
+	text         string
+	proposalType string
+	support      bool
+	proposers    map[common.Address]bool
+}
+
+// The record of a decision made about a proposal, which is kept so automatic votes can be audited
+type proposalVoteRecord struct {
+	Time       time.Time      `json:"time"`
+	ProposalID uint64         `json:"proposalId"`
+	Proposer   common.Address `json:"proposer"`
+	Message    string         `json:"message"`
+	Type       string         `json:"type"`
+	Payload    string         `json:"payload"`
+	Rule       string         `json:"rule,omitempty"`
+	Support    *bool          `json:"support,omitempty"`
+	TxHash     *common.Hash   `json:"txHash,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// Vote on proposals task
+type voteOnProposals struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	errLog  log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	w       *wallet.Wallet
+	rp      *rocketpool.RocketPool
+	nonces  *nonceManager
+	rules   []proposalVoteRule
+	checked map[uint64]bool
+}
+
+// Create vote on proposals task
+func newVoteOnProposals(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, nonces *nonceManager) (*voteOnProposals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the rules
+	rules, err := parseProposalVoteRules(cfg.Smartnode.WatchtowerProposalVoteRules.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &voteOnProposals{
+		c:       c,
+		log:     logger,
+		errLog:  errorLogger,
+		cfg:     cfg,
+		w:       w,
+		rp:      rp,
+		nonces:  nonces,
+		rules:   rules,
+		checked: map[uint64]bool{},
+	}, nil
+
+}
+
+// Vote on proposals that match the rules
+func (t *voteOnProposals) run() error {
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking for Oracle DAO proposals to vote on...")
+
+	// Get the proposals and the time the node joined, since members can't vote on proposals created before they joined
+	proposals, err := dao.GetDAOProposalsWithMember(t.rp, trustedNodeProposalsDao, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting Oracle DAO proposals: %w", err)
+	}
+	joinedTime, err := trustednode.GetMemberJoinedTime(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting the time the node joined the Oracle DAO: %w", err)
+	}
+
+	for _, proposal := range proposals {
+		if proposal.State != rptypes.Active || proposal.MemberVoted || proposal.CreatedTime <= joinedTime || t.checked[proposal.ID] {
+			continue
+		}
+
+		// Find the rule that matches it
+		record := proposalVoteRecord{
+			Time:       time.Now(),
+			ProposalID: proposal.ID,
+			Proposer:   proposal.ProposerAddress,
+			Message:    proposal.Message,
+			Payload:    proposal.PayloadStr,
+		}
+		rule, err := t.matchRule(proposal, &record)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't check proposal %d against the vote rules: %s", proposal.ID, err.Error())
+			continue
+		}
+		if rule == nil {
+			t.log.Printlnf("Proposal %d (%s) doesn't match any vote rules, leaving it for a manual vote.", proposal.ID, proposal.PayloadStr)
+			t.recordDecision(&record)
+			t.checked[proposal.ID] = true
+			continue
+		}
+
+		// Vote on it
+		record.Rule = rule.text
+		record.Support = &rule.support
+		err = t.vote(proposal, rule.support, &record)
+		if err != nil {
+			record.Error = err.Error()
+			t.errLog.Printlnf("Error voting on proposal %d: %s", proposal.ID, err.Error())
+		} else if record.TxHash != nil {
+			t.checked[proposal.ID] = true
+		}
+		t.recordDecision(&record)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the first rule that matches a proposal, or nil if none of them do
+func (t *voteOnProposals) matchRule(proposal dao.ProposalDetails, record *proposalVoteRecord) (*proposalVoteRule, error) {
+
+	// Get the proposal type from its payload
+	daoAbi, err := t.rp.GetABI(proposal.DAO, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the %s contract ABI: %w", proposal.DAO, err)
+	}
+	method, err := daoAbi.MethodById(proposal.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding the proposal payload: %w", err)
+	}
+	proposalType, exists := proposalTypesByMethod[method.RawName]
+	if !exists {
+		proposalType = method.RawName
+	}
+	record.Type = proposalType
+
+	for i, rule := range t.rules {
+		if rule.proposalType != proposalType {
+			continue
+		}
+		if rule.proposers != nil && !rule.proposers[proposal.ProposerAddress] {
+			continue
+		}
+		return &t.rules[i], nil
+	}
+	return nil, nil
+
+}
+
+// Vote on a proposal
+func (t *voteOnProposals) vote(proposal dao.ProposalDetails, support bool, record *proposalVoteRecord) error {
+
+	// Log
+	t.log.Printlnf("Voting %s on proposal %d (%s) from %s...", getVoteString(support), proposal.ID, proposal.PayloadStr, proposal.ProposerAddress.Hex())
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := trustednode.EstimateVoteOnProposalGas(t.rp, proposal.ID, support, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to vote on the proposal: %w", err)
+	}
+
+	// Print the gas info
+	maxFee := eth.GweiToWei(getWatchtowerMaxFee(t.c, t.cfg, t.log))
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
+		return nil
+	}
+
+	// Set the gas settings
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = eth.GweiToWei(getWatchtowerPrioFee(t.cfg))
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Vote
+	hash, err := t.nonces.send(opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return trustednode.VoteOnProposal(t.rp, proposal.ID, support, opts)
+	})
+	if err != nil {
+		return err
+	}
+	record.TxHash = &hash
+
+	// Print TX info and wait for it to be included in a block
+	hash, err = t.nonces.printAndWaitForTransaction(hash, t.log)
+	if err != nil {
+		return err
+	}
+	record.TxHash = &hash
+
+	// Log
+	t.log.Printlnf("Successfully voted %s on proposal %d.", getVoteString(support), proposal.ID)
+	return nil
+
+}
+
+// Record a decision about a proposal in the vote log
+func (t *voteOnProposals) recordDecision(record *proposalVoteRecord) {
+	err := appendAuditRecord(t.cfg.Smartnode.GetProposalVoteLogPath(true), record)
+	if err != nil {
+		t.errLog.Printlnf("WARNING: couldn't record the decision for proposal %d: %s", record.ProposalID, err.Error())
+	}
+}
+
+// Parse the proposal vote rules from the Smartnode config
+func parseProposalVoteRules(rules string) ([]proposalVoteRule, error) {
+	parsedRules := []proposalVoteRule{}
+	for _, entry := range splitTaskList(rules) {
+		rule := proposalVoteRule{
+			text: entry,
+		}
+
+		// Get the proposers it applies to
+		body, proposers, hasProposers := strings.Cut(entry, "@")
+		if hasProposers {
+			rule.proposers = map[common.Address]bool{}
+			for _, proposer := range strings.Split(proposers, "|") {
+				proposer = strings.TrimSpace(proposer)
+				if !common.IsHexAddress(proposer) {
+					return nil, fmt.Errorf("invalid proposer address [%s] in proposal vote rule [%s]", proposer, entry)
+				}
+				rule.proposers[common.HexToAddress(proposer)] = true
+			}
+		}
+
+		// Get the proposal type and vote
+		proposalType, vote, found := strings.Cut(body, "=")
+		if !found {
+			return nil, fmt.Errorf("proposal vote rule [%s] isn't in the form type=vote", entry)
+		}
+		rule.proposalType = strings.ToLower(strings.TrimSpace(proposalType))
+		if !isKnownProposalType(rule.proposalType) {
+			return nil, fmt.Errorf("unknown proposal type [%s] in proposal vote rule [%s]", proposalType, entry)
+		}
+		switch strings.ToLower(strings.TrimSpace(vote)) {
+		case "yes":
+			rule.support = true
+		case "no":
+			rule.support = false
+		default:
+			return nil, fmt.Errorf("invalid vote [%s] in proposal vote rule [%s]; it must be yes or no", vote, entry)
+		}
+
+		parsedRules = append(parsedRules, rule)
+	}
+	return parsedRules, nil
+}
+
+// Check if a proposal type can be used in a vote rule
+func isKnownProposalType(proposalType string) bool {
+	for _, knownType := range proposalTypesByMethod {
+		if proposalType == knownType {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the description of a vote for logging
+func getVoteString(support bool) string {
+	if support {
+		return "yes"
+	}
+	return "no"
+}
@@ -3,7 +3,6 @@ package watchtower
 import (
 	"fmt"
 	"math/big"
-	"math/rand"
 	"net/http"
 	"sync"
 	"time"
@@ -17,16 +16,24 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/reload"
+	"github.com/rocket-pool/smartnode/shared/services/shutdown"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/taskmetrics"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Config
-var minTasksInterval, _ = time.ParseDuration("4m")
-var maxTasksInterval, _ = time.ParseDuration("6m")
+var defaultTaskInterval, _ = time.ParseDuration("4m")
 var taskCooldown, _ = time.ParseDuration("5s")
 
+// Background work started by the tasks, which is drained before the daemon shuts down
+var backgroundTasks sync.WaitGroup
+
 const (
 	MaxConcurrentEth1Requests = 200
 
@@ -43,15 +50,40 @@ const (
 	ProcessPenaltiesColor          = color.FgHiMagenta
 	CancelBondsColor               = color.FgGreen
 	CheckSoloMigrationsColor       = color.FgCyan
+	VoteOnProposalsColor           = color.FgHiBlue
 	UpdateColor                    = color.FgHiWhite
 )
 
+// Keys for the alerts sent when an Oracle DAO duty fails
+const (
+	submitRewardsTreeAlertKey     string = "watchtower-submit-rewards-tree"
+	respondChallengesAlertKey     string = "watchtower-respond-challenges"
+	submitRplPriceAlertKey        string = "watchtower-submit-rpl-price"
+	submitNetworkBalancesAlertKey string = "watchtower-submit-network-balances"
+	dissolveMinipoolsAlertKey     string = "watchtower-dissolve-minipools"
+	scrubMinipoolsAlertKey        string = "watchtower-scrub-minipools"
+	cancelBondReductionsAlertKey  string = "watchtower-cancel-bond-reductions"
+	checkSoloMigrationsAlertKey   string = "watchtower-check-solo-migrations"
+	voteOnProposalsAlertKey       string = "watchtower-vote-on-proposals"
+)
+
 // Register watchtower command
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 	app.Commands = append(app.Commands, cli.Command{
 		Name:    name,
 		Aliases: aliases,
 		Usage:   "Run Rocket Pool watchtower activity daemon",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "health-address",
+				Usage: "Address to serve the /healthz and /readyz checks on if enabled",
+				Value: "0.0.0.0",
+			},
+			cli.UintFlag{
+				Name:  "health-port",
+				Usage: "Port to serve the /healthz and /readyz checks on; leave at 0 to disable them",
+			},
+		},
 		Action: func(c *cli.Context) error {
 			return run(c)
 		},
@@ -63,6 +95,19 @@ func run(c *cli.Context) error {
 
 	// Configure
 	configureHTTP()
+	logSettingsLog := log.NewModuleLogger(log.ModuleWatchtower, log.LevelInfo, UpdateColor)
+	if err := services.ConfigureLogging(c, health.Process_Watchtower, &logSettingsLog); err != nil {
+		return err
+	}
+
+	// Listen for the wallet to be unlocked if its password is only kept in memory
+	go func() {
+		unlockLog := log.NewModuleLogger(log.ModuleWatchtower, log.LevelInfo, UpdateColor)
+		err := services.ServeWalletUnlock(c, health.Process_Watchtower, &unlockLog)
+		if err != nil {
+			unlockLog.Println(err)
+		}
+	}()
 
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
@@ -86,19 +131,41 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	alerter, err := services.GetAlerter(c)
+	if err != nil {
+		return err
+	}
 
 	// Initialize the scrub metrics reporter
 	scrubCollector := collectors.NewScrubCollector()
+	dissolveCollector := collectors.NewDissolveCollector()
 
 	// Initialize error logger
-	errorLog := log.NewColorLogger(ErrorColor)
-	updateLog := log.NewColorLogger(UpdateColor)
+	errorLog := log.NewModuleLogger(log.ModuleWatchtower, log.LevelError, ErrorColor)
+	updateLog := log.NewModuleLogger(log.ModuleWatchtower, log.LevelInfo, UpdateColor)
+
+	// Initialize the duty status tracker
+	dutyStatus := newDutyStatusTracker(cfg, errorLog)
+
+	// Load the record of previous submissions, so they aren't repeated or contradicted after a restart
+	submissions, err := newSubmissionLedger(cfg, rp.Client, errorLog)
+	if err != nil {
+		return err
+	}
+
+	// Initialize the nonce manager, which all of the tasks share so their transactions don't collide
+	txJournal, err := services.GetTxJournal(c)
+	if err != nil {
+		return err
+	}
+	nonces := newNonceManager(c, cfg, w, rp.Client, txJournal, submissions, log.NewModuleLogger(log.ModuleNonces, log.LevelWarn, WarningColor))
 
 	// Create the state manager
 	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
 	if err != nil {
 		return err
 	}
+	m.EnableSnapshots()
 
 	// Get the node address
 	nodeAccount, err := w.GetNodeAccount()
@@ -107,84 +174,203 @@ func run(c *cli.Context) error {
 	}
 
 	// Initialize tasks
-	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m)
+	respondChallenges, err := newRespondChallenges(c, log.NewModuleLogger(log.ModuleRespondChallenges, log.LevelInfo, RespondChallengesColor).WithTask(taskName_RespondChallenges), m, nonces)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewModuleLogger(log.ModuleRplPrice, log.LevelInfo, SubmitRplPriceColor).WithTask(dutyName_SubmitRplPrice), errorLog, dutyStatus, submissions, nonces)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewModuleLogger(log.ModuleNetworkBalances, log.LevelInfo, SubmitNetworkBalancesColor).WithTask(dutyName_SubmitNetworkBalances), errorLog, dutyStatus, submissions, nonces)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
-	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewModuleLogger(log.ModuleDissolveMinipools, log.LevelInfo, DissolveTimedOutMinipoolsColor).WithTask(dutyName_DissolveTimedOutMinipools), dissolveCollector, dutyStatus, nonces)
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewModuleLogger(log.ModuleScrubMinipools, log.LevelInfo, SubmitScrubMinipoolsColor).WithTask(dutyName_SubmitScrubMinipools), errorLog, scrubCollector, dutyStatus, nonces)
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
-	submitRewardsTree, err := newSubmitRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+	submitRewardsTree, err := newSubmitRewardsTree(c, log.NewModuleLogger(log.ModuleRewards, log.LevelInfo, SubmitRewardsTreeColor).WithTask(dutyName_SubmitRewardsTree), errorLog, m, dutyStatus, submissions, nonces)
 	if err != nil {
 		return fmt.Errorf("error during rewards tree check: %w", err)
 	}
-	/*processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog)
-	if err != nil {
-		return fmt.Errorf("error during penalties check: %w", err)
-	}*/
-	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewModuleLogger(log.ModuleRewards, log.LevelInfo, SubmitRewardsTreeColor).WithTask(taskName_GenerateRewardsTree), errorLog, m)
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
-	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog)
+	cancelBondReductions, err := newCancelBondReductions(c, log.NewModuleLogger(log.ModuleCancelBondReductions, log.LevelInfo, CancelBondsColor).WithTask(taskName_CancelBondReductions), errorLog, nonces)
 	if err != nil {
 		return fmt.Errorf("error during bond reduction cancel check: %w", err)
 	}
-	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewColorLogger(CheckSoloMigrationsColor), errorLog)
+	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewModuleLogger(log.ModuleSoloMigrations, log.LevelInfo, CheckSoloMigrationsColor).WithTask(taskName_CheckSoloMigrations), errorLog, nonces)
 	if err != nil {
 		return fmt.Errorf("error during solo migration check: %w", err)
 	}
+	verifyRewardsTrees, err := newVerifyRewardsTrees(c, log.NewModuleLogger(log.ModuleRewards, log.LevelInfo, SubmitRewardsTreeColor).WithTask(taskName_VerifyRewardsTrees))
+	if err != nil {
+		return fmt.Errorf("error during rewards tree verification check: %w", err)
+	}
+
+	// Create the task scheduler
+	scheduler := newTaskScheduler(cfg, &errorLog)
+	scheduler.addTask(taskName_GenerateRewardsTree, defaultTaskInterval, false, false, func(ctx *taskContext) error {
+		return generateRewardsTree.run()
+	})
+	if cfg.Smartnode.VerifyRewardsTrees.Value.(bool) {
+		scheduler.addTask(taskName_VerifyRewardsTrees, defaultTaskInterval, false, false, func(ctx *taskContext) error {
+			return verifyRewardsTrees.run()
+		})
+	}
+	scheduler.addTask(dutyName_SubmitRewardsTree, defaultTaskInterval, false, true, func(ctx *taskContext) error {
+		err := submitRewardsTree.run(ctx.isOnOdao, ctx.state, ctx.latestBlock.Slot, ctx.isAtlasDeployed)
+		if ctx.isOnOdao {
+			alertDutyResult(alerter, &errorLog, submitRewardsTreeAlertKey, "Rewards tree submission failed", err)
+			dutyStatus.recordRun(dutyName_SubmitRewardsTree, err, submitRewardsTree.isPending())
+		}
+		return err
+	})
+	scheduler.addTask(taskName_RespondChallenges, defaultTaskInterval, true, true, func(ctx *taskContext) error {
+		err := respondChallenges.run(ctx.isAtlasDeployed)
+		alertDutyResult(alerter, &errorLog, respondChallengesAlertKey, "Oracle DAO challenge response failed", err)
+		return err
+	})
+	scheduler.addTask(dutyName_SubmitRplPrice, defaultTaskInterval, true, true, func(ctx *taskContext) error {
+		err := submitRplPrice.run(ctx.state, ctx.isAtlasDeployed)
+		alertDutyResult(alerter, &errorLog, submitRplPriceAlertKey, "RPL price submission failed", err)
+		dutyStatus.recordRun(dutyName_SubmitRplPrice, err, submitRplPrice.isPending())
+		return err
+	})
+	scheduler.addTask(dutyName_SubmitNetworkBalances, defaultTaskInterval, true, true, func(ctx *taskContext) error {
+		err := submitNetworkBalances.run(ctx.state, ctx.isAtlasDeployed)
+		alertDutyResult(alerter, &errorLog, submitNetworkBalancesAlertKey, "Network balance submission failed", err)
+		dutyStatus.recordRun(dutyName_SubmitNetworkBalances, err, submitNetworkBalances.isPending())
+		return err
+	})
+	scheduler.addTask(dutyName_DissolveTimedOutMinipools, defaultTaskInterval, true, true, func(ctx *taskContext) error {
+		err := dissolveTimedOutMinipools.run(ctx.state, ctx.isAtlasDeployed)
+		alertDutyResult(alerter, &errorLog, dissolveMinipoolsAlertKey, "Timed-out minipool dissolve failed", err)
+		dutyStatus.recordRun(dutyName_DissolveTimedOutMinipools, err, false)
+		return err
+	})
+	scheduler.addTask(dutyName_SubmitScrubMinipools, defaultTaskInterval, true, true, func(ctx *taskContext) error {
+		err := submitScrubMinipools.run(ctx.state, ctx.isAtlasDeployed)
+		alertDutyResult(alerter, &errorLog, scrubMinipoolsAlertKey, "Minipool scrub check failed", err)
+		dutyStatus.recordRun(dutyName_SubmitScrubMinipools, err, false)
+		return err
+	})
+	scheduler.addTask(taskName_CancelBondReductions, defaultTaskInterval, true, true, func(ctx *taskContext) error {
+		err := cancelBondReductions.run(ctx.state, ctx.isAtlasDeployed)
+		alertDutyResult(alerter, &errorLog, cancelBondReductionsAlertKey, "Bond reduction cancel check failed", err)
+		return err
+	})
+	scheduler.addTask(taskName_CheckSoloMigrations, defaultTaskInterval, true, true, func(ctx *taskContext) error {
+		err := checkSoloMigrations.run(ctx.state, ctx.isAtlasDeployed)
+		alertDutyResult(alerter, &errorLog, checkSoloMigrationsAlertKey, "Solo migration check failed", err)
+		return err
+	})
+	if cfg.Smartnode.WatchtowerProcessPenalties.Value.(bool) {
+		processPenalties, err := newProcessPenalties(c, log.NewModuleLogger(log.ModulePenalties, log.LevelInfo, ProcessPenaltiesColor).WithTask(taskName_ProcessPenalties), errorLog, m, nonces)
+		if err != nil {
+			return fmt.Errorf("error during penalties check: %w", err)
+		}
+		scheduler.addTask(taskName_ProcessPenalties, defaultTaskInterval, true, false, func(ctx *taskContext) error {
+			return processPenalties.run()
+		})
+	}
+	if cfg.Smartnode.WatchtowerProposalVoteRules.Value.(string) != "" {
+		voteOnProposals, err := newVoteOnProposals(c, log.NewModuleLogger(log.ModuleProposalVotes, log.LevelInfo, VoteOnProposalsColor).WithTask(taskName_VoteOnProposals), errorLog, nonces)
+		if err != nil {
+			return fmt.Errorf("error during proposal vote check: %w", err)
+		}
+		scheduler.addTask(taskName_VoteOnProposals, defaultTaskInterval, true, false, func(ctx *taskContext) error {
+			err := voteOnProposals.run()
+			alertDutyResult(alerter, &errorLog, voteOnProposalsAlertKey, "Oracle DAO proposal vote failed", err)
+			return err
+		})
+	}
+	scheduler.checkTaskNames()
+
+	// Stop starting new tasks when the daemon is told to shut down
+	coordinator := shutdown.NewCoordinator(cfg.Smartnode.GetPrepareShutdownPath(true), &updateLog)
+
+	// Reload the config between task passes when asked to; tree generation in the background isn't affected
+	reloadWatcher := reload.NewWatcher(cfg.Smartnode.GetReloadConfigPath(true), &updateLog)
+
+	// Restart when the wallet profile is switched, since the node account is part of the daemon's state
+	walletProfile := cfg.Smartnode.GetActiveWalletProfile()
+	walletProfileSwitched := false
 
-	intervalDelta := maxTasksInterval - minTasksInterval
-	secondsDelta := intervalDelta.Seconds()
+	// Record the task loop's progress for health checks
+	heartbeat := health.NewHeartbeat(cfg.Smartnode.GetTaskLoopHeartbeatPath(true, health.Process_Watchtower), &errorLog)
 
-	// Wait group to handle the various threads
+	// Record how long each task takes and whether it succeeds, and trace each pass if enabled
+	traceExporter, err := taskmetrics.NewTraceExporter(cfg, health.Process_Watchtower, &errorLog)
+	if err != nil {
+		return err
+	}
+	taskRecorder := taskmetrics.NewRecorder(health.Process_Watchtower, traceExporter)
+	taskRecorder.OnPanic(func(err *taskmetrics.PanicError) {
+		reportTaskPanic(cfg, alerter, &errorLog, health.Process_Watchtower, err)
+	})
+
+	// Wait group to handle the task loop
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(1)
 
 	// Run task loop
 	isAtlasDeployedMasterFlag := false
 	go func() {
-		for {
-			// Randomize the next interval
-			randomSeconds := rand.Intn(int(secondsDelta))
-			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
+		for !coordinator.IsStopping() {
+			// Wait while a shutdown is being prepared
+			if !coordinator.CanStartTasks() {
+				coordinator.Sleep(taskCooldown)
+				continue
+			}
+			if activeProfile := cfg.Smartnode.GetActiveWalletProfile(); activeProfile != walletProfile {
+				updateLog.Printlnf("The wallet profile was switched from '%s' to '%s', restarting to load it.", walletProfile, activeProfile)
+				walletProfileSwitched = true
+				break
+			}
+			if reloadWatcher.IsRequested() {
+				result, err := services.ReloadConfig(c)
+				if err != nil {
+					errorLog.Printlnf("Error reloading the config, keeping the current one: %s", err.Error())
+				} else {
+					reload.LogResult(result, &updateLog)
+					scheduler.reload(cfg)
+				}
+			}
 
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
 			if err != nil {
 				errorLog.Println(err)
-				time.Sleep(taskCooldown)
+				alertFailure(alerter, &errorLog, alerting.ExecutionClientSyncAlertKey, "Execution client is not ready", err)
+				coordinator.Sleep(taskCooldown)
 				continue
 			}
+			alerter.Resolve(alerting.ExecutionClientSyncAlertKey)
 
 			// Check the BC status
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
 			if err != nil {
 				errorLog.Println(err)
-				time.Sleep(taskCooldown)
+				alertFailure(alerter, &errorLog, alerting.BeaconClientSyncAlertKey, "Beacon node is not ready", err)
+				coordinator.Sleep(taskCooldown)
 				continue
 			}
+			alerter.Resolve(alerting.BeaconClientSyncAlertKey)
 
 			// Get the Beacon block
 			//latestBlock, err := m.GetLatestFinalizedBeaconBlock()
 			latestBlock, err := m.GetLatestBeaconBlock()
 			if err != nil {
 				errorLog.Println(fmt.Errorf("error getting latest Beacon block: %w", err))
-				time.Sleep(taskCooldown)
+				coordinator.Sleep(taskCooldown)
 				continue
 			}
 
@@ -192,118 +378,114 @@ func run(c *cli.Context) error {
 			isOnOdao, err := isOnOracleDAO(rp, nodeAccount.Address, latestBlock)
 			if err != nil {
 				errorLog.Println(err)
-				time.Sleep(taskCooldown)
+				coordinator.Sleep(taskCooldown)
 				continue
 			}
 
-			// Run the manual rewards tree generation
-			if err := generateRewardsTree.run(); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			if isOnOdao {
-				// Update the network state
-				state, err := updateNetworkState(m, &updateLog, latestBlock)
-				if err != nil {
-					errorLog.Println(err)
-					time.Sleep(taskCooldown)
-					continue
-				}
-
-				// Check for Atlas
-				if !isAtlasDeployedMasterFlag && state.IsAtlasDeployed {
-					printAtlasMessage(&updateLog)
-					isAtlasDeployedMasterFlag = true
-				}
-
-				// Run the rewards tree submission check
-				if err := submitRewardsTree.run(isOnOdao, state, latestBlock.Slot, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the challenge check
-				if err := respondChallenges.run(isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the price submission check
-				if err := submitRplPrice.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the network balance submission check
-				if err := submitNetworkBalances.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the minipool dissolve check
-				if err := dissolveTimedOutMinipools.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the minipool scrub check
-				if err := submitScrubMinipools.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the bond cancel check
-				if err := cancelBondReductions.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
+			// Get the tasks that are due
+			dueTasks := scheduler.getDueTasks(isOnOdao)
+			usesNetworkState := false
+			for _, task := range dueTasks {
+				if task.usesNetworkState {
+					usesNetworkState = true
+					break
 				}
-				time.Sleep(taskCooldown)
+			}
 
-				// Run the solo migration check
-				if err := checkSoloMigrations.run(state, isAtlasDeployedMasterFlag); err != nil {
-					errorLog.Println(err)
-				}
-				/*time.Sleep(taskCooldown)
-
-				// Run the fee recipient penalty check
-				if err := processPenalties.run(); err != nil {
-					errorLog.Println(err)
-				}*/
-				// DISABLED until MEV-Boost can support it
-			} else {
-				// Check for Atlas
-				isAtlasDeployed, err := state.IsAtlasDeployed(rp, &bind.CallOpts{
-					BlockNumber: big.NewInt(0).SetUint64(latestBlock.ExecutionBlockNumber),
-				})
-				if err != nil {
-					errorLog.Println(fmt.Errorf("error checking if Atlas is deployed: %w", err))
-					time.Sleep(taskCooldown)
-					continue
+			// Get the network state if any of them need it
+			pass := taskRecorder.StartPass()
+			ctx := &taskContext{
+				isOnOdao:    isOnOdao,
+				latestBlock: latestBlock,
+			}
+			if usesNetworkState {
+				if isOnOdao {
+					// Update the network state
+					var networkState *state.NetworkState
+					err := pass.RunTask(taskName_UpdateNetworkState, func() error {
+						var err error
+						networkState, err = updateNetworkState(m, &updateLog, latestBlock)
+						return err
+					})
+					if err != nil {
+						errorLog.Println(err)
+						pass.End()
+						coordinator.Sleep(taskCooldown)
+						continue
+					}
+
+					// Check for Atlas
+					if !isAtlasDeployedMasterFlag && networkState.IsAtlasDeployed {
+						printAtlasMessage(&updateLog)
+						isAtlasDeployedMasterFlag = true
+					}
+					ctx.state = networkState
+					ctx.isAtlasDeployed = isAtlasDeployedMasterFlag
+				} else {
+					// Check for Atlas
+					isAtlasDeployed, err := state.IsAtlasDeployed(rp, &bind.CallOpts{
+						BlockNumber: big.NewInt(0).SetUint64(latestBlock.ExecutionBlockNumber),
+					})
+					if err != nil {
+						errorLog.Println(fmt.Errorf("error checking if Atlas is deployed: %w", err))
+						pass.End()
+						coordinator.Sleep(taskCooldown)
+						continue
+					}
+					ctx.isAtlasDeployed = isAtlasDeployed
 				}
+			}
 
-				// Run the rewards tree submission check
-				if err := submitRewardsTree.run(isOnOdao, nil, latestBlock.Slot, isAtlasDeployed); err != nil {
-					errorLog.Println(err)
+			// Run the due tasks, unless the daemon is shutting down
+			heartbeat.RecordPassStart()
+			for _, task := range dueTasks {
+				if !coordinator.CanStartTasks() {
+					break
 				}
+				scheduler.runTask(task, ctx, pass)
+				coordinator.Sleep(taskCooldown)
 			}
+			heartbeat.RecordPassCompletion()
+			pass.End()
 
-			time.Sleep(interval)
+			coordinator.Sleep(scheduler.getTimeUntilNextRun(taskCooldown))
 		}
 		wg.Done()
 	}()
 
+	// Serve the health checks if enabled
+	if healthPort := c.Uint("health-port"); healthPort != 0 {
+		go func() {
+			err := health.RunServer(c, fmt.Sprintf("%s:%d", c.String("health-address"), healthPort), health.Process_Watchtower, &updateLog)
+			if err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector)
+		err := runMetricsServer(c, log.NewModuleLogger(log.ModuleMetrics, log.LevelInfo, MetricsColor), scrubCollector, dissolveCollector, dutyStatus.coll, taskRecorder.GetCollector())
 		if err != nil {
 			errorLog.Println(err)
 		}
-		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Wait for the task loop to stop, then for the tasks' background work (such as tree generation and in-flight transactions) to finish.
+	// A wallet profile switch must still restart the daemon if the work doesn't finish in time.
 	wg.Wait()
-	return nil
+	var restartErr error
+	if walletProfileSwitched {
+		restartErr = fmt.Errorf("restarting to load wallet profile '%s'", cfg.Smartnode.GetActiveWalletProfile())
+	}
+	updateLog.Println("Task loop stopped, waiting for background tasks to finish...")
+	drainTimeout := shutdown.GetDrainTimeout(time.Duration(cfg.Smartnode.ShutdownGracePeriod.Value.(uint64)) * time.Second)
+	if !coordinator.Drain(&backgroundTasks, drainTimeout) {
+		errorLog.Printlnf("Background tasks didn't finish within %s, shutting down anyway.", drainTimeout)
+		return restartErr
+	}
+	updateLog.Println("Background tasks finished, shutting down.")
+	return restartErr
 }
 
 // Configure HTTP transport settings
@@ -318,7 +500,7 @@ func configureHTTP() {
 
 // Check if Atlas has been deployed yet
 func printAtlasMessage(log *log.ColorLogger) {
-	log.Println(`
+	log.Print(`
 *       .
 *      / \
 *     |.'.|
@@ -343,7 +525,7 @@ func printAtlasMessage(log *log.ColorLogger) {
 func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, block beacon.BeaconBlock) (*state.NetworkState, error) {
 	log.Print("Getting latest network state... ")
 	// Get the state of the network
-	state, err := m.GetStateForSlot(block.Slot)
+	state, err := m.GetUpdatedStateForSlot(block.Slot)
 	if err != nil {
 		return nil, fmt.Errorf("error getting network state: %w", err)
 	}
@@ -362,3 +544,43 @@ func isOnOracleDAO(rp *rocketpool.RocketPool, nodeAddress common.Address, block
 	}
 	return nodeTrusted, nil
 }
+
+// Send an alert when an Oracle DAO duty fails, and resolve it once the duty succeeds again
+func alertDutyResult(alerter *alerting.Alerter, errorLog *log.ColorLogger, key string, title string, err error) {
+	if err == nil {
+		alerter.Resolve(key)
+		return
+	}
+	alertFailure(alerter, errorLog, key, title, err)
+}
+
+// Send an alert when a client check or an Oracle DAO duty fails
+func alertFailure(alerter *alerting.Alerter, errorLog *log.ColorLogger, key string, title string, err error) {
+	alertErr := alerter.Publish(alerting.Alert{
+		Key:      key,
+		Severity: alerting.Severity_Critical,
+		Title:    title,
+		Message:  err.Error(),
+	})
+	if alertErr != nil {
+		errorLog.Println(alertErr)
+	}
+}
+
+// Log the stack trace of a task that crashed, and send an alert about it if enabled
+func reportTaskPanic(cfg *config.RocketPoolConfig, alerter *alerting.Alerter, errorLog *log.ColorLogger, process string, err *taskmetrics.PanicError) {
+	taskLog := errorLog.WithTask(err.Task)
+	taskLog.Printlnf("The %s task crashed, recovering and continuing with the other tasks. Stack trace:\n%s", err.Task, err.Stack)
+	if cfg.Alerting.AlertOnTaskPanics.Value != true {
+		return
+	}
+	alertErr := alerter.Publish(alerting.Alert{
+		Key:      alerting.TaskPanicAlertKeyPrefix + err.Task,
+		Severity: alerting.Severity_Critical,
+		Title:    fmt.Sprintf("The %s task crashed", err.Task),
+		Message:  fmt.Sprintf("The %s task of the %s daemon crashed with [%v]; the daemon is still running, but the task will likely keep failing. Check the daemon's logs for the stack trace.", err.Task, process, err.Value),
+	})
+	if alertErr != nil {
+		errorLog.Println(alertErr)
+	}
+}
@@ -0,0 +1,178 @@
+package alerting
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	// Alerts with the same key won't be re-sent until this much time has passed
+	alertCooldown time.Duration = time.Hour
+
+	// Timeout for requests to the alert sinks
+	sinkTimeout time.Duration = 10 * time.Second
+)
+
+// Keys for alerts that are shared by multiple daemons
+const (
+	ExecutionClientSyncAlertKey     string = "ec-sync"
+	ExecutionClientFailoverAlertKey string = "ec-failover"
+	BeaconClientSyncAlertKey        string = "bc-sync"
+
+	// Followed by the name of the task that crashed
+	TaskPanicAlertKeyPrefix string = "task-panic-"
+)
+
+// The severity of an alert
+type Severity string
+
+const (
+	Severity_Info     Severity = "info"
+	Severity_Warning  Severity = "warning"
+	Severity_Critical Severity = "critical"
+)
+
+// An alert published by a daemon task
+type Alert struct {
+	// Identifies the condition that raised the alert, used to suppress duplicates
+	Key      string
+	Severity Severity
+	Title    string
+	Message  string
+}
+
+// A destination that alerts can be sent to
+type Sink interface {
+	// The name of the sink, used in error messages
+	GetName() string
+
+	// Send an alert to the sink
+	Send(alert Alert, nodeAddress common.Address, timestamp time.Time) error
+}
+
+// Sends alerts to all of the sinks defined in the Smartnode config
+type Alerter struct {
+	enabled     bool
+	nodeAddress common.Address
+	sinks       []Sink
+
+	// Internal fields
+	lastSent map[string]time.Time
+	lock     *sync.Mutex
+}
+
+// Create a new alerter from the Smartnode config
+func NewAlerter(cfg *config.RocketPoolConfig, nodeAddress common.Address) *Alerter {
+
+	alertingCfg := cfg.Alerting
+	client := &http.Client{
+		Timeout: sinkTimeout,
+	}
+
+	// Create the configured sinks
+	sinks := []Sink{}
+	if url := alertingCfg.WebhookUrl.Value.(string); url != "" {
+		sinks = append(sinks, newWebhookSink(client, url))
+	}
+	if url := alertingCfg.DiscordWebhookUrl.Value.(string); url != "" {
+		sinks = append(sinks, newDiscordSink(client, url))
+	}
+	botToken := alertingCfg.TelegramBotToken.Value.(string)
+	chatID := alertingCfg.TelegramChatID.Value.(string)
+	if botToken != "" && chatID != "" {
+		sinks = append(sinks, newTelegramSink(client, botToken, chatID))
+	}
+	if host := alertingCfg.SmtpHost.Value.(string); host != "" {
+		recipients := []string{}
+		for _, recipient := range strings.Split(alertingCfg.SmtpTo.Value.(string), ",") {
+			recipient = strings.TrimSpace(recipient)
+			if recipient != "" {
+				recipients = append(recipients, recipient)
+			}
+		}
+		sinks = append(sinks, newSmtpSink(
+			host,
+			alertingCfg.SmtpPort.Value.(uint16),
+			alertingCfg.SmtpUsername.Value.(string),
+			alertingCfg.SmtpPassword.Value.(string),
+			alertingCfg.SmtpFrom.Value.(string),
+			recipients,
+		))
+	}
+
+	return &Alerter{
+		enabled:     alertingCfg.EnableAlerting.Value == true,
+		nodeAddress: nodeAddress,
+		sinks:       sinks,
+		lastSent:    map[string]time.Time{},
+		lock:        &sync.Mutex{},
+	}
+
+}
+
+// Check if alerting is enabled and has at least one sink
+func (a *Alerter) IsEnabled() bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.enabled && len(a.sinks) > 0
+}
+
+// Rebuild the sinks from the Smartnode config after it has been reloaded, keeping the cooldowns of alerts that were already sent
+func (a *Alerter) Reload(cfg *config.RocketPoolConfig) {
+	newAlerter := NewAlerter(cfg, a.nodeAddress)
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.enabled = newAlerter.enabled
+	a.sinks = newAlerter.sinks
+}
+
+// Send an alert to all of the configured sinks.
+// Alerts with the same key as one sent within the cooldown period are dropped.
+func (a *Alerter) Publish(alert Alert) error {
+
+	if !a.IsEnabled() {
+		return nil
+	}
+
+	// Suppress duplicates
+	now := time.Now()
+	a.lock.Lock()
+	lastSent, exists := a.lastSent[alert.Key]
+	if exists && now.Sub(lastSent) < alertCooldown {
+		a.lock.Unlock()
+		return nil
+	}
+	a.lastSent[alert.Key] = now
+	sinks := a.sinks
+	a.lock.Unlock()
+
+	// Send to each sink, collecting the errors
+	errs := []string{}
+	for _, sink := range sinks {
+		err := sink.Send(alert, a.nodeAddress, now)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", sink.GetName(), err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("error sending alert [%s]: %s", alert.Title, strings.Join(errs, "; "))
+	}
+	return nil
+
+}
+
+// Clear the cooldown for an alert key, so the next alert for it is sent immediately.
+// Tasks should call this when the condition that raised the alert has been resolved.
+func (a *Alerter) Resolve(key string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.lastSent, key)
+}
@@ -0,0 +1,159 @@
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Format an alert as a single block of plain text
+func formatAlertText(alert Alert, nodeAddress common.Address) string {
+	return fmt.Sprintf("[%s] %s\n%s\nNode: %s", strings.ToUpper(string(alert.Severity)), alert.Title, alert.Message, nodeAddress.Hex())
+}
+
+// POST a JSON body to the given URL and check the response code
+func postJson(client *http.Client, url string, body interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error serializing alert: %w", err)
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("request failed with code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// Sends alerts to a generic webhook as JSON
+type webhookSink struct {
+	client *http.Client
+	url    string
+}
+
+func newWebhookSink(client *http.Client, url string) *webhookSink {
+	return &webhookSink{
+		client: client,
+		url:    url,
+	}
+}
+
+func (s *webhookSink) GetName() string {
+	return "webhook"
+}
+
+func (s *webhookSink) Send(alert Alert, nodeAddress common.Address, timestamp time.Time) error {
+	return postJson(s.client, s.url, map[string]interface{}{
+		"severity": alert.Severity,
+		"title":    alert.Title,
+		"message":  alert.Message,
+		"node":     nodeAddress.Hex(),
+		"time":     timestamp.UTC().Format(time.RFC3339),
+	})
+}
+
+// Sends alerts to a Discord channel webhook
+type discordSink struct {
+	client *http.Client
+	url    string
+}
+
+func newDiscordSink(client *http.Client, url string) *discordSink {
+	return &discordSink{
+		client: client,
+		url:    url,
+	}
+}
+
+func (s *discordSink) GetName() string {
+	return "Discord"
+}
+
+func (s *discordSink) Send(alert Alert, nodeAddress common.Address, timestamp time.Time) error {
+	return postJson(s.client, s.url, map[string]interface{}{
+		"content": formatAlertText(alert, nodeAddress),
+	})
+}
+
+// Sends alerts to a Telegram chat through a bot
+type telegramSink struct {
+	client   *http.Client
+	botToken string
+	chatID   string
+}
+
+func newTelegramSink(client *http.Client, botToken string, chatID string) *telegramSink {
+	return &telegramSink{
+		client:   client,
+		botToken: botToken,
+		chatID:   chatID,
+	}
+}
+
+func (s *telegramSink) GetName() string {
+	return "Telegram"
+}
+
+func (s *telegramSink) Send(alert Alert, nodeAddress common.Address, timestamp time.Time) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", s.botToken)
+	return postJson(s.client, url, map[string]interface{}{
+		"chat_id": s.chatID,
+		"text":    formatAlertText(alert, nodeAddress),
+	})
+}
+
+// Sends alerts by email
+type smtpSink struct {
+	host       string
+	port       uint16
+	username   string
+	password   string
+	from       string
+	recipients []string
+}
+
+func newSmtpSink(host string, port uint16, username string, password string, from string, recipients []string) *smtpSink {
+	return &smtpSink{
+		host:       host,
+		port:       port,
+		username:   username,
+		password:   password,
+		from:       from,
+		recipients: recipients,
+	}
+}
+
+func (s *smtpSink) GetName() string {
+	return "SMTP"
+}
+
+func (s *smtpSink) Send(alert Alert, nodeAddress common.Address, timestamp time.Time) error {
+	if len(s.recipients) == 0 {
+		return fmt.Errorf("no email recipients are configured")
+	}
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: [Rocket Pool] %s\r\nDate: %s\r\n\r\n%s\r\n",
+		s.from,
+		strings.Join(s.recipients, ", "),
+		alert.Title,
+		timestamp.Format(time.RFC1123Z),
+		formatAlertText(alert, nodeAddress),
+	)
+	return smtp.SendMail(fmt.Sprintf("%s:%d", s.host, s.port), auth, s.from, s.recipients, []byte(message))
+}
@@ -0,0 +1,164 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The rETH address is used as a canary to check that a client has the state for a block and is serving it correctly
+var rethAddressKey = crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH"))
+
+// Returned when neither the primary EC nor the archive EC can serve the state for a historical block
+type ArchiveRequiredError struct {
+	Block                uint64
+	PrimaryEarliestBlock uint64
+	ArchiveEcUrl         string
+	ArchiveEarliestBlock uint64
+	SearchError          error
+}
+
+func (e *ArchiveRequiredError) Error() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "***ERROR*** Primary EC cannot retrieve state for historical block %d", e.Block)
+	if e.SearchError == nil {
+		fmt.Fprintf(&builder, " (its earliest available state is for block %d)", e.PrimaryEarliestBlock)
+	}
+	if e.ArchiveEcUrl == "" {
+		builder.WriteString(" and the Archive EC is not specified.")
+	} else {
+		fmt.Fprintf(&builder, " and neither can the Archive EC [%s]", e.ArchiveEcUrl)
+		if e.SearchError == nil {
+			fmt.Fprintf(&builder, " (its earliest available state is for block %d)", e.ArchiveEarliestBlock)
+		}
+		builder.WriteString(".")
+	}
+	if e.SearchError != nil {
+		fmt.Fprintf(&builder, " The earliest available state couldn't be determined: %s", e.SearchError.Error())
+	}
+	return builder.String()
+}
+
+// Check if an error returned by an EC means it doesn't have the state for the requested block
+func IsMissingStateError(err error) bool {
+	errMessage := err.Error()
+	return strings.Contains(errMessage, "missing trie node") || // Geth
+		strings.Contains(errMessage, "No state available for block") || // Nethermind
+		strings.Contains(errMessage, "Internal error") // Besu
+}
+
+// Check if a client has the state for the given block
+func HasStateForBlock(client rocketpool.ExecutionClient, storageAddress common.Address, blockNumber *big.Int) (bool, error) {
+	_, err := client.CodeAt(context.Background(), storageAddress, blockNumber)
+	if err == nil {
+		return true, nil
+	}
+	if IsMissingStateError(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// Find the earliest block a client has the state for, assuming it has the state for every block after that one
+func FindEarliestStateBlock(client rocketpool.ExecutionClient, storageAddress common.Address) (uint64, error) {
+	latest, err := client.BlockNumber(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("error getting latest block: %w", err)
+	}
+
+	low := uint64(0)
+	high := latest
+	for low < high {
+		mid := low + (high-low)/2
+		hasState, err := HasStateForBlock(client, storageAddress, big.NewInt(0).SetUint64(mid))
+		if err != nil {
+			return 0, fmt.Errorf("error checking state for block %d: %w", mid, err)
+		}
+		if hasState {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low, nil
+}
+
+// Get a client that can serve the state for the given block, falling back to the archive EC if the primary EC can't.
+// If neither can, returns an ArchiveRequiredError describing the earliest block each one can serve.
+func GetClientForBlock(primary *rocketpool.RocketPool, cfg *config.RocketPoolConfig, printMessage func(string), blockNumber *big.Int) (*rocketpool.RocketPool, error) {
+
+	storageAddress := common.HexToAddress(cfg.Smartnode.GetStorageAddress())
+	hasState, err := HasStateForBlock(primary.Client, storageAddress, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting state for block %d: %w", blockNumber.Uint64(), err)
+	}
+	if hasState {
+		err = checkRethAddress(primary, cfg, blockNumber, "Primary")
+		if err != nil {
+			return nil, err
+		}
+		return primary, nil
+	}
+
+	// The state was missing so fall back to the archive node
+	archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
+	var archive *rocketpool.RocketPool
+	if archiveEcUrl != "" {
+		printMessage(fmt.Sprintf("Primary EC cannot retrieve state for historical block %d, using archive EC [%s]", blockNumber.Uint64(), archiveEcUrl))
+		ec, err := ethclient.Dial(archiveEcUrl)
+		if err != nil {
+			return nil, fmt.Errorf("Error connecting to archive EC: %w", err)
+		}
+		archive, err = rocketpool.NewRocketPool(ec, storageAddress)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating Rocket Pool client connected to archive EC: %w", err)
+		}
+		hasState, err = HasStateForBlock(archive.Client, storageAddress, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("error getting state for block %d from archive EC: %w", blockNumber.Uint64(), err)
+		}
+		if hasState {
+			err = checkRethAddress(archive, cfg, blockNumber, "Archive")
+			if err != nil {
+				return nil, err
+			}
+			return archive, nil
+		}
+	}
+
+	// Neither client can serve the block, so find out which blocks they can serve
+	archiveErr := &ArchiveRequiredError{
+		Block:        blockNumber.Uint64(),
+		ArchiveEcUrl: archiveEcUrl,
+	}
+	archiveErr.PrimaryEarliestBlock, archiveErr.SearchError = FindEarliestStateBlock(primary.Client, storageAddress)
+	if archive != nil && archiveErr.SearchError == nil {
+		archiveErr.ArchiveEarliestBlock, archiveErr.SearchError = FindEarliestStateBlock(archive.Client, storageAddress)
+	}
+	return nil, archiveErr
+
+}
+
+// Sanity check the rETH address to make sure the client is working right
+func checkRethAddress(client *rocketpool.RocketPool, cfg *config.RocketPoolConfig, blockNumber *big.Int, clientName string) error {
+	opts := &bind.CallOpts{
+		BlockNumber: blockNumber,
+	}
+	address, err := client.RocketStorage.GetAddress(opts, rethAddressKey)
+	if err != nil {
+		return fmt.Errorf("Error verifying rETH address with %s EC: %w", clientName, err)
+	}
+	if address != cfg.Smartnode.GetRethAddress() {
+		return fmt.Errorf("***ERROR*** Your %s EC provided %s as the rETH address, but it should have been %s!", clientName, address.Hex(), cfg.Smartnode.GetRethAddress().Hex())
+	}
+	return nil
+}
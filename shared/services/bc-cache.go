@@ -0,0 +1,138 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/cache"
+)
+
+// Settings
+const (
+	// How often to refresh the finalized epoch, which decides what data is safe to cache
+	bcCacheHeadRefreshInterval time.Duration = time.Minute
+)
+
+// The on-disk cache of finalized Beacon chain data used by the BeaconClientManager. Only data from epochs before the
+// chain's finalized epoch is cached, since it can never change.
+type bcCache struct {
+	disk *cache.DiskCache
+
+	// Internal fields
+	slotsPerEpoch  uint64
+	finalizedEpoch uint64
+	lastHeadCheck  time.Time
+	lock           *sync.Mutex
+}
+
+// A cached Beacon block, including whether or not the slot had one
+type cachedBeaconBlock struct {
+	Exists bool               `json:"exists"`
+	Block  beacon.BeaconBlock `json:"block"`
+}
+
+// Cached attestations for a slot, including whether or not the slot had a block
+type cachedAttestations struct {
+	Exists       bool                     `json:"exists"`
+	Attestations []beacon.AttestationInfo `json:"attestations"`
+}
+
+// Create a new cache for Beacon data in the given folder
+func newBcCache(path string, maxSizeMb uint64) (*bcCache, error) {
+	disk, err := cache.NewDiskCache(path, maxSizeMb*1024*1024)
+	if err != nil {
+		return nil, err
+	}
+	return &bcCache{
+		disk: disk,
+		lock: &sync.Mutex{},
+	}, nil
+}
+
+// Get the chain's finalized epoch and the number of slots per epoch, refreshing them if they're stale
+func (m *BeaconClientManager) getFinality() (uint64, uint64, bool) {
+	m.cache.lock.Lock()
+	defer m.cache.lock.Unlock()
+
+	if m.cache.slotsPerEpoch == 0 {
+		eth2Config, err := m.GetEth2Config()
+		if err != nil || eth2Config.SlotsPerEpoch == 0 {
+			return 0, 0, false
+		}
+		m.cache.slotsPerEpoch = eth2Config.SlotsPerEpoch
+	}
+
+	if time.Since(m.cache.lastHeadCheck) > bcCacheHeadRefreshInterval {
+		head, err := m.GetBeaconHead()
+		if err != nil {
+			return 0, 0, false
+		}
+		m.cache.finalizedEpoch = head.FinalizedEpoch
+		m.cache.lastHeadCheck = time.Now()
+	}
+
+	return m.cache.finalizedEpoch, m.cache.slotsPerEpoch, true
+}
+
+// Check if an epoch has been finalized, so its data can be cached
+func (m *BeaconClientManager) isEpochFinalized(epoch uint64) bool {
+	finalizedEpoch, _, ok := m.getFinality()
+	return ok && epoch < finalizedEpoch
+}
+
+// Check if a slot has been finalized, so its data can be cached
+func (m *BeaconClientManager) isSlotFinalized(slot uint64) bool {
+	finalizedEpoch, slotsPerEpoch, ok := m.getFinality()
+	return ok && slot/slotsPerEpoch < finalizedEpoch
+}
+
+// Check if a block ID refers to a finalized slot, so its data can be cached; named blocks and block roots aren't cached
+func (m *BeaconClientManager) isBlockCacheable(blockId string) bool {
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil {
+		return false
+	}
+	return m.isSlotFinalized(slot)
+}
+
+// Get the cache key for the statuses of a set of validators at a point in time, or false if they can't be cached
+func (m *BeaconClientManager) getValidatorStatusesKey(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (string, bool) {
+	if opts == nil {
+		return "", false
+	}
+
+	var stateId string
+	if opts.Slot != nil {
+		if !m.isSlotFinalized(*opts.Slot) {
+			return "", false
+		}
+		stateId = fmt.Sprintf("slot-%d", *opts.Slot)
+	} else if opts.Epoch != nil {
+		if !m.isEpochFinalized(*opts.Epoch) {
+			return "", false
+		}
+		stateId = fmt.Sprintf("epoch-%d", *opts.Epoch)
+	} else {
+		return "", false
+	}
+
+	// Identify the list of validators by a hash of their pubkeys, in order since the statuses are cached in that order
+	hash := sha256.New()
+	for _, pubkey := range pubkeys {
+		hash.Write(pubkey.Bytes())
+	}
+	return fmt.Sprintf("%s-%s", stateId, hex.EncodeToString(hash.Sum(nil))), true
+}
+
+// Save an entry to the cache, logging any errors since a cache failure shouldn't fail the request
+func (m *BeaconClientManager) putCacheEntry(kind string, key string, value interface{}) {
+	if err := m.cache.disk.Put(kind, key, value); err != nil {
+		m.logger.Printlnf("WARNING: %s", err.Error())
+	}
+}
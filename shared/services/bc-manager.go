@@ -2,12 +2,16 @@ package services
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/cache"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -15,14 +19,35 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// Settings
+const (
+	// Weight of the newest latency sample in an endpoint's moving average
+	bcLatencySampleWeight float64 = 0.3
+
+	// In balanced routing, endpoints slower than this multiple of the fastest one only get requests once the others fail
+	bcSlowEndpointFactor time.Duration = 3
+)
+
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
 type BeaconClientManager struct {
-	primaryBc       beacon.Client
-	fallbackBc      beacon.Client
+	endpoints       []*bcEndpoint
+	heavyEndpoints  []*bcEndpoint
+	routingMode     cfgtypes.BeaconRoutingMode
+	cache           *bcCache
 	logger          log.ColorLogger
-	primaryReady    bool
-	fallbackReady   bool
 	ignoreSyncCheck bool
+
+	// Internal fields
+	nextEndpoint int
+	lock         *sync.Mutex
+}
+
+// A Beacon node that the manager can route requests to
+type bcEndpoint struct {
+	name    string
+	client  beacon.Client
+	ready   bool
+	latency time.Duration
 }
 
 // This is a signature for a wrapped Beacon client function that only returns an error
@@ -57,8 +82,9 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 		return nil, fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
 	}
 
-	// Fallback CC
+	// Fallback CC and the extra Beacon nodes in the pool
 	var fallbackProvider string
+	poolProviders := []string{}
 	if cfg.UseFallbackClients.Value == true {
 		if cfg.IsNativeMode {
 			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
@@ -70,23 +96,82 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
 			}
 		}
+		for _, url := range strings.Split(cfg.BeaconNodePoolUrls.Value.(string), ",") {
+			url = strings.TrimSpace(url)
+			if url != "" {
+				poolProviders = append(poolProviders, url)
+			}
+		}
 	}
 
-	var primaryBc beacon.Client
-	var fallbackBc beacon.Client
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
+	// Get the custom headers for the primary if it's a remote provider
+	primaryHeaders, err := cfg.GetConsensusClientHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the HTTP headers for the primary Beacon client: %w", err)
+	}
+
+	// Create the endpoints; the primary is always first
+	endpoints := []*bcEndpoint{newBcEndpoint("Primary Beacon client", primaryProvider, primaryHeaders)}
 	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+		endpoints = append(endpoints, newBcEndpoint("Fallback Beacon client", fallbackProvider, nil))
+	}
+	for _, provider := range poolProviders {
+		endpoints = append(endpoints, newBcEndpoint(fmt.Sprintf("Beacon client [%s]", provider), provider, nil))
 	}
 
-	return &BeaconClientManager{
-		primaryBc:     primaryBc,
-		fallbackBc:    fallbackBc,
-		logger:        log.NewColorLogger(color.FgHiBlue),
-		primaryReady:  true,
-		fallbackReady: fallbackBc != nil,
-	}, nil
+	// Pin heavy operations to the designated Beacon node, falling back to the normal ones if it fails
+	heavyEndpoints := endpoints
+	if heavyProvider := cfg.Smartnode.RewardsBeaconNodeUrl.Value.(string); heavyProvider != "" {
+		heavyEndpoints = append([]*bcEndpoint{newBcEndpoint("Rewards Beacon client", heavyProvider, nil)}, endpoints...)
+	}
 
+	routingMode, _ := cfg.BeaconNodeRouting.Value.(cfgtypes.BeaconRoutingMode)
+	manager := &BeaconClientManager{
+		endpoints:      endpoints,
+		heavyEndpoints: heavyEndpoints,
+		routingMode:    routingMode,
+		logger:         log.NewModuleLogger(log.ModuleBcManager, log.LevelInfo, color.FgHiBlue),
+		lock:           &sync.Mutex{},
+	}
+
+	// Cache finalized data on disk if enabled; requests still work without it
+	if cacheSize := cfg.Smartnode.BeaconCacheSize.Value.(uint64); cacheSize > 0 {
+		bcCache, err := newBcCache(cfg.Smartnode.GetBeaconCachePath(true), cacheSize)
+		if err != nil {
+			manager.logger.Printlnf("WARNING: Beacon cache is disabled: %s", err.Error())
+		} else {
+			manager.cache = bcCache
+		}
+	}
+
+	return manager, nil
+
+}
+
+// Create a new endpoint for a Beacon node, which is assumed to be ready until its status is checked
+func newBcEndpoint(name string, provider string, headers map[string]string) *bcEndpoint {
+	return &bcEndpoint{
+		name:   name,
+		client: client.NewStandardHttpClientWithHeaders(provider, headers),
+		ready:  true,
+	}
+}
+
+// Get a Beacon client for heavy operations like rewards tree generation, which uses the Beacon node they're pinned to
+// if there is one
+func (m *BeaconClientManager) GetHeavyOperationClient() beacon.Client {
+	if len(m.heavyEndpoints) == len(m.endpoints) {
+		return m
+	}
+	return &BeaconClientManager{
+		endpoints:       m.heavyEndpoints,
+		heavyEndpoints:  m.heavyEndpoints,
+		routingMode:     cfgtypes.BeaconRoutingMode_Ordered,
+		cache:           m.cache,
+		logger:          m.logger,
+		ignoreSyncCheck: m.ignoreSyncCheck,
+		lock:            m.lock,
+	}
 }
 
 /// ======================
@@ -139,24 +224,54 @@ func (m *BeaconClientManager) GetEth2DepositContract() (beacon.Eth2DepositContra
 
 // Get the attestations in a Beacon chain block
 func (m *BeaconClientManager) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	cacheable := m.cache != nil && m.isBlockCacheable(blockId)
+	if cacheable {
+		var cached cachedAttestations
+		if m.cache.disk.Get(cache.Kind_Attestations, blockId, &cached) {
+			return cached.Attestations, cached.Exists, nil
+		}
+	}
+
 	result1, result2, err := m.runFunction2(func(client beacon.Client) (interface{}, interface{}, error) {
 		return client.GetAttestations(blockId)
 	})
 	if err != nil {
 		return nil, false, err
 	}
-	return result1.([]beacon.AttestationInfo), result2.(bool), nil
+	attestations, exists := result1.([]beacon.AttestationInfo), result2.(bool)
+	if cacheable {
+		m.putCacheEntry(cache.Kind_Attestations, blockId, cachedAttestations{
+			Exists:       exists,
+			Attestations: attestations,
+		})
+	}
+	return attestations, exists, nil
 }
 
 // Get a Beacon chain block
 func (m *BeaconClientManager) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	cacheable := m.cache != nil && m.isBlockCacheable(blockId)
+	if cacheable {
+		var cached cachedBeaconBlock
+		if m.cache.disk.Get(cache.Kind_Blocks, blockId, &cached) {
+			return cached.Block, cached.Exists, nil
+		}
+	}
+
 	result1, result2, err := m.runFunction2(func(client beacon.Client) (interface{}, interface{}, error) {
 		return client.GetBeaconBlock(blockId)
 	})
 	if err != nil {
 		return beacon.BeaconBlock{}, false, err
 	}
-	return result1.(beacon.BeaconBlock), result2.(bool), nil
+	block, exists := result1.(beacon.BeaconBlock), result2.(bool)
+	if cacheable {
+		m.putCacheEntry(cache.Kind_Blocks, blockId, cachedBeaconBlock{
+			Exists: exists,
+			Block:  block,
+		})
+	}
+	return block, exists, nil
 }
 
 // Get the Beacon chain's head information
@@ -194,13 +309,37 @@ func (m *BeaconClientManager) GetValidatorStatus(pubkey types.ValidatorPubkey, o
 
 // Get the statuses of multiple validators by their pubkeys
 func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	var key string
+	var cacheable bool
+	if m.cache != nil {
+		key, cacheable = m.getValidatorStatusesKey(pubkeys, opts)
+
+		// Statuses are stored as a list since pubkeys can't be JSON map keys
+		var cached []beacon.ValidatorStatus
+		if cacheable && m.cache.disk.Get(cache.Kind_Validators, key, &cached) && len(cached) == len(pubkeys) {
+			statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(cached))
+			for i, status := range cached {
+				statuses[pubkeys[i]] = status
+			}
+			return statuses, nil
+		}
+	}
+
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
 		return client.GetValidatorStatuses(pubkeys, opts)
 	})
 	if err != nil {
 		return nil, err
 	}
-	return result.(map[types.ValidatorPubkey]beacon.ValidatorStatus), nil
+	statuses := result.(map[types.ValidatorPubkey]beacon.ValidatorStatus)
+	if cacheable {
+		cached := make([]beacon.ValidatorStatus, len(pubkeys))
+		for i, pubkey := range pubkeys {
+			cached[i] = statuses[pubkey]
+		}
+		m.putCacheEntry(cache.Kind_Validators, key, cached)
+	}
+	return statuses, nil
 }
 
 // Get a validator's index
@@ -276,13 +415,38 @@ func (m *BeaconClientManager) GetEth1DataForEth2Block(blockId string) (beacon.Et
 
 // Get the attestation committees for an epoch
 func (m *BeaconClientManager) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Committee, error) {
+	var key string
+	cacheable := m.cache != nil && epoch != nil && m.isEpochFinalized(*epoch)
+	if cacheable {
+		key = strconv.FormatUint(*epoch, 10)
+		var cached []beacon.Committee
+		if m.cache.disk.Get(cache.Kind_Committees, key, &cached) {
+			return cached, nil
+		}
+	}
+
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
 		return client.GetCommitteesForEpoch(epoch)
 	})
 	if err != nil {
 		return nil, err
 	}
-	return result.([]beacon.Committee), nil
+	committees := result.([]beacon.Committee)
+	if cacheable {
+		m.putCacheEntry(cache.Kind_Committees, key, committees)
+	}
+	return committees, nil
+}
+
+// Get the indices of the validators in the sync committee for the given epoch, in committee order
+func (m *BeaconClientManager) GetSyncCommitteeForEpoch(epoch uint64) ([]uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetSyncCommitteeForEpoch(epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]uint64), nil
 }
 
 // Change the withdrawal credentials for a validator
@@ -303,36 +467,87 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex uint64,
 func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
-		FallbackEnabled: m.fallbackBc != nil,
+		FallbackEnabled: len(m.endpoints) > 1,
 	}
 
 	// Ignore the sync check and just use the predefined settings if requested
 	if m.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = m.primaryReady
-		status.PrimaryClientStatus.IsSynced = m.primaryReady
+		status.PrimaryClientStatus.IsWorking = m.isPrimaryReady()
+		status.PrimaryClientStatus.IsSynced = m.isPrimaryReady()
 		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = m.fallbackReady
-			status.FallbackClientStatus.IsSynced = m.fallbackReady
+			status.FallbackClientStatus.IsWorking = m.isFallbackReady()
+			status.FallbackClientStatus.IsSynced = m.isFallbackReady()
 		}
 		return status
 	}
 
-	// Get the primary BC status
-	status.PrimaryClientStatus = checkBcStatus(m.primaryBc)
+	// Get the status of every endpoint, timing the checks to score them
+	endpoints := m.endpoints
+	if len(m.heavyEndpoints) > len(endpoints) {
+		endpoints = m.heavyEndpoints
+	}
+	statuses := make(map[*bcEndpoint]api.ClientStatus, len(endpoints))
+	for _, endpoint := range endpoints {
+		start := time.Now()
+		endpointStatus := checkBcStatus(endpoint.client)
+		latency := time.Since(start)
+		statuses[endpoint] = endpointStatus
+
+		// Flag the ready clients
+		m.lock.Lock()
+		endpoint.ready = (endpointStatus.IsWorking && endpointStatus.IsSynced)
+		if endpointStatus.IsWorking {
+			if endpoint.latency == 0 {
+				endpoint.latency = latency
+			} else {
+				endpoint.latency = time.Duration(bcLatencySampleWeight*float64(latency) + (1-bcLatencySampleWeight)*float64(endpoint.latency))
+			}
+		}
+		m.lock.Unlock()
+	}
 
-	// Get the fallback BC status if applicable
+	// Report the primary, and the first ready fallback (or the first one if none are ready)
+	status.PrimaryClientStatus = statuses[m.endpoints[0]]
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkBcStatus(m.fallbackBc)
+		status.FallbackClientStatus = statuses[m.endpoints[1]]
+		for _, endpoint := range m.endpoints[1:] {
+			if endpoint.ready {
+				status.FallbackClientStatus = statuses[endpoint]
+				break
+			}
+		}
 	}
 
-	// Flag the ready clients
-	m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
-	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
-
 	return status
 
 }
 
+// Check if the primary client is ready
+func (m *BeaconClientManager) isPrimaryReady() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.endpoints[0].ready
+}
+
+// Check if any of the fallback clients are ready
+func (m *BeaconClientManager) isFallbackReady() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, endpoint := range m.endpoints[1:] {
+		if endpoint.ready {
+			return true
+		}
+	}
+	return false
+}
+
+// Flag whether the primary client is ready
+func (m *BeaconClientManager) setPrimaryReady(ready bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.endpoints[0].ready = ready
+}
+
 // Check the client status
 func checkBcStatus(client beacon.Client) api.ClientStatus {
 
@@ -361,130 +576,101 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 
 }
 
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
+// Get the ready endpoints in the order they should be tried, based on the routing mode
+func (m *BeaconClientManager) getRoute() []*bcEndpoint {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction0(function)
-			}
-			// If it's a different error, just return it
-			return err
+	ready := []*bcEndpoint{}
+	for _, endpoint := range m.endpoints {
+		if endpoint.ready {
+			ready = append(ready, endpoint)
 		}
-		// If there's no error, return the result
-		return nil
+	}
+	if m.routingMode != cfgtypes.BeaconRoutingMode_Balanced || len(ready) < 2 {
+		return ready
 	}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return fmt.Errorf("all Beacon clients failed")
-			}
-
-			// If it's a different error, just return it
-			return err
+	// Take turns between the endpoints that are close to the fastest one, and only use the slow ones if they all fail
+	fastest := time.Duration(0)
+	for _, endpoint := range ready {
+		if endpoint.latency > 0 && (fastest == 0 || endpoint.latency < fastest) {
+			fastest = endpoint.latency
 		}
-		// If there's no error, return the result
-		return nil
 	}
-
-	return fmt.Errorf("no Beacon clients were ready")
+	healthy := []*bcEndpoint{}
+	slow := []*bcEndpoint{}
+	for _, endpoint := range ready {
+		if fastest > 0 && endpoint.latency > fastest*bcSlowEndpointFactor {
+			slow = append(slow, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	m.nextEndpoint = (m.nextEndpoint + 1) % len(healthy)
+	route := make([]*bcEndpoint, 0, len(ready))
+	route = append(route, healthy[m.nextEndpoint:]...)
+	route = append(route, healthy[:m.nextEndpoint]...)
+	return append(route, slow...)
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
+func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction1(function)
-			}
-			// If it's a different error, just return it
-			return nil, err
-		}
-		// If there's no error, return the result
-		return result, nil
+	route := m.getRoute()
+	if len(route) == 0 {
+		return fmt.Errorf("no Beacon clients were ready")
 	}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, fmt.Errorf("all Beacon clients failed")
+	for i, endpoint := range route {
+		// Try to run the function on the endpoint
+		err := function(endpoint.client)
+		if err != nil && m.isDisconnected(err) {
+			// If it's disconnected, flag it and try the next one
+			m.lock.Lock()
+			endpoint.ready = false
+			m.lock.Unlock()
+			if i < len(route)-1 {
+				m.logger.Printlnf("WARNING: %s disconnected (%s), using %s...", endpoint.name, err.Error(), route[i+1].name)
+				continue
 			}
-			// If it's a different error, just return it
-			return nil, err
+			m.logger.Printlnf("WARNING: %s disconnected (%s)", endpoint.name, err.Error())
+			return fmt.Errorf("all Beacon clients failed")
 		}
-		// If there's no error, return the result
-		return result, nil
-	}
 
-	return nil, fmt.Errorf("no Beacon clients were ready")
+		// If there's no error or it's a different error, return it
+		return err
+	}
+	return fmt.Errorf("all Beacon clients failed")
 
 }
 
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
-func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
-
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result1, result2, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction2(function)
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
-		}
-		// If there's no error, return the result
-		return result1, result2, nil
+func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
+	var result interface{}
+	err := m.runFunction0(func(client beacon.Client) error {
+		var err error
+		result, err = function(client)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
+}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result1, result2, err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, nil, fmt.Errorf("all Beacon clients failed")
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
-		}
-		// If there's no error, return the result
-		return result1, result2, nil
+// Attempts to run a function progressively through each client until one succeeds or they all fail.
+func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
+	var result1, result2 interface{}
+	err := m.runFunction0(func(client beacon.Client) error {
+		var err error
+		result1, result2, err = function(client)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-
-	return nil, nil, fmt.Errorf("no Beacon clients were ready")
-
+	return result1, result2, nil
 }
 
 // Returns true if the error was a connection failure and a backup client is available
@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Config
+const (
+	// Bump this when the format of the cached data changes, so old entries are ignored
+	FormatVersion string = "v2"
+
+	DirMode  = 0770
+	FileMode = 0640
+
+	entryExtension string = ".json"
+)
+
+// The kinds of Beacon chain data that can be cached
+const (
+	Kind_Blocks       string = "blocks"
+	Kind_Attestations string = "attestations"
+	Kind_Committees   string = "committees"
+	Kind_Validators   string = "validators"
+)
+
+// A bounded on-disk cache for Beacon chain data that never changes once it's finalized. Entries are evicted in least
+// recently used order once the cache grows past its maximum size. Entries are written atomically, so several processes
+// can share the cache folder.
+type DiskCache struct {
+	path    string
+	maxSize int64
+
+	// Internal fields
+	entries map[string]*entry
+	size    int64
+	lock    *sync.Mutex
+}
+
+// A file in the cache
+type entry struct {
+	size     int64
+	lastUsed time.Time
+}
+
+// Create a new cache in the given folder, indexing the entries that are already in it
+func NewDiskCache(path string, maxSize uint64) (*DiskCache, error) {
+
+	cache := &DiskCache{
+		path:    filepath.Join(path, FormatVersion),
+		maxSize: int64(maxSize),
+		entries: map[string]*entry{},
+		lock:    &sync.Mutex{},
+	}
+
+	// Create the cache folder
+	if err := os.MkdirAll(cache.path, DirMode); err != nil {
+		return nil, fmt.Errorf("error creating Beacon cache folder: %w", err)
+	}
+
+	// Index the existing entries
+	err := filepath.WalkDir(cache.path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != entryExtension {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		cache.entries[path] = &entry{
+			size:     info.Size(),
+			lastUsed: info.ModTime(),
+		}
+		cache.size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading Beacon cache folder: %w", err)
+	}
+
+	// Make sure it's within its size limit
+	cache.lock.Lock()
+	cache.evict()
+	cache.lock.Unlock()
+	return cache, nil
+
+}
+
+// Load an entry into value; returns false if it isn't in the cache
+func (c *DiskCache) Get(kind string, key string, value interface{}) bool {
+	path := c.getEntryPath(kind, key)
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	// Drop entries that can't be decoded so they get downloaded again
+	if err := json.Unmarshal(bytes, value); err != nil {
+		c.remove(path)
+		return false
+	}
+
+	// Record the use for eviction, so the entry survives restarts as recently used
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	c.lock.Lock()
+	if cachedEntry, exists := c.entries[path]; exists {
+		cachedEntry.lastUsed = now
+	}
+	c.lock.Unlock()
+	return true
+}
+
+// Save an entry to the cache
+func (c *DiskCache) Put(kind string, key string, value interface{}) error {
+
+	// Don't bother if a single entry is bigger than the cache
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error encoding Beacon cache entry %s/%s: %w", kind, key, err)
+	}
+	size := int64(len(bytes))
+	if size > c.maxSize {
+		return nil
+	}
+
+	// Write it to a temporary file first so readers never see a partial entry
+	path := c.getEntryPath(kind, key)
+	if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+		return fmt.Errorf("error creating Beacon cache folder: %w", err)
+	}
+	tempPath := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tempPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("error writing Beacon cache entry %s/%s: %w", kind, key, err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("error saving Beacon cache entry %s/%s: %w", kind, key, err)
+	}
+
+	// Update the index and stay within the size limit
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if oldEntry, exists := c.entries[path]; exists {
+		c.size -= oldEntry.size
+	}
+	c.entries[path] = &entry{
+		size:     size,
+		lastUsed: time.Now(),
+	}
+	c.size += size
+	c.evict()
+	return nil
+
+}
+
+// Get the path of an entry
+func (c *DiskCache) getEntryPath(kind string, key string) string {
+	return filepath.Join(c.path, kind, key+entryExtension)
+}
+
+// Remove an entry from the cache
+func (c *DiskCache) remove(path string) {
+	_ = os.Remove(path)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if oldEntry, exists := c.entries[path]; exists {
+		c.size -= oldEntry.size
+		delete(c.entries, path)
+	}
+}
+
+// Delete the least recently used entries until the cache is within its size limit; the lock must be held
+func (c *DiskCache) evict() {
+	if c.size <= c.maxSize {
+		return
+	}
+
+	paths := make([]string, 0, len(c.entries))
+	for path := range c.entries {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return c.entries[paths[i]].lastUsed.Before(c.entries[paths[j]].lastUsed)
+	})
+	for _, path := range paths {
+		if c.size <= c.maxSize {
+			break
+		}
+		_ = os.Remove(path)
+		c.size -= c.entries[path].size
+		delete(c.entries, path)
+	}
+}
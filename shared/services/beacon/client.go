@@ -63,6 +63,14 @@ type BeaconBlock struct {
 	Attestations         []AttestationInfo
 	FeeRecipient         common.Address
 	ExecutionBlockNumber uint64
+	ExecutionBlockHash   common.Hash
+	SyncAggregateBits    bitfield.Bitvector512
+}
+
+type BeaconBlockHeader struct {
+	Slot      uint64
+	Root      common.Hash
+	StateRoot common.Hash
 }
 
 type Committee struct {
@@ -129,5 +137,6 @@ type Client interface {
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) ([]Committee, error)
+	GetSyncCommitteeForEpoch(epoch uint64) ([]uint64, error)
 	ChangeWithdrawalCredentials(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error
 }
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/go-bitfield"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Config
+const (
+	RequestSszContentType  = "application/octet-stream"
+	RequestSszAcceptHeader = "application/octet-stream;q=1.0,application/json;q=0.9"
+	ConsensusVersionHeader = "Eth-Consensus-Version"
+)
+
+var (
+	// The Beacon node responded with JSON instead of SSZ, so it doesn't support SSZ for the route
+	errSszNotServed = errors.New("the Beacon node did not respond with SSZ")
+
+	// The response is for a fork that this client can't decode as SSZ
+	errSszUnknownFork = errors.New("the SSZ response is for an unknown fork")
+)
+
+// The parts of a sync aggregate that are read from blocks
+type sszSyncAggregate interface {
+	GetSyncCommitteeBits() bitfield.Bitvector512
+}
+
+// The parts of an execution payload that are read from blocks, which are common to every fork's payload
+type sszExecutionPayload interface {
+	GetFeeRecipient() []byte
+	GetBlockNumber() uint64
+	GetBlockHash() []byte
+}
+
+// Get a Beacon block as SSZ if the Beacon node supports it, since it's much cheaper to download and decode than JSON.
+// Returns false for handled if the block should be requested as JSON instead.
+func (c *StandardHttpClient) getBeaconBlockPreferSsz(blockId string) (beacon.BeaconBlock, bool, bool, error) {
+	if c.sszUnsupported.Load() {
+		return beacon.BeaconBlock{}, false, false, nil
+	}
+
+	block, exists, err := c.getBeaconBlockSsz(blockId)
+	if errors.Is(err, errSszNotServed) {
+		// Don't ask for SSZ again if the Beacon node doesn't support it
+		c.sszUnsupported.Store(true)
+		return beacon.BeaconBlock{}, false, false, nil
+	}
+	if errors.Is(err, errSszUnknownFork) {
+		return beacon.BeaconBlock{}, false, false, nil
+	}
+	return block, exists, true, err
+}
+
+// Get a Beacon block as SSZ
+func (c *StandardHttpClient) getBeaconBlockSsz(blockId string) (beacon.BeaconBlock, bool, error) {
+	responseBody, status, header, err := c.getSszRequest(fmt.Sprintf(RequestBeaconBlockPath, blockId))
+	if err != nil {
+		return beacon.BeaconBlock{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return beacon.BeaconBlock{}, false, nil
+	}
+	if status == http.StatusNotAcceptable || status == http.StatusUnsupportedMediaType {
+		return beacon.BeaconBlock{}, false, errSszNotServed
+	}
+	if status != http.StatusOK {
+		return beacon.BeaconBlock{}, false, fmt.Errorf("Could not get beacon block data: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || mediaType != RequestSszContentType {
+		return beacon.BeaconBlock{}, false, errSszNotServed
+	}
+
+	// Decode the block for its fork
+	var block beacon.BeaconBlock
+	switch strings.ToLower(header.Get(ConsensusVersionHeader)) {
+	case "phase0":
+		signedBlock := &ethpb.SignedBeaconBlock{}
+		if err := signedBlock.UnmarshalSSZ(responseBody); err != nil {
+			return beacon.BeaconBlock{}, false, fmt.Errorf("Could not decode beacon block SSZ: %w", err)
+		}
+		message := signedBlock.GetBlock()
+		block = newSszBeaconBlock(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetAttestations(), nil, nil)
+	case "altair":
+		signedBlock := &ethpb.SignedBeaconBlockAltair{}
+		if err := signedBlock.UnmarshalSSZ(responseBody); err != nil {
+			return beacon.BeaconBlock{}, false, fmt.Errorf("Could not decode beacon block SSZ: %w", err)
+		}
+		message := signedBlock.GetBlock()
+		block = newSszBeaconBlock(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetAttestations(), message.GetBody().GetSyncAggregate(), nil)
+	case "bellatrix":
+		signedBlock := &ethpb.SignedBeaconBlockBellatrix{}
+		if err := signedBlock.UnmarshalSSZ(responseBody); err != nil {
+			return beacon.BeaconBlock{}, false, fmt.Errorf("Could not decode beacon block SSZ: %w", err)
+		}
+		message := signedBlock.GetBlock()
+		block = newSszBeaconBlock(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetAttestations(), message.GetBody().GetSyncAggregate(), message.GetBody().GetExecutionPayload())
+	case "capella":
+		signedBlock := &ethpb.SignedBeaconBlockCapella{}
+		if err := signedBlock.UnmarshalSSZ(responseBody); err != nil {
+			return beacon.BeaconBlock{}, false, fmt.Errorf("Could not decode beacon block SSZ: %w", err)
+		}
+		message := signedBlock.GetBlock()
+		block = newSszBeaconBlock(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetAttestations(), message.GetBody().GetSyncAggregate(), message.GetBody().GetExecutionPayload())
+	default:
+		return beacon.BeaconBlock{}, false, errSszUnknownFork
+	}
+
+	return block, true, nil
+}
+
+// Convert the fields of a decoded SSZ block into a Beacon block
+func newSszBeaconBlock(slot uint64, proposerIndex uint64, attestations []*ethpb.Attestation, syncAggregate sszSyncAggregate, payload sszExecutionPayload) beacon.BeaconBlock {
+	block := beacon.BeaconBlock{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+	}
+
+	// Execution payload only exists after the merge, so check for its existence
+	if payload != nil {
+		block.HasExecutionPayload = true
+		block.FeeRecipient = common.BytesToAddress(payload.GetFeeRecipient())
+		block.ExecutionBlockNumber = payload.GetBlockNumber()
+		block.ExecutionBlockHash = common.BytesToHash(payload.GetBlockHash())
+	}
+
+	// The sync aggregate only exists after Altair
+	if syncAggregate != nil {
+		block.SyncAggregateBits = syncAggregate.GetSyncCommitteeBits()
+	}
+
+	// Add attestation info; the aggregation bits are already in the same bitlist encoding the JSON API uses
+	for _, attestation := range attestations {
+		block.Attestations = append(block.Attestations, beacon.AttestationInfo{
+			AggregationBits: attestation.GetAggregationBits(),
+			SlotIndex:       uint64(attestation.GetData().GetSlot()),
+			CommitteeIndex:  uint64(attestation.GetData().GetCommitteeIndex()),
+		})
+	}
+
+	return block
+}
+
+// Make a GET request to the beacon node that prefers an SSZ response
+func (c *StandardHttpClient) getSszRequest(requestPath string) ([]byte, int, http.Header, error) {
+
+	// Build request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	if err != nil {
+		return []byte{}, 0, nil, err
+	}
+	request.Header.Set("Accept", RequestSszAcceptHeader)
+	c.setHeaders(request)
+
+	// Send request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return []byte{}, 0, nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Get response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, 0, nil, err
+	}
+
+	// Return
+	return body, response.StatusCode, response.Header, nil
+
+}
@@ -9,9 +9,11 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
 	"github.com/rocket-pool/rocketpool-go/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -32,12 +34,14 @@ const (
 	RequestEth2DepositContractMethod       = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath                     = "/eth/v1/beacon/genesis"
 	RequestCommitteePath                   = "/eth/v1/beacon/states/%s/committees"
+	RequestSyncCommitteePath               = "/eth/v1/beacon/states/%s/sync_committees"
 	RequestFinalityCheckpointsPath         = "/eth/v1/beacon/states/%s/finality_checkpoints"
 	RequestForkPath                        = "/eth/v1/beacon/states/%s/fork"
 	RequestValidatorsPath                  = "/eth/v1/beacon/states/%s/validators"
 	RequestVoluntaryExitPath               = "/eth/v1/beacon/pool/voluntary_exits"
 	RequestAttestationsPath                = "/eth/v1/beacon/blocks/%s/attestations"
 	RequestBeaconBlockPath                 = "/eth/v2/beacon/blocks/%s"
+	RequestBeaconBlockHeaderPath           = "/eth/v1/beacon/headers/%s"
 	RequestValidatorSyncDuties             = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
@@ -49,15 +53,29 @@ const (
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+
+	// Custom headers sent with every request, such as the authentication headers required by remote providers
+	headers map[string]string
+
+	// Set once the Beacon node has shown it can't serve SSZ, so blocks are only requested as JSON
+	sszUnsupported *atomic.Bool
 }
 
 // Create a new client instance
 func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
 	return &StandardHttpClient{
 		providerAddress: providerAddress,
+		sszUnsupported:  &atomic.Bool{},
 	}
 }
 
+// Create a new client instance that sends custom headers with every request
+func NewStandardHttpClientWithHeaders(providerAddress string, headers map[string]string) *StandardHttpClient {
+	client := NewStandardHttpClient(providerAddress)
+	client.headers = headers
+	return client
+}
+
 // Close the client connection
 func (c *StandardHttpClient) Close() error {
 	return nil
@@ -469,6 +487,12 @@ func (c *StandardHttpClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth
 }
 
 func (c *StandardHttpClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	// Get the attestations from the SSZ block if possible
+	block, exists, handled, err := c.getBeaconBlockPreferSsz(blockId)
+	if handled {
+		return block.Attestations, exists, err
+	}
+
 	attestations, exists, err := c.getAttestations(blockId)
 	if err != nil {
 		return nil, false, err
@@ -493,6 +517,12 @@ func (c *StandardHttpClient) GetAttestations(blockId string) ([]beacon.Attestati
 }
 
 func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	// Get the block as SSZ if possible
+	sszBlock, exists, handled, err := c.getBeaconBlockPreferSsz(blockId)
+	if handled {
+		return sszBlock, exists, err
+	}
+
 	block, exists, err := c.getBeaconBlock(blockId)
 	if err != nil {
 		return beacon.BeaconBlock{}, false, err
@@ -513,6 +543,12 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 		beaconBlock.HasExecutionPayload = true
 		beaconBlock.FeeRecipient = common.BytesToAddress(block.Data.Message.Body.ExecutionPayload.FeeRecipient)
 		beaconBlock.ExecutionBlockNumber = uint64(block.Data.Message.Body.ExecutionPayload.BlockNumber)
+		beaconBlock.ExecutionBlockHash = common.BytesToHash(block.Data.Message.Body.ExecutionPayload.BlockHash)
+	}
+
+	// The sync aggregate only exists after Altair
+	if block.Data.Message.Body.SyncAggregate != nil {
+		beaconBlock.SyncAggregateBits = bitfield.Bitvector512(block.Data.Message.Body.SyncAggregate.SyncCommitteeBits)
 	}
 
 	// Add attestation info
@@ -555,6 +591,20 @@ func (c *StandardHttpClient) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Comm
 	return committees, nil
 }
 
+// Get the indices of the validators in the sync committee for the given epoch, in committee order
+func (c *StandardHttpClient) GetSyncCommitteeForEpoch(epoch uint64) ([]uint64, error) {
+	response, err := c.getSyncCommittee("head", epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]uint64, len(response.Data.Validators))
+	for i, validator := range response.Data.Validators {
+		validators[i] = uint64(validator)
+	}
+	return validators, nil
+}
+
 // Perform a withdrawal credentials change on a validator
 func (c *StandardHttpClient) ChangeWithdrawalCredentials(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error {
 	return c.postWithdrawalCredentialsChange(BLSToExecutionChangeRequest{
@@ -781,6 +831,29 @@ func (c *StandardHttpClient) getAttestations(blockId string) (AttestationsRespon
 	return attestations, true, nil
 }
 
+// Get the header of a beacon block, such as "finalized" or a block root
+func (c *StandardHttpClient) GetBeaconBlockHeader(blockId string) (beacon.BeaconBlockHeader, bool, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockHeaderPath, blockId))
+	if err != nil {
+		return beacon.BeaconBlockHeader{}, false, fmt.Errorf("Could not get beacon block header: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return beacon.BeaconBlockHeader{}, false, nil
+	}
+	if status != http.StatusOK {
+		return beacon.BeaconBlockHeader{}, false, fmt.Errorf("Could not get beacon block header: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var header BeaconBlockHeaderResponse
+	if err := json.Unmarshal(responseBody, &header); err != nil {
+		return beacon.BeaconBlockHeader{}, false, fmt.Errorf("Could not decode beacon block header: %w", err)
+	}
+	return beacon.BeaconBlockHeader{
+		Slot:      uint64(header.Data.Header.Message.Slot),
+		Root:      common.BytesToHash(header.Data.Root),
+		StateRoot: common.BytesToHash(header.Data.Header.Message.StateRoot),
+	}, true, nil
+}
+
 // Get the target beacon block
 func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse, bool, error) {
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockPath, blockId))
@@ -820,6 +893,22 @@ func (c *StandardHttpClient) getCommittees(stateId string, epoch *uint64) (Commi
 	return committees, nil
 }
 
+// Get the sync committee for an epoch
+func (c *StandardHttpClient) getSyncCommittee(stateId string, epoch uint64) (SyncCommitteeResponse, error) {
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestSyncCommitteePath, stateId) + fmt.Sprintf("?epoch=%d", epoch))
+	if err != nil {
+		return SyncCommitteeResponse{}, fmt.Errorf("Could not get sync committee: %w", err)
+	}
+	if status != http.StatusOK {
+		return SyncCommitteeResponse{}, fmt.Errorf("Could not get sync committee: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var syncCommittee SyncCommitteeResponse
+	if err := json.Unmarshal(responseBody, &syncCommittee); err != nil {
+		return SyncCommitteeResponse{}, fmt.Errorf("Could not decode sync committee: %w", err)
+	}
+	return syncCommittee, nil
+}
+
 // Send withdrawal credentials change request
 func (c *StandardHttpClient) postWithdrawalCredentialsChange(request BLSToExecutionChangeRequest) error {
 	requestArray := []BLSToExecutionChangeRequest{request} // This route must be wrapped in an array
@@ -836,8 +925,15 @@ func (c *StandardHttpClient) postWithdrawalCredentialsChange(request BLSToExecut
 // Make a GET request to the beacon node
 func (c *StandardHttpClient) getRequest(requestPath string) ([]byte, int, error) {
 
+	// Build request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	c.setHeaders(request)
+
 	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+	response, err := http.DefaultClient.Do(request)
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -866,8 +962,16 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	}
 	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
+	// Build request
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), requestBodyReader)
+	if err != nil {
+		return []byte{}, 0, err
+	}
+	request.Header.Set("Content-Type", RequestContentType)
+	c.setHeaders(request)
+
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	response, err := http.DefaultClient.Do(request)
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -885,3 +989,10 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	return body, response.StatusCode, nil
 
 }
+
+// Add the custom headers to a request
+func (c *StandardHttpClient) setHeaders(request *http.Request) {
+	for name, value := range c.headers {
+		request.Header.Set(name, value)
+	}
+}
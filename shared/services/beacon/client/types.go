@@ -69,6 +69,20 @@ type FinalityCheckpointsResponse struct {
 		} `json:"finalized"`
 	} `json:"data"`
 }
+type BeaconBlockHeaderResponse struct {
+	Data struct {
+		Root   byteArray `json:"root"`
+		Header struct {
+			Message struct {
+				Slot          uinteger  `json:"slot"`
+				ProposerIndex uinteger  `json:"proposer_index"`
+				ParentRoot    byteArray `json:"parent_root"`
+				StateRoot     byteArray `json:"state_root"`
+				BodyRoot      byteArray `json:"body_root"`
+			} `json:"message"`
+		} `json:"header"`
+	} `json:"data"`
+}
 type ForkResponse struct {
 	Data struct {
 		PreviousVersion byteArray `json:"previous_version"`
@@ -94,7 +108,11 @@ type BeaconBlockResponse struct {
 				ExecutionPayload *struct {
 					FeeRecipient byteArray `json:"fee_recipient"`
 					BlockNumber  uinteger  `json:"block_number"`
+					BlockHash    byteArray `json:"block_hash"`
 				} `json:"execution_payload"`
+				SyncAggregate *struct {
+					SyncCommitteeBits byteArray `json:"sync_committee_bits"`
+				} `json:"sync_aggregate"`
 			} `json:"body"`
 		} `json:"message"`
 	} `json:"data"`
@@ -132,6 +150,12 @@ type ProposerDuty struct {
 	ValidatorIndex uinteger `json:"validator_index"`
 }
 
+type SyncCommitteeResponse struct {
+	Data struct {
+		Validators []uinteger `json:"validators"`
+	} `json:"data"`
+}
+
 type CommitteesResponse struct {
 	Data []Committee `json:"data"`
 }
@@ -0,0 +1,135 @@
+package calltrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Settings
+const (
+	// How often to check whether tracing has been toggled
+	flagCheckInterval time.Duration = 5 * time.Second
+
+	// The size a trace file can grow to before it's rotated, and the number of rotated files to keep
+	maxFileSize    int64 = 50 * 1024 * 1024
+	maxBackupFiles int   = 3
+
+	fileMode = 0644
+)
+
+// A single traced contract call
+type Record struct {
+	Time       time.Time `json:"time"`
+	Pid        int       `json:"pid"`
+	Contract   string    `json:"contract"`
+	Method     string    `json:"method"`
+	Args       string    `json:"args"`
+	Block      string    `json:"block"`
+	DurationMs int64     `json:"durationMs"`
+	Result     string    `json:"result,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Records contract calls to a rotating trace file while tracing is enabled. Tracing is toggled at runtime by the
+// presence of a flag file, so it can be switched on and off for every daemon at once without restarting them.
+type Tracer struct {
+	flagPath string
+	logPath  string
+
+	// Internal fields
+	enabled   bool
+	lastCheck time.Time
+	lock      *sync.Mutex
+}
+
+// Create a new tracer
+func NewTracer(flagPath string, logPath string) *Tracer {
+	return &Tracer{
+		flagPath: flagPath,
+		logPath:  logPath,
+		lock:     &sync.Mutex{},
+	}
+}
+
+// Check if tracing is enabled
+func (t *Tracer) IsEnabled() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if time.Since(t.lastCheck) >= flagCheckInterval {
+		t.enabled = IsEnabled(t.flagPath)
+		t.lastCheck = time.Now()
+	}
+	return t.enabled
+}
+
+// Write a call to the trace file. Records are appended one line at a time so several processes can share the file.
+func (t *Tracer) Record(record Record) error {
+	record.Pid = os.Getpid()
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error encoding call trace: %w", err)
+	}
+	bytes = append(bytes, '\n')
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	file, err := os.OpenFile(t.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, fileMode)
+	if err != nil {
+		return fmt.Errorf("error opening call trace file: %w", err)
+	}
+	_, err = file.Write(bytes)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("error writing call trace: %w", err)
+	}
+	info, err := file.Stat()
+	_ = file.Close()
+	if err != nil || info.Size() < maxFileSize {
+		return nil
+	}
+	return rotate(t.logPath)
+}
+
+// Check if tracing is enabled by the flag file
+func IsEnabled(flagPath string) bool {
+	_, err := os.Stat(flagPath)
+	return err == nil
+}
+
+// Turn tracing on or off for every process using the flag file
+func SetEnabled(flagPath string, enabled bool) error {
+	if !enabled {
+		err := os.Remove(flagPath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing call trace flag %s: %w", flagPath, err)
+		}
+		return nil
+	}
+
+	err := os.MkdirAll(filepath.Dir(flagPath), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating the call trace flag's directory: %w", err)
+	}
+	err = os.WriteFile(flagPath, []byte(time.Now().UTC().Format(time.RFC3339)), fileMode)
+	if err != nil {
+		return fmt.Errorf("error writing call trace flag %s: %w", flagPath, err)
+	}
+	return nil
+}
+
+// Move the trace file to the first backup, shifting the older backups down and dropping the oldest one
+func rotate(logPath string) error {
+	for i := maxBackupFiles - 1; i > 0; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", logPath, i), fmt.Sprintf("%s.%d", logPath, i+1))
+	}
+	err := os.Rename(logPath, fmt.Sprintf("%s.1", logPath))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error rotating call trace file: %w", err)
+	}
+	return nil
+}
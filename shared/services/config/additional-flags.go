@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A setting that holds custom command line flags for one of the clients
+type additionalFlagsSetting struct {
+	// The client or container the flags are passed to
+	client string
+
+	// The parameter holding the flags
+	param *config.Parameter
+
+	// The flags the Smartnode already sets for the client
+	managedFlags []string
+}
+
+// Split a string of additional command line flags into its arguments, respecting quotes
+func ParseAdditionalFlags(flags string) ([]string, error) {
+	args := []string{}
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	for _, char := range flags {
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			} else {
+				current.WriteRune(char)
+			}
+		case char == '"' || char == '\'':
+			quote = char
+			inArg = true
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(char)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// Get the name of a command line flag without its dashes or value, in lowercase so it can be compared across clients
+func getFlagName(arg string) string {
+	name := strings.TrimLeft(arg, "-")
+	if index := strings.IndexAny(name, "=:"); index >= 0 {
+		name = name[:index]
+	}
+	return strings.ToLower(name)
+}
+
+// Get the additional flags settings of the clients that are enabled, along with the flags the Smartnode sets for each of them
+func (cfg *RocketPoolConfig) getAdditionalFlagsSettings() []additionalFlagsSetting {
+	settings := []additionalFlagsSetting{}
+
+	// Execution client
+	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		switch cfg.ExecutionClient.Value.(config.ExecutionClient) {
+		case config.ExecutionClient_Geth:
+			settings = append(settings, additionalFlagsSetting{"Geth", &cfg.Geth.AdditionalFlags, gethManagedFlags})
+		case config.ExecutionClient_Nethermind:
+			settings = append(settings, additionalFlagsSetting{"Nethermind", &cfg.Nethermind.AdditionalFlags, nethermindManagedFlags})
+		case config.ExecutionClient_Besu:
+			settings = append(settings, additionalFlagsSetting{"Besu", &cfg.Besu.AdditionalFlags, besuManagedFlags})
+		}
+	}
+
+	// Consensus and validator clients
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		switch cfg.ConsensusClient.Value.(config.ConsensusClient) {
+		case config.ConsensusClient_Lighthouse:
+			settings = append(settings,
+				additionalFlagsSetting{"Lighthouse Beacon Node", &cfg.Lighthouse.AdditionalBnFlags, lighthouseBnManagedFlags},
+				additionalFlagsSetting{"Lighthouse Validator Client", &cfg.Lighthouse.AdditionalVcFlags, lighthouseVcManagedFlags},
+			)
+		case config.ConsensusClient_Lodestar:
+			settings = append(settings,
+				additionalFlagsSetting{"Lodestar Beacon Node", &cfg.Lodestar.AdditionalBnFlags, lodestarBnManagedFlags},
+				additionalFlagsSetting{"Lodestar Validator Client", &cfg.Lodestar.AdditionalVcFlags, lodestarVcManagedFlags},
+			)
+		case config.ConsensusClient_Nimbus:
+			settings = append(settings,
+				additionalFlagsSetting{"Nimbus Beacon Node", &cfg.Nimbus.AdditionalBnFlags, nimbusBnManagedFlags},
+				additionalFlagsSetting{"Nimbus Validator Client", &cfg.Nimbus.AdditionalVcFlags, nimbusVcManagedFlags},
+			)
+		case config.ConsensusClient_Prysm:
+			settings = append(settings,
+				additionalFlagsSetting{"Prysm Beacon Node", &cfg.Prysm.AdditionalBnFlags, prysmBnManagedFlags},
+				additionalFlagsSetting{"Prysm Validator Client", &cfg.Prysm.AdditionalVcFlags, prysmVcManagedFlags},
+			)
+		case config.ConsensusClient_Teku:
+			settings = append(settings,
+				additionalFlagsSetting{"Teku Beacon Node", &cfg.Teku.AdditionalBnFlags, tekuBnManagedFlags},
+				additionalFlagsSetting{"Teku Validator Client", &cfg.Teku.AdditionalVcFlags, tekuVcManagedFlags},
+			)
+		}
+	} else {
+		switch cfg.ExternalConsensusClient.Value.(config.ConsensusClient) {
+		case config.ConsensusClient_Lighthouse:
+			settings = append(settings, additionalFlagsSetting{"Lighthouse Validator Client", &cfg.ExternalLighthouse.AdditionalVcFlags, lighthouseVcManagedFlags})
+		case config.ConsensusClient_Lodestar:
+			settings = append(settings, additionalFlagsSetting{"Lodestar Validator Client", &cfg.ExternalLodestar.AdditionalVcFlags, lodestarVcManagedFlags})
+		case config.ConsensusClient_Nimbus:
+			settings = append(settings, additionalFlagsSetting{"Nimbus Validator Client", &cfg.ExternalNimbus.AdditionalVcFlags, nimbusVcManagedFlags})
+		case config.ConsensusClient_Prysm:
+			settings = append(settings, additionalFlagsSetting{"Prysm Validator Client", &cfg.ExternalPrysm.AdditionalVcFlags, prysmVcManagedFlags})
+		case config.ConsensusClient_Teku:
+			settings = append(settings, additionalFlagsSetting{"Teku Validator Client", &cfg.ExternalTeku.AdditionalVcFlags, tekuVcManagedFlags})
+		}
+	}
+
+	// MEV-Boost
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value == config.Mode_Local {
+		settings = append(settings, additionalFlagsSetting{"MEV-Boost", &cfg.MevBoost.AdditionalFlags, mevBoostManagedFlags})
+	}
+
+	// Metrics
+	if cfg.EnableMetrics.Value == true {
+		settings = append(settings,
+			additionalFlagsSetting{"Node Exporter", &cfg.Exporter.AdditionalFlags, nil},
+			additionalFlagsSetting{"Prometheus", &cfg.Prometheus.AdditionalFlags, nil},
+		)
+	}
+
+	return settings
+}
+
+// Check the additional flags of the enabled clients for flags that can't be parsed or that conflict with the ones the Smartnode sets
+func (cfg *RocketPoolConfig) validateAdditionalFlags() []string {
+	errors := []string{}
+	for _, setting := range cfg.getAdditionalFlagsSettings() {
+		value, _ := setting.param.Value.(string)
+		args, err := ParseAdditionalFlags(value)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Your %s additional flags can't be parsed: %s.", setting.client, err.Error()))
+			continue
+		}
+
+		managedFlags := map[string]string{}
+		for _, flag := range setting.managedFlags {
+			managedFlags[getFlagName(flag)] = flag
+		}
+		for _, arg := range args {
+			if !strings.HasPrefix(arg, "-") {
+				continue
+			}
+			if flag, exists := managedFlags[getFlagName(arg)]; exists {
+				errors = append(errors, fmt.Sprintf("Your %s additional flags include %s, which the Smartnode already sets based on your other settings. Please remove it, and change the matching setting in the `rocketpool service config` UI instead.", setting.client, flag))
+			}
+		}
+	}
+	return errors
+}
+
+// Format additional flags as extra entries of a Docker compose command list
+func formatComposeFlags(flags string) string {
+	args, err := ParseAdditionalFlags(flags)
+	if err != nil {
+		args = strings.Fields(flags)
+	}
+	formatted := ""
+	for _, arg := range args {
+		formatted += fmt.Sprintf(", %q", arg)
+	}
+	return formatted
+}
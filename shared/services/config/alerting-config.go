@@ -0,0 +1,221 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	defaultAlertingLowBalanceThreshold float64 = 0.05
+	defaultAlertingSmtpPort            uint16  = 587
+)
+
+// Configuration for the node alerting service
+type AlertingConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for sending alerts
+	EnableAlerting config.Parameter `yaml:"enableAlerting,omitempty"`
+
+	// The node wallet balance (in ETH) below which an alert is sent
+	LowBalanceThreshold config.Parameter `yaml:"lowBalanceThreshold,omitempty"`
+
+	// Toggle for sending an alert when a daemon task crashes
+	AlertOnTaskPanics config.Parameter `yaml:"alertOnTaskPanics,omitempty"`
+
+	// Generic webhook sink
+	WebhookUrl config.Parameter `yaml:"webhookUrl,omitempty"`
+
+	// Discord sink
+	DiscordWebhookUrl config.Parameter `yaml:"discordWebhookUrl,omitempty"`
+
+	// Telegram sink
+	TelegramBotToken config.Parameter `yaml:"telegramBotToken,omitempty"`
+	TelegramChatID   config.Parameter `yaml:"telegramChatId,omitempty"`
+
+	// SMTP sink
+	SmtpHost     config.Parameter `yaml:"smtpHost,omitempty"`
+	SmtpPort     config.Parameter `yaml:"smtpPort,omitempty"`
+	SmtpUsername config.Parameter `yaml:"smtpUsername,omitempty"`
+	SmtpPassword config.Parameter `yaml:"smtpPassword,omitempty"`
+	SmtpFrom     config.Parameter `yaml:"smtpFrom,omitempty"`
+	SmtpTo       config.Parameter `yaml:"smtpTo,omitempty"`
+}
+
+// Generates a new alerting config
+func NewAlertingConfig(cfg *RocketPoolConfig) *AlertingConfig {
+	return &AlertingConfig{
+		Title: "Alerting Settings",
+
+		EnableAlerting: config.Parameter{
+			ID:                 "enableAlerting",
+			Name:               "Enable Alerting",
+			Description:        "Enable this to have the node and watchtower daemons send alerts (such as a low node wallet balance, clients being out of sync, validators losing balance, or failed Oracle DAO duties) to the destinations configured below.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		LowBalanceThreshold: config.Parameter{
+			ID:                 "lowBalanceThreshold",
+			Name:               "Low Balance Threshold",
+			Description:        "An alert will be sent when your node wallet's ETH balance drops below this amount, since it may not be able to pay for transactions.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultAlertingLowBalanceThreshold},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AlertOnTaskPanics: config.Parameter{
+			ID:                 "alertOnTaskPanics",
+			Name:               "Alert on Task Crashes",
+			Description:        "Send an alert when one of the daemon's tasks crashes with an unexpected error (a panic). The daemon recovers from these and keeps running its other tasks, but the crashed task will likely keep failing until the Smartnode is updated, so it's worth reporting.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		WebhookUrl: config.Parameter{
+			ID:                 "webhookUrl",
+			Name:               "Webhook URL",
+			Description:        "(Optional) A URL that alerts will be POSTed to as JSON objects with `severity`, `title`, `message`, `node`, and `time` fields.",
+			Type:               config.ParameterType_String,
+			IsSecret:           true,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		DiscordWebhookUrl: config.Parameter{
+			ID:                 "discordWebhookUrl",
+			Name:               "Discord Webhook URL",
+			Description:        "(Optional) The URL of a Discord channel webhook that alerts will be posted to.",
+			Type:               config.ParameterType_String,
+			IsSecret:           true,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		TelegramBotToken: config.Parameter{
+			ID:                 "telegramBotToken",
+			Name:               "Telegram Bot Token",
+			Description:        "(Optional) The token of the Telegram bot that will send alerts. Requires the Telegram Chat ID to be set as well.",
+			Type:               config.ParameterType_String,
+			IsSecret:           true,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		TelegramChatID: config.Parameter{
+			ID:                 "telegramChatId",
+			Name:               "Telegram Chat ID",
+			Description:        "(Optional) The ID of the Telegram chat the bot will send alerts to.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpHost: config.Parameter{
+			ID:                 "smtpHost",
+			Name:               "SMTP Host",
+			Description:        "(Optional) The hostname of the SMTP server used to send alerts by email. Leave this blank to disable email alerts.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpPort: config.Parameter{
+			ID:                 "smtpPort",
+			Name:               "SMTP Port",
+			Description:        "The port of the SMTP server used to send alerts by email.",
+			Type:               config.ParameterType_Uint16,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultAlertingSmtpPort},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpUsername: config.Parameter{
+			ID:                 "smtpUsername",
+			Name:               "SMTP Username",
+			Description:        "(Optional) The username to log into the SMTP server with.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpPassword: config.Parameter{
+			ID:                 "smtpPassword",
+			Name:               "SMTP Password",
+			Description:        "(Optional) The password to log into the SMTP server with.",
+			Type:               config.ParameterType_String,
+			IsSecret:           true,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpFrom: config.Parameter{
+			ID:                 "smtpFrom",
+			Name:               "Email Sender",
+			Description:        "(Optional) The address alert emails will be sent from.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		SmtpTo: config.Parameter{
+			ID:                 "smtpTo",
+			Name:               "Email Recipients",
+			Description:        "(Optional) A comma-separated list of addresses that alert emails will be sent to.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *AlertingConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.EnableAlerting,
+		&cfg.LowBalanceThreshold,
+		&cfg.AlertOnTaskPanics,
+		&cfg.WebhookUrl,
+		&cfg.DiscordWebhookUrl,
+		&cfg.TelegramBotToken,
+		&cfg.TelegramChatID,
+		&cfg.SmtpHost,
+		&cfg.SmtpPort,
+		&cfg.SmtpUsername,
+		&cfg.SmtpPassword,
+		&cfg.SmtpFrom,
+		&cfg.SmtpTo,
+	}
+}
+
+// The the title for the config
+func (cfg *AlertingConfig) GetConfigTitle() string {
+	return cfg.Title
+}
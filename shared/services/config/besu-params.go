@@ -13,6 +13,27 @@ const (
 	besuStopSignal       string = "SIGTERM"
 )
 
+// The flags the Smartnode sets for Besu from its settings, which can't be overridden with additional flags
+var besuManagedFlags = []string{
+	"--network",
+	"--data-path",
+	"--rpc-http-enabled",
+	"--rpc-http-host",
+	"--rpc-http-port",
+	"--rpc-ws-enabled",
+	"--rpc-ws-host",
+	"--rpc-ws-port",
+	"--engine-rpc-port",
+	"--engine-host-allowlist",
+	"--engine-jwt-secret",
+	"--p2p-port",
+	"--max-peers",
+	"--data-storage-format",
+	"--metrics-enabled",
+	"--metrics-host",
+	"--metrics-port",
+}
+
 // Configuration for Besu
 type BesuConfig struct {
 	Title string `yaml:"-"`
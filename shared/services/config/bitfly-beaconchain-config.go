@@ -32,6 +32,7 @@ func NewBitflyNodeMetricsConfig(cfg *RocketPoolConfig) *BitflyNodeMetricsConfig
 			Name:                 "Beaconcha.in API Key",
 			Description:          "The API key used to authenticate your Beaconcha.in node metrics integration. Can be found in your Beaconcha.in account settings.\n\nPlease visit https://beaconcha.in/user/settings#api to access your account information.",
 			Type:                 config.ParameterType_String,
+			IsSecret:             true,
 			Default:              map[config.Network]interface{}{config.Network_All: defaultBitflyNodeMetricsSecret},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator, config.ContainerID_Eth2},
 			EnvironmentVariables: []string{"BITFLY_NODE_METRICS_SECRET"},
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The prefix of the environment variables that override config parameters
+const OverrideEnvVarPrefix string = "RP_"
+
+// Where the value of a config parameter came from, in order of increasing precedence
+type ConfigSource string
+
+const (
+	ConfigSource_Default     ConfigSource = "default"
+	ConfigSource_File        ConfigSource = "file"
+	ConfigSource_Environment ConfigSource = "environment"
+	ConfigSource_Flag        ConfigSource = "flag"
+)
+
+// Get the environment variable that overrides a parameter, such as RP_SMARTNODE_MANUALMAXFEE
+func GetOverrideEnvVar(section string, id string) string {
+	return OverrideEnvVarPrefix + strings.ToUpper(strings.ReplaceAll(section, "-", "_")) + "_" + strings.ToUpper(id)
+}
+
+// Get the source of a parameter's value
+func (cfg *RocketPoolConfig) GetSettingSource(section string, id string) ConfigSource {
+	source, exists := cfg.settingSources[section+"."+id]
+	if !exists {
+		return ConfigSource_Default
+	}
+	return source
+}
+
+// Get the parameters of each section of the config, including the root section
+func (cfg *RocketPoolConfig) GetSections() map[string][]*config.Parameter {
+	sections := map[string][]*config.Parameter{
+		rootConfigName: cfg.GetParameters(),
+	}
+	for name, subconfig := range cfg.GetSubconfigs() {
+		sections[name] = subconfig.GetParameters()
+	}
+	return sections
+}
+
+// Override parameters with the values of their environment variables and of flags in the form section.id=value.
+// Flags take precedence over environment variables, which take precedence over the settings file.
+// The overrides only apply to this instance of the config; they're never saved to the settings file.
+func (cfg *RocketPoolConfig) ApplyOverrides(flagOverrides []string) error {
+
+	sections := cfg.GetSections()
+	type override struct {
+		value  string
+		source ConfigSource
+	}
+	overrides := map[string]override{}
+
+	// Get the environment variable overrides
+	for section, params := range sections {
+		for _, param := range params {
+			value, exists := os.LookupEnv(GetOverrideEnvVar(section, param.ID))
+			if exists {
+				overrides[section+"."+param.ID] = override{value: value, source: ConfigSource_Environment}
+			}
+		}
+	}
+
+	// Get the flag overrides
+	for _, flagOverride := range flagOverrides {
+		key, value, found := strings.Cut(flagOverride, "=")
+		if !found {
+			return fmt.Errorf("invalid config override [%s]: it must be in the form section.parameter=value", flagOverride)
+		}
+		section, id, found := strings.Cut(key, ".")
+		if !found {
+			return fmt.Errorf("invalid config override [%s]: it must be in the form section.parameter=value", flagOverride)
+		}
+		if findParameter(sections[section], id) == nil {
+			return fmt.Errorf("invalid config override [%s]: there is no parameter %s in section %s", flagOverride, id, section)
+		}
+		overrides[key] = override{value: value, source: ConfigSource_Flag}
+	}
+
+	if cfg.settingSources == nil {
+		cfg.settingSources = map[string]ConfigSource{}
+	}
+
+	// Apply a network override first, so the other parameters' defaults follow it
+	networkKey := "smartnode." + cfg.Smartnode.Network.ID
+	if networkOverride, exists := overrides[networkKey]; exists {
+		networkParam := cfg.Smartnode.Network
+		err := networkParam.Deserialize(map[string]string{networkParam.ID: networkOverride.value}, config.Network_All)
+		if err != nil {
+			return fmt.Errorf("invalid %s override: %w", networkOverride.source, err)
+		}
+		cfg.ChangeNetwork(networkParam.Value.(config.Network))
+		cfg.settingSources[networkKey] = networkOverride.source
+		delete(overrides, networkKey)
+	}
+
+	// Apply the rest
+	network := cfg.Smartnode.Network.Value.(config.Network)
+	for key, override := range overrides {
+		section, id, _ := strings.Cut(key, ".")
+		param := findParameter(sections[section], id)
+		err := param.Deserialize(map[string]string{id: override.value}, network)
+		if err != nil {
+			return fmt.Errorf("invalid %s override for %s: %w", override.source, key, err)
+		}
+		cfg.settingSources[key] = override.source
+	}
+
+	return nil
+
+}
+
+// Find a parameter by its ID
+func findParameter(params []*config.Parameter, id string) *config.Parameter {
+	for _, param := range params {
+		if param.ID == id {
+			return param
+		}
+	}
+	return nil
+}
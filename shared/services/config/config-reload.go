@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The outcome of reloading the config while the daemons are running
+type ConfigReloadResult struct {
+	// The settings that changed and now have their new values, as section.id
+	Applied []string
+
+	// The settings that changed but won't take effect until the daemons are restarted, as section.id
+	RequiresRestart []string
+}
+
+// Check if any setting in a section was applied by the reload
+func (r ConfigReloadResult) AppliedSection(section string) bool {
+	for _, key := range r.Applied {
+		if strings.HasPrefix(key, section+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the parameters that can change while the daemons are running.
+// These are read each time a task runs, or are reapplied by the daemons after a reload; everything else only takes effect on restart.
+func (cfg *RocketPoolConfig) getReloadableParameters() []*config.Parameter {
+	params := []*config.Parameter{
+		&cfg.Smartnode.ManualMaxFee,
+		&cfg.Smartnode.PriorityFee,
+		&cfg.Smartnode.AutoTxGasThreshold,
+		&cfg.Smartnode.DistributeThreshold,
+		&cfg.Smartnode.RewardsRetentionIntervals,
+		&cfg.Smartnode.RewardsPruneMode,
+		&cfg.Smartnode.WatchtowerGasMode,
+		&cfg.Smartnode.WatchtowerMaxFeeOverride,
+		&cfg.Smartnode.WatchtowerPrioFeeOverride,
+		&cfg.Smartnode.WatchtowerDeferralFee,
+		&cfg.Smartnode.WatchtowerMaxDeferral,
+		&cfg.Smartnode.WatchtowerTaskJitter,
+		&cfg.Smartnode.WatchtowerTaskIntervals,
+		&cfg.Smartnode.WatchtowerBalanceTolerance,
+		&cfg.Smartnode.WatchtowerWithholdDeviatingBalances,
+		&cfg.Smartnode.WatchtowerPriceTolerance,
+		&cfg.Smartnode.WatchtowerDissolveBatchSize,
+		&cfg.Smartnode.WatchtowerDissolveGasCeiling,
+		&cfg.Smartnode.LogLevel,
+		&cfg.Smartnode.ModuleLogLevels,
+	}
+	return append(params, cfg.Alerting.GetParameters()...)
+}
+
+// Apply the reloadable settings of a newly loaded config to this one, in place, so everything holding this config sees them.
+// Changes to any other settings are reported but not applied, since the daemons were built around their old values.
+func (cfg *RocketPoolConfig) Reload(newCfg *RocketPoolConfig) ConfigReloadResult {
+	reloadable := map[*config.Parameter]bool{}
+	for _, param := range cfg.getReloadableParameters() {
+		reloadable[param] = true
+	}
+
+	result := ConfigReloadResult{
+		Applied:         []string{},
+		RequiresRestart: []string{},
+	}
+	newSections := newCfg.GetSections()
+	for section, params := range cfg.GetSections() {
+		newParams := newSections[section]
+		for i, param := range params {
+			newParam := newParams[i]
+			if fmt.Sprint(param.Value) == fmt.Sprint(newParam.Value) {
+				continue
+			}
+			key := section + "." + param.ID
+			if !reloadable[param] {
+				result.RequiresRestart = append(result.RequiresRestart, key)
+				continue
+			}
+			param.Value = newParam.Value
+			if cfg.settingSources == nil {
+				cfg.settingSources = map[string]ConfigSource{}
+			}
+			cfg.settingSources[key] = newCfg.GetSettingSource(section, param.ID)
+			result.Applied = append(result.Applied, key)
+		}
+	}
+
+	sort.Strings(result.Applied)
+	sort.Strings(result.RequiresRestart)
+	return result
+}
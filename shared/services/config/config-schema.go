@@ -0,0 +1,280 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings in the root section that aren't parameters
+var rootMetadataSettings = []string{"rpDir", "isNative", "version"}
+
+// The kinds of problem a settings file can have
+type ConfigErrorKind string
+
+const (
+	ConfigErrorKind_InvalidVersion   ConfigErrorKind = "invalid-version"
+	ConfigErrorKind_UnknownSection   ConfigErrorKind = "unknown-section"
+	ConfigErrorKind_UnknownParameter ConfigErrorKind = "unknown-parameter"
+	ConfigErrorKind_InvalidType      ConfigErrorKind = "invalid-type"
+	ConfigErrorKind_InvalidOption    ConfigErrorKind = "invalid-option"
+	ConfigErrorKind_TooLong          ConfigErrorKind = "too-long"
+	ConfigErrorKind_InvalidFormat    ConfigErrorKind = "invalid-format"
+	ConfigErrorKind_InvalidConfig    ConfigErrorKind = "invalid-config"
+)
+
+// The schema of every parameter in the config, for tools that manage the settings file
+type ConfigSchema struct {
+	Version  string                       `json:"version"`
+	Networks []config.Network             `json:"networks"`
+	Sections map[string][]ParameterSchema `json:"sections"`
+}
+
+// The schema of a single parameter
+type ParameterSchema struct {
+	ID                  string                         `json:"id"`
+	Name                string                         `json:"name"`
+	Description         string                         `json:"description"`
+	Type                config.ParameterType           `json:"type"`
+	Default             map[config.Network]interface{} `json:"default"`
+	Options             []ParameterOptionSchema        `json:"options,omitempty"`
+	MaxLength           int                            `json:"maxLength,omitempty"`
+	Regex               string                         `json:"regex,omitempty"`
+	CanBeBlank          bool                           `json:"canBeBlank"`
+	Secret              bool                           `json:"secret"`
+	Advanced            bool                           `json:"advanced"`
+	AffectsContainers   []config.ContainerID           `json:"affectsContainers"`
+	NetworkDescriptions map[config.Network]string      `json:"networkDescriptions,omitempty"`
+}
+
+// The schema of one option of a choice parameter
+type ParameterOptionSchema struct {
+	Value       interface{} `json:"value"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+}
+
+// A problem with a setting in a settings file
+type ConfigError struct {
+	Kind      ConfigErrorKind `json:"kind"`
+	Section   string          `json:"section,omitempty"`
+	Parameter string          `json:"parameter,omitempty"`
+	Value     string          `json:"value,omitempty"`
+	Message   string          `json:"message"`
+}
+
+func (e ConfigError) Error() string {
+	if e.Parameter != "" {
+		return fmt.Sprintf("[%s] %s.%s: %s", e.Kind, e.Section, e.Parameter, e.Message)
+	}
+	if e.Section != "" {
+		return fmt.Sprintf("[%s] %s: %s", e.Kind, e.Section, e.Message)
+	}
+	return fmt.Sprintf("[%s] %s", e.Kind, e.Message)
+}
+
+// Get the schema of every parameter in the config
+func (cfg *RocketPoolConfig) GetSchema() ConfigSchema {
+	schema := ConfigSchema{
+		Version:  shared.RocketPoolVersion,
+		Networks: []config.Network{},
+		Sections: map[string][]ParameterSchema{},
+	}
+	for _, option := range cfg.Smartnode.Network.Options {
+		schema.Networks = append(schema.Networks, option.Value.(config.Network))
+	}
+
+	for name, params := range cfg.GetSections() {
+		schema.Sections[name] = getParameterSchemas(params)
+	}
+	return schema
+}
+
+// Get the schemas of a section's parameters
+func getParameterSchemas(params []*config.Parameter) []ParameterSchema {
+	schemas := []ParameterSchema{}
+	for _, param := range params {
+		schema := ParameterSchema{
+			ID:                  param.ID,
+			Name:                param.Name,
+			Description:         param.Description,
+			Type:                param.Type,
+			Default:             param.Default,
+			MaxLength:           param.MaxLength,
+			Regex:               param.Regex,
+			CanBeBlank:          param.CanBeBlank,
+			Secret:              param.IsSecret,
+			Advanced:            param.Advanced,
+			AffectsContainers:   param.AffectsContainers,
+			NetworkDescriptions: param.DescriptionsByNetwork,
+		}
+		for _, option := range param.Options {
+			schema.Options = append(schema.Options, ParameterOptionSchema{
+				Value:       option.Value,
+				Name:        option.Name,
+				Description: option.Description,
+			})
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas
+}
+
+// Check a settings file's contents against the config's schema, then check that the resulting config is valid.
+// Returns every problem found instead of stopping at the first one.
+func ValidateSettings(settings map[string]map[string]string) []ConfigError {
+	errors := []ConfigError{}
+
+	// Upgrade the settings to the latest version so renamed parameters are recognized
+	err := migration.UpdateConfig(settings)
+	if err != nil {
+		return append(errors, ConfigError{
+			Kind:    ConfigErrorKind_InvalidVersion,
+			Message: err.Error(),
+		})
+	}
+
+	cfg := NewRocketPoolConfig("", false)
+	sections := cfg.GetSections()
+
+	// Check the sections in a stable order so the output is repeatable
+	sectionNames := []string{}
+	for name := range settings {
+		sectionNames = append(sectionNames, name)
+	}
+	sort.Strings(sectionNames)
+	for _, sectionName := range sectionNames {
+		params, exists := sections[sectionName]
+		if !exists {
+			errors = append(errors, ConfigError{
+				Kind:    ConfigErrorKind_UnknownSection,
+				Section: sectionName,
+				Message: "there is no section with this name",
+			})
+			continue
+		}
+		paramsByID := map[string]*config.Parameter{}
+		for _, param := range params {
+			paramsByID[param.ID] = param
+		}
+
+		sectionSettings := settings[sectionName]
+		ids := []string{}
+		for id := range sectionSettings {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			value := sectionSettings[id]
+			param, exists := paramsByID[id]
+			if !exists {
+				if sectionName == rootConfigName && isRootMetadataSetting(id) {
+					continue
+				}
+				errors = append(errors, ConfigError{
+					Kind:      ConfigErrorKind_UnknownParameter,
+					Section:   sectionName,
+					Parameter: id,
+					Value:     value,
+					Message:   "there is no parameter with this ID",
+				})
+				continue
+			}
+			if err := validateParameterValue(param, value); err != nil {
+				err.Section = sectionName
+				errors = append(errors, *err)
+			}
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+
+	// The values are all valid, so check the config as a whole
+	if _, exists := settings[rootConfigName]; !exists {
+		settings[rootConfigName] = map[string]string{}
+	}
+	if _, exists := settings[rootConfigName]["isNative"]; !exists {
+		settings[rootConfigName]["isNative"] = "false"
+	}
+	err = cfg.Deserialize(settings)
+	if err != nil {
+		return append(errors, ConfigError{
+			Kind:    ConfigErrorKind_InvalidConfig,
+			Message: err.Error(),
+		})
+	}
+	for _, message := range cfg.Validate() {
+		errors = append(errors, ConfigError{
+			Kind:    ConfigErrorKind_InvalidConfig,
+			Message: message,
+		})
+	}
+	return errors
+}
+
+// Check a single value against its parameter
+func validateParameterValue(param *config.Parameter, value string) *ConfigError {
+	newError := func(kind ConfigErrorKind, message string) *ConfigError {
+		return &ConfigError{
+			Kind:      kind,
+			Parameter: param.ID,
+			Value:     value,
+			Message:   message,
+		}
+	}
+
+	var err error
+	switch param.Type {
+	case config.ParameterType_Int:
+		_, err = strconv.ParseInt(value, 0, 0)
+	case config.ParameterType_Uint:
+		_, err = strconv.ParseUint(value, 0, 0)
+	case config.ParameterType_Uint16:
+		_, err = strconv.ParseUint(value, 0, 16)
+	case config.ParameterType_Bool:
+		_, err = strconv.ParseBool(value)
+	case config.ParameterType_Float:
+		_, err = strconv.ParseFloat(value, 64)
+	case config.ParameterType_String:
+		if param.IsSecret && config.IsSecretReference(value) {
+			return nil
+		}
+		if param.MaxLength > 0 && len(value) > param.MaxLength {
+			return newError(ConfigErrorKind_TooLong, fmt.Sprintf("the value is %d characters long but the maximum is %d", len(value), param.MaxLength))
+		}
+		if param.Regex != "" && value != "" && !regexp.MustCompile(param.Regex).MatchString(value) {
+			return newError(ConfigErrorKind_InvalidFormat, fmt.Sprintf("the value doesn't match the expected format %s", param.Regex))
+		}
+	case config.ParameterType_Choice:
+		for _, option := range param.Options {
+			if fmt.Sprint(option.Value) == value {
+				return nil
+			}
+		}
+		options := []string{}
+		for _, option := range param.Options {
+			options = append(options, fmt.Sprint(option.Value))
+		}
+		return newError(ConfigErrorKind_InvalidOption, fmt.Sprintf("the value must be one of %v", options))
+	}
+	if err != nil {
+		return newError(ConfigErrorKind_InvalidType, fmt.Sprintf("the value is not a valid %s", param.Type))
+	}
+	return nil
+}
+
+// Check if a root setting is metadata about the settings file rather than a parameter
+func isRootMetadataSetting(id string) bool {
+	for _, setting := range rootMetadataSettings {
+		if setting == id {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -13,6 +16,12 @@ type ExternalExecutionConfig struct {
 
 	// The URL of the websocket endpoint
 	WsUrl config.Parameter `yaml:"wsUrl,omitempty"`
+
+	// Toggle for an endpoint run by a third-party provider
+	RemoteProvider config.Parameter `yaml:"remoteProvider,omitempty"`
+
+	// Custom HTTP headers to send with each request, such as authentication headers
+	HttpHeaders config.Parameter `yaml:"httpHeaders,omitempty"`
 }
 
 // Configuration for external Consensus clients
@@ -22,6 +31,12 @@ type ExternalLighthouseConfig struct {
 	// The URL of the HTTP endpoint
 	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
 
+	// Toggle for an endpoint run by a third-party provider
+	RemoteProvider config.Parameter `yaml:"remoteProvider,omitempty"`
+
+	// Custom HTTP headers to send with each request, such as authentication headers
+	HttpHeaders config.Parameter `yaml:"httpHeaders,omitempty"`
+
 	// Custom proposal graffiti
 	Graffiti config.Parameter `yaml:"graffiti,omitempty"`
 
@@ -42,6 +57,12 @@ type ExternalLodestarConfig struct {
 	// The URL of the HTTP endpoint
 	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
 
+	// Toggle for an endpoint run by a third-party provider
+	RemoteProvider config.Parameter `yaml:"remoteProvider,omitempty"`
+
+	// Custom HTTP headers to send with each request, such as authentication headers
+	HttpHeaders config.Parameter `yaml:"httpHeaders,omitempty"`
+
 	// Custom proposal graffiti
 	Graffiti config.Parameter `yaml:"graffiti,omitempty"`
 
@@ -62,6 +83,12 @@ type ExternalNimbusConfig struct {
 	// The URL of the HTTP endpoint
 	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
 
+	// Toggle for an endpoint run by a third-party provider
+	RemoteProvider config.Parameter `yaml:"remoteProvider,omitempty"`
+
+	// Custom HTTP headers to send with each request, such as authentication headers
+	HttpHeaders config.Parameter `yaml:"httpHeaders,omitempty"`
+
 	// Custom proposal graffiti
 	Graffiti config.Parameter `yaml:"graffiti,omitempty"`
 
@@ -82,6 +109,12 @@ type ExternalPrysmConfig struct {
 	// The URL of the gRPC (REST) endpoint for the Beacon API
 	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
 
+	// Toggle for an endpoint run by a third-party provider
+	RemoteProvider config.Parameter `yaml:"remoteProvider,omitempty"`
+
+	// Custom HTTP headers to send with each request, such as authentication headers
+	HttpHeaders config.Parameter `yaml:"httpHeaders,omitempty"`
+
 	// Custom proposal graffiti
 	Graffiti config.Parameter `yaml:"graffiti,omitempty"`
 
@@ -105,6 +138,12 @@ type ExternalTekuConfig struct {
 	// The URL of the HTTP endpoint
 	HttpUrl config.Parameter `yaml:"httpUrl,omitempty"`
 
+	// Toggle for an endpoint run by a third-party provider
+	RemoteProvider config.Parameter `yaml:"remoteProvider,omitempty"`
+
+	// Custom HTTP headers to send with each request, such as authentication headers
+	HttpHeaders config.Parameter `yaml:"httpHeaders,omitempty"`
+
 	// Custom proposal graffiti
 	Graffiti config.Parameter `yaml:"graffiti,omitempty"`
 
@@ -143,6 +182,31 @@ func NewExternalExecutionConfig(cfg *RocketPoolConfig) *ExternalExecutionConfig
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		RemoteProvider: config.Parameter{
+			ID:                   "remoteProvider",
+			Name:                 "Remote Provider",
+			Description:          "Enable this if this is a remote Execution client run by a third-party provider instead of one you run yourself, so you can use the Smartnode on constrained hardware. The Smartnode will relax its sync checks for it, since providers load-balance across many nodes.\n\n[orange]WARNING: You will be trusting the provider to give you correct information about the chain. A faulty or malicious provider can cause you to miss duties, or to sign things based on false data.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HttpHeaders: config.Parameter{
+			ID:                   "httpHeaders",
+			Name:                 "HTTP Headers",
+			Description:          "Custom HTTP headers to send with every request to this Execution client, such as the authentication or request signing headers your provider requires. Use the format `Name: value`, and separate multiple headers with semicolons.\n\nNOTE: These are only sent by the Smartnode itself, not by the Validator Client.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -163,6 +227,31 @@ func NewExternalLighthouseConfig(cfg *RocketPoolConfig) *ExternalLighthouseConfi
 			OverwriteOnUpgrade:   false,
 		},
 
+		RemoteProvider: config.Parameter{
+			ID:                   "remoteProvider",
+			Name:                 "Remote Provider",
+			Description:          "Enable this if this is a remote Beacon node run by a third-party provider instead of one you run yourself, so you can use the Smartnode on constrained hardware. The Smartnode will relax its sync checks for it, since providers load-balance across many nodes.\n\n[orange]WARNING: You will be trusting the provider to give you correct information about the chain. A faulty or malicious provider can cause you to miss duties, or to sign things based on false data.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HttpHeaders: config.Parameter{
+			ID:                   "httpHeaders",
+			Name:                 "HTTP Headers",
+			Description:          "Custom HTTP headers to send with every request to this Beacon node, such as the authentication or request signing headers your provider requires. Use the format `Name: value`, and separate multiple headers with semicolons.\n\nNOTE: These are only sent by the Smartnode itself, not by the Validator Client.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		Graffiti: config.Parameter{
 			ID:                   GraffitiID,
 			Name:                 "Custom Graffiti",
@@ -235,6 +324,31 @@ func NewExternalLodestarConfig(cfg *RocketPoolConfig) *ExternalLodestarConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		RemoteProvider: config.Parameter{
+			ID:                   "remoteProvider",
+			Name:                 "Remote Provider",
+			Description:          "Enable this if this is a remote Beacon node run by a third-party provider instead of one you run yourself, so you can use the Smartnode on constrained hardware. The Smartnode will relax its sync checks for it, since providers load-balance across many nodes.\n\n[orange]WARNING: You will be trusting the provider to give you correct information about the chain. A faulty or malicious provider can cause you to miss duties, or to sign things based on false data.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HttpHeaders: config.Parameter{
+			ID:                   "httpHeaders",
+			Name:                 "HTTP Headers",
+			Description:          "Custom HTTP headers to send with every request to this Beacon node, such as the authentication or request signing headers your provider requires. Use the format `Name: value`, and separate multiple headers with semicolons.\n\nNOTE: These are only sent by the Smartnode itself, not by the Validator Client.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		Graffiti: config.Parameter{
 			ID:                   GraffitiID,
 			Name:                 "Custom Graffiti",
@@ -308,6 +422,31 @@ func NewExternalNimbusConfig(cfg *RocketPoolConfig) *ExternalNimbusConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		RemoteProvider: config.Parameter{
+			ID:                   "remoteProvider",
+			Name:                 "Remote Provider",
+			Description:          "Enable this if this is a remote Beacon node run by a third-party provider instead of one you run yourself, so you can use the Smartnode on constrained hardware. The Smartnode will relax its sync checks for it, since providers load-balance across many nodes.\n\n[orange]WARNING: You will be trusting the provider to give you correct information about the chain. A faulty or malicious provider can cause you to miss duties, or to sign things based on false data.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HttpHeaders: config.Parameter{
+			ID:                   "httpHeaders",
+			Name:                 "HTTP Headers",
+			Description:          "Custom HTTP headers to send with every request to this Beacon node, such as the authentication or request signing headers your provider requires. Use the format `Name: value`, and separate multiple headers with semicolons.\n\nNOTE: These are only sent by the Smartnode itself, not by the Validator Client.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		Graffiti: config.Parameter{
 			ID:                   GraffitiID,
 			Name:                 "Custom Graffiti",
@@ -380,6 +519,31 @@ func NewExternalPrysmConfig(cfg *RocketPoolConfig) *ExternalPrysmConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		RemoteProvider: config.Parameter{
+			ID:                   "remoteProvider",
+			Name:                 "Remote Provider",
+			Description:          "Enable this if this is a remote Beacon node run by a third-party provider instead of one you run yourself, so you can use the Smartnode on constrained hardware. The Smartnode will relax its sync checks for it, since providers load-balance across many nodes.\n\n[orange]WARNING: You will be trusting the provider to give you correct information about the chain. A faulty or malicious provider can cause you to miss duties, or to sign things based on false data.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HttpHeaders: config.Parameter{
+			ID:                   "httpHeaders",
+			Name:                 "HTTP Headers",
+			Description:          "Custom HTTP headers to send with every request to this Beacon node, such as the authentication or request signing headers your provider requires. Use the format `Name: value`, and separate multiple headers with semicolons.\n\nNOTE: These are only sent by the Smartnode itself, not by the Validator Client.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		JsonRpcUrl: config.Parameter{
 			ID:                   "jsonRpcUrl",
 			Name:                 "gRPC URL",
@@ -464,6 +628,31 @@ func NewExternalTekuConfig(cfg *RocketPoolConfig) *ExternalTekuConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		RemoteProvider: config.Parameter{
+			ID:                   "remoteProvider",
+			Name:                 "Remote Provider",
+			Description:          "Enable this if this is a remote Beacon node run by a third-party provider instead of one you run yourself, so you can use the Smartnode on constrained hardware. The Smartnode will relax its sync checks for it, since providers load-balance across many nodes.\n\n[orange]WARNING: You will be trusting the provider to give you correct information about the chain. A faulty or malicious provider can cause you to miss duties, or to sign things based on false data.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HttpHeaders: config.Parameter{
+			ID:                   "httpHeaders",
+			Name:                 "HTTP Headers",
+			Description:          "Custom HTTP headers to send with every request to this Beacon node, such as the authentication or request signing headers your provider requires. Use the format `Name: value`, and separate multiple headers with semicolons.\n\nNOTE: These are only sent by the Smartnode itself, not by the Validator Client.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		Graffiti: config.Parameter{
 			ID:                   GraffitiID,
 			Name:                 "Custom Graffiti",
@@ -512,6 +701,8 @@ func (cfg *ExternalExecutionConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.HttpUrl,
 		&cfg.WsUrl,
+		&cfg.RemoteProvider,
+		&cfg.HttpHeaders,
 	}
 }
 
@@ -519,6 +710,8 @@ func (cfg *ExternalExecutionConfig) GetParameters() []*config.Parameter {
 func (cfg *ExternalLighthouseConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.HttpUrl,
+		&cfg.RemoteProvider,
+		&cfg.HttpHeaders,
 		&cfg.Graffiti,
 		&cfg.DoppelgangerDetection,
 		&cfg.ContainerTag,
@@ -530,6 +723,8 @@ func (cfg *ExternalLighthouseConfig) GetParameters() []*config.Parameter {
 func (cfg *ExternalNimbusConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.HttpUrl,
+		&cfg.RemoteProvider,
+		&cfg.HttpHeaders,
 		&cfg.Graffiti,
 		&cfg.DoppelgangerDetection,
 		&cfg.ContainerTag,
@@ -541,6 +736,8 @@ func (cfg *ExternalNimbusConfig) GetParameters() []*config.Parameter {
 func (cfg *ExternalLodestarConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.HttpUrl,
+		&cfg.RemoteProvider,
+		&cfg.HttpHeaders,
 		&cfg.Graffiti,
 		&cfg.DoppelgangerDetection,
 		&cfg.ContainerTag,
@@ -552,6 +749,8 @@ func (cfg *ExternalLodestarConfig) GetParameters() []*config.Parameter {
 func (cfg *ExternalPrysmConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.HttpUrl,
+		&cfg.RemoteProvider,
+		&cfg.HttpHeaders,
 		&cfg.JsonRpcUrl,
 		&cfg.Graffiti,
 		&cfg.DoppelgangerDetection,
@@ -564,6 +763,8 @@ func (cfg *ExternalPrysmConfig) GetParameters() []*config.Parameter {
 func (cfg *ExternalTekuConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.HttpUrl,
+		&cfg.RemoteProvider,
+		&cfg.HttpHeaders,
 		&cfg.Graffiti,
 		&cfg.ContainerTag,
 		&cfg.AdditionalVcFlags,
@@ -620,6 +821,56 @@ func (cfg *ExternalTekuConfig) GetApiUrl() string {
 	return cfg.HttpUrl.Value.(string)
 }
 
+// Get the custom HTTP headers for API requests from the config
+func (cfg *ExternalLighthouseConfig) GetApiHeaders() string {
+	return cfg.HttpHeaders.Value.(string)
+}
+
+// Get the custom HTTP headers for API requests from the config
+func (cfg *ExternalNimbusConfig) GetApiHeaders() string {
+	return cfg.HttpHeaders.Value.(string)
+}
+
+// Get the custom HTTP headers for API requests from the config
+func (cfg *ExternalLodestarConfig) GetApiHeaders() string {
+	return cfg.HttpHeaders.Value.(string)
+}
+
+// Get the custom HTTP headers for API requests from the config
+func (cfg *ExternalPrysmConfig) GetApiHeaders() string {
+	return cfg.HttpHeaders.Value.(string)
+}
+
+// Get the custom HTTP headers for API requests from the config
+func (cfg *ExternalTekuConfig) GetApiHeaders() string {
+	return cfg.HttpHeaders.Value.(string)
+}
+
+// Check if the client is run by a remote provider
+func (cfg *ExternalLighthouseConfig) IsRemoteProvider() bool {
+	return cfg.RemoteProvider.Value == true
+}
+
+// Check if the client is run by a remote provider
+func (cfg *ExternalNimbusConfig) IsRemoteProvider() bool {
+	return cfg.RemoteProvider.Value == true
+}
+
+// Check if the client is run by a remote provider
+func (cfg *ExternalLodestarConfig) IsRemoteProvider() bool {
+	return cfg.RemoteProvider.Value == true
+}
+
+// Check if the client is run by a remote provider
+func (cfg *ExternalPrysmConfig) IsRemoteProvider() bool {
+	return cfg.RemoteProvider.Value == true
+}
+
+// Check if the client is run by a remote provider
+func (cfg *ExternalTekuConfig) IsRemoteProvider() bool {
+	return cfg.RemoteProvider.Value == true
+}
+
 // Get the name of the client
 func (cfg *ExternalLighthouseConfig) GetName() string {
 	return "Lighthouse"
@@ -674,3 +925,21 @@ func (cfg *ExternalPrysmConfig) GetConfigTitle() string {
 func (cfg *ExternalTekuConfig) GetConfigTitle() string {
 	return cfg.Title
 }
+
+// Parse a list of custom HTTP headers in the `Name: value; Name: value` format
+func ParseHttpHeaders(headers string) (map[string]string, error) {
+	parsedHeaders := map[string]string{}
+	for _, header := range strings.Split(headers, ";") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		name, value, found := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("header [%s] is not in the `Name: value` format", header)
+		}
+		parsedHeaders[name] = strings.TrimSpace(value)
+	}
+	return parsedHeaders, nil
+}
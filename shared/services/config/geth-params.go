@@ -15,6 +15,35 @@ const (
 	gethStopSignal       string = "SIGTERM"
 )
 
+// The flags the Smartnode sets for Geth from its settings, which can't be overridden with additional flags
+var gethManagedFlags = []string{
+	"--mainnet",
+	"--goerli",
+	"--sepolia",
+	"--datadir",
+	"--http",
+	"--http.addr",
+	"--http.port",
+	"--http.api",
+	"--http.vhosts",
+	"--ws",
+	"--ws.addr",
+	"--ws.port",
+	"--ws.api",
+	"--authrpc.addr",
+	"--authrpc.port",
+	"--authrpc.vhosts",
+	"--authrpc.jwtsecret",
+	"--port",
+	"--discovery.port",
+	"--cache",
+	"--maxpeers",
+	"--syncmode",
+	"--metrics",
+	"--metrics.addr",
+	"--metrics.port",
+}
+
 // Configuration for Geth
 type GethConfig struct {
 	Title string `yaml:"-"`
@@ -13,6 +13,39 @@ const (
 	defaultLhMaxPeers         uint16 = 80
 )
 
+// The flags the Smartnode sets for the Lighthouse BN from its settings, which can't be overridden with additional flags
+var lighthouseBnManagedFlags = []string{
+	"--network",
+	"--datadir",
+	"--port",
+	"--discovery-port",
+	"--execution-endpoint",
+	"--execution-jwt",
+	"--http",
+	"--http-address",
+	"--http-port",
+	"--target-peers",
+	"--checkpoint-sync-url",
+	"--metrics",
+	"--metrics-address",
+	"--metrics-port",
+}
+
+// The flags the Smartnode sets for the Lighthouse VC from its settings, which can't be overridden with additional flags
+var lighthouseVcManagedFlags = []string{
+	"--network",
+	"--datadir",
+	"--beacon-nodes",
+	"--init-slashing-protection",
+	"--graffiti",
+	"--graffiti-file",
+	"--suggested-fee-recipient",
+	"--metrics",
+	"--metrics-address",
+	"--metrics-port",
+	"--enable-doppelganger-protection",
+}
+
 // Configuration for Lighthouse
 type LighthouseConfig struct {
 	Title string `yaml:"-"`
@@ -10,6 +10,38 @@ const (
 	defaultLodestarMaxPeers uint16 = 50
 )
 
+// The flags the Smartnode sets for the Lodestar BN from its settings, which can't be overridden with additional flags
+var lodestarBnManagedFlags = []string{
+	"--network",
+	"--dataDir",
+	"--port",
+	"--execution.urls",
+	"--jwt-secret",
+	"--rest",
+	"--rest.address",
+	"--rest.port",
+	"--targetPeers",
+	"--checkpointSyncUrl",
+	"--metrics",
+	"--metrics.address",
+	"--metrics.port",
+}
+
+// The flags the Smartnode sets for the Lodestar VC from its settings, which can't be overridden with additional flags
+var lodestarVcManagedFlags = []string{
+	"--network",
+	"--dataDir",
+	"--beacon-nodes",
+	"--keystoresDir",
+	"--secretsDir",
+	"--graffiti",
+	"--suggestedFeeRecipient",
+	"--metrics",
+	"--metrics.address",
+	"--metrics.port",
+	"--doppelgangerProtection",
+}
+
 // Configuration for Lodestar
 type LodestarConfig struct {
 	Title string `yaml:"-"`
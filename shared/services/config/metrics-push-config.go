@@ -0,0 +1,144 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	defaultMetricsPushInterval uint64 = 60
+	defaultMetricsPushJob      string = "rocketpool"
+)
+
+// Configuration for pushing the Smartnode's metrics to a remote service
+type MetricsPushConfig struct {
+	Title string `yaml:"-"`
+
+	// Where to push the metrics to
+	Mode config.Parameter `yaml:"mode,omitempty"`
+
+	// The URL of the Pushgateway or remote-write endpoint
+	Url config.Parameter `yaml:"url,omitempty"`
+
+	// The username for basic authentication
+	Username config.Parameter `yaml:"username,omitempty"`
+
+	// The password or API key for basic authentication
+	Password config.Parameter `yaml:"password,omitempty"`
+
+	// How often to push the metrics, in seconds
+	Interval config.Parameter `yaml:"interval,omitempty"`
+
+	// The job name to push the metrics under
+	Job config.Parameter `yaml:"job,omitempty"`
+}
+
+// Generates a new metrics push config
+func NewMetricsPushConfig(cfg *RocketPoolConfig) *MetricsPushConfig {
+	return &MetricsPushConfig{
+		Title: "Metrics Push Settings",
+
+		Mode: config.Parameter{
+			ID:                   "mode",
+			Name:                 "Push Metrics",
+			Description:          "Push the Smartnode's metrics to a remote service as well as serving them to the local Prometheus. This is useful if your node is behind a NAT and you want to view its metrics with a hosted service such as Grafana Cloud or VictoriaMetrics.\n\nOnly the Smartnode's own metrics are pushed, not the metrics from your clients or the Node Exporter.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.MetricsPushMode_Disabled},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Disabled",
+				Description: "Only serve the metrics to the local Prometheus.",
+				Value:       config.MetricsPushMode_Disabled,
+			}, {
+				Name:        "Pushgateway",
+				Description: "Push the metrics to a Prometheus Pushgateway.",
+				Value:       config.MetricsPushMode_Pushgateway,
+			}, {
+				Name:        "Remote Write",
+				Description: "Send the metrics to an endpoint that supports the Prometheus remote-write protocol, such as Grafana Cloud or VictoriaMetrics.",
+				Value:       config.MetricsPushMode_RemoteWrite,
+			}},
+		},
+
+		Url: config.Parameter{
+			ID:                   "url",
+			Name:                 "Push URL",
+			Description:          "The URL of the Pushgateway (e.g. http://pushgateway.example.com:9091), or the full URL of the remote-write endpoint (e.g. https://prometheus-prod-01-eu-west-0.grafana.net/api/prom/push).",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Username: config.Parameter{
+			ID:                   "username",
+			Name:                 "Push Username",
+			Description:          "The username to authenticate with, if the service requires basic authentication. For Grafana Cloud, this is the instance ID of your Prometheus data source.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Password: config.Parameter{
+			ID:                   "password",
+			Name:                 "Push Password",
+			Description:          "The password or API key to authenticate with, if the service requires basic authentication.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Interval: config.Parameter{
+			ID:                   "interval",
+			Name:                 "Push Interval",
+			Description:          "How often to push the metrics, in seconds.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultMetricsPushInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Job: config.Parameter{
+			ID:                   "job",
+			Name:                 "Push Job Name",
+			Description:          "The job label the metrics are pushed under. If you push metrics from more than one node to the same service, give each one a different job name.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultMetricsPushJob},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *MetricsPushConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Mode,
+		&cfg.Url,
+		&cfg.Username,
+		&cfg.Password,
+		&cfg.Interval,
+		&cfg.Job,
+	}
+}
+
+// The the title for the config
+func (cfg *MetricsPushConfig) GetConfigTitle() string {
+	return cfg.Title
+}
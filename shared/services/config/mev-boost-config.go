@@ -21,6 +21,16 @@ const (
 	AllMevRelayDescription      string = "and allow for all types of MEV (including sandwich attacks)."
 )
 
+// The flags the Smartnode sets for MEV-Boost from its settings, which can't be overridden with additional flags
+var mevBoostManagedFlags = []string{
+	"-mainnet",
+	"-goerli",
+	"-sepolia",
+	"-addr",
+	"-relays",
+	"-relay-check",
+}
+
 // Configuration for MEV-Boost
 type MevBoostConfig struct {
 	Title string `yaml:"-"`
@@ -2,6 +2,7 @@ package migration
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-version"
@@ -9,54 +10,93 @@ import (
 
 type ConfigUpgrader struct {
 	Version     *version.Version
+	Description string
 	UpgradeFunc func(serializedConfig map[string]map[string]string) error
 }
 
-func UpdateConfig(serializedConfig map[string]map[string]string) error {
+// The kinds of change an upgrade can make to a setting
+type SettingChangeKind string
 
-	// Get the config's version
-	configVersion, err := getVersionFromConfig(serializedConfig)
-	if err != nil {
-		return err
-	}
+const (
+	SettingChangeKind_Added   SettingChangeKind = "added"
+	SettingChangeKind_Removed SettingChangeKind = "removed"
+	SettingChangeKind_Changed SettingChangeKind = "changed"
+)
+
+// A difference between two versions of a settings file
+type SettingChange struct {
+	Kind     SettingChangeKind
+	Section  string
+	ID       string
+	OldValue string
+	NewValue string
+}
+
+// Get every config upgrader, ordered by the version they upgrade from
+func GetUpgraders() ([]ConfigUpgrader, error) {
 
 	// Create versions
 	v131, err := parseVersion("1.3.1")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	v151, err := parseVersion("1.5.1")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create the collection of upgraders
 	upgraders := []ConfigUpgrader{
 		{
 			Version:     v131,
+			Description: "Move the P2P port and ethstats settings from Geth into the common Execution client settings",
 			UpgradeFunc: upgradeFromV131,
 		}, {
 			Version:     v151,
-			UpgradeFunc: upgradeFromV151,
+			Description: "Rename Nimbus's additionalFlags to additionalBnFlags",
+			UpgradeFunc: renameParameter("nimbus", "additionalFlags", "additionalBnFlags"),
 		},
 	}
 
-	// Find the index of the provided config's version
-	targetIndex := -1
-	for i, upgrader := range upgraders {
+	return upgraders, nil
+
+}
+
+// Get the upgraders that will be applied to the given config, in the order they'll be applied
+func GetPendingUpgrades(serializedConfig map[string]map[string]string) ([]ConfigUpgrader, error) {
+
+	// Get the config's version
+	configVersion, err := getVersionFromConfig(serializedConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	upgraders, err := GetUpgraders()
+	if err != nil {
+		return nil, err
+	}
+
+	// Every upgrader for the config's version or a later one applies
+	pending := []ConfigUpgrader{}
+	for _, upgrader := range upgraders {
 		if configVersion.LessThanOrEqual(upgrader.Version) {
-			targetIndex = i
+			pending = append(pending, upgrader)
 		}
 	}
+	return pending, nil
 
-	// If there are no upgrades to apply, return
-	if targetIndex == -1 {
-		return nil
+}
+
+func UpdateConfig(serializedConfig map[string]map[string]string) error {
+
+	// Get the upgrades to apply
+	upgraders, err := GetPendingUpgrades(serializedConfig)
+	if err != nil {
+		return err
 	}
 
-	// If there are upgrades, start at the first applicable index and apply them all in series
-	for i := targetIndex; i < len(upgraders); i++ {
-		upgrader := upgraders[i]
+	// Apply them all in series
+	for _, upgrader := range upgraders {
 		err = upgrader.UpgradeFunc(serializedConfig)
 		if err != nil {
 			return fmt.Errorf("error applying upgrade for config version %s: %w", upgrader.Version.String(), err)
@@ -67,6 +107,78 @@ func UpdateConfig(serializedConfig map[string]map[string]string) error {
 
 }
 
+// Create a deep copy of a serialized config, so upgrades can be previewed without changing it
+func CopySettings(serializedConfig map[string]map[string]string) map[string]map[string]string {
+	settingsCopy := map[string]map[string]string{}
+	for section, params := range serializedConfig {
+		sectionCopy := map[string]string{}
+		for id, value := range params {
+			sectionCopy[id] = value
+		}
+		settingsCopy[section] = sectionCopy
+	}
+	return settingsCopy
+}
+
+// Get the differences between two serialized configs, ordered by section and parameter
+func DiffSettings(before map[string]map[string]string, after map[string]map[string]string) []SettingChange {
+	changes := []SettingChange{}
+	for section, params := range before {
+		for id, oldValue := range params {
+			newValue, exists := after[section][id]
+			if !exists {
+				changes = append(changes, SettingChange{Kind: SettingChangeKind_Removed, Section: section, ID: id, OldValue: oldValue})
+			} else if newValue != oldValue {
+				changes = append(changes, SettingChange{Kind: SettingChangeKind_Changed, Section: section, ID: id, OldValue: oldValue, NewValue: newValue})
+			}
+		}
+	}
+	for section, params := range after {
+		for id, newValue := range params {
+			if _, exists := before[section][id]; !exists {
+				changes = append(changes, SettingChange{Kind: SettingChangeKind_Added, Section: section, ID: id, NewValue: newValue})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i int, j int) bool {
+		if changes[i].Section != changes[j].Section {
+			return changes[i].Section < changes[j].Section
+		}
+		return changes[i].ID < changes[j].ID
+	})
+	return changes
+}
+
+// Create an upgrade that renames a parameter within its section
+func renameParameter(section string, oldID string, newID string) func(serializedConfig map[string]map[string]string) error {
+	return moveParameter(section, oldID, section, newID)
+}
+
+// Create an upgrade that moves a parameter to a different section or ID, keeping its value.
+// It does nothing if the parameter isn't in the config, so the parameter will use its default value.
+func moveParameter(oldSection string, oldID string, newSection string, newID string) func(serializedConfig map[string]map[string]string) error {
+	return func(serializedConfig map[string]map[string]string) error {
+		oldSettings, exists := serializedConfig[oldSection]
+		if !exists {
+			return nil
+		}
+		value, exists := oldSettings[oldID]
+		if !exists {
+			return nil
+		}
+
+		newSettings, exists := serializedConfig[newSection]
+		if !exists {
+			newSettings = map[string]string{}
+			serializedConfig[newSection] = newSettings
+		}
+		newSettings[newID] = value
+		delete(oldSettings, oldID)
+		return nil
+	}
+}
+
 // Get the Smartnode version that the given config was built with
 func getVersionFromConfig(serializedConfig map[string]map[string]string) (*version.Version, error) {
 	rootConfig, exists := serializedConfig["root"]
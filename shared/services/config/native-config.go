@@ -6,6 +6,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
+// The default user for the Native mode systemd services
+const defaultNativeServiceUser string = "rp"
+
 // Configuration for Native mode
 type NativeConfig struct {
 	Title string `yaml:"-"`
@@ -24,6 +27,18 @@ type NativeConfig struct {
 
 	// The command for stopping the validator container in native mode
 	ValidatorStopCommand config.Parameter `yaml:"validatorStopCommand,omitempty"`
+
+	// The command that runs the Execution client as a systemd service
+	EcCommand config.Parameter `yaml:"ecCommand,omitempty"`
+
+	// The command that runs the Beacon Node as a systemd service
+	BnCommand config.Parameter `yaml:"bnCommand,omitempty"`
+
+	// The command that runs the Validator Client as a systemd service
+	VcCommand config.Parameter `yaml:"vcCommand,omitempty"`
+
+	// The user that the systemd services run as
+	ServiceUser config.Parameter `yaml:"serviceUser,omitempty"`
 }
 
 // Generates a new Smartnode configuration
@@ -112,6 +127,54 @@ func NewNativeConfig(cfg *RocketPoolConfig) *NativeConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		EcCommand: config.Parameter{
+			ID:                   "ecCommand",
+			Name:                 "Execution Client Command",
+			Description:          "The full command line that runs your Execution client (e.g. `/usr/local/bin/geth --mainnet --datadir /srv/geth ...`). If you set this, `rocketpool service native install` will create a systemd service for it so the Smartnode can start, stop, and monitor it. Leave it blank if you manage your Execution client yourself.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BnCommand: config.Parameter{
+			ID:                   "bnCommand",
+			Name:                 "Beacon Node Command",
+			Description:          "The full command line that runs your Consensus client's Beacon Node. If you set this, `rocketpool service native install` will create a systemd service for it so the Smartnode can start, stop, and monitor it. Leave it blank if you manage your Beacon Node yourself.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VcCommand: config.Parameter{
+			ID:                   "vcCommand",
+			Name:                 "Validator Client Command",
+			Description:          "The full command line that runs your Consensus client's Validator Client. If you set this, `rocketpool service native install` will create a systemd service for it so the Smartnode can start, stop, and monitor it. Leave it blank if you manage your Validator Client yourself.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ServiceUser: config.Parameter{
+			ID:                   "serviceUser",
+			Name:                 "Service User",
+			Description:          "The user account that the systemd services created by `rocketpool service native install` will run as. It must be able to read and write the Smartnode's data folder.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNativeServiceUser},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 
 }
@@ -124,6 +187,10 @@ func (cfg *NativeConfig) GetParameters() []*config.Parameter {
 		&cfg.CcHttpUrl,
 		&cfg.ValidatorRestartCommand,
 		&cfg.ValidatorStopCommand,
+		&cfg.EcCommand,
+		&cfg.BnCommand,
+		&cfg.VcCommand,
+		&cfg.ServiceUser,
 	}
 }
 
@@ -15,6 +15,28 @@ const (
 	nethermindStopSignal       string = "SIGTERM"
 )
 
+// The flags the Smartnode sets for Nethermind from its settings, which can't be overridden with additional flags
+var nethermindManagedFlags = []string{
+	"--config",
+	"--datadir",
+	"--Init.WebSocketsEnabled",
+	"--JsonRpc.Enabled",
+	"--JsonRpc.Host",
+	"--JsonRpc.Port",
+	"--JsonRpc.WebSocketsPort",
+	"--JsonRpc.EngineHost",
+	"--JsonRpc.EnginePort",
+	"--JsonRpc.JwtSecretFile",
+	"--JsonRpc.AdditionalRpcUrls",
+	"--JsonRpc.EnabledModules",
+	"--Network.P2PPort",
+	"--Network.DiscoveryPort",
+	"--Network.MaxActivePeers",
+	"--Pruning.CacheMb",
+	"--Metrics.Enabled",
+	"--Metrics.ExposePort",
+}
+
 // Configuration for Nethermind
 type NethermindConfig struct {
 	Title string `yaml:"-"`
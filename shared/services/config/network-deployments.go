@@ -0,0 +1,380 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The contracts and services a Rocket Pool deployment uses on an Ethereum network
+type NetworkDeployment struct {
+	// The URL to provide the user so they can follow pending transactions
+	TxWatchUrl string `json:"txWatchUrl,omitempty"`
+
+	// The URL to use for staking rETH
+	StakeUrl string `json:"stakeUrl,omitempty"`
+
+	// The execution chain ID
+	ChainID uint `json:"chainID,omitempty"`
+
+	// The hash of the execution chain's genesis block, used to check that the Execution client is on the right chain
+	GenesisBlockHash string `json:"genesisBlockHash,omitempty"`
+
+	// The Beacon Chain's genesis time, used to check that the Beacon Node is on the right chain
+	BeaconGenesisTime uint64 `json:"beaconGenesisTime,omitempty"`
+
+	// The contract address of RocketStorage
+	StorageAddress string `json:"storageAddress,omitempty"`
+
+	// The contract address of the 1inch oracle
+	OneInchOracleAddress string `json:"oneInchOracleAddress,omitempty"`
+
+	// The contract address of the RPL token
+	RplTokenAddress string `json:"rplTokenAddress,omitempty"`
+
+	// The contract address of the RPL faucet
+	RplFaucetAddress string `json:"rplFaucetAddress,omitempty"`
+
+	// The contract address of rETH
+	RethAddress string `json:"rethAddress,omitempty"`
+
+	// The address of the Balancer vault
+	BalancerVaultAddress string `json:"balancerVaultAddress,omitempty"`
+
+	// The contract address of rocketRewardsPool from v1.0.0
+	V1_0_0_RewardsPoolAddress string `json:"v1_0_0_RewardsPoolAddress,omitempty"`
+
+	// The contract address of rocketClaimNode from v1.0.0
+	V1_0_0_ClaimNodeAddress string `json:"v1_0_0_ClaimNodeAddress,omitempty"`
+
+	// The contract address of rocketClaimTrustedNode from v1.0.0
+	V1_0_0_ClaimTrustedNodeAddress string `json:"v1_0_0_ClaimTrustedNodeAddress,omitempty"`
+
+	// The contract address of rocketMinipoolManager from v1.0.0
+	V1_0_0_MinipoolManagerAddress string `json:"v1_0_0_MinipoolManagerAddress,omitempty"`
+
+	// The contract address of rocketNetworkPrices from v1.1.0
+	V1_1_0_NetworkPricesAddress string `json:"v1_1_0_NetworkPricesAddress,omitempty"`
+
+	// The contract address of rocketNodeStaking from v1.1.0
+	V1_1_0_NodeStakingAddress string `json:"v1_1_0_NodeStakingAddress,omitempty"`
+
+	// The contract address of rocketNodeDeposit from v1.1.0
+	V1_1_0_NodeDepositAddress string `json:"v1_1_0_NodeDepositAddress,omitempty"`
+
+	// The contract address of rocketMinipoolQueue from v1.1.0
+	V1_1_0_MinipoolQueueAddress string `json:"v1_1_0_MinipoolQueueAddress,omitempty"`
+
+	// The contract address of rocketMinipoolFactory from v1.1.0
+	V1_1_0_MinipoolFactoryAddress string `json:"v1_1_0_MinipoolFactoryAddress,omitempty"`
+
+	// The contract address for Snapshot delegation
+	SnapshotDelegationAddress string `json:"snapshotDelegationAddress,omitempty"`
+
+	// The Snapshot API domain
+	SnapshotApiDomain string `json:"snapshotApiDomain,omitempty"`
+
+	// Addresses for RocketRewardsPool that have been upgraded during development
+	PreviousRewardsPoolAddresses map[string][]common.Address `json:"previousRewardsPoolAddresses,omitempty"`
+
+	// The RocketOvmPriceMessenger Optimism address
+	OptimismPriceMessengerAddress string `json:"optimismPriceMessengerAddress,omitempty"`
+
+	// The RocketOvmPriceMessenger Polygon address
+	PolygonPriceMessengerAddress string `json:"polygonPriceMessengerAddress,omitempty"`
+
+	// The RocketOvmPriceMessenger Arbitrum address
+	ArbitrumPriceMessengerAddress string `json:"arbitrumPriceMessengerAddress,omitempty"`
+
+	// The UniswapV3 pool address (used for RPL price TWAP info)
+	RplTwapPoolAddress string `json:"rplTwapPoolAddress,omitempty"`
+
+	// The multicall contract address
+	MulticallAddress string `json:"multicallAddress,omitempty"`
+
+	// The BalanceChecker contract address
+	BalanceBatcherAddress string `json:"balanceBatcherAddress,omitempty"`
+
+	// The FlashBots Protect RPC endpoint
+	FlashbotsProtectUrl string `json:"flashbotsProtectUrl,omitempty"`
+
+	// Rewards submission block maps
+	RewardsSubmissionBlockMaps []uint64 `json:"rewardsSubmissionBlockMaps,omitempty"`
+
+	// The expected keccak256 hash of the deployed bytecode of each Rocket Pool contract, by contract name
+	ExpectedCodeHashes map[string]common.Hash `json:"expectedCodeHashes,omitempty"`
+
+	// The expected version of each Rocket Pool contract, by contract name
+	ExpectedContractVersions map[string]uint8 `json:"expectedContractVersions,omitempty"`
+}
+
+// The built-in deployments for each network; a devnet can replace any of these values with a deployment file
+var networkDeployments = map[config.Network]NetworkDeployment{
+	config.Network_Mainnet: {
+		TxWatchUrl:                     "https://etherscan.io/tx",
+		StakeUrl:                       "https://stake.rocketpool.net",
+		ChainID:                        1, // Mainnet
+		StorageAddress:                 "0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46",
+		OneInchOracleAddress:           "0x07D91f5fb9Bf7798734C3f606dB065549F6893bb",
+		RplTokenAddress:                "0xD33526068D116cE69F19A9ee46F0bd304F21A51f",
+		RplFaucetAddress:               "",
+		RethAddress:                    "0xae78736Cd615f374D3085123A210448E74Fc6393",
+		BalancerVaultAddress:           "0xBA12222222228d8Ba445958a75a0704d566BF2C8",
+		V1_0_0_RewardsPoolAddress:      "0xA3a18348e6E2d3897B6f2671bb8c120e36554802",
+		V1_0_0_ClaimNodeAddress:        "0x899336A2a86053705E65dB61f52C686dcFaeF548",
+		V1_0_0_ClaimTrustedNodeAddress: "0x6af730deB0463b432433318dC8002C0A4e9315e8",
+		V1_0_0_MinipoolManagerAddress:  "0x6293B8abC1F36aFB22406Be5f96D893072A8cF3a",
+		V1_1_0_NetworkPricesAddress:    "0xd3f500F550F46e504A4D2153127B47e007e11166",
+		V1_1_0_NodeStakingAddress:      "0xA73ec45Fe405B5BFCdC0bF4cbc9014Bb32a01cd2",
+		V1_1_0_NodeDepositAddress:      "0x1Cc9cF5586522c6F483E84A19c3C2B0B6d027bF0",
+		V1_1_0_MinipoolQueueAddress:    "0x5870dA524635D1310Dc0e6F256Ce331012C9C19E",
+		V1_1_0_MinipoolFactoryAddress:  "0x54705f80D7C51Fcffd9C659ce3f3C9a7dCCf5788",
+		SnapshotDelegationAddress:      "0x469788fE6E9E9681C6ebF3bF78e7Fd26Fc015446",
+		SnapshotApiDomain:              "hub.snapshot.org",
+		PreviousRewardsPoolAddresses: map[string][]common.Address{
+			"v1.1.0": []common.Address{
+				common.HexToAddress("0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1"),
+			},
+		},
+		OptimismPriceMessengerAddress: "0xdddcf2c25d50ec22e67218e873d46938650d03a7",
+		PolygonPriceMessengerAddress:  "0xb1029Ac2Be4e08516697093e2AFeC435057f3511",
+		ArbitrumPriceMessengerAddress: "0x05330300f829AD3fC8f33838BC88CFC4093baD53",
+		RplTwapPoolAddress:            "0xe42318ea3b998e8355a3da364eb9d48ec725eb45",
+		MulticallAddress:              "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
+		BalanceBatcherAddress:         "0xb1f8e55c7f64d203c1400b9d8555d050f94adf39",
+		FlashbotsProtectUrl:           "https://rpc.flashbots.net/",
+		RewardsSubmissionBlockMaps: []uint64{
+			15451165, 15637542, 15839520, 16038366, 16238906, 16439406, // 5
+			16639856, 16841781,
+		},
+	},
+	config.Network_Prater: {
+		TxWatchUrl:                     "https://goerli.etherscan.io/tx",
+		StakeUrl:                       "https://testnet.rocketpool.net",
+		ChainID:                        5, // Goerli
+		StorageAddress:                 "0xd8Cd47263414aFEca62d6e2a3917d6600abDceB3",
+		OneInchOracleAddress:           "0x4eDC966Df24264C9C817295a0753804EcC46Dd22",
+		RplTokenAddress:                "0x5e932688e81a182e3de211db6544f98b8e4f89c7",
+		RplFaucetAddress:               "0x95D6b8E2106E3B30a72fC87e2B56ce15E37853F9",
+		RethAddress:                    "0x178E141a0E3b34152f73Ff610437A7bf9B83267A",
+		BalancerVaultAddress:           "0xBA12222222228d8Ba445958a75a0704d566BF2C8",
+		V1_0_0_RewardsPoolAddress:      "0xf9aE18eB0CE4930Bc3d7d1A5E33e4286d4FB0f8B",
+		V1_0_0_ClaimNodeAddress:        "0xc05b7A2a03A6d2736d1D0ebf4d4a0aFE2cc32cE1",
+		V1_0_0_ClaimTrustedNodeAddress: "0x730982F4439E5AC30292333ff7d0C478907f2219",
+		V1_0_0_MinipoolManagerAddress:  "0xB815a94430f08dD2ab61143cE1D5739Ac81D3C6d",
+		V1_1_0_NetworkPricesAddress:    "0x12f96dC173a806D18d71fAFe3C1BA2149c3E3Dc6",
+		V1_1_0_NodeStakingAddress:      "0xA73ec45Fe405B5BFCdC0bF4cbc9014Bb32a01cd2",
+		V1_1_0_NodeDepositAddress:      "0x1Cc9cF5586522c6F483E84A19c3C2B0B6d027bF0",
+		V1_1_0_MinipoolQueueAddress:    "0xEF5EF45bf1CC08D5694f87F8c4023f00CCCB7237",
+		V1_1_0_MinipoolFactoryAddress:  "0x54705f80D7C51Fcffd9C659ce3f3C9a7dCCf5788",
+		SnapshotDelegationAddress:      "0xD0897D68Cd66A710dDCecDe30F7557972181BEDc",
+		SnapshotApiDomain:              "testnet.snapshot.org",
+		PreviousRewardsPoolAddresses: map[string][]common.Address{
+			"v1.1.0-rc1": []common.Address{
+				common.HexToAddress("0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1"),
+			},
+			"v1.2.0-rc1": []common.Address{
+				common.HexToAddress("0x6e91E3416acf3d015358eeAAF247a0674F6c306f"),
+			},
+		},
+		OptimismPriceMessengerAddress: "0x87E2deCE7d0A080D579f63cbcD7e1629BEcd7E7d",
+		PolygonPriceMessengerAddress:  "0x6D736da1dC2562DBeA9998385A0A27d8c2B2793e",
+		ArbitrumPriceMessengerAddress: "0x2b52479F6ea009907e46fc43e91064D1b92Fdc86",
+		RplTwapPoolAddress:            "0x5cE71E603B138F7e65029Cc1918C0566ed0dBD4B",
+		MulticallAddress:              "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
+		BalanceBatcherAddress:         "0x9788C4E93f9002a7ad8e72633b11E8d1ecd51f9b",
+		FlashbotsProtectUrl:           "https://rpc-goerli.flashbots.net/",
+		RewardsSubmissionBlockMaps: []uint64{
+			7287326, 7297026, 7314231, 7331462, 7387271, 7412366, // 5
+			7420574, 7436546, 7456423, 7473017, 7489726, 7506706, // 11
+			7525902, 7544630, 7562851, 7581623, 7600343, 7618815, // 17
+			7636720, 7654452, 7672147, 7689735, 7707617, 7725232, // 23
+			7742548, 7760702, 7777078, 7794263, 7811800, 7829115, // 29
+			7846870, 7863708, 7881537, 7900095, 7918951, 7937222, // 35
+			7955161, 7972837, 7990504, 8008474, 8027271, 8045546, // 41
+			8063957, 8082659, 8101400, 8119473, 8136892, 8154565, // 47
+			8172349, 8189717, 8207105, 8224279, 8241674, 8258210, // 53
+			8274526, 8290763, 8307407, 8324452, 8341708, 8359470, // 59
+			8377175, 8394786, 8412599, 8430221, 8447800, 8465317, // 65
+			8482337, 8499227, 8516593, 8533890, 8551379, 8569494, // 71
+			8587146, 8604666, 8621961, 8639563, 8656830, 8673617, // 77
+		},
+	},
+	config.Network_Devnet: {
+		TxWatchUrl:                     "https://goerli.etherscan.io/tx",
+		StakeUrl:                       "TBD",
+		ChainID:                        5, // Also goerli
+		StorageAddress:                 "0x6A18E47f8CcB453Dd0894AC003f74BEE7e47A368",
+		OneInchOracleAddress:           "0x4eDC966Df24264C9C817295a0753804EcC46Dd22",
+		RplTokenAddress:                "0x09b6aEF57B580f5CB46746BA59ed312Ba80E8Ad4",
+		RplFaucetAddress:               "0x218a718A1B23B13737E2F566Dd45730E8DAD451b",
+		RethAddress:                    "0x2DF914425da6d0067EF1775AfDBDd7B24fc8100E",
+		BalancerVaultAddress:           "0xBA12222222228d8Ba445958a75a0704d566BF2C8",
+		V1_0_0_RewardsPoolAddress:      "0x4A1b5Ab9F6C36E7168dE5F994172028Ca8554e02",
+		V1_0_0_ClaimNodeAddress:        "",
+		V1_0_0_ClaimTrustedNodeAddress: "",
+		V1_0_0_MinipoolManagerAddress:  "",
+		V1_1_0_NetworkPricesAddress:    "",
+		V1_1_0_NodeStakingAddress:      "",
+		V1_1_0_NodeDepositAddress:      "",
+		V1_1_0_MinipoolQueueAddress:    "",
+		V1_1_0_MinipoolFactoryAddress:  "",
+		SnapshotDelegationAddress:      "",
+		SnapshotApiDomain:              "",
+		PreviousRewardsPoolAddresses:   map[string][]common.Address{},
+		OptimismPriceMessengerAddress:  "",
+		PolygonPriceMessengerAddress:   "0x6D736da1dC2562DBeA9998385A0A27d8c2B2793e",
+		ArbitrumPriceMessengerAddress:  "0x2b52479F6ea009907e46fc43e91064D1b92Fdc86",
+		RplTwapPoolAddress:             "0x5cE71E603B138F7e65029Cc1918C0566ed0dBD4B",
+		MulticallAddress:               "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
+		BalanceBatcherAddress:          "0x9788C4E93f9002a7ad8e72633b11E8d1ecd51f9b",
+		FlashbotsProtectUrl:            "https://rpc-goerli.flashbots.net/",
+		RewardsSubmissionBlockMaps: []uint64{
+			7955303, 7972424, 8009064, 8026821, 8045113, 8063501, // 5
+			8082186, 8100941, 8119074, 8136452, 8154152, 8171923, // 11
+			8189312, 8206689, 8223857, 8241269, 8257834, 8274178, // 17
+			8290333, 8307005, 8324055, 8341308, 8359051, 8376744, // 23
+			8394338, 8412142,
+		},
+	},
+}
+
+// Get the deployment for the selected network, including any values replaced by its deployment file.
+// If a custom deployment file is set, it describes the whole deployment instead.
+// The deployment follows the selected network and deployment file, so it's reloaded whenever either one changes.
+func (cfg *SmartnodeConfig) GetDeployment() (*NetworkDeployment, error) {
+	cfg.deploymentLock.Lock()
+	defer cfg.deploymentLock.Unlock()
+
+	network := cfg.Network.Value.(config.Network)
+	path := cfg.GetDeploymentFilePath()
+	if cfg.deployment != nil && cfg.deploymentNetwork == network && cfg.deploymentPath == path {
+		return cfg.deployment, cfg.deploymentErr
+	}
+	cfg.deployment, cfg.deploymentFile, cfg.deploymentErr = cfg.loadDeployment(network, path)
+	cfg.deploymentNetwork = network
+	cfg.deploymentPath = path
+	return cfg.deployment, cfg.deploymentErr
+}
+
+// Get the deployment file that was loaded for the selected network, or an empty string if it only uses the built-in deployment
+func (cfg *SmartnodeConfig) GetDeploymentFile() string {
+	_, _ = cfg.GetDeployment()
+	cfg.deploymentLock.Lock()
+	defer cfg.deploymentLock.Unlock()
+	return cfg.deploymentFile
+}
+
+// Check if the deployment comes entirely from a custom deployment file instead of the built-in deployments
+func (cfg *SmartnodeConfig) IsCustomDeployment() bool {
+	return cfg.DeploymentFile.Value.(string) != ""
+}
+
+// Get the path of the deployment file; this is the custom deployment file if one is set, otherwise it's the file that can
+// replace values in the selected network's deployment
+func (cfg *SmartnodeConfig) GetDeploymentFilePath() string {
+	filename := cfg.DeploymentFile.Value.(string)
+	if filename == "" {
+		filename = fmt.Sprintf(DeploymentFileFormat, string(cfg.Network.Value.(config.Network)))
+	}
+	if !cfg.parent.IsNativeMode {
+		// The daemons see the data folder at a fixed path
+		if _, err := os.Stat(DaemonDataPath); err == nil {
+			return filepath.Join(DaemonDataPath, filename)
+		}
+	}
+
+	return filepath.Join(os.ExpandEnv(cfg.DataPath.Value.(string)), filename)
+}
+
+// Get the deployment for the selected network, falling back to the built-in one if its deployment file couldn't be loaded
+func (cfg *SmartnodeConfig) getDeployment() *NetworkDeployment {
+	deployment, _ := cfg.GetDeployment()
+	return deployment
+}
+
+// Load the deployment for a network, starting from the built-in one and replacing any values set in its deployment file.
+// A custom deployment file doesn't start from the built-in deployment, so it has to provide every value it needs.
+func (cfg *SmartnodeConfig) loadDeployment(network config.Network, path string) (*NetworkDeployment, string, error) {
+
+	builtIn, exists := networkDeployments[network]
+	deployment, err := copyDeployment(builtIn)
+	if err != nil {
+		return &NetworkDeployment{}, "", fmt.Errorf("error copying the %s deployment: %w", network, err)
+	}
+
+	isCustom := cfg.IsCustomDeployment()
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !isCustom {
+		if !exists {
+			return deployment, "", fmt.Errorf("there is no deployment for network %s", network)
+		}
+		return deployment, "", nil
+	} else if err != nil {
+		return deployment, "", fmt.Errorf("error reading deployment file %s: %w", path, err)
+	}
+
+	fileDeployment := &NetworkDeployment{}
+	if !isCustom {
+		fileDeployment, err = copyDeployment(builtIn)
+		if err != nil {
+			return deployment, "", fmt.Errorf("error copying the %s deployment: %w", network, err)
+		}
+	}
+	err = json.Unmarshal(bytes, fileDeployment)
+	if err != nil {
+		return deployment, "", fmt.Errorf("error deserializing deployment file %s: %w", path, err)
+	}
+	if !common.IsHexAddress(fileDeployment.StorageAddress) {
+		return deployment, "", fmt.Errorf("deployment file %s has an invalid storage address [%s]", path, fileDeployment.StorageAddress)
+	}
+	if isCustom {
+		err = validateCustomDeployment(fileDeployment)
+		if err != nil {
+			return deployment, "", fmt.Errorf("deployment file %s is incomplete: %w", path, err)
+		}
+	}
+	return fileDeployment, path, nil
+
+}
+
+// Make sure a custom deployment has the values the Smartnode can't run without
+func validateCustomDeployment(deployment *NetworkDeployment) error {
+	if deployment.ChainID == 0 {
+		return fmt.Errorf("it doesn't have a chain ID")
+	}
+	if deployment.GenesisBlockHash != "" && len(common.FromHex(deployment.GenesisBlockHash)) != common.HashLength {
+		return fmt.Errorf("its genesis block hash [%s] is invalid", deployment.GenesisBlockHash)
+	}
+	requiredAddresses := []struct {
+		name    string
+		address string
+	}{
+		{"multicallAddress", deployment.MulticallAddress},
+		{"balanceBatcherAddress", deployment.BalanceBatcherAddress},
+	}
+	for _, required := range requiredAddresses {
+		if !common.IsHexAddress(required.address) {
+			return fmt.Errorf("its %s [%s] is missing or invalid", required.name, required.address)
+		}
+	}
+	return nil
+}
+
+// Make a deep copy of a deployment so changes to it can't modify the registry
+func copyDeployment(deployment NetworkDeployment) (*NetworkDeployment, error) {
+	bytes, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+	deploymentCopy := &NetworkDeployment{}
+	err = json.Unmarshal(bytes, deploymentCopy)
+	if err != nil {
+		return nil, err
+	}
+	return deploymentCopy, nil
+}
@@ -19,6 +19,35 @@ const (
 	defaultNimbusMaxPeersAmd uint16 = 160
 )
 
+// The flags the Smartnode sets for the Nimbus BN from its settings, which can't be overridden with additional flags
+var nimbusBnManagedFlags = []string{
+	"--network",
+	"--data-dir",
+	"--tcp-port",
+	"--udp-port",
+	"--web3-url",
+	"--jwt-secret",
+	"--rest",
+	"--rest-address",
+	"--rest-port",
+	"--max-peers",
+	"--metrics",
+	"--metrics-address",
+	"--metrics-port",
+}
+
+// The flags the Smartnode sets for the Nimbus VC from its settings, which can't be overridden with additional flags
+var nimbusVcManagedFlags = []string{
+	"--data-dir",
+	"--beacon-node",
+	"--graffiti",
+	"--suggested-fee-recipient",
+	"--metrics",
+	"--metrics-address",
+	"--metrics-port",
+	"--doppelganger-detection",
+}
+
 // Configuration for Nimbus
 type NimbusConfig struct {
 	Title string `yaml:"-"`
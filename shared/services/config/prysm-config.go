@@ -20,6 +20,46 @@ const (
 	defaultPrysmMaxPeers    uint16 = 45
 )
 
+// The flags the Smartnode sets for the Prysm BN from its settings, which can't be overridden with additional flags
+var prysmBnManagedFlags = []string{
+	"--accept-terms-of-use",
+	"--mainnet",
+	"--goerli",
+	"--prater",
+	"--sepolia",
+	"--datadir",
+	"--p2p-tcp-port",
+	"--p2p-udp-port",
+	"--execution-endpoint",
+	"--jwt-secret",
+	"--rpc-host",
+	"--rpc-port",
+	"--grpc-gateway-host",
+	"--grpc-gateway-port",
+	"--p2p-max-peers",
+	"--checkpoint-sync-url",
+	"--monitoring-host",
+	"--monitoring-port",
+}
+
+// The flags the Smartnode sets for the Prysm VC from its settings, which can't be overridden with additional flags
+var prysmVcManagedFlags = []string{
+	"--accept-terms-of-use",
+	"--mainnet",
+	"--goerli",
+	"--prater",
+	"--sepolia",
+	"--datadir",
+	"--wallet-dir",
+	"--wallet-password-file",
+	"--beacon-rpc-provider",
+	"--graffiti",
+	"--suggested-fee-recipient",
+	"--monitoring-host",
+	"--monitoring-port",
+	"--enable-doppelganger",
+}
+
 // Configuration for Prysm
 type PrysmConfig struct {
 	Title string `yaml:"title,omitempty"`
@@ -57,13 +57,19 @@ type RocketPoolConfig struct {
 
 	IsNativeMode bool `yaml:"-"`
 
+	// Where each parameter's value came from, keyed by section.id
+	settingSources map[string]ConfigSource
+
 	// Execution client settings
 	ExecutionClientMode config.Parameter `yaml:"executionClientMode,omitempty"`
 	ExecutionClient     config.Parameter `yaml:"executionClient,omitempty"`
 
 	// Fallback settings
-	UseFallbackClients config.Parameter `yaml:"useFallbackClients,omitempty"`
-	ReconnectDelay     config.Parameter `yaml:"reconnectDelay,omitempty"`
+	UseFallbackClients   config.Parameter `yaml:"useFallbackClients,omitempty"`
+	ReconnectDelay       config.Parameter `yaml:"reconnectDelay,omitempty"`
+	FallbackTransactions config.Parameter `yaml:"fallbackTransactions,omitempty"`
+	BeaconNodePoolUrls   config.Parameter `yaml:"beaconNodePoolUrls,omitempty"`
+	BeaconNodeRouting    config.Parameter `yaml:"beaconNodeRouting,omitempty"`
 
 	// Consensus client settings
 	ConsensusClientMode     config.Parameter `yaml:"consensusClientMode,omitempty"`
@@ -73,6 +79,8 @@ type RocketPoolConfig struct {
 	// Metrics settings
 	EnableMetrics           config.Parameter `yaml:"enableMetrics,omitempty"`
 	EnableODaoMetrics       config.Parameter `yaml:"enableODaoMetrics,omitempty"`
+	DisabledCollectors      config.Parameter `yaml:"disabledCollectors,omitempty"`
+	DiskAlertDays           config.Parameter `yaml:"diskAlertDays,omitempty"`
 	EcMetricsPort           config.Parameter `yaml:"ecMetricsPort,omitempty"`
 	BnMetricsPort           config.Parameter `yaml:"bnMetricsPort,omitempty"`
 	VcMetricsPort           config.Parameter `yaml:"vcMetricsPort,omitempty"`
@@ -112,8 +120,12 @@ type RocketPoolConfig struct {
 	Grafana           *GrafanaConfig           `yaml:"grafana,omitempty"`
 	Prometheus        *PrometheusConfig        `yaml:"prometheus,omitempty"`
 	Exporter          *ExporterConfig          `yaml:"exporter,omitempty"`
+	MetricsPush       *MetricsPushConfig       `yaml:"metricsPush,omitempty"`
 	BitflyNodeMetrics *BitflyNodeMetricsConfig `yaml:"bitflyNodeMetrics,omitempty"`
 
+	// Tracing
+	Tracing *TracingConfig `yaml:"tracing,omitempty"`
+
 	// Native mode
 	Native *NativeConfig `yaml:"native,omitempty"`
 
@@ -121,6 +133,9 @@ type RocketPoolConfig struct {
 	EnableMevBoost config.Parameter `yaml:"enableMevBoost,omitempty"`
 	MevBoost       *MevBoostConfig  `yaml:"mevBoost,omitempty"`
 
+	// Alerting
+	Alerting *AlertingConfig `yaml:"alerting,omitempty"`
+
 	// Addons
 	GraffitiWallWriter addontypes.SmartnodeAddon `yaml:"addon-gww,omitempty"`
 }
@@ -128,6 +143,29 @@ type RocketPoolConfig struct {
 // Load configuration settings from a file
 func LoadFromFile(path string) (*RocketPoolConfig, error) {
 
+	// Return nil if the file doesn't exist
+	settings, err := LoadSettingsFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, nil
+	}
+
+	// Deserialize it into a config object
+	cfg := NewRocketPoolConfig(filepath.Dir(path), false)
+	err = cfg.Deserialize(settings)
+	if err != nil {
+		return nil, fmt.Errorf("could not deserialize settings file: %w", err)
+	}
+
+	return cfg, nil
+
+}
+
+// Load a settings file as it's saved, without upgrading or deserializing it
+func LoadSettingsFromFile(path string) (map[string]map[string]string, error) {
+
 	// Return nil if the file doesn't exist
 	_, err := os.Stat(path)
 	if os.IsNotExist(err) {
@@ -145,15 +183,11 @@ func LoadFromFile(path string) (*RocketPoolConfig, error) {
 	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
 		return nil, fmt.Errorf("could not parse settings file: %w", err)
 	}
-
-	// Deserialize it into a config object
-	cfg := NewRocketPoolConfig(filepath.Dir(path), false)
-	err = cfg.Deserialize(settings)
-	if err != nil {
-		return nil, fmt.Errorf("could not deserialize settings file: %w", err)
+	if settings == nil {
+		settings = map[string]map[string]string{}
 	}
 
-	return cfg, nil
+	return settings, nil
 
 }
 
@@ -237,6 +271,51 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		FallbackTransactions: config.Parameter{
+			ID:                   "fallbackTransactions",
+			Name:                 "Send Transactions to Fallback",
+			Description:          "Enable this to let the Smartnode send transactions through your fallback Execution client while your primary one is offline. Disable it if you only trust the fallback for reading chain data; transactions will fail until your primary client is back.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconNodePoolUrls: config.Parameter{
+			ID:                   "beaconNodePoolUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          "A comma-separated list of the URLs of extra Beacon nodes the Smartnode can send requests to, on top of your primary and fallback Consensus clients. They're used if the clients before them are unavailable, or share the load with them if the routing mode is Balanced.\n\nNOTE: These are only used by the Smartnode, not by your Validator Client.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconNodeRouting: config.Parameter{
+			ID:                   "beaconNodeRouting",
+			Name:                 "Beacon Node Routing",
+			Description:          "Select how the Smartnode chooses which of its Beacon nodes to send each request to. Beacon nodes that are offline or still syncing are always skipped.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.BeaconRoutingMode_Ordered},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Ordered",
+				Description: "Send every request to your primary Consensus client, and only use the fallback and additional Beacon nodes (in that order) while the ones before them are unavailable.",
+				Value:       config.BeaconRoutingMode_Ordered,
+			}, {
+				Name:        "Balanced",
+				Description: "Take turns sending requests to each of the Beacon nodes that are ready, skipping any that respond much more slowly than the fastest one.",
+				Value:       config.BeaconRoutingMode_Balanced,
+			}},
+		},
+
 		ConsensusClientMode: config.Parameter{
 			ID:                   "consensusClientMode",
 			Name:                 "Consensus Client Mode",
@@ -340,6 +419,30 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		DisabledCollectors: config.Parameter{
+			ID:                   "disabledCollectors",
+			Name:                 "Disabled Metrics Collectors",
+			Description:          "A comma-separated list of the Smartnode metrics collectors to turn off, for example if some of them put too much load on your clients. The collectors are:\n\nbeacon, demand, ec_failover, fee_recipient, gas, health, node, odao, performance, reth, rewards, rpl, smoothing_pool, snapshot, supply, task, trusted_node, validator_performance\n\nThe time each collector takes and the errors it runs into are reported in the rocketpool_collector metrics.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DiskAlertDays: config.Parameter{
+			ID:                   "diskAlertDays",
+			Name:                 "Disk Exhaustion Alert Days",
+			Description:          "The health metrics collector projects when the disks holding your Execution and Consensus client data will fill up, based on how fast their chain data has been growing. If either disk is projected to fill up in fewer than this many days, the rocketpool_health_disk_exhaustion_alert metric will be set so your alerting can warn you.\n\nSet this to 0 to disable the alert.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(14)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		EnableBitflyNodeMetrics: config.Parameter{
 			ID:                   "enableBitflyNodeMetrics",
 			Name:                 "Enable Beaconcha.in Node Metrics",
@@ -463,9 +566,12 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	cfg.Grafana = NewGrafanaConfig(cfg)
 	cfg.Prometheus = NewPrometheusConfig(cfg)
 	cfg.Exporter = NewExporterConfig(cfg)
+	cfg.MetricsPush = NewMetricsPushConfig(cfg)
 	cfg.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(cfg)
+	cfg.Tracing = NewTracingConfig(cfg)
 	cfg.Native = NewNativeConfig(cfg)
 	cfg.MevBoost = NewMevBoostConfig(cfg)
+	cfg.Alerting = NewAlertingConfig(cfg)
 
 	// Addons
 	cfg.GraffitiWallWriter = addons.NewGraffitiWallWriter()
@@ -525,11 +631,16 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 		&cfg.ExecutionClient,
 		&cfg.UseFallbackClients,
 		&cfg.ReconnectDelay,
+		&cfg.FallbackTransactions,
+		&cfg.BeaconNodePoolUrls,
+		&cfg.BeaconNodeRouting,
 		&cfg.ConsensusClientMode,
 		&cfg.ConsensusClient,
 		&cfg.ExternalConsensusClient,
 		&cfg.EnableMetrics,
 		&cfg.EnableODaoMetrics,
+		&cfg.DisabledCollectors,
+		&cfg.DiskAlertDays,
 		&cfg.EnableBitflyNodeMetrics,
 		&cfg.EcMetricsPort,
 		&cfg.BnMetricsPort,
@@ -566,9 +677,12 @@ func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
 		"grafana":            cfg.Grafana,
 		"prometheus":         cfg.Prometheus,
 		"exporter":           cfg.Exporter,
+		"metricsPush":        cfg.MetricsPush,
 		"bitflyNodeMetrics":  cfg.BitflyNodeMetrics,
+		"tracing":            cfg.Tracing,
 		"native":             cfg.Native,
 		"mevBoost":           cfg.MevBoost,
+		"alerting":           cfg.Alerting,
 		"addons-gww":         cfg.GraffitiWallWriter.GetConfig(),
 	}
 }
@@ -731,6 +845,52 @@ func (cfg *RocketPoolConfig) IsDoppelgangerEnabled() (bool, error) {
 	}
 }
 
+// Check if the primary Execution client is run by a remote provider
+func (cfg *RocketPoolConfig) IsRemoteExecutionProvider() bool {
+	return !cfg.IsNativeMode &&
+		cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_External &&
+		cfg.ExternalExecution.RemoteProvider.Value == true
+}
+
+// Check if the primary Consensus client is run by a remote provider
+func (cfg *RocketPoolConfig) IsRemoteConsensusProvider() bool {
+	externalConfig := cfg.getExternalConsensusConfig()
+	return externalConfig != nil && externalConfig.IsRemoteProvider()
+}
+
+// Get the custom HTTP headers to send with requests to the primary Execution client
+func (cfg *RocketPoolConfig) GetExecutionClientHeaders() (map[string]string, error) {
+	if cfg.IsNativeMode || cfg.ExecutionClientMode.Value.(config.Mode) != config.Mode_External {
+		return nil, nil
+	}
+	return ParseHttpHeaders(cfg.ExternalExecution.HttpHeaders.Value.(string))
+}
+
+// Get the custom HTTP headers to send with requests to the primary Consensus client
+func (cfg *RocketPoolConfig) GetConsensusClientHeaders() (map[string]string, error) {
+	externalConfig := cfg.getExternalConsensusConfig()
+	if externalConfig == nil {
+		return nil, nil
+	}
+	return ParseHttpHeaders(externalConfig.GetApiHeaders())
+}
+
+// Get the configuration for the selected external Consensus client, or nil if it isn't external
+func (cfg *RocketPoolConfig) getExternalConsensusConfig() config.ExternalConsensusConfig {
+	if cfg.IsNativeMode || cfg.ConsensusClientMode.Value.(config.Mode) != config.Mode_External {
+		return nil
+	}
+	ccConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return nil
+	}
+	externalConfig, ok := ccConfig.(config.ExternalConsensusConfig)
+	if !ok {
+		return nil
+	}
+	return externalConfig
+}
+
 // Serializes the configuration into a map of maps, compatible with a settings file
 func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 
@@ -783,8 +943,12 @@ func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string)
 	}
 
 	// Deserialize root params
+	cfg.settingSources = map[string]ConfigSource{}
 	rootParams := masterMap[rootConfigName]
 	for _, param := range cfg.GetParameters() {
+		if _, exists := rootParams[param.ID]; exists {
+			cfg.settingSources[rootConfigName+"."+param.ID] = ConfigSource_File
+		}
 		// Note: if the root config doesn't exist, this will end up using the default values for all of its settings
 		err := param.Deserialize(rootParams, network)
 		if err != nil {
@@ -803,6 +967,9 @@ func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string)
 	for name, subconfig := range cfg.GetSubconfigs() {
 		subconfigParams := masterMap[name]
 		for _, param := range subconfig.GetParameters() {
+			if _, exists := subconfigParams[param.ID]; exists {
+				cfg.settingSources[name+"."+param.ID] = ConfigSource_File
+			}
 			// Note: if the subconfig doesn't exist, this will end up using the default values for all of its settings
 			err := param.Deserialize(subconfigParams, network)
 			if err != nil {
@@ -980,10 +1147,10 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 
 		// Additional metrics flags
 		if cfg.Exporter.AdditionalFlags.Value.(string) != "" {
-			envVars["EXPORTER_ADDITIONAL_FLAGS"] = fmt.Sprintf(", \"%s\"", cfg.Exporter.AdditionalFlags.Value.(string))
+			envVars["EXPORTER_ADDITIONAL_FLAGS"] = formatComposeFlags(cfg.Exporter.AdditionalFlags.Value.(string))
 		}
 		if cfg.Prometheus.AdditionalFlags.Value.(string) != "" {
-			envVars["PROMETHEUS_ADDITIONAL_FLAGS"] = fmt.Sprintf(", \"%s\"", cfg.Prometheus.AdditionalFlags.Value.(string))
+			envVars["PROMETHEUS_ADDITIONAL_FLAGS"] = formatComposeFlags(cfg.Prometheus.AdditionalFlags.Value.(string))
 		}
 	}
 
@@ -1113,6 +1280,14 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		errors = append(errors, "You are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
 	}
 
+	// Make sure the custom headers for remote providers can be parsed
+	if _, err := cfg.GetExecutionClientHeaders(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your external Execution client's HTTP headers are invalid: %s", err.Error()))
+	}
+	if _, err := cfg.GetConsensusClientHeaders(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your external Consensus client's HTTP headers are invalid: %s", err.Error()))
+	}
+
 	// Ensure there's a MEV-boost URL
 	if !cfg.IsNativeMode && cfg.EnableMevBoost.Value == true {
 		switch cfg.MevBoost.Mode.Value.(config.Mode) {
@@ -1132,6 +1307,24 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// Make sure the custom flags for each client don't conflict with the ones the Smartnode sets
+	errors = append(errors, cfg.validateAdditionalFlags()...)
+
+	// Ensure there's somewhere to push metrics to
+	if cfg.EnableMetrics.Value == true && cfg.MetricsPush.Mode.Value.(config.MetricsPushMode) != config.MetricsPushMode_Disabled && cfg.MetricsPush.Url.Value.(string) == "" {
+		errors = append(errors, "You have metrics pushing enabled but don't have a URL set. Please enter the URL to push the metrics to, or disable metrics pushing.")
+	}
+
+	// Make sure the tracing headers can be parsed
+	if _, err := cfg.Tracing.GetHeaders(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your trace export headers are invalid: %s.", err.Error()))
+	}
+
+	// Nice levels above the maximum are clamped by the OS, so reject them rather than silently using a different one
+	if cfg.Smartnode.TreeWorkerNiceLevel.Value.(uint64) > maxTreeWorkerNiceLevel {
+		errors = append(errors, fmt.Sprintf("The tree worker nice level must be between 0 and %d.", maxTreeWorkerNiceLevel))
+	}
+
 	return errors
 }
 
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The size of the key used to encrypt secrets, in bytes
+const secretsKeySize int = 32
+
+// How a secret parameter's value is kept in the settings file
+type SecretStorage string
+
+const (
+	SecretStorage_Unset     SecretStorage = "unset"
+	SecretStorage_Plaintext SecretStorage = "plaintext"
+	SecretStorage_Encrypted SecretStorage = "encrypted"
+	SecretStorage_File      SecretStorage = "file"
+	SecretStorage_Env       SecretStorage = "env"
+)
+
+// A parameter that holds a secret, along with the section it belongs to
+type SecretParameter struct {
+	Section   string
+	Parameter *config.Parameter
+}
+
+// Get how a secret parameter's value is kept in the settings file
+func GetSecretStorage(value string) SecretStorage {
+	switch {
+	case value == "":
+		return SecretStorage_Unset
+	case strings.HasPrefix(value, config.SecretPrefix_Encrypted):
+		return SecretStorage_Encrypted
+	case strings.HasPrefix(value, config.SecretPrefix_File):
+		return SecretStorage_File
+	case strings.HasPrefix(value, config.SecretPrefix_Env):
+		return SecretStorage_Env
+	default:
+		return SecretStorage_Plaintext
+	}
+}
+
+// Get every parameter that holds a secret, ordered by section
+func (cfg *RocketPoolConfig) GetSecretParameters() []SecretParameter {
+	sections := cfg.GetSections()
+	sectionNames := []string{}
+	for name := range sections {
+		sectionNames = append(sectionNames, name)
+	}
+	sort.Strings(sectionNames)
+
+	secrets := []SecretParameter{}
+	for _, section := range sectionNames {
+		for _, param := range sections[section] {
+			if param.IsSecret {
+				secrets = append(secrets, SecretParameter{Section: section, Parameter: param})
+			}
+		}
+	}
+	return secrets
+}
+
+// Replace the values of secret parameters that refer to encrypted values, files, or environment variables with the real values.
+// The daemons resolve every secret; outside of them, only the secrets passed to containers as environment variables are resolved.
+// The resolved config must never be saved, or the secrets will be written to the settings file in plaintext.
+func (cfg *RocketPoolConfig) ResolveSecrets(daemon bool) error {
+	keyPath := cfg.Smartnode.GetSecretsKeyPath(daemon)
+	var key []byte
+	getKey := func() ([]byte, error) {
+		if key != nil {
+			return key, nil
+		}
+		var err error
+		key, err = LoadSecretsKey(keyPath)
+		return key, err
+	}
+
+	for _, secret := range cfg.GetSecretParameters() {
+		param := secret.Parameter
+		value, isString := param.Value.(string)
+		if !isString || !config.IsSecretReference(value) {
+			continue
+		}
+		if !daemon && len(param.EnvironmentVariables) == 0 {
+			continue
+		}
+		resolved, err := resolveSecret(value, getKey)
+		if err != nil {
+			return fmt.Errorf("error resolving secret %s.%s: %w", secret.Section, param.ID, err)
+		}
+		if param.MaxLength > 0 && len(resolved) > param.MaxLength {
+			return fmt.Errorf("secret %s.%s is longer than the max length of [%d]", secret.Section, param.ID, param.MaxLength)
+		}
+		if param.Regex != "" && resolved != "" && !regexp.MustCompile(param.Regex).MatchString(resolved) {
+			return fmt.Errorf("secret %s.%s did not match the expected format", secret.Section, param.ID)
+		}
+		param.Value = resolved
+	}
+	return nil
+}
+
+// Encrypt a secret with the key in the data folder, creating the key if it doesn't exist yet
+func (cfg *RocketPoolConfig) EncryptSecret(plaintext string, daemon bool) (string, error) {
+	key, err := loadOrCreateSecretsKey(cfg.Smartnode.GetSecretsKeyPath(daemon))
+	if err != nil {
+		return "", err
+	}
+	aead, err := getSecretsCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("error generating nonce: %w", err)
+	}
+	ciphertext := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return config.SecretPrefix_Encrypted + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Load the key used to encrypt secrets
+func LoadSecretsKey(path string) ([]byte, error) {
+	bytes, err := os.ReadFile(os.ExpandEnv(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading secrets key [%s]: %w", path, err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(bytes)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding secrets key [%s]: %w", path, err)
+	}
+	if len(key) != secretsKeySize {
+		return nil, fmt.Errorf("secrets key [%s] is %d bytes but it should be %d", path, len(key), secretsKeySize)
+	}
+	return key, nil
+}
+
+// Load the key used to encrypt secrets, or create a new one if it doesn't exist
+func loadOrCreateSecretsKey(path string) ([]byte, error) {
+	expandedPath := os.ExpandEnv(path)
+	_, err := os.Stat(expandedPath)
+	if err == nil {
+		return LoadSecretsKey(path)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error checking for secrets key [%s]: %w", path, err)
+	}
+
+	key := make([]byte, secretsKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("error generating secrets key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(expandedPath), 0700); err != nil {
+		return nil, fmt.Errorf("error creating folder for secrets key [%s]: %w", path, err)
+	}
+	if err := os.WriteFile(expandedPath, []byte(hex.EncodeToString(key)), 0600); err != nil {
+		return nil, fmt.Errorf("error saving secrets key [%s]: %w", path, err)
+	}
+	return key, nil
+}
+
+// Get the real value of a secret reference
+func resolveSecret(value string, getKey func() ([]byte, error)) (string, error) {
+	switch GetSecretStorage(value) {
+	case SecretStorage_Env:
+		name := strings.TrimPrefix(value, config.SecretPrefix_Env)
+		resolved, exists := os.LookupEnv(name)
+		if !exists {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return resolved, nil
+
+	case SecretStorage_File:
+		path := os.ExpandEnv(strings.TrimPrefix(value, config.SecretPrefix_File))
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading secret file [%s]: %w", path, err)
+		}
+		return strings.TrimRight(string(bytes), "\r\n"), nil
+
+	case SecretStorage_Encrypted:
+		key, err := getKey()
+		if err != nil {
+			return "", err
+		}
+		aead, err := getSecretsCipher(key)
+		if err != nil {
+			return "", err
+		}
+		ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, config.SecretPrefix_Encrypted))
+		if err != nil {
+			return "", fmt.Errorf("error decoding encrypted secret: %w", err)
+		}
+		if len(ciphertext) < aead.NonceSize() {
+			return "", fmt.Errorf("encrypted secret is too short")
+		}
+		nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+		plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+		if err != nil {
+			return "", fmt.Errorf("error decrypting secret (was it encrypted with a different secrets key?): %w", err)
+		}
+		return string(plaintext), nil
+
+	default:
+		return value, nil
+	}
+}
+
+// Create the cipher used to encrypt and decrypt secrets
+func getSecretsCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating secrets cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating secrets cipher: %w", err)
+	}
+	return aead, nil
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared"
@@ -12,34 +15,85 @@ import (
 
 // Constants
 const (
-	smartnodeTag                       string = "rocketpool/smartnode:v" + shared.RocketPoolVersion
-	pruneProvisionerTag                string = "rocketpool/eth1-prune-provision:v0.0.1"
-	ecMigratorTag                      string = "rocketpool/ec-migrator:v1.0.0"
-	NetworkID                          string = "network"
-	ProjectNameID                      string = "projectName"
-	SnapshotID                         string = "rocketpool-dao.eth"
-	RewardsTreeFilenameFormat          string = "rp-rewards-%s-%d.json"
-	MinipoolPerformanceFilenameFormat  string = "rp-minipool-performance-%s-%d.json"
-	RewardsTreeIpfsExtension           string = ".zst"
-	RewardsTreesFolder                 string = "rewards-trees"
-	DaemonDataPath                     string = "/.rocketpool/data"
-	WatchtowerFolder                   string = "watchtower"
-	WatchtowerStateFile                string = "state.yml"
-	RegenerateRewardsTreeRequestSuffix string = ".request"
-	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
-	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
-	SecondaryRewardsFileUrl            string = "https://ipfs.io/ipfs/%s/%s"
-	FeeRecipientFilename               string = "rp-fee-recipient.txt"
-	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
+	smartnodeTag                        string = "rocketpool/smartnode:v" + shared.RocketPoolVersion
+	pruneProvisionerTag                 string = "rocketpool/eth1-prune-provision:v0.0.1"
+	ecMigratorTag                       string = "rocketpool/ec-migrator:v1.0.0"
+	NetworkID                           string = "network"
+	ProjectNameID                       string = "projectName"
+	SnapshotID                          string = "rocketpool-dao.eth"
+	RewardsTreeFilenameFormat           string = "rp-rewards-%s-%d.json"
+	MinipoolPerformanceFilenameFormat   string = "rp-minipool-performance-%s-%d.json"
+	RewardsTreeIpfsExtension            string = ".zst"
+	RewardsTreesFolder                  string = "rewards-trees"
+	RewardsArchiveFolder                string = "archive"
+	PrunedRewardsSummaryFilenameFormat  string = "rp-pruned-rewards-%s.json"
+	TreeGenProfilesFolder               string = "profiles"
+	TreeGenProfileFormat                string = "rp-treegen-%s-%d-%s"
+	DaemonDataPath                      string = "/.rocketpool/data"
+	WatchtowerFolder                    string = "watchtower"
+	WatchtowerDutyStatusFilename        string = "duty-status.json"
+	WatchtowerSubmissionsFilename       string = "submissions.json"
+	WatchtowerStateFile                 string = "state.yml"
+	RegenerateRewardsTreeRequestSuffix  string = ".request"
+	RegenerateRewardsTreeRequestFormat  string = "%d" + RegenerateRewardsTreeRequestSuffix
+	ForceBalanceSubmissionRequestFormat string = "force-balances-%d.flag"
+	VerifyRewardsTreeRequestSuffix      string = ".verify"
+	VerifyRewardsTreeRequestFormat      string = "%d" + VerifyRewardsTreeRequestSuffix
+	RewardsTreeVerificationFormat       string = "verification-%d.json"
+	PenaltyEvidenceFilename             string = "penalty-evidence.jsonl"
+	ProposalVoteLogFilename             string = "proposal-votes.jsonl"
+	VotingTreeFilenameFormat            string = "rp-voting-tree-%s-%d.json"
+	VotingTreesFolder                   string = "voting-trees"
+	ApiTokenFilename                    string = "api-token"
+	SecretsKeyFilename                  string = "secrets.key"
+	ApiSocketFilename                   string = "api.sock"
+	TransactionJournalFilename          string = "tx-journal.jsonl"
+	TransactionQueueFilename            string = "tx-queue.jsonl"
+	BeaconCacheFolder                   string = "beacon-cache"
+	CallTraceFlagFilename               string = "call-trace.flag"
+	CallTraceLogFilename                string = "call-trace.jsonl"
+	StateSnapshotFolder                 string = "state-snapshots"
+	CachedNodeStateFilename             string = "node-state.json.gz"
+	PrepareShutdownFilename             string = "prepare-shutdown"
+	ReloadConfigFilename                string = "reload-config"
+	LogLevelOverridesFilename           string = "log-levels.json"
+	DaemonLogsFolder                    string = "logs"
+	DaemonLogFileFormat                 string = "%s.log"
+	TaskLoopHeartbeatFormat             string = "%s-heartbeat.json"
+	HardwareWalletAccountFilename       string = "hardware-wallet.json"
+	WalletProfilesFolder                string = "wallets"
+	ActiveWalletProfileFilename         string = "active-wallet"
+	DefaultWalletProfile                string = "default"
+	WalletUnlockSocketFormat            string = "%s-unlock.sock"
+	WalletSpendLogFilename              string = "spend-log.jsonl"
+	PrimaryRewardsFileUrl               string = "https://%s.ipfs.dweb.link/%s"
+	SecondaryRewardsFileUrl             string = "https://ipfs.io/ipfs/%s/%s"
+	Web3StorageRewardsFileUrl           string = "https://%s.ipfs.w3s.link/%s"
+	FeeRecipientFilename                string = "rp-fee-recipient.txt"
+	NativeFeeRecipientFilename          string = "rp-fee-recipient-env.txt"
+	DeploymentFileFormat                string = "deployment-%s.json"
 )
 
 // Defaults
 const (
-	defaultProjectName       string = "rocketpool"
-	WatchtowerMaxFeeDefault  uint64 = 200
-	WatchtowerPrioFeeDefault uint64 = 3
+	defaultProjectName                string  = "rocketpool"
+	WatchtowerMaxFeeDefault           uint64  = 200
+	WatchtowerPrioFeeDefault          uint64  = 3
+	WatchtowerTaskJitterDefault       uint64  = 120
+	WatchtowerBalanceToleranceDefault float64 = 0.1
+	WatchtowerPriceToleranceDefault   float64 = 5
+	WatchtowerDeferralFeeDefault      float64 = 30
+	WatchtowerMaxDeferralDefault      uint64  = 12
+	defaultLogFileMaxSize             uint64  = 20
+	defaultLogFileMaxAge              uint64  = 24
+	defaultLogFileMaxCount            uint64  = 7
+	defaultTreeWorkerNiceLevel        uint64  = 10
+	maxTreeWorkerNiceLevel            uint64  = 19
 )
 
+// Wallet profile names are used as folder names
+var walletProfileNamePattern = regexp.MustCompile("^[A-Za-z0-9_-]{1,32}$")
+
 // Configuration for the Smartnode
 type SmartnodeConfig struct {
 	Title string `yaml:"-"`
@@ -81,116 +135,205 @@ type SmartnodeConfig struct {
 	// URL for an EC with archive mode, for manual rewards tree generation
 	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
 
+	// URL for a Beacon node that heavy operations like rewards tree generation are pinned to
+	RewardsBeaconNodeUrl config.Parameter `yaml:"rewardsBeaconNodeUrl,omitempty"`
+
+	// Toggle for regenerating the rewards tree of each new interval to verify the one submitted by the Oracle DAO
+	VerifyRewardsTrees config.Parameter `yaml:"verifyRewardsTrees,omitempty"`
+
+	// Toggle for generating rewards trees in a separate worker process, and the resource limits for it
+	UseTreeWorker         config.Parameter `yaml:"useTreeWorker,omitempty"`
+	TreeWorkerMemoryLimit config.Parameter `yaml:"treeWorkerMemoryLimit,omitempty"`
+	TreeWorkerNiceLevel   config.Parameter `yaml:"treeWorkerNiceLevel,omitempty"`
+
+	// What to capture when profiling rewards tree generation
+	TreeGenProfiling config.Parameter `yaml:"treeGenProfiling,omitempty"`
+
+	// The number of concurrent Beacon API requests to use when checking attestations during rewards tree generation
+	AttestationScanConcurrency config.Parameter `yaml:"attestationScanConcurrency,omitempty"`
+
+	// Limits for the Beacon API requests made during rewards tree generation
+	BeaconRequestRateLimit  config.Parameter `yaml:"beaconRequestRateLimit,omitempty"`
+	BeaconRequestMaxRetries config.Parameter `yaml:"beaconRequestMaxRetries,omitempty"`
+
+	// The maximum size of the on-disk cache of finalized Beacon chain data, in MB
+	BeaconCacheSize config.Parameter `yaml:"beaconCacheSize,omitempty"`
+
+	// The maximum number of concurrent Execution client calls to send as a single JSON-RPC batch request
+	ExecutionBatchSize config.Parameter `yaml:"executionBatchSize,omitempty"`
+
+	// Toggle for sending transactions through a private relay instead of the public mempool, the relay to use, and whether to fall back to the public mempool if it fails
+	UsePrivateRelay      config.Parameter `yaml:"usePrivateRelay,omitempty"`
+	PrivateRelayUrl      config.Parameter `yaml:"privateRelayUrl,omitempty"`
+	PrivateRelayFallback config.Parameter `yaml:"privateRelayFallback,omitempty"`
+
+	// The number of network state snapshots to keep on disk for incident analysis
+	StateSnapshotRetention config.Parameter `yaml:"stateSnapshotRetention,omitempty"`
+
+	// How often the watchtower rebuilds the network state from scratch instead of applying contract events to it
+	StateFullRefreshInterval config.Parameter `yaml:"stateFullRefreshInterval,omitempty"`
+
+	// How old the node daemon's cached network state can be before API checks read from the chain instead
+	CachedStateMaxSlots config.Parameter `yaml:"cachedStateMaxSlots,omitempty"`
+
+	// How long Docker gives the node and watchtower daemons to finish their work when they're stopped
+	ShutdownGracePeriod config.Parameter `yaml:"shutdownGracePeriod,omitempty"`
+
+	// The format that rewards files are saved to disk in
+	RewardsFileFormat config.Parameter `yaml:"rewardsFileFormat,omitempty"`
+
+	// The number of intervals to keep rewards files for, and what to do with them afterwards
+	RewardsRetentionIntervals config.Parameter `yaml:"rewardsRetentionIntervals,omitempty"`
+	RewardsPruneMode          config.Parameter `yaml:"rewardsPruneMode,omitempty"`
+
+	// Where Oracle DAO members upload rewards files to
+	RewardsStorageMode config.Parameter `yaml:"rewardsStorageMode,omitempty"`
+
 	// Token for Oracle DAO members to use when uploading Merkle trees to Web3.Storage
 	Web3StorageApiToken config.Parameter `yaml:"web3StorageApiToken,omitempty"`
 
-	// Manual override for the watchtower's max fee
-	WatchtowerMaxFeeOverride config.Parameter `yaml:"watchtowerMaxFeeOverride,omitempty"`
+	// The URL of the IPFS node's HTTP API to upload rewards files to
+	IpfsApiUrl config.Parameter `yaml:"ipfsApiUrl,omitempty"`
 
-	// Manual override for the watchtower's priority fee
-	WatchtowerPrioFeeOverride config.Parameter `yaml:"watchtowerPrioFeeOverride,omitempty"`
+	// S3-compatible object storage for rewards files
+	S3Endpoint        config.Parameter `yaml:"s3Endpoint,omitempty"`
+	S3Region          config.Parameter `yaml:"s3Region,omitempty"`
+	S3Bucket          config.Parameter `yaml:"s3Bucket,omitempty"`
+	S3AccessKeyID     config.Parameter `yaml:"s3AccessKeyId,omitempty"`
+	S3SecretAccessKey config.Parameter `yaml:"s3SecretAccessKey,omitempty"`
 
-	// The epoch to switch over to TWAP for RPL price reporting
-	RplTwapEpoch config.Parameter `yaml:"rplTwapEpoch,omitempty"`
+	// Additional pinning services for rewards files
+	PinataJwt      config.Parameter `yaml:"pinataJwt,omitempty"`
+	IpfsClusterUrl config.Parameter `yaml:"ipfsClusterUrl,omitempty"`
 
-	// The epoch to start using the new network balance calculation implementation
-	BalancesModernizationEpoch config.Parameter `yaml:"balancesModernizationEpoch,omitempty"`
+	// Toggle for checking that rewards files can be retrieved from a public gateway before submitting them
+	VerifyRewardsRetrievability config.Parameter `yaml:"verifyRewardsRetrievability,omitempty"`
 
-	///////////////////////////
-	// Non-editable settings //
-	///////////////////////////
+	// How the watchtower chooses the fees for its transactions
+	WatchtowerGasMode config.Parameter `yaml:"watchtowerGasMode,omitempty"`
+
+	// The watchtower's max fee (or its ceiling, in dynamic mode)
+	WatchtowerMaxFeeOverride config.Parameter `yaml:"watchtowerMaxFeeOverride,omitempty"`
 
-	// The URL to provide the user so they can follow pending transactions
-	txWatchUrl map[config.Network]string `yaml:"-"`
+	// The watchtower's priority fee
+	WatchtowerPrioFeeOverride config.Parameter `yaml:"watchtowerPrioFeeOverride,omitempty"`
+
+	// The network fee (in gwei) at or above which non-urgent watchtower submissions are deferred, in dynamic mode
+	WatchtowerDeferralFee config.Parameter `yaml:"watchtowerDeferralFee,omitempty"`
 
-	// The URL to use for staking rETH
-	stakeUrl map[config.Network]string `yaml:"-"`
+	// The maximum time (in hours) a non-urgent watchtower submission can be deferred for, in dynamic mode
+	WatchtowerMaxDeferral config.Parameter `yaml:"watchtowerMaxDeferral,omitempty"`
 
-	// The map of networks to execution chain IDs
-	chainID map[config.Network]uint `yaml:"-"`
+	// The maximum random delay (in seconds) added to each watchtower task's interval
+	WatchtowerTaskJitter config.Parameter `yaml:"watchtowerTaskJitter,omitempty"`
 
-	// The contract address of RocketStorage
-	storageAddress map[config.Network]string `yaml:"-"`
+	// Overrides for the run intervals of individual watchtower tasks
+	WatchtowerTaskIntervals config.Parameter `yaml:"watchtowerTaskIntervals,omitempty"`
 
-	// The contract address of the 1inch oracle
-	oneInchOracleAddress map[config.Network]string `yaml:"-"`
+	// Watchtower tasks that should not be run
+	WatchtowerDisabledTasks config.Parameter `yaml:"watchtowerDisabledTasks,omitempty"`
 
-	// The contract address of the RPL token
-	rplTokenAddress map[config.Network]string `yaml:"-"`
+	// The maximum deviation (in percent) from other Oracle DAO members' balance submissions before an alert is raised
+	WatchtowerBalanceTolerance config.Parameter `yaml:"watchtowerBalanceTolerance,omitempty"`
 
-	// The contract address of the RPL faucet
-	rplFaucetAddress map[config.Network]string `yaml:"-"`
+	// Toggle for withholding balance submissions that deviate from other Oracle DAO members until they're manually approved
+	WatchtowerWithholdDeviatingBalances config.Parameter `yaml:"watchtowerWithholdDeviatingBalances,omitempty"`
 
-	// The contract address for Snapshot delegation
-	snapshotDelegationAddress map[config.Network]string `yaml:"-"`
+	// Secondary RPL price sources to cross-validate the TWAP against before submitting prices
+	WatchtowerPriceOracles   config.Parameter `yaml:"watchtowerPriceOracles,omitempty"`
+	WatchtowerPriceTolerance config.Parameter `yaml:"watchtowerPriceTolerance,omitempty"`
 
-	// The Snapshot API domain
-	snapshotApiDomain map[config.Network]string `yaml:"-"`
+	// Toggle for checking proposals for illegal fee recipients and submitting penalties
+	WatchtowerProcessPenalties config.Parameter `yaml:"watchtowerProcessPenalties,omitempty"`
 
-	// The contract address of rETH
-	rethAddress map[config.Network]string `yaml:"-"`
+	// Rules for automatically voting on Oracle DAO proposals
+	WatchtowerProposalVoteRules config.Parameter `yaml:"watchtowerProposalVoteRules,omitempty"`
 
-	// The contract address of rocketRewardsPool from v1.0.0
-	v1_0_0_RewardsPoolAddress map[config.Network]string `yaml:"-"`
+	// Limits for the watchtower's timed-out minipool dissolutions
+	WatchtowerDissolveBatchSize  config.Parameter `yaml:"watchtowerDissolveBatchSize,omitempty"`
+	WatchtowerDissolveGasCeiling config.Parameter `yaml:"watchtowerDissolveGasCeiling,omitempty"`
 
-	// The contract address of rocketClaimNode from v1.0.0
-	v1_0_0_ClaimNodeAddress map[config.Network]string `yaml:"-"`
+	// Secondary-market sources for the rETH/ETH price
+	RethPriceSources config.Parameter `yaml:"rethPriceSources,omitempty"`
 
-	// The contract address of rocketClaimTrustedNode from v1.0.0
-	v1_0_0_ClaimTrustedNodeAddress map[config.Network]string `yaml:"-"`
+	// The price API and currency used to value rewards in fiat
+	PriceApiUrl  config.Parameter `yaml:"priceApiUrl,omitempty"`
+	FiatCurrency config.Parameter `yaml:"fiatCurrency,omitempty"`
 
-	// The contract address of rocketMinipoolManager from v1.0.0
-	v1_0_0_MinipoolManagerAddress map[config.Network]string `yaml:"-"`
+	// The role granted to API server clients that don't provide a token
+	ApiUnauthenticatedRole config.Parameter `yaml:"apiUnauthenticatedRole,omitempty"`
 
-	// The contract address of rocketNetworkPrices from v1.1.0
-	v1_1_0_NetworkPricesAddress map[config.Network]string `yaml:"-"`
+	// Additional API server tokens and the roles they grant
+	ApiRoleTokens config.Parameter `yaml:"apiRoleTokens,omitempty"`
 
-	// The contract address of rocketNodeStaking from v1.1.0
-	v1_1_0_NodeStakingAddress map[config.Network]string `yaml:"-"`
+	// What signs transactions for the node account
+	NodeKeySigner config.Parameter `yaml:"nodeKeySigner,omitempty"`
 
-	// The contract address of rocketNodeDeposit from v1.1.0
-	v1_1_0_NodeDepositAddress map[config.Network]string `yaml:"-"`
+	// The derivation path of the node account on a hardware wallet
+	HardwareWalletDerivationPath config.Parameter `yaml:"hardwareWalletDerivationPath,omitempty"`
 
-	// The contract address of rocketMinipoolQueue from v1.1.0
-	v1_1_0_MinipoolQueueAddress map[config.Network]string `yaml:"-"`
+	// The URL of the remote signer and the node account it signs for
+	ExternalSignerUrl     config.Parameter `yaml:"externalSignerUrl,omitempty"`
+	ExternalSignerAddress config.Parameter `yaml:"externalSignerAddress,omitempty"`
 
-	// The contract address of rocketMinipoolFactory from v1.1.0
-	v1_1_0_MinipoolFactoryAddress map[config.Network]string `yaml:"-"`
+	// Where the node wallet's password comes from, and the provider-specific source of it
+	WalletPasswordProvider config.Parameter `yaml:"walletPasswordProvider,omitempty"`
+	WalletPasswordSource   config.Parameter `yaml:"walletPasswordSource,omitempty"`
 
-	// Addresses for RocketRewardsPool that have been upgraded during development
-	previousRewardsPoolAddresses map[config.Network]map[string][]common.Address `yaml:"-"`
+	// The Web3Signer instance that holds validator keys, and the validator clients it holds them for
+	Web3SignerUrl     config.Parameter `yaml:"web3SignerUrl,omitempty"`
+	Web3SignerClients config.Parameter `yaml:"web3SignerClients,omitempty"`
 
-	// The RocketOvmPriceMessenger Optimism address for each network
-	optimismPriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// The validator client's keymanager API, used to check the fee recipient it's actually using
+	VcKeymanagerUrl       config.Parameter `yaml:"vcKeymanagerUrl,omitempty"`
+	VcKeymanagerTokenPath config.Parameter `yaml:"vcKeymanagerTokenPath,omitempty"`
 
-	// The RocketOvmPriceMessenger Polygon address for each network
-	polygonPriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// Limits on the transactions the node wallet will sign, and which of them need interactive confirmation
+	WalletPolicyMaxValue        config.Parameter `yaml:"walletPolicyMaxValue,omitempty"`
+	WalletPolicyMaxFee          config.Parameter `yaml:"walletPolicyMaxFee,omitempty"`
+	WalletPolicyDailySpendCap   config.Parameter `yaml:"walletPolicyDailySpendCap,omitempty"`
+	WalletPolicyConfirmCommands config.Parameter `yaml:"walletPolicyConfirmCommands,omitempty"`
+	WalletPolicyAutoTasks       config.Parameter `yaml:"walletPolicyAutoTasks,omitempty"`
 
-	// The RocketOvmPriceMessenger Arbitrum address for each network
-	arbitrumPriceMessengerAddress map[config.Network]string `yaml:"-"`
+	// A deployment file in the data folder describing a custom Rocket Pool deployment, such as one on a private chain
+	DeploymentFile config.Parameter `yaml:"deploymentFile,omitempty"`
 
-	// Rewards submission block maps
-	rewardsSubmissionBlockMaps map[config.Network][]uint64 `yaml:"-"`
+	// How the daemons write their logs, and the minimum level of the messages they write overall and per module
+	LogFormat       config.Parameter `yaml:"logFormat,omitempty"`
+	LogLevel        config.Parameter `yaml:"logLevel,omitempty"`
+	ModuleLogLevels config.Parameter `yaml:"moduleLogLevels,omitempty"`
 
-	// The UniswapV3 pool address for each network (used for RPL price TWAP info)
-	rplTwapPoolAddress map[config.Network]string `yaml:"-"`
+	// When the daemons' log files are rotated, and how many old ones are kept
+	LogFileMaxSize  config.Parameter `yaml:"logFileMaxSize,omitempty"`
+	LogFileMaxAge   config.Parameter `yaml:"logFileMaxAge,omitempty"`
+	LogFileMaxCount config.Parameter `yaml:"logFileMaxCount,omitempty"`
+
+	// The epoch to switch over to TWAP for RPL price reporting
+	RplTwapEpoch config.Parameter `yaml:"rplTwapEpoch,omitempty"`
 
-	// The multicall contract address
-	multicallAddress map[config.Network]string `yaml:"-"`
+	// The epoch to start using the new network balance calculation implementation
+	BalancesModernizationEpoch config.Parameter `yaml:"balancesModernizationEpoch,omitempty"`
 
-	// The BalanceChecker contract address
-	balancebatcherAddress map[config.Network]string `yaml:"-"`
+	///////////////////////////
+	// Non-editable settings //
+	///////////////////////////
 
-	// The FlashBots Protect RPC endpoint
-	flashbotsProtectUrl map[config.Network]string `yaml:"-"`
+	// The deployment for the network it was last loaded for, and the deployment file it was loaded from if there was one
+	deployment        *NetworkDeployment `yaml:"-"`
+	deploymentNetwork config.Network     `yaml:"-"`
+	deploymentFile    string             `yaml:"-"`
+	deploymentPath    string             `yaml:"-"`
+	deploymentErr     error              `yaml:"-"`
+	deploymentLock    *sync.Mutex        `yaml:"-"`
 }
 
 // Generates a new Smartnode configuration
 func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 
 	return &SmartnodeConfig{
-		Title:  "Smartnode Settings",
-		parent: cfg,
+		Title:          "Smartnode Settings",
+		parent:         cfg,
+		deploymentLock: &sync.Mutex{},
 
 		ProjectName: config.Parameter{
 			ID:                   ProjectNameID,
@@ -316,6 +459,7 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			Name:                 "Archive-Mode EC URL",
 			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
 			Type:                 config.ParameterType_String,
+			IsSecret:             true,
 			Default:              map[config.Network]interface{}{config.Network_All: ""},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
@@ -323,294 +467,1183 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
-		Web3StorageApiToken: config.Parameter{
-			ID:                   "web3StorageApiToken",
-			Name:                 "Web3.Storage API Token",
-			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The API token for your https://web3.storage/ account. This is required in order for you to upload Merkle rewards trees to Web3.Storage at each rewards interval.",
+		RewardsBeaconNodeUrl: config.Parameter{
+			ID:                   "rewardsBeaconNodeUrl",
+			Name:                 "Rewards Tree Beacon Node URL",
+			Description:          "The URL of a Beacon node to send the heavy requests made during Merkle rewards tree generation to, such as scanning attestations, so they don't slow down your primary Consensus client. Leave this blank to use your normal Beacon nodes.\n\nIf this Beacon node goes offline, generation will continue on your normal Beacon nodes.",
 			Type:                 config.ParameterType_String,
 			Default:              map[config.Network]interface{}{config.Network_All: ""},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
 		},
 
-		WatchtowerMaxFeeOverride: config.Parameter{
-			ID:                   "watchtowerMaxFeeOverride",
-			Name:                 "Watchtower Max Fee Override",
-			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the max fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the max fee higher than %d during times of extreme network stress.", WatchtowerMaxFeeDefault, WatchtowerMaxFeeDefault),
-			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerMaxFeeDefault)},
+		VerifyRewardsTrees: config.Parameter{
+			ID:                   "verifyRewardsTrees",
+			Name:                 "Verify Rewards Trees",
+			Description:          "Enable this to have your node independently regenerate the Merkle rewards tree for each new rewards interval once the Oracle DAO has submitted it, and compare its Merkle root against the canonical one on-chain. You will be alerted if they don't match.\n\nThis takes a significant amount of time and resources at the start of each interval, and may require an Archive-Mode EC.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		WatchtowerPrioFeeOverride: config.Parameter{
-			ID:                   "watchtowerPrioFeeOverride",
-			Name:                 "Watchtower Priority Fee Override",
-			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]Use this to override the priority fee (in gwei) for watchtower transactions. Note that if you set it below %d, the setting will be ignored; it can only be used to set the priority fee higher than %d during times of extreme network stress.", WatchtowerPrioFeeDefault, WatchtowerPrioFeeDefault),
-			Type:                 config.ParameterType_Float,
-			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerPrioFeeDefault)},
+		UseTreeWorker: config.Parameter{
+			ID:                   "useTreeWorker",
+			Name:                 "Use Tree Worker Process",
+			Description:          "Enable this to have the watchtower generate Merkle rewards trees in a separate worker process, instead of inside the watchtower itself. If generation runs out of memory or crashes, only the worker is lost; the watchtower and its other duties, such as price and balance submissions, keep running.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		RplTwapEpoch: config.Parameter{
-			ID:          "rplTwapEpoch",
-			Name:        "RPL TWAP Epoch",
-			Description: "[orange]**For Oracle DAO members only.**\n\n[white]The epoch to switch from spot prices to TWAP for RPL price submission.",
-			Type:        config.ParameterType_Uint,
-			Default: map[config.Network]interface{}{
-				config.Network_Mainnet: uint64(193414),
-				config.Network_Prater:  uint64(162094),
-				config.Network_Devnet:  uint64(162094),
-			},
+		TreeWorkerMemoryLimit: config.Parameter{
+			ID:                   "treeWorkerMemoryLimit",
+			Name:                 "Tree Worker Memory Limit",
+			Description:          "The soft memory limit (in MiB) of the rewards tree worker process, passed to it as `GOMEMLIMIT`. The worker will collect garbage more aggressively as it approaches this limit, trading speed for a smaller footprint.\n\nUse 0 for no limit.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		BalancesModernizationEpoch: config.Parameter{
-			ID:          "balancesModernizationEpoch",
-			Name:        "Balances Modernization Epoch",
-			Description: "[orange]**For Oracle DAO members only.**\n\n[white]The epoch to switch from the old network balance calculation method to the new one.",
-			Type:        config.ParameterType_Uint,
-			Default: map[config.Network]interface{}{
-				config.Network_Mainnet: uint64(194089),
-				config.Network_Prater:  uint64(162094),
-				config.Network_Devnet:  uint64(162094),
-			},
+		TreeWorkerNiceLevel: config.Parameter{
+			ID:                   "treeWorkerNiceLevel",
+			Name:                 "Tree Worker Nice Level",
+			Description:          "The nice level (from 0 to 19) that the rewards tree worker process runs at. Higher levels give it a lower CPU priority, so tree generation doesn't compete with your clients and the watchtower's other duties.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultTreeWorkerNiceLevel},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           false,
-			OverwriteOnUpgrade:   true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		txWatchUrl: map[config.Network]string{
-			config.Network_Mainnet: "https://etherscan.io/tx",
-			config.Network_Prater:  "https://goerli.etherscan.io/tx",
-			config.Network_Devnet:  "https://goerli.etherscan.io/tx",
+		TreeGenProfiling: config.Parameter{
+			ID:                   "treeGenProfiling",
+			Name:                 "Tree Generation Profiling",
+			Description:          "Select what to record when your node generates a Merkle rewards tree. The timing of each phase of generation is written to a report in the `profiles` folder of your rewards trees folder, along with any CPU profile or execution trace that was captured.\n\nThis is meant for diagnosing slow tree generation, and is not needed during normal operation.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.TreeGenProfilingMode_None},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Don't profile tree generation.",
+				Value:       config.TreeGenProfilingMode_None,
+			}, {
+				Name:        "Timing",
+				Description: "Only record how long each phase of tree generation takes.",
+				Value:       config.TreeGenProfilingMode_Timing,
+			}, {
+				Name:        "CPU Profile",
+				Description: "Record the timing of each phase, and capture a CPU profile and a heap profile of generation that can be opened with `go tool pprof`.",
+				Value:       config.TreeGenProfilingMode_Cpu,
+			}, {
+				Name:        "Execution Trace",
+				Description: "Record the timing of each phase, and capture an execution trace of generation that can be opened with `go tool trace`. Traces can be very large on long intervals.",
+				Value:       config.TreeGenProfilingMode_Trace,
+			}},
 		},
 
-		stakeUrl: map[config.Network]string{
-			config.Network_Mainnet: "https://stake.rocketpool.net",
-			config.Network_Prater:  "https://testnet.rocketpool.net",
-			config.Network_Devnet:  "TBD",
+		AttestationScanConcurrency: config.Parameter{
+			ID:                   "attestationScanConcurrency",
+			Name:                 "Attestation Scan Concurrency",
+			Description:          "The maximum number of requests to send to your Beacon Node at the same time while checking the attestation performance of Smoothing Pool minipools during rewards tree generation.\n\nLower this if your Beacon Node struggles to keep up during tree generation, or raise it if it's fast and you want generation to finish sooner.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(32)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		chainID: map[config.Network]uint{
-			config.Network_Mainnet: 1, // Mainnet
-			config.Network_Prater:  5, // Goerli
-			config.Network_Devnet:  5, // Also goerli
+		BeaconRequestRateLimit: config.Parameter{
+			ID:                   "beaconRequestRateLimit",
+			Name:                 "Beacon Request Rate Limit",
+			Description:          "The maximum number of requests per second to send to your Beacon Node during rewards tree generation. Set this if you use a Beacon Node provider with a rate limit.\n\nUse 0 for no limit.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		storageAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x1d8f8f00cfa6758d7bE78336684788Fb0ee0Fa46",
-			config.Network_Prater:  "0xd8Cd47263414aFEca62d6e2a3917d6600abDceB3",
-			config.Network_Devnet:  "0x6A18E47f8CcB453Dd0894AC003f74BEE7e47A368",
+		BeaconRequestMaxRetries: config.Parameter{
+			ID:                   "beaconRequestMaxRetries",
+			Name:                 "Beacon Request Retries",
+			Description:          "The number of times a failed request to your Beacon Node will be retried during rewards tree generation before generation is aborted. Retries wait longer after each attempt, up to one minute.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(5)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		oneInchOracleAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x07D91f5fb9Bf7798734C3f606dB065549F6893bb",
-			config.Network_Prater:  "0x4eDC966Df24264C9C817295a0753804EcC46Dd22",
-			config.Network_Devnet:  "0x4eDC966Df24264C9C817295a0753804EcC46Dd22",
+		BeaconCacheSize: config.Parameter{
+			ID:                   "beaconCacheSize",
+			Name:                 "Beacon Cache Size",
+			Description:          "The maximum size, in MB, of the on-disk cache of finalized Beacon chain data (blocks, attestations, committees, and validator statuses) that the watchtower and rewards tree generation share. Repeated runs over the same interval will read from this cache instead of downloading the same data from your Beacon Node again. The least recently used data is removed once the cache is full.\n\nSet this to 0 to disable the cache.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(1024)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		rplTokenAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xD33526068D116cE69F19A9ee46F0bd304F21A51f",
-			config.Network_Prater:  "0x5e932688e81a182e3de211db6544f98b8e4f89c7",
-			config.Network_Devnet:  "0x09b6aEF57B580f5CB46746BA59ed312Ba80E8Ad4",
+		ExecutionBatchSize: config.Parameter{
+			ID:                   "executionBatchSize",
+			Name:                 "Execution Batch Size",
+			Description:          "The maximum number of contract calls and balance lookups that the Smartnode will combine into a single JSON-RPC batch request to your Execution client. Batching greatly reduces the number of round trips needed to load things like your minipool details, which is especially helpful for remote Execution clients such as Infura.\n\nSet this to 0 to send every request individually, if your Execution client doesn't support batch requests.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(100)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		rplFaucetAddress: map[config.Network]string{
-			config.Network_Mainnet: "",
-			config.Network_Prater:  "0x95D6b8E2106E3B30a72fC87e2B56ce15E37853F9",
-			config.Network_Devnet:  "0x218a718A1B23B13737E2F566Dd45730E8DAD451b",
+		UsePrivateRelay: config.Parameter{
+			ID:                   "usePrivateRelay",
+			Name:                 "Use Private Relay",
+			Description:          "Enable this to send the Smartnode's transactions (including the node daemon's automatic ones) through a private transaction relay instead of broadcasting them to the public mempool. Transactions sent privately can't be seen by MEV searchers before they're included in a block, which protects large RPL swaps and stakes from being front-run or sandwiched.\n\nYour Execution client is still used for everything else, such as reading the chain and waiting for transactions.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		rethAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xae78736Cd615f374D3085123A210448E74Fc6393",
-			config.Network_Prater:  "0x178E141a0E3b34152f73Ff610437A7bf9B83267A",
-			config.Network_Devnet:  "0x2DF914425da6d0067EF1775AfDBDd7B24fc8100E",
+		PrivateRelayUrl: config.Parameter{
+			ID:                   "privateRelayUrl",
+			Name:                 "Private Relay URL",
+			Description:          "The JSON-RPC URL of the private transaction relay to send transactions through when Use Private Relay is enabled. It must accept `eth_sendRawTransaction`.\n\nLeave this blank to use Flashbots Protect.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		v1_0_0_RewardsPoolAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xA3a18348e6E2d3897B6f2671bb8c120e36554802",
-			config.Network_Prater:  "0xf9aE18eB0CE4930Bc3d7d1A5E33e4286d4FB0f8B",
-			config.Network_Devnet:  "0x4A1b5Ab9F6C36E7168dE5F994172028Ca8554e02",
+		PrivateRelayFallback: config.Parameter{
+			ID:                   "privateRelayFallback",
+			Name:                 "Private Relay Fallback",
+			Description:          "Enable this to send a transaction to the public mempool through your Execution client if the private relay can't be reached or rejects it. Disable it if you would rather a transaction fail than be broadcast publicly.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		v1_0_0_ClaimNodeAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x899336A2a86053705E65dB61f52C686dcFaeF548",
-			config.Network_Prater:  "0xc05b7A2a03A6d2736d1D0ebf4d4a0aFE2cc32cE1",
-			config.Network_Devnet:  "",
+		StateSnapshotRetention: config.Parameter{
+			ID:                   "stateSnapshotRetention",
+			Name:                 "State Snapshot Retention",
+			Description:          "The number of network state snapshots to keep on disk. When this is more than 0, the node and watchtower daemons save a compressed copy of each network state they load (node details, minipool statuses, stakes, and validator statuses), which you can compare with `rocketpool network diff-state-snapshots` to see what changed between them.\n\nFull network snapshots taken by the watchtower can be large on Mainnet. Set this to 0 to disable snapshots.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		v1_0_0_ClaimTrustedNodeAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x6af730deB0463b432433318dC8002C0A4e9315e8",
-			config.Network_Prater:  "0x730982F4439E5AC30292333ff7d0C478907f2219",
-			config.Network_Devnet:  "",
+		StateFullRefreshInterval: config.Parameter{
+			ID:                   "stateFullRefreshInterval",
+			Name:                 "Full State Refresh Interval",
+			Description:          "The number of minutes between full rebuilds of the network state in the watchtower. Between full rebuilds, the watchtower only reloads the nodes and minipools that emitted contract events or had their balances change since the previous update, which puts much less load on your Execution client.\n\nSet this to 0 to rebuild the entire network state on every update.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(60)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		v1_0_0_MinipoolManagerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x6293B8abC1F36aFB22406Be5f96D893072A8cF3a",
-			config.Network_Prater:  "0xB815a94430f08dD2ab61143cE1D5739Ac81D3C6d",
-			config.Network_Devnet:  "",
+		CachedStateMaxSlots: config.Parameter{
+			ID:                   "cachedStateMaxSlots",
+			Name:                 "Cached State Max Age (Slots)",
+			Description:          "The node daemon caches the network state it loads for your node on disk. Commands that check whether an action is possible (such as whether a minipool can be staked or refunded) use that cache instead of querying your clients if it was loaded no more than this many Beacon slots behind the chain head, so they respond much faster.\n\nYou can skip the cache for a single command with `rocketpool --no-cache`. Set this to 0 to disable the cache entirely.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(50)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		v1_1_0_NetworkPricesAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xd3f500F550F46e504A4D2153127B47e007e11166",
-			config.Network_Prater:  "0x12f96dC173a806D18d71fAFe3C1BA2149c3E3Dc6",
-			config.Network_Devnet:  "",
+		ShutdownGracePeriod: config.Parameter{
+			ID:                   "shutdownGracePeriod",
+			Name:                 "Shutdown Grace Period",
+			Description:          "The number of seconds Docker (or systemd in Native mode) gives the node and watchtower daemons to finish their current task and background work (such as in-flight transactions and rewards tree generation) when they're stopped, before it kills them. The daemons stop waiting for their background work a few seconds before this runs out.\n\nTo keep the containers from starting new tasks well before an upgrade, run `rocketpool api service prepare-shutdown`; `rocketpool api service cancel-shutdown` resumes them if you change your mind.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(60)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		v1_1_0_NodeStakingAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xA73ec45Fe405B5BFCdC0bF4cbc9014Bb32a01cd2",
-			config.Network_Prater:  "0xA73ec45Fe405B5BFCdC0bF4cbc9014Bb32a01cd2",
-			config.Network_Devnet:  "",
+		RewardsFileFormat: config.Parameter{
+			ID:                   "rewardsFileFormat",
+			Name:                 "Rewards File Format",
+			Description:          "Select the format that Merkle rewards tree and minipool performance files are saved to disk in when your node generates or downloads them.\n\nThe files uploaded to IPFS by the Oracle DAO always use JSON.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsFileFormat_Json},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "JSON",
+				Description: "Save the files as JSON, which is the format used by the Oracle DAO when publishing them.",
+				Value:       config.RewardsFileFormat_Json,
+			}, {
+				Name:        "Binary",
+				Description: "Save the files in a compact binary format that is written incrementally, which uses much less memory and disk space on large intervals. Use this if your node runs out of memory while generating rewards trees.",
+				Value:       config.RewardsFileFormat_Binary,
+			}},
 		},
 
-		v1_1_0_NodeDepositAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x1Cc9cF5586522c6F483E84A19c3C2B0B6d027bF0",
-			config.Network_Prater:  "0x1Cc9cF5586522c6F483E84A19c3C2B0B6d027bF0",
-			config.Network_Devnet:  "",
+		RewardsRetentionIntervals: config.Parameter{
+			ID:                   "rewardsRetentionIntervals",
+			Name:                 "Rewards File Retention",
+			Description:          "The number of past rewards intervals to keep Merkle rewards tree and minipool performance files for. Older files will be pruned by the node daemon after each new interval.\n\nRewards tree files for intervals your node hasn't claimed yet are always kept, since they're needed to claim. Use 0 to keep all files.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		v1_1_0_MinipoolQueueAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x5870dA524635D1310Dc0e6F256Ce331012C9C19E",
-			config.Network_Prater:  "0xEF5EF45bf1CC08D5694f87F8c4023f00CCCB7237",
-			config.Network_Devnet:  "",
+		RewardsPruneMode: config.Parameter{
+			ID:                   "rewardsPruneMode",
+			Name:                 "Rewards File Pruning",
+			Description:          "Select what happens to rewards files that are older than the retention period.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsPruneMode_Delete},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Delete",
+				Description: "Delete the old files.",
+				Value:       config.RewardsPruneMode_Delete,
+			}, {
+				Name:        "Archive",
+				Description: "Compress the old files and move them into the `archive` folder inside the rewards trees folder.",
+				Value:       config.RewardsPruneMode_Archive,
+			}},
 		},
 
-		v1_1_0_MinipoolFactoryAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x54705f80D7C51Fcffd9C659ce3f3C9a7dCCf5788",
-			config.Network_Prater:  "0x54705f80D7C51Fcffd9C659ce3f3C9a7dCCf5788",
-			config.Network_Devnet:  "",
+		RewardsStorageMode: config.Parameter{
+			ID:                   "rewardsStorageMode",
+			Name:                 "Rewards File Storage",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Select where the Merkle rewards tree and minipool performance files should be uploaded to at each rewards interval.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RewardsStorageMode_Web3Storage},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Web3.Storage",
+				Description: "Upload the files to https://web3.storage/. This requires a Web3.Storage API token.",
+				Value:       config.RewardsStorageMode_Web3Storage,
+			}, {
+				Name:        "IPFS Node",
+				Description: "Upload and pin the files to your own IPFS node (such as Kubo / go-ipfs) through its HTTP API.",
+				Value:       config.RewardsStorageMode_Ipfs,
+			}, {
+				Name:        "S3",
+				Description: "Upload the files to an S3-compatible object storage bucket. The IPFS CID of each file will be calculated locally; you are responsible for making the files available on IPFS.",
+				Value:       config.RewardsStorageMode_S3,
+			}, {
+				Name:        "Local Only",
+				Description: "Don't upload the files anywhere. The IPFS CID of each file will be calculated locally; you are responsible for making the files available on IPFS.",
+				Value:       config.RewardsStorageMode_Local,
+			}},
 		},
 
-		snapshotDelegationAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x469788fE6E9E9681C6ebF3bF78e7Fd26Fc015446",
-			config.Network_Prater:  "0xD0897D68Cd66A710dDCecDe30F7557972181BEDc",
-			config.Network_Devnet:  "",
+		Web3StorageApiToken: config.Parameter{
+			ID:                   "web3StorageApiToken",
+			Name:                 "Web3.Storage API Token",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The API token for your https://web3.storage/ account. This is required in order for you to upload Merkle rewards trees to Web3.Storage at each rewards interval.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		snapshotApiDomain: map[config.Network]string{
-			config.Network_Mainnet: "hub.snapshot.org",
-			config.Network_Prater:  "testnet.snapshot.org",
-			config.Network_Devnet:  "",
+		IpfsApiUrl: config.Parameter{
+			ID:                   "ipfsApiUrl",
+			Name:                 "IPFS API URL",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The URL of your IPFS node's HTTP API, used when the rewards file storage is set to IPFS Node.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "http://localhost:5001"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		previousRewardsPoolAddresses: map[config.Network]map[string][]common.Address{
-			config.Network_Mainnet: {
-				"v1.1.0": []common.Address{
-					common.HexToAddress("0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1"),
-				},
-			},
-			config.Network_Prater: {
-				"v1.1.0-rc1": []common.Address{
-					common.HexToAddress("0x594Fb75D3dc2DFa0150Ad03F99F97817747dd4E1"),
-				},
-				"v1.2.0-rc1": []common.Address{
-					common.HexToAddress("0x6e91E3416acf3d015358eeAAF247a0674F6c306f"),
-				},
-			},
-			config.Network_Devnet: {},
+		S3Endpoint: config.Parameter{
+			ID:                   "s3Endpoint",
+			Name:                 "S3 Endpoint",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The URL of the S3-compatible object storage service, such as `https://s3.us-east-1.amazonaws.com`, used when the rewards file storage is set to S3.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		optimismPriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xdddcf2c25d50ec22e67218e873d46938650d03a7",
-			config.Network_Prater:  "0x87E2deCE7d0A080D579f63cbcD7e1629BEcd7E7d",
-			config.Network_Devnet:  "",
+		S3Region: config.Parameter{
+			ID:                   "s3Region",
+			Name:                 "S3 Region",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The region of the S3 bucket.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "us-east-1"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		polygonPriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xb1029Ac2Be4e08516697093e2AFeC435057f3511",
-			config.Network_Prater:  "0x6D736da1dC2562DBeA9998385A0A27d8c2B2793e",
-			config.Network_Devnet:  "0x6D736da1dC2562DBeA9998385A0A27d8c2B2793e",
+		S3Bucket: config.Parameter{
+			ID:                   "s3Bucket",
+			Name:                 "S3 Bucket",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The name of the S3 bucket to upload rewards files to.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		arbitrumPriceMessengerAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x05330300f829AD3fC8f33838BC88CFC4093baD53",
-			config.Network_Prater:  "0x2b52479F6ea009907e46fc43e91064D1b92Fdc86",
-			config.Network_Devnet:  "0x2b52479F6ea009907e46fc43e91064D1b92Fdc86",
+		S3AccessKeyID: config.Parameter{
+			ID:                   "s3AccessKeyId",
+			Name:                 "S3 Access Key ID",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The ID of the access key used to upload to the S3 bucket.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		rplTwapPoolAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xe42318ea3b998e8355a3da364eb9d48ec725eb45",
-			config.Network_Prater:  "0x5cE71E603B138F7e65029Cc1918C0566ed0dBD4B",
-			config.Network_Devnet:  "0x5cE71E603B138F7e65029Cc1918C0566ed0dBD4B",
+		S3SecretAccessKey: config.Parameter{
+			ID:                   "s3SecretAccessKey",
+			Name:                 "S3 Secret Access Key",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The secret of the access key used to upload to the S3 bucket.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		multicallAddress: map[config.Network]string{
-			config.Network_Mainnet: "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
-			config.Network_Prater:  "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
-			config.Network_Devnet:  "0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696",
+		PinataJwt: config.Parameter{
+			ID:                   "pinataJwt",
+			Name:                 "Pinata JWT",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white](Optional) The JWT of your https://pinata.cloud/ API key. If set, rewards files will also be pinned to Pinata after they're uploaded for redundancy.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		balancebatcherAddress: map[config.Network]string{
-			config.Network_Mainnet: "0xb1f8e55c7f64d203c1400b9d8555d050f94adf39",
-			config.Network_Prater:  "0x9788C4E93f9002a7ad8e72633b11E8d1ecd51f9b",
-			config.Network_Devnet:  "0x9788C4E93f9002a7ad8e72633b11E8d1ecd51f9b",
+		IpfsClusterUrl: config.Parameter{
+			ID:                   "ipfsClusterUrl",
+			Name:                 "IPFS Cluster URL",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white](Optional) The URL of an IPFS Cluster REST API, such as `http://localhost:9094`. If set, rewards files will also be pinned to the cluster after they're uploaded for redundancy.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
 		},
 
-		flashbotsProtectUrl: map[config.Network]string{
-			config.Network_Mainnet: "https://rpc.flashbots.net/",
-			config.Network_Prater:  "https://rpc-goerli.flashbots.net/",
-			config.Network_Devnet:  "https://rpc-goerli.flashbots.net/",
+		VerifyRewardsRetrievability: config.Parameter{
+			ID:                   "verifyRewardsRetrievability",
+			Name:                 "Verify Rewards File Retrievability",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Enable this to check that each rewards file can be downloaded from a public IPFS gateway before its CID is submitted to the contracts. If it can't, the submission will be retried later.\n\nThis only applies to the storage modes that publish rewards files to IPFS (Web3.Storage and IPFS); it's skipped for the other modes.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
 		},
 
-		rewardsSubmissionBlockMaps: map[config.Network][]uint64{
-			config.Network_Mainnet: {
-				15451165, 15637542, 15839520, 16038366, 16238906, 16439406, // 5
-				16639856, 16841781,
-			},
-			config.Network_Prater: {
-				7287326, 7297026, 7314231, 7331462, 7387271, 7412366, // 5
-				7420574, 7436546, 7456423, 7473017, 7489726, 7506706, // 11
-				7525902, 7544630, 7562851, 7581623, 7600343, 7618815, // 17
-				7636720, 7654452, 7672147, 7689735, 7707617, 7725232, // 23
-				7742548, 7760702, 7777078, 7794263, 7811800, 7829115, // 29
-				7846870, 7863708, 7881537, 7900095, 7918951, 7937222, // 35
-				7955161, 7972837, 7990504, 8008474, 8027271, 8045546, // 41
-				8063957, 8082659, 8101400, 8119473, 8136892, 8154565, // 47
-				8172349, 8189717, 8207105, 8224279, 8241674, 8258210, // 53
-				8274526, 8290763, 8307407, 8324452, 8341708, 8359470, // 59
-				8377175, 8394786, 8412599, 8430221, 8447800, 8465317, // 65
-				8482337, 8499227, 8516593, 8533890, 8551379, 8569494, // 71
-				8587146, 8604666, 8621961, 8639563, 8656830, 8673617, // 77
-			},
-			config.Network_Devnet: {
-				7955303, 7972424, 8009064, 8026821, 8045113, 8063501, // 5
-				8082186, 8100941, 8119074, 8136452, 8154152, 8171923, // 11
-				8189312, 8206689, 8223857, 8241269, 8257834, 8274178, // 17
-				8290333, 8307005, 8324055, 8341308, 8359051, 8376744, // 23
-				8394338, 8412142,
-			},
+		WatchtowerGasMode: config.Parameter{
+			ID:                   "watchtowerGasMode",
+			Name:                 "Watchtower Gas Mode",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Choose how the watchtower sets the fees for its transactions.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.WatchtowerGasMode_Static},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Static",
+				Description: "Always use the Watchtower Max Fee and Watchtower Priority Fee.",
+				Value:       config.WatchtowerGasMode_Static,
+			}, {
+				Name:        "Dynamic",
+				Description: "Use the max fee suggested by the gas oracle, up to the Watchtower Max Fee. Non-urgent submissions (such as the rewards tree) are deferred while the network fee is at or above the Watchtower Deferral Fee, until the Watchtower Max Deferral has passed.",
+				Value:       config.WatchtowerGasMode_Dynamic,
+			}},
 		},
-	}
 
-}
+		WatchtowerMaxFeeOverride: config.Parameter{
+			ID:                   "watchtowerMaxFeeOverride",
+			Name:                 "Watchtower Max Fee",
+			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]The max fee (in gwei) for watchtower transactions. In Dynamic gas mode, this is the highest max fee the watchtower will use, regardless of what the gas oracle suggests.\n\nThe watchtower never uses a max fee below %d, in either mode; setting this lower has no effect.", WatchtowerMaxFeeDefault),
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerMaxFeeDefault)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
 
-// Get the parameters for this config
-func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
-	return []*config.Parameter{
-		&cfg.Network,
-		&cfg.ProjectName,
-		&cfg.DataPath,
-		&cfg.ManualMaxFee,
-		&cfg.PriorityFee,
-		&cfg.AutoTxGasThreshold,
-		&cfg.DistributeThreshold,
-		&cfg.RewardsTreeMode,
-		&cfg.ArchiveECUrl,
-		&cfg.Web3StorageApiToken,
+		WatchtowerPrioFeeOverride: config.Parameter{
+			ID:                   "watchtowerPrioFeeOverride",
+			Name:                 "Watchtower Priority Fee",
+			Description:          fmt.Sprintf("[orange]**For Oracle DAO members only.**\n\n[white]The priority fee (in gwei) for watchtower transactions. The watchtower never uses a priority fee below %d; setting this lower has no effect.", WatchtowerPrioFeeDefault),
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(WatchtowerPrioFeeDefault)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		WatchtowerDeferralFee: config.Parameter{
+			ID:                   "watchtowerDeferralFee",
+			Name:                 "Watchtower Deferral Fee",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]In Dynamic gas mode, non-urgent submissions (such as the rewards tree) will be deferred while the network fee (in gwei) suggested by the gas oracle is at or above this value. Set it to 0 to never defer them.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: WatchtowerDeferralFeeDefault},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerMaxDeferral: config.Parameter{
+			ID:                   "watchtowerMaxDeferral",
+			Name:                 "Watchtower Max Deferral",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]In Dynamic gas mode, the maximum time (in hours) a non-urgent submission can be deferred for after it becomes due. Once it has passed, the submission will be made regardless of the network fee.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: WatchtowerMaxDeferralDefault},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerTaskJitter: config.Parameter{
+			ID:                   "watchtowerTaskJitter",
+			Name:                 "Watchtower Task Jitter",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The maximum random delay (in seconds) added to the interval of each watchtower task after it runs. This keeps Oracle DAO members from all running their duties at the same time.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: WatchtowerTaskJitterDefault},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerTaskIntervals: config.Parameter{
+			ID:                   "watchtowerTaskIntervals",
+			Name:                 "Watchtower Task Intervals",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white](Optional) A comma-separated list of `task=interval` pairs that override how often individual watchtower tasks run, such as `submit-rpl-price=10m,submit-scrub-minipools=2m`. Tasks that aren't listed use their default interval.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerDisabledTasks: config.Parameter{
+			ID:                   "watchtowerDisabledTasks",
+			Name:                 "Watchtower Disabled Tasks",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white](Optional) A comma-separated list of watchtower tasks that should not be run, such as `check-solo-migrations,cancel-bond-reductions`. Disabling Oracle DAO duties may affect the network, so only use this if you know what you're doing.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerBalanceTolerance: config.Parameter{
+			ID:                   "watchtowerBalanceTolerance",
+			Name:                 "Watchtower Balance Tolerance",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Before submitting network balances, the watchtower compares them with the balances already submitted by other Oracle DAO members for the same block. If none of them are within this tolerance (in percent), an alert will be raised.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: WatchtowerBalanceToleranceDefault},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerWithholdDeviatingBalances: config.Parameter{
+			ID:                   "watchtowerWithholdDeviatingBalances",
+			Name:                 "Withhold Deviating Balances",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Enable this to have the watchtower withhold network balance submissions that deviate from the other Oracle DAO members' submissions beyond the tolerance until you review them. Once you have reviewed a withheld submission, you can release it with `rocketpool odao force-balance-submission`.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerPriceOracles: config.Parameter{
+			ID:          "watchtowerPriceOracles",
+			Name:        "Secondary Price Oracles",
+			Description: "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of secondary RPL/ETH price sources that the RPL price will be cross-validated against before it's submitted. Use `1inch` for the 1inch off-chain oracle, or `chainlink:<address>` for a Chainlink-compatible RPL/ETH price feed. Chainlink rounds older than the feed's heartbeat are rejected as stale; it defaults to 24 hours, and can be set with `chainlink:<address>:<heartbeat>` (e.g. `chainlink:<address>:1h`).\n\nIf any of them deviate from the RPL price by more than the Price Tolerance, or none of them can be reached, the price won't be submitted and an alert will be raised. Leave this blank to submit the RPL price without cross-validating it.",
+			Type:        config.ParameterType_String,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: "1inch",
+				config.Network_Prater:  "",
+				config.Network_Devnet:  "",
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerPriceTolerance: config.Parameter{
+			ID:                   "watchtowerPriceTolerance",
+			Name:                 "Price Tolerance",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The maximum amount (in percent) that a secondary price oracle can deviate from the RPL price before the price submission is refused.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: WatchtowerPriceToleranceDefault},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerProcessPenalties: config.Parameter{
+			ID:                   "watchtowerProcessPenalties",
+			Name:                 "Process Penalties",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]Enable this to have the watchtower check the fee recipient of every block proposed by a Rocket Pool validator, and submit a penalty against minipools that used an illegal one. The evidence for each penalty is recorded in the `penalty-evidence.jsonl` file in your watchtower folder.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerProposalVoteRules: config.Parameter{
+			ID:                   "watchtowerProposalVoteRules",
+			Name:                 "Proposal Vote Rules",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white](Optional) A comma-separated list of `type=vote` rules the watchtower will use to automatically vote on Oracle DAO proposals, such as `invite=yes,leave=yes`. The type can be `invite`, `leave`, `replace`, `kick`, `setting` or `upgrade`, and the vote can be `yes` or `no`. Add `@` followed by a `|`-separated list of addresses to a rule to only apply it to proposals made by those members, such as `invite=yes@0x1234...|0x5678...`. The first matching rule is used; proposals that don't match any rule are left for you to vote on manually. Every decision is recorded in the `proposal-votes.jsonl` file in your watchtower folder.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerDissolveBatchSize: config.Parameter{
+			ID:                   "watchtowerDissolveBatchSize",
+			Name:                 "Dissolve Batch Size",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The maximum number of timed-out minipool dissolutions the watchtower will submit at once before waiting for them to be included in a block.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(10)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerDissolveGasCeiling: config.Parameter{
+			ID:                   "watchtowerDissolveGasCeiling",
+			Name:                 "Dissolve Gas Ceiling",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The maximum total amount of gas (in gas units, not gwei) that a single batch of timed-out minipool dissolutions can use. Any remaining dissolutions will be submitted in the next batch.\n\nUse 0 for no limit.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RethPriceSources: config.Parameter{
+			ID:          "rethPriceSources",
+			Name:        "rETH Price Sources",
+			Description: "A comma-separated list of DEX pools to read the secondary-market rETH/ETH price from, which is compared against the protocol's rETH exchange rate in `rocketpool network stats` and the node's metrics.\n\nUse `uniswapv3:<pool address>` for a Uniswap v3 rETH/WETH pool, or `balancer:<pool ID>` for a Balancer pool containing rETH and WETH. Leave this blank to disable secondary-market price checks.",
+			Type:        config.ParameterType_String,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: "uniswapv3:0xa4e0faA58465A2D369aa21B3e42d43374c6F9613,balancer:0x1e19cf2d73a72ef1332c882f20534b6519be0276000200000000000000000112",
+				config.Network_Prater:  "",
+				config.Network_Devnet:  "",
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriceApiUrl: config.Parameter{
+			ID:                   "priceApiUrl",
+			Name:                 "Price API URL",
+			Description:          "The base URL of a CoinGecko-compatible price API, used to value your rewards in fiat currency in `rocketpool node rewards-report`. Leave this blank to leave fiat values out of the report.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "https://api.coingecko.com/api/v3"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		FiatCurrency: config.Parameter{
+			ID:                   "fiatCurrency",
+			Name:                 "Fiat Currency",
+			Description:          "The currency code (such as `usd` or `eur`) to value your rewards in when using the Price API.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "usd"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ApiUnauthenticatedRole: config.Parameter{
+			ID:                   "apiUnauthenticatedRole",
+			Name:                 "API Unauthenticated Role",
+			Description:          "The role granted to clients of the API server (`rocketpool api server`) that don't provide a token.\n\nNone will reject them, and Monitor will let them call the commands that only read state (such as `node status` and the `can-*` checks) and subscribe to the event stream.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.ApiRole_None},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Reject API server clients that don't provide a token.",
+				Value:       config.ApiRole_None,
+			}, {
+				Name:        "Monitor",
+				Description: "Let API server clients that don't provide a token call read-only commands.",
+				Value:       config.ApiRole_Monitor,
+			}},
+		},
+
+		ApiRoleTokens: config.Parameter{
+			ID:                   "apiRoleTokens",
+			Name:                 "API Role Tokens",
+			Description:          "(Optional) A comma-separated list of `role=token` pairs granting additional API server tokens a role, such as `monitor=0123abcd...`. The role can be `monitor` (read-only commands) or `admin` (every command).\n\nThe token in the `api-token` file in your data folder always has the admin role.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeKeySigner: config.Parameter{
+			ID:                   "nodeKeySigner",
+			Name:                 "Node Key Signer",
+			Description:          "What signs transactions for your node account.\n\nLocal uses the key derived from your node wallet's mnemonic. Ledger and Trezor use a key on a USB hardware wallet instead, so the node account's private key never touches this machine; the device must be connected (and passed through to the Smartnode's containers in Docker mode), and you'll need to confirm each transaction on it. Clef and Web3Signer send each transaction to a remote signer at the External Signer URL, so the node never holds the key at all.\n\nYour validator keys are still derived from the node wallet.\n\n[orange]NOTE: Ledgers need version 1.9 or newer of the Ethereum app, with blind signing enabled. Trezors can only sign legacy transactions with this version of the Smartnode, so their transactions pay the current base fee plus your priority fee as their gas price, and may need to be replaced if the base fee rises before they're included.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.NodeKeySigner_Local},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Local",
+				Description: "Sign with the node wallet's key.",
+				Value:       config.NodeKeySigner_Local,
+			}, {
+				Name:        "Ledger",
+				Description: "Sign with a Ledger running the Ethereum app.",
+				Value:       config.NodeKeySigner_Ledger,
+			}, {
+				Name:        "Trezor",
+				Description: "Sign with a Trezor.",
+				Value:       config.NodeKeySigner_Trezor,
+			}, {
+				Name:        "Clef",
+				Description: "Sign with Clef's external API (the account_* methods).",
+				Value:       config.NodeKeySigner_Clef,
+			}, {
+				Name:        "Web3Signer",
+				Description: "Sign with Web3Signer's Eth1 JSON-RPC API (the eth_sign* methods).",
+				Value:       config.NodeKeySigner_Web3Signer,
+			}},
+		},
+
+		HardwareWalletDerivationPath: config.Parameter{
+			ID:                   "hardwareWalletDerivationPath",
+			Name:                 "Hardware Wallet Derivation Path",
+			Description:          "The derivation path of the node account on your hardware wallet, if the Node Key Signer is Ledger or Trezor. Ledger Live's first account is `m/44'/60'/0'/0/0`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "m/44'/60'/0'/0/0"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ExternalSignerUrl: config.Parameter{
+			ID:                   "externalSignerUrl",
+			Name:                 "External Signer URL",
+			Description:          "The URL of the remote signer's JSON-RPC API, if the Node Key Signer is Clef or Web3Signer. It must be reachable from the Smartnode's containers in Docker mode.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ExternalSignerAddress: config.Parameter{
+			ID:                   "externalSignerAddress",
+			Name:                 "External Signer Address",
+			Description:          "The address of the node account on the remote signer, if the Node Key Signer is Clef or Web3Signer.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WalletPasswordProvider: config.Parameter{
+			ID:                   "walletPasswordProvider",
+			Name:                 "Wallet Password Provider",
+			Description:          "Where the password that encrypts your node wallet comes from.\n\nFile stores it in plain text next to the wallet, which is how the Smartnode has always worked. Environment reads it from the environment variable named by the Wallet Password Source, and Command runs the Wallet Password Source as a shell command and uses what it prints, such as a call to a secrets manager; neither of these can be set by `rocketpool wallet init`, so set it up before creating your wallet. AWS KMS and GCP KMS encrypt the password with the KMS key named by the Wallet Password Source and only store the ciphertext, using the `aws` or `gcloud` CLI with whatever credentials they're configured with. Prompt never stores it at all: the Smartnode starts locked, and you have to run `rocketpool wallet unlock` every time it restarts.\n\n[orange]NOTE: Prompt keeps the password in each daemon's memory, so the CLI must be connected to the API server for commands that use the wallet.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.WalletPasswordProvider_File},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "File",
+				Description: "Store the password in a file next to the wallet.",
+				Value:       config.WalletPasswordProvider_File,
+			}, {
+				Name:        "Environment",
+				Description: "Read the password from an environment variable.",
+				Value:       config.WalletPasswordProvider_Environment,
+			}, {
+				Name:        "Command",
+				Description: "Run a command that prints the password.",
+				Value:       config.WalletPasswordProvider_Command,
+			}, {
+				Name:        "AWS KMS",
+				Description: "Store the password encrypted with an AWS KMS key.",
+				Value:       config.WalletPasswordProvider_AwsKms,
+			}, {
+				Name:        "GCP KMS",
+				Description: "Store the password encrypted with a Google Cloud KMS key.",
+				Value:       config.WalletPasswordProvider_GcpKms,
+			}, {
+				Name:        "Prompt",
+				Description: "Don't store the password; unlock the wallet with it after every restart.",
+				Value:       config.WalletPasswordProvider_Prompt,
+			}},
+		},
+
+		WalletPasswordSource: config.Parameter{
+			ID:                   "walletPasswordSource",
+			Name:                 "Wallet Password Source",
+			Description:          "Where the Wallet Password Provider gets the password from: the name of the environment variable for Environment, the shell command for Command, the key ID or ARN for AWS KMS, or the full resource name of the key (`projects/.../locations/.../keyRings/.../cryptoKeys/...`) for GCP KMS. It isn't used by File or Prompt.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3SignerUrl: config.Parameter{
+			ID:                   "web3SignerUrl",
+			Name:                 "Web3Signer URL",
+			Description:          "(Optional) The URL of a Web3Signer instance to store validator keys in, instead of saving them to disk. Its keymanager API must be enabled (`--key-manager-api-enabled`), and it should use its slashing protection database.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"WEB3SIGNER_URL"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3SignerClients: config.Parameter{
+			ID:                   "web3SignerClients",
+			Name:                 "Web3Signer Clients",
+			Description:          "A comma-separated list of the validator clients (`lighthouse`, `lodestar`, `nimbus`, `prysm`, and `teku`) whose keys should be stored in Web3Signer instead of on disk, if the Web3Signer URL is set. Set this to `all` for every client.\n\nThose clients must be configured to use Web3Signer as their remote signer.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "all"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VcKeymanagerUrl: config.Parameter{
+			ID:                   "vcKeymanagerUrl",
+			Name:                 "VC Keymanager API URL",
+			Description:          "(Optional) The URL of your validator client's keymanager API. If this is set, the node daemon asks the validator client which fee recipient it's actually using for each of your minipools, instead of only checking the fee recipient file it writes.\n\nThe keymanager API must be enabled in your validator client.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VcKeymanagerTokenPath: config.Parameter{
+			ID:                   "vcKeymanagerTokenPath",
+			Name:                 "VC Keymanager API Token Path",
+			Description:          "The path of the file holding the bearer token for your validator client's keymanager API, as the node daemon sees it. It's only used if the VC Keymanager API URL is set.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WalletPolicyMaxValue: config.Parameter{
+			ID:                   "walletPolicyMaxValue",
+			Name:                 "Max ETH per Transaction",
+			Description:          "The most ETH (not including gas) a single transaction from your node wallet can send. The wallet will refuse to sign any transaction above this, including ones from the node and watchtower daemons.\n\nSet this to 0 for no limit.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WalletPolicyMaxFee: config.Parameter{
+			ID:                   "walletPolicyMaxFee",
+			Name:                 "Max Fee Limit",
+			Description:          "The highest max fee (in gwei) a transaction from your node wallet can pay. The wallet will refuse to sign any transaction with a higher max fee, including ones from the node and watchtower daemons.\n\nSet this to 0 for no limit.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WalletPolicyDailySpendCap: config.Parameter{
+			ID:                   "walletPolicyDailySpendCap",
+			Name:                 "Daily Spend Cap",
+			Description:          "The most ETH your node wallet can spend across the last 24 hours, counting each transaction's value plus the most it could pay for gas. The wallet will refuse to sign any transaction that would go over it, including ones from the node and watchtower daemons.\n\nSet this to 0 for no limit.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WalletPolicyConfirmCommands: config.Parameter{
+			ID:                   "walletPolicyConfirmCommands",
+			Name:                 "Commands Requiring Confirmation",
+			Description:          "(Optional) A comma-separated list of `rocketpool` commands that always ask for confirmation, even when `--yes` is passed, such as `node send,node withdraw-rpl`. Set this to `all` for every command.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WalletPolicyAutoTasks: config.Parameter{
+			ID:                   "walletPolicyAutoTasks",
+			Name:                 "Automatic Transaction Tasks",
+			Description:          "A comma-separated list of the node daemon's tasks that can submit transactions automatically: `stake-prelaunch-minipools`, `distribute-minipools`, `reduce-bonds`, `promote-minipools`, and `submit-voting-trees`. Set this to `all` for every task, or leave it blank to do all of them yourself with the `rocketpool` commands.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "all"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DeploymentFile: config.Parameter{
+			ID:                   "deploymentFile",
+			Name:                 "Custom Deployment File",
+			Description:          "[orange]**For developers and integration testing only.**[white]\n\nThe name of a JSON deployment file in your Smartnode data folder that describes a custom Rocket Pool deployment, such as one on a private chain. It replaces the built-in deployment for the selected network entirely, so it must provide `chainID`, `storageAddress`, `multicallAddress`, and `balanceBatcherAddress`, and can provide `genesisBlockHash` and `beaconGenesisTime` to check that your clients are on the right chain.\n\nLeave this blank to use the built-in deployment.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFormat: config.Parameter{
+			ID:                   "logFormat",
+			Name:                 "Log Format",
+			Description:          "Select how the node and watchtower daemons write their logs.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.LogFormat_Text},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Text",
+				Description: "Write colored, human-readable lines.",
+				Value:       config.LogFormat_Text,
+			}, {
+				Name:        "JSON",
+				Description: "Write one JSON object per line with the time, level, module, and message, for log collectors like Loki or Elasticsearch.",
+				Value:       config.LogFormat_Json,
+			}},
+		},
+
+		LogLevel: config.Parameter{
+			ID:                   "logLevel",
+			Name:                 "Log Level",
+			Description:          "Select the minimum level of the messages the node and watchtower daemons write. This can be changed without restarting them with `rocketpool service reload-config` or `rocketpool service log-level`.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.LogLevel_Info},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Debug",
+				Description: "Write everything, including detailed progress that's only useful for troubleshooting.",
+				Value:       config.LogLevel_Debug,
+			}, {
+				Name:        "Info",
+				Description: "Write the normal activity of each task.",
+				Value:       config.LogLevel_Info,
+			}, {
+				Name:        "Warn",
+				Description: "Only write warnings and errors.",
+				Value:       config.LogLevel_Warn,
+			}, {
+				Name:        "Error",
+				Description: "Only write errors.",
+				Value:       config.LogLevel_Error,
+			}},
+		},
+
+		ModuleLogLevels: config.Parameter{
+			ID:                   "moduleLogLevels",
+			Name:                 "Module Log Levels",
+			Description:          "Override the log level for specific modules, as a comma-separated list of module=level (e.g. `rewards=debug,node-health=warn`). The modules are listed by `rocketpool service log-level`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			Regex:                "^(\\s*[A-Za-z0-9_-]+\\s*=\\s*(?i:debug|info|warn|warning|error)\\s*(,|$))*$",
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFileMaxSize: config.Parameter{
+			ID:                   "logFileMaxSize",
+			Name:                 "Log File Max Size",
+			Description:          "Besides their regular output, the node and watchtower daemons write their logs to files in your data folder so `rocketpool service logs --task` can search them. This is the size, in MB, a log file can reach before it's rotated.\n\nUse 0 to only rotate by age.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultLogFileMaxSize},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFileMaxAge: config.Parameter{
+			ID:                   "logFileMaxAge",
+			Name:                 "Log File Max Age",
+			Description:          "The number of hours a daemon log file is written to before it's rotated.\n\nUse 0 to only rotate by size.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultLogFileMaxAge},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFileMaxCount: config.Parameter{
+			ID:                   "logFileMaxCount",
+			Name:                 "Rotated Log Files",
+			Description:          "The number of rotated log files to keep for each daemon. Older ones are deleted.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultLogFileMaxCount},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RplTwapEpoch: config.Parameter{
+			ID:          "rplTwapEpoch",
+			Name:        "RPL TWAP Epoch",
+			Description: "[orange]**For Oracle DAO members only.**\n\n[white]The epoch to switch from spot prices to TWAP for RPL price submission.",
+			Type:        config.ParameterType_Uint,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: uint64(193414),
+				config.Network_Prater:  uint64(162094),
+				config.Network_Devnet:  uint64(162094),
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		BalancesModernizationEpoch: config.Parameter{
+			ID:          "balancesModernizationEpoch",
+			Name:        "Balances Modernization Epoch",
+			Description: "[orange]**For Oracle DAO members only.**\n\n[white]The epoch to switch from the old network balance calculation method to the new one.",
+			Type:        config.ParameterType_Uint,
+			Default: map[config.Network]interface{}{
+				config.Network_Mainnet: uint64(194089),
+				config.Network_Prater:  uint64(162094),
+				config.Network_Devnet:  uint64(162094),
+			},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+	}
+
+}
+
+// Get the parameters for this config
+func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Network,
+		&cfg.ProjectName,
+		&cfg.DataPath,
+		&cfg.ManualMaxFee,
+		&cfg.PriorityFee,
+		&cfg.AutoTxGasThreshold,
+		&cfg.DistributeThreshold,
+		&cfg.RewardsTreeMode,
+		&cfg.ArchiveECUrl,
+		&cfg.RewardsBeaconNodeUrl,
+		&cfg.VerifyRewardsTrees,
+		&cfg.UseTreeWorker,
+		&cfg.TreeWorkerMemoryLimit,
+		&cfg.TreeWorkerNiceLevel,
+		&cfg.TreeGenProfiling,
+		&cfg.AttestationScanConcurrency,
+		&cfg.BeaconRequestRateLimit,
+		&cfg.BeaconRequestMaxRetries,
+		&cfg.BeaconCacheSize,
+		&cfg.ExecutionBatchSize,
+		&cfg.UsePrivateRelay,
+		&cfg.PrivateRelayUrl,
+		&cfg.PrivateRelayFallback,
+		&cfg.StateSnapshotRetention,
+		&cfg.StateFullRefreshInterval,
+		&cfg.CachedStateMaxSlots,
+		&cfg.ShutdownGracePeriod,
+		&cfg.RewardsFileFormat,
+		&cfg.RewardsRetentionIntervals,
+		&cfg.RewardsPruneMode,
+		&cfg.RewardsStorageMode,
+		&cfg.Web3StorageApiToken,
+		&cfg.IpfsApiUrl,
+		&cfg.S3Endpoint,
+		&cfg.S3Region,
+		&cfg.S3Bucket,
+		&cfg.S3AccessKeyID,
+		&cfg.S3SecretAccessKey,
+		&cfg.PinataJwt,
+		&cfg.IpfsClusterUrl,
+		&cfg.VerifyRewardsRetrievability,
+		&cfg.WatchtowerGasMode,
 		&cfg.WatchtowerMaxFeeOverride,
 		&cfg.WatchtowerPrioFeeOverride,
+		&cfg.WatchtowerDeferralFee,
+		&cfg.WatchtowerMaxDeferral,
+		&cfg.WatchtowerTaskJitter,
+		&cfg.WatchtowerTaskIntervals,
+		&cfg.WatchtowerDisabledTasks,
+		&cfg.WatchtowerBalanceTolerance,
+		&cfg.WatchtowerWithholdDeviatingBalances,
+		&cfg.WatchtowerPriceOracles,
+		&cfg.WatchtowerPriceTolerance,
+		&cfg.WatchtowerProcessPenalties,
+		&cfg.WatchtowerProposalVoteRules,
+		&cfg.WatchtowerDissolveBatchSize,
+		&cfg.WatchtowerDissolveGasCeiling,
+		&cfg.RethPriceSources,
+		&cfg.PriceApiUrl,
+		&cfg.FiatCurrency,
+		&cfg.ApiUnauthenticatedRole,
+		&cfg.ApiRoleTokens,
+		&cfg.NodeKeySigner,
+		&cfg.HardwareWalletDerivationPath,
+		&cfg.ExternalSignerUrl,
+		&cfg.ExternalSignerAddress,
+		&cfg.WalletPasswordProvider,
+		&cfg.WalletPasswordSource,
+		&cfg.Web3SignerUrl,
+		&cfg.Web3SignerClients,
+		&cfg.VcKeymanagerUrl,
+		&cfg.VcKeymanagerTokenPath,
+		&cfg.WalletPolicyMaxValue,
+		&cfg.WalletPolicyMaxFee,
+		&cfg.WalletPolicyDailySpendCap,
+		&cfg.WalletPolicyConfirmCommands,
+		&cfg.WalletPolicyAutoTasks,
+		&cfg.DeploymentFile,
+		&cfg.LogFormat,
+		&cfg.LogLevel,
+		&cfg.ModuleLogLevels,
+		&cfg.LogFileMaxSize,
+		&cfg.LogFileMaxAge,
+		&cfg.LogFileMaxCount,
 		&cfg.RplTwapEpoch,
 		&cfg.BalancesModernizationEpoch,
 	}
@@ -619,51 +1652,155 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 // Getters for the non-editable parameters
 
 func (cfg *SmartnodeConfig) GetTxWatchUrl() string {
-	return cfg.txWatchUrl[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().TxWatchUrl
 }
 
 func (cfg *SmartnodeConfig) GetStakeUrl() string {
-	return cfg.stakeUrl[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().StakeUrl
 }
 
 func (cfg *SmartnodeConfig) GetChainID() uint {
-	return cfg.chainID[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().ChainID
 }
 
 func (cfg *SmartnodeConfig) GetWalletPath() string {
+	return filepath.Join(cfg.GetWalletProfilePath(cfg.GetActiveWalletProfile()), "wallet")
+}
+
+func (cfg *SmartnodeConfig) GetHardwareWalletAccountPath() string {
 	if cfg.parent.IsNativeMode {
-		return filepath.Join(cfg.DataPath.Value.(string), "wallet")
+		return filepath.Join(cfg.DataPath.Value.(string), HardwareWalletAccountFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, HardwareWalletAccountFilename)
+}
+
+// Check if a validator client's keys should be stored in Web3Signer
+func (cfg *SmartnodeConfig) UsesWeb3Signer(client string) bool {
+	if cfg.Web3SignerUrl.Value.(string) == "" {
+		return false
 	}
+	return listContains(cfg.Web3SignerClients.Value.(string), client)
+}
+
+// Check if a command always needs interactive confirmation under the wallet policy
+func (cfg *SmartnodeConfig) RequiresConfirmation(command string) bool {
+	return listContains(cfg.WalletPolicyConfirmCommands.Value.(string), command)
+}
+
+// Check if a node daemon task can submit transactions automatically under the wallet policy
+func (cfg *SmartnodeConfig) IsAutoTxTaskApproved(task string) bool {
+	return listContains(cfg.WalletPolicyAutoTasks.Value.(string), task)
+}
 
-	return filepath.Join(DaemonDataPath, "wallet")
+// Check if a comma-separated list contains an entry, or is set to all
+func listContains(list string, entry string) bool {
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "all" || candidate == entry {
+			return true
+		}
+	}
+	return false
 }
 
 func (cfg *SmartnodeConfig) GetPasswordPath() string {
+	return filepath.Join(cfg.GetWalletProfilePath(cfg.GetActiveWalletProfile()), "password")
+}
+
+// Get the path of the socket a daemon listens on to be unlocked, when the wallet password is only kept in memory
+func (cfg *SmartnodeConfig) GetWalletUnlockSocketPath(process string) string {
+	filename := fmt.Sprintf(WalletUnlockSocketFormat, process)
 	if cfg.parent.IsNativeMode {
-		return filepath.Join(cfg.DataPath.Value.(string), "password")
+		return filepath.Join(cfg.DataPath.Value.(string), filename)
 	}
 
-	return filepath.Join(DaemonDataPath, "password")
+	return filepath.Join(DaemonDataPath, filename)
 }
 
+// Get the validator keychain of the active wallet profile. It's always the one in the data folder, since that's the folder
+// the validator clients mount; switching profiles swaps the keychains (see GetInactiveValidatorKeychainPath).
 func (cfg *SmartnodeConfig) GetValidatorKeychainPath() string {
-	if cfg.parent.IsNativeMode {
-		return filepath.Join(cfg.DataPath.Value.(string), "validators")
-	}
+	return filepath.Join(cfg.GetWalletProfilePath(DefaultWalletProfile), "validators")
+}
 
-	return filepath.Join(DaemonDataPath, "validators")
+// Get the folder a wallet profile's validator keychain is kept in while another profile is active
+func (cfg *SmartnodeConfig) GetInactiveValidatorKeychainPath(profile string) string {
+	return filepath.Join(cfg.GetWalletProfilesPath(), profile, "validators")
 }
 
 func (cfg *SmartnodeConfig) GetWalletPathInCLI() string {
-	return filepath.Join(cfg.DataPath.Value.(string), "wallet")
+	return filepath.Join(cfg.getWalletProfilePathInCLI(cfg.getActiveWalletProfileInCLI()), "wallet")
 }
 
 func (cfg *SmartnodeConfig) GetPasswordPathInCLI() string {
-	return filepath.Join(cfg.DataPath.Value.(string), "password")
+	return filepath.Join(cfg.getWalletProfilePathInCLI(cfg.getActiveWalletProfileInCLI()), "password")
 }
 
+// Get the validator keychain of the active wallet profile, which is always the one in the data folder
 func (cfg *SmartnodeConfig) GetValidatorKeychainPathInCLI() string {
-	return filepath.Join(cfg.DataPath.Value.(string), "validators")
+	return filepath.Join(cfg.getWalletProfilePathInCLI(DefaultWalletProfile), "validators")
+}
+
+// Get the folder that holds the wallet profiles other than the default one
+func (cfg *SmartnodeConfig) GetWalletProfilesPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), WalletProfilesFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, WalletProfilesFolder)
+}
+
+// Get the folder that holds a wallet profile's wallet, password, and validator keychain.
+// The default profile uses the data folder itself, so wallets made before profiles existed keep working.
+func (cfg *SmartnodeConfig) GetWalletProfilePath(profile string) string {
+	if profile == DefaultWalletProfile {
+		if cfg.parent.IsNativeMode {
+			return cfg.DataPath.Value.(string)
+		}
+		return DaemonDataPath
+	}
+
+	return filepath.Join(cfg.GetWalletProfilesPath(), profile)
+}
+
+// Get the path of the file that records the active wallet profile
+func (cfg *SmartnodeConfig) GetActiveWalletProfilePath() string {
+	return filepath.Join(cfg.GetWalletProfilesPath(), ActiveWalletProfileFilename)
+}
+
+// Get the active wallet profile, which is the default one unless another has been selected
+func (cfg *SmartnodeConfig) GetActiveWalletProfile() string {
+	return readWalletProfile(os.ExpandEnv(cfg.GetActiveWalletProfilePath()))
+}
+
+func (cfg *SmartnodeConfig) getWalletProfilePathInCLI(profile string) string {
+	if profile == DefaultWalletProfile {
+		return cfg.DataPath.Value.(string)
+	}
+	return filepath.Join(cfg.DataPath.Value.(string), WalletProfilesFolder, profile)
+}
+
+func (cfg *SmartnodeConfig) getActiveWalletProfileInCLI() string {
+	return readWalletProfile(os.ExpandEnv(filepath.Join(cfg.DataPath.Value.(string), WalletProfilesFolder, ActiveWalletProfileFilename)))
+}
+
+// Check if a wallet profile name is valid; names are used as folder names, so they're kept simple
+func IsValidWalletProfileName(profile string) bool {
+	return walletProfileNamePattern.MatchString(profile)
+}
+
+// Read the wallet profile recorded in a file, falling back to the default one if there isn't a valid one
+func readWalletProfile(path string) string {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return DefaultWalletProfile
+	}
+	profile := strings.TrimSpace(string(bytes))
+	if !IsValidWalletProfileName(profile) {
+		return DefaultWalletProfile
+	}
+	return profile
 }
 
 func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
@@ -691,23 +1828,23 @@ func (cfg *SmartnodeConfig) GetCustomKeyPasswordFilePath() string {
 }
 
 func (cfg *SmartnodeConfig) GetStorageAddress() string {
-	return cfg.storageAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().StorageAddress
 }
 
 func (cfg *SmartnodeConfig) GetOneInchOracleAddress() string {
-	return cfg.oneInchOracleAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().OneInchOracleAddress
 }
 
 func (cfg *SmartnodeConfig) GetRplTokenAddress() string {
-	return cfg.rplTokenAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().RplTokenAddress
 }
 
 func (cfg *SmartnodeConfig) GetRplFaucetAddress() string {
-	return cfg.rplFaucetAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().RplFaucetAddress
 }
 
 func (cfg *SmartnodeConfig) GetSnapshotDelegationAddress() string {
-	return cfg.snapshotDelegationAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().SnapshotDelegationAddress
 }
 
 func (cfg *SmartnodeConfig) GetSmartnodeContainerTag() string {
@@ -723,7 +1860,7 @@ func (cfg *SmartnodeConfig) GetEcMigratorContainerTag() string {
 }
 
 func (cfg *SmartnodeConfig) GetSnapshotApiDomain() string {
-	return cfg.snapshotApiDomain[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().SnapshotApiDomain
 }
 
 func (cfg *SmartnodeConfig) GetVotingSnapshotID() [32]byte {
@@ -744,7 +1881,11 @@ func (cfg *SmartnodeConfig) GetConfigTitle() string {
 }
 
 func (cfg *SmartnodeConfig) GetRethAddress() common.Address {
-	return common.HexToAddress(cfg.rethAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().RethAddress)
+}
+
+func (cfg *SmartnodeConfig) GetBalancerVaultAddress() string {
+	return cfg.getDeployment().BalancerVaultAddress
 }
 
 func getDefaultDataDir(config *RocketPoolConfig) string {
@@ -767,6 +1908,40 @@ func (cfg *SmartnodeConfig) GetMinipoolPerformancePath(interval uint64, daemon b
 	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
 }
 
+func (cfg *SmartnodeConfig) GetVotingTreePath(proposalId uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, VotingTreesFolder, fmt.Sprintf(VotingTreeFilenameFormat, string(cfg.Network.Value.(config.Network)), proposalId))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), VotingTreesFolder, fmt.Sprintf(VotingTreeFilenameFormat, string(cfg.Network.Value.(config.Network)), proposalId))
+}
+
+func (cfg *SmartnodeConfig) GetRewardsArchiveFolder(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, RewardsArchiveFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, RewardsArchiveFolder)
+}
+
+func (cfg *SmartnodeConfig) GetPrunedRewardsSummaryPath(daemon bool) string {
+	filename := fmt.Sprintf(PrunedRewardsSummaryFilenameFormat, string(cfg.Network.Value.(config.Network)))
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, filename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, filename)
+}
+
+func (cfg *SmartnodeConfig) GetTreeGenProfilePath(interval uint64, kind string, daemon bool) string {
+	filename := fmt.Sprintf(TreeGenProfileFormat, string(cfg.Network.Value.(config.Network)), interval, kind)
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, TreeGenProfilesFolder, filename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, TreeGenProfilesFolder, filename)
+}
+
 func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64, daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
@@ -783,6 +1958,157 @@ func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder)
 }
 
+func (cfg *SmartnodeConfig) GetWatchtowerDutyStatusPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), WatchtowerDutyStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetWatchtowerSubmissionsPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), WatchtowerSubmissionsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetForceBalanceSubmissionRequestPath(block uint64, daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), fmt.Sprintf(ForceBalanceSubmissionRequestFormat, block))
+}
+
+func (cfg *SmartnodeConfig) GetVerifyRewardsTreeRequestPath(interval uint64, daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), fmt.Sprintf(VerifyRewardsTreeRequestFormat, interval))
+}
+
+func (cfg *SmartnodeConfig) GetRewardsTreeVerificationPath(interval uint64, daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), fmt.Sprintf(RewardsTreeVerificationFormat, interval))
+}
+
+func (cfg *SmartnodeConfig) GetPenaltyEvidencePath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), PenaltyEvidenceFilename)
+}
+
+func (cfg *SmartnodeConfig) GetProposalVoteLogPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), ProposalVoteLogFilename)
+}
+
+func (cfg *SmartnodeConfig) GetApiTokenPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ApiTokenFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ApiTokenFilename)
+}
+
+func (cfg *SmartnodeConfig) GetSecretsKeyPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, SecretsKeyFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), SecretsKeyFilename)
+}
+
+func (cfg *SmartnodeConfig) GetApiSocketPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ApiSocketFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ApiSocketFilename)
+}
+
+func (cfg *SmartnodeConfig) GetTransactionJournalPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, TransactionJournalFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), TransactionJournalFilename)
+}
+
+func (cfg *SmartnodeConfig) GetTransactionQueuePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, TransactionQueueFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), TransactionQueueFilename)
+}
+
+func (cfg *SmartnodeConfig) GetBeaconCachePath(daemon bool) string {
+	network := string(cfg.Network.Value.(config.Network))
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, BeaconCacheFolder, network)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), BeaconCacheFolder, network)
+}
+
+func (cfg *SmartnodeConfig) GetCallTraceFlagPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, CallTraceFlagFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), CallTraceFlagFilename)
+}
+
+func (cfg *SmartnodeConfig) GetCallTraceLogPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, CallTraceLogFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), CallTraceLogFilename)
+}
+
+func (cfg *SmartnodeConfig) GetStateSnapshotPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, StateSnapshotFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), StateSnapshotFolder)
+}
+
+func (cfg *SmartnodeConfig) GetCachedNodeStatePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, CachedNodeStateFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), CachedNodeStateFilename)
+}
+
+func (cfg *SmartnodeConfig) GetPrepareShutdownPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, PrepareShutdownFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), PrepareShutdownFilename)
+}
+
+func (cfg *SmartnodeConfig) GetReloadConfigPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ReloadConfigFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ReloadConfigFilename)
+}
+
+func (cfg *SmartnodeConfig) GetLogLevelOverridesPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, LogLevelOverridesFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), LogLevelOverridesFilename)
+}
+
+func (cfg *SmartnodeConfig) GetDaemonLogPath(daemon bool, process string) string {
+	filename := fmt.Sprintf(DaemonLogFileFormat, process)
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, DaemonLogsFolder, filename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), DaemonLogsFolder, filename)
+}
+
+func (cfg *SmartnodeConfig) GetTaskLoopHeartbeatPath(daemon bool, process string) string {
+	filename := fmt.Sprintf(TaskLoopHeartbeatFormat, process)
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, filename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), filename)
+}
+
 func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	if !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, "validators", FeeRecipientFilename)
@@ -792,75 +2118,86 @@ func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 }
 
 func (cfg *SmartnodeConfig) GetV100RewardsPoolAddress() common.Address {
-	return common.HexToAddress(cfg.v1_0_0_RewardsPoolAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_0_0_RewardsPoolAddress)
 }
 
 func (cfg *SmartnodeConfig) GetV100ClaimNodeAddress() common.Address {
-	return common.HexToAddress(cfg.v1_0_0_ClaimNodeAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_0_0_ClaimNodeAddress)
 }
 
 func (cfg *SmartnodeConfig) GetV100ClaimTrustedNodeAddress() common.Address {
-	return common.HexToAddress(cfg.v1_0_0_ClaimTrustedNodeAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_0_0_ClaimTrustedNodeAddress)
 }
 
 func (cfg *SmartnodeConfig) GetV100MinipoolManagerAddress() common.Address {
-	return common.HexToAddress(cfg.v1_0_0_MinipoolManagerAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_0_0_MinipoolManagerAddress)
 }
 
 func (cfg *SmartnodeConfig) GetV110NetworkPricesAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_NetworkPricesAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_1_0_NetworkPricesAddress)
 }
 
 func (cfg *SmartnodeConfig) GetV110NodeStakingAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_NodeStakingAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_1_0_NodeStakingAddress)
 }
 
 func (cfg *SmartnodeConfig) GetV110NodeDepositAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_NodeDepositAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_1_0_NodeDepositAddress)
 }
 
 func (cfg *SmartnodeConfig) GetV110MinipoolQueueAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_MinipoolQueueAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_1_0_MinipoolQueueAddress)
 }
 
 func (cfg *SmartnodeConfig) GetV110MinipoolFactoryAddress() common.Address {
-	return common.HexToAddress(cfg.v1_1_0_MinipoolFactoryAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getDeployment().V1_1_0_MinipoolFactoryAddress)
 }
 
 func (cfg *SmartnodeConfig) GetPreviousRewardsPoolAddresses() map[string][]common.Address {
-	return cfg.previousRewardsPoolAddresses[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().PreviousRewardsPoolAddresses
 }
 
 func (cfg *SmartnodeConfig) GetOptimismMessengerAddress() string {
-	return cfg.optimismPriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().OptimismPriceMessengerAddress
 }
 
 func (cfg *SmartnodeConfig) GetPolygonMessengerAddress() string {
-	return cfg.polygonPriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().PolygonPriceMessengerAddress
 }
 
 func (cfg *SmartnodeConfig) GetArbitrumMessengerAddress() string {
-	return cfg.arbitrumPriceMessengerAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().ArbitrumPriceMessengerAddress
 }
 
 func (cfg *SmartnodeConfig) GetRplTwapPoolAddress() string {
-	return cfg.rplTwapPoolAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().RplTwapPoolAddress
 }
 
 func (cfg *SmartnodeConfig) GetMulticallAddress() string {
-	return cfg.multicallAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().MulticallAddress
 }
 
 func (cfg *SmartnodeConfig) GetBalanceBatcherAddress() string {
-	return cfg.balancebatcherAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().BalanceBatcherAddress
 }
 
 func (cfg *SmartnodeConfig) GetFlashbotsProtectUrl() string {
-	return cfg.flashbotsProtectUrl[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().FlashbotsProtectUrl
+}
+
+// Get the URL of the private relay to send transactions through, or an empty string if they should go to the public mempool
+func (cfg *SmartnodeConfig) GetPrivateRelayUrl() string {
+	if cfg.UsePrivateRelay.Value != true {
+		return ""
+	}
+	if url := cfg.PrivateRelayUrl.Value.(string); url != "" {
+		return url
+	}
+	return cfg.GetFlashbotsProtectUrl()
 }
 
 func (cfg *SmartnodeConfig) GetRewardsSubmissionBlockMaps() []uint64 {
-	return cfg.rewardsSubmissionBlockMaps[cfg.Network.Value.(config.Network)]
+	return cfg.getDeployment().RewardsSubmissionBlockMaps
 }
 
 func getNetworkOptions() []config.ParameterOption {
@@ -879,7 +2216,7 @@ func getNetworkOptions() []config.ParameterOption {
 	if strings.HasSuffix(shared.RocketPoolVersion, "-dev") {
 		options = append(options, config.ParameterOption{
 			Name:        "Devnet",
-			Description: "This is a development network used by Rocket Pool engineers to test new features and contract upgrades before they are promoted to Prater for staging. You should not use this network unless invited to do so by the developers.",
+			Description: "This is a development network used by Rocket Pool engineers to test new features and contract upgrades before they are promoted to Prater for staging. You should not use this network unless invited to do so by the developers.\nThe addresses of a custom devnet deployment can be provided in a `deployment-devnet.json` file in the data folder.",
 			Value:       config.Network_Devnet,
 		})
 	}
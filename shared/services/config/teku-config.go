@@ -11,6 +11,38 @@ const (
 	defaultTekuMaxPeers uint16 = 100
 )
 
+// The flags the Smartnode sets for the Teku BN from its settings, which can't be overridden with additional flags
+var tekuBnManagedFlags = []string{
+	"--network",
+	"--data-path",
+	"--p2p-port",
+	"--ee-endpoint",
+	"--ee-jwt-secret-file",
+	"--rest-api-enabled",
+	"--rest-api-interface",
+	"--rest-api-port",
+	"--p2p-peer-lower-bound",
+	"--p2p-peer-upper-bound",
+	"--initial-state",
+	"--metrics-enabled",
+	"--metrics-interface",
+	"--metrics-port",
+}
+
+// The flags the Smartnode sets for the Teku VC from its settings, which can't be overridden with additional flags
+var tekuVcManagedFlags = []string{
+	"--network",
+	"--data-path",
+	"--beacon-node-api-endpoint",
+	"--validator-keys",
+	"--validators-graffiti",
+	"--validators-proposer-default-fee-recipient",
+	"--metrics-enabled",
+	"--metrics-interface",
+	"--metrics-port",
+	"--doppelganger-detection-enabled",
+}
+
 // Configuration for Teku
 type TekuConfig struct {
 	Title string `yaml:"-"`
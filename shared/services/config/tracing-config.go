@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	defaultTracingServiceName string = "rocketpool"
+)
+
+// Configuration for exporting traces of the daemons' task loops to an OpenTelemetry collector
+type TracingConfig struct {
+	Title string `yaml:"-"`
+
+	// The OTLP/HTTP endpoint to send traces to; blank disables tracing
+	Endpoint config.Parameter `yaml:"endpoint,omitempty"`
+
+	// Extra HTTP headers to send with each export, such as for authentication
+	Headers config.Parameter `yaml:"headers,omitempty"`
+
+	// The service name the traces are reported under
+	ServiceName config.Parameter `yaml:"serviceName,omitempty"`
+}
+
+// Generates a new tracing config
+func NewTracingConfig(cfg *RocketPoolConfig) *TracingConfig {
+	return &TracingConfig{
+		Title: "Tracing Settings",
+
+		Endpoint: config.Parameter{
+			ID:                   "endpoint",
+			Name:                 "Trace Export URL",
+			Description:          "The base URL of an OpenTelemetry collector's OTLP/HTTP receiver (e.g. http://localhost:4318). If set, the node and watchtower daemons send a trace of each pass of their task loop there, with a span for every task, so you can see which task is slowing the loop down or failing.\n\nLeave this blank to disable tracing.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			Regex:                "^$|^https?://.+$",
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Headers: config.Parameter{
+			ID:                   "headers",
+			Name:                 "Trace Export Headers",
+			Description:          "Extra HTTP headers to send with the traces, as a comma-separated list of name=value (e.g. `Authorization=Bearer abc123`). Use this if your collector or hosted tracing service requires authentication.",
+			Type:                 config.ParameterType_String,
+			IsSecret:             true,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ServiceName: config.Parameter{
+			ID:                   "serviceName",
+			Name:                 "Trace Service Name",
+			Description:          "The service name the traces are reported under. Each daemon adds its own suffix, e.g. `rocketpool-node`. If you send traces from more than one node to the same collector, give each one a different name.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultTracingServiceName},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *TracingConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Endpoint,
+		&cfg.Headers,
+		&cfg.ServiceName,
+	}
+}
+
+// Parse the extra headers to send with each export
+func (cfg *TracingConfig) GetHeaders() (map[string]string, error) {
+	headers := map[string]string{}
+	for _, entry := range strings.Split(cfg.Headers.Value.(string), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("header [%s] isn't in the form name=value", entry)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// The the title for the config
+func (cfg *TracingConfig) GetConfigTitle() string {
+	return cfg.Title
+}
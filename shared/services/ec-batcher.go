@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Settings
+const (
+	// How long to wait for more calls to join a batch before sending it
+	ecBatchWindow time.Duration = 5 * time.Millisecond
+)
+
+// Combines concurrent read-only Execution client calls into JSON-RPC batch requests, so groups of calls like the ones
+// made when loading minipool details only take a single round trip.
+type ecBatcher struct {
+	manager *ExecutionClientManager
+	maxSize int
+
+	// Internal fields
+	pending []*ecBatchCall
+	timer   *time.Timer
+	lock    *sync.Mutex
+}
+
+// A call waiting to be sent in a batch
+type ecBatchCall struct {
+	elem rpc.BatchElem
+	done chan error
+}
+
+// Create a new batcher for the given manager
+func newEcBatcher(manager *ExecutionClientManager, maxSize int) *ecBatcher {
+	return &ecBatcher{
+		manager: manager,
+		maxSize: maxSize,
+		lock:    &sync.Mutex{},
+	}
+}
+
+// Run eth_call as part of a batch
+func (b *ecBatcher) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result hexutil.Bytes
+	if err := b.call(ctx, &result, "eth_call", toCallArg(call), toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Run eth_getBalance as part of a batch
+func (b *ecBatcher) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result hexutil.Big
+	if err := b.call(ctx, &result, "eth_getBalance", account, toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// Queue a call for the next batch and wait for its result
+func (b *ecBatcher) call(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	call := &ecBatchCall{
+		elem: rpc.BatchElem{
+			Method: method,
+			Args:   args,
+			Result: result,
+		},
+		done: make(chan error, 1),
+	}
+
+	// Send the batch right away if it's full, otherwise give other calls a chance to join it
+	b.lock.Lock()
+	b.pending = append(b.pending, call)
+	if len(b.pending) >= b.maxSize {
+		b.flush()
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(ecBatchWindow, func() {
+			b.lock.Lock()
+			defer b.lock.Unlock()
+			b.flush()
+		})
+	}
+	b.lock.Unlock()
+
+	select {
+	case err := <-call.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Send the pending calls in a batch; the lock must be held
+func (b *ecBatcher) flush() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.pending) == 0 {
+		return
+	}
+	calls := b.pending
+	b.pending = nil
+	go b.send(calls)
+}
+
+// Send a batch of calls and report each one's result
+func (b *ecBatcher) send(calls []*ecBatchCall) {
+
+	// A lone call doesn't need a batch
+	if len(calls) == 1 {
+		calls[0].done <- b.sendSingle(calls[0])
+		return
+	}
+
+	elems := make([]rpc.BatchElem, len(calls))
+	for i, call := range calls {
+		elems[i] = call.elem
+	}
+	_, err := b.manager.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return nil, b.manager.getRpcClient(client).BatchCallContext(context.Background(), elems)
+	})
+
+	// If the batch itself was rejected, the client may not support batches so send the calls individually instead
+	if err != nil && !b.manager.isDisconnected(err) {
+		for _, call := range calls {
+			call.done <- b.sendSingle(call)
+		}
+		return
+	}
+
+	for i, call := range calls {
+		if err != nil {
+			call.done <- err
+		} else {
+			call.done <- elems[i].Error
+		}
+	}
+
+}
+
+// Send a single call outside of a batch
+func (b *ecBatcher) sendSingle(call *ecBatchCall) error {
+	_, err := b.manager.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return nil, b.manager.getRpcClient(client).CallContext(context.Background(), call.elem.Result, call.elem.Method, call.elem.Args...)
+	})
+	return err
+}
+
+// Convert a call message to the argument format used by eth_call
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
+
+// Convert a block number to the argument format used by the JSON-RPC API
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	pending := big.NewInt(-1)
+	if number.Cmp(pending) == 0 {
+		return "pending"
+	}
+	return hexutil.EncodeBig(number)
+}
@@ -2,33 +2,62 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/calltrace"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// The delay before rechecking a failed primary client if the configured reconnect delay can't be parsed
+const defaultReconnectDelay time.Duration = 60 * time.Second
+
 // This is a proxy for multiple ETH clients, providing natural fallback support if one of them fails.
 type ExecutionClientManager struct {
 	primaryEcUrl    string
 	fallbackEcUrl   string
 	primaryEc       *ethclient.Client
 	fallbackEc      *ethclient.Client
+	primaryRpc      *rpc.Client
+	fallbackRpc     *rpc.Client
+	batcher         *ecBatcher
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+	fallbackWrites  bool
+	reconnectDelay  time.Duration
+	remoteProvider  bool
+	tracer          *calltrace.Tracer
+	relay           *privateRelay
+
+	// Internal fields
+	failoverStats    ClientFailoverStats
+	lastPrimaryCheck time.Time
+	lock             *sync.Mutex
+}
+
+// A record of the manager's failovers from the primary client to the fallback
+type ClientFailoverStats struct {
+	UsingFallback      bool
+	FailoverCount      uint64
+	LastFailoverTime   time.Time
+	LastFailoverReason string
 }
 
 // This is a signature for a wrapped ethclient.Client function
@@ -64,28 +93,68 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	primaryRpc, err := rpc.Dial(primaryEcUrl)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
+	primaryEc := ethclient.NewClient(primaryRpc)
+
+	// Add the custom headers for the primary if it's a remote provider
+	primaryHeaders, err := cfg.GetExecutionClientHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the HTTP headers for the primary EC: %w", err)
+	}
+	for name, value := range primaryHeaders {
+		primaryRpc.SetHeader(name, value)
+	}
 
+	var fallbackRpc *rpc.Client
 	var fallbackEc *ethclient.Client
 	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+		fallbackRpc, err = rpc.Dial(fallbackEcUrl)
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
+		fallbackEc = ethclient.NewClient(fallbackRpc)
 	}
 
-	return &ExecutionClientManager{
-		primaryEcUrl:  primaryEcUrl,
-		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
-		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
-		primaryReady:  true,
-		fallbackReady: fallbackEc != nil,
-	}, nil
+	// Get the delay before rechecking a failed primary client
+	reconnectDelay, err := time.ParseDuration(cfg.ReconnectDelay.Value.(string))
+	if err != nil {
+		reconnectDelay = defaultReconnectDelay
+	}
+
+	manager := &ExecutionClientManager{
+		primaryEcUrl:   primaryEcUrl,
+		fallbackEcUrl:  fallbackEcUrl,
+		primaryEc:      primaryEc,
+		fallbackEc:     fallbackEc,
+		primaryRpc:     primaryRpc,
+		fallbackRpc:    fallbackRpc,
+		logger:         log.NewModuleLogger(log.ModuleEcManager, log.LevelInfo, color.FgYellow),
+		primaryReady:   true,
+		fallbackReady:  fallbackEc != nil,
+		fallbackWrites: cfg.FallbackTransactions.Value == true,
+		reconnectDelay: reconnectDelay,
+		remoteProvider: cfg.IsRemoteExecutionProvider(),
+		tracer:         calltrace.NewTracer(cfg.Smartnode.GetCallTraceFlagPath(true), cfg.Smartnode.GetCallTraceLogPath(true)),
+		lock:           &sync.Mutex{},
+	}
+
+	// Send transactions through a private relay if enabled
+	if relayUrl := cfg.Smartnode.GetPrivateRelayUrl(); relayUrl != "" {
+		manager.relay, err = newPrivateRelay(relayUrl, cfg.Smartnode.PrivateRelayFallback.Value == true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Batch concurrent calls together if enabled
+	if batchSize := cfg.Smartnode.ExecutionBatchSize.Value.(uint64); batchSize > 1 {
+		manager.batcher = newEcBatcher(manager, int(batchSize))
+	}
+
+	return manager, nil
 
 }
 
@@ -108,6 +177,49 @@ func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Add
 // CallContract executes an Ethereum contract call with the specified data as the
 // input.
 func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if p.tracer.IsEnabled() {
+		return p.traceCall(ctx, call, blockNumber)
+	}
+	return p.callContract(ctx, call, blockNumber)
+}
+
+// Run a contract call and record it in the call trace
+func (p *ExecutionClientManager) traceCall(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	start := time.Now()
+	result, err := p.callContract(ctx, call, blockNumber)
+
+	record := calltrace.Record{
+		Time:       start,
+		Args:       hexutil.Encode(call.Data),
+		Block:      "latest",
+		DurationMs: time.Since(start).Milliseconds(),
+		Result:     hexutil.Encode(result),
+	}
+	if call.To != nil {
+		record.Contract = call.To.Hex()
+	}
+	if len(call.Data) >= 4 {
+		record.Method = hexutil.Encode(call.Data[:4])
+		record.Args = hexutil.Encode(call.Data[4:])
+	}
+	if blockNumber != nil {
+		record.Block = blockNumber.String()
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if traceErr := p.tracer.Record(record); traceErr != nil {
+		p.logger.Printlnf("WARNING: %s", traceErr.Error())
+	}
+
+	return result, err
+}
+
+// Run a contract call, in a batch if batching is enabled
+func (p *ExecutionClientManager) callContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if p.batcher != nil {
+		return p.batcher.CallContract(ctx, call, blockNumber)
+	}
 	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return client.CallContract(ctx, call, blockNumber)
 	})
@@ -163,6 +275,9 @@ func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account com
 	if err != nil {
 		return 0, err
 	}
+	if p.relay != nil {
+		return p.relay.getPendingNonce(ctx, account, result.(uint64)), nil
+	}
 	return result.(uint64), err
 }
 
@@ -190,6 +305,30 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 	return result.(*big.Int), err
 }
 
+// FeeHistory retrieves the fee market history for the blockCount blocks ending
+// at lastBlock, with the priority fees at the requested percentiles of each block.
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.FeeHistory), err
+}
+
+// BlockByNumber returns a block from the current canonical chain, including its transactions.
+// If number is nil, the latest known block is returned.
+func (p *ExecutionClientManager) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Block), err
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific
 // transaction based on the current pending state of the backend blockchain.
 // There is no guarantee that this is the true gas limit requirement as other
@@ -200,14 +339,26 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 		return client.EstimateGas(ctx, call)
 	})
 	if err != nil {
-		return 0, err
+		return 0, p.getRevertError(ctx, call, err)
 	}
 	return result.(uint64), err
 }
 
 // SendTransaction injects the transaction into the pending pool for execution.
+// If a private relay is enabled, the transaction is sent through it instead, and only reaches the public pool if the relay fails and fallback is allowed.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	if p.relay != nil {
+		err := p.relay.sendTransaction(ctx, tx)
+		if err == nil {
+			return nil
+		}
+		if !p.relay.fallback {
+			return fmt.Errorf("%w; sending transactions to the public mempool when the private relay fails is disabled", err)
+		}
+		p.logger.Printlnf("WARNING: %s, sending it to the public mempool instead", err.Error())
+	}
+
+	_, err := p.runWriteFunction(func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
 	return err
@@ -277,6 +428,9 @@ func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error
 // BalanceAt returns the wei balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if p.batcher != nil {
+		return p.batcher.BalanceAt(ctx, account, blockNumber)
+	}
 	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return client.BalanceAt(ctx, account, blockNumber)
 	})
@@ -293,6 +447,9 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 		result := []interface{}{tx, isPending}
 		return result, err
 	})
+	if errors.Is(err, ethereum.NotFound) && p.relay != nil {
+		return p.relay.getTransaction(ctx, hash)
+	}
 	if err != nil {
 		return nil, false, err
 	}
@@ -350,14 +507,23 @@ func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.
 	}
 
 	// Get the primary EC status
-	status.PrimaryClientStatus = checkEcStatus(p.primaryEc)
+	status.PrimaryClientStatus = checkEcStatus(p.primaryEc, p.remoteProvider)
+
+	// Make sure a remote provider is serving the expected network, since it isn't managed by the Smartnode
+	if p.remoteProvider && status.PrimaryClientStatus.IsWorking {
+		expectedChainID := cfg.Smartnode.GetChainID()
+		if status.PrimaryClientStatus.NetworkId != expectedChainID {
+			status.PrimaryClientStatus.IsSynced = false
+			status.PrimaryClientStatus.Error = fmt.Sprintf("The remote provider is using a different chain [%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", getNetworkNameFromId(status.PrimaryClientStatus.NetworkId), status.PrimaryClientStatus.NetworkId, getNetworkNameFromId(expectedChainID), expectedChainID)
+		}
+	}
 
 	// Flag if primary client is ready
-	p.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
+	p.setPrimaryReady(status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced, status.PrimaryClientStatus.Error)
 
 	// Get the fallback EC status if applicable
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkEcStatus(p.fallbackEc)
+		status.FallbackClientStatus = checkEcStatus(p.fallbackEc, false)
 		// Check if fallback is using the expected network
 		expectedChainID := cfg.Smartnode.GetChainID()
 		if status.FallbackClientStatus.NetworkId != expectedChainID {
@@ -387,7 +553,7 @@ func getNetworkNameFromId(networkId uint) string {
 }
 
 // Check the client status
-func checkEcStatus(client *ethclient.Client) api.ClientStatus {
+func checkEcStatus(client *ethclient.Client, remoteProvider bool) api.ClientStatus {
 
 	status := api.ClientStatus{}
 
@@ -404,13 +570,17 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 		status.NetworkId = uint(networkId.Uint64())
 	}
 
-	// Get the fallback's sync progress
-	progress, err := client.SyncProgress(context.Background())
-	if err != nil {
-		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
-		status.IsSynced = false
-		status.IsWorking = false
-		return status
+	// Get the fallback's sync progress; remote providers load-balance across many nodes, so their sync progress isn't
+	// meaningful and only the age of their latest block is checked
+	var progress *ethereum.SyncProgress
+	if !remoteProvider {
+		progress, err = client.SyncProgress(context.Background())
+		if err != nil {
+			status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
+			status.IsSynced = false
+			status.IsWorking = false
+			return status
+		}
 	}
 
 	// Make sure it's up to date
@@ -455,18 +625,83 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 
 }
 
+// Get a snapshot of the manager's failovers from the primary client to the fallback
+func (p *ExecutionClientManager) GetFailoverStats() ClientFailoverStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	stats := p.failoverStats
+	stats.UsingFallback = !p.primaryReady && p.fallbackEc != nil
+	return stats
+}
+
+// Flag whether the primary client is ready, recording a failover if it went down while a fallback is configured
+func (p *ExecutionClientManager) setPrimaryReady(ready bool, reason string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	wasReady := p.primaryReady
+	p.primaryReady = ready
+	p.lastPrimaryCheck = time.Now()
+	if p.fallbackEc == nil || wasReady == ready {
+		return
+	}
+
+	if ready {
+		p.logger.Printlnf("Primary Execution client is ready again, switching back to it.")
+		return
+	}
+	if reason == "" {
+		reason = "not synced"
+	}
+	p.failoverStats.FailoverCount++
+	p.failoverStats.LastFailoverTime = p.lastPrimaryCheck
+	p.failoverStats.LastFailoverReason = reason
+	p.logger.Printlnf("WARNING: Primary Execution client failed (%s), using fallback...", reason)
+}
+
+// Recheck a failed primary client once the reconnect delay has passed, so the manager switches back to it when it recovers
+func (p *ExecutionClientManager) checkPrimaryRecovered() {
+	if p.primaryReady || p.ignoreSyncCheck || p.fallbackEc == nil {
+		return
+	}
+	p.lock.Lock()
+	due := time.Since(p.lastPrimaryCheck) >= p.reconnectDelay
+	if due {
+		p.lastPrimaryCheck = time.Now()
+	}
+	p.lock.Unlock()
+	if !due {
+		return
+	}
+
+	status := checkEcStatus(p.primaryEc, p.remoteProvider)
+	if status.IsWorking && status.IsSynced {
+		p.setPrimaryReady(true, "")
+	}
+}
+
+// Get the raw RPC connection for one of the manager's clients
+func (p *ExecutionClientManager) getRpcClient(client *ethclient.Client) *rpc.Client {
+	if client == p.fallbackEc {
+		return p.fallbackRpc
+	}
+	return p.primaryRpc
+}
+
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
 
+	// Switch back to the primary if it has recovered
+	p.checkPrimaryRecovered()
+
 	// Check if we can use the primary
 	if p.primaryReady {
 		// Try to run the function on the primary
 		result, err := function(p.primaryEc)
 		if err != nil {
 			if p.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
-				p.primaryReady = false
+				// If it's disconnected, flag it and try the fallback
+				p.setPrimaryReady(false, fmt.Sprintf("disconnected: %s", err.Error()))
 				return p.runFunction(function)
 			}
 
@@ -500,6 +735,33 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 	return nil, fmt.Errorf("no Execution clients were ready")
 }
 
+// Runs a function that sends data to the network, only trying the fallback if the config allows it.
+func (p *ExecutionClientManager) runWriteFunction(function ecFunction) (interface{}, error) {
+
+	// Use the normal progression if writes can fail over
+	if p.fallbackWrites || p.fallbackEc == nil {
+		return p.runFunction(function)
+	}
+
+	// Switch back to the primary if it has recovered
+	p.checkPrimaryRecovered()
+	if !p.primaryReady {
+		return nil, fmt.Errorf("the primary Execution client is not ready, and sending transactions through the fallback client is disabled")
+	}
+
+	// Try to run the function on the primary
+	result, err := function(p.primaryEc)
+	if err != nil {
+		if p.isDisconnected(err) {
+			p.setPrimaryReady(false, fmt.Sprintf("disconnected: %s", err.Error()))
+			return nil, fmt.Errorf("the primary Execution client disconnected (%w), and sending transactions through the fallback client is disabled", err)
+		}
+		return nil, err
+	}
+	return result, nil
+
+}
+
 // Returns true if the error was a connection failure and a backup client is available
 func (p *ExecutionClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
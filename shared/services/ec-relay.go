@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Sends transactions through a private relay such as Flashbots Protect instead of the public mempool.
+// Relayed transactions aren't in the Execution client's mempool until they're included in a block, so the relay
+// also covers them in pending nonce and transaction lookups.
+type privateRelay struct {
+	url      string
+	client   *ethclient.Client
+	fallback bool
+
+	// Internal fields
+	nextNonces map[common.Address]uint64
+	lock       *sync.Mutex
+}
+
+// Create a new relay client for the given URL
+func newPrivateRelay(url string, fallback bool) (*privateRelay, error) {
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to private relay at [%s]: %w", url, err)
+	}
+	return &privateRelay{
+		url:        url,
+		client:     client,
+		fallback:   fallback,
+		nextNonces: map[common.Address]uint64{},
+		lock:       &sync.Mutex{},
+	}, nil
+}
+
+// Send a transaction through the relay, and remember its nonce so the next transaction from the same account doesn't reuse it
+func (r *privateRelay) sendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := r.client.SendTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("error sending transaction %s through private relay at [%s]: %w", tx.Hash().Hex(), r.url, err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if tx.Nonce()+1 > r.nextNonces[sender] {
+		r.nextNonces[sender] = tx.Nonce() + 1
+	}
+	return nil
+}
+
+// Get the pending nonce of an account, accounting for transactions sent through the relay that the Execution client hasn't seen yet
+func (r *privateRelay) getPendingNonce(ctx context.Context, account common.Address, clientNonce uint64) uint64 {
+	nonce := clientNonce
+	if relayNonce, err := r.client.PendingNonceAt(ctx, account); err == nil && relayNonce > nonce {
+		nonce = relayNonce
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.nextNonces[account] > nonce {
+		nonce = r.nextNonces[account]
+	}
+	return nonce
+}
+
+// Get a transaction that the Execution client couldn't find, in case it's still pending in the relay
+func (r *privateRelay) getTransaction(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	tx, isPending, err := r.client.TransactionByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, false, ethereum.NotFound
+		}
+		return nil, false, fmt.Errorf("error getting transaction %s from private relay at [%s]: %w", hash.Hex(), r.url, err)
+	}
+	return tx, isPending, nil
+}
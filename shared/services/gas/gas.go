@@ -9,6 +9,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
@@ -50,6 +51,13 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		}
 	}
 
+	// Wait for the base fee to drop below the requested threshold if provided
+	whenGasBelow, deadline := rp.GetTxQueueSettings()
+	if whenGasBelow > 0 {
+		maxFeeGwei = whenGasBelow + maxPriorityFeeGwei
+		fmt.Printf("%sThis transaction will be queued for the node daemon, which will submit it once the base fee drops below %.2f gwei.\nIt will be dropped if that doesn't happen within %s.%s\n", colorYellow, whenGasBelow, deadline, colorReset)
+	}
+
 	// Use the requested max fee and priority fee if provided
 	if maxFeeGwei != 0 {
 		fmt.Printf("%sUsing the requested max fee of %.2f gwei (including a max priority fee of %.2f gwei).\n", colorYellow, maxFeeGwei, maxPriorityFeeGwei)
@@ -66,14 +74,23 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		fmt.Printf("Total cost: %.4f to %.4f ETH%s\n", lowLimit, highLimit, colorReset)
 
 	} else {
-		if headless {
+		// Try to get the suggested fees from the gas oracle
+		oracleResponse, oracleErr := rp.GetGasOracle()
+		if oracleErr == nil && headless {
+			maxFeeGwei = eth.WeiToGwei(oracleResponse.Estimate.Fast.MaxBaseFee) + maxPriorityFeeGwei
+		} else if oracleErr == nil {
+			// Print the gas oracle data and ask for an amount
+			maxFeeGwei = handleOracleGasPrices(oracleResponse.Estimate, gasInfo, maxPriorityFeeGwei, gasLimit)
+		} else if headless {
+			fmt.Printf("%sWarning: couldn't get gas estimates from the gas oracle - %s\nFalling back to Etherchain%s\n", colorYellow, oracleErr.Error(), colorReset)
 			maxFeeWei, err := GetHeadlessMaxFeeWei()
 			if err != nil {
 				return err
 			}
 			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
 		} else {
-			// Try to get the latest gas prices from Etherchain
+			// Fall back to the latest gas prices from Etherchain
+			fmt.Printf("%sWarning: couldn't get gas estimates from the gas oracle - %s\nFalling back to Etherchain%s\n", colorYellow, oracleErr.Error(), colorReset)
 			etherchainData, err := etherchain.GetGasPrices()
 			if err == nil {
 				// Print the Etherchain data and ask for an amount
@@ -119,6 +136,9 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 	}
 
 	rp.AssignGasSettings(maxFeeGwei, maxPriorityFeeGwei, gasLimit)
+	if whenGasBelow > 0 {
+		rp.QueueTransactions()
+	}
 	return nil
 
 }
@@ -139,6 +159,68 @@ func GetHeadlessMaxFeeWei() (*big.Int, error) {
 	return nil, fmt.Errorf("Error getting gas price suggestions: %w", err)
 }
 
+func handleOracleGasPrices(estimate *oracle.FeeEstimate, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
+
+	tiers := []struct {
+		name       string
+		suggestion oracle.FeeSuggestion
+	}{
+		{name: "Fast", suggestion: estimate.Fast},
+		{name: "Standard", suggestion: estimate.Standard},
+		{name: "Slow", suggestion: estimate.Slow},
+	}
+
+	fmt.Printf("%s+============ Suggested Gas Prices ============+\n", colorBlue)
+	fmt.Println("|   Speed   |  Max Fee  |    Total Gas Cost    |")
+	var fastGwei float64
+	for _, tier := range tiers {
+		tierGwei := math.RoundUp(eth.WeiToGwei(tier.suggestion.MaxBaseFee)+priorityFee, 0)
+		tierEth := tierGwei / eth.WeiPerGwei
+		if tier.name == "Fast" {
+			fastGwei = tierGwei
+		}
+
+		var lowLimit float64
+		var highLimit float64
+		if gasLimit == 0 {
+			lowLimit = tierEth * float64(gasInfo.EstGasLimit)
+			highLimit = tierEth * float64(gasInfo.SafeGasLimit)
+		} else {
+			lowLimit = tierEth * float64(gasLimit)
+			highLimit = lowLimit
+		}
+		fmt.Printf("| %-9s | %-9s | %.4f to %.4f ETH |\n",
+			tier.name, fmt.Sprintf("%d gwei", int(tierGwei)), lowLimit, highLimit)
+	}
+	fmt.Printf("+==============================================+\n\n%s", colorReset)
+
+	fmt.Printf("These prices are based on a base fee of %.2f gwei for the next block, and include a maximum priority fee of %.2f gwei.\n", eth.WeiToGwei(estimate.BaseFee), priorityFee)
+
+	for {
+		desiredPrice := cliutils.Prompt(
+			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
+			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
+			"Not a valid gas price, try again:")
+
+		if desiredPrice == "" {
+			return fastGwei
+		}
+
+		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
+		if err != nil {
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
+			continue
+		}
+		if desiredPriceFloat <= 0 {
+			fmt.Println("Max fee must be greater than zero.")
+			continue
+		}
+
+		return desiredPriceFloat
+	}
+
+}
+
 func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
 
 	rapidGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.RapidWei)+priorityFee, 0)
@@ -0,0 +1,194 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// Settings
+const (
+	// The number of recent blocks to build the fee estimate from
+	feeHistoryBlocks uint64 = 20
+
+	// The base fee can rise by 12.5% per full block; each tier's max fee covers this many full blocks in a row
+	slowBaseFeeBlocks     int = 1
+	standardBaseFeeBlocks int = 3
+	fastBaseFeeBlocks     int = 6
+)
+
+// The percentiles of each block's priority fees used for the slow, standard, and fast tiers
+var rewardPercentiles = []float64{10, 50, 90}
+
+// The lowest priority fee any tier will suggest (0.1 gwei)
+var minPriorityFee = big.NewInt(1e8)
+
+// A fee tier, from slowest (and cheapest) to fastest
+type FeeTier string
+
+const (
+	FeeTier_Slow     FeeTier = "slow"
+	FeeTier_Standard FeeTier = "standard"
+	FeeTier_Fast     FeeTier = "fast"
+)
+
+// The EIP-1559 fees suggested for a tier
+type FeeSuggestion struct {
+	MaxBaseFee     *big.Int `json:"maxBaseFee"`
+	MaxPriorityFee *big.Int `json:"maxPriorityFee"`
+	MaxFee         *big.Int `json:"maxFee"`
+}
+
+// The fee estimate for the block after the latest one, along with the recent history it was built from
+type FeeEstimate struct {
+	Block          uint64        `json:"block"`
+	BaseFee        *big.Int      `json:"baseFee"`
+	BaseFeeHistory []*big.Int    `json:"baseFeeHistory"`
+	GasUsedRatios  []float64     `json:"gasUsedRatios"`
+	Slow           FeeSuggestion `json:"slow"`
+	Standard       FeeSuggestion `json:"standard"`
+	Fast           FeeSuggestion `json:"fast"`
+}
+
+// An Execution client that can provide the fee market history
+type FeeHistoryProvider interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// Suggests EIP-1559 fees from the recent fee market history of the Execution client
+type FeeOracle struct {
+	client FeeHistoryProvider
+	latest *FeeEstimate
+	lock   sync.Mutex
+}
+
+// Create a new fee oracle
+func NewFeeOracle(client FeeHistoryProvider) *FeeOracle {
+	return &FeeOracle{
+		client: client,
+	}
+}
+
+// Get the fee estimate for the next block.
+// The estimate is only rebuilt once a new block has arrived.
+func (o *FeeOracle) GetFeeEstimate() (*FeeEstimate, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	blockNumber, err := o.client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting the latest block number: %w", err)
+	}
+	if o.latest != nil && o.latest.Block == blockNumber {
+		return o.latest, nil
+	}
+
+	history, err := o.client.FeeHistory(context.Background(), feeHistoryBlocks, new(big.Int).SetUint64(blockNumber), rewardPercentiles)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the fee history for the last %d blocks: %w", feeHistoryBlocks, err)
+	}
+	estimate, err := newFeeEstimate(blockNumber, history)
+	if err != nil {
+		return nil, err
+	}
+	o.latest = estimate
+	return estimate, nil
+}
+
+// Get the max fee for a tier.
+// If a priority fee is provided, it replaces the one suggested for the tier.
+func (o *FeeOracle) GetMaxFeeWei(tier FeeTier, priorityFee *big.Int) (*big.Int, error) {
+	estimate, err := o.GetFeeEstimate()
+	if err != nil {
+		return nil, err
+	}
+	suggestion, err := estimate.GetSuggestion(tier)
+	if err != nil {
+		return nil, err
+	}
+	if priorityFee == nil {
+		return suggestion.MaxFee, nil
+	}
+	return new(big.Int).Add(suggestion.MaxBaseFee, priorityFee), nil
+}
+
+// Get the fees suggested for a tier
+func (e *FeeEstimate) GetSuggestion(tier FeeTier) (FeeSuggestion, error) {
+	switch tier {
+	case FeeTier_Slow:
+		return e.Slow, nil
+	case FeeTier_Standard:
+		return e.Standard, nil
+	case FeeTier_Fast:
+		return e.Fast, nil
+	default:
+		return FeeSuggestion{}, fmt.Errorf("unknown fee tier '%s'", tier)
+	}
+}
+
+// Build a fee estimate from the fee history ending at the provided block
+func newFeeEstimate(blockNumber uint64, history *ethereum.FeeHistory) (*FeeEstimate, error) {
+
+	// The history includes the base fee of the next block after the blocks it covers
+	if len(history.BaseFee) == 0 {
+		return nil, fmt.Errorf("the Execution client didn't return any base fees; the chain may not support EIP-1559")
+	}
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	estimate := &FeeEstimate{
+		Block:          blockNumber,
+		BaseFee:        nextBaseFee,
+		BaseFeeHistory: history.BaseFee[:len(history.BaseFee)-1],
+		GasUsedRatios:  history.GasUsedRatio,
+	}
+	estimate.Slow = getSuggestion(nextBaseFee, slowBaseFeeBlocks, getPriorityFee(history, 0))
+	estimate.Standard = getSuggestion(nextBaseFee, standardBaseFeeBlocks, getPriorityFee(history, 1))
+	estimate.Fast = getSuggestion(nextBaseFee, fastBaseFeeBlocks, getPriorityFee(history, 2))
+	return estimate, nil
+
+}
+
+// Get the median priority fee at the provided percentile across the non-empty blocks in the history
+func getPriorityFee(history *ethereum.FeeHistory, percentileIndex int) *big.Int {
+	rewards := []*big.Int{}
+	for i, blockRewards := range history.Reward {
+		// Empty blocks report a priority fee of zero, which isn't a useful suggestion
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		if percentileIndex < len(blockRewards) && blockRewards[percentileIndex] != nil {
+			rewards = append(rewards, blockRewards[percentileIndex])
+		}
+	}
+	if len(rewards) == 0 {
+		return new(big.Int).Set(minPriorityFee)
+	}
+
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Cmp(rewards[j]) < 0
+	})
+	median := rewards[len(rewards)/2]
+	if median.Cmp(minPriorityFee) < 0 {
+		return new(big.Int).Set(minPriorityFee)
+	}
+	return new(big.Int).Set(median)
+}
+
+// Get the fees for a tier whose max base fee covers the provided number of full blocks after the next one
+func getSuggestion(baseFee *big.Int, fullBlocks int, priorityFee *big.Int) FeeSuggestion {
+	maxBaseFee := new(big.Int).Set(baseFee)
+	for i := 0; i < fullBlocks; i++ {
+		maxBaseFee.Mul(maxBaseFee, big.NewInt(9))
+		maxBaseFee.Div(maxBaseFee, big.NewInt(8))
+	}
+	return FeeSuggestion{
+		MaxBaseFee:     maxBaseFee,
+		MaxPriorityFee: priorityFee,
+		MaxFee:         new(big.Int).Add(maxBaseFee, priorityFee),
+	}
+}
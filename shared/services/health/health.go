@@ -0,0 +1,258 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	Process_Node       string = "node"
+	Process_Watchtower string = "watchtower"
+
+	DefaultMaxTaskLoopAge time.Duration = 30 * time.Minute
+
+	HealthPath string = "/healthz"
+	ReadyPath  string = "/readyz"
+)
+
+// The task loop progress a daemon records in its heartbeat file
+type heartbeat struct {
+	LastPassStart      time.Time `json:"lastPassStart"`
+	LastPassCompletion time.Time `json:"lastPassCompletion"`
+}
+
+// Records the progress of a daemon's task loop so health checks in other processes can see it
+type Heartbeat struct {
+	path    string
+	current heartbeat
+	log     *log.ColorLogger
+}
+
+// Create a new heartbeat that's saved to the given path
+func NewHeartbeat(path string, logger *log.ColorLogger) *Heartbeat {
+	return &Heartbeat{
+		path: path,
+		log:  logger,
+	}
+}
+
+// Record that the task loop has started running its tasks
+func (h *Heartbeat) RecordPassStart() {
+	h.current.LastPassStart = time.Now()
+	h.save()
+}
+
+// Record that the task loop has finished running its tasks
+func (h *Heartbeat) RecordPassCompletion() {
+	h.current.LastPassCompletion = time.Now()
+	h.save()
+}
+
+// Save the heartbeat; failures are only logged since they shouldn't stop the daemon
+func (h *Heartbeat) save() {
+	bytes, err := json.Marshal(h.current)
+	if err != nil {
+		h.log.Printlnf("WARNING: couldn't serialize the task loop heartbeat: %s", err.Error())
+		return
+	}
+	err = os.MkdirAll(filepath.Dir(h.path), 0755)
+	if err == nil {
+		err = os.WriteFile(h.path, bytes, 0644)
+	}
+	if err != nil {
+		h.log.Printlnf("WARNING: couldn't save the task loop heartbeat to %s: %s", h.path, err.Error())
+	}
+}
+
+// Load a daemon's heartbeat; returns nil if the daemon hasn't saved one yet
+func loadHeartbeat(path string) (*heartbeat, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading task loop heartbeat %s: %w", path, err)
+	}
+	var hb heartbeat
+	err = json.Unmarshal(bytes, &hb)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing task loop heartbeat %s: %w", path, err)
+	}
+	return &hb, nil
+}
+
+// Check the health of a daemon process.
+// The process is healthy unless its task loop is stuck on a pass that has run for longer than maxAge.
+// It's ready once the wallet, clients, and contracts are available and its task loop has completed a pass within maxAge.
+func Check(c *cli.Context, process string, maxAge time.Duration) (*api.ServiceHealthResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ServiceHealthResponse{
+		Process: process,
+		Healthy: true,
+		Checks:  []api.HealthCheck{},
+	}
+	addCheck := func(name string, passed bool, message string) {
+		response.Checks = append(response.Checks, api.HealthCheck{
+			Name:    name,
+			Passed:  passed,
+			Message: message,
+		})
+	}
+
+	// Check the wallet
+	w, err := services.GetWallet(c)
+	if err != nil {
+		addCheck("wallet", false, err.Error())
+	} else if !w.IsInitialized() {
+		addCheck("wallet", false, "the node wallet hasn't been initialized")
+	} else {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			addCheck("wallet", false, err.Error())
+		} else {
+			addCheck("wallet", true, fmt.Sprintf("node account %s is available", nodeAccount.Address.Hex()))
+		}
+	}
+
+	// Check the execution client
+	ecSynced := false
+	ecMgr, err := services.GetEthClient(c)
+	if err != nil {
+		addCheck("execution-client", false, err.Error())
+	} else {
+		ecSynced, err = checkClientManagerStatus(ecMgr.CheckStatus(cfg))
+		if ecSynced {
+			addCheck("execution-client", true, "synced")
+		} else {
+			addCheck("execution-client", false, err.Error())
+		}
+	}
+
+	// Check the beacon node
+	bcMgr, err := services.GetBeaconClient(c)
+	if err != nil {
+		addCheck("beacon-client", false, err.Error())
+	} else {
+		bcSynced, err := checkClientManagerStatus(bcMgr.CheckStatus())
+		if bcSynced {
+			addCheck("beacon-client", true, "synced")
+		} else {
+			addCheck("beacon-client", false, err.Error())
+		}
+	}
+
+	// Check the contract manager
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		addCheck("contracts", false, err.Error())
+	} else if !ecSynced {
+		addCheck("contracts", false, "the contracts can't be checked until the execution client is synced")
+	} else if _, err := rp.GetAddress("rocketNodeManager", nil); err != nil {
+		addCheck("contracts", false, fmt.Sprintf("error getting a contract address from Rocket Storage: %s", err.Error()))
+	} else {
+		addCheck("contracts", true, "initialized")
+	}
+
+	// Check the task loop
+	hb, err := loadHeartbeat(cfg.Smartnode.GetTaskLoopHeartbeatPath(true, process))
+	if err != nil {
+		addCheck("task-loop", false, err.Error())
+	} else if hb == nil {
+		addCheck("task-loop", false, fmt.Sprintf("the %s task loop hasn't run yet", process))
+	} else {
+		response.LastTaskLoopCompletion = hb.LastPassCompletion
+		running := hb.LastPassStart.After(hb.LastPassCompletion)
+		if running && time.Since(hb.LastPassStart) > maxAge {
+			response.Healthy = false
+			addCheck("task-loop", false, fmt.Sprintf("the %s task loop has been running the same pass since %s and appears to be stuck", process, hb.LastPassStart.Format(time.RFC1123)))
+		} else if hb.LastPassCompletion.IsZero() {
+			addCheck("task-loop", false, fmt.Sprintf("the %s task loop hasn't completed a pass yet", process))
+		} else if time.Since(hb.LastPassCompletion) > maxAge {
+			addCheck("task-loop", false, fmt.Sprintf("the %s task loop hasn't completed a pass since %s", process, hb.LastPassCompletion.Format(time.RFC1123)))
+		} else {
+			addCheck("task-loop", true, fmt.Sprintf("last completed a pass at %s", hb.LastPassCompletion.Format(time.RFC1123)))
+		}
+	}
+
+	// The process is ready if every check passed
+	response.Ready = true
+	for _, check := range response.Checks {
+		if !check.Passed {
+			response.Ready = false
+			break
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Serve the health and readiness checks over HTTP, returning 503 when they fail
+func RunServer(c *cli.Context, address string, process string, logger *log.ColorLogger) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		serveCheck(c, w, process, false)
+	})
+	mux.HandleFunc(ReadyPath, func(w http.ResponseWriter, r *http.Request) {
+		serveCheck(c, w, process, true)
+	})
+	logger.Printlnf("Starting health checks on %s.", address)
+	err := http.ListenAndServe(address, mux)
+	if err != nil {
+		return fmt.Errorf("Error running health check server: %w", err)
+	}
+	return nil
+}
+
+// Write the result of a health or readiness check
+func serveCheck(c *cli.Context, w http.ResponseWriter, process string, readiness bool) {
+	response, err := Check(c, process, DefaultMaxTaskLoopAge)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	response.Status = "success"
+	status := http.StatusOK
+	if (readiness && !response.Ready) || (!readiness && !response.Healthy) {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Check if the active client of a manager is synced, returning the reason if it isn't
+func checkClientManagerStatus(status *api.ClientManagerStatus) (bool, error) {
+	if status.PrimaryClientStatus.IsWorking {
+		if status.PrimaryClientStatus.IsSynced {
+			return true, nil
+		}
+		return false, fmt.Errorf("the primary client is still syncing (%.2f%%)", status.PrimaryClientStatus.SyncProgress*100)
+	}
+	if status.FallbackEnabled && status.FallbackClientStatus.IsWorking {
+		if status.FallbackClientStatus.IsSynced {
+			return true, nil
+		}
+		return false, fmt.Errorf("the primary client is unavailable (%s) and the fallback client is still syncing (%.2f%%)", status.PrimaryClientStatus.Error, status.FallbackClientStatus.SyncProgress*100)
+	}
+	return false, fmt.Errorf("the primary client is unavailable (%s)", status.PrimaryClientStatus.Error)
+}
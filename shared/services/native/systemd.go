@@ -0,0 +1,164 @@
+package native
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	UnitPrefix    string = "rp-"
+	UnitSuffix    string = ".service"
+	UnitsDir      string = "systemd"
+	SystemUnitDir string = "/etc/systemd/system"
+	unitFileMode         = 0644
+	clientStopSec int    = 300
+)
+
+// The processes the Smartnode can run as systemd services in Native mode
+type ServiceID string
+
+const (
+	ServiceID_Ec         ServiceID = "ec"
+	ServiceID_Bn         ServiceID = "bn"
+	ServiceID_Vc         ServiceID = "vc"
+	ServiceID_Node       ServiceID = "node"
+	ServiceID_Watchtower ServiceID = "watchtower"
+)
+
+// A process to run as a systemd service
+type Service struct {
+	ID          ServiceID
+	Description string
+	Command     string
+	User        string
+	After       []ServiceID
+	StopTimeout int
+}
+
+// Get the name of a service's systemd unit
+func GetUnitName(id ServiceID) string {
+	return UnitPrefix + string(id) + UnitSuffix
+}
+
+// Get the names of the systemd units for a list of services
+func GetUnitNames(services []Service) []string {
+	names := make([]string, len(services))
+	for i, service := range services {
+		names[i] = GetUnitName(service.ID)
+	}
+	return names
+}
+
+// Get the services to run for a Native mode config.
+// The node and watchtower daemons are always included; the clients are only included if their commands have been set.
+func GetServices(cfg *config.RocketPoolConfig, daemonPath string, settingsPath string) []Service {
+	user := cfg.Native.ServiceUser.Value.(string)
+	services := []Service{}
+
+	// Clients
+	clients := []ServiceID{}
+	if command := strings.TrimSpace(cfg.Native.EcCommand.Value.(string)); command != "" {
+		services = append(services, Service{
+			ID:          ServiceID_Ec,
+			Description: "Rocket Pool Execution Client",
+			Command:     command,
+			User:        user,
+			StopTimeout: clientStopSec,
+		})
+		clients = append(clients, ServiceID_Ec)
+	}
+	if command := strings.TrimSpace(cfg.Native.BnCommand.Value.(string)); command != "" {
+		services = append(services, Service{
+			ID:          ServiceID_Bn,
+			Description: "Rocket Pool Beacon Node",
+			Command:     command,
+			User:        user,
+			After:       clients,
+			StopTimeout: clientStopSec,
+		})
+		clients = append(clients, ServiceID_Bn)
+	}
+	if command := strings.TrimSpace(cfg.Native.VcCommand.Value.(string)); command != "" {
+		services = append(services, Service{
+			ID:          ServiceID_Vc,
+			Description: "Rocket Pool Validator Client",
+			Command:     command,
+			User:        user,
+			After:       clients,
+			StopTimeout: clientStopSec,
+		})
+	}
+
+	// Daemons; they get the same time to drain their work as they do in Docker mode
+	daemonCommand := fmt.Sprintf("%s --settings %s", daemonPath, settingsPath)
+	daemonStopSec := int(cfg.Smartnode.ShutdownGracePeriod.Value.(uint64))
+	services = append(services,
+		Service{
+			ID:          ServiceID_Node,
+			Description: "Rocket Pool Node",
+			Command:     daemonCommand + " node",
+			User:        user,
+			After:       clients,
+			StopTimeout: daemonStopSec,
+		},
+		Service{
+			ID:          ServiceID_Watchtower,
+			Description: "Rocket Pool Watchtower",
+			Command:     daemonCommand + " watchtower",
+			User:        user,
+			After:       clients,
+			StopTimeout: daemonStopSec,
+		},
+	)
+
+	return services
+}
+
+// Render the systemd unit file for a service
+func (service Service) RenderUnit() string {
+	after := []string{"network-online.target"}
+	for _, id := range service.After {
+		after = append(after, GetUnitName(id))
+	}
+
+	var builder strings.Builder
+	builder.WriteString("# Generated by the Rocket Pool Smartnode; changes will be overwritten by `rocketpool service native install`\n")
+	builder.WriteString("[Unit]\n")
+	fmt.Fprintf(&builder, "Description=%s\n", service.Description)
+	fmt.Fprintf(&builder, "After=%s\n", strings.Join(after, " "))
+	builder.WriteString("Wants=network-online.target\n\n")
+	builder.WriteString("[Service]\n")
+	builder.WriteString("Type=simple\n")
+	fmt.Fprintf(&builder, "User=%s\n", service.User)
+	builder.WriteString("Restart=always\n")
+	builder.WriteString("RestartSec=5\n")
+	fmt.Fprintf(&builder, "TimeoutStopSec=%d\n", service.StopTimeout)
+	fmt.Fprintf(&builder, "ExecStart=%s\n\n", service.Command)
+	builder.WriteString("[Install]\n")
+	builder.WriteString("WantedBy=multi-user.target\n")
+	return builder.String()
+}
+
+// Write the unit files for a list of services into a folder, returning their paths
+func WriteUnits(services []Service, unitsDir string) ([]string, error) {
+	err := os.MkdirAll(unitsDir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating systemd unit folder [%s]: %w", unitsDir, err)
+	}
+
+	paths := []string{}
+	for _, service := range services {
+		path := filepath.Join(unitsDir, GetUnitName(service.ID))
+		err = os.WriteFile(path, []byte(service.RenderUnit()), unitFileMode)
+		if err != nil {
+			return nil, fmt.Errorf("error writing systemd unit [%s]: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
 // Config
@@ -12,26 +15,93 @@ const (
 	FileMode          = 0600
 )
 
+// Returned when the password is only kept in memory and hasn't been provided since the process started
+var ErrPasswordLocked = errors.New("The node wallet is locked; run 'rocketpool wallet unlock' to unlock it")
+
 // Password manager
 type PasswordManager struct {
 	passwordPath string
+
+	// Where the password comes from, and the provider-specific source of it
+	provider cfgtypes.WalletPasswordProvider
+	source   string
+
+	// The password for the prompt provider, once the wallet has been unlocked
+	unlockedPassword string
+	lock             sync.Mutex
 }
 
 // Create new password manager
 func NewPasswordManager(passwordPath string) *PasswordManager {
 	return &PasswordManager{
 		passwordPath: passwordPath,
+		provider:     cfgtypes.WalletPasswordProvider_File,
 	}
 }
 
+// Set where the password comes from; the password file is used by default
+func (pm *PasswordManager) SetProvider(provider cfgtypes.WalletPasswordProvider, source string) {
+	pm.provider = provider
+	pm.source = source
+}
+
+// Get where the password comes from
+func (pm *PasswordManager) GetProvider() cfgtypes.WalletPasswordProvider {
+	return pm.provider
+}
+
+// Point the password manager at a different password file, such as another wallet profile's
+func (pm *PasswordManager) SetPasswordPath(passwordPath string) {
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	pm.passwordPath = passwordPath
+	pm.unlockedPassword = ""
+}
+
 // Check if the password has been set
 func (pm *PasswordManager) IsPasswordSet() bool {
-	_, err := os.ReadFile(pm.passwordPath)
-	return (err == nil)
+	switch pm.provider {
+	case cfgtypes.WalletPasswordProvider_Environment:
+		return os.Getenv(pm.source) != ""
+	case cfgtypes.WalletPasswordProvider_Command:
+		return pm.source != ""
+	case cfgtypes.WalletPasswordProvider_Prompt:
+		return !pm.IsLocked()
+	default:
+		_, err := os.ReadFile(pm.passwordPath)
+		return (err == nil)
+	}
+}
+
+// Check if the password is only kept in memory and hasn't been provided yet
+func (pm *PasswordManager) IsLocked() bool {
+	if pm.provider != cfgtypes.WalletPasswordProvider_Prompt {
+		return false
+	}
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	return pm.unlockedPassword == ""
 }
 
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
+	switch pm.provider {
+	case cfgtypes.WalletPasswordProvider_Environment:
+		return getEnvironmentPassword(pm.source)
+	case cfgtypes.WalletPasswordProvider_Command:
+		return getCommandPassword(pm.source)
+	case cfgtypes.WalletPasswordProvider_AwsKms:
+		return getAwsKmsPassword(pm.passwordPath)
+	case cfgtypes.WalletPasswordProvider_GcpKms:
+		return getGcpKmsPassword(pm.passwordPath, pm.source)
+	case cfgtypes.WalletPasswordProvider_Prompt:
+		pm.lock.Lock()
+		defer pm.lock.Unlock()
+		if pm.unlockedPassword == "" {
+			return "", ErrPasswordLocked
+		}
+		return pm.unlockedPassword, nil
+	}
 
 	// Read from disk
 	password, err := os.ReadFile(pm.passwordPath)
@@ -57,6 +127,20 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
+	// Save it wherever the provider keeps it
+	switch pm.provider {
+	case cfgtypes.WalletPasswordProvider_Environment:
+		return fmt.Errorf("The password comes from the %s environment variable, so it can't be set by the Smartnode", pm.source)
+	case cfgtypes.WalletPasswordProvider_Command:
+		return errors.New("The password comes from the wallet password command, so it can't be set by the Smartnode")
+	case cfgtypes.WalletPasswordProvider_AwsKms:
+		return setAwsKmsPassword(pm.passwordPath, pm.source, password)
+	case cfgtypes.WalletPasswordProvider_GcpKms:
+		return setGcpKmsPassword(pm.passwordPath, pm.source, password)
+	case cfgtypes.WalletPasswordProvider_Prompt:
+		return pm.Unlock(password)
+	}
+
 	// Write to disk
 	if err := os.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write password to disk: %w", err)
@@ -67,9 +151,39 @@ func (pm *PasswordManager) SetPassword(password string) error {
 
 }
 
+// Provide the password for the prompt provider, which only keeps it in memory
+func (pm *PasswordManager) Unlock(password string) error {
+	if pm.provider != cfgtypes.WalletPasswordProvider_Prompt {
+		return fmt.Errorf("The wallet password comes from the %s provider, so the wallet doesn't need to be unlocked", pm.provider)
+	}
+	if password == "" {
+		return errors.New("The password can't be empty")
+	}
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	pm.unlockedPassword = password
+	return nil
+}
+
+// Forget the password for the prompt provider, locking the wallet again
+func (pm *PasswordManager) Lock() {
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	pm.unlockedPassword = ""
+}
+
 // Delete the password
 func (pm *PasswordManager) DeletePassword() error {
 
+	// Only the file-based providers have anything to delete
+	switch pm.provider {
+	case cfgtypes.WalletPasswordProvider_Environment, cfgtypes.WalletPasswordProvider_Command:
+		return nil
+	case cfgtypes.WalletPasswordProvider_Prompt:
+		pm.Lock()
+		return nil
+	}
+
 	// Check if it exists
 	_, err := os.Stat(pm.passwordPath)
 	if os.IsNotExist(err) {
@@ -0,0 +1,148 @@
+package passwords
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Config
+const (
+	ProviderCommandTimeout time.Duration = 1 * time.Minute
+)
+
+// Read the password from an environment variable
+func getEnvironmentPassword(variable string) (string, error) {
+	if variable == "" {
+		return "", errors.New("The wallet password source must be set to the name of the environment variable that holds the password")
+	}
+	password := os.Getenv(variable)
+	if password == "" {
+		return "", fmt.Errorf("The %s environment variable isn't set", variable)
+	}
+	return password, nil
+}
+
+// Get the password from what a shell command prints
+func getCommandPassword(command string) (string, error) {
+	if command == "" {
+		return "", errors.New("The wallet password source must be set to the command that prints the password")
+	}
+	output, err := runProviderCommand(nil, "sh", "-c", command)
+	if err != nil {
+		return "", fmt.Errorf("Could not get the password from the wallet password command: %w", err)
+	}
+	password := strings.TrimRight(string(output), "\r\n")
+	if password == "" {
+		return "", errors.New("The wallet password command didn't print a password")
+	}
+	return password, nil
+}
+
+// Encrypt the password with an AWS KMS key and save the ciphertext
+func setAwsKmsPassword(path string, keyID string, password string) error {
+	if keyID == "" {
+		return errors.New("The wallet password source must be set to the ID or ARN of the AWS KMS key")
+	}
+	output, err := runProviderCommand([]byte(password), "aws", "kms", "encrypt", "--key-id", keyID, "--plaintext", "fileb:///dev/stdin", "--output", "text", "--query", "CiphertextBlob")
+	if err != nil {
+		return fmt.Errorf("Could not encrypt the password with AWS KMS: %w", err)
+	}
+	return saveCiphertext(path, strings.TrimSpace(string(output)))
+}
+
+// Decrypt the password saved with an AWS KMS key; the key is part of the ciphertext, so it isn't needed here
+func getAwsKmsPassword(path string) (string, error) {
+	ciphertext, err := loadCiphertext(path)
+	if err != nil {
+		return "", err
+	}
+	output, err := runProviderCommand(ciphertext, "aws", "kms", "decrypt", "--ciphertext-blob", "fileb:///dev/stdin", "--output", "text", "--query", "Plaintext")
+	if err != nil {
+		return "", fmt.Errorf("Could not decrypt the password with AWS KMS: %w", err)
+	}
+	password, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(output)))
+	if err != nil {
+		return "", fmt.Errorf("Could not decode the password decrypted by AWS KMS: %w", err)
+	}
+	return string(password), nil
+}
+
+// Encrypt the password with a GCP KMS key and save the ciphertext
+func setGcpKmsPassword(path string, key string, password string) error {
+	if key == "" {
+		return errors.New("The wallet password source must be set to the resource name of the GCP KMS key")
+	}
+	output, err := runProviderCommand([]byte(password), "gcloud", "kms", "encrypt", "--key", key, "--plaintext-file", "-", "--ciphertext-file", "-")
+	if err != nil {
+		return fmt.Errorf("Could not encrypt the password with GCP KMS: %w", err)
+	}
+	return saveCiphertext(path, base64.StdEncoding.EncodeToString(output))
+}
+
+// Decrypt the password saved with a GCP KMS key
+func getGcpKmsPassword(path string, key string) (string, error) {
+	if key == "" {
+		return "", errors.New("The wallet password source must be set to the resource name of the GCP KMS key")
+	}
+	ciphertext, err := loadCiphertext(path)
+	if err != nil {
+		return "", err
+	}
+	output, err := runProviderCommand(ciphertext, "gcloud", "kms", "decrypt", "--key", key, "--ciphertext-file", "-", "--plaintext-file", "-")
+	if err != nil {
+		return "", fmt.Errorf("Could not decrypt the password with GCP KMS: %w", err)
+	}
+	return string(output), nil
+}
+
+// Save a KMS-encrypted password as base64 in place of the plain text password file
+func saveCiphertext(path string, ciphertext string) error {
+	if _, err := base64.StdEncoding.DecodeString(ciphertext); err != nil || ciphertext == "" {
+		return errors.New("The KMS didn't return a valid ciphertext")
+	}
+	if err := os.WriteFile(path, []byte(ciphertext), FileMode); err != nil {
+		return fmt.Errorf("Could not write encrypted password to disk: %w", err)
+	}
+	return nil
+}
+
+// Load a KMS-encrypted password
+func loadCiphertext(path string) ([]byte, error) {
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read encrypted password from disk: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("Could not decode encrypted password: %w", err)
+	}
+	return ciphertext, nil
+}
+
+// Run a command for a password provider, passing it the given input and returning what it prints
+func runProviderCommand(input []byte, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ProviderCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,84 @@
+package pdao
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The protocol DAO contracts aren't bound by rocketpool-go yet, so they're loaded by name; their ABIs are stored on-chain
+// in RocketStorage along with their addresses
+
+var rocketDAOProtocolProposalLock sync.Mutex
+
+func getRocketDAOProtocolProposal(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketDAOProtocolProposalLock.Lock()
+	defer rocketDAOProtocolProposalLock.Unlock()
+	return rp.GetContract("rocketDAOProtocolProposal", opts)
+}
+
+var rocketDAOProtocolProposalsLock sync.Mutex
+
+func getRocketDAOProtocolProposals(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketDAOProtocolProposalsLock.Lock()
+	defer rocketDAOProtocolProposalsLock.Unlock()
+	return rp.GetContract("rocketDAOProtocolProposals", opts)
+}
+
+var rocketDAOProtocolVerifierLock sync.Mutex
+
+func getRocketDAOProtocolVerifier(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketDAOProtocolVerifierLock.Lock()
+	defer rocketDAOProtocolVerifierLock.Unlock()
+	return rp.GetContract("rocketDAOProtocolVerifier", opts)
+}
+
+var rocketDAOProtocolSettingsProposalsLock sync.Mutex
+
+func getRocketDAOProtocolSettingsProposals(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketDAOProtocolSettingsProposalsLock.Lock()
+	defer rocketDAOProtocolSettingsProposalsLock.Unlock()
+	return rp.GetContract("rocketDAOProtocolSettingsProposals", opts)
+}
+
+var rocketNetworkVotingLock sync.Mutex
+
+func getRocketNetworkVoting(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketNetworkVotingLock.Lock()
+	defer rocketNetworkVotingLock.Unlock()
+	return rp.GetContract("rocketNetworkVoting", opts)
+}
+
+var rocketNodeStakingLock sync.Mutex
+
+func getRocketNodeStaking(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketNodeStakingLock.Lock()
+	defer rocketNodeStakingLock.Unlock()
+	return rp.GetContract("rocketNodeStaking", opts)
+}
+
+var rocketDAOSecurityLock sync.Mutex
+
+func getRocketDAOSecurity(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketDAOSecurityLock.Lock()
+	defer rocketDAOSecurityLock.Unlock()
+	return rp.GetContract("rocketDAOSecurity", opts)
+}
+
+var rocketDAOSecurityActionsLock sync.Mutex
+
+func getRocketDAOSecurityActions(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketDAOSecurityActionsLock.Lock()
+	defer rocketDAOSecurityActionsLock.Unlock()
+	return rp.GetContract("rocketDAOSecurityActions", opts)
+}
+
+var rocketDAOSecurityProposalsLock sync.Mutex
+
+func getRocketDAOSecurityProposals(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketDAOSecurityProposalsLock.Lock()
+	defer rocketDAOSecurityProposalsLock.Unlock()
+	return rp.GetContract("rocketDAOSecurityProposals", opts)
+}
@@ -0,0 +1,488 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/strings"
+	"golang.org/x/sync/errgroup"
+)
+
+// Settings
+const (
+	ProposalDetailsBatchSize = 10
+)
+
+// Protocol DAO proposal states
+type ProposalState uint8
+
+const (
+	ProposalState_Pending ProposalState = iota
+	ProposalState_ActivePhase1
+	ProposalState_ActivePhase2
+	ProposalState_Destroyed
+	ProposalState_Vetoed
+	ProposalState_QuorumNotMet
+	ProposalState_Defeated
+	ProposalState_Succeeded
+	ProposalState_Expired
+	ProposalState_Executed
+)
+
+var proposalStates = []string{"Pending", "Active (Phase 1)", "Active (Phase 2)", "Destroyed", "Vetoed", "Quorum not met", "Defeated", "Succeeded", "Expired", "Executed"}
+
+func (s ProposalState) String() string {
+	if int(s) >= len(proposalStates) {
+		return fmt.Sprintf("Unknown (%d)", s)
+	}
+	return proposalStates[s]
+}
+
+// Protocol DAO vote directions
+type VoteDirection uint8
+
+const (
+	VoteDirection_NoVote VoteDirection = iota
+	VoteDirection_Abstain
+	VoteDirection_For
+	VoteDirection_Against
+	VoteDirection_AgainstWithVeto
+)
+
+var voteDirections = []string{"none", "abstain", "for", "against", "veto"}
+
+func (d VoteDirection) String() string {
+	if int(d) >= len(voteDirections) {
+		return fmt.Sprintf("unknown (%d)", d)
+	}
+	return voteDirections[d]
+}
+
+// Parse a vote direction from its name
+func ParseVoteDirection(value string) (VoteDirection, error) {
+	for direction, name := range voteDirections {
+		if direction != int(VoteDirection_NoVote) && value == name {
+			return VoteDirection(direction), nil
+		}
+	}
+	return VoteDirection_NoVote, fmt.Errorf("Invalid vote direction '%s'; must be 'abstain', 'for', 'against', or 'veto'", value)
+}
+
+// Protocol DAO proposal details
+type ProposalDetails struct {
+	ID                    uint64         `json:"id"`
+	ProposerAddress       common.Address `json:"proposerAddress"`
+	Message               string         `json:"message"`
+	TargetBlock           uint32         `json:"targetBlock"`
+	CreatedTime           uint64         `json:"createdTime"`
+	Phase1EndTime         uint64         `json:"phase1EndTime"`
+	Phase2EndTime         uint64         `json:"phase2EndTime"`
+	ExpiryTime            uint64         `json:"expiryTime"`
+	VotingPowerRequired   *big.Int       `json:"votingPowerRequired"`
+	VotingPowerFor        *big.Int       `json:"votingPowerFor"`
+	VotingPowerAgainst    *big.Int       `json:"votingPowerAgainst"`
+	VotingPowerToVeto     *big.Int       `json:"votingPowerToVeto"`
+	VotingPowerVeto       *big.Int       `json:"votingPowerVeto"`
+	VotingPowerAbstained  *big.Int       `json:"votingPowerAbstained"`
+	Payload               []byte         `json:"payload"`
+	PayloadStr            string         `json:"payloadStr"`
+	State                 ProposalState  `json:"state"`
+	StateName             string         `json:"stateName"`
+	NodeVoteDirection     VoteDirection  `json:"nodeVoteDirection"`
+	NodeVoteDirectionName string         `json:"nodeVoteDirectionName"`
+	IsProposedByNode      bool           `json:"isProposedByNode"`
+}
+
+// Get the details of all protocol DAO proposals, including the given node's vote on each
+func GetProposals(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) ([]ProposalDetails, error) {
+
+	// Get proposal count
+	proposalCount, err := GetProposalCount(rp, opts)
+	if err != nil {
+		return []ProposalDetails{}, err
+	}
+
+	// Load proposal details in batches
+	details := make([]ProposalDetails, proposalCount)
+	for bsi := uint64(0); bsi < proposalCount; bsi += ProposalDetailsBatchSize {
+
+		// Get batch start & end index
+		psi := bsi
+		pei := bsi + ProposalDetailsBatchSize
+		if pei > proposalCount {
+			pei = proposalCount
+		}
+
+		// Load details
+		var wg errgroup.Group
+		for pi := psi; pi < pei; pi++ {
+			pi := pi
+			wg.Go(func() error {
+				proposalDetails, err := GetProposalDetails(rp, pi+1, nodeAddress, opts) // Proposals are 1-indexed
+				if err == nil {
+					details[pi] = proposalDetails
+				}
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return []ProposalDetails{}, err
+		}
+
+	}
+
+	// Return
+	return details, nil
+
+}
+
+// Get the details of a protocol DAO proposal, including the given node's vote on it
+func GetProposalDetails(rp *rocketpool.RocketPool, proposalId uint64, nodeAddress common.Address, opts *bind.CallOpts) (ProposalDetails, error) {
+
+	// Data
+	details := ProposalDetails{ID: proposalId}
+	var wg errgroup.Group
+
+	// Load data
+	wg.Go(func() error {
+		var err error
+		details.ProposerAddress, err = GetProposalProposer(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.Message, err = getProposalMessage(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.TargetBlock, err = GetProposalBlock(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.CreatedTime, err = getProposalUint(rp, proposalId, "getCreated", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.Phase1EndTime, err = getProposalUint(rp, proposalId, "getPhase1End", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.Phase2EndTime, err = getProposalUint(rp, proposalId, "getPhase2End", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.ExpiryTime, err = getProposalUint(rp, proposalId, "getExpires", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.VotingPowerRequired, err = getProposalBig(rp, proposalId, "getVotingPowerRequired", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.VotingPowerFor, err = getProposalBig(rp, proposalId, "getVotingPowerFor", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.VotingPowerAgainst, err = getProposalBig(rp, proposalId, "getVotingPowerAgainst", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.VotingPowerToVeto, err = getProposalBig(rp, proposalId, "getVetoQuorum", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.VotingPowerVeto, err = getProposalBig(rp, proposalId, "getVotingPowerVeto", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.VotingPowerAbstained, err = getProposalBig(rp, proposalId, "getVotingPowerAbstained", opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.Payload, err = getProposalPayload(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.State, err = GetProposalState(rp, proposalId, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		details.NodeVoteDirection, err = GetProposalVoteDirection(rp, proposalId, nodeAddress, opts)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return ProposalDetails{}, err
+	}
+
+	// Describe the payload and the enums
+	payloadStr, err := dao.GetProposalPayloadString(rp, "rocketDAOProtocolProposals", details.Payload, opts)
+	if err != nil {
+		payloadStr = "(unknown)"
+	}
+	details.PayloadStr = payloadStr
+	details.StateName = details.State.String()
+	details.NodeVoteDirectionName = details.NodeVoteDirection.String()
+	details.IsProposedByNode = (details.ProposerAddress == nodeAddress)
+
+	// Return
+	return details, nil
+
+}
+
+// Get the number of protocol DAO proposals
+func GetProposalCount(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	count := new(*big.Int)
+	if err := rocketDAOProtocolProposal.Call(opts, count, "getTotal"); err != nil {
+		return 0, fmt.Errorf("Could not get protocol DAO proposal count: %w", err)
+	}
+	return (*count).Uint64(), nil
+}
+
+// Get the node that made a protocol DAO proposal
+func GetProposalProposer(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (common.Address, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return common.Address{}, err
+	}
+	proposer := new(common.Address)
+	if err := rocketDAOProtocolProposal.Call(opts, proposer, "getProposer", big.NewInt(int64(proposalId))); err != nil {
+		return common.Address{}, fmt.Errorf("Could not get protocol DAO proposal %d proposer: %w", proposalId, err)
+	}
+	return *proposer, nil
+}
+
+// Get the state of a protocol DAO proposal
+func GetProposalState(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (ProposalState, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	state := new(uint8)
+	if err := rocketDAOProtocolProposal.Call(opts, state, "getState", big.NewInt(int64(proposalId))); err != nil {
+		return 0, fmt.Errorf("Could not get protocol DAO proposal %d state: %w", proposalId, err)
+	}
+	return ProposalState(*state), nil
+}
+
+// Get the block a protocol DAO proposal's voting power was snapshotted at
+func GetProposalBlock(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (uint32, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	block := new(uint32)
+	if err := rocketDAOProtocolProposal.Call(opts, block, "getProposalBlock", big.NewInt(int64(proposalId))); err != nil {
+		return 0, fmt.Errorf("Could not get protocol DAO proposal %d block: %w", proposalId, err)
+	}
+	return *block, nil
+}
+
+// Get the direction a node voted in on a protocol DAO proposal, or VoteDirection_NoVote if it hasn't voted
+func GetProposalVoteDirection(rp *rocketpool.RocketPool, proposalId uint64, nodeAddress common.Address, opts *bind.CallOpts) (VoteDirection, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	direction := new(uint8)
+	if err := rocketDAOProtocolProposal.Call(opts, direction, "getReceiptDirection", big.NewInt(int64(proposalId)), nodeAddress); err != nil {
+		return 0, fmt.Errorf("Could not get protocol DAO proposal %d vote of node %s: %w", proposalId, nodeAddress.Hex(), err)
+	}
+	return VoteDirection(*direction), nil
+}
+
+// Get the protocol DAO proposal bond, in RPL
+func GetProposalBond(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
+	rocketDAOProtocolSettingsProposals, err := getRocketDAOProtocolSettingsProposals(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	bond := new(*big.Int)
+	if err := rocketDAOProtocolSettingsProposals.Call(opts, bond, "getProposalBond"); err != nil {
+		return nil, fmt.Errorf("Could not get protocol DAO proposal bond: %w", err)
+	}
+	return *bond, nil
+}
+
+// Get the payload of a proposal to change a protocol DAO setting; the value must be a *big.Int, bool, or common.Address
+func GetSettingProposalPayload(rp *rocketpool.RocketPool, contractName string, settingName string, value interface{}) ([]byte, error) {
+	rocketDAOProtocolProposals, err := getRocketDAOProtocolProposals(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	var method string
+	switch value.(type) {
+	case *big.Int:
+		method = "proposalSettingUint"
+	case bool:
+		method = "proposalSettingBool"
+	case common.Address:
+		method = "proposalSettingAddress"
+	default:
+		return nil, fmt.Errorf("Unsupported setting value type %T", value)
+	}
+	payload, err := rocketDAOProtocolProposals.ABI.Pack(method, contractName, settingName, value)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode setting proposal payload: %w", err)
+	}
+	return payload, nil
+}
+
+// Estimate the gas of SubmitProposal
+func EstimateProposalGas(rp *rocketpool.RocketPool, message string, payload []byte, blockNumber uint32, pollard []Node, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	message = strings.Sanitize(message)
+	return rocketDAOProtocolProposal.GetTransactionGasInfo(opts, "propose", message, payload, blockNumber, pollard)
+}
+
+// Submit a protocol DAO proposal, along with the pollard of the network voting tree at the given block
+func SubmitProposal(rp *rocketpool.RocketPool, message string, payload []byte, blockNumber uint32, pollard []Node, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	proposalCount, err := GetProposalCount(rp, nil)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	message = strings.Sanitize(message)
+	tx, err := rocketDAOProtocolProposal.Transact(opts, "propose", message, payload, blockNumber, pollard)
+	if err != nil {
+		return 0, common.Hash{}, fmt.Errorf("Could not submit protocol DAO proposal: %w", err)
+	}
+	return proposalCount + 1, tx.Hash(), nil
+}
+
+// Estimate the gas of VoteOnProposal
+func EstimateVoteOnProposalGas(rp *rocketpool.RocketPool, proposalId uint64, direction VoteDirection, votingPower *big.Int, nodeIndex uint64, witness []Node, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocolProposal.GetTransactionGasInfo(opts, "vote", big.NewInt(int64(proposalId)), uint8(direction), votingPower, big.NewInt(int64(nodeIndex)), witness)
+}
+
+// Vote on a protocol DAO proposal with the voting power delegated to the node, proven against the proposal's voting tree
+func VoteOnProposal(rp *rocketpool.RocketPool, proposalId uint64, direction VoteDirection, votingPower *big.Int, nodeIndex uint64, witness []Node, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocolProposal.Transact(opts, "vote", big.NewInt(int64(proposalId)), uint8(direction), votingPower, big.NewInt(int64(nodeIndex)), witness)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not vote on protocol DAO proposal %d: %w", proposalId, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of OverrideVote
+func EstimateOverrideVoteGas(rp *rocketpool.RocketPool, proposalId uint64, direction VoteDirection, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocolProposal.GetTransactionGasInfo(opts, "overrideVote", big.NewInt(int64(proposalId)), uint8(direction))
+}
+
+// Override the vote the node's delegate cast on a protocol DAO proposal with the node's own voting power
+func OverrideVote(rp *rocketpool.RocketPool, proposalId uint64, direction VoteDirection, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocolProposal.Transact(opts, "overrideVote", big.NewInt(int64(proposalId)), uint8(direction))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not override the delegate vote on protocol DAO proposal %d: %w", proposalId, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of ExecuteProposal
+func EstimateExecuteProposalGas(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocolProposal.GetTransactionGasInfo(opts, "execute", big.NewInt(int64(proposalId)))
+}
+
+// Execute a protocol DAO proposal that passed
+func ExecuteProposal(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocolProposal.Transact(opts, "execute", big.NewInt(int64(proposalId)))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not execute protocol DAO proposal %d: %w", proposalId, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Proposal getters
+func getProposalMessage(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) (string, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return "", err
+	}
+	message := new(string)
+	if err := rocketDAOProtocolProposal.Call(opts, message, "getMessage", big.NewInt(int64(proposalId))); err != nil {
+		return "", fmt.Errorf("Could not get protocol DAO proposal %d message: %w", proposalId, err)
+	}
+	return strings.Sanitize(*message), nil
+}
+func getProposalPayload(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.CallOpts) ([]byte, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	payload := new([]byte)
+	if err := rocketDAOProtocolProposal.Call(opts, payload, "getPayload", big.NewInt(int64(proposalId))); err != nil {
+		return nil, fmt.Errorf("Could not get protocol DAO proposal %d payload: %w", proposalId, err)
+	}
+	return *payload, nil
+}
+func getProposalUint(rp *rocketpool.RocketPool, proposalId uint64, method string, opts *bind.CallOpts) (uint64, error) {
+	value, err := getProposalBig(rp, proposalId, method, opts)
+	if err != nil {
+		return 0, err
+	}
+	return value.Uint64(), nil
+}
+func getProposalBig(rp *rocketpool.RocketPool, proposalId uint64, method string, opts *bind.CallOpts) (*big.Int, error) {
+	rocketDAOProtocolProposal, err := getRocketDAOProtocolProposal(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	value := new(*big.Int)
+	if err := rocketDAOProtocolProposal.Call(opts, value, method, big.NewInt(int64(proposalId))); err != nil {
+		return nil, fmt.Errorf("Could not get protocol DAO proposal %d %s: %w", proposalId, method, err)
+	}
+	return *value, nil
+}
@@ -0,0 +1,206 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/strings"
+)
+
+// The name of the security council's proposals contract, which its proposals are recorded under in the DAO proposals
+// contract shared with the oracle DAO
+const SecurityCouncilDAOName = "rocketDAOSecurityProposals"
+
+// Check whether a node is a member of the security council
+func GetSecurityMemberExists(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	rocketDAOSecurity, err := getRocketDAOSecurity(rp, opts)
+	if err != nil {
+		return false, err
+	}
+	exists := new(bool)
+	if err := rocketDAOSecurity.Call(opts, exists, "getMemberIsValid", nodeAddress); err != nil {
+		return false, fmt.Errorf("Could not get security council membership of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return *exists, nil
+}
+
+// Get the number of security council members
+func GetSecurityMemberCount(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	rocketDAOSecurity, err := getRocketDAOSecurity(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	count := new(*big.Int)
+	if err := rocketDAOSecurity.Call(opts, count, "getMemberCount"); err != nil {
+		return 0, fmt.Errorf("Could not get security council member count: %w", err)
+	}
+	return (*count).Uint64(), nil
+}
+
+// Get the time a membership proposal for a node was executed, such as "invited" or "leave", or 0 if it never was
+func GetSecurityMemberProposalExecutedTime(rp *rocketpool.RocketPool, proposalType string, nodeAddress common.Address, opts *bind.CallOpts) (uint64, error) {
+	rocketDAOSecurity, err := getRocketDAOSecurity(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	executedTime := new(*big.Int)
+	if err := rocketDAOSecurity.Call(opts, executedTime, "getMemberProposalExecutedTime", proposalType, nodeAddress); err != nil {
+		return 0, fmt.Errorf("Could not get security council %s proposal executed time of node %s: %w", proposalType, nodeAddress.Hex(), err)
+	}
+	return (*executedTime).Uint64(), nil
+}
+
+// Estimate the gas of JoinSecurityCouncil
+func EstimateJoinSecurityCouncilGas(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOSecurityActions, err := getRocketDAOSecurityActions(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOSecurityActions.GetTransactionGasInfo(opts, "actionJoin")
+}
+
+// Join the security council after being invited by the protocol DAO
+func JoinSecurityCouncil(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOSecurityActions, err := getRocketDAOSecurityActions(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOSecurityActions.Transact(opts, "actionJoin")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not join the security council: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of RequestLeaveSecurityCouncil
+func EstimateRequestLeaveSecurityCouncilGas(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOSecurityActions, err := getRocketDAOSecurityActions(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOSecurityActions.GetTransactionGasInfo(opts, "actionRequestLeave")
+}
+
+// Request to leave the security council, which starts the wait before the node can leave
+func RequestLeaveSecurityCouncil(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOSecurityActions, err := getRocketDAOSecurityActions(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOSecurityActions.Transact(opts, "actionRequestLeave")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not request to leave the security council: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of LeaveSecurityCouncil
+func EstimateLeaveSecurityCouncilGas(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOSecurityActions, err := getRocketDAOSecurityActions(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOSecurityActions.GetTransactionGasInfo(opts, "actionLeave")
+}
+
+// Leave the security council after a leave request has been made and its wait has passed
+func LeaveSecurityCouncil(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOSecurityActions, err := getRocketDAOSecurityActions(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOSecurityActions.Transact(opts, "actionLeave")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not leave the security council: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get the payload of a security council proposal to disable a boolean protocol setting, such as deposits
+func GetSecurityDisableSettingPayload(rp *rocketpool.RocketPool, contractName string, settingName string) ([]byte, error) {
+	rocketDAOSecurityProposals, err := getRocketDAOSecurityProposals(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := rocketDAOSecurityProposals.ABI.Pack("proposalSettingBool", contractName, settingName, false)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode disable setting proposal payload: %w", err)
+	}
+	return payload, nil
+}
+
+// Estimate the gas of SubmitSecurityProposal
+func EstimateSecurityProposalGas(rp *rocketpool.RocketPool, message string, payload []byte, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOSecurityProposals, err := getRocketDAOSecurityProposals(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	message = strings.Sanitize(message)
+	return rocketDAOSecurityProposals.GetTransactionGasInfo(opts, "propose", message, payload)
+}
+
+// Submit a security council proposal
+func SubmitSecurityProposal(rp *rocketpool.RocketPool, message string, payload []byte, opts *bind.TransactOpts) (uint64, common.Hash, error) {
+	rocketDAOSecurityProposals, err := getRocketDAOSecurityProposals(rp, nil)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	proposalCount, err := dao.GetProposalCount(rp, nil)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	message = strings.Sanitize(message)
+	tx, err := rocketDAOSecurityProposals.Transact(opts, "propose", message, payload)
+	if err != nil {
+		return 0, common.Hash{}, fmt.Errorf("Could not submit security council proposal: %w", err)
+	}
+	return proposalCount + 1, tx.Hash(), nil
+}
+
+// Estimate the gas of VoteOnSecurityProposal
+func EstimateVoteOnSecurityProposalGas(rp *rocketpool.RocketPool, proposalId uint64, support bool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOSecurityProposals, err := getRocketDAOSecurityProposals(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOSecurityProposals.GetTransactionGasInfo(opts, "vote", big.NewInt(int64(proposalId)), support)
+}
+
+// Vote on a security council proposal
+func VoteOnSecurityProposal(rp *rocketpool.RocketPool, proposalId uint64, support bool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOSecurityProposals, err := getRocketDAOSecurityProposals(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOSecurityProposals.Transact(opts, "vote", big.NewInt(int64(proposalId)), support)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not vote on security council proposal %d: %w", proposalId, err)
+	}
+	return tx.Hash(), nil
+}
+
+// Estimate the gas of ExecuteSecurityProposal
+func EstimateExecuteSecurityProposalGas(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOSecurityProposals, err := getRocketDAOSecurityProposals(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOSecurityProposals.GetTransactionGasInfo(opts, "execute", big.NewInt(int64(proposalId)))
+}
+
+// Execute a security council proposal that passed
+func ExecuteSecurityProposal(rp *rocketpool.RocketPool, proposalId uint64, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOSecurityProposals, err := getRocketDAOSecurityProposals(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOSecurityProposals.Transact(opts, "execute", big.NewInt(int64(proposalId)))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not execute security council proposal %d: %w", proposalId, err)
+	}
+	return tx.Hash(), nil
+}
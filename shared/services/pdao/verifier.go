@@ -0,0 +1,89 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The states of a challenge to a node of a proposal's voting tree
+type ChallengeState uint8
+
+const (
+	ChallengeState_Unchallenged ChallengeState = iota
+	ChallengeState_Challenged
+	ChallengeState_Responded
+	ChallengeState_Paid
+)
+
+// Get the state of a challenge to a node of a proposal's voting tree
+func GetChallengeState(rp *rocketpool.RocketPool, proposalId uint64, index uint64, opts *bind.CallOpts) (ChallengeState, error) {
+	rocketDAOProtocolVerifier, err := getRocketDAOProtocolVerifier(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	state := new(uint8)
+	if err := rocketDAOProtocolVerifier.Call(opts, state, "getChallengeState", big.NewInt(int64(proposalId)), big.NewInt(int64(index))); err != nil {
+		return 0, fmt.Errorf("Could not get the state of the challenge to index %d of protocol DAO proposal %d: %w", index, proposalId, err)
+	}
+	return ChallengeState(*state), nil
+}
+
+// Get the generalized indices of the voting tree nodes that have been challenged on a proposal, starting the search at
+// the given block
+func GetChallengedIndices(rp *rocketpool.RocketPool, proposalId uint64, fromBlock *big.Int, intervalSize *big.Int) ([]uint64, error) {
+	rocketDAOProtocolVerifier, err := getRocketDAOProtocolVerifier(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the challenge events for the proposal
+	challengeEvent := rocketDAOProtocolVerifier.ABI.Events["ChallengeSubmitted"]
+	addressFilter := []common.Address{*rocketDAOProtocolVerifier.Address}
+	topicFilter := [][]common.Hash{{challengeEvent.ID}, {common.BigToHash(big.NewInt(int64(proposalId)))}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, intervalSize, fromBlock, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get challenges to protocol DAO proposal %d: %w", proposalId, err)
+	}
+
+	// Get the challenged index from each one
+	indices := make([]uint64, 0, len(logs))
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := challengeEvent.Inputs.NonIndexed().UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("Could not decode challenge to protocol DAO proposal %d: %w", proposalId, err)
+		}
+		index, ok := values["index"].(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("Challenge to protocol DAO proposal %d had no index", proposalId)
+		}
+		indices = append(indices, index.Uint64())
+	}
+	return indices, nil
+}
+
+// Estimate the gas of SubmitRoot
+func EstimateSubmitRootGas(rp *rocketpool.RocketPool, proposalId uint64, index uint64, pollard []Node, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketDAOProtocolVerifier, err := getRocketDAOProtocolVerifier(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketDAOProtocolVerifier.GetTransactionGasInfo(opts, "submitRoot", big.NewInt(int64(proposalId)), big.NewInt(int64(index)), pollard)
+}
+
+// Answer a challenge to a node of a proposal's voting tree with the pollard below it
+func SubmitRoot(rp *rocketpool.RocketPool, proposalId uint64, index uint64, pollard []Node, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketDAOProtocolVerifier, err := getRocketDAOProtocolVerifier(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketDAOProtocolVerifier.Transact(opts, "submitRoot", big.NewInt(int64(proposalId)), big.NewInt(int64(index)), pollard)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not answer the challenge to index %d of protocol DAO proposal %d: %w", index, proposalId, err)
+	}
+	return tx.Hash(), nil
+}
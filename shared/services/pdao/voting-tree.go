@@ -0,0 +1,147 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// A node of the network voting tree, as the protocol DAO contracts encode it.
+// Each node commits to the sum of the voting power of the leaves below it.
+type Node struct {
+	Hash common.Hash `json:"hash"`
+	Sum  *big.Int    `json:"sum"`
+}
+
+// The network voting tree: a Merkle sum tree with one leaf per node, in node index order, holding the voting power
+// delegated to that node.
+// Nodes are addressed by their generalized index: the root is 1 and the children of node i are 2i and 2i+1.
+type VotingTree struct {
+	Depth         uint64
+	DepthPerRound uint64
+	nodes         []Node
+}
+
+// Create the voting tree for the given delegated voting powers.
+// The tree is padded with empty leaves to a power of two, and is never shallower than the depth submitted per round so
+// a full pollard can always be taken from the root.
+func NewVotingTree(votingPowers []*big.Int, depthPerRound uint64) *VotingTree {
+
+	// Get the depth
+	depth := depthPerRound
+	for uint64(1)<<depth < uint64(len(votingPowers)) {
+		depth++
+	}
+	leafCount := uint64(1) << depth
+
+	// Create the leaves
+	nodes := make([]Node, 2*leafCount)
+	for i := uint64(0); i < leafCount; i++ {
+		sum := big.NewInt(0)
+		if i < uint64(len(votingPowers)) {
+			sum.Set(votingPowers[i])
+		}
+		nodes[leafCount+i] = Node{
+			Hash: crypto.Keccak256Hash(math.U256Bytes(new(big.Int).Set(sum))),
+			Sum:  sum,
+		}
+	}
+
+	// Hash each level up to the root
+	for i := leafCount - 1; i > 0; i-- {
+		nodes[i] = getParentNode(nodes[2*i], nodes[2*i+1])
+	}
+
+	return &VotingTree{
+		Depth:         depth,
+		DepthPerRound: depthPerRound,
+		nodes:         nodes,
+	}
+
+}
+
+// Get the root of the tree
+func (t *VotingTree) Root() Node {
+	return t.nodes[1]
+}
+
+// Get the generalized index of a network node's leaf
+func (t *VotingTree) GetLeafIndex(nodeIndex uint64) uint64 {
+	return uint64(1)<<t.Depth + nodeIndex
+}
+
+// Get a network node's leaf
+func (t *VotingTree) GetLeaf(nodeIndex uint64) Node {
+	return t.nodes[t.GetLeafIndex(nodeIndex)]
+}
+
+// Get the pollard below the node at a generalized index: the nodes the depth per round below it, from left to right,
+// or its leaves if they're closer.
+// The pollard below the root is submitted with a proposal, and the pollard below a challenged node answers the challenge.
+func (t *VotingTree) GetPollard(index uint64) ([]Node, error) {
+	if index == 0 || index >= uint64(len(t.nodes)) {
+		return nil, fmt.Errorf("Index %d is not in the voting tree", index)
+	}
+	depth := t.DepthPerRound
+	for depth > 0 && index<<depth >= uint64(len(t.nodes)) {
+		depth--
+	}
+	start := index << depth
+	pollard := make([]Node, uint64(1)<<depth)
+	copy(pollard, t.nodes[start:start+uint64(len(pollard))])
+	return pollard, nil
+}
+
+// Get the witness proving a network node's leaf against the root: the sibling of each node on the path from the leaf
+// up to, but not including, the root
+func (t *VotingTree) GetProof(nodeIndex uint64) ([]Node, error) {
+	if nodeIndex >= uint64(1)<<t.Depth {
+		return nil, fmt.Errorf("Node index %d is not in the voting tree", nodeIndex)
+	}
+	witness := make([]Node, 0, t.Depth)
+	for index := t.GetLeafIndex(nodeIndex); index > 1; index /= 2 {
+		witness = append(witness, t.nodes[index^1])
+	}
+	return witness, nil
+}
+
+// Get the parent of two nodes, which commits to both of their hashes and sums
+func getParentNode(left Node, right Node) Node {
+	sum := new(big.Int).Add(left.Sum, right.Sum)
+	hash := crypto.Keccak256Hash(
+		left.Hash.Bytes(),
+		math.U256Bytes(new(big.Int).Set(left.Sum)),
+		right.Hash.Bytes(),
+		math.U256Bytes(new(big.Int).Set(right.Sum)),
+	)
+	return Node{
+		Hash: hash,
+		Sum:  sum,
+	}
+}
+
+// A proposal's voting tree as it's saved and uploaded, with enough information to rebuild and check it
+type VotingTreeFile struct {
+	Network       string           `json:"network"`
+	ProposalID    uint64           `json:"proposalId"`
+	BlockNumber   uint32           `json:"blockNumber"`
+	DepthPerRound uint64           `json:"depthPerRound"`
+	Root          Node             `json:"root"`
+	NodeAddresses []common.Address `json:"nodeAddresses"`
+	VotingPowers  []*big.Int       `json:"votingPowers"`
+}
+
+// Rebuild the tree from the file, making sure it matches the root that was saved with it
+func (f *VotingTreeFile) GetTree() (*VotingTree, error) {
+	if len(f.NodeAddresses) != len(f.VotingPowers) {
+		return nil, fmt.Errorf("Voting tree for proposal %d has %d nodes but %d voting powers", f.ProposalID, len(f.NodeAddresses), len(f.VotingPowers))
+	}
+	tree := NewVotingTree(f.VotingPowers, f.DepthPerRound)
+	if tree.Root().Hash != f.Root.Hash {
+		return nil, fmt.Errorf("Voting tree for proposal %d has root %s but %s was saved with it", f.ProposalID, tree.Root().Hash.Hex(), f.Root.Hash.Hex())
+	}
+	return tree, nil
+}
@@ -0,0 +1,174 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"golang.org/x/sync/errgroup"
+)
+
+// Settings
+const (
+	NodeVotingPowerBatchSize = 100
+)
+
+// Get a node's own voting power at a block
+func GetVotingPower(rp *rocketpool.RocketPool, nodeAddress common.Address, blockNumber uint32, opts *bind.CallOpts) (*big.Int, error) {
+	rocketNetworkVoting, err := getRocketNetworkVoting(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	votingPower := new(*big.Int)
+	if err := rocketNetworkVoting.Call(opts, votingPower, "getVotingPower", nodeAddress, blockNumber); err != nil {
+		return nil, fmt.Errorf("Could not get voting power of node %s at block %d: %w", nodeAddress.Hex(), blockNumber, err)
+	}
+	return *votingPower, nil
+}
+
+// Get the node a node had delegated its voting power to at a block
+func GetDelegate(rp *rocketpool.RocketPool, nodeAddress common.Address, blockNumber uint32, opts *bind.CallOpts) (common.Address, error) {
+	rocketNetworkVoting, err := getRocketNetworkVoting(rp, opts)
+	if err != nil {
+		return common.Address{}, err
+	}
+	delegate := new(common.Address)
+	if err := rocketNetworkVoting.Call(opts, delegate, "getDelegate", nodeAddress, blockNumber); err != nil {
+		return common.Address{}, fmt.Errorf("Could not get delegate of node %s at block %d: %w", nodeAddress.Hex(), blockNumber, err)
+	}
+	return *delegate, nil
+}
+
+// Check whether a node has initialized its on-chain voting power
+func GetVotingInitialized(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	rocketNetworkVoting, err := getRocketNetworkVoting(rp, opts)
+	if err != nil {
+		return false, err
+	}
+	initialized := new(bool)
+	if err := rocketNetworkVoting.Call(opts, initialized, "getVotingInitialised", nodeAddress); err != nil {
+		return false, fmt.Errorf("Could not get voting initialization status of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return *initialized, nil
+}
+
+// Estimate the gas of InitializeVoting
+func EstimateInitializeVotingGas(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNetworkVoting, err := getRocketNetworkVoting(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNetworkVoting.GetTransactionGasInfo(opts, "initialiseVoting")
+}
+
+// Initialize the node's on-chain voting power, which is required before it can propose or vote
+func InitializeVoting(rp *rocketpool.RocketPool, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNetworkVoting, err := getRocketNetworkVoting(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketNetworkVoting.Transact(opts, "initialiseVoting")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("Could not initialize voting: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get the number of levels of the voting tree submitted at once, when proposing or answering a challenge
+func GetDepthPerRound(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+	rocketDAOProtocolVerifier, err := getRocketDAOProtocolVerifier(rp, opts)
+	if err != nil {
+		return 0, err
+	}
+	depth := new(*big.Int)
+	if err := rocketDAOProtocolVerifier.Call(opts, depth, "getDepthPerRound"); err != nil {
+		return 0, fmt.Errorf("Could not get voting tree depth per round: %w", err)
+	}
+	return (*depth).Uint64(), nil
+}
+
+// Get the amount of a node's staked RPL that's locked, such as for proposal bonds
+func GetNodeRPLLocked(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	rocketNodeStaking, err := getRocketNodeStaking(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	locked := new(*big.Int)
+	if err := rocketNodeStaking.Call(opts, locked, "getNodeRPLLocked", nodeAddress); err != nil {
+		return nil, fmt.Errorf("Could not get locked RPL of node %s: %w", nodeAddress.Hex(), err)
+	}
+	return *locked, nil
+}
+
+// Get the addresses of all nodes registered at a block, in node index order, and the voting power delegated to each.
+// A node's delegated voting power is the sum of the voting power of every node that delegated to it, including itself
+// if it hadn't delegated elsewhere.
+func GetNodeVotingPowers(rp *rocketpool.RocketPool, blockNumber uint32) ([]common.Address, []*big.Int, error) {
+
+	// Get the nodes registered at the block
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(int64(blockNumber)),
+	}
+	nodeAddresses, err := node.GetNodeAddresses(rp, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error getting node addresses at block %d: %w", blockNumber, err)
+	}
+	nodeCount := uint64(len(nodeAddresses))
+
+	// Get each node's own voting power and delegate in batches
+	votingPowers := make([]*big.Int, nodeCount)
+	delegates := make([]common.Address, nodeCount)
+	for bsi := uint64(0); bsi < nodeCount; bsi += NodeVotingPowerBatchSize {
+
+		// Get batch start & end index
+		nsi := bsi
+		nei := bsi + NodeVotingPowerBatchSize
+		if nei > nodeCount {
+			nei = nodeCount
+		}
+
+		// Load details
+		var wg errgroup.Group
+		for ni := nsi; ni < nei; ni++ {
+			ni := ni
+			wg.Go(func() error {
+				var err error
+				votingPowers[ni], err = GetVotingPower(rp, nodeAddresses[ni], blockNumber, opts)
+				return err
+			})
+			wg.Go(func() error {
+				var err error
+				delegates[ni], err = GetDelegate(rp, nodeAddresses[ni], blockNumber, opts)
+				return err
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, nil, err
+		}
+
+	}
+
+	// Add each node's voting power to its delegate's
+	nodeIndices := make(map[common.Address]int, nodeCount)
+	for i, address := range nodeAddresses {
+		nodeIndices[address] = i
+	}
+	delegatedPowers := make([]*big.Int, nodeCount)
+	for i := range delegatedPowers {
+		delegatedPowers[i] = big.NewInt(0)
+	}
+	for i, votingPower := range votingPowers {
+		delegateIndex, exists := nodeIndices[delegates[i]]
+		if !exists {
+			delegateIndex = i
+		}
+		delegatedPowers[delegateIndex].Add(delegatedPowers[delegateIndex], votingPower)
+	}
+
+	// Return
+	return nodeAddresses, delegatedPowers, nil
+
+}
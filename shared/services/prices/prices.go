@@ -0,0 +1,96 @@
+package prices
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Coin IDs in the price API
+const (
+	CoinId_Eth string = "ethereum"
+	CoinId_Rpl string = "rocket-pool"
+)
+
+const (
+	historyPathFormat string        = "%s/coins/%s/history?date=%s&localization=false"
+	requestTimeout    time.Duration = 30 * time.Second
+)
+
+// Standard response
+type historyResponse struct {
+	MarketData *struct {
+		CurrentPrice map[string]float64 `json:"current_price"`
+	} `json:"market_data"`
+}
+
+// Gets historical prices from a CoinGecko-compatible price API, caching them by day
+type PriceClient struct {
+	baseUrl  string
+	currency string
+	client   *http.Client
+	cache    map[string]float64
+}
+
+// Create a new price client; currency is a code such as "usd"
+func NewPriceClient(baseUrl string, currency string) *PriceClient {
+	return &PriceClient{
+		baseUrl:  strings.TrimSuffix(baseUrl, "/"),
+		currency: strings.ToLower(currency),
+		client: &http.Client{
+			Timeout: requestTimeout,
+		},
+		cache: map[string]float64{},
+	}
+}
+
+// Get the price of a coin at the start of the day (UTC) that the given time falls on
+func (c *PriceClient) GetHistoricalPrice(coinId string, date time.Time) (float64, error) {
+
+	// Check the cache
+	dateString := date.UTC().Format("02-01-2006")
+	key := coinId + "/" + dateString
+	if price, exists := c.cache[key]; exists {
+		return price, nil
+	}
+
+	// Send request
+	response, err := c.client.Get(fmt.Sprintf(historyPathFormat, c.baseUrl, coinId, dateString))
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Check the response code
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("request for the %s price on %s failed with code %d", coinId, dateString, response.StatusCode)
+	}
+
+	// Get response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	// Deserialize response
+	var history historyResponse
+	if err := json.Unmarshal(body, &history); err != nil {
+		return 0, fmt.Errorf("Could not decode price API response: %w", err)
+	}
+	if history.MarketData == nil {
+		return 0, fmt.Errorf("the price API has no market data for %s on %s", coinId, dateString)
+	}
+	price, exists := history.MarketData.CurrentPrice[c.currency]
+	if !exists {
+		return 0, fmt.Errorf("the price API has no %s price for %s on %s", c.currency, coinId, dateString)
+	}
+
+	c.cache[key] = price
+	return price, nil
+
+}
@@ -0,0 +1,101 @@
+package reload
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Watches for requests to reload a daemon's config. A reload is requested by sending the daemon SIGHUP, or through the
+// API, which leaves a marker with the time of the request. Daemons check for requests between task passes, so running
+// tasks are never interrupted.
+type Watcher struct {
+	markerPath  string
+	lastRequest time.Time
+	signals     chan os.Signal
+	log         *log.ColorLogger
+}
+
+// Create a new reload watcher and start listening for SIGHUP
+func NewWatcher(markerPath string, logger *log.ColorLogger) *Watcher {
+	w := &Watcher{
+		markerPath:  markerPath,
+		lastRequest: time.Now(),
+		signals:     make(chan os.Signal, 1),
+		log:         logger,
+	}
+	signal.Notify(w.signals, syscall.SIGHUP)
+	return w
+}
+
+// Check if a reload has been requested since the last check
+func (w *Watcher) IsRequested() bool {
+	select {
+	case sig := <-w.signals:
+		w.log.Printlnf("Received %s, reloading the config...", sig)
+		return true
+	default:
+	}
+
+	requestedAt, err := getRequestedTime(w.markerPath)
+	if err != nil {
+		w.log.Printlnf("WARNING: couldn't check for a config reload request: %s", err.Error())
+		return false
+	}
+	if !requestedAt.After(w.lastRequest) {
+		return false
+	}
+	w.lastRequest = requestedAt
+	w.log.Printlnf("A config reload was requested at %s, reloading the config...", requestedAt.Format(time.RFC1123))
+	return true
+}
+
+// Record that a config reload has been requested, so the daemons reload it before their next task pass
+func RequestReload(markerPath string) error {
+	err := os.MkdirAll(filepath.Dir(markerPath), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating the config reload marker's directory: %w", err)
+	}
+	err = os.WriteFile(markerPath, []byte(time.Now().UTC().Format(time.RFC3339Nano)), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing config reload marker %s: %w", markerPath, err)
+	}
+	return nil
+}
+
+// Log which settings a reload applied and which need a restart
+func LogResult(result config.ConfigReloadResult, logger *log.ColorLogger) {
+	if len(result.Applied) == 0 && len(result.RequiresRestart) == 0 {
+		logger.Println("The config hasn't changed.")
+		return
+	}
+	if len(result.Applied) > 0 {
+		logger.Printlnf("Applied the new values of %s.", strings.Join(result.Applied, ", "))
+	}
+	if len(result.RequiresRestart) > 0 {
+		logger.Printlnf("WARNING: %s changed, but the daemon must be restarted for the new values to take effect.", strings.Join(result.RequiresRestart, ", "))
+	}
+}
+
+// Get the time a reload was last requested at, or the zero time if it never was
+func getRequestedTime(markerPath string) (time.Time, error) {
+	bytes, err := os.ReadFile(markerPath)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error reading config reload marker %s: %w", markerPath, err)
+	}
+	requestedAt, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(bytes)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing config reload marker %s: %w", markerPath, err)
+	}
+	return requestedAt, nil
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
 	"github.com/urfave/cli"
 )
 
@@ -38,6 +40,9 @@ func RequireNodePassword(c *cli.Context) error {
 		return err
 	}
 	if !nodePasswordSet {
+		if locked, _ := getNodeWalletLocked(c); locked {
+			return passwords.ErrPasswordLocked
+		}
 		return errors.New("The node password has not been set. Please run 'rocketpool wallet init' and try again.")
 	}
 	return nil
@@ -138,6 +143,23 @@ func RequireNodeRegistered(c *cli.Context) error {
 	return nil
 }
 
+func RequireNodeSecurityMember(c *cli.Context) error {
+	if err := RequireNodeWallet(c); err != nil {
+		return err
+	}
+	if err := RequireRocketStorage(c); err != nil {
+		return err
+	}
+	nodeSecurityMember, err := getNodeSecurityMember(c)
+	if err != nil {
+		return err
+	}
+	if !nodeSecurityMember {
+		return errors.New("The node is not a member of the security council. Nodes can only join the security council by invite from the protocol DAO.")
+	}
+	return nil
+}
+
 func RequireNodeTrusted(c *cli.Context) error {
 	if err := RequireNodeWallet(c); err != nil {
 		return err
@@ -169,7 +191,11 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 			return nil
 		}
 		if verbose {
-			log.Printf("The node password has not been set, retrying in %s...\n", checkNodePasswordInterval.String())
+			if locked, _ := getNodeWalletLocked(c); locked {
+				log.Printf("The node wallet is locked, retrying in %s...\n", checkNodePasswordInterval.String())
+			} else {
+				log.Printf("The node password has not been set, retrying in %s...\n", checkNodePasswordInterval.String())
+			}
 		}
 		time.Sleep(checkNodePasswordInterval)
 	}
@@ -258,6 +284,15 @@ func getNodePasswordSet(c *cli.Context) (bool, error) {
 	return pm.IsPasswordSet(), nil
 }
 
+// Check if the node wallet is locked because its password is only kept in memory and hasn't been provided yet
+func getNodeWalletLocked(c *cli.Context) (bool, error) {
+	pm, err := GetPasswordManager(c)
+	if err != nil {
+		return false, err
+	}
+	return pm.IsLocked(), nil
+}
+
 // Check if the node wallet is initialized
 func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 	w, err := GetWallet(c)
@@ -336,6 +371,22 @@ func getNodeRegistered(c *cli.Context) (bool, error) {
 }
 
 // Check if the node is a member of the oracle DAO
+func getNodeSecurityMember(c *cli.Context) (bool, error) {
+	w, err := GetWallet(c)
+	if err != nil {
+		return false, err
+	}
+	rp, err := GetRocketPool(c)
+	if err != nil {
+		return false, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return false, err
+	}
+	return pdao.GetSecurityMemberExists(rp, nodeAccount.Address, nil)
+}
+
 func getNodeTrusted(c *cli.Context) (bool, error) {
 	w, err := GetWallet(c)
 	if err != nil {
@@ -400,12 +451,12 @@ func checkBeaconClientStatus(bcMgr *BeaconClientManager) (bool, error) {
 
 	// Check the BC status
 	mgrStatus := bcMgr.CheckStatus()
-	if bcMgr.primaryReady {
+	if bcMgr.isPrimaryReady() {
 		return true, nil
 	}
 
 	// If the primary isn't synced but there's a fallback and it is, return true
-	if bcMgr.fallbackReady {
+	if bcMgr.isFallbackReady() {
 		if mgrStatus.PrimaryClientStatus.Error != "" {
 			log.Printf("Primary consensus client is unavailable (%s), using fallback consensus client...\n", mgrStatus.PrimaryClientStatus.Error)
 		} else {
@@ -584,7 +635,7 @@ func waitBeaconClientSynced(c *cli.Context, verbose bool, timeout int64) (bool,
 		// Check sync status
 		if syncStatus.Syncing {
 			if verbose {
-				log.Println("Eth 2.0 node syncing: %.2f%%\n", syncStatus.Progress*100)
+				log.Printf("Eth 2.0 node syncing: %.2f%%\n", syncStatus.Progress*100)
 			}
 		} else {
 			return true, nil
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// The selectors of the errors Solidity reverts with on its own
+var (
+	revertErrorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string), used by require() and revert() with a message
+	revertPanicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256), used by failed asserts and arithmetic errors
+)
+
+// The reason given when a transaction reverts without any data
+const noRevertReason string = "no reason given"
+
+// The meanings of the codes Solidity panics with
+var panicReasons = map[uint64]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an invalid function",
+}
+
+// An error for a transaction that would revert, with the reason decoded from the revert data
+type RevertError struct {
+	Reason string
+	Data   []byte
+
+	// The error the Execution client returned
+	err error
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("execution reverted: %s", e.Reason)
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.err
+}
+
+// Get the reason a transaction would revert from the error returned while simulating it, such as a failed gas estimate.
+// If the error isn't a revert, it's returned so the caller can fail with it.
+func GetRevertReason(err error) (string, error) {
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		return revertErr.Reason, nil
+	}
+	return "", err
+}
+
+// Decode the data a transaction reverted with into a readable reason
+func DecodeRevertData(data []byte) string {
+	if len(data) == 0 {
+		return noRevertReason
+	}
+	if len(data) < 4 {
+		return fmt.Sprintf("invalid revert data %s", hexutil.Encode(data))
+	}
+
+	selector := data[:4]
+	switch {
+	case bytes.Equal(selector, revertErrorSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return fmt.Sprintf("invalid revert message %s", hexutil.Encode(data))
+		}
+		return reason
+
+	case bytes.Equal(selector, revertPanicSelector):
+		code := new(big.Int).SetBytes(data[4:])
+		reason := "unknown panic"
+		if code.IsUint64() {
+			if knownReason, exists := panicReasons[code.Uint64()]; exists {
+				reason = knownReason
+			}
+		}
+		return fmt.Sprintf("panic 0x%x (%s)", code, reason)
+
+	default:
+		// Custom errors can't be named without the ABI of the contract that raised them, so show the selector and arguments
+		if len(data) == 4 {
+			return fmt.Sprintf("custom error %s", hexutil.Encode(selector))
+		}
+		return fmt.Sprintf("custom error %s with arguments %s", hexutil.Encode(selector), hexutil.Encode(data[4:]))
+	}
+}
+
+// Get the revert in an error returned by an Execution client, or nil if it isn't one
+func parseRevertError(err error) *RevertError {
+
+	// Clients that follow Geth return the revert data alongside the message
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if dataString, ok := dataErr.ErrorData().(string); ok {
+			data, decodeErr := hexutil.Decode(dataString)
+			if decodeErr == nil {
+				return &RevertError{
+					Reason: DecodeRevertData(data),
+					Data:   data,
+					err:    err,
+				}
+			}
+		}
+	}
+
+	// Otherwise, fall back to the reason in the message
+	message := err.Error()
+	index := strings.Index(message, "execution reverted")
+	if index == -1 {
+		return nil
+	}
+	reason := strings.TrimSpace(strings.TrimPrefix(message[index+len("execution reverted"):], ":"))
+	if reason == "" {
+		reason = noRevertReason
+	}
+	return &RevertError{
+		Reason: reason,
+		err:    err,
+	}
+
+}
+
+// Get the revert behind a failed gas estimate.
+// Some clients don't say why an estimate failed, so the call is simulated with eth_call to get the revert data.
+// If it doesn't revert, the estimate's error is returned as-is.
+func (p *ExecutionClientManager) getRevertError(ctx context.Context, call ethereum.CallMsg, estimateErr error) error {
+	if revertErr := parseRevertError(estimateErr); revertErr != nil {
+		return revertErr
+	}
+
+	_, callErr := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.CallContract(ctx, call, nil)
+	})
+	if callErr == nil {
+		return estimateErr
+	}
+	revertErr := parseRevertError(callErr)
+	if revertErr == nil {
+		return estimateErr
+	}
+	revertErr.err = fmt.Errorf("%w (simulated: %s)", estimateErr, callErr.Error())
+	return revertErr
+}
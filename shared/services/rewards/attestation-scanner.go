@@ -0,0 +1,119 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"golang.org/x/sync/errgroup"
+)
+
+// Settings
+const (
+	// The number of epochs of committee and attestation records that can be fetched ahead of the one being processed
+	attestationPrefetchEpochs int = 2
+
+	// Attestations can be included until the end of the epoch after the one they were made in, so duties are only kept this long
+	attestationInclusionEpochs uint64 = 1
+)
+
+// The committees and attestations for a single epoch
+type epochAttestations struct {
+	epoch               uint64
+	committees          []beacon.Committee
+	attestationsPerSlot [][]beacon.AttestationInfo
+	err                 error
+}
+
+// Get the number of concurrent Beacon API requests to use when scanning attestations
+func getAttestationScanConcurrency(cfg *config.RocketPoolConfig) int {
+	concurrency := int(cfg.Smartnode.AttestationScanConcurrency.Value.(uint64))
+	if concurrency < 1 {
+		return 1
+	}
+	return concurrency
+}
+
+// Fetch the committees and attestations for each epoch in the range, handing them to the processor one epoch at a time in order.
+// Only a few epochs are fetched ahead of the processor, so memory usage doesn't grow with the length of the range.
+// Committees are only fetched for epochs up to dutiesEndEpoch; later epochs are only checked for lingering attestations.
+func scanAttestations(bc beacon.Client, slotsPerEpoch uint64, concurrency int, startEpoch uint64, endEpoch uint64, dutiesEndEpoch uint64, process func(data *epochAttestations) error) error {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Fetch the epochs in the background
+	results := make(chan *epochAttestations, attestationPrefetchEpochs)
+	go func() {
+		defer close(results)
+		for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+			data := fetchEpochAttestations(bc, slotsPerEpoch, concurrency, epoch, epoch <= dutiesEndEpoch)
+			select {
+			case results <- data:
+			case <-ctx.Done():
+				return
+			}
+			if data.err != nil {
+				return
+			}
+		}
+	}()
+
+	// Process them in order
+	for data := range results {
+		if data.err != nil {
+			return data.err
+		}
+		err := process(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+
+}
+
+// Get the committees (optionally) and the attestations in each slot for an epoch
+func fetchEpochAttestations(bc beacon.Client, slotsPerEpoch uint64, concurrency int, epoch uint64, getCommittees bool) *epochAttestations {
+
+	data := &epochAttestations{
+		epoch:               epoch,
+		attestationsPerSlot: make([][]beacon.AttestationInfo, slotsPerEpoch),
+	}
+	var wg errgroup.Group
+	wg.SetLimit(concurrency)
+
+	if getCommittees {
+		wg.Go(func() error {
+			var err error
+			data.committees, err = bc.GetCommitteesForEpoch(&epoch)
+			return err
+		})
+	}
+
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		i := i
+		slot := epoch*slotsPerEpoch + i
+		wg.Go(func() error {
+			attestations, found, err := bc.GetAttestations(fmt.Sprint(slot))
+			if err != nil {
+				return err
+			}
+			if found {
+				data.attestationsPerSlot[i] = attestations
+			} else {
+				data.attestationsPerSlot[i] = []beacon.AttestationInfo{}
+			}
+			return nil
+		})
+	}
+
+	err := wg.Wait()
+	if err != nil {
+		data.err = fmt.Errorf("Error getting committee and attestation records for epoch %d: %w", epoch, err)
+	}
+	return data
+
+}
@@ -0,0 +1,172 @@
+package rewards
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	beaconRetryInitialBackoff time.Duration = time.Second
+	beaconRetryMaxBackoff     time.Duration = time.Minute
+)
+
+// Wraps the Beacon client used during tree generation so its requests are rate limited, and failed requests are retried
+// with an exponential backoff. This lets generation complete on rate-limited or unreliable Beacon nodes.
+type treeGenBeaconClient struct {
+	beacon.Client
+	log        log.ColorLogger
+	logPrefix  string
+	limiter    *requestLimiter
+	maxRetries uint64
+}
+
+// A Beacon client that can provide a separate client for heavy operations
+type heavyOperationClientProvider interface {
+	GetHeavyOperationClient() beacon.Client
+}
+
+// Spaces requests out evenly so they don't exceed a maximum rate
+type requestLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// Create a new tree generation Beacon client from the settings in the Smartnode config
+func newTreeGenBeaconClient(bc beacon.Client, cfg *config.RocketPoolConfig, logger log.ColorLogger, logPrefix string) *treeGenBeaconClient {
+	// Use the Beacon node that heavy operations are pinned to, if the client manager has one
+	if manager, ok := bc.(heavyOperationClientProvider); ok {
+		bc = manager.GetHeavyOperationClient()
+	}
+
+	limiter := &requestLimiter{}
+	rateLimit := cfg.Smartnode.BeaconRequestRateLimit.Value.(uint64)
+	if rateLimit > 0 {
+		limiter.interval = time.Second / time.Duration(rateLimit)
+	}
+
+	return &treeGenBeaconClient{
+		Client:     bc,
+		log:        logger,
+		logPrefix:  logPrefix,
+		limiter:    limiter,
+		maxRetries: cfg.Smartnode.BeaconRequestMaxRetries.Value.(uint64),
+	}
+}
+
+// Get the attestations in a block
+func (c *treeGenBeaconClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	var attestations []beacon.AttestationInfo
+	var found bool
+	err := c.call(fmt.Sprintf("getting attestations for block %s", blockId), func() error {
+		var err error
+		attestations, found, err = c.Client.GetAttestations(blockId)
+		return err
+	})
+	return attestations, found, err
+}
+
+// Get a Beacon block
+func (c *treeGenBeaconClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	var block beacon.BeaconBlock
+	var found bool
+	err := c.call(fmt.Sprintf("getting Beacon block %s", blockId), func() error {
+		var err error
+		block, found, err = c.Client.GetBeaconBlock(blockId)
+		return err
+	})
+	return block, found, err
+}
+
+// Get the committees for an epoch
+func (c *treeGenBeaconClient) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Committee, error) {
+	var committees []beacon.Committee
+	description := "getting committees for the head epoch"
+	if epoch != nil {
+		description = fmt.Sprintf("getting committees for epoch %d", *epoch)
+	}
+	err := c.call(description, func() error {
+		var err error
+		committees, err = c.Client.GetCommitteesForEpoch(epoch)
+		return err
+	})
+	return committees, err
+}
+
+// Get the Beacon chain's config
+func (c *treeGenBeaconClient) GetEth2Config() (beacon.Eth2Config, error) {
+	var eth2Config beacon.Eth2Config
+	err := c.call("getting the Beacon config", func() error {
+		var err error
+		eth2Config, err = c.Client.GetEth2Config()
+		return err
+	})
+	return eth2Config, err
+}
+
+// Get the EL data for a Beacon block
+func (c *treeGenBeaconClient) GetEth1DataForEth2Block(blockId string) (beacon.Eth1Data, bool, error) {
+	var eth1Data beacon.Eth1Data
+	var found bool
+	err := c.call(fmt.Sprintf("getting EL data for Beacon block %s", blockId), func() error {
+		var err error
+		eth1Data, found, err = c.Client.GetEth1DataForEth2Block(blockId)
+		return err
+	})
+	return eth1Data, found, err
+}
+
+// Get the statuses of multiple validators
+func (c *treeGenBeaconClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	var statuses map[types.ValidatorPubkey]beacon.ValidatorStatus
+	err := c.call(fmt.Sprintf("getting the statuses of %d validators", len(pubkeys)), func() error {
+		var err error
+		statuses, err = c.Client.GetValidatorStatuses(pubkeys, opts)
+		return err
+	})
+	return statuses, err
+}
+
+// Run a request once the rate limit allows it, retrying it with an exponential backoff if it fails
+func (c *treeGenBeaconClient) call(description string, request func() error) error {
+	backoff := beaconRetryInitialBackoff
+	for attempt := uint64(0); ; attempt++ {
+		c.limiter.wait()
+		err := request()
+		if err == nil || attempt >= c.maxRetries {
+			return err
+		}
+
+		c.log.Printlnf("%s WARNING: error %s (attempt %d of %d), retrying in %s: %s", c.logPrefix, description, attempt+1, c.maxRetries+1, backoff, err.Error())
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > beaconRetryMaxBackoff {
+			backoff = beaconRetryMaxBackoff
+		}
+	}
+}
+
+// Wait until the next request is allowed
+func (l *requestLimiter) wait() {
+	if l.interval == 0 {
+		return
+	}
+
+	l.lock.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.lock.Unlock()
+
+	time.Sleep(delay)
+}
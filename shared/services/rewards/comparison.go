@@ -0,0 +1,307 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// The differences between two rewards files for the same interval
+type RewardsFileComparison struct {
+	// The header fields that don't match, such as the snapshot blocks
+	FieldDifferences []RewardsFieldDifference `json:"fieldDifferences"`
+
+	// The Merkle roots recorded in each file, and the ones rebuilt from their node rewards
+	MerkleRootA        common.Hash              `json:"merkleRootA"`
+	MerkleRootB        common.Hash              `json:"merkleRootB"`
+	RebuiltMerkleRootA common.Hash              `json:"rebuiltMerkleRootA"`
+	RebuiltMerkleRootB common.Hash              `json:"rebuiltMerkleRootB"`
+	MerkleRootsMatch   bool                     `json:"merkleRootsMatch"`
+	MismatchedSubtree  *MerkleSubtreeDifference `json:"mismatchedSubtree,omitempty"`
+	MismatchedLeavesA  []MerkleLeaf             `json:"mismatchedLeavesA"`
+	MismatchedLeavesB  []MerkleLeaf             `json:"mismatchedLeavesB"`
+
+	// The nodes that only have rewards in one of the files, and the ones whose rewards differ
+	AddedNodes   []common.Address   `json:"addedNodes"`
+	RemovedNodes []common.Address   `json:"removedNodes"`
+	ChangedNodes []NodeRewardsDelta `json:"changedNodes"`
+}
+
+// A header field of the rewards files that doesn't match
+type RewardsFieldDifference struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// The smallest subtree of the Merkle trees that contains every mismatched leaf
+type MerkleSubtreeDifference struct {
+	// The depth of the subtree's root, where the tree's root is at depth 0
+	Depth uint64 `json:"depth"`
+
+	// The position of the subtree's root within its level
+	Position uint64 `json:"position"`
+
+	// The range of leaf positions the subtree covers, from FirstLeaf up to but not including EndLeaf
+	FirstLeaf uint64 `json:"firstLeaf"`
+	EndLeaf   uint64 `json:"endLeaf"`
+
+	// The hashes of the subtree's root in each tree
+	HashA common.Hash `json:"hashA"`
+	HashB common.Hash `json:"hashB"`
+}
+
+// A leaf of a rewards Merkle tree
+type MerkleLeaf struct {
+	Position uint64         `json:"position"`
+	Node     common.Address `json:"node"`
+	Hash     common.Hash    `json:"hash"`
+}
+
+// How a node's rewards changed from the first file to the second
+type NodeRewardsDelta struct {
+	Node             common.Address `json:"node"`
+	RewardNetworkA   uint64         `json:"rewardNetworkA"`
+	RewardNetworkB   uint64         `json:"rewardNetworkB"`
+	CollateralRpl    *big.Int       `json:"collateralRpl"`
+	OracleDaoRpl     *big.Int       `json:"oracleDaoRpl"`
+	SmoothingPoolEth *big.Int       `json:"smoothingPoolEth"`
+}
+
+// A rewards file's Merkle tree, laid out like the go-merkletree library builds it with sorted leaves and sorted pairs:
+// node 1 is the root, the children of node i are 2i and 2i+1, and the leaves start at node leafOffset
+type rewardsMerkleTree struct {
+	nodes      [][]byte
+	leafOffset uint64
+	leafCount  uint64
+	leafNodes  map[common.Hash]common.Address
+}
+
+// Compare two rewards files, usually one generated locally and the canonical one, to find out why their Merkle roots differ
+func CompareRewardsFiles(a *RewardsFile, b *RewardsFile) *RewardsFileComparison {
+	comparison := &RewardsFileComparison{
+		FieldDifferences:  compareRewardsFields(a, b),
+		MerkleRootA:       common.HexToHash(a.MerkleRoot),
+		MerkleRootB:       common.HexToHash(b.MerkleRoot),
+		MismatchedLeavesA: []MerkleLeaf{},
+		MismatchedLeavesB: []MerkleLeaf{},
+		AddedNodes:        []common.Address{},
+		RemovedNodes:      []common.Address{},
+		ChangedNodes:      []NodeRewardsDelta{},
+	}
+
+	// Rebuild the trees from the node rewards, and find where they diverge
+	treeA := newRewardsMerkleTree(a)
+	treeB := newRewardsMerkleTree(b)
+	comparison.RebuiltMerkleRootA = treeA.root()
+	comparison.RebuiltMerkleRootB = treeB.root()
+	comparison.MerkleRootsMatch = (comparison.MerkleRootA == comparison.MerkleRootB && comparison.RebuiltMerkleRootA == comparison.RebuiltMerkleRootB)
+	if comparison.RebuiltMerkleRootA != comparison.RebuiltMerkleRootB {
+		comparison.MismatchedSubtree = findMismatchedSubtree(treeA, treeB)
+		comparison.MismatchedLeavesA = treeA.getLeavesMissingFrom(treeB)
+		comparison.MismatchedLeavesB = treeB.getLeavesMissingFrom(treeA)
+	}
+
+	// Compare the rewards of each node
+	for address, rewardsA := range a.NodeRewards {
+		rewardsB, exists := b.NodeRewards[address]
+		if !exists {
+			comparison.RemovedNodes = append(comparison.RemovedNodes, address)
+			continue
+		}
+		delta := NodeRewardsDelta{
+			Node:             address,
+			RewardNetworkA:   rewardsA.RewardNetwork,
+			RewardNetworkB:   rewardsB.RewardNetwork,
+			CollateralRpl:    big.NewInt(0).Sub(&rewardsB.CollateralRpl.Int, &rewardsA.CollateralRpl.Int),
+			OracleDaoRpl:     big.NewInt(0).Sub(&rewardsB.OracleDaoRpl.Int, &rewardsA.OracleDaoRpl.Int),
+			SmoothingPoolEth: big.NewInt(0).Sub(&rewardsB.SmoothingPoolEth.Int, &rewardsA.SmoothingPoolEth.Int),
+		}
+		if delta.RewardNetworkA != delta.RewardNetworkB || delta.CollateralRpl.Sign() != 0 || delta.OracleDaoRpl.Sign() != 0 || delta.SmoothingPoolEth.Sign() != 0 {
+			comparison.ChangedNodes = append(comparison.ChangedNodes, delta)
+		}
+	}
+	for address := range b.NodeRewards {
+		if _, exists := a.NodeRewards[address]; !exists {
+			comparison.AddedNodes = append(comparison.AddedNodes, address)
+		}
+	}
+	sortAddresses(comparison.AddedNodes)
+	sortAddresses(comparison.RemovedNodes)
+	sort.Slice(comparison.ChangedNodes, func(i, j int) bool {
+		return bytes.Compare(comparison.ChangedNodes[i].Node[:], comparison.ChangedNodes[j].Node[:]) < 0
+	})
+
+	return comparison
+}
+
+// Get the header fields of two rewards files that don't match
+func compareRewardsFields(a *RewardsFile, b *RewardsFile) []RewardsFieldDifference {
+	differences := []RewardsFieldDifference{}
+	compare := func(field string, valueA interface{}, valueB interface{}) {
+		stringA := fmt.Sprint(valueA)
+		stringB := fmt.Sprint(valueB)
+		if stringA != stringB {
+			differences = append(differences, RewardsFieldDifference{
+				Field: field,
+				A:     stringA,
+				B:     stringB,
+			})
+		}
+	}
+
+	compare("rewardsFileVersion", a.RewardsFileVersion, b.RewardsFileVersion)
+	compare("rulesetVersion", a.RulesetVersion, b.RulesetVersion)
+	compare("index", a.Index, b.Index)
+	compare("network", a.Network, b.Network)
+	compare("startTime", a.StartTime.UTC(), b.StartTime.UTC())
+	compare("endTime", a.EndTime.UTC(), b.EndTime.UTC())
+	compare("consensusStartBlock", a.ConsensusStartBlock, b.ConsensusStartBlock)
+	compare("consensusEndBlock", a.ConsensusEndBlock, b.ConsensusEndBlock)
+	compare("executionStartBlock", a.ExecutionStartBlock, b.ExecutionStartBlock)
+	compare("executionEndBlock", a.ExecutionEndBlock, b.ExecutionEndBlock)
+	compare("intervalsPassed", a.IntervalsPassed, b.IntervalsPassed)
+	if a.TotalRewards != nil && b.TotalRewards != nil {
+		compare("totalRewards.protocolDaoRpl", a.TotalRewards.ProtocolDaoRpl, b.TotalRewards.ProtocolDaoRpl)
+		compare("totalRewards.totalCollateralRpl", a.TotalRewards.TotalCollateralRpl, b.TotalRewards.TotalCollateralRpl)
+		compare("totalRewards.totalOracleDaoRpl", a.TotalRewards.TotalOracleDaoRpl, b.TotalRewards.TotalOracleDaoRpl)
+		compare("totalRewards.totalSmoothingPoolEth", a.TotalRewards.TotalSmoothingPoolEth, b.TotalRewards.TotalSmoothingPoolEth)
+		compare("totalRewards.poolStakerSmoothingPoolEth", a.TotalRewards.PoolStakerSmoothingPoolEth, b.TotalRewards.PoolStakerSmoothingPoolEth)
+		compare("totalRewards.nodeOperatorSmoothingPoolEth", a.TotalRewards.NodeOperatorSmoothingPoolEth, b.TotalRewards.NodeOperatorSmoothingPoolEth)
+	}
+	return differences
+}
+
+// Rebuild the Merkle tree of a rewards file from its node rewards
+func newRewardsMerkleTree(rewardsFile *RewardsFile) *rewardsMerkleTree {
+	hasher := keccak256.New()
+	tree := &rewardsMerkleTree{
+		leafNodes: map[common.Hash]common.Address{},
+	}
+
+	// Hash the leaves and sort them by hash
+	leaves := make([][]byte, 0, len(rewardsFile.NodeRewards))
+	for address, rewardsForNode := range rewardsFile.NodeRewards {
+		if rewardsForNode.CollateralRpl.Sign() == 0 && rewardsForNode.OracleDaoRpl.Sign() == 0 && rewardsForNode.SmoothingPoolEth.Sign() == 0 {
+			continue
+		}
+		leaf := hasher.Hash(getMerkleLeafData(address, rewardsForNode))
+		tree.leafNodes[common.BytesToHash(leaf)] = address
+		leaves = append(leaves, leaf)
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i], leaves[j]) < 0
+	})
+	tree.leafCount = uint64(len(leaves))
+	if tree.leafCount == 0 {
+		return tree
+	}
+
+	// Pad the leaves up to a power of 2 and hash the branches
+	tree.leafOffset = 1
+	for tree.leafOffset < tree.leafCount {
+		tree.leafOffset *= 2
+	}
+	tree.nodes = make([][]byte, tree.leafOffset*2)
+	for i := uint64(0); i < tree.leafOffset; i++ {
+		if i < tree.leafCount {
+			tree.nodes[tree.leafOffset+i] = leaves[i]
+		} else {
+			tree.nodes[tree.leafOffset+i] = make([]byte, hasher.HashLength())
+		}
+	}
+	for i := tree.leafOffset - 1; i > 0; i-- {
+		left := tree.nodes[i*2]
+		right := tree.nodes[i*2+1]
+		if bytes.Compare(left, right) > 0 {
+			left, right = right, left
+		}
+		tree.nodes[i] = hasher.Hash(left, right)
+	}
+	return tree
+}
+
+// Get the leaf data for a node, which is address[20] :: network[32] :: RPL[32] :: ETH[32]
+func getMerkleLeafData(address common.Address, rewardsForNode *NodeRewardsInfo) []byte {
+	nodeData := make([]byte, 0, 20+32*3)
+	nodeData = append(nodeData, address.Bytes()...)
+	nodeData = append(nodeData, common.BigToHash(big.NewInt(0).SetUint64(rewardsForNode.RewardNetwork)).Bytes()...)
+	rplRewards := big.NewInt(0).Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
+	nodeData = append(nodeData, common.BigToHash(rplRewards).Bytes()...)
+	nodeData = append(nodeData, common.BigToHash(&rewardsForNode.SmoothingPoolEth.Int).Bytes()...)
+	return nodeData
+}
+
+// Get the root of the tree, or an empty hash if it has no leaves
+func (t *rewardsMerkleTree) root() common.Hash {
+	if t.leafCount == 0 {
+		return common.Hash{}
+	}
+	return common.BytesToHash(t.nodes[1])
+}
+
+// Get the leaves of this tree that aren't in the other one
+func (t *rewardsMerkleTree) getLeavesMissingFrom(other *rewardsMerkleTree) []MerkleLeaf {
+	leaves := []MerkleLeaf{}
+	for i := uint64(0); i < t.leafCount; i++ {
+		hash := common.BytesToHash(t.nodes[t.leafOffset+i])
+		if _, exists := other.leafNodes[hash]; !exists {
+			leaves = append(leaves, MerkleLeaf{
+				Position: i,
+				Node:     t.leafNodes[hash],
+				Hash:     hash,
+			})
+		}
+	}
+	return leaves
+}
+
+// Find the smallest subtree that contains every leaf that differs between two trees of the same shape.
+// Returns nil if the trees have different shapes, since their subtrees don't line up.
+func findMismatchedSubtree(a *rewardsMerkleTree, b *rewardsMerkleTree) *MerkleSubtreeDifference {
+	if a.leafCount == 0 || a.leafOffset != b.leafOffset {
+		return nil
+	}
+
+	// Follow the mismatched branch down from the root until both children differ or a leaf is reached
+	node := uint64(1)
+	depth := uint64(0)
+	for node < a.leafOffset {
+		leftDiffers := !bytes.Equal(a.nodes[node*2], b.nodes[node*2])
+		rightDiffers := !bytes.Equal(a.nodes[node*2+1], b.nodes[node*2+1])
+		if leftDiffers && rightDiffers {
+			break
+		} else if leftDiffers {
+			node = node * 2
+		} else if rightDiffers {
+			node = node*2 + 1
+		} else {
+			break
+		}
+		depth++
+	}
+
+	// Get the range of leaves under the subtree, ignoring the padding
+	width := a.leafOffset >> depth
+	position := node - (uint64(1) << depth)
+	endLeaf := (position + 1) * width
+	leafCount := a.leafCount
+	if b.leafCount > leafCount {
+		leafCount = b.leafCount
+	}
+	if endLeaf > leafCount {
+		endLeaf = leafCount
+	}
+	return &MerkleSubtreeDifference{
+		Depth:     depth,
+		Position:  position,
+		FirstLeaf: position * width,
+		EndLeaf:   endLeaf,
+		HashA:     common.BytesToHash(a.nodes[node]),
+		HashB:     common.BytesToHash(b.nodes[node]),
+	}
+}
@@ -41,6 +41,7 @@ type treeGeneratorImpl_v2 struct {
 	rp                   *rocketpool.RocketPool
 	cfg                  *config.RocketPoolConfig
 	bc                   beacon.Client
+	profiler             *Profiler
 	opts                 *bind.CallOpts
 	nodeAddresses        []common.Address
 	nodeDetails          []*NodeSmoothingDetails
@@ -100,7 +101,7 @@ func (r *treeGeneratorImpl_v2) getRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
-func (r *treeGeneratorImpl_v2) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*RewardsFile, error) {
+func (r *treeGeneratorImpl_v2) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, profiler *Profiler) (*RewardsFile, error) {
 
 	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
@@ -108,6 +109,7 @@ func (r *treeGeneratorImpl_v2) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.profiler = profiler
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -135,7 +137,7 @@ func (r *treeGeneratorImpl_v2) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.epsilon = big.NewInt(int64(minipoolCount))
 
 	// Calculate the RPL rewards
-	err = r.calculateRplRewards()
+	err = r.profiler.TimePhase(ProfilePhase_RplRewards, r.calculateRplRewards)
 	if err != nil {
 		return nil, fmt.Errorf("Error calculating RPL rewards: %w", err)
 	}
@@ -150,7 +152,7 @@ func (r *treeGeneratorImpl_v2) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.updateNetworksAndTotals()
 
 	// Generate the Merkle Tree
-	err = r.generateMerkleTree()
+	err = r.profiler.TimePhase(ProfilePhase_MerkleBuild, r.generateMerkleTree)
 	if err != nil {
 		return nil, fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
@@ -609,7 +611,7 @@ func (r *treeGeneratorImpl_v2) calculateEthRewards(checkBeaconPerformance bool)
 
 	// Get the details for nodes eligible for Smoothing Pool rewards
 	// This should be all of the eth1 calls, so do them all at the start of Smoothing Pool calculation to prevent the need for an archive node during normal operations
-	err = r.getSmoothingPoolNodeDetails()
+	err = r.profiler.TimePhase(ProfilePhase_NodeEnumeration, r.getSmoothingPoolNodeDetails)
 	if err != nil {
 		return err
 	}
@@ -627,7 +629,7 @@ func (r *treeGeneratorImpl_v2) calculateEthRewards(checkBeaconPerformance bool)
 		Slots: map[uint64]*SlotInfo{},
 	}
 	if checkBeaconPerformance {
-		err = r.processAttestationsForInterval()
+		err = r.profiler.TimePhase(ProfilePhase_AttestationScan, r.processAttestationsForInterval)
 		if err != nil {
 			return err
 		}
@@ -39,6 +39,7 @@ type treeGeneratorImpl_v4 struct {
 	rp                     *rocketpool.RocketPool
 	cfg                    *config.RocketPoolConfig
 	bc                     beacon.Client
+	profiler               *Profiler
 	opts                   *bind.CallOpts
 	nodeAddresses          []common.Address
 	nodeDetails            []*NodeSmoothingDetails
@@ -107,7 +108,7 @@ func (r *treeGeneratorImpl_v4) getRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
-func (r *treeGeneratorImpl_v4) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*RewardsFile, error) {
+func (r *treeGeneratorImpl_v4) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, profiler *Profiler) (*RewardsFile, error) {
 
 	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
@@ -115,6 +116,7 @@ func (r *treeGeneratorImpl_v4) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.profiler = profiler
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -156,7 +158,7 @@ func (r *treeGeneratorImpl_v4) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	}
 
 	// Calculate the RPL rewards
-	err = r.calculateRplRewards()
+	err = r.profiler.TimePhase(ProfilePhase_RplRewards, r.calculateRplRewards)
 	if err != nil {
 		return nil, fmt.Errorf("Error calculating RPL rewards: %w", err)
 	}
@@ -171,7 +173,7 @@ func (r *treeGeneratorImpl_v4) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.updateNetworksAndTotals()
 
 	// Generate the Merkle Tree
-	err = r.generateMerkleTree()
+	err = r.profiler.TimePhase(ProfilePhase_MerkleBuild, r.generateMerkleTree)
 	if err != nil {
 		return nil, fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
@@ -662,7 +664,7 @@ func (r *treeGeneratorImpl_v4) calculateEthRewards(checkBeaconPerformance bool)
 
 	// Get the details for nodes eligible for Smoothing Pool rewards
 	// This should be all of the eth1 calls, so do them all at the start of Smoothing Pool calculation to prevent the need for an archive node during normal operations
-	err = r.getSmoothingPoolNodeDetails()
+	err = r.profiler.TimePhase(ProfilePhase_NodeEnumeration, r.getSmoothingPoolNodeDetails)
 	if err != nil {
 		return err
 	}
@@ -680,7 +682,7 @@ func (r *treeGeneratorImpl_v4) calculateEthRewards(checkBeaconPerformance bool)
 		Slots: map[uint64]*SlotInfo{},
 	}
 	if checkBeaconPerformance {
-		err = r.processAttestationsForInterval()
+		err = r.profiler.TimePhase(ProfilePhase_AttestationScan, r.processAttestationsForInterval)
 		if err != nil {
 			return err
 		}
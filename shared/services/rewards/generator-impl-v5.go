@@ -37,6 +37,7 @@ type treeGeneratorImpl_v5 struct {
 	rp                     *rocketpool.RocketPool
 	cfg                    *config.RocketPoolConfig
 	bc                     beacon.Client
+	profiler               *Profiler
 	opts                   *bind.CallOpts
 	nodeDetails            []*NodeSmoothingDetails
 	smoothingPoolBalance   *big.Int
@@ -104,7 +105,7 @@ func (r *treeGeneratorImpl_v5) getRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
-func (r *treeGeneratorImpl_v5) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*RewardsFile, error) {
+func (r *treeGeneratorImpl_v5) generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, profiler *Profiler) (*RewardsFile, error) {
 
 	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
@@ -112,6 +113,7 @@ func (r *treeGeneratorImpl_v5) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.profiler = profiler
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -136,7 +138,7 @@ func (r *treeGeneratorImpl_v5) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.epsilon = big.NewInt(int64(minipoolCount))
 
 	// Calculate the RPL rewards
-	err := r.calculateRplRewards()
+	err := r.profiler.TimePhase(ProfilePhase_RplRewards, r.calculateRplRewards)
 	if err != nil {
 		return nil, fmt.Errorf("Error calculating RPL rewards: %w", err)
 	}
@@ -151,7 +153,7 @@ func (r *treeGeneratorImpl_v5) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.updateNetworksAndTotals()
 
 	// Generate the Merkle Tree
-	err = r.generateMerkleTree()
+	err = r.profiler.TimePhase(ProfilePhase_MerkleBuild, r.generateMerkleTree)
 	if err != nil {
 		return nil, fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
@@ -361,6 +363,7 @@ func (r *treeGeneratorImpl_v5) calculateRplRewards() error {
 				r.rewardsFile.NodeRewards[nodeDetails.NodeAddress] = rewardsForNode
 			}
 			rewardsForNode.CollateralRpl.Add(&rewardsForNode.CollateralRpl.Int, nodeRplRewards)
+			r.log.Debugf("%s Node %s earned %s collateral RPL (effective stake %s)", r.logPrefix, nodeDetails.NodeAddress.Hex(), nodeRplRewards.String(), trueNodeEffectiveStakes[nodeDetails.NodeAddress].String())
 
 			// Add the rewards to the running total for the specified network
 			rewardsForNetwork, exists := r.rewardsFile.NetworkRewards[rewardsForNode.RewardNetwork]
@@ -541,7 +544,7 @@ func (r *treeGeneratorImpl_v5) calculateEthRewards(checkBeaconPerformance bool)
 
 	// Get the details for nodes eligible for Smoothing Pool rewards
 	// This should be all of the eth1 calls, so do them all at the start of Smoothing Pool calculation to prevent the need for an archive node during normal operations
-	err = r.getSmoothingPoolNodeDetails()
+	err = r.profiler.TimePhase(ProfilePhase_NodeEnumeration, r.getSmoothingPoolNodeDetails)
 	if err != nil {
 		return err
 	}
@@ -549,6 +552,7 @@ func (r *treeGeneratorImpl_v5) calculateEthRewards(checkBeaconPerformance bool)
 	for _, nodeInfo := range r.nodeDetails {
 		if nodeInfo.IsEligible {
 			eligible++
+			r.log.Debugf("%s Node %s is eligible for Smoothing Pool rewards with %d minipools", r.logPrefix, nodeInfo.Address.Hex(), len(nodeInfo.Minipools))
 		}
 	}
 	r.log.Printlnf("%s %d / %d nodes were eligible for Smoothing Pool rewards", r.logPrefix, eligible, len(r.nodeDetails))
@@ -559,7 +563,7 @@ func (r *treeGeneratorImpl_v5) calculateEthRewards(checkBeaconPerformance bool)
 		Slots: map[uint64]*SlotInfo{},
 	}
 	if checkBeaconPerformance {
-		err = r.processAttestationsForInterval()
+		err = r.profiler.TimePhase(ProfilePhase_AttestationScan, r.processAttestationsForInterval)
 		if err != nil {
 			return err
 		}
@@ -572,7 +576,7 @@ func (r *treeGeneratorImpl_v5) calculateEthRewards(checkBeaconPerformance bool)
 			// Check if the node is currently opted in for simplicity
 			if nodeInfo.IsEligible && nodeInfo.IsOptedIn && r.elEndTime.Sub(nodeInfo.OptInTime) > 0 {
 				for _, minipool := range nodeInfo.Minipools {
-					minipool.CompletedAttestations = map[uint64]bool{0: true}
+					minipool.GoodAttestations = 1
 
 					// Make up an attestation
 					details := r.networkState.MinipoolDetailsByAddress[minipool.Address]
@@ -626,7 +630,7 @@ func (r *treeGeneratorImpl_v5) calculateEthRewards(checkBeaconPerformance bool)
 
 			// Add minipool rewards to the JSON
 			for _, minipoolInfo := range nodeInfo.Minipools {
-				successfulAttestations := uint64(len(minipoolInfo.CompletedAttestations))
+				successfulAttestations := minipoolInfo.GoodAttestations
 				missingAttestations := uint64(len(minipoolInfo.MissingAttestationSlots))
 				performance := &SmoothingPoolMinipoolPerformance{
 					Pubkey:                  minipoolInfo.ValidatorPubkey.Hex(),
@@ -687,7 +691,7 @@ func (r *treeGeneratorImpl_v5) calculateNodeRewards() (*big.Int, *big.Int, error
 		nodeInfo.SmoothingPoolEth = big.NewInt(0)
 		if nodeInfo.IsEligible {
 			for _, minipool := range nodeInfo.Minipools {
-				if len(minipool.CompletedAttestations)+len(minipool.MissingAttestationSlots) == 0 || !minipool.WasActive {
+				if minipool.GoodAttestations+uint64(len(minipool.MissingAttestationSlots)) == 0 || !minipool.WasActive {
 					// Ignore minipools that weren't active for the interval
 					minipool.WasActive = false
 					minipool.MinipoolShare = big.NewInt(0)
@@ -738,30 +742,28 @@ func (r *treeGeneratorImpl_v5) processAttestationsForInterval() error {
 		return err
 	}
 
-	// Check all of the attestations for each epoch
+	// Check all of the attestations for each epoch, including the epoch after the end of the interval for any lingering attestations
+	concurrency := getAttestationScanConcurrency(r.cfg)
 	r.log.Printlnf("%s Checking participation of %d minipools for epochs %d to %d", r.logPrefix, len(r.validatorIndexMap), startEpoch, endEpoch)
-	r.log.Printlnf("%s NOTE: this will take a long time, progress is reported every 100 epochs", r.logPrefix)
+	r.log.Printlnf("%s NOTE: this will take a long time, progress is reported every 100 epochs (using up to %d concurrent Beacon requests)", r.logPrefix, concurrency)
 
 	epochsDone := 0
 	reportStartTime := time.Now()
-	for epoch := startEpoch; epoch < endEpoch+1; epoch++ {
+	err = scanAttestations(r.bc, r.slotsPerEpoch, concurrency, startEpoch, endEpoch+1, endEpoch, func(data *epochAttestations) error {
 		if epochsDone == 100 {
 			timeTaken := time.Since(reportStartTime)
-			r.log.Printlnf("%s On Epoch %d of %d (%.2f%%)... (%s so far)", r.logPrefix, epoch, endEpoch, float64(epoch-startEpoch)/float64(endEpoch-startEpoch)*100.0, timeTaken)
+			r.log.Printlnf("%s On Epoch %d of %d (%.2f%%)... (%s so far)", r.logPrefix, data.epoch, endEpoch, float64(data.epoch-startEpoch)/float64(endEpoch-startEpoch)*100.0, timeTaken)
 			epochsDone = 0
 		}
 
-		err := r.processEpoch(true, epoch)
+		err := r.processEpoch(data)
 		if err != nil {
 			return err
 		}
 
 		epochsDone++
-	}
-
-	// Check the epoch after the end of the interval for any lingering attestations
-	epoch := endEpoch + 1
-	err = r.processEpoch(false, epoch)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -771,60 +773,36 @@ func (r *treeGeneratorImpl_v5) processAttestationsForInterval() error {
 
 }
 
-// Process an epoch, optionally getting the duties for all eligible minipools in it and checking each one's attestation performance
-func (r *treeGeneratorImpl_v5) processEpoch(getDuties bool, epoch uint64) error {
-
-	// Get the committee info and attestation records for this epoch
-	var committeeData []beacon.Committee
-	attestationsPerSlot := make([][]beacon.AttestationInfo, r.slotsPerEpoch)
-	var wg errgroup.Group
-
-	if getDuties {
-		wg.Go(func() error {
-			var err error
-			committeeData, err = r.bc.GetCommitteesForEpoch(&epoch)
-			return err
-		})
-	}
-
-	for i := uint64(0); i < r.slotsPerEpoch; i++ {
-		i := i
-		slot := epoch*r.slotsPerEpoch + i
-		wg.Go(func() error {
-			attestations, found, err := r.bc.GetAttestations(fmt.Sprint(slot))
-			if err != nil {
-				return err
-			}
-			if found {
-				attestationsPerSlot[i] = attestations
-			} else {
-				attestationsPerSlot[i] = []beacon.AttestationInfo{}
-			}
-			return nil
-		})
-	}
-	err := wg.Wait()
-	if err != nil {
-		return fmt.Errorf("Error getting committee and attestaion records for epoch %d: %w", epoch, err)
-	}
+// Process an epoch, getting the duties for all eligible minipools in it if its committees were provided and checking each one's attestation performance
+func (r *treeGeneratorImpl_v5) processEpoch(data *epochAttestations) error {
 
-	if getDuties {
+	if data.committees != nil {
 		// Get all of the expected duties for the epoch
-		err = r.getDutiesForEpoch(committeeData)
+		err := r.getDutiesForEpoch(data.committees)
 		if err != nil {
-			return fmt.Errorf("Error getting duties for epoch %d: %w", epoch, err)
+			return fmt.Errorf("Error getting duties for epoch %d: %w", data.epoch, err)
 		}
 	}
 
 	// Process all of the slots in the epoch
 	for i := uint64(0); i < r.slotsPerEpoch; i++ {
-		slot := epoch*r.slotsPerEpoch + i
-		attestations := attestationsPerSlot[i]
+		slot := data.epoch*r.slotsPerEpoch + i
+		attestations := data.attestationsPerSlot[i]
 		if len(attestations) > 0 {
 			r.checkDutiesForSlot(attestations, slot)
 		}
 	}
 
+	// Drop the duties that can't be fulfilled anymore; they're already recorded as missed on each minipool
+	if data.epoch >= attestationInclusionEpochs {
+		cutoffSlot := (data.epoch - attestationInclusionEpochs + 1) * r.slotsPerEpoch
+		for slotIndex := range r.intervalDutiesInfo.Slots {
+			if slotIndex < cutoffSlot {
+				delete(r.intervalDutiesInfo.Slots, slotIndex)
+			}
+		}
+	}
+
 	return nil
 
 }
@@ -856,7 +834,7 @@ func (r *treeGeneratorImpl_v5) checkDutiesForSlot(attestations []beacon.Attestat
 						if len(slotInfo.Committees) == 0 {
 							delete(r.intervalDutiesInfo.Slots, attestation.SlotIndex)
 						}
-						validator.CompletedAttestations[attestation.SlotIndex] = true
+						validator.GoodAttestations++
 						delete(validator.MissingAttestationSlots, attestation.SlotIndex)
 
 						// Check if this minipool was opted into the SP for this block
@@ -1061,7 +1039,6 @@ func (r *treeGeneratorImpl_v5) getSmoothingPoolNodeDetails() error {
 							//MissedAttestations:      0,
 							//GoodAttestations:        0,
 							MissingAttestationSlots: map[uint64]bool{},
-							CompletedAttestations:   map[uint64]bool{},
 							WasActive:               true,
 							AttestationScore:        big.NewInt(0),
 						})
@@ -33,24 +33,26 @@ const (
 )
 
 type TreeGenerator struct {
-	rewardsIntervalInfos map[uint64]rewardsIntervalInfo
-	logger               log.ColorLogger
-	logPrefix            string
-	rp                   *rocketpool.RocketPool
-	cfg                  *config.RocketPoolConfig
-	bc                   beacon.Client
-	index                uint64
-	startTime            time.Time
-	endTime              time.Time
-	consensusBlock       uint64
-	elSnapshotHeader     *types.Header
-	intervalsPassed      uint64
-	generatorImpl        treeGeneratorImpl
-	approximatorImpl     treeGeneratorImpl
+	logger           log.ColorLogger
+	logPrefix        string
+	rp               *rocketpool.RocketPool
+	cfg              *config.RocketPoolConfig
+	bc               beacon.Client
+	index            uint64
+	startTime        time.Time
+	endTime          time.Time
+	consensusBlock   uint64
+	elSnapshotHeader *types.Header
+	intervalsPassed  uint64
+	state            *state.NetworkState
+	generatorImpl    treeGeneratorImpl
+	approximatorImpl treeGeneratorImpl
+	profiler         *Profiler
 }
 
+// The implementation of a rewards ruleset; see rewardsRulesets for how each one is selected
 type treeGeneratorImpl interface {
-	generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*RewardsFile, error)
+	generateTree(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, profiler *Profiler) (*RewardsFile, error)
 	approximateStakerShareOfSmoothingPool(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client) (*big.Int, error)
 	getRulesetVersion() uint64
 }
@@ -61,97 +63,61 @@ func NewTreeGenerator(logger log.ColorLogger, logPrefix string, rp *rocketpool.R
 		logPrefix:        logPrefix,
 		rp:               rp,
 		cfg:              cfg,
-		bc:               bc,
+		bc:               newTreeGenBeaconClient(bc, cfg, logger, logPrefix),
 		index:            index,
 		startTime:        startTime,
 		endTime:          endTime,
 		consensusBlock:   consensusBlock,
 		elSnapshotHeader: elSnapshotHeader,
 		intervalsPassed:  intervalsPassed,
+		state:            state,
 	}
 
-	// Create the interval wrappers
-	rewardsIntervalInfos := []rewardsIntervalInfo{
-		{
-			rewardsRulesetVersion: 5,
-			mainnetStartInterval:  MainnetV5Interval,
-			praterStartInterval:   PraterV5Interval,
-			generator:             newTreeGeneratorImpl_v5(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed, state),
-		}, {
-			rewardsRulesetVersion: 4,
-			mainnetStartInterval:  MainnetV4Interval,
-			praterStartInterval:   PraterV4Interval,
-			generator:             newTreeGeneratorImpl_v4(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed),
-		}, {
-			rewardsRulesetVersion: 3,
-			mainnetStartInterval:  MainnetV3Interval,
-			praterStartInterval:   PraterV3Interval,
-			generator:             newTreeGeneratorImpl_v3(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed),
-		}, {
-			rewardsRulesetVersion: 2,
-			mainnetStartInterval:  MainnetV2Interval,
-			praterStartInterval:   PraterV2Interval,
-			generator:             newTreeGeneratorImpl_v2(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed),
-		}, {
-			rewardsRulesetVersion: 1,
-			mainnetStartInterval:  0,
-			praterStartInterval:   0,
-			generator:             newTreeGeneratorImpl_v1(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed),
-		},
+	// Generate the tree with the ruleset active for this interval
+	network := t.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	generatorVersion, err := GetRulesetVersion(network, t.index)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ruleset for interval %d: %w", t.index, err)
 	}
-
-	// Create the map of versions to infos
-	t.rewardsIntervalInfos = map[uint64]rewardsIntervalInfo{}
-	for _, info := range rewardsIntervalInfos {
-		// Sanity check to make sure there aren't multiple infos with the same version
-		_, exists := t.rewardsIntervalInfos[info.rewardsRulesetVersion]
-		if exists {
-			return nil, fmt.Errorf("multiple ruleset interval infos with ruleset v%d", info.rewardsRulesetVersion)
-		}
-
-		t.rewardsIntervalInfos[info.rewardsRulesetVersion] = info
+	generatorRuleset, err := getRewardsRuleset(generatorVersion)
+	if err != nil {
+		return nil, err
+	}
+	t.generatorImpl = generatorRuleset.newImpl(t)
+	if t.generatorImpl.getRulesetVersion() != generatorVersion {
+		return nil, fmt.Errorf("the implementation of ruleset v%d reports itself as v%d", generatorVersion, t.generatorImpl.getRulesetVersion())
 	}
 
-	// Get the current network
-	network := t.cfg.Smartnode.Network.Value.(cfgtypes.Network)
-
-	// Determine which actual rulesets to use based on the current interval number, checking in descending order from the latest
-	// to interval 2 since interval 1 is the default
-	foundGenerator := false
-	foundApproximator := false
-	for i := uint64(len(t.rewardsIntervalInfos)); i > 1; i-- {
-		info := t.rewardsIntervalInfos[i]
-		startInterval, err := info.GetStartInterval(network)
+	// Approximations are made during the interval before the tree exists, so they use the ruleset that was active for the
+	// previous interval; this keeps a new ruleset from affecting balances until its first tree has been generated
+	approximatorVersion := uint64(1)
+	if t.index > 0 {
+		approximatorVersion, err = GetRulesetVersion(network, t.index-1)
 		if err != nil {
-			return nil, fmt.Errorf("error getting start interval for rewards period %d: %w", i, err)
-		}
-		if !foundGenerator && t.index >= startInterval {
-			t.generatorImpl = info.generator
-			foundGenerator = true
-		}
-		if !foundApproximator && t.index > startInterval {
-			t.approximatorImpl = info.generator
-			foundApproximator = true
+			return nil, fmt.Errorf("error getting ruleset for interval %d: %w", t.index-1, err)
 		}
-
-		if foundGenerator && foundApproximator {
-			break
-		}
-	}
-
-	// Default to interval 1 if nothing could be found
-	if !foundGenerator {
-		t.generatorImpl = t.rewardsIntervalInfos[1].generator
 	}
-	if !foundApproximator {
-		t.approximatorImpl = t.rewardsIntervalInfos[1].generator
+	if approximatorVersion == generatorVersion {
+		t.approximatorImpl = t.generatorImpl
+	} else {
+		approximatorRuleset, err := getRewardsRuleset(approximatorVersion)
+		if err != nil {
+			return nil, err
+		}
+		t.approximatorImpl = approximatorRuleset.newImpl(t)
 	}
 
 	return t, nil
 }
 
+// Set the profiler that records the phases of tree generation
+func (t *TreeGenerator) SetProfiler(profiler *Profiler) {
+	t.profiler = profiler
+}
+
 func (t *TreeGenerator) GenerateTree() (*RewardsFile, error) {
-	return t.generatorImpl.generateTree(t.rp, t.cfg, t.bc)
+	t.profiler.SetRulesetVersion(t.generatorImpl.getRulesetVersion())
+	return t.generatorImpl.generateTree(t.rp, t.cfg, t.bc, t.profiler)
 }
 
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPool() (*big.Int, error) {
@@ -167,19 +133,21 @@ func (t *TreeGenerator) GetApproximatorRulesetVersion() uint64 {
 }
 
 func (t *TreeGenerator) GenerateTreeWithRuleset(ruleset uint64) (*RewardsFile, error) {
-	info, exists := t.rewardsIntervalInfos[ruleset]
-	if !exists {
-		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
+	info, err := getRewardsRuleset(ruleset)
+	if err != nil {
+		return nil, err
 	}
 
-	return info.generator.generateTree(t.rp, t.cfg, t.bc)
+	generator := info.newImpl(t)
+	t.profiler.SetRulesetVersion(generator.getRulesetVersion())
+	return generator.generateTree(t.rp, t.cfg, t.bc, t.profiler)
 }
 
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPoolWithRuleset(ruleset uint64) (*big.Int, error) {
-	info, exists := t.rewardsIntervalInfos[ruleset]
-	if !exists {
-		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
+	info, err := getRewardsRuleset(ruleset)
+	if err != nil {
+		return nil, err
 	}
 
-	return info.generator.approximateStakerShareOfSmoothingPool(t.rp, t.cfg, t.bc)
+	return info.newImpl(t).approximateStakerShareOfSmoothingPool(t.rp, t.cfg, t.bc)
 }
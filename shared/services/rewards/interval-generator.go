@@ -0,0 +1,135 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/archive"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Generate the rewards file for an interval that has already been submitted, using the archive EC if the primary EC
+// doesn't have the state for it anymore. Returns the interval's rewards event so the caller can check the canonical root.
+// The profiler records the phases of generation, and may be nil.
+func GenerateRewardsFileForInterval(logger log.ColorLogger, logPrefix string, rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, m *state.NetworkStateManager, index uint64, profiler *Profiler) (*RewardsFile, rewards.RewardsEvent, error) {
+
+	// Find the event for this interval
+	rewardsEvent, err := GetRewardSnapshotEvent(rp, cfg, index)
+	if err != nil {
+		return nil, rewards.RewardsEvent{}, fmt.Errorf("Error getting event for interval %d: %w", index, err)
+	}
+	logger.Printlnf("%s Found snapshot event: Beacon block %s, execution block %s", logPrefix, rewardsEvent.ConsensusBlock.String(), rewardsEvent.ExecutionBlock.String())
+
+	// Get the EL block
+	elBlockHeader, err := rp.Client.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return nil, rewardsEvent, fmt.Errorf("Error getting execution block: %w", err)
+	}
+
+	// Make sure the block's state is available before starting
+	client, err := archive.GetClientForBlock(rp, cfg, func(message string) {
+		logger.Printlnf("%s %s", logPrefix, message)
+	}, elBlockHeader.Number)
+	if err != nil {
+		return nil, rewardsEvent, err
+	}
+
+	// Profile the rest of generation if enabled
+	startProfileCapture(logger, logPrefix, profiler)
+	defer stopProfileCapture(logger, logPrefix, profiler)
+
+	// Get the state for the target slot
+	var state *state.NetworkState
+	err = profiler.TimePhase(ProfilePhase_NetworkState, func() error {
+		state, err = m.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+		return err
+	})
+	if err != nil {
+		return nil, rewardsEvent, fmt.Errorf("error getting state for beacon slot %d: %w", rewardsEvent.ConsensusBlock.Uint64(), err)
+	}
+
+	// Generate the rewards file
+	start := time.Now()
+	treegen, err := NewTreeGenerator(logger, logPrefix, client, cfg, bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state)
+	if err != nil {
+		return nil, rewardsEvent, fmt.Errorf("Error creating Merkle tree generator: %w", err)
+	}
+	treegen.SetProfiler(profiler)
+	rewardsFile, err := treegen.GenerateTree()
+	if err != nil {
+		return nil, rewardsEvent, fmt.Errorf("Error generating Merkle tree: %w", err)
+	}
+	for address, network := range rewardsFile.InvalidNetworkNodes {
+		logger.Printlnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", logPrefix, address.Hex(), network)
+	}
+	logger.Printlnf("%s Finished in %s", logPrefix, time.Since(start).String())
+
+	return rewardsFile, rewardsEvent, nil
+
+}
+
+// Generate the rewards file for an interval that has just ended, from the snapshot blocks picked for its submission.
+// The client should be one that has the state for the snapshot's EL block. The profiler records the phases of generation, and may be nil.
+func GenerateRewardsFileForSnapshot(logger log.ColorLogger, logPrefix string, client *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, index uint64, startTime time.Time, endTime time.Time, consensusBlock uint64, elBlockHeader *types.Header, intervalsPassed uint64, profiler *Profiler) (*RewardsFile, error) {
+
+	// Create a new state gen manager
+	elBlockIndex := elBlockHeader.Number.Uint64()
+	mgr, err := state.NewNetworkStateManager(client, cfg, client.Client, bc, &logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network state manager for EL block %d, Beacon slot %d: %w", elBlockIndex, consensusBlock, err)
+	}
+
+	// Profile the rest of generation if enabled
+	startProfileCapture(logger, logPrefix, profiler)
+	defer stopProfileCapture(logger, logPrefix, profiler)
+
+	// Create a new state for the target block
+	var state *state.NetworkState
+	err = profiler.TimePhase(ProfilePhase_NetworkState, func() error {
+		state, err = mgr.GetStateForSlot(consensusBlock)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get network state for EL block %d, Beacon slot %d: %w", elBlockIndex, consensusBlock, err)
+	}
+
+	// Generate the rewards file
+	treegen, err := NewTreeGenerator(logger, logPrefix, client, cfg, bc, index, startTime, endTime, consensusBlock, elBlockHeader, intervalsPassed, state)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating Merkle tree generator: %w", err)
+	}
+	treegen.SetProfiler(profiler)
+	rewardsFile, err := treegen.GenerateTree()
+	if err != nil {
+		return nil, fmt.Errorf("Error generating Merkle tree: %w", err)
+	}
+	for address, network := range rewardsFile.InvalidNetworkNodes {
+		logger.Printlnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", logPrefix, address.Hex(), network)
+	}
+
+	return rewardsFile, nil
+
+}
+
+// Start capturing a profile of generation; failing to do so isn't fatal, since only the timing would be lost
+func startProfileCapture(logger log.ColorLogger, logPrefix string, profiler *Profiler) {
+	err := profiler.StartCapture()
+	if err != nil {
+		logger.Printlnf("%s WARNING: couldn't start capturing a profile of tree generation: %s", logPrefix, err.Error())
+	}
+}
+
+// Stop capturing the profile of generation
+func stopProfileCapture(logger log.ColorLogger, logPrefix string, profiler *Profiler) {
+	err := profiler.StopCapture()
+	if err != nil {
+		logger.Printlnf("%s WARNING: couldn't save the profile of tree generation: %s", logPrefix, err.Error())
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
 const (
@@ -92,6 +93,7 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	}
 
 	info.CID = event.MerkleTreeCID
+	info.MerkleRoot = event.MerkleRoot
 	info.StartTime = event.IntervalStartTime
 	info.EndTime = event.IntervalEndTime
 	merkleRootCanon := event.MerkleRoot
@@ -102,20 +104,27 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	if os.IsNotExist(err) {
 		info.TreeFileExists = false
 		err = nil
+
+		// If the tree was pruned, use the node's rewards that were saved from it
+		var summary PrunedIntervalSummary
+		var isPruned bool
+		summary, isPruned, err = GetPrunedIntervalSummary(cfg, nodeAddress, interval)
+		if err != nil || !isPruned {
+			return
+		}
+		info.IsPruned = true
+		info.MerkleRootValid = merkleRootCanon == summary.MerkleRoot
+		info.NodeExists = summary.NodeExists
+		info.CollateralRplAmount = summary.CollateralRplAmount
+		info.ODaoRplAmount = summary.ODaoRplAmount
+		info.SmoothingPoolEthAmount = summary.SmoothingPoolEthAmount
 		return
 	}
 	info.TreeFileExists = true
 
 	// Unmarshal it
-	fileBytes, err := os.ReadFile(info.TreeFilePath)
-	if err != nil {
-		err = fmt.Errorf("error reading %s: %w", info.TreeFilePath, err)
-		return
-	}
-	var proofWrapper RewardsFile
-	err = json.Unmarshal(fileBytes, &proofWrapper)
+	proofWrapper, err := LoadRewardsFile(info.TreeFilePath)
 	if err != nil {
-		err = fmt.Errorf("error deserializing %s: %w", info.TreeFilePath, err)
 		return
 	}
 
@@ -331,8 +340,8 @@ func GetELBlockHeaderForTime(targetTime time.Time, rp *rocketpool.RocketPool) (*
 	}
 }
 
-// Downloads a single rewards file
-func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, isDaemon bool) error {
+// Downloads a single rewards file and verifies that its Merkle root matches the canonical one
+func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, merkleRoot common.Hash, isDaemon bool) error {
 
 	// Determine file name and path
 	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(interval, isDaemon))
@@ -346,6 +355,7 @@ func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid stri
 	urls := []string{
 		fmt.Sprintf(config.PrimaryRewardsFileUrl, cid, ipfsFilename),
 		fmt.Sprintf(config.SecondaryRewardsFileUrl, cid, ipfsFilename),
+		fmt.Sprintf(config.Web3StorageRewardsFileUrl, cid, ipfsFilename),
 	}
 
 	// Attempt downloads
@@ -376,8 +386,25 @@ func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid stri
 				continue
 			}
 
-			// Write the file
-			err = os.WriteFile(rewardsTreePath, decompressedBytes, 0644)
+			// Make sure the Merkle root matches the canonical one
+			var proofWrapper RewardsFile
+			err = json.Unmarshal(decompressedBytes, &proofWrapper)
+			if err != nil {
+				errBuilder.WriteString(fmt.Sprintf("Error deserializing %s: %s\n", url, err.Error()))
+				continue
+			}
+			merkleRootFromFile := common.HexToHash(proofWrapper.MerkleRoot)
+			if merkleRootFromFile != merkleRoot {
+				errBuilder.WriteString(fmt.Sprintf("Merkle root from %s (%s) does not match the canonical one (%s)\n", url, merkleRootFromFile.Hex(), merkleRoot.Hex()))
+				continue
+			}
+
+			// Write the file, keeping the original JSON unless the binary format has been selected
+			if cfg.Smartnode.RewardsFileFormat.Value.(cfgtypes.RewardsFileFormat) == cfgtypes.RewardsFileFormat_Binary {
+				err = SaveRewardsFileBinary(rewardsTreePath, &proofWrapper)
+			} else {
+				err = os.WriteFile(rewardsTreePath, decompressedBytes, 0644)
+			}
 			if err != nil {
 				return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
 			}
@@ -389,6 +416,139 @@ func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid stri
 
 }
 
+// Downloads a single minipool performance file and verifies that it belongs to the expected interval
+func DownloadMinipoolPerformanceFile(cfg *config.RocketPoolConfig, interval uint64, cid string, isDaemon bool) error {
+
+	// Determine file name and path
+	performancePath, err := homedir.Expand(cfg.Smartnode.GetMinipoolPerformancePath(interval, isDaemon))
+	if err != nil {
+		return fmt.Errorf("error expanding minipool performance path: %w", err)
+	}
+	ipfsFilename := filepath.Base(performancePath) + config.RewardsTreeIpfsExtension
+
+	// Create URL list
+	urls := []string{
+		fmt.Sprintf(config.PrimaryRewardsFileUrl, cid, ipfsFilename),
+		fmt.Sprintf(config.SecondaryRewardsFileUrl, cid, ipfsFilename),
+		fmt.Sprintf(config.Web3StorageRewardsFileUrl, cid, ipfsFilename),
+	}
+
+	// Attempt downloads
+	errBuilder := strings.Builder{}
+	for _, url := range urls {
+		resp, err := http.Get(url)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed (%s)\n", url, err.Error()))
+			continue
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			errBuilder.WriteString(fmt.Sprintf("Downloading %s failed with status %s\n", url, resp.Status))
+			continue
+		}
+		bytes, err := io.ReadAll(resp.Body)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Error reading response bytes from %s: %s\n", url, err.Error()))
+			continue
+		}
+
+		// Decompress it
+		decompressedBytes, err := decompressFile(bytes)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Error decompressing %s: %s\n", url, err.Error()))
+			continue
+		}
+
+		// Make sure it's for the right interval
+		var performanceFile MinipoolPerformanceFile
+		err = json.Unmarshal(decompressedBytes, &performanceFile)
+		if err != nil {
+			errBuilder.WriteString(fmt.Sprintf("Error deserializing %s: %s\n", url, err.Error()))
+			continue
+		}
+		if performanceFile.Index != interval {
+			errBuilder.WriteString(fmt.Sprintf("%s is for interval %d instead of interval %d\n", url, performanceFile.Index, interval))
+			continue
+		}
+
+		// Write the file, keeping the original JSON unless the binary format has been selected
+		if cfg.Smartnode.RewardsFileFormat.Value.(cfgtypes.RewardsFileFormat) == cfgtypes.RewardsFileFormat_Binary {
+			err = SaveMinipoolPerformanceFileBinary(performancePath, &performanceFile)
+		} else {
+			err = os.WriteFile(performancePath, decompressedBytes, 0644)
+		}
+		if err != nil {
+			return fmt.Errorf("error saving interval %d minipool performance file to %s: %w", interval, performancePath, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf(errBuilder.String())
+
+}
+
+// Gets the minipool performance file for an interval, loading it from disk (or the rewards archive) if it's there and downloading it otherwise.
+// The rewards file for the interval is downloaded too if it's missing, since it holds the performance file's CID.
+func GetMinipoolPerformanceFile(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, interval uint64) (performanceFile *MinipoolPerformanceFile, downloaded bool, err error) {
+
+	// Use the local copy if there is one
+	performancePath := cfg.Smartnode.GetMinipoolPerformancePath(interval, true)
+	archivePath := filepath.Join(cfg.Smartnode.GetRewardsArchiveFolder(true), filepath.Base(performancePath)+config.RewardsTreeIpfsExtension)
+	for _, path := range []string{performancePath, archivePath} {
+		_, err = os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			err = fmt.Errorf("error checking %s: %w", path, err)
+			return
+		}
+		performanceFile, err = LoadMinipoolPerformanceFile(path)
+		return
+	}
+
+	// Get the rewards file, which has the performance file's CID
+	rewardsPath := cfg.Smartnode.GetRewardsTreePath(interval, true)
+	_, err = os.Stat(rewardsPath)
+	if os.IsNotExist(err) {
+		var event rewards.RewardsEvent
+		event, err = GetRewardSnapshotEvent(rp, cfg, interval)
+		if err != nil {
+			err = fmt.Errorf("error getting the rewards event for interval %d: %w", interval, err)
+			return
+		}
+		err = DownloadRewardsFile(cfg, interval, event.MerkleTreeCID, event.MerkleRoot, true)
+		if err != nil {
+			err = fmt.Errorf("error downloading the rewards file for interval %d: %w", interval, err)
+			return
+		}
+	} else if err != nil {
+		err = fmt.Errorf("error checking %s: %w", rewardsPath, err)
+		return
+	}
+	rewardsFile, err := LoadRewardsFile(rewardsPath)
+	if err != nil {
+		return
+	}
+	cid := rewardsFile.MinipoolPerformanceFileCID
+	if cid == "" || cid == "---" {
+		err = fmt.Errorf("the rewards file for interval %d doesn't have the CID of a minipool performance file", interval)
+		return
+	}
+
+	// Download the performance file
+	err = DownloadMinipoolPerformanceFile(cfg, interval, cid, true)
+	if err != nil {
+		err = fmt.Errorf("error downloading the minipool performance file for interval %d: %w", interval, err)
+		return
+	}
+	downloaded = true
+	performanceFile, err = LoadMinipoolPerformanceFile(performancePath)
+	return
+
+}
+
 // Decompresses a rewards file
 func decompressFile(compressedBytes []byte) ([]byte, error) {
 	decoder, err := zstd.NewReader(nil)
@@ -0,0 +1,267 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The phases of tree generation that are timed when profiling is enabled
+const (
+	ProfilePhase_NetworkState    string = "network-state"
+	ProfilePhase_RplRewards      string = "rpl-rewards"
+	ProfilePhase_NodeEnumeration string = "node-enumeration"
+	ProfilePhase_AttestationScan string = "attestation-scan"
+	ProfilePhase_MerkleBuild     string = "merkle-build"
+	ProfilePhase_Serialization   string = "serialization"
+)
+
+// The kinds of files a profiled generation creates
+const (
+	profileKind_Report string = "report.json"
+	profileKind_Cpu    string = "cpu.pprof"
+	profileKind_Heap   string = "heap.pprof"
+	profileKind_Trace  string = "trace.out"
+)
+
+// The time spent in one phase of tree generation
+type ProfilePhase struct {
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"startTime"`
+	Seconds   float64   `json:"seconds"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// The report written after a profiled tree generation
+type ProfileReport struct {
+	Index          uint64         `json:"index"`
+	RulesetVersion uint64         `json:"rulesetVersion"`
+	Mode           string         `json:"mode"`
+	StartTime      time.Time      `json:"startTime"`
+	TotalSeconds   float64        `json:"totalSeconds"`
+	Phases         []ProfilePhase `json:"phases"`
+	Files          []string       `json:"files,omitempty"`
+}
+
+// Records how long each phase of tree generation takes, and captures a CPU profile or an execution trace of it if selected.
+// A nil profiler means profiling is disabled, so its methods can always be called.
+type Profiler struct {
+	cfg    *config.RocketPoolConfig
+	mode   cfgtypes.TreeGenProfilingMode
+	report ProfileReport
+	lock   sync.Mutex
+
+	// The file the CPU profile or trace is being written to while it's captured
+	captureFile *os.File
+}
+
+// Create a profiler for generating the tree of an interval, or nil if profiling is disabled
+func NewProfiler(cfg *config.RocketPoolConfig, index uint64) *Profiler {
+	mode := cfg.Smartnode.TreeGenProfiling.Value.(cfgtypes.TreeGenProfilingMode)
+	switch mode {
+	case cfgtypes.TreeGenProfilingMode_Timing, cfgtypes.TreeGenProfilingMode_Cpu, cfgtypes.TreeGenProfilingMode_Trace:
+	default:
+		return nil
+	}
+
+	return &Profiler{
+		cfg:  cfg,
+		mode: mode,
+		report: ProfileReport{
+			Index:     index,
+			Mode:      string(mode),
+			StartTime: time.Now(),
+			Phases:    []ProfilePhase{},
+		},
+	}
+}
+
+// Start capturing a CPU profile or an execution trace of this process, if one was selected
+func (p *Profiler) StartCapture() error {
+	if p == nil {
+		return nil
+	}
+
+	var kind string
+	var start func(file *os.File) error
+	switch p.mode {
+	case cfgtypes.TreeGenProfilingMode_Cpu:
+		kind = profileKind_Cpu
+		start = func(file *os.File) error {
+			return pprof.StartCPUProfile(file)
+		}
+	case cfgtypes.TreeGenProfilingMode_Trace:
+		kind = profileKind_Trace
+		start = func(file *os.File) error {
+			return trace.Start(file)
+		}
+	default:
+		return nil
+	}
+
+	file, err := p.createFile(kind)
+	if err != nil {
+		return err
+	}
+	err = start(file)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error starting %s capture: %w", kind, err)
+	}
+	p.captureFile = file
+	return nil
+}
+
+// Stop the capture started by StartCapture; in CPU mode, this also saves a heap profile of what generation left in memory
+func (p *Profiler) StopCapture() error {
+	if p == nil || p.captureFile == nil {
+		return nil
+	}
+
+	switch p.mode {
+	case cfgtypes.TreeGenProfilingMode_Cpu:
+		pprof.StopCPUProfile()
+	case cfgtypes.TreeGenProfilingMode_Trace:
+		trace.Stop()
+	}
+	err := p.captureFile.Close()
+	p.captureFile = nil
+	if err != nil {
+		return fmt.Errorf("error closing profile capture: %w", err)
+	}
+
+	if p.mode == cfgtypes.TreeGenProfilingMode_Cpu {
+		file, err := p.createFile(profileKind_Heap)
+		if err != nil {
+			return err
+		}
+		err = pprof.WriteHeapProfile(file)
+		closeErr := file.Close()
+		if err != nil {
+			return fmt.Errorf("error writing heap profile: %w", err)
+		}
+		if closeErr != nil {
+			return fmt.Errorf("error closing heap profile: %w", closeErr)
+		}
+	}
+	return nil
+}
+
+// Run a phase of tree generation, recording how long it took
+func (p *Profiler) TimePhase(name string, run func() error) error {
+	if p == nil {
+		return run()
+	}
+
+	phase := ProfilePhase{
+		Name:      name,
+		StartTime: time.Now(),
+	}
+	err := run()
+	phase.Seconds = time.Since(phase.StartTime).Seconds()
+	if err != nil {
+		phase.Error = err.Error()
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.report.Phases = append(p.report.Phases, phase)
+	return err
+}
+
+// Set the version of the ruleset the tree was generated with
+func (p *Profiler) SetRulesetVersion(rulesetVersion uint64) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.report.RulesetVersion = rulesetVersion
+}
+
+// Get a copy of the report so far, or nil if profiling is disabled
+func (p *Profiler) GetReport() *ProfileReport {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	report := p.report
+	report.Phases = append([]ProfilePhase{}, p.report.Phases...)
+	report.Files = append([]string{}, p.report.Files...)
+	return &report
+}
+
+// Add the phases recorded by another process that generated the tree, such as the tree worker
+func (p *Profiler) Merge(report *ProfileReport) {
+	if p == nil || report == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.report.RulesetVersion = report.RulesetVersion
+	p.report.Phases = append(p.report.Phases, report.Phases...)
+	p.report.Files = append(p.report.Files, report.Files...)
+}
+
+// Finish the report and save it; failing to do so is only logged, since it doesn't affect the generated tree
+func (p *Profiler) SaveReport(logger log.ColorLogger, logPrefix string) {
+	if p == nil {
+		return
+	}
+	path, err := p.saveReport()
+	if err != nil {
+		logger.Printlnf("%s WARNING: %s", logPrefix, err.Error())
+		return
+	}
+	logger.Printlnf("%s Saved the tree generation profile to %s.", logPrefix, path)
+}
+
+// Finish the report and save it, returning its path
+func (p *Profiler) saveReport() (string, error) {
+	p.lock.Lock()
+	p.report.TotalSeconds = time.Since(p.report.StartTime).Seconds()
+	bytes, err := json.MarshalIndent(p.report, "", "  ")
+	p.lock.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("error serializing tree generation profile: %w", err)
+	}
+
+	path := p.cfg.Smartnode.GetTreeGenProfilePath(p.report.Index, profileKind_Report, true)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return "", fmt.Errorf("error creating tree generation profile folder: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return "", fmt.Errorf("error saving tree generation profile to %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// Create one of the profile files, recording it in the report
+func (p *Profiler) createFile(kind string) (*os.File, error) {
+	path := p.cfg.Smartnode.GetTreeGenProfilePath(p.report.Index, kind, true)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating tree generation profile folder: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s: %w", path, err)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.report.Files = append(p.report.Files, path)
+	return file, nil
+}
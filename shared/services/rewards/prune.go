@@ -0,0 +1,278 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A rewards file that is older than the retention period
+type PrunableRewardsFile struct {
+	Interval uint64 `json:"interval"`
+	Path     string `json:"path"`
+	Size     uint64 `json:"size"`
+}
+
+// The node's rewards from a claimed interval whose rewards tree has been pruned
+type PrunedIntervalSummary struct {
+	MerkleRoot             common.Hash   `json:"merkleRoot"`
+	NodeExists             bool          `json:"nodeExists"`
+	CollateralRplAmount    *QuotedBigInt `json:"collateralRplAmount"`
+	ODaoRplAmount          *QuotedBigInt `json:"oDaoRplAmount"`
+	SmoothingPoolEthAmount *QuotedBigInt `json:"smoothingPoolEthAmount"`
+}
+
+// The summaries of the pruned intervals, by node address and then by interval
+type prunedRewardsSummary map[common.Address]map[uint64]PrunedIntervalSummary
+
+// Get the intervals that are older than the retention period, mapped to whether or not their rewards tree can be pruned too.
+// Rewards trees are only prunable once the node has claimed the interval, since they're needed to build the claim.
+func GetPrunableIntervals(rp *rocketpool.RocketPool, nodeAddress common.Address, retention uint64) (map[uint64]bool, error) {
+
+	intervals := map[uint64]bool{}
+	if retention == 0 {
+		return intervals, nil
+	}
+
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current reward index: %w", err)
+	}
+	currentIndex := currentIndexBig.Uint64()
+	if currentIndex <= retention {
+		return intervals, nil
+	}
+
+	_, claimed, err := GetClaimStatus(rp, nodeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting claim status: %w", err)
+	}
+	for i := uint64(0); i+retention < currentIndex; i++ {
+		intervals[i] = false
+	}
+	for _, i := range claimed {
+		if _, exists := intervals[i]; exists {
+			intervals[i] = true
+		}
+	}
+
+	return intervals, nil
+
+}
+
+// Get the rewards tree, compressed, and minipool performance files on disk that are older than the retention period
+func GetPrunableRewardsFiles(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, retention uint64, isDaemon bool) ([]PrunableRewardsFile, error) {
+
+	intervals, err := GetPrunableIntervals(rp, nodeAddress, retention)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []PrunableRewardsFile{}
+	for i := uint64(0); i < uint64(len(intervals)); i++ {
+		treePath := cfg.Smartnode.GetRewardsTreePath(i, isDaemon)
+		performancePath := cfg.Smartnode.GetMinipoolPerformancePath(i, isDaemon)
+		paths := []string{
+			performancePath,
+			performancePath + config.RewardsTreeIpfsExtension,
+			treePath + config.RewardsTreeIpfsExtension,
+		}
+		if intervals[i] {
+			paths = append(paths, treePath)
+		}
+
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error checking %s: %w", path, err)
+			}
+			files = append(files, PrunableRewardsFile{
+				Interval: i,
+				Path:     path,
+				Size:     uint64(info.Size()),
+			})
+		}
+	}
+
+	return files, nil
+
+}
+
+// Get the node's rewards from an interval whose rewards tree has been pruned.
+// Returns false if the interval hasn't been pruned for the node.
+func GetPrunedIntervalSummary(cfg *config.RocketPoolConfig, nodeAddress common.Address, interval uint64) (PrunedIntervalSummary, bool, error) {
+	summaries, err := loadPrunedRewardsSummary(cfg.Smartnode.GetPrunedRewardsSummaryPath(true))
+	if err != nil {
+		return PrunedIntervalSummary{}, false, err
+	}
+	summary, exists := summaries[nodeAddress][interval]
+	return summary, exists, nil
+}
+
+// Load the summaries of the pruned intervals
+func loadPrunedRewardsSummary(path string) (prunedRewardsSummary, error) {
+	summaries := prunedRewardsSummary{}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return summaries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading pruned rewards summary %s: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, &summaries)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing pruned rewards summary %s: %w", path, err)
+	}
+	return summaries, nil
+}
+
+// Save the node's rewards from each rewards tree that's about to be pruned, so its lifetime rewards can still be calculated once they're gone
+func savePrunedRewardsSummary(cfg *config.RocketPoolConfig, files []PrunableRewardsFile, nodeAddress common.Address, isDaemon bool) error {
+
+	path := cfg.Smartnode.GetPrunedRewardsSummaryPath(isDaemon)
+	summaries, err := loadPrunedRewardsSummary(path)
+	if err != nil {
+		return err
+	}
+	nodeSummaries, exists := summaries[nodeAddress]
+	if !exists {
+		nodeSummaries = map[uint64]PrunedIntervalSummary{}
+		summaries[nodeAddress] = nodeSummaries
+	}
+
+	updated := false
+	for _, file := range files {
+		if file.Path != cfg.Smartnode.GetRewardsTreePath(file.Interval, isDaemon) {
+			continue
+		}
+		rewardsFile, err := LoadRewardsFile(file.Path)
+		if err != nil {
+			return fmt.Errorf("error loading rewards tree for interval %d: %w", file.Interval, err)
+		}
+		summary := PrunedIntervalSummary{
+			MerkleRoot:             common.HexToHash(rewardsFile.MerkleRoot),
+			CollateralRplAmount:    NewQuotedBigInt(0),
+			ODaoRplAmount:          NewQuotedBigInt(0),
+			SmoothingPoolEthAmount: NewQuotedBigInt(0),
+		}
+		if rewards, exists := rewardsFile.NodeRewards[nodeAddress]; exists {
+			summary.NodeExists = true
+			summary.CollateralRplAmount = rewards.CollateralRpl
+			summary.ODaoRplAmount = rewards.OracleDaoRpl
+			summary.SmoothingPoolEthAmount = rewards.SmoothingPoolEth
+		}
+		nodeSummaries[file.Interval] = summary
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+
+	bytes, err := json.Marshal(summaries)
+	if err != nil {
+		return fmt.Errorf("error serializing pruned rewards summary: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving pruned rewards summary to %s: %w", path, err)
+	}
+	return nil
+
+}
+
+// Delete or archive the provided rewards files.
+// The node's rewards from any rewards trees in them are saved to the pruned rewards summary first.
+func PruneRewardsFiles(cfg *config.RocketPoolConfig, files []PrunableRewardsFile, nodeAddress common.Address, mode cfgtypes.RewardsPruneMode, isDaemon bool) error {
+
+	err := savePrunedRewardsSummary(cfg, files, nodeAddress, isDaemon)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case cfgtypes.RewardsPruneMode_Delete:
+		for _, file := range files {
+			err := os.Remove(file.Path)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("error deleting %s: %w", file.Path, err)
+			}
+		}
+
+	case cfgtypes.RewardsPruneMode_Archive:
+		archiveFolder := cfg.Smartnode.GetRewardsArchiveFolder(isDaemon)
+		err := os.MkdirAll(archiveFolder, 0755)
+		if err != nil {
+			return fmt.Errorf("error creating archive folder %s: %w", archiveFolder, err)
+		}
+		for _, file := range files {
+			err := archiveRewardsFile(file.Path, archiveFolder)
+			if err != nil {
+				return fmt.Errorf("error archiving %s: %w", file.Path, err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("unknown rewards prune mode '%s'", mode)
+	}
+
+	return nil
+
+}
+
+// Move a rewards file into the archive folder, compressing it first if it isn't already compressed
+func archiveRewardsFile(path string, archiveFolder string) error {
+
+	filename := filepath.Base(path)
+	if strings.HasSuffix(filename, config.RewardsTreeIpfsExtension) {
+		return os.Rename(path, filepath.Join(archiveFolder, filename))
+	}
+
+	// Compress the file into the archive
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	archivePath := filepath.Join(archiveFolder, filename+config.RewardsTreeIpfsExtension)
+	target, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	encoder, err := zstd.NewWriter(target)
+	if err != nil {
+		target.Close()
+		return fmt.Errorf("error creating compression encoder: %w", err)
+	}
+	_, err = io.Copy(encoder, source)
+	if err == nil {
+		err = encoder.Close()
+	} else {
+		encoder.Close()
+	}
+	closeErr := target.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archivePath)
+		return err
+	}
+
+	source.Close()
+	return os.Remove(path)
+
+}
@@ -0,0 +1,574 @@
+package rewards
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The binary format is a compact alternative to JSON for storing rewards files locally.
+// Files are written entry-by-entry through a buffered writer, so the whole serialized file never has to be held in memory.
+// Every file starts with a magic prefix and a format version so the loaders can tell it apart from JSON.
+const (
+	binaryFormatVersion uint64 = 1
+
+	// Upper bound on the length of any single field, to catch corrupted files before allocating
+	maxBinaryFieldLength uint64 = 1 << 30
+)
+
+var (
+	rewardsFileBinaryMagic             = []byte("RPRF")
+	minipoolPerformanceFileBinaryMagic = []byte("RPMP")
+)
+
+// Save a rewards file to disk in the format selected in the Smartnode config
+func SaveRewardsFile(cfg *config.RocketPoolConfig, path string, rewardsFile *RewardsFile) error {
+	if cfg.Smartnode.RewardsFileFormat.Value.(cfgtypes.RewardsFileFormat) == cfgtypes.RewardsFileFormat_Binary {
+		return SaveRewardsFileBinary(path, rewardsFile)
+	}
+	wrapperBytes, err := json.Marshal(rewardsFile)
+	if err != nil {
+		return fmt.Errorf("error serializing rewards file into JSON: %w", err)
+	}
+	return os.WriteFile(path, wrapperBytes, 0644)
+}
+
+// Save a minipool performance file to disk in the format selected in the Smartnode config
+func SaveMinipoolPerformanceFile(cfg *config.RocketPoolConfig, path string, performanceFile *MinipoolPerformanceFile) error {
+	if cfg.Smartnode.RewardsFileFormat.Value.(cfgtypes.RewardsFileFormat) == cfgtypes.RewardsFileFormat_Binary {
+		return SaveMinipoolPerformanceFileBinary(path, performanceFile)
+	}
+	performanceBytes, err := json.Marshal(performanceFile)
+	if err != nil {
+		return fmt.Errorf("error serializing minipool performance file into JSON: %w", err)
+	}
+	return os.WriteFile(path, performanceBytes, 0644)
+}
+
+// Save a rewards file to disk in the binary format
+func SaveRewardsFileBinary(path string, rewardsFile *RewardsFile) error {
+	return saveBinaryFile(path, func(w io.Writer) error {
+		return WriteRewardsFileBinary(w, rewardsFile)
+	})
+}
+
+// Save a minipool performance file to disk in the binary format
+func SaveMinipoolPerformanceFileBinary(path string, performanceFile *MinipoolPerformanceFile) error {
+	return saveBinaryFile(path, func(w io.Writer) error {
+		return WriteMinipoolPerformanceFileBinary(w, performanceFile)
+	})
+}
+
+// Load a rewards file from disk, in either the JSON or the binary format; files compressed for IPFS are decompressed first
+func LoadRewardsFile(path string) (*RewardsFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if strings.HasSuffix(path, config.RewardsTreeIpfsExtension) {
+		fileBytes, err = decompressFile(fileBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing %s: %w", path, err)
+		}
+	}
+	rewardsFile, err := ParseRewardsFile(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return rewardsFile, nil
+}
+
+// Parse a serialized rewards file, in either the JSON or the binary format
+func ParseRewardsFile(fileBytes []byte) (*RewardsFile, error) {
+	if IsBinaryRewardsFile(fileBytes) {
+		return ReadRewardsFileBinary(bytes.NewReader(fileBytes))
+	}
+	rewardsFile := new(RewardsFile)
+	err := json.Unmarshal(fileBytes, rewardsFile)
+	if err != nil {
+		return nil, err
+	}
+	return rewardsFile, nil
+}
+
+// Check if serialized rewards file bytes are in the binary format
+func IsBinaryRewardsFile(fileBytes []byte) bool {
+	return bytes.HasPrefix(fileBytes, rewardsFileBinaryMagic)
+}
+
+// Load a minipool performance file from disk, in either the JSON or the binary format; files compressed for IPFS are decompressed first
+func LoadMinipoolPerformanceFile(path string) (*MinipoolPerformanceFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if strings.HasSuffix(path, config.RewardsTreeIpfsExtension) {
+		fileBytes, err = decompressFile(fileBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing %s: %w", path, err)
+		}
+	}
+	performanceFile, err := ParseMinipoolPerformanceFile(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return performanceFile, nil
+}
+
+// Parse a serialized minipool performance file, in either the JSON or the binary format
+func ParseMinipoolPerformanceFile(fileBytes []byte) (*MinipoolPerformanceFile, error) {
+	if IsBinaryMinipoolPerformanceFile(fileBytes) {
+		return ReadMinipoolPerformanceFileBinary(bytes.NewReader(fileBytes))
+	}
+	performanceFile := new(MinipoolPerformanceFile)
+	err := json.Unmarshal(fileBytes, performanceFile)
+	if err != nil {
+		return nil, err
+	}
+	return performanceFile, nil
+}
+
+// Check if serialized minipool performance file bytes are in the binary format
+func IsBinaryMinipoolPerformanceFile(fileBytes []byte) bool {
+	return bytes.HasPrefix(fileBytes, minipoolPerformanceFileBinaryMagic)
+}
+
+// Write a rewards file to the writer in the binary format
+func WriteRewardsFileBinary(w io.Writer, rewardsFile *RewardsFile) error {
+	bw := newBinaryWriter(w, rewardsFileBinaryMagic)
+
+	// Header
+	bw.writeUint64(rewardsFile.RewardsFileVersion)
+	bw.writeUint64(rewardsFile.RulesetVersion)
+	bw.writeUint64(rewardsFile.Index)
+	bw.writeString(rewardsFile.Network)
+	bw.writeTime(rewardsFile.StartTime)
+	bw.writeTime(rewardsFile.EndTime)
+	bw.writeUint64(rewardsFile.ConsensusStartBlock)
+	bw.writeUint64(rewardsFile.ConsensusEndBlock)
+	bw.writeUint64(rewardsFile.ExecutionStartBlock)
+	bw.writeUint64(rewardsFile.ExecutionEndBlock)
+	bw.writeUint64(rewardsFile.IntervalsPassed)
+	bw.writeString(rewardsFile.MerkleRoot)
+	bw.writeString(rewardsFile.MinipoolPerformanceFileCID)
+
+	// Totals
+	totalRewards := rewardsFile.TotalRewards
+	if totalRewards == nil {
+		totalRewards = &TotalRewards{}
+	}
+	bw.writeBigInt(totalRewards.ProtocolDaoRpl)
+	bw.writeBigInt(totalRewards.TotalCollateralRpl)
+	bw.writeBigInt(totalRewards.TotalOracleDaoRpl)
+	bw.writeBigInt(totalRewards.TotalSmoothingPoolEth)
+	bw.writeBigInt(totalRewards.PoolStakerSmoothingPoolEth)
+	bw.writeBigInt(totalRewards.NodeOperatorSmoothingPoolEth)
+
+	// Network rewards, sorted so the output is deterministic
+	networks := make([]uint64, 0, len(rewardsFile.NetworkRewards))
+	for network := range rewardsFile.NetworkRewards {
+		networks = append(networks, network)
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i] < networks[j]
+	})
+	bw.writeUint64(uint64(len(networks)))
+	for _, network := range networks {
+		info := rewardsFile.NetworkRewards[network]
+		bw.writeUint64(network)
+		bw.writeBigInt(info.CollateralRpl)
+		bw.writeBigInt(info.OracleDaoRpl)
+		bw.writeBigInt(info.SmoothingPoolEth)
+	}
+
+	// Node rewards
+	nodes := make([]common.Address, 0, len(rewardsFile.NodeRewards))
+	for address := range rewardsFile.NodeRewards {
+		nodes = append(nodes, address)
+	}
+	sortAddresses(nodes)
+	bw.writeUint64(uint64(len(nodes)))
+	for _, address := range nodes {
+		info := rewardsFile.NodeRewards[address]
+		bw.writeBytes(address.Bytes())
+		bw.writeUint64(info.RewardNetwork)
+		bw.writeBigInt(info.CollateralRpl)
+		bw.writeBigInt(info.OracleDaoRpl)
+		bw.writeBigInt(info.SmoothingPoolEth)
+		bw.writeFloat64(info.SmoothingPoolEligibilityRate)
+		bw.writeUint64(uint64(len(info.MerkleProof)))
+		for _, proofLevel := range info.MerkleProof {
+			bw.writeString(proofLevel)
+		}
+	}
+
+	return bw.flush()
+}
+
+// Read a rewards file in the binary format from the reader
+func ReadRewardsFileBinary(r io.Reader) (*RewardsFile, error) {
+	br, err := newBinaryReader(r, rewardsFileBinaryMagic)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header
+	rewardsFile := &RewardsFile{
+		RewardsFileVersion:         br.readUint64(),
+		RulesetVersion:             br.readUint64(),
+		Index:                      br.readUint64(),
+		Network:                    br.readString(),
+		StartTime:                  br.readTime(),
+		EndTime:                    br.readTime(),
+		ConsensusStartBlock:        br.readUint64(),
+		ConsensusEndBlock:          br.readUint64(),
+		ExecutionStartBlock:        br.readUint64(),
+		ExecutionEndBlock:          br.readUint64(),
+		IntervalsPassed:            br.readUint64(),
+		MerkleRoot:                 br.readString(),
+		MinipoolPerformanceFileCID: br.readString(),
+	}
+
+	// Totals
+	rewardsFile.TotalRewards = &TotalRewards{
+		ProtocolDaoRpl:               br.readBigInt(),
+		TotalCollateralRpl:           br.readBigInt(),
+		TotalOracleDaoRpl:            br.readBigInt(),
+		TotalSmoothingPoolEth:        br.readBigInt(),
+		PoolStakerSmoothingPoolEth:   br.readBigInt(),
+		NodeOperatorSmoothingPoolEth: br.readBigInt(),
+	}
+
+	// Network rewards
+	networkCount := br.readLength()
+	rewardsFile.NetworkRewards = map[uint64]*NetworkRewardsInfo{}
+	for i := uint64(0); i < networkCount && br.err == nil; i++ {
+		network := br.readUint64()
+		rewardsFile.NetworkRewards[network] = &NetworkRewardsInfo{
+			CollateralRpl:    br.readBigInt(),
+			OracleDaoRpl:     br.readBigInt(),
+			SmoothingPoolEth: br.readBigInt(),
+		}
+	}
+
+	// Node rewards
+	nodeCount := br.readLength()
+	rewardsFile.NodeRewards = map[common.Address]*NodeRewardsInfo{}
+	for i := uint64(0); i < nodeCount && br.err == nil; i++ {
+		address := common.BytesToAddress(br.readBytes())
+		info := &NodeRewardsInfo{
+			RewardNetwork:                br.readUint64(),
+			CollateralRpl:                br.readBigInt(),
+			OracleDaoRpl:                 br.readBigInt(),
+			SmoothingPoolEth:             br.readBigInt(),
+			SmoothingPoolEligibilityRate: br.readFloat64(),
+		}
+		proofCount := br.readLength()
+		info.MerkleProof = []string{}
+		for j := uint64(0); j < proofCount && br.err == nil; j++ {
+			info.MerkleProof = append(info.MerkleProof, br.readString())
+		}
+		rewardsFile.NodeRewards[address] = info
+	}
+
+	if br.err != nil {
+		return nil, fmt.Errorf("error reading binary rewards file: %w", br.err)
+	}
+	return rewardsFile, nil
+}
+
+// Write a minipool performance file to the writer in the binary format
+func WriteMinipoolPerformanceFileBinary(w io.Writer, performanceFile *MinipoolPerformanceFile) error {
+	bw := newBinaryWriter(w, minipoolPerformanceFileBinaryMagic)
+
+	// Header
+	bw.writeUint64(performanceFile.Index)
+	bw.writeString(performanceFile.Network)
+	bw.writeTime(performanceFile.StartTime)
+	bw.writeTime(performanceFile.EndTime)
+	bw.writeUint64(performanceFile.ConsensusStartBlock)
+	bw.writeUint64(performanceFile.ConsensusEndBlock)
+	bw.writeUint64(performanceFile.ExecutionStartBlock)
+	bw.writeUint64(performanceFile.ExecutionEndBlock)
+
+	// Minipool performance
+	minipools := make([]common.Address, 0, len(performanceFile.MinipoolPerformance))
+	for address := range performanceFile.MinipoolPerformance {
+		minipools = append(minipools, address)
+	}
+	sortAddresses(minipools)
+	bw.writeUint64(uint64(len(minipools)))
+	for _, address := range minipools {
+		performance := performanceFile.MinipoolPerformance[address]
+		bw.writeBytes(address.Bytes())
+		bw.writeString(performance.Pubkey)
+		bw.writeUint64(performance.StartSlot)
+		bw.writeUint64(performance.EndSlot)
+		bw.writeFloat64(performance.ActiveFraction)
+		bw.writeUint64(performance.SuccessfulAttestations)
+		bw.writeUint64(performance.MissedAttestations)
+		bw.writeFloat64(performance.ParticipationRate)
+		bw.writeUint64(uint64(len(performance.MissingAttestationSlots)))
+		for _, slot := range performance.MissingAttestationSlots {
+			bw.writeUint64(slot)
+		}
+		bw.writeFloat64(performance.EthEarned)
+	}
+
+	return bw.flush()
+}
+
+// Read a minipool performance file in the binary format from the reader
+func ReadMinipoolPerformanceFileBinary(r io.Reader) (*MinipoolPerformanceFile, error) {
+	br, err := newBinaryReader(r, minipoolPerformanceFileBinaryMagic)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header
+	performanceFile := &MinipoolPerformanceFile{
+		Index:               br.readUint64(),
+		Network:             br.readString(),
+		StartTime:           br.readTime(),
+		EndTime:             br.readTime(),
+		ConsensusStartBlock: br.readUint64(),
+		ConsensusEndBlock:   br.readUint64(),
+		ExecutionStartBlock: br.readUint64(),
+		ExecutionEndBlock:   br.readUint64(),
+	}
+
+	// Minipool performance
+	minipoolCount := br.readLength()
+	performanceFile.MinipoolPerformance = map[common.Address]*SmoothingPoolMinipoolPerformance{}
+	for i := uint64(0); i < minipoolCount && br.err == nil; i++ {
+		address := common.BytesToAddress(br.readBytes())
+		performance := &SmoothingPoolMinipoolPerformance{
+			Pubkey:                 br.readString(),
+			StartSlot:              br.readUint64(),
+			EndSlot:                br.readUint64(),
+			ActiveFraction:         br.readFloat64(),
+			SuccessfulAttestations: br.readUint64(),
+			MissedAttestations:     br.readUint64(),
+			ParticipationRate:      br.readFloat64(),
+		}
+		slotCount := br.readLength()
+		performance.MissingAttestationSlots = []uint64{}
+		for j := uint64(0); j < slotCount && br.err == nil; j++ {
+			performance.MissingAttestationSlots = append(performance.MissingAttestationSlots, br.readUint64())
+		}
+		performance.EthEarned = br.readFloat64()
+		performanceFile.MinipoolPerformance[address] = performance
+	}
+
+	if br.err != nil {
+		return nil, fmt.Errorf("error reading binary minipool performance file: %w", br.err)
+	}
+	return performanceFile, nil
+}
+
+// Create a file and stream serialized data into it
+func saveBinaryFile(path string, write func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	err = write(file)
+	closeErr := file.Close()
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("error closing %s: %w", path, closeErr)
+	}
+	return nil
+}
+
+// Sort addresses so the output is deterministic
+func sortAddresses(addresses []common.Address) {
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+}
+
+// Writes primitive values in the binary format, holding onto the first error
+type binaryWriter struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func newBinaryWriter(w io.Writer, magic []byte) *binaryWriter {
+	bw := &binaryWriter{
+		w: bufio.NewWriter(w),
+	}
+	bw.write(magic)
+	bw.writeUint64(binaryFormatVersion)
+	return bw
+}
+
+func (bw *binaryWriter) write(data []byte) {
+	if bw.err != nil {
+		return
+	}
+	_, bw.err = bw.w.Write(data)
+}
+
+func (bw *binaryWriter) writeUint64(value uint64) {
+	length := binary.PutUvarint(bw.buf[:], value)
+	bw.write(bw.buf[:length])
+}
+
+func (bw *binaryWriter) writeFloat64(value float64) {
+	binary.LittleEndian.PutUint64(bw.buf[:8], math.Float64bits(value))
+	bw.write(bw.buf[:8])
+}
+
+func (bw *binaryWriter) writeBytes(value []byte) {
+	bw.writeUint64(uint64(len(value)))
+	bw.write(value)
+}
+
+func (bw *binaryWriter) writeString(value string) {
+	bw.writeBytes([]byte(value))
+}
+
+// Big integers are written as a sign byte followed by their magnitude; nil is written as an empty magnitude
+func (bw *binaryWriter) writeBigInt(value *QuotedBigInt) {
+	if value == nil {
+		bw.write([]byte{0})
+		bw.writeBytes(nil)
+		return
+	}
+	var sign byte
+	if value.Sign() < 0 {
+		sign = 1
+	}
+	bw.write([]byte{sign})
+	bw.writeBytes(value.Bytes())
+}
+
+func (bw *binaryWriter) writeTime(value time.Time) {
+	timeBytes, err := value.MarshalBinary()
+	if err != nil {
+		if bw.err == nil {
+			bw.err = err
+		}
+		return
+	}
+	bw.writeBytes(timeBytes)
+}
+
+func (bw *binaryWriter) flush() error {
+	if bw.err != nil {
+		return bw.err
+	}
+	return bw.w.Flush()
+}
+
+// Reads primitive values in the binary format, holding onto the first error
+type binaryReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func newBinaryReader(r io.Reader, magic []byte) (*binaryReader, error) {
+	br := &binaryReader{
+		r: bufio.NewReader(r),
+	}
+	header := make([]byte, len(magic))
+	_, err := io.ReadFull(br.r, header)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file header: %w", err)
+	}
+	if !bytes.Equal(header, magic) {
+		return nil, fmt.Errorf("file header [%x] does not match the expected header [%x]", header, magic)
+	}
+	version := br.readUint64()
+	if br.err != nil {
+		return nil, fmt.Errorf("error reading file version: %w", br.err)
+	}
+	if version != binaryFormatVersion {
+		return nil, fmt.Errorf("unsupported binary format version %d", version)
+	}
+	return br, nil
+}
+
+func (br *binaryReader) read(length uint64) []byte {
+	if br.err != nil {
+		return nil
+	}
+	data := make([]byte, length)
+	_, br.err = io.ReadFull(br.r, data)
+	return data
+}
+
+func (br *binaryReader) readUint64() uint64 {
+	if br.err != nil {
+		return 0
+	}
+	var value uint64
+	value, br.err = binary.ReadUvarint(br.r)
+	return value
+}
+
+func (br *binaryReader) readLength() uint64 {
+	length := br.readUint64()
+	if br.err == nil && length > maxBinaryFieldLength {
+		br.err = fmt.Errorf("length %d exceeds the maximum of %d", length, maxBinaryFieldLength)
+		return 0
+	}
+	return length
+}
+
+func (br *binaryReader) readFloat64() float64 {
+	data := br.read(8)
+	if br.err != nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(data))
+}
+
+func (br *binaryReader) readBytes() []byte {
+	length := br.readLength()
+	return br.read(length)
+}
+
+func (br *binaryReader) readString() string {
+	return string(br.readBytes())
+}
+
+func (br *binaryReader) readBigInt() *QuotedBigInt {
+	sign := br.read(1)
+	magnitude := br.readBytes()
+	if br.err != nil {
+		return nil
+	}
+	value := &QuotedBigInt{}
+	value.SetBytes(magnitude)
+	if sign[0] == 1 {
+		value.Neg(&value.Int)
+	}
+	return value
+}
+
+func (br *binaryReader) readTime() time.Time {
+	timeBytes := br.readBytes()
+	if br.err != nil {
+		return time.Time{}
+	}
+	var value time.Time
+	br.err = value.UnmarshalBinary(timeBytes)
+	return value
+}
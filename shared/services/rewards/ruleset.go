@@ -0,0 +1,133 @@
+package rewards
+
+import (
+	"fmt"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A version of the rules used to calculate an interval's rewards.
+// Each ruleset activates at a fixed interval on each network and is used for every interval until the next one activates,
+// so any past interval can be regenerated with exactly the rules it was originally calculated with.
+type rewardsRuleset struct {
+	// The version recorded in the rewards files generated with this ruleset
+	version uint64
+
+	// The first interval that uses this ruleset on each network
+	startIntervals map[cfgtypes.Network]uint64
+
+	// Create the implementation of this ruleset for a tree generator
+	newImpl func(t *TreeGenerator) treeGeneratorImpl
+}
+
+// Every rewards ruleset, in order of activation.
+// New rulesets are appended with the interval they activate at; existing entries must never change once their start
+// interval has passed, or the trees of older intervals could no longer be regenerated identically.
+var rewardsRulesets = []rewardsRuleset{
+	{
+		version: 1,
+		startIntervals: map[cfgtypes.Network]uint64{
+			cfgtypes.Network_Mainnet: 0,
+			cfgtypes.Network_Prater:  0,
+			cfgtypes.Network_Devnet:  0,
+		},
+		newImpl: func(t *TreeGenerator) treeGeneratorImpl {
+			return newTreeGeneratorImpl_v1(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed)
+		},
+	}, {
+		version: 2,
+		startIntervals: map[cfgtypes.Network]uint64{
+			cfgtypes.Network_Mainnet: MainnetV2Interval,
+			cfgtypes.Network_Prater:  PraterV2Interval,
+			cfgtypes.Network_Devnet:  0,
+		},
+		newImpl: func(t *TreeGenerator) treeGeneratorImpl {
+			return newTreeGeneratorImpl_v2(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed)
+		},
+	}, {
+		version: 3,
+		startIntervals: map[cfgtypes.Network]uint64{
+			cfgtypes.Network_Mainnet: MainnetV3Interval,
+			cfgtypes.Network_Prater:  PraterV3Interval,
+			cfgtypes.Network_Devnet:  0,
+		},
+		newImpl: func(t *TreeGenerator) treeGeneratorImpl {
+			return newTreeGeneratorImpl_v3(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed)
+		},
+	}, {
+		version: 4,
+		startIntervals: map[cfgtypes.Network]uint64{
+			cfgtypes.Network_Mainnet: MainnetV4Interval,
+			cfgtypes.Network_Prater:  PraterV4Interval,
+			cfgtypes.Network_Devnet:  0,
+		},
+		newImpl: func(t *TreeGenerator) treeGeneratorImpl {
+			return newTreeGeneratorImpl_v4(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed)
+		},
+	}, {
+		version: 5,
+		startIntervals: map[cfgtypes.Network]uint64{
+			cfgtypes.Network_Mainnet: MainnetV5Interval,
+			cfgtypes.Network_Prater:  PraterV5Interval,
+			cfgtypes.Network_Devnet:  0,
+		},
+		newImpl: func(t *TreeGenerator) treeGeneratorImpl {
+			return newTreeGeneratorImpl_v5(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.consensusBlock, t.elSnapshotHeader, t.intervalsPassed, t.state)
+		},
+	},
+}
+
+// Get the version of the ruleset used to calculate the rewards of an interval on the given network
+func GetRulesetVersion(network cfgtypes.Network, index uint64) (uint64, error) {
+	if err := checkRewardsRulesets(); err != nil {
+		return 0, err
+	}
+
+	version := uint64(0)
+	for _, ruleset := range rewardsRulesets {
+		startInterval, exists := ruleset.startIntervals[network]
+		if !exists {
+			return 0, fmt.Errorf("unknown network: %s", string(network))
+		}
+		if index >= startInterval {
+			version = ruleset.version
+		}
+	}
+	return version, nil
+}
+
+// Get the ruleset with the given version
+func getRewardsRuleset(version uint64) (rewardsRuleset, error) {
+	for _, ruleset := range rewardsRulesets {
+		if ruleset.version == version {
+			return ruleset, nil
+		}
+	}
+	return rewardsRuleset{}, fmt.Errorf("ruleset v%d does not exist", version)
+}
+
+// Make sure the rulesets are in order, so every interval maps to exactly one of them
+func checkRewardsRulesets() error {
+	for i, ruleset := range rewardsRulesets {
+		if ruleset.version != uint64(i+1) {
+			return fmt.Errorf("ruleset v%d is out of order; expected v%d", ruleset.version, i+1)
+		}
+		if i == 0 {
+			continue
+		}
+		previous := rewardsRulesets[i-1]
+		for network, startInterval := range ruleset.startIntervals {
+			previousStartInterval, exists := previous.startIntervals[network]
+			if !exists {
+				return fmt.Errorf("ruleset v%d has no start interval for network %s", previous.version, string(network))
+			}
+			if startInterval < previousStartInterval {
+				return fmt.Errorf("ruleset v%d starts at interval %d on %s, before ruleset v%d at interval %d", ruleset.version, startInterval, string(network), previous.version, previousStartInterval)
+			}
+		}
+		if len(ruleset.startIntervals) != len(previous.startIntervals) {
+			return fmt.Errorf("rulesets v%d and v%d don't have start intervals for the same networks", previous.version, ruleset.version)
+		}
+	}
+	return nil
+}
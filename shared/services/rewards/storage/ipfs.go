@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Timeout for requests to the IPFS node
+const ipfsRequestTimeout time.Duration = 5 * time.Minute
+
+// Uploads and pins files to an IPFS node through its HTTP API
+type ipfsUploader struct {
+	apiUrl string
+	client *http.Client
+}
+
+// An entry in the response to an IPFS add request
+type ipfsAddResponse struct {
+	Name string `json:"Name"`
+	Hash string `json:"Hash"`
+}
+
+func newIpfsUploader(apiUrl string) (*ipfsUploader, error) {
+	if apiUrl == "" {
+		return nil, fmt.Errorf("you have not configured the URL of your IPFS node's API yet; please enter it in the Smartnode section of the `service config` TUI (or use `--smartnode-ipfsApiUrl` if you configure your system headlessly)")
+	}
+	return &ipfsUploader{
+		apiUrl: strings.TrimSuffix(apiUrl, "/"),
+		client: &http.Client{
+			Timeout: ipfsRequestTimeout,
+		},
+	}, nil
+}
+
+func (u *ipfsUploader) GetName() string {
+	return "IPFS node"
+}
+
+func (u *ipfsUploader) Upload(path string) (string, error) {
+	// Build the multipart body with the file
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", path, err)
+	}
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return "", fmt.Errorf("error creating upload request: %w", err)
+	}
+	_, err = part.Write(fileBytes)
+	if err != nil {
+		return "", fmt.Errorf("error creating upload request: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return "", fmt.Errorf("error creating upload request: %w", err)
+	}
+
+	// Wrap the file in a directory and use CIDv1 so it can be retrieved from subdomain gateways like the other backends
+	url := fmt.Sprintf("%s/api/v0/add?pin=true&wrap-with-directory=true&cid-version=1", u.apiUrl)
+	resp, err := u.client.Post(url, writer.FormDataContentType(), body)
+	if err != nil {
+		return "", fmt.Errorf("error uploading to IPFS node: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("IPFS node returned code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	// The response is one JSON object per added entry; the wrapping directory has an empty name
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var entry ipfsAddResponse
+		err = decoder.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("error decoding IPFS node response: %w", err)
+		}
+		if entry.Name == "" {
+			return entry.Hash, nil
+		}
+	}
+	return "", fmt.Errorf("IPFS node response did not include the CID of the wrapping directory")
+}
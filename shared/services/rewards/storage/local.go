@@ -0,0 +1,17 @@
+package storage
+
+// Doesn't upload files anywhere, just calculates their CIDs.
+// The Oracle DAO member is responsible for making them available on IPFS.
+type localUploader struct{}
+
+func newLocalUploader() *localUploader {
+	return &localUploader{}
+}
+
+func (u *localUploader) GetName() string {
+	return "local storage"
+}
+
+func (u *localUploader) Upload(path string) (string, error) {
+	return getLocalCid(path)
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	pinRequestTimeout     time.Duration = time.Minute
+	pinataPinByHashUrl    string        = "https://api.pinata.cloud/pinning/pinByHash"
+	gatewayRequestTimeout time.Duration = 2 * time.Minute
+	gatewayRetryCount     int           = 5
+	gatewayRetryDelay     time.Duration = 30 * time.Second
+)
+
+// A service that pins an existing CID so it stays available on IPFS
+type Pinner interface {
+	// The name of the service, used in log messages
+	GetName() string
+
+	// Pin the CID, using the given name as a label if the service supports one
+	Pin(cid string, name string) error
+}
+
+// Create pinners for all of the additional pinning services in the Smartnode config
+func NewPinners(cfg *config.RocketPoolConfig) []Pinner {
+	client := &http.Client{
+		Timeout: pinRequestTimeout,
+	}
+	pinners := []Pinner{}
+	if jwt := cfg.Smartnode.PinataJwt.Value.(string); jwt != "" {
+		pinners = append(pinners, &pinataPinner{
+			client: client,
+			jwt:    jwt,
+		})
+	}
+	if clusterUrl := cfg.Smartnode.IpfsClusterUrl.Value.(string); clusterUrl != "" {
+		pinners = append(pinners, &clusterPinner{
+			client: client,
+			apiUrl: strings.TrimSuffix(clusterUrl, "/"),
+		})
+	}
+	return pinners
+}
+
+// Pins CIDs with Pinata
+type pinataPinner struct {
+	client *http.Client
+	jwt    string
+}
+
+func (p *pinataPinner) GetName() string {
+	return "Pinata"
+}
+
+func (p *pinataPinner) Pin(cid string, name string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"hashToPin": cid,
+		"pinataMetadata": map[string]string{
+			"name": name,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing pin request: %w", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, pinataPinByHashUrl, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating pin request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+p.jwt)
+	return sendPinRequest(p.client, request)
+}
+
+// Pins CIDs with an IPFS Cluster through its REST API
+type clusterPinner struct {
+	client *http.Client
+	apiUrl string
+}
+
+func (p *clusterPinner) GetName() string {
+	return "IPFS Cluster"
+}
+
+func (p *clusterPinner) Pin(cid string, name string) error {
+	pinUrl := fmt.Sprintf("%s/pins/%s?name=%s", p.apiUrl, url.PathEscape(cid), url.QueryEscape(name))
+	request, err := http.NewRequest(http.MethodPost, pinUrl, nil)
+	if err != nil {
+		return fmt.Errorf("error creating pin request: %w", err)
+	}
+	return sendPinRequest(p.client, request)
+}
+
+// Send a pin request and check the response code
+func sendPinRequest(client *http.Client, request *http.Request) error {
+	resp, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("request failed with code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// Check that a file can be downloaded from a public IPFS gateway, retrying while it propagates.
+// The gateway URL format takes the CID and the filename.
+func VerifyRetrievable(gatewayUrlFormat string, cid string, filename string, expectedSize int, logger func(string)) error {
+	client := &http.Client{
+		Timeout: gatewayRequestTimeout,
+	}
+	fileUrl := fmt.Sprintf(gatewayUrlFormat, cid, filename)
+
+	var err error
+	for attempt := 1; attempt <= gatewayRetryCount; attempt++ {
+		err = downloadFromGateway(client, fileUrl, expectedSize)
+		if err == nil {
+			return nil
+		}
+		if attempt < gatewayRetryCount {
+			logger(fmt.Sprintf("Couldn't retrieve %s yet (attempt %d of %d): %s. Retrying in %s...", fileUrl, attempt, gatewayRetryCount, err.Error(), gatewayRetryDelay))
+			time.Sleep(gatewayRetryDelay)
+		}
+	}
+	return fmt.Errorf("couldn't retrieve %s after %d attempts: %w", fileUrl, gatewayRetryCount, err)
+}
+
+// Download a file from a gateway and check its size
+func downloadFromGateway(client *http.Client, fileUrl string, expectedSize int) error {
+	resp, err := client.Get(fileUrl)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gateway returned code %d", resp.StatusCode)
+	}
+	size, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if size != int64(expectedSize) {
+		return fmt.Errorf("gateway returned %d bytes but the file has %d bytes", size, expectedSize)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	s3RequestTimeout time.Duration = 5 * time.Minute
+	s3Service        string        = "s3"
+	s3Algorithm      string        = "AWS4-HMAC-SHA256"
+	s3SignedHeaders  string        = "host;x-amz-content-sha256;x-amz-date"
+)
+
+// Uploads files to an S3-compatible object storage bucket.
+// The CIDs are calculated locally; the Oracle DAO member is responsible for making the files available on IPFS.
+type s3Uploader struct {
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+}
+
+func newS3Uploader(endpoint string, region string, bucket string, accessKeyID string, secretAccessKey string) (*s3Uploader, error) {
+	if endpoint == "" || bucket == "" || accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("you have not finished configuring S3 storage yet; please enter the endpoint, bucket, and access key in the Smartnode section of the `service config` TUI")
+	}
+	endpointUrl, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint [%s]: %w", endpoint, err)
+	}
+	if endpointUrl.Scheme == "" || endpointUrl.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint [%s]: it must include the scheme and host, such as https://s3.us-east-1.amazonaws.com", endpoint)
+	}
+
+	return &s3Uploader{
+		endpoint:        endpointUrl,
+		region:          region,
+		bucket:          bucket,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		client: &http.Client{
+			Timeout: s3RequestTimeout,
+		},
+	}, nil
+}
+
+func (u *s3Uploader) GetName() string {
+	return "S3"
+}
+
+func (u *s3Uploader) Upload(path string) (string, error) {
+	cid, err := getLocalCid(path)
+	if err != nil {
+		return "", err
+	}
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	// Store the file under its CID so the bucket mirrors the IPFS gateway layout
+	objectPath := fmt.Sprintf("%s/%s/%s/%s", u.endpoint.Path, url.PathEscape(u.bucket), url.PathEscape(cid), url.PathEscape(filepath.Base(path)))
+	request, err := http.NewRequest(http.MethodPut, u.endpoint.Scheme+"://"+u.endpoint.Host+objectPath, bytes.NewReader(fileBytes))
+	if err != nil {
+		return "", fmt.Errorf("error creating S3 request: %w", err)
+	}
+	u.signRequest(request, objectPath, fileBytes, time.Now().UTC())
+
+	resp, err := u.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("error uploading to S3: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("S3 returned code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return cid, nil
+}
+
+// Sign a request with AWS Signature Version 4
+func (u *s3Uploader) signRequest(request *http.Request, objectPath string, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+	request.Header.Set("x-amz-date", amzDate)
+	request.Header.Set("x-amz-content-sha256", payloadHash)
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		objectPath,
+		"",
+		fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", u.endpoint.Host, payloadHash, amzDate),
+		s3SignedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, u.region, s3Service)
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSha256([]byte("AWS4"+u.secretAccessKey), date)
+	signingKey = hmacSha256(signingKey, u.region)
+	signingKey = hmacSha256(signingKey, s3Service)
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", s3Algorithm, u.accessKeyID, scope, s3SignedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
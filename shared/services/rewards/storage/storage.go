@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	bserv "github.com/ipfs/go-blockservice"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/ipfs/go-merkledag"
+	"github.com/klauspost/compress/zstd"
+	"github.com/web3-storage/go-w3s-client/adder"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A backend that the Oracle DAO can store rewards files in
+type Uploader interface {
+	// The name of the backend, used in log messages
+	GetName() string
+
+	// Upload a file and get the IPFS CID of the directory that wraps it
+	Upload(path string) (string, error)
+}
+
+// Create the uploader for the storage backend selected in the Smartnode config
+func NewUploader(cfg *config.RocketPoolConfig) (Uploader, error) {
+	mode := cfg.Smartnode.RewardsStorageMode.Value.(cfgtypes.RewardsStorageMode)
+	switch mode {
+	case cfgtypes.RewardsStorageMode_Web3Storage:
+		return newWeb3StorageUploader(cfg.Smartnode.Web3StorageApiToken.Value.(string))
+	case cfgtypes.RewardsStorageMode_Ipfs:
+		return newIpfsUploader(cfg.Smartnode.IpfsApiUrl.Value.(string))
+	case cfgtypes.RewardsStorageMode_S3:
+		return newS3Uploader(
+			cfg.Smartnode.S3Endpoint.Value.(string),
+			cfg.Smartnode.S3Region.Value.(string),
+			cfg.Smartnode.S3Bucket.Value.(string),
+			cfg.Smartnode.S3AccessKeyID.Value.(string),
+			cfg.Smartnode.S3SecretAccessKey.Value.(string),
+		)
+	case cfgtypes.RewardsStorageMode_Local:
+		return newLocalUploader(), nil
+	default:
+		return nil, fmt.Errorf("unknown rewards storage mode [%v]", mode)
+	}
+}
+
+// Check if the storage backend selected in the Smartnode config publishes rewards files to IPFS, so they can be retrieved from a public gateway
+func PublishesToIpfs(cfg *config.RocketPoolConfig) bool {
+	switch cfg.Smartnode.RewardsStorageMode.Value.(cfgtypes.RewardsStorageMode) {
+	case cfgtypes.RewardsStorageMode_Web3Storage, cfgtypes.RewardsStorageMode_Ipfs:
+		return true
+	default:
+		return false
+	}
+}
+
+// Calculate the CID of the directory that wraps a file, the same way Web3.Storage does, without uploading it
+func getLocalCid(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	datastore := dssync.MutexWrap(ds.NewMapDatastore())
+	dag := merkledag.NewDAGService(bserv.New(blockstore.NewBlockstore(datastore), nil))
+	dagFormatter, err := adder.NewAdder(context.Background(), dag)
+	if err != nil {
+		return "", fmt.Errorf("error creating DAG builder: %w", err)
+	}
+	root, err := dagFormatter.Add(file, "", nil)
+	if err != nil {
+		return "", fmt.Errorf("error calculating CID for %s: %w", path, err)
+	}
+	return root.String(), nil
+}
+
+// Compress a file, upload it to the storage backend selected in the Smartnode config, and pin it to the additional
+// pinning services, returning its CID.
+// The compressed file is saved to the given path, which is the one that's uploaded.
+func UploadFile(cfg *config.RocketPoolConfig, fileBytes []byte, compressedPath string, description string, logger func(string)) (string, error) {
+
+	// Create the uploader
+	uploader, err := NewUploader(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	// Compress the file
+	encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	compressedBytes := encoder.EncodeAll(fileBytes, make([]byte, 0, len(fileBytes)))
+
+	// Write the compressed data to the file
+	err = os.WriteFile(compressedPath, compressedBytes, 0644)
+	if err != nil {
+		return "", fmt.Errorf("Error writing %s to %s: %w", description, compressedPath, err)
+	}
+
+	// Upload it
+	logger(fmt.Sprintf("Uploading %s to %s...", description, uploader.GetName()))
+	cid, err := uploader.Upload(compressedPath)
+	if err != nil {
+		return "", fmt.Errorf("Error uploading %s to %s: %w", description, uploader.GetName(), err)
+	}
+
+	// Pin it to the additional pinning services for redundancy
+	filename := filepath.Base(compressedPath)
+	for _, pinner := range NewPinners(cfg) {
+		err = pinner.Pin(cid, filename)
+		if err != nil {
+			logger(fmt.Sprintf("WARNING: couldn't pin %s to %s: %s", description, pinner.GetName(), err.Error()))
+		} else {
+			logger(fmt.Sprintf("Pinned %s to %s.", description, pinner.GetName()))
+		}
+	}
+
+	// Make sure it can be retrieved before its CID is used, if the backend publishes it to IPFS
+	if cfg.Smartnode.VerifyRewardsRetrievability.Value == true && PublishesToIpfs(cfg) {
+		logger(fmt.Sprintf("Verifying that the %s can be retrieved from a public gateway...", description))
+		err = VerifyRetrievable(config.PrimaryRewardsFileUrl, cid, filename, len(compressedBytes), logger)
+		if err != nil {
+			return "", fmt.Errorf("Error verifying %s is retrievable: %w", description, err)
+		}
+	}
+
+	return cid, nil
+
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/web3-storage/go-w3s-client"
+)
+
+// Uploads files to Web3.Storage
+type web3StorageUploader struct {
+	client w3s.Client
+}
+
+func newWeb3StorageUploader(apiToken string) (*web3StorageUploader, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("***ERROR***\nYou have not configured your Web3.Storage API token yet, so you cannot submit Merkle rewards trees.\nPlease get an API token from https://web3.storage and enter it in the Smartnode section of the `service config` TUI (or use `--smartnode-web3StorageApiToken` if you configure your system headlessly).")
+	}
+
+	client, err := w3s.NewClient(w3s.WithToken(apiToken))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating new Web3.Storage client: %w", err)
+	}
+	return &web3StorageUploader{
+		client: client,
+	}, nil
+}
+
+func (u *web3StorageUploader) GetName() string {
+	return "Web3.Storage"
+}
+
+func (u *web3StorageUploader) Upload(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	cid, err := u.client.Put(context.Background(), file)
+	if err != nil {
+		return "", err
+	}
+	return cid.String(), nil
+}
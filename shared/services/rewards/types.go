@@ -15,8 +15,10 @@ type IntervalInfo struct {
 	Index                  uint64        `json:"index"`
 	TreeFilePath           string        `json:"treeFilePath"`
 	TreeFileExists         bool          `json:"treeFileExists"`
+	IsPruned               bool          `json:"isPruned"`
 	MerkleRootValid        bool          `json:"merkleRootValid"`
 	CID                    string        `json:"cid"`
+	MerkleRoot             common.Hash   `json:"merkleRoot"`
 	StartTime              time.Time     `json:"startTime"`
 	EndTime                time.Time     `json:"endTime"`
 	NodeExists             bool          `json:"nodeExists"`
@@ -0,0 +1,48 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// The result of independently regenerating the rewards tree for an interval and comparing it to the canonical one
+type RewardsTreeVerification struct {
+	Index               uint64    `json:"index"`
+	CanonicalMerkleRoot string    `json:"canonicalMerkleRoot,omitempty"`
+	GeneratedMerkleRoot string    `json:"generatedMerkleRoot,omitempty"`
+	Matches             bool      `json:"matches"`
+	VerifiedTime        time.Time `json:"verifiedTime"`
+	Error               string    `json:"error,omitempty"`
+}
+
+// Save the result of a rewards tree verification to disk
+func SaveRewardsTreeVerification(path string, verification *RewardsTreeVerification) error {
+	bytes, err := json.Marshal(verification)
+	if err != nil {
+		return fmt.Errorf("error serializing verification for interval %d: %w", verification.Index, err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving verification for interval %d to %s: %w", verification.Index, path, err)
+	}
+	return nil
+}
+
+// Load the result of a rewards tree verification from disk; returns nil if the interval hasn't been verified yet
+func LoadRewardsTreeVerification(path string) (*RewardsTreeVerification, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	verification := new(RewardsTreeVerification)
+	err = json.Unmarshal(bytes, verification)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return verification, nil
+}
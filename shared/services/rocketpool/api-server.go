@@ -0,0 +1,182 @@
+package rocketpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+const (
+	ApiServerUnixPrefix string = "unix://"
+	apiServerUnixHost   string = "http://rocketpool-api"
+)
+
+// The connection to a daemon's API server, used instead of docker exec when set
+type apiServerConnection struct {
+	baseUrl    string
+	token      string
+	httpClient *http.Client
+}
+
+// A request body for the API server
+type apiServerRequest struct {
+	Version     uint64            `json:"version,omitempty"`
+	Args        []string          `json:"args"`
+	GlobalFlags map[string]string `json:"globalFlags"`
+}
+
+// Create a new Rocket Pool client that calls a daemon's API server (see `rocketpool api server`) instead of running API commands through docker exec.
+// The address is either a Unix socket path prefixed with unix:// or an HTTP URL, and the token is the contents of the daemon's api-token file.
+func NewApiServerClient(address string, token string, debug bool) (*Client, error) {
+	connection := &apiServerConnection{
+		token: token,
+	}
+	if strings.HasPrefix(address, ApiServerUnixPrefix) {
+		socketPath := strings.TrimPrefix(address, ApiServerUnixPrefix)
+		connection.baseUrl = apiServerUnixHost
+		connection.httpClient = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		}
+	} else {
+		connection.baseUrl = strings.TrimSuffix(address, "/")
+		connection.httpClient = &http.Client{}
+	}
+
+	client, err := NewClient("", "", 0, 0, 0, "", debug)
+	if err != nil {
+		return nil, err
+	}
+	client.apiServer = connection
+
+	// Make sure the server's response types match this client's
+	version, err := client.ApiServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	if version.SchemaVersion != api.ResponseSchemaVersion {
+		return nil, fmt.Errorf("API server uses response schema version %d but this client requires version %d (API server is running Smartnode %s)", version.SchemaVersion, api.ResponseSchemaVersion, version.SmartnodeVersion)
+	}
+	return client, nil
+}
+
+// Get the versions of the API server
+func (c *Client) ApiServerVersion() (api.ApiVersionResponse, error) {
+	if c.apiServer == nil {
+		return api.ApiVersionResponse{}, fmt.Errorf("Client is not connected to an API server")
+	}
+	responseBytes, err := c.apiServer.post("/version", apiServerRequest{})
+	if err != nil {
+		return api.ApiVersionResponse{}, fmt.Errorf("Could not get API server version: %w", err)
+	}
+	var response api.ApiVersionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ApiVersionResponse{}, fmt.Errorf("Could not decode API server version response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ApiVersionResponse{}, fmt.Errorf("Could not get API server version: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Call the API server with the same args that would be passed to `rocketpool api`
+func (c *Client) callApiServer(args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("No API command provided")
+	}
+
+	// The wait command is the only one at the root; the others are all under a module
+	pathLength := 2
+	if args[0] == "wait" {
+		pathLength = 1
+	}
+	if len(args) < pathLength {
+		return nil, fmt.Errorf("Invalid API command: %s", strings.Join(args, " "))
+	}
+
+	request := apiServerRequest{
+		Version: api.GetCommandVersion(args[:pathLength]),
+		Args:    args[pathLength:],
+		GlobalFlags: map[string]string{
+			"maxFee":     strconv.FormatFloat(c.maxFee, 'f', -1, 64),
+			"maxPrioFee": strconv.FormatFloat(c.maxPrioFee, 'f', -1, 64),
+			"gasLimit":   strconv.FormatUint(c.gasLimit, 10),
+		},
+	}
+	if c.customNonce != nil {
+		request.GlobalFlags["nonce"] = c.customNonce.String()
+	}
+	if c.ignoreSyncCheck || c.forceFallbacks {
+		return nil, fmt.Errorf("The --ignore-sync-check and --force-fallbacks flags can't be used with the API server, since it shares its clients between commands")
+	}
+	if c.noCache {
+		request.GlobalFlags["no-cache"] = "true"
+	}
+
+	path := "/" + strings.Join(args[:pathLength], "/")
+	if c.debugPrint {
+		fmt.Println("To API server:")
+		fmt.Println(path, request.Args)
+	}
+	output, err := c.apiServer.post(path, request)
+	if c.debugPrint {
+		if output != nil {
+			fmt.Println("API Out:")
+			fmt.Println(string(output))
+		}
+		if err != nil {
+			fmt.Println("API Err:")
+			fmt.Println(err.Error())
+		}
+	}
+
+	// Reset the gas settings after the call
+	c.maxFee = c.originalMaxFee
+	c.maxPrioFee = c.originalMaxPrioFee
+	c.gasLimit = c.originalGasLimit
+
+	return output, err
+}
+
+// Send a request to the API server
+func (s *apiServerConnection) post(path string, request apiServerRequest) ([]byte, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode API server request: %w", err)
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, s.baseUrl+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("Could not create API server request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	response, err := s.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("Could not reach API server: %w", err)
+	}
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read API server response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API server returned %s: %s", response.Status, strings.TrimSpace(string(responseBytes)))
+	}
+	return responseBytes, nil
+}
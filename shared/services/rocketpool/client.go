@@ -53,6 +53,7 @@ const (
 
 	templateSuffix    string = ".tmpl"
 	composeFileSuffix string = ".yml"
+	shutdownFileName  string = "shutdown"
 
 	nethermindPruneStarterCommand string = "dotnet /setup/NethermindPruneStarter/NethermindPruneStarter.dll"
 	nethermindAdminUrl            string = "http://127.0.0.1:7434"
@@ -92,17 +93,61 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	noCache            bool
+	apiServer          *apiServerConnection
+	whenGasBelow       float64
+	txQueueDeadline    time.Duration
+	queueTransactions  bool
 }
 
 // Create new Rocket Pool client from CLI context
 func NewClientFromCtx(c *cli.Context) (*Client, error) {
-	return NewClient(c.GlobalString("config-path"),
+	client, err := NewClient(c.GlobalString("config-path"),
 		c.GlobalString("daemon-path"),
 		c.GlobalFloat64("maxFee"),
 		c.GlobalFloat64("maxPrioFee"),
 		c.GlobalUint64("gasLimit"),
 		c.GlobalString("nonce"),
 		c.GlobalBool("debug"))
+	if err != nil {
+		return nil, err
+	}
+	client.noCache = c.GlobalBool("no-cache")
+	client.whenGasBelow = c.GlobalFloat64("when-gas-below")
+	if client.whenGasBelow < 0 {
+		return nil, fmt.Errorf("Invalid --when-gas-below value %f - must not be negative", client.whenGasBelow)
+	}
+	deadline := c.GlobalString("deadline")
+	if deadline == "" {
+		deadline = DefaultTxQueueDeadline
+	}
+	client.txQueueDeadline, err = time.ParseDuration(deadline)
+	if err != nil || client.txQueueDeadline <= 0 {
+		return nil, fmt.Errorf("Invalid --deadline value '%s' - must be a positive duration such as 48h", deadline)
+	}
+	client.applyConfirmationPolicy(c)
+	return client, nil
+}
+
+// Ignore the --yes flag for commands that the wallet policy says always need interactive confirmation
+func (c *Client) applyConfirmationPolicy(ctx *cli.Context) {
+	if !ctx.Bool("yes") {
+		return
+	}
+	cfg, isNew, err := c.LoadConfig()
+	if err != nil || isNew {
+		return
+	}
+
+	// Subcommand apps are named after their parent commands, such as "rocketpool node"
+	command := strings.Join(append(strings.Fields(ctx.App.Name)[1:], ctx.Command.Name), " ")
+	if !cfg.Smartnode.RequiresConfirmation(command) {
+		return
+	}
+	if err := ctx.Set("yes", "false"); err != nil {
+		return
+	}
+	fmt.Printf("%sYour wallet policy requires interactive confirmation for `rocketpool %s`, so --yes will be ignored.%s\n\n", colorYellow, command, colorReset)
 }
 
 // Create new Rocket Pool client
@@ -189,6 +234,37 @@ func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
 	return rp.SaveConfig(cfg, expandedPath)
 }
 
+// Load the settings file as it's saved, without upgrading it
+func (c *Client) LoadSettings() (map[string]map[string]string, error) {
+	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
+	expandedPath, err := homedir.Expand(settingsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return config.LoadSettingsFromFile(expandedPath)
+}
+
+// Copy the settings file to a backup file in the config folder, returning the backup's path
+func (c *Client) BackupSettings(backupFile string) (string, error) {
+	settingsFilePath, err := homedir.Expand(filepath.Join(c.configPath, SettingsFile))
+	if err != nil {
+		return "", fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	backupFilePath, err := homedir.Expand(filepath.Join(c.configPath, backupFile))
+	if err != nil {
+		return "", fmt.Errorf("error expanding backup file path: %w", err)
+	}
+	bytes, err := os.ReadFile(settingsFilePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading settings file: %w", err)
+	}
+	err = os.WriteFile(backupFilePath, bytes, 0664)
+	if err != nil {
+		return "", fmt.Errorf("error writing settings backup: %w", err)
+	}
+	return backupFilePath, nil
+}
+
 // Remove the upgrade flag file
 func (c *Client) RemoveUpgradeFlagFile() error {
 	expandedPath, err := homedir.Expand(c.configPath)
@@ -1346,8 +1422,13 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 		externalIP = ip.String()
 	}
 
-	// Set up environment variables and deploy the template config files
-	settings := cfg.GenerateEnvironmentVariables()
+	// Set up environment variables and deploy the template config files, giving the containers the real values of any secrets
+	resolvedCfg := cfg.CreateCopy()
+	err = resolvedCfg.ResolveSecrets(false)
+	if err != nil {
+		return "", err
+	}
+	settings := resolvedCfg.GenerateEnvironmentVariables()
 	settings["EXTERNAL_IP"] = shellescape.Quote(externalIP)
 
 	// Deploy the templates and run environment variable substitution on them
@@ -1442,7 +1523,6 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		return []string{}, fmt.Errorf("could not write node container file to %s: %w", nodeComposePath, err)
 	}
 	deployedContainers = append(deployedContainers, nodeComposePath)
-	deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.NodeContainerName+composeFileSuffix))
 
 	// Watchtower
 	contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.WatchtowerContainerName+templateSuffix))
@@ -1455,6 +1535,18 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		return []string{}, fmt.Errorf("could not write watchtower container file to %s: %w", watchtowerComposePath, err)
 	}
 	deployedContainers = append(deployedContainers, watchtowerComposePath)
+
+	// Give the node and watchtower containers long enough to drain their work when they're stopped.
+	// Their override files come after this so the user can still change the grace period.
+	gracePeriod := cfg.Smartnode.ShutdownGracePeriod.Value.(uint64)
+	contents = []byte(fmt.Sprintf("services:\n  %s:\n    stop_grace_period: %ds\n  %s:\n    stop_grace_period: %ds\n", config.NodeContainerName, gracePeriod, config.WatchtowerContainerName, gracePeriod))
+	shutdownComposePath := filepath.Join(runtimeFolder, shutdownFileName+composeFileSuffix)
+	err = os.WriteFile(shutdownComposePath, contents, 0664)
+	if err != nil {
+		return []string{}, fmt.Errorf("could not write shutdown settings file to %s: %w", shutdownComposePath, err)
+	}
+	deployedContainers = append(deployedContainers, shutdownComposePath)
+	deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.NodeContainerName+composeFileSuffix))
 	deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.WatchtowerContainerName+composeFileSuffix))
 
 	// Validator
@@ -1618,6 +1710,19 @@ func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir strin
 
 // Call the Rocket Pool API
 func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+	// Queue transactions for the node daemon instead of submitting them if requested
+	if c.queueTransactions {
+		fields := append(strings.Fields(args), otherArgs...)
+		if isTransactionCommand(fields) {
+			return c.queueTransaction(fields)
+		}
+	}
+
+	// Use the API server if connected to one
+	if c.apiServer != nil {
+		return c.callApiServer(append(strings.Fields(args), otherArgs...))
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
@@ -1628,13 +1733,14 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getNoCacheFlag(), c.getGasOpts(), c.getCustomNonce(), args)
 	} else {
-		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s %s api %s",
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
 			ignoreSyncCheckFlag,
 			forceFallbackECFlag,
+			c.getNoCacheFlag(),
 			c.getGasOpts(),
 			c.getCustomNonce(),
 			args)
@@ -1661,18 +1767,19 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getNoCacheFlag(), c.getGasOpts(), c.getCustomNonce(), args)
 	} else {
 		envArgs := ""
 		for key, value := range envVars {
 			envArgs += fmt.Sprintf("%s=%s ", key, shellescape.Quote(value))
 		}
-		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s %s api %s",
 			envArgs,
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
 			ignoreSyncCheckFlag,
 			forceFallbackECFlag,
+			c.getNoCacheFlag(),
 			c.getGasOpts(),
 			c.getCustomNonce(),
 			args)
@@ -1757,6 +1864,14 @@ func (c *Client) getGasOpts() string {
 	return opts
 }
 
+// Get the flag that makes the daemon skip the cached network state, if requested
+func (c *Client) getNoCacheFlag() string {
+	if c.noCache {
+		return "--no-cache"
+	}
+	return ""
+}
+
 func (c *Client) getCustomNonce() string {
 	// Set the custom nonce
 	nonce := ""
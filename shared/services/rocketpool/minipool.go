@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -503,3 +504,39 @@ func (c *Client) ChangeWithdrawalCredentials(address common.Address, mnemonic st
 	}
 	return response, nil
 }
+
+// Get the node's minipools whose validators still use BLS withdrawal creds
+func (c *Client) GetBlsCredsMinipools() (api.GetBlsCredsMinipoolsResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-bls-creds-minipools")
+	if err != nil {
+		return api.GetBlsCredsMinipoolsResponse{}, fmt.Errorf("Could not get minipools with BLS withdrawal creds: %w", err)
+	}
+	var response api.GetBlsCredsMinipoolsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetBlsCredsMinipoolsResponse{}, fmt.Errorf("Could not decode get-bls-creds-minipools response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetBlsCredsMinipoolsResponse{}, fmt.Errorf("Could not get minipools with BLS withdrawal creds: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Change the BLS withdrawal creds of minipool validators to their minipool addresses
+func (c *Client) RotateWithdrawalCredentials(addresses []common.Address, mnemonic string) (api.RotateWithdrawalCredsResponse, error) {
+	addressStrings := make([]string, len(addresses))
+	for i, address := range addresses {
+		addressStrings[i] = address.Hex()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool rotate-withdrawal-creds %s", strings.Join(addressStrings, ",")), mnemonic)
+	if err != nil {
+		return api.RotateWithdrawalCredsResponse{}, fmt.Errorf("Could not rotate withdrawal creds: %w", err)
+	}
+	var response api.RotateWithdrawalCredsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RotateWithdrawalCredsResponse{}, fmt.Errorf("Could not decode rotate-withdrawal-creds response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RotateWithdrawalCredsResponse{}, fmt.Errorf("Could not rotate withdrawal creds: %s", response.Error)
+	}
+	return response, nil
+}
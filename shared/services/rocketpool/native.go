@@ -0,0 +1,141 @@
+package rocketpool
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/native"
+)
+
+// Get the systemd services for the Native mode config
+func (c *Client) GetNativeServices(cfg *config.RocketPoolConfig) ([]native.Service, error) {
+
+	// Cancel if running in docker mode
+	if c.daemonPath == "" {
+		return nil, errors.New("command only available in Native Mode (with '--daemon-path' option specified)")
+	}
+
+	// Get the absolute paths the daemons will be run with
+	daemonPath, err := filepath.Abs(c.daemonPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting absolute daemon path: %w", err)
+	}
+	configPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+	configPath, err = filepath.Abs(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting absolute config path: %w", err)
+	}
+
+	return native.GetServices(cfg, shellescape.Quote(daemonPath), shellescape.Quote(filepath.Join(configPath, SettingsFile))), nil
+
+}
+
+// Write the systemd units for the Native mode services, install them into the system unit folder, and enable them so they
+// start on boot. Returns the paths of the installed units.
+func (c *Client) InstallNativeServices(cfg *config.RocketPoolConfig) ([]string, error) {
+
+	// Get the services
+	services, err := c.GetNativeServices(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the units into the config folder so they can be reviewed
+	configPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+	unitsDir, err := filepath.Abs(filepath.Join(configPath, native.UnitsDir))
+	if err != nil {
+		return nil, fmt.Errorf("error getting absolute systemd unit folder path: %w", err)
+	}
+	paths, err := native.WriteUnits(services, unitsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy them into the system unit folder, owned by root; linking them from the config folder would let anyone who can
+	// write to it change what runs as root, and would break if the folder isn't mounted when systemd starts.
+	// Any existing unit is removed first in case it's a link from a previous install.
+	installedPaths := make([]string, len(paths))
+	for i, path := range paths {
+		installedPaths[i] = filepath.Join(native.SystemUnitDir, filepath.Base(path))
+		err = c.printOutput(fmt.Sprintf("sudo rm -f %s && sudo install -m 0644 -o root -g root %s %s", shellescape.Quote(installedPaths[i]), shellescape.Quote(path), shellescape.Quote(installedPaths[i])))
+		if err != nil {
+			return nil, fmt.Errorf("error installing systemd unit %s: %w", filepath.Base(path), err)
+		}
+	}
+
+	// Reload systemd and enable them
+	err = c.printOutput("sudo systemctl daemon-reload")
+	if err != nil {
+		return nil, fmt.Errorf("error reloading systemd: %w", err)
+	}
+	err = c.printOutput(fmt.Sprintf("sudo systemctl enable %s", strings.Join(native.GetUnitNames(services), " ")))
+	if err != nil {
+		return nil, fmt.Errorf("error enabling systemd services: %w", err)
+	}
+
+	return installedPaths, nil
+
+}
+
+// Start the Native mode services
+func (c *Client) StartNativeServices(cfg *config.RocketPoolConfig) error {
+	return c.runNativeServiceCommand(cfg, "start")
+}
+
+// Stop the Native mode services
+func (c *Client) StopNativeServices(cfg *config.RocketPoolConfig) error {
+	return c.runNativeServiceCommand(cfg, "stop")
+}
+
+// Get the state of each Native mode service, by unit name
+func (c *Client) GetNativeServiceStatus(cfg *config.RocketPoolConfig) (map[string]string, error) {
+
+	// Get the services
+	services, err := c.GetNativeServices(cfg)
+	if err != nil {
+		return nil, err
+	}
+	units := native.GetUnitNames(services)
+
+	// systemctl exits with an error if any of the units aren't active, so ignore it and parse the states instead
+	output, err := c.readOutput(fmt.Sprintf("systemctl is-active %s || true", strings.Join(units, " ")))
+	if err != nil {
+		return nil, fmt.Errorf("error getting systemd service status: %w", err)
+	}
+	states := strings.Fields(string(output))
+	if len(states) != len(units) {
+		return nil, fmt.Errorf("could not parse systemd service status from output '%s'", string(output))
+	}
+
+	status := map[string]string{}
+	for i, unit := range units {
+		status[unit] = states[i]
+	}
+	return status, nil
+
+}
+
+// Run a systemctl command on all of the Native mode services
+func (c *Client) runNativeServiceCommand(cfg *config.RocketPoolConfig, action string) error {
+	services, err := c.GetNativeServices(cfg)
+	if err != nil {
+		return err
+	}
+	err = c.printOutput(fmt.Sprintf("sudo systemctl %s %s", action, strings.Join(native.GetUnitNames(services), " ")))
+	if err != nil {
+		return fmt.Errorf("error running systemctl %s: %w", action, err)
+	}
+	return nil
+}
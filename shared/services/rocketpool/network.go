@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -71,6 +72,22 @@ func (c *Client) NetworkStats() (api.NetworkStatsResponse, error) {
 	return response, nil
 }
 
+// Get the loaded Rocket Pool deployment and the status of its contracts
+func (c *Client) NetworkDeploymentStatus() (api.NetworkDeploymentStatusResponse, error) {
+	responseBytes, err := c.callAPI("network deployment-status")
+	if err != nil {
+		return api.NetworkDeploymentStatusResponse{}, fmt.Errorf("Could not get deployment status: %w", err)
+	}
+	var response api.NetworkDeploymentStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkDeploymentStatusResponse{}, fmt.Errorf("Could not decode deployment status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkDeploymentStatusResponse{}, fmt.Errorf("Could not get deployment status: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the timezone map
 func (c *Client) TimezoneMap() (api.NetworkTimezonesResponse, error) {
 	responseBytes, err := c.callAPI("network timezone-map")
@@ -104,17 +121,81 @@ func (c *Client) CanGenerateRewardsTree(index uint64) (api.CanNetworkGenerateRew
 }
 
 // Set a request marker for the watchtower to generate the rewards tree for the given interval
+func (c *Client) RequestRewardsTree(index uint64) (api.NetworkRequestRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network request-rewards-tree %d", index))
+	if err != nil {
+		return api.NetworkRequestRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %w", err)
+	}
+	var response api.NetworkRequestRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkRequestRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree generation response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkRequestRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Generate the rewards tree for the given interval in the API container, waiting until it's done
 func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("network generate-rewards-tree %d", index))
 	if err != nil {
-		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %w", err)
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not generate rewards tree: %w", err)
 	}
 	var response api.NetworkGenerateRewardsTreeResponse
 	if err := json.Unmarshal(responseBytes, &response); err != nil {
 		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree generation response: %w", err)
 	}
 	if response.Error != "" {
-		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %s", response.Error)
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not generate rewards tree: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check if the rewards tree for the provided interval can be verified, and get the result of its last verification
+func (c *Client) CanVerifyRewardsTree(index uint64) (api.CanNetworkVerifyRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network can-verify-rewards-tree %d", index))
+	if err != nil {
+		return api.CanNetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not check rewards tree verification status: %w", err)
+	}
+	var response api.CanNetworkVerifyRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree verification status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not check rewards tree verification status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set a request marker for the watchtower to verify the rewards tree for the given interval
+func (c *Client) VerifyRewardsTree(index uint64) (api.NetworkVerifyRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network verify-rewards-tree %d", index))
+	if err != nil {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree verification: %w", err)
+	}
+	var response api.NetworkVerifyRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree verification response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree verification: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get a minipool's performance for the given interval from its minipool performance file
+func (c *Client) GetMinipoolPerformance(index uint64, minipoolAddress common.Address) (api.NetworkMinipoolPerformanceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network get-minipool-performance %d %s", index, minipoolAddress.Hex()))
+	if err != nil {
+		return api.NetworkMinipoolPerformanceResponse{}, fmt.Errorf("Could not get minipool performance: %w", err)
+	}
+	var response api.NetworkMinipoolPerformanceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkMinipoolPerformanceResponse{}, fmt.Errorf("Could not decode minipool performance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkMinipoolPerformanceResponse{}, fmt.Errorf("Could not get minipool performance: %s", response.Error)
 	}
 	return response, nil
 }
@@ -168,6 +249,22 @@ func (c *Client) IsAtlasDeployed() (api.IsAtlasDeployedResponse, error) {
 }
 
 // Get the address of the latest minipool delegate contract
+// Get the fee tiers suggested by the gas oracle for the next block
+func (c *Client) GetGasOracle() (api.NetworkGasOracleResponse, error) {
+	responseBytes, err := c.callAPI("network gas-oracle")
+	if err != nil {
+		return api.NetworkGasOracleResponse{}, fmt.Errorf("could not get gas oracle fees: %w", err)
+	}
+	var response api.NetworkGasOracleResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkGasOracleResponse{}, fmt.Errorf("could not decode gas-oracle response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkGasOracleResponse{}, fmt.Errorf("could not get gas oracle fees: %s", response.Error)
+	}
+	return response, nil
+}
+
 func (c *Client) GetLatestDelegate() (api.GetLatestDelegateResponse, error) {
 	responseBytes, err := c.callAPI("network latest-delegate")
 	if err != nil {
@@ -182,3 +279,51 @@ func (c *Client) GetLatestDelegate() (api.GetLatestDelegateResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the deposit pool and minipool queue status, including the rETH exchange rate over the last few days
+func (c *Client) NetworkQueueStatus(days uint64) (api.NetworkQueueStatusResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network queue-status %d", days))
+	if err != nil {
+		return api.NetworkQueueStatusResponse{}, fmt.Errorf("could not get queue status: %w", err)
+	}
+	var response api.NetworkQueueStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkQueueStatusResponse{}, fmt.Errorf("could not decode queue-status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkQueueStatusResponse{}, fmt.Errorf("could not get queue status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the names of the saved network state snapshots
+func (c *Client) GetStateSnapshots() (api.StateSnapshotsResponse, error) {
+	responseBytes, err := c.callAPI("network state-snapshots")
+	if err != nil {
+		return api.StateSnapshotsResponse{}, fmt.Errorf("could not get state snapshots: %w", err)
+	}
+	var response api.StateSnapshotsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.StateSnapshotsResponse{}, fmt.Errorf("could not decode state-snapshots response: %w", err)
+	}
+	if response.Error != "" {
+		return api.StateSnapshotsResponse{}, fmt.Errorf("could not get state snapshots: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Compare two saved network state snapshots
+func (c *Client) DiffStateSnapshots(from string, to string) (api.StateSnapshotDiffResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network diff-state-snapshots %s %s", from, to))
+	if err != nil {
+		return api.StateSnapshotDiffResponse{}, fmt.Errorf("could not compare state snapshots: %w", err)
+	}
+	var response api.StateSnapshotDiffResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.StateSnapshotDiffResponse{}, fmt.Errorf("could not decode diff-state-snapshots response: %w", err)
+	}
+	if response.Error != "" {
+		return api.StateSnapshotDiffResponse{}, fmt.Errorf("could not compare state snapshots: %s", response.Error)
+	}
+	return response, nil
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -62,6 +63,22 @@ func (c *Client) NodeStatus() (api.NodeStatusResponse, error) {
 	return response, nil
 }
 
+// Run the node registration preflight checks
+func (c *Client) NodePreflight(timezoneLocation string) (api.NodePreflightResponse, error) {
+	responseBytes, err := c.callAPI("node preflight", timezoneLocation)
+	if err != nil {
+		return api.NodePreflightResponse{}, fmt.Errorf("Could not run node preflight checks: %w", err)
+	}
+	var response api.NodePreflightResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodePreflightResponse{}, fmt.Errorf("Could not decode node preflight response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodePreflightResponse{}, fmt.Errorf("Could not run node preflight checks: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can be registered
 func (c *Client) CanRegisterNode(timezoneLocation string) (api.CanRegisterNodeResponse, error) {
 	responseBytes, err := c.callAPI("node can-register", timezoneLocation)
@@ -478,6 +495,54 @@ func (c *Client) NodeSend(amountWei *big.Int, token string, toAddress common.Add
 	return response, nil
 }
 
+// Check whether the node can sweep its ETH balance to its withdrawal address
+func (c *Client) CanNodeSweep(reserveWei *big.Int) (api.CanNodeSweepResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-sweep %s", reserveWei.String()))
+	if err != nil {
+		return api.CanNodeSweepResponse{}, fmt.Errorf("Could not get can node sweep status: %w", err)
+	}
+	var response api.CanNodeSweepResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNodeSweepResponse{}, fmt.Errorf("Could not decode can node sweep response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNodeSweepResponse{}, fmt.Errorf("Could not get can node sweep status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sweep the node's ETH balance above the reserve to its withdrawal address
+func (c *Client) NodeSweep(reserveWei *big.Int) (api.NodeSweepResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node sweep %s", reserveWei.String()))
+	if err != nil {
+		return api.NodeSweepResponse{}, fmt.Errorf("Could not sweep node ETH: %w", err)
+	}
+	var response api.NodeSweepResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSweepResponse{}, fmt.Errorf("Could not decode node sweep response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSweepResponse{}, fmt.Errorf("Could not sweep node ETH: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast an externally-signed transaction through the daemon's execution client
+func (c *Client) SendRawTransaction(serializedTx string) (api.NodeSendRawTransactionResponse, error) {
+	responseBytes, err := c.callAPI("node send-raw-tx", serializedTx)
+	if err != nil {
+		return api.NodeSendRawTransactionResponse{}, fmt.Errorf("Could not send raw transaction: %w", err)
+	}
+	var response api.NodeSendRawTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSendRawTransactionResponse{}, fmt.Errorf("Could not decode send raw transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSendRawTransactionResponse{}, fmt.Errorf("Could not send raw transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can burn tokens
 func (c *Client) CanNodeBurn(amountWei *big.Int, token string) (api.CanNodeBurnResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-burn %s %s", amountWei.String(), token))
@@ -622,6 +687,40 @@ func (c *Client) SetSnapshotDelegate(address common.Address) (api.SetSnapshotDel
 	return response, nil
 }
 
+// Get the node's current voting snapshot delegate
+func (c *Client) GetSnapshotDelegate() (api.GetSnapshotDelegateResponse, error) {
+	responseBytes, err := c.callAPI("node get-snapshot-delegate")
+	if err != nil {
+		return api.GetSnapshotDelegateResponse{}, fmt.Errorf("Could not get get-snapshot-delegate response: %w", err)
+	}
+	var response api.GetSnapshotDelegateResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetSnapshotDelegateResponse{}, fmt.Errorf("Could not decode get-snapshot-delegate response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetSnapshotDelegateResponse{}, fmt.Errorf("Could not get get-snapshot-delegate response: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sign an off-chain Snapshot vote with the node private key
+func (c *Client) SignSnapshotVote(proposalId common.Hash, choice uint64) (api.SignSnapshotVoteResponse, error) {
+	// Ignore sync status so we can sign votes even without ready clients
+	c.ignoreSyncCheck = true
+	responseBytes, err := c.callAPI(fmt.Sprintf("node sign-snapshot-vote %s %d", proposalId.Hex(), choice))
+	if err != nil {
+		return api.SignSnapshotVoteResponse{}, fmt.Errorf("Could not sign Snapshot vote: %w", err)
+	}
+	var response api.SignSnapshotVoteResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SignSnapshotVoteResponse{}, fmt.Errorf("Could not decode sign-snapshot-vote response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SignSnapshotVoteResponse{}, fmt.Errorf("Could not sign Snapshot vote: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to clear the node's voting snapshot delegate
 func (c *Client) EstimateClearSnapshotDelegateGas() (api.EstimateClearSnapshotDelegateGasResponse, error) {
 	responseBytes, err := c.callAPI("node estimate-clear-snapshot-delegate-gas")
@@ -926,6 +1025,25 @@ func (c *Client) SignMessage(message string) (api.NodeSignResponse, error) {
 	return response, nil
 }
 
+// Use the node private key to sign an EIP-712 typed data payload
+func (c *Client) SignTypedData(typedDataJson string) (api.NodeSignResponse, error) {
+	// Ignore sync status so we can sign messages even without ready clients
+	c.ignoreSyncCheck = true
+	responseBytes, err := c.callAPI("node sign-typed-data", typedDataJson)
+	if err != nil {
+		return api.NodeSignResponse{}, fmt.Errorf("Could not sign typed data: %w", err)
+	}
+
+	var response api.NodeSignResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSignResponse{}, fmt.Errorf("Could not decode node sign response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSignResponse{}, fmt.Errorf("Could not sign typed data: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a vacant minipool can be created for solo staker migration
 func (c *Client) CanCreateVacantMinipool(amountWei *big.Int, minFee float64, salt *big.Int, pubkey types.ValidatorPubkey) (api.CanCreateVacantMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-create-vacant-minipool %s %f %s %s", amountWei.String(), minFee, salt.String(), pubkey.Hex()))
@@ -974,6 +1092,22 @@ func (c *Client) CheckCollateral() (api.CheckCollateralResponse, error) {
 	return response, nil
 }
 
+// Get the node's collateral details and the RPL required to reach the standard and custom collateral targets
+func (c *Client) GetCollateralInfo(customTargetPercent float64) (api.NodeCollateralInfoResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-collateral-info %f", customTargetPercent))
+	if err != nil {
+		return api.NodeCollateralInfoResponse{}, fmt.Errorf("Could not get collateral info: %w", err)
+	}
+	var response api.NodeCollateralInfoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeCollateralInfoResponse{}, fmt.Errorf("Could not decode collateral info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeCollateralInfoResponse{}, fmt.Errorf("Could not get collateral info: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the ETH balance of the node address
 func (c *Client) GetEthBalance() (api.NodeEthBalanceResponse, error) {
 	responseBytes, err := c.callAPI("node get-eth-balance")
@@ -989,3 +1123,99 @@ func (c *Client) GetEthBalance() (api.NodeEthBalanceResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the transactions the daemon has submitted, newest first
+func (c *Client) NodeTxHistory(limit uint64) (api.NodeTxHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node tx-history %d", limit))
+	if err != nil {
+		return api.NodeTxHistoryResponse{}, fmt.Errorf("Could not get transaction history: %w", err)
+	}
+	var response api.NodeTxHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTxHistoryResponse{}, fmt.Errorf("Could not decode transaction history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTxHistoryResponse{}, fmt.Errorf("Could not get transaction history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the rewards the node earned in each of the last intervals (0 for all of them)
+func (c *Client) NodeRewardsReport(intervals uint64) (api.NodeRewardsReportResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node rewards-report %d", intervals))
+	if err != nil {
+		return api.NodeRewardsReportResponse{}, fmt.Errorf("Could not get rewards report: %w", err)
+	}
+	var response api.NodeRewardsReportResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRewardsReportResponse{}, fmt.Errorf("Could not decode rewards report response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRewardsReportResponse{}, fmt.Errorf("Could not get rewards report: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Queue an API command for the node daemon to run once the base fee drops below the given max base fee (in gwei)
+func (c *Client) NodeQueueTx(maxBaseFee float64, deadline time.Duration, args []string) (api.NodeQueueTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node queue-tx %f %s", maxBaseFee, deadline.String()), args...)
+	if err != nil {
+		return api.NodeQueueTxResponse{}, fmt.Errorf("Could not queue transaction: %w", err)
+	}
+	var response api.NodeQueueTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeQueueTxResponse{}, fmt.Errorf("Could not decode queue transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeQueueTxResponse{}, fmt.Errorf("Could not queue transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the API commands queued to run once the base fee drops, newest first
+func (c *Client) NodeTxQueue() (api.NodeTxQueueResponse, error) {
+	responseBytes, err := c.callAPI("node get-tx-queue")
+	if err != nil {
+		return api.NodeTxQueueResponse{}, fmt.Errorf("Could not get transaction queue: %w", err)
+	}
+	var response api.NodeTxQueueResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTxQueueResponse{}, fmt.Errorf("Could not decode transaction queue response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTxQueueResponse{}, fmt.Errorf("Could not get transaction queue: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel a queued API command that hasn't run yet
+func (c *Client) NodeCancelQueuedTx(id uint64) (api.NodeCancelQueuedTxResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-queued-tx %d", id))
+	if err != nil {
+		return api.NodeCancelQueuedTxResponse{}, fmt.Errorf("Could not cancel queued transaction: %w", err)
+	}
+	var response api.NodeCancelQueuedTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeCancelQueuedTxResponse{}, fmt.Errorf("Could not decode cancel queued transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeCancelQueuedTxResponse{}, fmt.Errorf("Could not cancel queued transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the status of a transaction the daemon has submitted
+func (c *Client) NodeTxStatus(hash common.Hash) (api.NodeTxStatusResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node tx-status %s", hash.Hex()))
+	if err != nil {
+		return api.NodeTxStatusResponse{}, fmt.Errorf("Could not get transaction status: %w", err)
+	}
+	var response api.NodeTxStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTxStatusResponse{}, fmt.Errorf("Could not decode transaction status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTxStatusResponse{}, fmt.Errorf("Could not get transaction status: %s", response.Error)
+	}
+	return response, nil
+}
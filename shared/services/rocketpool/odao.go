@@ -26,6 +26,54 @@ func (c *Client) TNDAOStatus() (api.TNDAOStatusResponse, error) {
 	return response, nil
 }
 
+// Get the status of the watchtower's oracle DAO duties
+func (c *Client) TNDAODutyStatus() (api.TNDAODutyStatusResponse, error) {
+	responseBytes, err := c.callAPI("odao duty-status")
+	if err != nil {
+		return api.TNDAODutyStatusResponse{}, fmt.Errorf("Could not get oracle DAO duty status: %w", err)
+	}
+	var response api.TNDAODutyStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAODutyStatusResponse{}, fmt.Errorf("Could not decode oracle DAO duty status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAODutyStatusResponse{}, fmt.Errorf("Could not get oracle DAO duty status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the values the watchtower has submitted, and any discrepancies with them it has found since
+func (c *Client) TNDAOSubmissionRecords() (api.TNDAOSubmissionRecordsResponse, error) {
+	responseBytes, err := c.callAPI("odao submission-records")
+	if err != nil {
+		return api.TNDAOSubmissionRecordsResponse{}, fmt.Errorf("Could not get watchtower submission records: %w", err)
+	}
+	var response api.TNDAOSubmissionRecordsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOSubmissionRecordsResponse{}, fmt.Errorf("Could not decode watchtower submission records response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOSubmissionRecordsResponse{}, fmt.Errorf("Could not get watchtower submission records: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Have the watchtower submit the current network balances even if they deviate from other oracle DAO members
+func (c *Client) TNDAOForceBalanceSubmission() (api.TNDAOForceBalanceSubmissionResponse, error) {
+	responseBytes, err := c.callAPI("odao force-balance-submission")
+	if err != nil {
+		return api.TNDAOForceBalanceSubmissionResponse{}, fmt.Errorf("Could not force balance submission: %w", err)
+	}
+	var response api.TNDAOForceBalanceSubmissionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOForceBalanceSubmissionResponse{}, fmt.Errorf("Could not decode force balance submission response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOForceBalanceSubmissionResponse{}, fmt.Errorf("Could not force balance submission: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get oracle DAO members
 func (c *Client) TNDAOMembers() (api.TNDAOMembersResponse, error) {
 	responseBytes, err := c.callAPI("odao members")
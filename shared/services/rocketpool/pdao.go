@@ -0,0 +1,228 @@
+package rocketpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the node's protocol DAO voting status
+func (c *Client) PDAOStatus() (api.PDAOStatusResponse, error) {
+	responseBytes, err := c.callAPI("pdao status")
+	if err != nil {
+		return api.PDAOStatusResponse{}, fmt.Errorf("Could not get protocol DAO status: %w", err)
+	}
+	var response api.PDAOStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOStatusResponse{}, fmt.Errorf("Could not decode protocol DAO status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOStatusResponse{}, fmt.Errorf("Could not get protocol DAO status: %s", response.Error)
+	}
+	if response.VotingPower == nil {
+		response.VotingPower = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Get the protocol DAO proposals
+func (c *Client) PDAOProposals() (api.PDAOProposalsResponse, error) {
+	responseBytes, err := c.callAPI("pdao proposals")
+	if err != nil {
+		return api.PDAOProposalsResponse{}, fmt.Errorf("Could not get protocol DAO proposals: %w", err)
+	}
+	var response api.PDAOProposalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOProposalsResponse{}, fmt.Errorf("Could not decode protocol DAO proposals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOProposalsResponse{}, fmt.Errorf("Could not get protocol DAO proposals: %s", response.Error)
+	}
+	for i := 0; i < len(response.Proposals); i++ {
+		proposal := &response.Proposals[i]
+		for _, votingPower := range []**big.Int{&proposal.VotingPowerRequired, &proposal.VotingPowerFor, &proposal.VotingPowerAgainst, &proposal.VotingPowerToVeto, &proposal.VotingPowerVeto, &proposal.VotingPowerAbstained} {
+			if *votingPower == nil {
+				*votingPower = big.NewInt(0)
+			}
+		}
+	}
+	return response, nil
+}
+
+// Get the details of a protocol DAO proposal
+func (c *Client) PDAOProposalDetails(proposalId uint64) (api.PDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao proposal-details %d", proposalId))
+	if err != nil {
+		return api.PDAOProposalResponse{}, fmt.Errorf("Could not get protocol DAO proposal: %w", err)
+	}
+	var response api.PDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOProposalResponse{}, fmt.Errorf("Could not decode protocol DAO proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOProposalResponse{}, fmt.Errorf("Could not get protocol DAO proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can initialize its on-chain voting power
+func (c *Client) CanInitializePDAOVoting() (api.CanInitializePDAOVotingResponse, error) {
+	responseBytes, err := c.callAPI("pdao can-initialize-voting")
+	if err != nil {
+		return api.CanInitializePDAOVotingResponse{}, fmt.Errorf("Could not get can initialize voting status: %w", err)
+	}
+	var response api.CanInitializePDAOVotingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanInitializePDAOVotingResponse{}, fmt.Errorf("Could not decode can initialize voting response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanInitializePDAOVotingResponse{}, fmt.Errorf("Could not get can initialize voting status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Initialize the node's on-chain voting power
+func (c *Client) InitializePDAOVoting() (api.InitializePDAOVotingResponse, error) {
+	responseBytes, err := c.callAPI("pdao initialize-voting")
+	if err != nil {
+		return api.InitializePDAOVotingResponse{}, fmt.Errorf("Could not initialize voting: %w", err)
+	}
+	var response api.InitializePDAOVotingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.InitializePDAOVotingResponse{}, fmt.Errorf("Could not decode initialize voting response: %w", err)
+	}
+	if response.Error != "" {
+		return api.InitializePDAOVotingResponse{}, fmt.Errorf("Could not initialize voting: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can propose changing a protocol DAO setting
+func (c *Client) CanProposePDAOSetting(contractName string, settingName string, settingType string, value string) (api.CanProposePDAOSettingResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao can-propose-setting %s %s %s %s", contractName, settingName, settingType, value))
+	if err != nil {
+		return api.CanProposePDAOSettingResponse{}, fmt.Errorf("Could not get can propose setting status: %w", err)
+	}
+	var response api.CanProposePDAOSettingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanProposePDAOSettingResponse{}, fmt.Errorf("Could not decode can propose setting response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanProposePDAOSettingResponse{}, fmt.Errorf("Could not get can propose setting status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Propose changing a protocol DAO setting
+func (c *Client) ProposePDAOSetting(contractName string, settingName string, settingType string, value string) (api.ProposePDAOSettingResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao propose-setting %s %s %s %s", contractName, settingName, settingType, value))
+	if err != nil {
+		return api.ProposePDAOSettingResponse{}, fmt.Errorf("Could not propose setting: %w", err)
+	}
+	var response api.ProposePDAOSettingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ProposePDAOSettingResponse{}, fmt.Errorf("Could not decode propose setting response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ProposePDAOSettingResponse{}, fmt.Errorf("Could not propose setting: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can vote on a protocol DAO proposal
+func (c *Client) CanVoteOnPDAOProposal(proposalId uint64, direction string) (api.CanVoteOnPDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao can-vote-proposal %d %s", proposalId, direction))
+	if err != nil {
+		return api.CanVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not get can vote on protocol DAO proposal status: %w", err)
+	}
+	var response api.CanVoteOnPDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not decode can vote on protocol DAO proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not get can vote on protocol DAO proposal status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Vote on a protocol DAO proposal
+func (c *Client) VoteOnPDAOProposal(proposalId uint64, direction string) (api.VoteOnPDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao vote-proposal %d %s", proposalId, direction))
+	if err != nil {
+		return api.VoteOnPDAOProposalResponse{}, fmt.Errorf("Could not vote on protocol DAO proposal: %w", err)
+	}
+	var response api.VoteOnPDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VoteOnPDAOProposalResponse{}, fmt.Errorf("Could not decode vote on protocol DAO proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VoteOnPDAOProposalResponse{}, fmt.Errorf("Could not vote on protocol DAO proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can override its delegate's vote on a protocol DAO proposal
+func (c *Client) CanOverrideDelegateVoteOnPDAOProposal(proposalId uint64, direction string) (api.CanOverrideDelegateVoteOnPDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao can-override-vote %d %s", proposalId, direction))
+	if err != nil {
+		return api.CanOverrideDelegateVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not get can override delegate vote status: %w", err)
+	}
+	var response api.CanOverrideDelegateVoteOnPDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanOverrideDelegateVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not decode can override delegate vote response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanOverrideDelegateVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not get can override delegate vote status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Override the node's delegate's vote on a protocol DAO proposal
+func (c *Client) OverrideDelegateVoteOnPDAOProposal(proposalId uint64, direction string) (api.OverrideDelegateVoteOnPDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao override-vote %d %s", proposalId, direction))
+	if err != nil {
+		return api.OverrideDelegateVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not override delegate vote: %w", err)
+	}
+	var response api.OverrideDelegateVoteOnPDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.OverrideDelegateVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not decode override delegate vote response: %w", err)
+	}
+	if response.Error != "" {
+		return api.OverrideDelegateVoteOnPDAOProposalResponse{}, fmt.Errorf("Could not override delegate vote: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can execute a protocol DAO proposal
+func (c *Client) CanExecutePDAOProposal(proposalId uint64) (api.CanExecutePDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao can-execute-proposal %d", proposalId))
+	if err != nil {
+		return api.CanExecutePDAOProposalResponse{}, fmt.Errorf("Could not get can execute protocol DAO proposal status: %w", err)
+	}
+	var response api.CanExecutePDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanExecutePDAOProposalResponse{}, fmt.Errorf("Could not decode can execute protocol DAO proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanExecutePDAOProposalResponse{}, fmt.Errorf("Could not get can execute protocol DAO proposal status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Execute a protocol DAO proposal
+func (c *Client) ExecutePDAOProposal(proposalId uint64) (api.ExecutePDAOProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao execute-proposal %d", proposalId))
+	if err != nil {
+		return api.ExecutePDAOProposalResponse{}, fmt.Errorf("Could not execute protocol DAO proposal: %w", err)
+	}
+	var response api.ExecutePDAOProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExecutePDAOProposalResponse{}, fmt.Errorf("Could not decode execute protocol DAO proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExecutePDAOProposalResponse{}, fmt.Errorf("Could not execute protocol DAO proposal: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,232 @@
+package rocketpool
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the node's security council status
+func (c *Client) SecurityStatus() (api.SecurityStatusResponse, error) {
+	responseBytes, err := c.callAPI("security status")
+	if err != nil {
+		return api.SecurityStatusResponse{}, fmt.Errorf("Could not get security council status: %w", err)
+	}
+	var response api.SecurityStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SecurityStatusResponse{}, fmt.Errorf("Could not decode get security council status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SecurityStatusResponse{}, fmt.Errorf("Could not get security council status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the security council proposals
+func (c *Client) SecurityProposals() (api.SecurityProposalsResponse, error) {
+	responseBytes, err := c.callAPI("security proposals")
+	if err != nil {
+		return api.SecurityProposalsResponse{}, fmt.Errorf("Could not get security council proposals: %w", err)
+	}
+	var response api.SecurityProposalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SecurityProposalsResponse{}, fmt.Errorf("Could not decode get security council proposals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SecurityProposalsResponse{}, fmt.Errorf("Could not get security council proposals: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can join the security council
+func (c *Client) SecurityCanJoin() (api.CanJoinSecurityCouncilResponse, error) {
+	responseBytes, err := c.callAPI("security can-join")
+	if err != nil {
+		return api.CanJoinSecurityCouncilResponse{}, fmt.Errorf("Could not check if node can join the security council: %w", err)
+	}
+	var response api.CanJoinSecurityCouncilResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanJoinSecurityCouncilResponse{}, fmt.Errorf("Could not decode check if node can join the security council response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanJoinSecurityCouncilResponse{}, fmt.Errorf("Could not check if node can join the security council: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Join the security council
+func (c *Client) SecurityJoin() (api.JoinSecurityCouncilResponse, error) {
+	responseBytes, err := c.callAPI("security join")
+	if err != nil {
+		return api.JoinSecurityCouncilResponse{}, fmt.Errorf("Could not join the security council: %w", err)
+	}
+	var response api.JoinSecurityCouncilResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.JoinSecurityCouncilResponse{}, fmt.Errorf("Could not decode join the security council response: %w", err)
+	}
+	if response.Error != "" {
+		return api.JoinSecurityCouncilResponse{}, fmt.Errorf("Could not join the security council: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can request to leave the security council
+func (c *Client) SecurityCanRequestLeave() (api.CanRequestLeaveSecurityCouncilResponse, error) {
+	responseBytes, err := c.callAPI("security can-request-leave")
+	if err != nil {
+		return api.CanRequestLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not check if node can request to leave the security council: %w", err)
+	}
+	var response api.CanRequestLeaveSecurityCouncilResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanRequestLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not decode check if node can request to leave the security council response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanRequestLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not check if node can request to leave the security council: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Request to leave the security council
+func (c *Client) SecurityRequestLeave() (api.RequestLeaveSecurityCouncilResponse, error) {
+	responseBytes, err := c.callAPI("security request-leave")
+	if err != nil {
+		return api.RequestLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not request to leave the security council: %w", err)
+	}
+	var response api.RequestLeaveSecurityCouncilResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RequestLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not decode request to leave the security council response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RequestLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not request to leave the security council: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can leave the security council
+func (c *Client) SecurityCanLeave() (api.CanLeaveSecurityCouncilResponse, error) {
+	responseBytes, err := c.callAPI("security can-leave")
+	if err != nil {
+		return api.CanLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not check if node can leave the security council: %w", err)
+	}
+	var response api.CanLeaveSecurityCouncilResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not decode check if node can leave the security council response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanLeaveSecurityCouncilResponse{}, fmt.Errorf("Could not check if node can leave the security council: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Leave the security council
+func (c *Client) SecurityLeave() (api.LeaveSecurityCouncilResponse, error) {
+	responseBytes, err := c.callAPI("security leave")
+	if err != nil {
+		return api.LeaveSecurityCouncilResponse{}, fmt.Errorf("Could not leave the security council: %w", err)
+	}
+	var response api.LeaveSecurityCouncilResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.LeaveSecurityCouncilResponse{}, fmt.Errorf("Could not decode leave the security council response: %w", err)
+	}
+	if response.Error != "" {
+		return api.LeaveSecurityCouncilResponse{}, fmt.Errorf("Could not leave the security council: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can propose disabling a boolean protocol setting
+func (c *Client) SecurityCanProposeDisableSetting(contractName string, settingName string) (api.CanProposeSecurityDisableSettingResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("security can-propose-disable-setting %s %s", contractName, settingName))
+	if err != nil {
+		return api.CanProposeSecurityDisableSettingResponse{}, fmt.Errorf("Could not check if node can propose disabling a setting: %w", err)
+	}
+	var response api.CanProposeSecurityDisableSettingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanProposeSecurityDisableSettingResponse{}, fmt.Errorf("Could not decode check if node can propose disabling a setting response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanProposeSecurityDisableSettingResponse{}, fmt.Errorf("Could not check if node can propose disabling a setting: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Propose disabling a boolean protocol setting
+func (c *Client) SecurityProposeDisableSetting(contractName string, settingName string) (api.ProposeSecurityDisableSettingResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("security propose-disable-setting %s %s", contractName, settingName))
+	if err != nil {
+		return api.ProposeSecurityDisableSettingResponse{}, fmt.Errorf("Could not submit security council disable setting proposal: %w", err)
+	}
+	var response api.ProposeSecurityDisableSettingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ProposeSecurityDisableSettingResponse{}, fmt.Errorf("Could not decode submit security council disable setting proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ProposeSecurityDisableSettingResponse{}, fmt.Errorf("Could not submit security council disable setting proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can vote on a security council proposal
+func (c *Client) SecurityCanVoteOnProposal(proposalId uint64, support bool) (api.CanVoteOnSecurityProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("security can-vote-proposal %d %t", proposalId, support))
+	if err != nil {
+		return api.CanVoteOnSecurityProposalResponse{}, fmt.Errorf("Could not check if node can vote on security council proposal: %w", err)
+	}
+	var response api.CanVoteOnSecurityProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanVoteOnSecurityProposalResponse{}, fmt.Errorf("Could not decode check if node can vote on security council proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanVoteOnSecurityProposalResponse{}, fmt.Errorf("Could not check if node can vote on security council proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Vote on a security council proposal
+func (c *Client) SecurityVoteOnProposal(proposalId uint64, support bool) (api.VoteOnSecurityProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("security vote-proposal %d %t", proposalId, support))
+	if err != nil {
+		return api.VoteOnSecurityProposalResponse{}, fmt.Errorf("Could not vote on security council proposal: %w", err)
+	}
+	var response api.VoteOnSecurityProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VoteOnSecurityProposalResponse{}, fmt.Errorf("Could not decode vote on security council proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VoteOnSecurityProposalResponse{}, fmt.Errorf("Could not vote on security council proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can execute a security council proposal
+func (c *Client) SecurityCanExecuteProposal(proposalId uint64) (api.CanExecuteSecurityProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("security can-execute-proposal %d", proposalId))
+	if err != nil {
+		return api.CanExecuteSecurityProposalResponse{}, fmt.Errorf("Could not check if node can execute security council proposal: %w", err)
+	}
+	var response api.CanExecuteSecurityProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanExecuteSecurityProposalResponse{}, fmt.Errorf("Could not decode check if node can execute security council proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanExecuteSecurityProposalResponse{}, fmt.Errorf("Could not check if node can execute security council proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Execute a security council proposal
+func (c *Client) SecurityExecuteProposal(proposalId uint64) (api.ExecuteSecurityProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("security execute-proposal %d", proposalId))
+	if err != nil {
+		return api.ExecuteSecurityProposalResponse{}, fmt.Errorf("Could not execute security council proposal: %w", err)
+	}
+	var response api.ExecuteSecurityProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExecuteSecurityProposalResponse{}, fmt.Errorf("Could not decode execute security council proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExecuteSecurityProposalResponse{}, fmt.Errorf("Could not execute security council proposal: %s", response.Error)
+	}
+	return response, nil
+}
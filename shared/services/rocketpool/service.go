@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
 // Deletes the data folder including the wallet file, password file, and all validator keys.
@@ -40,6 +41,22 @@ func (c *Client) GetClientStatus() (api.ClientStatusResponse, error) {
 	return response, nil
 }
 
+// Gets the config the daemon is running with and where each value came from
+func (c *Client) GetEffectiveConfig() (api.GetEffectiveConfigResponse, error) {
+	responseBytes, err := c.callAPI("service get-effective-config")
+	if err != nil {
+		return api.GetEffectiveConfigResponse{}, fmt.Errorf("Could not get effective config: %w", err)
+	}
+	var response api.GetEffectiveConfigResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetEffectiveConfigResponse{}, fmt.Errorf("Could not decode effective config response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetEffectiveConfigResponse{}, fmt.Errorf("Could not get effective config: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Restarts the Validator client
 func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	responseBytes, err := c.callAPI("service restart-vc")
@@ -55,3 +72,199 @@ func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	}
 	return response, nil
 }
+
+// Stop the node and watchtower daemons from starting new tasks until they're restarted or the shutdown is cancelled
+func (c *Client) PrepareShutdown() (api.PrepareShutdownResponse, error) {
+	responseBytes, err := c.callAPI("service prepare-shutdown")
+	if err != nil {
+		return api.PrepareShutdownResponse{}, fmt.Errorf("Could not prepare shutdown: %w", err)
+	}
+	var response api.PrepareShutdownResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PrepareShutdownResponse{}, fmt.Errorf("Could not decode prepare-shutdown response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PrepareShutdownResponse{}, fmt.Errorf("Could not prepare shutdown: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel a prepared shutdown, so the node and watchtower daemons resume starting new tasks
+func (c *Client) CancelShutdown() (api.CancelShutdownResponse, error) {
+	responseBytes, err := c.callAPI("service cancel-shutdown")
+	if err != nil {
+		return api.CancelShutdownResponse{}, fmt.Errorf("Could not cancel shutdown: %w", err)
+	}
+	var response api.CancelShutdownResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CancelShutdownResponse{}, fmt.Errorf("Could not decode cancel-shutdown response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CancelShutdownResponse{}, fmt.Errorf("Could not cancel shutdown: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Checks that two independent checkpoint sync providers agree on the latest finalized state
+func (c *Client) VerifyCheckpointSync(providerUrl string, verificationUrl string) (api.VerifyCheckpointSyncResponse, error) {
+	responseBytes, err := c.callAPI("service verify-checkpoint-sync", providerUrl, verificationUrl)
+	if err != nil {
+		return api.VerifyCheckpointSyncResponse{}, fmt.Errorf("Could not verify checkpoint sync: %w", err)
+	}
+	var response api.VerifyCheckpointSyncResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyCheckpointSyncResponse{}, fmt.Errorf("Could not decode verify-checkpoint-sync response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyCheckpointSyncResponse{}, fmt.Errorf("Could not verify checkpoint sync: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Asks the daemons to reload their config
+func (c *Client) ReloadConfig() (api.ReloadConfigResponse, error) {
+	responseBytes, err := c.callAPI("service reload-config")
+	if err != nil {
+		return api.ReloadConfigResponse{}, fmt.Errorf("Could not reload config: %w", err)
+	}
+	var response api.ReloadConfigResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReloadConfigResponse{}, fmt.Errorf("Could not decode reload-config response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ReloadConfigResponse{}, fmt.Errorf("Could not reload config: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the recent entries from the daemons' log files that match the given filters
+func (c *Client) QueryLogs(process string, task string, module string, since string, level string, limit uint64) (api.QueryLogsResponse, error) {
+	responseBytes, err := c.callAPI("service query-logs --process", process, "--task", task, "--module", module, "--since", since, "--level", level, "--limit", fmt.Sprint(limit))
+	if err != nil {
+		return api.QueryLogsResponse{}, fmt.Errorf("Could not query logs: %w", err)
+	}
+	var response api.QueryLogsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.QueryLogsResponse{}, fmt.Errorf("Could not decode query-logs response: %w", err)
+	}
+	if response.Error != "" {
+		return api.QueryLogsResponse{}, fmt.Errorf("Could not query logs: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the log levels of the daemons
+func (c *Client) GetLogLevels() (api.GetLogLevelsResponse, error) {
+	responseBytes, err := c.callAPI("service get-log-levels")
+	if err != nil {
+		return api.GetLogLevelsResponse{}, fmt.Errorf("Could not get log levels: %w", err)
+	}
+	var response api.GetLogLevelsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetLogLevelsResponse{}, fmt.Errorf("Could not decode get-log-levels response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetLogLevelsResponse{}, fmt.Errorf("Could not get log levels: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Change the log level of a module while the daemons are running
+func (c *Client) SetLogLevel(module string, level string) (api.SetLogLevelResponse, error) {
+	responseBytes, err := c.callAPI("service set-log-level", module, level)
+	if err != nil {
+		return api.SetLogLevelResponse{}, fmt.Errorf("Could not set log level: %w", err)
+	}
+	var response api.SetLogLevelResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetLogLevelResponse{}, fmt.Errorf("Could not decode set-log-level response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetLogLevelResponse{}, fmt.Errorf("Could not set log level: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Return a module, or every module if none is given, to the log levels in the config
+func (c *Client) ResetLogLevels(module string) (api.SetLogLevelResponse, error) {
+	args := []string{}
+	if module != "" {
+		args = append(args, module)
+	}
+	responseBytes, err := c.callAPI("service reset-log-levels", args...)
+	if err != nil {
+		return api.SetLogLevelResponse{}, fmt.Errorf("Could not reset log levels: %w", err)
+	}
+	var response api.SetLogLevelResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetLogLevelResponse{}, fmt.Errorf("Could not decode reset-log-levels response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetLogLevelResponse{}, fmt.Errorf("Could not reset log levels: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Checks whether contract call tracing is enabled
+func (c *Client) GetCallTracing() (api.CallTracingStatusResponse, error) {
+	responseBytes, err := c.callAPI("service get-call-tracing")
+	if err != nil {
+		return api.CallTracingStatusResponse{}, fmt.Errorf("Could not get call tracing status: %w", err)
+	}
+	var response api.CallTracingStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CallTracingStatusResponse{}, fmt.Errorf("Could not decode call tracing status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CallTracingStatusResponse{}, fmt.Errorf("Could not get call tracing status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Turns contract call tracing on or off
+func (c *Client) SetCallTracing(enabled bool) (api.CallTracingStatusResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service set-call-tracing %t", enabled))
+	if err != nil {
+		return api.CallTracingStatusResponse{}, fmt.Errorf("Could not set call tracing: %w", err)
+	}
+	var response api.CallTracingStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CallTracingStatusResponse{}, fmt.Errorf("Could not decode set call tracing response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CallTracingStatusResponse{}, fmt.Errorf("Could not set call tracing: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the rewards files that are older than the provided number of intervals and can be pruned
+func (c *Client) CanPruneRewardsFiles(retention uint64) (api.CanPruneRewardsFilesResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service can-prune-rewards %d", retention))
+	if err != nil {
+		return api.CanPruneRewardsFilesResponse{}, fmt.Errorf("Could not get can-prune-rewards status: %w", err)
+	}
+	var response api.CanPruneRewardsFilesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanPruneRewardsFilesResponse{}, fmt.Errorf("Could not decode can-prune-rewards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanPruneRewardsFilesResponse{}, fmt.Errorf("Could not get can-prune-rewards status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Deletes or archives the rewards files that are older than the provided number of intervals
+func (c *Client) PruneRewardsFiles(retention uint64, mode cfgtypes.RewardsPruneMode) (api.PruneRewardsFilesResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service prune-rewards %d %s", retention, mode))
+	if err != nil {
+		return api.PruneRewardsFilesResponse{}, fmt.Errorf("Could not prune rewards files: %w", err)
+	}
+	var response api.PruneRewardsFilesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PruneRewardsFilesResponse{}, fmt.Errorf("Could not decode prune-rewards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PruneRewardsFilesResponse{}, fmt.Errorf("Could not prune rewards files: %s", response.Error)
+	}
+	return response, nil
+}
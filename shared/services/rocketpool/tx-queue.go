@@ -0,0 +1,56 @@
+package rocketpool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The default time a queued transaction waits for the base fee to drop before it's dropped
+const DefaultTxQueueDeadline string = "48h"
+
+// The error returned in place of a transaction's response when it was queued for the node daemon instead of being submitted
+type TransactionQueuedError struct {
+	Intent txqueue.Intent
+}
+
+func (e *TransactionQueuedError) Error() string {
+	return fmt.Sprintf("the transaction was queued as #%d instead of being submitted; the node daemon will submit it once the base fee is below %.2f gwei, or drop it after %s", e.Intent.ID, e.Intent.MaxBaseFee, e.Intent.Deadline.Format(time.RFC822))
+}
+
+// Get the base fee (in gwei) transactions should wait for and how long they can wait, or 0 if they should be submitted right away
+func (c *Client) GetTxQueueSettings() (float64, time.Duration) {
+	return c.whenGasBelow, c.txQueueDeadline
+}
+
+// Queue every transaction from here on for the node daemon instead of submitting it
+func (c *Client) QueueTransactions() {
+	c.queueTransactions = true
+}
+
+// Check if an API command submits a transaction, using the same list of read-only commands as the API server's monitor role
+func isTransactionCommand(args []string) bool {
+	// The wait command is the only one at the root; the others are all under a module
+	if len(args) < 2 || args[0] == "wait" {
+		return false
+	}
+	return !api.IsReadOnlyCommand(args[:2])
+}
+
+// Queue an API command for the node daemon, returning the error that stands in for its response
+func (c *Client) queueTransaction(args []string) ([]byte, error) {
+	c.queueTransactions = false
+	defer func() {
+		c.queueTransactions = true
+	}()
+
+	response, err := c.NodeQueueTx(c.whenGasBelow, c.txQueueDeadline, args)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &TransactionQueuedError{
+		Intent: response.Intent,
+	}
+}
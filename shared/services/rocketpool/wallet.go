@@ -1,10 +1,15 @@
 package rocketpool
 
 import (
+	"crypto/ecdsa"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -40,6 +45,22 @@ func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
 	return response, nil
 }
 
+// Unlock the wallet
+func (c *Client) UnlockWallet(password string) (api.UnlockWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet unlock", password)
+	if err != nil {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not unlock wallet: %w", err)
+	}
+	var response api.UnlockWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not decode unlock wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not unlock wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Initialize wallet
 func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, error) {
 	responseBytes, err := c.callAPI("wallet init --derivation-path", derivationPath)
@@ -57,7 +78,8 @@ func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, erro
 }
 
 // Recover wallet
-func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
+// Validator pubkeys and minipool addresses can be provided to only recover those keys, and a search depth of 0 uses the default.
+func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint, validatorPubkeys []types.ValidatorPubkey, minipools []common.Address, searchDepth uint) (api.RecoverWalletResponse, error) {
 	command := "wallet recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
@@ -65,6 +87,23 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	if walletIndex != 0 {
 		command += fmt.Sprintf("--wallet-index %d ", walletIndex)
 	}
+	if len(validatorPubkeys) > 0 {
+		pubkeyStrings := make([]string, len(validatorPubkeys))
+		for i, pubkey := range validatorPubkeys {
+			pubkeyStrings[i] = pubkey.Hex()
+		}
+		command += fmt.Sprintf("--validator-pubkeys %s ", strings.Join(pubkeyStrings, ","))
+	}
+	if len(minipools) > 0 {
+		addressStrings := make([]string, len(minipools))
+		for i, address := range minipools {
+			addressStrings[i] = address.Hex()
+		}
+		command += fmt.Sprintf("--minipools %s ", strings.Join(addressStrings, ","))
+	}
+	if searchDepth != 0 {
+		command += fmt.Sprintf("--search-depth %d ", searchDepth)
+	}
 	command += "--derivation-path"
 
 	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
@@ -81,6 +120,22 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	return response, nil
 }
 
+// Initialize the wallet from a node private key
+func (c *Client) ImportNodeKey(privateKey *ecdsa.PrivateKey) (api.ImportNodeKeyResponse, error) {
+	responseBytes, err := c.callAPI("wallet import-keystore", hex.EncodeToString(crypto.FromECDSA(privateKey)))
+	if err != nil {
+		return api.ImportNodeKeyResponse{}, fmt.Errorf("Could not import node key: %w", err)
+	}
+	var response api.ImportNodeKeyResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportNodeKeyResponse{}, fmt.Errorf("Could not decode import node key response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportNodeKeyResponse{}, fmt.Errorf("Could not import node key: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Search and recover wallet
 func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool) (api.SearchAndRecoverWalletResponse, error) {
 	command := "wallet search-and-recover "
@@ -164,6 +219,90 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Get the wallet profiles
+func (c *Client) WalletProfiles() (api.WalletProfilesResponse, error) {
+	responseBytes, err := c.callAPI("wallet profiles")
+	if err != nil {
+		return api.WalletProfilesResponse{}, fmt.Errorf("Could not get wallet profiles: %w", err)
+	}
+	var response api.WalletProfilesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.WalletProfilesResponse{}, fmt.Errorf("Could not decode wallet profiles response: %w", err)
+	}
+	if response.Error != "" {
+		return api.WalletProfilesResponse{}, fmt.Errorf("Could not get wallet profiles: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Make a wallet profile the active one
+func (c *Client) SwitchWalletProfile(profile string) (api.SwitchWalletProfileResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet switch-profile %s", profile))
+	if err != nil {
+		return api.SwitchWalletProfileResponse{}, fmt.Errorf("Could not switch wallet profile: %w", err)
+	}
+	var response api.SwitchWalletProfileResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SwitchWalletProfileResponse{}, fmt.Errorf("Could not decode switch wallet profile response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SwitchWalletProfileResponse{}, fmt.Errorf("Could not switch wallet profile: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the validator keys stored in Web3Signer
+func (c *Client) GetWeb3SignerKeys() (api.Web3SignerKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet web3signer-keys")
+	if err != nil {
+		return api.Web3SignerKeysResponse{}, fmt.Errorf("Could not get Web3Signer keys: %w", err)
+	}
+	var response api.Web3SignerKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.Web3SignerKeysResponse{}, fmt.Errorf("Could not decode Web3Signer keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.Web3SignerKeysResponse{}, fmt.Errorf("Could not get Web3Signer keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Import the node wallet's validator keys into Web3Signer, along with optional slashing protection data
+func (c *Client) ImportWeb3SignerKeys(slashingProtectionPath string) (api.ImportWeb3SignerKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet web3signer-import --slashing-protection", slashingProtectionPath)
+	if err != nil {
+		return api.ImportWeb3SignerKeysResponse{}, fmt.Errorf("Could not import keys into Web3Signer: %w", err)
+	}
+	var response api.ImportWeb3SignerKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportWeb3SignerKeysResponse{}, fmt.Errorf("Could not decode import Web3Signer keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportWeb3SignerKeysResponse{}, fmt.Errorf("Could not import keys into Web3Signer: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Remove validator keys from Web3Signer
+func (c *Client) RemoveWeb3SignerKeys(pubkeys []types.ValidatorPubkey) (api.RemoveWeb3SignerKeysResponse, error) {
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = pubkey.Hex()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet web3signer-remove %s", strings.Join(pubkeyStrings, ",")))
+	if err != nil {
+		return api.RemoveWeb3SignerKeysResponse{}, fmt.Errorf("Could not remove keys from Web3Signer: %w", err)
+	}
+	var response api.RemoveWeb3SignerKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RemoveWeb3SignerKeysResponse{}, fmt.Errorf("Could not decode remove Web3Signer keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RemoveWeb3SignerKeysResponse{}, fmt.Errorf("Could not remove keys from Web3Signer: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to set an ENS reverse record to a name
 func (c *Client) EstimateGasSetEnsName(name string) (api.SetEnsNameResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("wallet estimate-gas-set-ens-name %s", name))
@@ -196,6 +335,90 @@ func (c *Client) SetEnsName(name string) (api.SetEnsNameResponse, error) {
 	return response, nil
 }
 
+// Get the node wallet's nonces, pending transactions, and nonce gaps
+func (c *Client) WalletPending() (api.WalletPendingResponse, error) {
+	responseBytes, err := c.callAPI("wallet pending")
+	if err != nil {
+		return api.WalletPendingResponse{}, fmt.Errorf("Could not get pending transactions: %w", err)
+	}
+	var response api.WalletPendingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.WalletPendingResponse{}, fmt.Errorf("Could not decode pending transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.WalletPendingResponse{}, fmt.Errorf("Could not get pending transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a stuck nonce can be cleared
+func (c *Client) CanClearStuckNonce(nonce uint64) (api.CanClearStuckNonceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet can-clear-stuck %d", nonce))
+	if err != nil {
+		return api.CanClearStuckNonceResponse{}, fmt.Errorf("Could not get can clear stuck nonce status: %w", err)
+	}
+	var response api.CanClearStuckNonceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanClearStuckNonceResponse{}, fmt.Errorf("Could not decode can clear stuck nonce response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanClearStuckNonceResponse{}, fmt.Errorf("Could not get can clear stuck nonce status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Replace a stuck nonce with a zero-value transaction to the node itself
+func (c *Client) ClearStuckNonce(nonce uint64) (api.ClearStuckNonceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet clear-stuck %d", nonce))
+	if err != nil {
+		return api.ClearStuckNonceResponse{}, fmt.Errorf("Could not clear stuck nonce: %w", err)
+	}
+	var response api.ClearStuckNonceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ClearStuckNonceResponse{}, fmt.Errorf("Could not decode clear stuck nonce response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ClearStuckNonceResponse{}, fmt.Errorf("Could not clear stuck nonce: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a validator key can be deleted
+func (c *Client) CanDeleteValidatorKey(pubkey types.ValidatorPubkey) (api.CanDeleteValidatorKeyResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet can-delete-validator-key %s", pubkey.Hex()))
+	if err != nil {
+		return api.CanDeleteValidatorKeyResponse{}, fmt.Errorf("Could not get can delete validator key status: %w", err)
+	}
+	var response api.CanDeleteValidatorKeyResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanDeleteValidatorKeyResponse{}, fmt.Errorf("Could not decode can delete validator key response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanDeleteValidatorKeyResponse{}, fmt.Errorf("Could not get can delete validator key status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Delete a validator key from the validator keystores
+func (c *Client) DeleteValidatorKey(pubkey types.ValidatorPubkey, force bool) (api.DeleteValidatorKeyResponse, error) {
+	command := "wallet delete-validator-key "
+	if force {
+		command += "--force "
+	}
+	responseBytes, err := c.callAPI(command + pubkey.Hex())
+	if err != nil {
+		return api.DeleteValidatorKeyResponse{}, fmt.Errorf("Could not delete validator key: %w", err)
+	}
+	var response api.DeleteValidatorKeyResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DeleteValidatorKeyResponse{}, fmt.Errorf("Could not decode delete validator key response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DeleteValidatorKeyResponse{}, fmt.Errorf("Could not delete validator key: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Export wallet
 func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	responseBytes, err := c.callAPI("wallet export")
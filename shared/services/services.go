@@ -1,10 +1,15 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"os"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
@@ -13,16 +18,24 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/txjournal"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	lokeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -32,6 +45,8 @@ const (
 	EcContainerName         string = "eth1"
 	FallbackEcContainerName string = "eth1-fallback"
 	BnContainerName         string = "eth2"
+
+	logLevelPollInterval time.Duration = 15 * time.Second
 )
 
 // Service instances & initializers
@@ -45,8 +60,23 @@ var (
 	oneInchOracle      *contracts.OneInchOracle
 	rplFaucet          *contracts.RPLFaucet
 	snapshotDelegation *contracts.SnapshotDelegation
+	feeOracle          *oracle.FeeOracle
 	beaconClient       beacon.Client
 	docker             *client.Client
+	alerter            *alerting.Alerter
+	txJournal          *txjournal.Journal
+	txQueue            *txqueue.Queue
+
+	// Initialization errors are kept so every caller sees them, not just the first
+	cfgErr                error
+	nodeWalletErr         error
+	ecManagerErr          error
+	bcManagerErr          error
+	rocketPoolErr         error
+	oneInchOracleErr      error
+	rplFaucetErr          error
+	snapshotDelegationErr error
+	dockerErr             error
 
 	initCfg                sync.Once
 	initPasswordManager    sync.Once
@@ -57,8 +87,17 @@ var (
 	initOneInchOracle      sync.Once
 	initRplFaucet          sync.Once
 	initSnapshotDelegation sync.Once
+	initFeeOracle          sync.Once
 	initBeaconClient       sync.Once
 	initDocker             sync.Once
+	initAlerter            sync.Once
+
+	// The wallet profile the password manager and node wallet were last loaded from
+	passwordManagerProfile string
+	nodeWalletProfile      string
+	walletProfileLock      sync.Mutex
+	initTxJournal          sync.Once
+	initTxQueue            sync.Once
 )
 
 //
@@ -86,6 +125,21 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	return getWallet(c, cfg, pm)
 }
 
+// Update the node wallet's gas settings from the provided context, for daemons that run more than one command
+func UpdateWalletGasSettings(c *cli.Context) error {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return err
+	}
+	maxFee, maxPriorityFee := getGasSettings(c, cfg)
+	w.SetGasSettings(maxFee, maxPriorityFee)
+	return nil
+}
+
 func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -153,6 +207,18 @@ func GetSnapshotDelegation(c *cli.Context) (*contracts.SnapshotDelegation, error
 	return getSnapshotDelegation(cfg, ec)
 }
 
+func GetFeeOracle(c *cli.Context) (*oracle.FeeOracle, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := getEthClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getFeeOracle(ec), nil
+}
+
 func GetBeaconClient(c *cli.Context) (*BeaconClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -165,78 +231,308 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
 
+func GetAlerter(c *cli.Context) (*alerting.Alerter, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm := getPasswordManager(cfg)
+	w, err := getWallet(c, cfg, pm)
+	if err != nil {
+		return nil, err
+	}
+	return getAlerter(cfg, w), nil
+}
+
+// Reload the settings file and apply the settings that can change while the daemons are running
+func ReloadConfig(c *cli.Context) (config.ConfigReloadResult, error) {
+	currentCfg, err := getConfig(c)
+	if err != nil {
+		return config.ConfigReloadResult{}, err
+	}
+	newCfg, err := loadConfig(c)
+	if err != nil {
+		return config.ConfigReloadResult{}, fmt.Errorf("error loading the new config: %w", err)
+	}
+	result := currentCfg.Reload(newCfg)
+
+	// Rebuild the alert sinks if their settings changed
+	if alerter != nil && result.AppliedSection("alerting") {
+		alerter.Reload(currentCfg)
+	}
+
+	// Reapply the log levels
+	if err := applyLogSettings(currentCfg); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Apply the config's log format and levels, write the daemon's log entries to its rotating log file,
+// then watch for level changes made at runtime with `rocketpool service log-level`
+func ConfigureLogging(c *cli.Context, process string, logger *log.ColorLogger) error {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+	if err := applyLogSettings(cfg); err != nil {
+		return err
+	}
+
+	// Open the log file
+	maxSize := int64(cfg.Smartnode.LogFileMaxSize.Value.(uint64)) * 1024 * 1024
+	maxAge := time.Duration(cfg.Smartnode.LogFileMaxAge.Value.(uint64)) * time.Hour
+	maxCount := int(cfg.Smartnode.LogFileMaxCount.Value.(uint64))
+	logFile, err := log.NewRotatingFile(cfg.Smartnode.GetDaemonLogPath(true, process), maxSize, maxAge, maxCount)
+	if err != nil {
+		return err
+	}
+	log.SetFileOutput(logFile)
+
+	log.WatchLevelOverrides(cfg.Smartnode.GetLogLevelOverridesPath(true), logLevelPollInterval, logger)
+	return nil
+}
+
+// Apply the config's log format and levels to a worker process, sending its log entries to the daemon that spawned it
+// instead of printing them or writing them to the daemon's log file
+func ConfigureWorkerLogging(c *cli.Context, output io.Writer) error {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+	if err := applyLogSettings(cfg); err != nil {
+		return err
+	}
+	log.SetWorkerOutput(output)
+	return nil
+}
+
+func GetTxJournal(c *cli.Context) (*txjournal.Journal, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getTxJournal(cfg), nil
+}
+
+func GetTxQueue(c *cli.Context) (*txqueue.Queue, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getTxQueue(cfg), nil
+}
+
 //
 // Service instance getters
 //
 
+// Apply the log settings of a config
+func applyLogSettings(cfg *config.RocketPoolConfig) error {
+	level, err := log.ParseLevel(string(cfg.Smartnode.LogLevel.Value.(cfgtypes.LogLevel)))
+	if err != nil {
+		return fmt.Errorf("error parsing the log level: %w", err)
+	}
+	moduleLevels, err := log.ParseModuleLevels(cfg.Smartnode.ModuleLogLevels.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error parsing the module log levels: %w", err)
+	}
+	log.Configure(log.Format(cfg.Smartnode.LogFormat.Value.(cfgtypes.LogFormat)), level, moduleLevels)
+	return nil
+}
+
 func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
-	var err error
 	initCfg.Do(func() {
-		settingsFile := os.ExpandEnv(c.GlobalString("settings"))
-		cfg, err = rp.LoadConfigFromFile(settingsFile)
-		if cfg == nil && err == nil {
-			err = fmt.Errorf("Settings file [%s] not found.", settingsFile)
-		}
+		cfg, cfgErr = loadConfig(c)
 	})
-	return cfg, err
+	return cfg, cfgErr
+}
+
+// Load the config from the settings file, then apply the overrides and resolve the secrets
+func loadConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
+	settingsFile := os.ExpandEnv(c.GlobalString("settings"))
+	loadedCfg, err := rp.LoadConfigFromFile(settingsFile)
+	if err != nil {
+		return nil, err
+	}
+	if loadedCfg == nil {
+		return nil, fmt.Errorf("Settings file [%s] not found.", settingsFile)
+	}
+	err = loadedCfg.ApplyOverrides(c.GlobalStringSlice("set"))
+	if err != nil {
+		return nil, err
+	}
+	err = loadedCfg.ResolveSecrets(true)
+	if err != nil {
+		return nil, err
+	}
+	return loadedCfg, nil
 }
 
 func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
+	walletProfileLock.Lock()
+	defer walletProfileLock.Unlock()
+
+	profile := cfg.Smartnode.GetActiveWalletProfile()
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(getProfileFilePath(cfg, profile, "password")))
+		passwordManager.SetProvider(cfg.Smartnode.WalletPasswordProvider.Value.(cfgtypes.WalletPasswordProvider), cfg.Smartnode.WalletPasswordSource.Value.(string))
+		passwordManagerProfile = profile
 	})
+
+	// Follow the active wallet profile if it's been switched
+	if passwordManagerProfile != profile {
+		passwordManager.SetPasswordPath(os.ExpandEnv(getProfileFilePath(cfg, profile, "password")))
+		passwordManagerProfile = profile
+	}
 	return passwordManager
 }
 
 func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
-	var err error
+	walletProfileLock.Lock()
+	defer walletProfileLock.Unlock()
+
+	profile := cfg.Smartnode.GetActiveWalletProfile()
 	initNodeWallet.Do(func() {
-		var maxFee *big.Int
-		maxFeeFloat := c.GlobalFloat64("maxFee")
-		if maxFeeFloat == 0 {
-			maxFeeFloat = cfg.Smartnode.ManualMaxFee.Value.(float64)
-		}
-		if maxFeeFloat != 0 {
-			maxFee = eth.GweiToWei(maxFeeFloat)
-		}
+		maxFee, maxPriorityFee := getGasSettings(c, cfg)
+		chainId := cfg.Smartnode.GetChainID()
 
-		var maxPriorityFee *big.Int
-		maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
-		if maxPriorityFeeFloat == 0 {
-			maxPriorityFeeFloat = cfg.Smartnode.PriorityFee.Value.(float64)
-		}
-		if maxPriorityFeeFloat != 0 {
-			maxPriorityFee = eth.GweiToWei(maxPriorityFeeFloat)
+		nodeWallet, nodeWalletErr = wallet.NewWallet(os.ExpandEnv(getProfileFilePath(cfg, profile, "wallet")), chainId, maxFee, maxPriorityFee, 0, pm)
+		if nodeWalletErr != nil {
+			return
 		}
+		nodeWalletProfile = profile
 
-		chainId := cfg.Smartnode.GetChainID()
+		// Keystores
+		for name, ks := range getKeystores(cfg, pm) {
+			nodeWallet.AddKeystore(name, ks)
+		}
 
-		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), chainId, maxFee, maxPriorityFee, 0, pm)
+		// Node key signer
+		var signer wallet.NodeSigner
+		var err error
+		switch signerType := cfg.Smartnode.NodeKeySigner.Value.(cfgtypes.NodeKeySigner); signerType {
+		case cfgtypes.NodeKeySigner_Ledger, cfgtypes.NodeKeySigner_Trezor:
+			signer, err = wallet.NewHardwareNodeSigner(signerType, cfg.Smartnode.HardwareWalletDerivationPath.Value.(string), os.ExpandEnv(cfg.Smartnode.GetHardwareWalletAccountPath()), func() (*big.Int, error) {
+				ec, err := getEthClient(c, cfg)
+				if err != nil {
+					return nil, err
+				}
+				header, err := ec.HeaderByNumber(context.Background(), nil)
+				if err != nil {
+					return nil, fmt.Errorf("error getting the latest block: %w", err)
+				}
+				if header.BaseFee == nil {
+					return nil, errors.New("the latest block doesn't have a base fee")
+				}
+				return header.BaseFee, nil
+			})
+		case cfgtypes.NodeKeySigner_Clef, cfgtypes.NodeKeySigner_Web3Signer:
+			signer, err = wallet.NewExternalNodeSigner(signerType, cfg.Smartnode.ExternalSignerUrl.Value.(string), cfg.Smartnode.ExternalSignerAddress.Value.(string))
+		}
 		if err != nil {
+			nodeWallet, nodeWalletErr = nil, err
 			return
 		}
+		if signer != nil {
+			nodeWallet.SetNodeSigner(signer)
+		}
 
-		// Keystores
-		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		lodestarKeystore := lokeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		prysmKeystore := prkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		tekuKeystore := tkkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()), pm)
-		nodeWallet.AddKeystore("lighthouse", lighthouseKeystore)
-		nodeWallet.AddKeystore("lodestar", lodestarKeystore)
-		nodeWallet.AddKeystore("nimbus", nimbusKeystore)
-		nodeWallet.AddKeystore("prysm", prysmKeystore)
-		nodeWallet.AddKeystore("teku", tekuKeystore)
+		// Transaction policy
+		nodeWallet.SetTxPolicy(getTxPolicy(cfg, profile))
 	})
-	return nodeWallet, err
+	if nodeWalletErr != nil {
+		return nil, nodeWalletErr
+	}
+
+	// Follow the active wallet profile if it's been switched; every holder of the wallet sees the new profile
+	if nodeWalletProfile != profile {
+		if err := nodeWallet.SwitchProfile(os.ExpandEnv(getProfileFilePath(cfg, profile, "wallet")), getKeystores(cfg, pm)); err != nil {
+			return nil, fmt.Errorf("error switching to wallet profile '%s': %w", profile, err)
+		}
+		nodeWallet.SetTxPolicy(getTxPolicy(cfg, profile))
+		nodeWalletProfile = profile
+	}
+	return nodeWallet, nil
+}
+
+// Get the validator keystores for the active wallet profile; its keychain is always the one the validator clients mount
+func getKeystores(cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) map[string]keystore.Keystore {
+	keychainPath := os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())
+	localKeystores := map[string]keystore.Keystore{
+		"lighthouse": lhkeystore.NewKeystore(keychainPath, pm),
+		"lodestar":   lokeystore.NewKeystore(keychainPath, pm),
+		"nimbus":     nmkeystore.NewKeystore(keychainPath, pm),
+		"prysm":      prkeystore.NewKeystore(keychainPath, pm),
+		"teku":       tkkeystore.NewKeystore(keychainPath, pm),
+	}
+
+	// Clients that use Web3Signer get their keys from it instead of from disk
+	keystores := map[string]keystore.Keystore{}
+	usesWeb3Signer := false
+	for name, ks := range localKeystores {
+		if cfg.Smartnode.UsesWeb3Signer(name) {
+			usesWeb3Signer = true
+			continue
+		}
+		keystores[name] = ks
+	}
+	if usesWeb3Signer {
+		keystores["web3signer"] = w3skeystore.NewKeystore(keychainPath, cfg.Smartnode.Web3SignerUrl.Value.(string))
+	}
+	return keystores
+}
+
+// Get the transaction policy for a wallet profile; each profile's spending is counted separately
+func getTxPolicy(cfg *config.RocketPoolConfig, profile string) *wallet.TxPolicy {
+	var maxValue, maxFee, dailySpendCap *big.Int
+	if value := cfg.Smartnode.WalletPolicyMaxValue.Value.(float64); value > 0 {
+		maxValue = eth.EthToWei(value)
+	}
+	if value := cfg.Smartnode.WalletPolicyMaxFee.Value.(float64); value > 0 {
+		maxFee = eth.GweiToWei(value)
+	}
+	if value := cfg.Smartnode.WalletPolicyDailySpendCap.Value.(float64); value > 0 {
+		dailySpendCap = eth.EthToWei(value)
+	}
+	if maxValue == nil && maxFee == nil && dailySpendCap == nil {
+		return nil
+	}
+	return wallet.NewTxPolicy(maxValue, maxFee, dailySpendCap, os.ExpandEnv(getProfileFilePath(cfg, profile, config.WalletSpendLogFilename)))
+}
+
+// Get the path of a file in a wallet profile's folder
+func getProfileFilePath(cfg *config.RocketPoolConfig, profile string, filename string) string {
+	return filepath.Join(cfg.Smartnode.GetWalletProfilePath(profile), filename)
+}
+
+func getGasSettings(c *cli.Context, cfg *config.RocketPoolConfig) (*big.Int, *big.Int) {
+	var maxFee *big.Int
+	maxFeeFloat := c.GlobalFloat64("maxFee")
+	if maxFeeFloat == 0 {
+		maxFeeFloat = cfg.Smartnode.ManualMaxFee.Value.(float64)
+	}
+	if maxFeeFloat != 0 {
+		maxFee = eth.GweiToWei(maxFeeFloat)
+	}
+
+	var maxPriorityFee *big.Int
+	maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
+	if maxPriorityFeeFloat == 0 {
+		maxPriorityFeeFloat = cfg.Smartnode.PriorityFee.Value.(float64)
+	}
+	if maxPriorityFeeFloat != 0 {
+		maxPriorityFee = eth.GweiToWei(maxPriorityFeeFloat)
+	}
+
+	return maxFee, maxPriorityFee
 }
 
 func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
-	var err error
 	initECManager.Do(func() {
 		// Create a new client manager
-		ecManager, err = NewExecutionClientManager(cfg)
-		if err == nil {
+		ecManager, ecManagerErr = NewExecutionClientManager(cfg)
+		if ecManagerErr == nil {
 			// Check if the manager should ignore sync checks and/or default to using the fallback (used by the API container when driven by the CLI)
 			if c.GlobalBool("ignore-sync-check") {
 				ecManager.ignoreSyncCheck = true
@@ -246,66 +542,98 @@ func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClien
 			}
 		}
 	})
-	return ecManager, err
+	return ecManager, ecManagerErr
 }
 
 func getRocketPool(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*rocketpool.RocketPool, error) {
-	var err error
 	initRocketPool.Do(func() {
-		rocketPool, err = rocketpool.NewRocketPool(client, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+		deployment, err := cfg.Smartnode.GetDeployment()
+		if err != nil {
+			rocketPoolErr = fmt.Errorf("error loading the Rocket Pool deployment: %w", err)
+			return
+		}
+		rocketPool, rocketPoolErr = rocketpool.NewRocketPool(client, common.HexToAddress(deployment.StorageAddress))
 	})
-	return rocketPool, err
+	return rocketPool, rocketPoolErr
 }
 
 func getOneInchOracle(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*contracts.OneInchOracle, error) {
-	var err error
 	initOneInchOracle.Do(func() {
-		oneInchOracle, err = contracts.NewOneInchOracle(common.HexToAddress(cfg.Smartnode.GetOneInchOracleAddress()), client)
+		oneInchOracle, oneInchOracleErr = contracts.NewOneInchOracle(common.HexToAddress(cfg.Smartnode.GetOneInchOracleAddress()), client)
 	})
-	return oneInchOracle, err
+	return oneInchOracle, oneInchOracleErr
 }
 
 func getRplFaucet(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*contracts.RPLFaucet, error) {
-	var err error
 	initRplFaucet.Do(func() {
-		rplFaucet, err = contracts.NewRPLFaucet(common.HexToAddress(cfg.Smartnode.GetRplFaucetAddress()), client)
+		rplFaucet, rplFaucetErr = contracts.NewRPLFaucet(common.HexToAddress(cfg.Smartnode.GetRplFaucetAddress()), client)
 	})
-	return rplFaucet, err
+	return rplFaucet, rplFaucetErr
 }
 
 func getSnapshotDelegation(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*contracts.SnapshotDelegation, error) {
-	var err error
 	initSnapshotDelegation.Do(func() {
 		address := cfg.Smartnode.GetSnapshotDelegationAddress()
 		if address != "" {
-			snapshotDelegation, err = contracts.NewSnapshotDelegation(common.HexToAddress(address), client)
+			snapshotDelegation, snapshotDelegationErr = contracts.NewSnapshotDelegation(common.HexToAddress(address), client)
 		}
 	})
-	return snapshotDelegation, err
+	return snapshotDelegation, snapshotDelegationErr
+}
+
+func getFeeOracle(client oracle.FeeHistoryProvider) *oracle.FeeOracle {
+	initFeeOracle.Do(func() {
+		feeOracle = oracle.NewFeeOracle(client)
+	})
+	return feeOracle
 }
 
 func getBeaconClient(c *cli.Context, cfg *config.RocketPoolConfig) (*BeaconClientManager, error) {
-	var err error
 	initBCManager.Do(func() {
 		// Create a new client manager
-		bcManager, err = NewBeaconClientManager(cfg)
-		if err == nil {
+		bcManager, bcManagerErr = NewBeaconClientManager(cfg)
+		if bcManagerErr == nil {
 			// Check if the manager should ignore sync checks and/or default to using the fallback (used by the API container when driven by the CLI)
 			if c.GlobalBool("ignore-sync-check") {
 				bcManager.ignoreSyncCheck = true
 			}
 			if c.GlobalBool("force-fallbacks") {
-				bcManager.primaryReady = false
+				bcManager.setPrimaryReady(false)
 			}
 		}
 	})
-	return bcManager, err
+	return bcManager, bcManagerErr
 }
 
 func getDocker() (*client.Client, error) {
-	var err error
 	initDocker.Do(func() {
-		docker, err = client.NewClientWithOpts(client.WithVersion(DockerAPIVersion))
+		docker, dockerErr = client.NewClientWithOpts(client.WithVersion(DockerAPIVersion))
+	})
+	return docker, dockerErr
+}
+
+func getAlerter(cfg *config.RocketPoolConfig, w *wallet.Wallet) *alerting.Alerter {
+	initAlerter.Do(func() {
+		// Alerts are tagged with the node address if the wallet has been initialized
+		var nodeAddress common.Address
+		if nodeAccount, err := w.GetNodeAccount(); err == nil {
+			nodeAddress = nodeAccount.Address
+		}
+		alerter = alerting.NewAlerter(cfg, nodeAddress)
+	})
+	return alerter
+}
+
+func getTxJournal(cfg *config.RocketPoolConfig) *txjournal.Journal {
+	initTxJournal.Do(func() {
+		txJournal = txjournal.NewJournal(cfg.Smartnode.GetTransactionJournalPath(true))
+	})
+	return txJournal
+}
+
+func getTxQueue(cfg *config.RocketPoolConfig) *txqueue.Queue {
+	initTxQueue.Do(func() {
+		txQueue = txqueue.NewQueue(cfg.Smartnode.GetTransactionQueuePath(true))
 	})
-	return docker, err
+	return txQueue
 }
@@ -0,0 +1,164 @@
+package shutdown
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long before the container's stop grace period runs out the daemons stop waiting for their background work, so they
+// can exit cleanly instead of being killed
+const DrainMargin time.Duration = 5 * time.Second
+
+// Get how long a daemon can wait for its background work to finish after being told to stop, given the stop grace period
+// of its container
+func GetDrainTimeout(gracePeriod time.Duration) time.Duration {
+	if gracePeriod <= DrainMargin {
+		return 0
+	}
+	return gracePeriod - DrainMargin
+}
+
+// Coordinates a graceful shutdown of a daemon's task loop. Once the daemon receives SIGTERM or SIGINT, or a shutdown has
+// been prepared with the API after the daemon started, no new tasks are started; the daemon finishes the one it's running
+// and waits for its background work to drain before exiting. A prepared shutdown can be cancelled with the API, which
+// resumes the tasks.
+type Coordinator struct {
+	markerPath string
+	startTime  time.Time
+	stopping   chan struct{}
+	stopOnce   sync.Once
+	stoppedAt  time.Time
+	isPaused   bool
+	log        *log.ColorLogger
+}
+
+// Create a new shutdown coordinator and start listening for termination signals
+func NewCoordinator(markerPath string, logger *log.ColorLogger) *Coordinator {
+	c := &Coordinator{
+		markerPath: markerPath,
+		startTime:  time.Now(),
+		stopping:   make(chan struct{}),
+		log:        logger,
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		sig := <-signals
+		c.log.Printlnf("Received %s, finishing the current task before shutting down...", sig)
+		c.stopOnce.Do(func() {
+			c.stoppedAt = time.Now()
+			close(c.stopping)
+		})
+	}()
+
+	return c
+}
+
+// Check if the daemon has been told to stop
+func (c *Coordinator) IsStopping() bool {
+	select {
+	case <-c.stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// Check if new tasks can be started
+func (c *Coordinator) CanStartTasks() bool {
+	if c.IsStopping() {
+		return false
+	}
+
+	preparedAt, err := getPreparedTime(c.markerPath)
+	if err != nil {
+		c.log.Printlnf("WARNING: couldn't check for a prepared shutdown: %s", err.Error())
+		return true
+	}
+	paused := preparedAt.After(c.startTime)
+	if paused && !c.isPaused {
+		c.log.Printlnf("A shutdown was prepared at %s, so no new tasks will be started until the daemon restarts or the shutdown is cancelled.", preparedAt.Format(time.RFC1123))
+	} else if !paused && c.isPaused {
+		c.log.Println("The prepared shutdown was cancelled, resuming tasks.")
+	}
+	c.isPaused = paused
+	return !paused
+}
+
+// Sleep for the given duration, waking early if the daemon is told to stop. Returns false if it was.
+func (c *Coordinator) Sleep(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.stopping:
+		return false
+	}
+}
+
+// Wait for the daemon's background work to finish, up to the timeout since the daemon was told to stop (so the time spent
+// finishing the current task counts against it). Returns false if it timed out.
+func (c *Coordinator) Drain(background *sync.WaitGroup, timeout time.Duration) bool {
+	if c.IsStopping() {
+		timeout -= time.Since(c.stoppedAt)
+	}
+	done := make(chan struct{})
+	go func() {
+		background.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Record that a shutdown is being prepared, so the daemons stop starting new tasks until they're restarted
+func PrepareShutdown(markerPath string) error {
+	err := os.MkdirAll(filepath.Dir(markerPath), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating the prepared shutdown marker's directory: %w", err)
+	}
+	err = os.WriteFile(markerPath, []byte(time.Now().UTC().Format(time.RFC3339)), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing prepared shutdown marker %s: %w", markerPath, err)
+	}
+	return nil
+}
+
+// Cancel a prepared shutdown, so the daemons resume starting new tasks
+func CancelShutdown(markerPath string) error {
+	err := os.Remove(markerPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing prepared shutdown marker %s: %w", markerPath, err)
+	}
+	return nil
+}
+
+// Get the time a shutdown was prepared at, or the zero time if it hasn't been
+func getPreparedTime(markerPath string) (time.Time, error) {
+	bytes, err := os.ReadFile(markerPath)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error reading prepared shutdown marker %s: %w", markerPath, err)
+	}
+	preparedAt, err := time.Parse(time.RFC3339, strings.TrimSpace(string(bytes)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing prepared shutdown marker %s: %w", markerPath, err)
+	}
+	return preparedAt, nil
+}
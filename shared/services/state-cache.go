@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Get the node's network state cached by the node daemon. Returns nil if the cache is disabled, missing, too many slots
+// behind the chain head, belongs to a different node, or the command was run with --no-cache; callers should read from
+// the chain instead.
+func GetCachedNodeState(c *cli.Context, nodeAddress common.Address) *state.NetworkState {
+	if c.GlobalBool("no-cache") {
+		return nil
+	}
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return nil
+	}
+	maxSlots := cfg.Smartnode.CachedStateMaxSlots.Value.(uint64)
+	if maxSlots == 0 {
+		return nil
+	}
+
+	// Load the cache and make sure it's usable
+	cachedState, err := state.LoadCachedState(cfg.Smartnode.GetCachedNodeStatePath(true))
+	if err != nil {
+		return nil
+	}
+	if _, exists := cachedState.NodeDetailsByAddress[nodeAddress]; !exists {
+		return nil
+	}
+
+	// Get the head slot the same way the node daemon does, from the latest EL block
+	ec, err := GetEthClient(c)
+	if err != nil {
+		return nil
+	}
+	latestBlockHeader, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil
+	}
+	latestBlockTime := time.Unix(int64(latestBlockHeader.Time), 0)
+	genesisTime := time.Unix(int64(cachedState.BeaconConfig.GenesisTime), 0)
+	headSlot := uint64(latestBlockTime.Sub(genesisTime).Seconds()) / cachedState.BeaconConfig.SecondsPerSlot
+
+	// Reject the cache if it's too far behind the head, or ahead of it (e.g. after a resync of the clients)
+	if cachedState.BeaconSlotNumber > headSlot || headSlot-cachedState.BeaconSlotNumber > maxSlots {
+		return nil
+	}
+	return cachedState
+}
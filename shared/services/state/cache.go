@@ -0,0 +1,29 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Save the latest state loaded by the node daemon, so API commands can read it instead of querying the chain
+func SaveCachedState(path string, state *NetworkState) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating cached state folder: %w", err)
+	}
+	err = writeSnapshotFile(path, newSnapshot(GetSnapshotName(SnapshotScope_Node, state.BeaconSlotNumber), SnapshotScope_Node, state))
+	if err != nil {
+		return fmt.Errorf("error saving cached state: %w", err)
+	}
+	return nil
+}
+
+// Load the state cached by the node daemon
+func LoadCachedState(path string) (*NetworkState, error) {
+	snapshot, err := readSnapshotFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading cached state: %w", err)
+	}
+	return snapshot.ToNetworkState(), nil
+}
@@ -0,0 +1,371 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const (
+	// The number of blocks before the previous state to scan for events again, so shallow reorgs don't drop any
+	incrementalReorgMargin uint64 = 8
+)
+
+// Returned when the changes since the previous state can't be applied incrementally
+var ErrFullRefreshRequired = errors.New("the network state must be rebuilt from scratch")
+
+// Updates a previous network state to the provided Beacon slot. Instead of reading every node and minipool again,
+// only the ones that emitted contract events or had their balances change since the previous state are reloaded.
+// Returns ErrFullRefreshRequired if something changed that affects the entire network, such as a contract upgrade
+// or an RPL price update.
+func UpdateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, bc beacon.Client, log *log.ColorLogger, previous *NetworkState, slotNumber uint64) (*NetworkState, error) {
+	// Get the relevant network contracts
+	multicallerAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
+	balanceBatcherAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
+
+	// Get the execution block for the given slot
+	beaconBlock, exists, err := bc.GetBeaconBlock(fmt.Sprintf("%d", slotNumber))
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon block for slot %d: %w", slotNumber, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("slot %d did not have a Beacon block", slotNumber)
+	}
+	elBlockNumber := beaconBlock.ExecutionBlockNumber
+	if elBlockNumber < previous.ElBlockNumber {
+		return nil, ErrFullRefreshRequired
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(elBlockNumber),
+	}
+
+	isAtlasDeployed, err := IsAtlasDeployed(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if Atlas is deployed: %w", err)
+	}
+	if isAtlasDeployed != previous.IsAtlasDeployed {
+		return nil, ErrFullRefreshRequired
+	}
+
+	// Create the state wrapper
+	state := &NetworkState{
+		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		BeaconSlotNumber:         slotNumber,
+		ElBlockNumber:            elBlockNumber,
+		BeaconConfig:             previous.BeaconConfig,
+		log:                      log,
+		IsAtlasDeployed:          isAtlasDeployed,
+	}
+
+	state.logLine("Updating network state from EL block %d to EL block %d, Beacon slot %d", previous.ElBlockNumber, elBlockNumber, slotNumber)
+	start := time.Now()
+
+	// Network contracts and details
+	contracts, err := rpstate.NewNetworkContracts(rp, multicallerAddress, balanceBatcherAddress, isAtlasDeployed, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network contracts: %w", err)
+	}
+	state.NetworkDetails, err = rpstate.NewNetworkDetails(rp, contracts, isAtlasDeployed)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network details: %w", err)
+	}
+	state.logLine("1/5 - Retrieved network details (%s so far)", time.Since(start))
+
+	// Find the nodes and minipools that changed since the previous state
+	dirtyNodes, newNodes, dirtyMinipools, err := getChangesFromEvents(cfg, rp, contracts, previous, elBlockNumber, opts)
+	if err != nil {
+		return nil, err
+	}
+	nodeBalances, distributorBalances, err := getChangesFromBalances(contracts, previous, dirtyMinipools, opts)
+	if err != nil {
+		return nil, err
+	}
+	state.logLine("2/5 - Found %d changed nodes, %d new nodes, and %d changed minipools (%s so far)", len(dirtyNodes), len(newNodes), len(dirtyMinipools), time.Since(start))
+
+	// Reload the changed nodes and add the new ones
+	state.NodeDetails = make([]rpstate.NativeNodeDetails, 0, len(previous.NodeDetails)+len(newNodes))
+	reloadNodeMinipools := map[common.Address]bool{}
+	for i, details := range previous.NodeDetails {
+		if dirtyNodes[details.NodeAddress] {
+			details, err = rpstate.GetNativeNodeDetails(rp, contracts, details.NodeAddress, isAtlasDeployed)
+			if err != nil {
+				return nil, fmt.Errorf("error getting details for node %s: %w", previous.NodeDetails[i].NodeAddress.Hex(), err)
+			}
+		} else {
+			details.BalanceETH = nodeBalances[i]
+			details.DistributorBalance = distributorBalances[i]
+		}
+		state.NodeDetails = append(state.NodeDetails, details)
+	}
+	for _, address := range newNodes {
+		details, err := rpstate.GetNativeNodeDetails(rp, contracts, address, isAtlasDeployed)
+		if err != nil {
+			return nil, fmt.Errorf("error getting details for node %s: %w", address.Hex(), err)
+		}
+		if details.Exists {
+			state.NodeDetails = append(state.NodeDetails, details)
+		}
+	}
+	for i := range state.NodeDetails {
+		// The average fee and distributor shares are calculated in place, so they can't be shared with the previous state
+		details := &state.NodeDetails[i]
+		details.AverageNodeFee = big.NewInt(0)
+		details.DistributorBalanceUserETH = big.NewInt(0)
+		details.DistributorBalanceNodeETH = big.NewInt(0)
+
+		// Nodes that gained or lost minipools need their whole minipool list reloaded
+		if details.MinipoolCount != nil && details.MinipoolCount.Uint64() != uint64(len(previous.MinipoolDetailsByNode[details.NodeAddress])) {
+			reloadNodeMinipools[details.NodeAddress] = true
+		}
+	}
+	state.logLine("3/5 - Reloaded node details (%s so far)", time.Since(start))
+
+	// Reload the changed minipools
+	state.MinipoolDetails = make([]rpstate.NativeMinipoolDetails, 0, len(previous.MinipoolDetails))
+	for _, details := range previous.MinipoolDetails {
+		if reloadNodeMinipools[details.NodeAddress] {
+			continue
+		}
+		if dirtyMinipools[details.MinipoolAddress] {
+			address := details.MinipoolAddress
+			details, err = rpstate.GetNativeMinipoolDetails(rp, contracts, address)
+			if err != nil {
+				return nil, fmt.Errorf("error getting details for minipool %s: %w", address.Hex(), err)
+			}
+		}
+		state.MinipoolDetails = append(state.MinipoolDetails, details)
+	}
+	for nodeAddress := range reloadNodeMinipools {
+		nodeMinipools, err := rpstate.GetNodeNativeMinipoolDetails(rp, contracts, nodeAddress)
+		if err != nil {
+			return nil, fmt.Errorf("error getting minipool details for node %s: %w", nodeAddress.Hex(), err)
+		}
+		state.MinipoolDetails = append(state.MinipoolDetails, nodeMinipools...)
+		for _, details := range nodeMinipools {
+			dirtyMinipools[details.MinipoolAddress] = true
+		}
+	}
+
+	// Make sure nothing was missed before trusting the update
+	nodeCount, err := node.GetNodeCount(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node count: %w", err)
+	}
+	minipoolCount, err := minipool.GetMinipoolCount(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool count: %w", err)
+	}
+	if nodeCount != uint64(len(state.NodeDetails)) || minipoolCount != uint64(len(state.MinipoolDetails)) {
+		state.logLine("Network has %d nodes and %d minipools but the updated state has %d and %d", nodeCount, minipoolCount, len(state.NodeDetails), len(state.MinipoolDetails))
+		return nil, ErrFullRefreshRequired
+	}
+
+	// Create the lookups and calculate avg node fees and distributor shares
+	pubkeys := state.createLookups()
+	for _, details := range state.NodeDetails {
+		rpstate.CalculateAverageFeeAndDistributorShares(rp, contracts, details, state.MinipoolDetailsByNode[details.NodeAddress])
+	}
+
+	// Get the validator stats from Beacon
+	statusMap, err := bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{
+		Slot: &slotNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	state.ValidatorDetails = statusMap
+	state.logLine("4/5 - Retrieved validator details (total time: %s)", time.Since(start))
+
+	// Recalculate the complete node and user shares for the minipools whose balances changed
+	mpds := []*rpstate.NativeMinipoolDetails{}
+	beaconBalances := []*big.Int{}
+	for i, mpd := range state.MinipoolDetails {
+		validator := state.ValidatorDetails[mpd.Pubkey]
+		previousValidator := previous.ValidatorDetails[mpd.Pubkey]
+		if !dirtyMinipools[mpd.MinipoolAddress] &&
+			mpd.NodeShareOfBalanceIncludingBeacon != nil &&
+			validator.Exists == previousValidator.Exists &&
+			validator.Balance == previousValidator.Balance {
+			continue
+		}
+
+		details := &state.MinipoolDetails[i]
+		details.NodeShareOfBalanceIncludingBeacon = nil
+		details.UserShareOfBalanceIncludingBeacon = nil
+		mpds = append(mpds, details)
+		if !validator.Exists {
+			beaconBalances = append(beaconBalances, big.NewInt(0))
+		} else {
+			beaconBalances = append(beaconBalances, eth.GweiToWei(float64(validator.Balance)))
+		}
+	}
+	err = rpstate.CalculateCompleteMinipoolShares(rp, contracts, mpds, beaconBalances)
+	if err != nil {
+		return nil, err
+	}
+	state.logLine("5/5 - Recalculated complete node and user balance shares for %d minipools (total time: %s)", len(mpds), time.Since(start))
+
+	return state, nil
+}
+
+// Get the nodes and minipools referenced by the contract events emitted since the previous state
+func getChangesFromEvents(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, contracts *rpstate.NetworkContracts, previous *NetworkState, elBlockNumber uint64, opts *bind.CallOpts) (map[common.Address]bool, []common.Address, map[common.Address]bool, error) {
+	dirtyNodes := map[common.Address]bool{}
+	newNodes := []common.Address{}
+	dirtyMinipools := map[common.Address]bool{}
+	if elBlockNumber == previous.ElBlockNumber {
+		return dirtyNodes, newNodes, dirtyMinipools, nil
+	}
+
+	// Get the block range to scan
+	fromBlock := uint64(0)
+	if previous.ElBlockNumber > incrementalReorgMargin {
+		fromBlock = previous.ElBlockNumber + 1 - incrementalReorgMargin
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+	fromBlockBig := big.NewInt(0).SetUint64(fromBlock)
+	toBlockBig := big.NewInt(0).SetUint64(elBlockNumber)
+
+	// Changes to these contracts affect every node, so they can't be applied incrementally
+	upgradeAddress, err := rp.GetAddress("rocketDAONodeTrustedUpgrade", opts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting upgrade contract address: %w", err)
+	}
+	networkWideContracts := map[common.Address]bool{
+		*upgradeAddress:                        true,
+		*contracts.RocketNetworkPrices.Address: true,
+	}
+
+	// Get the events from the node, minipool management, and token contracts
+	addresses := []common.Address{
+		*upgradeAddress,
+		*contracts.RocketNetworkPrices.Address,
+		*contracts.RocketNodeManager.Address,
+		*contracts.RocketNodeStaking.Address,
+		*contracts.RocketNodeDeposit.Address,
+		*contracts.RocketMinipoolManager.Address,
+		*contracts.RocketTokenRETH.Address,
+		*contracts.RocketTokenRPL.Address,
+		*contracts.RocketTokenRPLFixedSupply.Address,
+	}
+	if contracts.RocketMinipoolBondReducer != nil {
+		addresses = append(addresses, *contracts.RocketMinipoolBondReducer.Address)
+	}
+	logs, err := eth.GetLogs(rp, addresses, nil, intervalSize, fromBlockBig, toBlockBig, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting network contract events: %w", err)
+	}
+	candidateNodes := map[common.Address]bool{}
+	for _, eventLog := range logs {
+		if networkWideContracts[eventLog.Address] {
+			return nil, nil, nil, ErrFullRefreshRequired
+		}
+		for _, address := range getIndexedAddresses(eventLog) {
+			if _, exists := previous.NodeDetailsByAddress[address]; exists {
+				dirtyNodes[address] = true
+			} else if _, exists := previous.MinipoolDetailsByAddress[address]; exists {
+				dirtyMinipools[address] = true
+			} else if eventLog.Address == *contracts.RocketNodeManager.Address && !candidateNodes[address] {
+				candidateNodes[address] = true
+				newNodes = append(newNodes, address)
+			}
+		}
+	}
+
+	// Get the events emitted by the minipools themselves
+	minipoolAbi, err := rp.GetABI("rocketMinipool", opts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting minipool ABI: %w", err)
+	}
+	minipoolTopics := make([]common.Hash, 0, len(minipoolAbi.Events))
+	for _, event := range minipoolAbi.Events {
+		minipoolTopics = append(minipoolTopics, event.ID)
+	}
+	logs, err = eth.GetLogs(rp, nil, [][]common.Hash{minipoolTopics}, intervalSize, fromBlockBig, toBlockBig, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting minipool events: %w", err)
+	}
+	for _, eventLog := range logs {
+		if _, exists := previous.MinipoolDetailsByAddress[eventLog.Address]; exists {
+			dirtyMinipools[eventLog.Address] = true
+		}
+	}
+
+	return dirtyNodes, newNodes, dirtyMinipools, nil
+}
+
+// Get the minipools whose contract balances changed since the previous state, since ETH can arrive without an event (e.g. Beacon withdrawals).
+// Also returns the current ETH balances of each node in the previous state and its fee distributor.
+func getChangesFromBalances(contracts *rpstate.NetworkContracts, previous *NetworkState, dirtyMinipools map[common.Address]bool, opts *bind.CallOpts) ([]*big.Int, []*big.Int, error) {
+	addresses := make([]common.Address, len(previous.MinipoolDetails))
+	for i, mpd := range previous.MinipoolDetails {
+		addresses[i] = mpd.MinipoolAddress
+	}
+	balances, err := contracts.BalanceBatcher.GetEthBalances(addresses, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting minipool balances: %w", err)
+	}
+	for i, mpd := range previous.MinipoolDetails {
+		if mpd.Balance == nil || mpd.Balance.Cmp(balances[i]) != 0 {
+			dirtyMinipools[mpd.MinipoolAddress] = true
+		}
+	}
+
+	// Node and distributor balances don't need anything else reloaded when they change
+	nodeAddresses := make([]common.Address, len(previous.NodeDetails))
+	distributorAddresses := make([]common.Address, len(previous.NodeDetails))
+	for i, details := range previous.NodeDetails {
+		nodeAddresses[i] = details.NodeAddress
+		distributorAddresses[i] = details.FeeDistributorAddress
+	}
+	nodeBalances, err := contracts.BalanceBatcher.GetEthBalances(nodeAddresses, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting node balances: %w", err)
+	}
+	distributorBalances, err := contracts.BalanceBatcher.GetEthBalances(distributorAddresses, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting distributor balances: %w", err)
+	}
+
+	return nodeBalances, distributorBalances, nil
+}
+
+// Get the addresses stored in the indexed topics of an event
+func getIndexedAddresses(eventLog types.Log) []common.Address {
+	addresses := []common.Address{}
+	emptyAddress := common.Address{}
+	for _, topic := range eventLog.Topics[1:] {
+		// Addresses are left-padded with 12 zero bytes
+		isAddress := true
+		for _, b := range topic[:12] {
+			if b != 0 {
+				isAddress = false
+				break
+			}
+		}
+		address := common.BytesToAddress(topic[12:])
+		if isAddress && address != emptyAddress {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
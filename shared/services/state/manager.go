@@ -2,8 +2,10 @@ package state
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -24,6 +26,13 @@ type NetworkStateManager struct {
 	Network      cfgtypes.Network
 	ChainID      uint
 	BeaconConfig beacon.Eth2Config
+
+	// Internal fields
+	snapshotsEnabled bool
+	cacheEnabled     bool
+	trackedState     *NetworkState
+	lastFullRefresh  time.Time
+	trackedLock      sync.Mutex
 }
 
 // Create a new manager for the network state
@@ -52,13 +61,57 @@ func NewNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 
 }
 
+// Persist every state the manager creates to the snapshot folder, if the snapshot retention setting allows it
+func (m *NetworkStateManager) EnableSnapshots() {
+	m.snapshotsEnabled = m.cfg.Smartnode.StateSnapshotRetention.Value.(uint64) > 0
+}
+
+// Save every node state the manager creates to the cache used by the API, if the cache is enabled
+func (m *NetworkStateManager) EnableStateCache() {
+	m.cacheEnabled = m.cfg.Smartnode.CachedStateMaxSlots.Value.(uint64) > 0
+}
+
 // Get the state of the network using the latest Execution layer block
 func (m *NetworkStateManager) GetHeadState() (*NetworkState, error) {
 	targetSlot, err := m.GetHeadSlot()
 	if err != nil {
 		return nil, fmt.Errorf("error getting latest Beacon slot: %w", err)
 	}
-	return m.getState(targetSlot)
+	return m.GetUpdatedStateForSlot(targetSlot)
+}
+
+// Get the state of the network at the provided Beacon slot. If a state was already created by this function,
+// it's updated with the changes since then instead of rebuilding it from scratch until the full refresh interval passes.
+func (m *NetworkStateManager) GetUpdatedStateForSlot(slotNumber uint64) (*NetworkState, error) {
+	m.trackedLock.Lock()
+	defer m.trackedLock.Unlock()
+
+	// Try an incremental update first
+	refreshInterval := time.Duration(m.cfg.Smartnode.StateFullRefreshInterval.Value.(uint64)) * time.Minute
+	if m.trackedState != nil && slotNumber >= m.trackedState.BeaconSlotNumber && time.Since(m.lastFullRefresh) < refreshInterval {
+		state, err := UpdateNetworkState(m.cfg, m.rp, m.bc, m.log, m.trackedState, slotNumber)
+		if err == nil {
+			m.trackedState = state
+			m.saveSnapshot(SnapshotScope_Network, state)
+			return state, nil
+		}
+		if m.log != nil {
+			if errors.Is(err, ErrFullRefreshRequired) {
+				m.log.Println("Network-wide changes were detected, rebuilding the network state.")
+			} else {
+				m.log.Printlnf("WARNING: updating the network state failed, rebuilding it instead: %s", err.Error())
+			}
+		}
+	}
+
+	// Rebuild the state from scratch
+	state, err := m.getState(slotNumber)
+	if err != nil {
+		return nil, err
+	}
+	m.trackedState = state
+	m.lastFullRefresh = time.Now()
+	return state, nil
 }
 
 // Get the state of the network for a single node using the latest Execution layer block, along with the total effective RPL stake for the network
@@ -135,6 +188,7 @@ func (m *NetworkStateManager) getState(slotNumber uint64) (*NetworkState, error)
 	if err != nil {
 		return nil, err
 	}
+	m.saveSnapshot(SnapshotScope_Network, state)
 	return state, nil
 }
 
@@ -144,12 +198,30 @@ func (m *NetworkStateManager) getStateForNode(nodeAddress common.Address, slotNu
 	if err != nil {
 		return nil, nil, err
 	}
+	m.saveSnapshot(SnapshotScope_Node, state)
+	if m.cacheEnabled {
+		err = SaveCachedState(m.cfg.Smartnode.GetCachedNodeStatePath(true), state)
+		if err != nil && m.log != nil {
+			m.log.Printlnf("WARNING: %s", err.Error())
+		}
+	}
 	return state, totalEffectiveStake, nil
 }
 
+// Persist a state if snapshots are enabled; failing to save one doesn't fail the state update
+func (m *NetworkStateManager) saveSnapshot(scope string, state *NetworkState) {
+	if !m.snapshotsEnabled {
+		return
+	}
+	err := SaveSnapshot(m.cfg.Smartnode.GetStateSnapshotPath(true), scope, state, m.cfg.Smartnode.StateSnapshotRetention.Value.(uint64))
+	if err != nil && m.log != nil {
+		m.log.Printlnf("WARNING: %s", err.Error())
+	}
+}
+
 // Logs a line if the logger is specified
 func (m *NetworkStateManager) logLine(format string, v ...interface{}) {
 	if m.log != nil {
-		m.log.Printlnf(format, v)
+		m.log.Printlnf(format, v...)
 	}
 }
@@ -121,28 +121,8 @@ func CreateNetworkState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool,
 	}
 	state.logLine("3/5 - Retrieved minipool details (%s so far)", time.Since(start))
 
-	// Create the node lookup
-	for i, details := range state.NodeDetails {
-		state.NodeDetailsByAddress[details.NodeAddress] = &state.NodeDetails[i]
-	}
-
-	// Create the minipool lookups
-	pubkeys := make([]types.ValidatorPubkey, 0, len(state.MinipoolDetails))
-	emptyPubkey := types.ValidatorPubkey{}
-	for i, details := range state.MinipoolDetails {
-		state.MinipoolDetailsByAddress[details.MinipoolAddress] = &state.MinipoolDetails[i]
-		if details.Pubkey != emptyPubkey {
-			pubkeys = append(pubkeys, details.Pubkey)
-		}
-
-		// The map of nodes to minipools
-		nodeList, exists := state.MinipoolDetailsByNode[details.NodeAddress]
-		if !exists {
-			nodeList = []*rpstate.NativeMinipoolDetails{}
-		}
-		nodeList = append(nodeList, &state.MinipoolDetails[i])
-		state.MinipoolDetailsByNode[details.NodeAddress] = nodeList
-	}
+	// Create the node and minipool lookups
+	pubkeys := state.createLookups()
 
 	// Calculate avg node fees and distributor shares
 	for _, details := range state.NodeDetails {
@@ -255,28 +235,8 @@ func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.Rock
 	}
 	state.logLine("3/5 - Retrieved minipool details (%s so far)", time.Since(start))
 
-	// Create the node lookup
-	for i, details := range state.NodeDetails {
-		state.NodeDetailsByAddress[details.NodeAddress] = &state.NodeDetails[i]
-	}
-
-	// Create the minipool lookups
-	pubkeys := make([]types.ValidatorPubkey, 0, len(state.MinipoolDetails))
-	emptyPubkey := types.ValidatorPubkey{}
-	for i, details := range state.MinipoolDetails {
-		state.MinipoolDetailsByAddress[details.MinipoolAddress] = &state.MinipoolDetails[i]
-		if details.Pubkey != emptyPubkey {
-			pubkeys = append(pubkeys, details.Pubkey)
-		}
-
-		// The map of nodes to minipools
-		nodeList, exists := state.MinipoolDetailsByNode[details.NodeAddress]
-		if !exists {
-			nodeList = []*rpstate.NativeMinipoolDetails{}
-		}
-		nodeList = append(nodeList, &state.MinipoolDetails[i])
-		state.MinipoolDetailsByNode[details.NodeAddress] = nodeList
-	}
+	// Create the node and minipool lookups
+	pubkeys := state.createLookups()
 
 	// Calculate avg node fees and distributor shares
 	for _, details := range state.NodeDetails {
@@ -321,6 +281,34 @@ func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.Rock
 	return state, totalEffectiveStake, nil
 }
 
+// Create the node and minipool lookups, returning the pubkeys of every minipool that has one
+func (s *NetworkState) createLookups() []types.ValidatorPubkey {
+	// Create the node lookup
+	for i, details := range s.NodeDetails {
+		s.NodeDetailsByAddress[details.NodeAddress] = &s.NodeDetails[i]
+	}
+
+	// Create the minipool lookups
+	pubkeys := make([]types.ValidatorPubkey, 0, len(s.MinipoolDetails))
+	emptyPubkey := types.ValidatorPubkey{}
+	for i, details := range s.MinipoolDetails {
+		s.MinipoolDetailsByAddress[details.MinipoolAddress] = &s.MinipoolDetails[i]
+		if details.Pubkey != emptyPubkey {
+			pubkeys = append(pubkeys, details.Pubkey)
+		}
+
+		// The map of nodes to minipools
+		nodeList, exists := s.MinipoolDetailsByNode[details.NodeAddress]
+		if !exists {
+			nodeList = []*rpstate.NativeMinipoolDetails{}
+		}
+		nodeList = append(nodeList, &s.MinipoolDetails[i])
+		s.MinipoolDetailsByNode[details.NodeAddress] = nodeList
+	}
+
+	return pubkeys
+}
+
 // Calculate the true effective stakes of all nodes in the state, using the validator status
 // on Beacon as a reference for minipool eligibility instead of the EL-based minipool status
 func (s *NetworkState) CalculateTrueEffectiveStakes(scaleByParticipation bool) (map[common.Address]*big.Int, *big.Int, error) {
@@ -0,0 +1,217 @@
+package state
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The scope of a snapshot
+const (
+	SnapshotScope_Network string = "network"
+	SnapshotScope_Node    string = "node"
+
+	snapshotExtension string = ".json.gz"
+)
+
+// A copy of a NetworkState that's persisted to disk, for analyzing how the network changed over time
+type NetworkStateSnapshot struct {
+	Name             string                          `json:"name"`
+	Scope            string                          `json:"scope"`
+	Time             time.Time                       `json:"time"`
+	IsAtlasDeployed  bool                            `json:"isAtlasDeployed"`
+	ElBlockNumber    uint64                          `json:"elBlockNumber"`
+	BeaconSlotNumber uint64                          `json:"beaconSlotNumber"`
+	NetworkDetails   *rpstate.NetworkDetails         `json:"networkDetails"`
+	NodeDetails      []rpstate.NativeNodeDetails     `json:"nodeDetails"`
+	MinipoolDetails  []rpstate.NativeMinipoolDetails `json:"minipoolDetails"`
+	ValidatorDetails []beacon.ValidatorStatus        `json:"validatorDetails"`
+}
+
+// Get the name of the snapshot for a state
+func GetSnapshotName(scope string, slot uint64) string {
+	return fmt.Sprintf("%s-%d", scope, slot)
+}
+
+// Save a state to the snapshot folder, then delete the oldest snapshots of the same scope beyond the retention limit
+func SaveSnapshot(folder string, scope string, state *NetworkState, retention uint64) error {
+
+	// Write it to a temporary file first so readers never see a partial snapshot
+	snapshot := newSnapshot(GetSnapshotName(scope, state.BeaconSlotNumber), scope, state)
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating state snapshot folder: %w", err)
+	}
+	err = writeSnapshotFile(filepath.Join(folder, snapshot.Name+snapshotExtension), snapshot)
+	if err != nil {
+		return fmt.Errorf("error saving state snapshot %s: %w", snapshot.Name, err)
+	}
+
+	// Prune the old ones
+	names, err := ListSnapshots(folder)
+	if err != nil {
+		return err
+	}
+	scoped := []string{}
+	for _, name := range names {
+		if strings.HasPrefix(name, scope+"-") {
+			scoped = append(scoped, name)
+		}
+	}
+	for i := 0; i+int(retention) < len(scoped); i++ {
+		err = os.Remove(filepath.Join(folder, scoped[i]+snapshotExtension))
+		if err != nil {
+			return fmt.Errorf("error deleting old state snapshot %s: %w", scoped[i], err)
+		}
+	}
+	return nil
+
+}
+
+// Get the names of the snapshots in a folder, ordered by scope and then by slot
+func ListSnapshots(folder string) ([]string, error) {
+	entries, err := os.ReadDir(folder)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading state snapshot folder: %w", err)
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), snapshotExtension) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), snapshotExtension))
+	}
+	sort.Slice(names, func(i, j int) bool {
+		scopeI, slotI := parseSnapshotName(names[i])
+		scopeJ, slotJ := parseSnapshotName(names[j])
+		if scopeI != scopeJ {
+			return scopeI < scopeJ
+		}
+		return slotI < slotJ
+	})
+	return names, nil
+}
+
+// Load a snapshot by name
+func LoadSnapshot(folder string, name string) (*NetworkStateSnapshot, error) {
+	if strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid state snapshot name [%s]", name)
+	}
+	snapshot, err := readSnapshotFile(filepath.Join(folder, name+snapshotExtension))
+	if err != nil {
+		return nil, fmt.Errorf("error loading state snapshot %s: %w", name, err)
+	}
+	return snapshot, nil
+}
+
+// Convert a snapshot back into a network state. The Beacon config isn't part of the snapshot, so it's left empty.
+func (s *NetworkStateSnapshot) ToNetworkState() *NetworkState {
+	state := &NetworkState{
+		IsAtlasDeployed:          s.IsAtlasDeployed,
+		ElBlockNumber:            s.ElBlockNumber,
+		BeaconSlotNumber:         s.BeaconSlotNumber,
+		NetworkDetails:           s.NetworkDetails,
+		NodeDetails:              s.NodeDetails,
+		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetails:          s.MinipoolDetails,
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		ValidatorDetails:         make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(s.ValidatorDetails)),
+	}
+	state.createLookups()
+	for _, status := range s.ValidatorDetails {
+		state.ValidatorDetails[status.Pubkey] = status
+	}
+	return state
+}
+
+// Create a snapshot of a state
+func newSnapshot(name string, scope string, state *NetworkState) NetworkStateSnapshot {
+	snapshot := NetworkStateSnapshot{
+		Name:             name,
+		Scope:            scope,
+		Time:             time.Now().UTC(),
+		IsAtlasDeployed:  state.IsAtlasDeployed,
+		ElBlockNumber:    state.ElBlockNumber,
+		BeaconSlotNumber: state.BeaconSlotNumber,
+		NetworkDetails:   state.NetworkDetails,
+		NodeDetails:      state.NodeDetails,
+		MinipoolDetails:  state.MinipoolDetails,
+		ValidatorDetails: make([]beacon.ValidatorStatus, 0, len(state.ValidatorDetails)),
+	}
+
+	// Validator statuses are stored as a list since pubkeys can't be JSON map keys
+	for _, status := range state.ValidatorDetails {
+		snapshot.ValidatorDetails = append(snapshot.ValidatorDetails, status)
+	}
+	return snapshot
+}
+
+// Write a snapshot to a temporary file and move it into place, so readers never see a partial one
+func writeSnapshotFile(path string, snapshot NetworkStateSnapshot) error {
+	tempPath := path + ".tmp"
+	file, err := os.Create(tempPath)
+	if err != nil {
+		return err
+	}
+	writer := gzip.NewWriter(file)
+	err = json.NewEncoder(writer).Encode(snapshot)
+	if err == nil {
+		err = writer.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+	return os.Rename(tempPath, path)
+}
+
+// Read a snapshot file
+func readSnapshotFile(path string) (*NetworkStateSnapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var snapshot NetworkStateSnapshot
+	err = json.NewDecoder(reader).Decode(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Split a snapshot name into its scope and slot
+func parseSnapshotName(name string) (string, uint64) {
+	separator := strings.LastIndex(name, "-")
+	if separator == -1 {
+		return name, 0
+	}
+	slot, _ := strconv.ParseUint(name[separator+1:], 10, 64)
+	return name[:separator], slot
+}
@@ -0,0 +1,204 @@
+package taskmetrics
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Settings
+const (
+	namespace     string = "rocketpool"
+	subsystem     string = "task"
+	collectorName string = "task"
+)
+
+// The upper bounds of the task duration histogram buckets, in seconds
+var durationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800}
+
+// The run history of a single task
+type taskMetrics struct {
+	successes           uint64
+	failures            uint64
+	panics              uint64
+	consecutiveFailures float64
+	lastDuration        float64
+	lastRunTime         time.Time
+	durationSum         float64
+	durationBuckets     map[float64]uint64
+}
+
+// Represents the collector for the task loop metrics of a daemon
+type TaskCollector struct {
+
+	// The number of times each task has run, by result
+	runsDesc *prometheus.Desc
+
+	// The number of times each task has crashed
+	panicsDesc *prometheus.Desc
+
+	// The distribution of each task's run time
+	durationDesc *prometheus.Desc
+
+	// How long each task took the last time it ran
+	lastDurationDesc *prometheus.Desc
+
+	// The time each task last finished
+	lastRunTimeDesc *prometheus.Desc
+
+	// The number of times in a row each task has failed
+	consecutiveFailuresDesc *prometheus.Desc
+
+	// How long the last pass of the task loop took
+	passDurationDesc *prometheus.Desc
+
+	// The metrics for each task
+	tasks map[string]*taskMetrics
+
+	// How long the last pass took
+	lastPassDuration float64
+
+	// Mutex
+	lock sync.Mutex
+}
+
+// Create a new TaskCollector instance for a daemon
+func NewTaskCollector(process string) *TaskCollector {
+	labels := prometheus.Labels{"process": process}
+	return &TaskCollector{
+		runsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "runs_total"),
+			"The number of times the task has run, by result",
+			[]string{"task", "result"}, labels,
+		),
+		panicsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "panics_total"),
+			"The number of times the task has crashed with a panic; these are also counted as failed runs",
+			[]string{"task"}, labels,
+		),
+		durationDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "duration_seconds"),
+			"How long the task takes to run",
+			[]string{"task"}, labels,
+		),
+		lastDurationDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_duration_seconds"),
+			"How long the task took the last time it ran",
+			[]string{"task"}, labels,
+		),
+		lastRunTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_run_time"),
+			"The time the task last finished running",
+			[]string{"task"}, labels,
+		),
+		consecutiveFailuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "consecutive_failures"),
+			"The number of times in a row the task has failed",
+			[]string{"task"}, labels,
+		),
+		passDurationDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_pass_duration_seconds"),
+			"How long the last pass of the task loop took, including the time between tasks",
+			nil, labels,
+		),
+		tasks: map[string]*taskMetrics{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *TaskCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.runsDesc
+	channel <- collector.panicsDesc
+	channel <- collector.durationDesc
+	channel <- collector.lastDurationDesc
+	channel <- collector.lastRunTimeDesc
+	channel <- collector.consecutiveFailuresDesc
+	channel <- collector.passDurationDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *TaskCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	names := make([]string, 0, len(collector.tasks))
+	for name := range collector.tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		metrics := collector.tasks[name]
+		channel <- prometheus.MustNewConstMetric(
+			collector.runsDesc, prometheus.CounterValue, float64(metrics.successes), name, "success")
+		channel <- prometheus.MustNewConstMetric(
+			collector.runsDesc, prometheus.CounterValue, float64(metrics.failures), name, "failure")
+		channel <- prometheus.MustNewConstMetric(
+			collector.panicsDesc, prometheus.CounterValue, float64(metrics.panics), name)
+		buckets := map[float64]uint64{}
+		for bound, count := range metrics.durationBuckets {
+			buckets[bound] = count
+		}
+		channel <- prometheus.MustNewConstHistogram(
+			collector.durationDesc, metrics.successes+metrics.failures, metrics.durationSum, buckets, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastDurationDesc, prometheus.GaugeValue, metrics.lastDuration, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastRunTimeDesc, prometheus.GaugeValue, float64(metrics.lastRunTime.Unix()), name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.consecutiveFailuresDesc, prometheus.GaugeValue, metrics.consecutiveFailures, name)
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.passDurationDesc, prometheus.GaugeValue, collector.lastPassDuration)
+}
+
+// Get the name used to enable or disable the collector in the config
+func (collector *TaskCollector) GetName() string {
+	return collectorName
+}
+
+// Get the number of errors the collector has run into; it doesn't query anything, so this is always 0
+func (collector *TaskCollector) GetErrorCount() uint64 {
+	return 0
+}
+
+// Record a run of a task
+func (collector *TaskCollector) recordRun(task string, duration time.Duration, err error) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	metrics, exists := collector.tasks[task]
+	if !exists {
+		metrics = &taskMetrics{
+			durationBuckets: map[float64]uint64{},
+		}
+		for _, bound := range durationBuckets {
+			metrics.durationBuckets[bound] = 0
+		}
+		collector.tasks[task] = metrics
+	}
+
+	seconds := duration.Seconds()
+	if err == nil {
+		metrics.successes++
+		metrics.consecutiveFailures = 0
+	} else {
+		metrics.failures++
+		metrics.consecutiveFailures++
+		var panicErr *PanicError
+		if errors.As(err, &panicErr) {
+			metrics.panics++
+		}
+	}
+	metrics.lastDuration = seconds
+	metrics.lastRunTime = time.Now()
+	metrics.durationSum += seconds
+	for _, bound := range durationBuckets {
+		if seconds <= bound {
+			metrics.durationBuckets[bound]++
+		}
+	}
+}
+
+// Record a pass of the task loop
+func (collector *TaskCollector) recordPass(duration time.Duration) {
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.lastPassDuration = duration.Seconds()
+}
@@ -0,0 +1,36 @@
+package taskmetrics
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// The error returned by a task that panicked instead of returning
+type PanicError struct {
+	// The name of the task that panicked
+	Task string
+
+	// The value the task panicked with
+	Value interface{}
+
+	// The stack trace of the goroutine at the time of the panic
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task %s crashed: %v", e.Task, e.Value)
+}
+
+// Run a task, turning a panic into a PanicError so it doesn't take down the whole daemon
+func runRecovered(task string, run func() error) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = &PanicError{
+				Task:  task,
+				Value: value,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+	return run()
+}
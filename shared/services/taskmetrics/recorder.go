@@ -0,0 +1,91 @@
+package taskmetrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Span attribute names
+const (
+	processAttribute  string = "rocketpool.process"
+	taskAttribute     string = "rocketpool.task"
+	failuresAttribute string = "rocketpool.failures"
+)
+
+// Records how long each of a daemon's tasks take and whether they succeed, as metrics and as traces if tracing is enabled
+type Recorder struct {
+	process   string
+	collector *TaskCollector
+	exporter  *TraceExporter
+
+	// Called when a task panics, after it has been recovered
+	panicHandler func(err *PanicError)
+}
+
+// A single pass of a daemon's task loop
+type Pass struct {
+	recorder *Recorder
+	span     *Span
+	start    time.Time
+	failures int
+}
+
+// Create a recorder for a daemon; the exporter can be nil if tracing is disabled
+func NewRecorder(process string, exporter *TraceExporter) *Recorder {
+	return &Recorder{
+		process:   process,
+		collector: NewTaskCollector(process),
+		exporter:  exporter,
+	}
+}
+
+// Get the collector for the task metrics
+func (r *Recorder) GetCollector() *TaskCollector {
+	return r.collector
+}
+
+// Set the function that is called when a task panics, such as to log its stack trace or send an alert
+func (r *Recorder) OnPanic(handler func(err *PanicError)) {
+	r.panicHandler = handler
+}
+
+// Start a pass of the task loop
+func (r *Recorder) StartPass() *Pass {
+	span := r.exporter.StartSpan(r.process+"-task-loop", nil)
+	span.SetAttribute(processAttribute, r.process)
+	return &Pass{
+		recorder: r,
+		span:     span,
+		start:    time.Now(),
+	}
+}
+
+// Run a task as part of the pass, recording its duration and result.
+// If the task panics, it is recovered and a PanicError is returned so the task loop can carry on.
+func (p *Pass) RunTask(task string, run func() error) error {
+	span := p.recorder.exporter.StartSpan(task, p.span)
+	span.SetAttribute(processAttribute, p.recorder.process)
+	span.SetAttribute(taskAttribute, task)
+
+	start := time.Now()
+	err := runRecovered(task, run)
+	p.recorder.collector.recordRun(task, time.Since(start), err)
+	span.End(err)
+	if err != nil {
+		p.failures++
+	}
+
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) && p.recorder.panicHandler != nil {
+		p.recorder.panicHandler(panicErr)
+	}
+	return err
+}
+
+// Finish the pass
+func (p *Pass) End() {
+	p.recorder.collector.recordPass(time.Since(p.start))
+	p.span.SetAttribute(failuresAttribute, strconv.Itoa(p.failures))
+	p.span.End(nil)
+}
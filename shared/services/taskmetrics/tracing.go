@@ -0,0 +1,232 @@
+package taskmetrics
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	tracesPath         string        = "/v1/traces"
+	traceExportTimeout time.Duration = 30 * time.Second
+	traceExportPeriod  time.Duration = 10 * time.Second
+	maxQueuedSpans     int           = 2048
+	tracerScopeName    string        = "github.com/rocket-pool/smartnode"
+
+	// OTLP span kinds and status codes
+	spanKindInternal int = 1
+	statusCodeOk     int = 1
+	statusCodeError  int = 2
+)
+
+// A timed operation in a trace. A nil span is valid and does nothing, so callers don't need to check if tracing is enabled.
+type Span struct {
+	exporter   *TraceExporter
+	traceId    [16]byte
+	spanId     [8]byte
+	parentId   [8]byte
+	hasParent  bool
+	name       string
+	start      time.Time
+	end        time.Time
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+// Sends spans to an OpenTelemetry collector with the OTLP/HTTP JSON protocol, in batches
+type TraceExporter struct {
+	url         string
+	headers     map[string]string
+	serviceName string
+	client      *http.Client
+	log         *log.ColorLogger
+
+	queue []*Span
+	lock  sync.Mutex
+}
+
+// Create a trace exporter for a daemon from the tracing config, and start sending its spans in the background.
+// Returns nil if tracing is disabled.
+func NewTraceExporter(cfg *config.RocketPoolConfig, process string, logger *log.ColorLogger) (*TraceExporter, error) {
+	endpoint := strings.TrimRight(cfg.Tracing.Endpoint.Value.(string), "/")
+	if endpoint == "" {
+		return nil, nil
+	}
+	headers, err := cfg.Tracing.GetHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the trace export headers: %w", err)
+	}
+
+	e := &TraceExporter{
+		url:         endpoint + tracesPath,
+		headers:     headers,
+		serviceName: fmt.Sprintf("%s-%s", cfg.Tracing.ServiceName.Value.(string), process),
+		client:      &http.Client{Timeout: traceExportTimeout},
+		log:         logger,
+		queue:       []*Span{},
+	}
+	go func() {
+		for {
+			time.Sleep(traceExportPeriod)
+			if err := e.flush(); err != nil {
+				e.log.Printlnf("WARNING: couldn't export traces: %s", err.Error())
+			}
+		}
+	}()
+	return e, nil
+}
+
+// Start a span, as the root of a new trace if it has no parent
+func (e *TraceExporter) StartSpan(name string, parent *Span) *Span {
+	if e == nil {
+		return nil
+	}
+	span := &Span{
+		exporter:   e,
+		name:       name,
+		start:      time.Now(),
+		attributes: map[string]string{},
+	}
+	rand.Read(span.spanId[:])
+	if parent != nil {
+		span.traceId = parent.traceId
+		span.parentId = parent.spanId
+		span.hasParent = true
+	} else {
+		rand.Read(span.traceId[:])
+	}
+	return span
+}
+
+// Set an attribute on the span
+func (s *Span) SetAttribute(key string, value string) {
+	if s == nil {
+		return
+	}
+	s.attributes[key] = value
+}
+
+// End the span, marking it as failed if there was an error, and queue it for export
+func (s *Span) End(err error) {
+	if s == nil || s.ended {
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.err = err
+	s.exporter.enqueue(s)
+}
+
+// Queue a finished span, dropping the oldest ones if the collector has fallen behind
+func (e *TraceExporter) enqueue(span *Span) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.queue = append(e.queue, span)
+	if len(e.queue) > maxQueuedSpans {
+		e.queue = e.queue[len(e.queue)-maxQueuedSpans:]
+	}
+}
+
+// Send the queued spans to the collector
+func (e *TraceExporter) flush() error {
+	e.lock.Lock()
+	spans := e.queue
+	e.queue = []*Span{}
+	e.lock.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(e.buildRequest(spans))
+	if err != nil {
+		return fmt.Errorf("error encoding spans: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), traceExportTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		request.Header.Set(name, value)
+	}
+	response, err := e.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error sending %d spans to %s: %w", len(spans), e.url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("%s returned status %d: %s", e.url, response.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// Build an OTLP ExportTraceServiceRequest for a batch of spans
+func (e *TraceExporter) buildRequest(spans []*Span) map[string]interface{} {
+	otlpSpans := make([]map[string]interface{}, len(spans))
+	for i, span := range spans {
+		status := map[string]interface{}{"code": statusCodeOk}
+		if span.err != nil {
+			status = map[string]interface{}{"code": statusCodeError, "message": span.err.Error()}
+		}
+		otlpSpan := map[string]interface{}{
+			"traceId":           hex.EncodeToString(span.traceId[:]),
+			"spanId":            hex.EncodeToString(span.spanId[:]),
+			"name":              span.name,
+			"kind":              spanKindInternal,
+			"startTimeUnixNano": strconv.FormatInt(span.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(span.end.UnixNano(), 10),
+			"attributes":        getOtlpAttributes(span.attributes),
+			"status":            status,
+		}
+		if span.hasParent {
+			otlpSpan["parentSpanId"] = hex.EncodeToString(span.parentId[:])
+		}
+		otlpSpans[i] = otlpSpan
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": getOtlpAttributes(map[string]string{"service.name": e.serviceName}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": tracerScopeName},
+						"spans": otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Convert attributes to OTLP key-value pairs
+func getOtlpAttributes(attributes map[string]string) []interface{} {
+	otlpAttributes := []interface{}{}
+	for key, value := range attributes {
+		otlpAttributes = append(otlpAttributes, map[string]interface{}{
+			"key":   key,
+			"value": map[string]interface{}{"stringValue": value},
+		})
+	}
+	return otlpAttributes
+}
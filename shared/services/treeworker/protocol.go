@@ -0,0 +1,44 @@
+package treeworker
+
+import (
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The kinds of generation the worker can do
+const (
+	// Regenerate the tree for an interval that has already been submitted
+	Mode_Interval string = "interval"
+
+	// Generate the tree for an interval that has just ended, from a snapshot picked by the watchtower
+	Mode_Snapshot string = "snapshot"
+)
+
+// Settings
+const (
+	// The files the worker creates in its output folder
+	socketFilename      string = "worker.sock"
+	rewardsFilename     string = "rewards.json"
+	performanceFilename string = "performance.json"
+
+	// The largest message the worker can send over its socket
+	maxMessageSize int = 1024 * 1024
+)
+
+// The outcome of a tree generation run, sent by the worker once it's done
+type Result struct {
+	// The Merkle root of the tree submitted by the Oracle DAO, in interval mode
+	CanonicalMerkleRoot string `json:"canonicalMerkleRoot,omitempty"`
+
+	// The phases of generation the worker timed, if profiling is enabled
+	Profile *rprewards.ProfileReport `json:"profile,omitempty"`
+
+	// Set if generation failed
+	Error string `json:"error,omitempty"`
+}
+
+// A line sent by the worker over its socket; either a log entry or the result
+type message struct {
+	*log.Entry
+	Result *Result `json:"result,omitempty"`
+}
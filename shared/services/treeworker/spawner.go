@@ -0,0 +1,227 @@
+package treeworker
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The name of the daemon command that runs the worker
+const WorkerCommand string = "tree-worker"
+
+// The snapshot an interval's tree is generated from in snapshot mode
+type Snapshot struct {
+	Index           uint64
+	StartTime       time.Time
+	EndTime         time.Time
+	ConsensusBlock  uint64
+	ExecutionBlock  uint64
+	IntervalsPassed uint64
+}
+
+// Runs rewards tree generation in worker processes, so running out of memory or crashing during generation
+// only takes down the worker instead of the whole daemon
+type Spawner struct {
+	cfg *config.RocketPoolConfig
+
+	// The global flags the daemon was started with, passed on so the worker loads the same config
+	globalArgs []string
+}
+
+// Create a new spawner for the daemon running the given command
+func NewSpawner(c *cli.Context) (*Spawner, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return &Spawner{
+		cfg:        cfg,
+		globalArgs: getGlobalArgs(c),
+	}, nil
+}
+
+// Check if rewards trees should be generated in a worker process
+func (s *Spawner) IsEnabled() bool {
+	return s.cfg.Smartnode.UseTreeWorker.Value == true
+}
+
+// Regenerate the rewards file for an interval that has already been submitted in a worker process.
+// Returns the Merkle root of the tree submitted by the Oracle DAO so the caller can compare it against the generated one.
+// The phases the worker timed are added to the profiler, which may be nil.
+func (s *Spawner) GenerateInterval(index uint64, logPrefix string, logger *log.ColorLogger, profiler *rprewards.Profiler) (*rprewards.RewardsFile, common.Hash, error) {
+	rewardsFile, result, err := s.run(Mode_Interval, []string{
+		"--index", strconv.FormatUint(index, 10),
+		"--log-prefix", logPrefix,
+	}, logger, profiler)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	return rewardsFile, common.HexToHash(result.CanonicalMerkleRoot), nil
+}
+
+// Generate the rewards file for an interval that has just ended in a worker process.
+// The phases the worker timed are added to the profiler, which may be nil.
+func (s *Spawner) GenerateSnapshot(snapshot Snapshot, logPrefix string, logger *log.ColorLogger, profiler *rprewards.Profiler) (*rprewards.RewardsFile, error) {
+	rewardsFile, _, err := s.run(Mode_Snapshot, []string{
+		"--index", strconv.FormatUint(snapshot.Index, 10),
+		"--start-time", strconv.FormatInt(snapshot.StartTime.Unix(), 10),
+		"--end-time", strconv.FormatInt(snapshot.EndTime.Unix(), 10),
+		"--consensus-block", strconv.FormatUint(snapshot.ConsensusBlock, 10),
+		"--execution-block", strconv.FormatUint(snapshot.ExecutionBlock, 10),
+		"--intervals-passed", strconv.FormatUint(snapshot.IntervalsPassed, 10),
+		"--log-prefix", logPrefix,
+	}, logger, profiler)
+	return rewardsFile, err
+}
+
+// Run a worker and wait for it to finish, printing its log entries with the logger
+func (s *Spawner) run(mode string, args []string, logger *log.ColorLogger, profiler *rprewards.Profiler) (*rprewards.RewardsFile, *Result, error) {
+
+	// Create a folder for the worker's socket and output files
+	folder, err := os.MkdirTemp("", "rocketpool-tree-worker-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating tree worker folder: %w", err)
+	}
+	defer os.RemoveAll(folder)
+	listener, err := net.Listen("unix", filepath.Join(folder, socketFilename))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listening on tree worker socket: %w", err)
+	}
+	defer listener.Close()
+
+	// Start the worker, and stop listening if it exits before connecting
+	args = append([]string{"--output", folder}, args...)
+	cmd := s.createCommand(append(args, mode), logger)
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("error starting tree worker: %w", err)
+	}
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+		listener.Close()
+	}()
+
+	// Print its log entries until it disconnects
+	result := readMessages(listener, logger)
+	exitErr := <-exited
+	if result == nil {
+		if exitErr != nil {
+			return nil, nil, fmt.Errorf("tree worker stopped before finishing: %w", exitErr)
+		}
+		return nil, nil, fmt.Errorf("tree worker stopped before finishing")
+	}
+	profiler.Merge(result.Profile)
+	if result.Error != "" {
+		return nil, result, errors.New(result.Error)
+	}
+
+	// Load the generated files
+	rewardsFile := new(rprewards.RewardsFile)
+	if err := readJson(filepath.Join(folder, rewardsFilename), rewardsFile); err != nil {
+		return nil, result, err
+	}
+	if err := readJson(filepath.Join(folder, performanceFilename), &rewardsFile.MinipoolPerformanceFile); err != nil {
+		return nil, result, err
+	}
+	return rewardsFile, result, nil
+
+}
+
+// Create the command that runs the worker with the configured resource limits
+func (s *Spawner) createCommand(args []string, logger *log.ColorLogger) *exec.Cmd {
+	executable, err := os.Executable()
+	if err != nil {
+		executable = os.Args[0]
+	}
+	args = append(append(append([]string{}, s.globalArgs...), WorkerCommand), args...)
+	cmd := exec.Command(executable, args...)
+
+	// The priority has to be set before the worker starts, since it only applies to threads created afterwards
+	if nice := s.cfg.Smartnode.TreeWorkerNiceLevel.Value.(uint64); nice > 0 {
+		nicePath, err := exec.LookPath("nice")
+		if err != nil {
+			logger.Printlnf("WARNING: couldn't find the nice command, so the tree worker will run at the normal priority: %s", err.Error())
+		} else {
+			cmd = exec.Command(nicePath, append([]string{"-n", strconv.FormatUint(nice, 10), executable}, args...)...)
+		}
+	}
+
+	cmd.Env = os.Environ()
+	if limit := s.cfg.Smartnode.TreeWorkerMemoryLimit.Value.(uint64); limit > 0 {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GOMEMLIMIT=%dMiB", limit))
+	}
+
+	// Crashes like running out of memory are printed by the Go runtime rather than logged, so pass them through
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// Get the global flags the daemon was started with, which are the arguments before its command
+func getGlobalArgs(c *cli.Context) []string {
+	names := append([]string{c.Command.Name}, c.Command.Aliases...)
+	for i, arg := range os.Args[1:] {
+		for _, name := range names {
+			if arg == name {
+				return os.Args[1 : i+1]
+			}
+		}
+	}
+	return []string{}
+}
+
+// Print the log entries sent by a worker until it disconnects, and return its result if it sent one
+func readMessages(listener net.Listener, logger *log.ColorLogger) *Result {
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+
+	var result *Result
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			logger.Printlnf("WARNING: ignoring invalid message from the tree worker: %s", err.Error())
+			continue
+		}
+		if msg.Result != nil {
+			result = msg.Result
+		} else if msg.Entry != nil {
+			logger.Relay(*msg.Entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		logger.Printlnf("WARNING: error reading from the tree worker: %s", err.Error())
+	}
+	return result
+}
+
+// Read a JSON file created by the worker
+func readJson(path string, value interface{}) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening tree worker output [%s]: %w", path, err)
+	}
+	defer file.Close()
+	if err := json.NewDecoder(bufio.NewReader(file)).Decode(value); err != nil {
+		return fmt.Errorf("error reading tree worker output [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package treeworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The connection to the daemon that spawned the worker
+type daemonConn struct {
+	conn net.Conn
+	lock sync.Mutex
+}
+
+// Send a log entry to the daemon; the log package writes each one as a line of JSON
+func (d *daemonConn) Write(data []byte) (int, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.conn.Write(data)
+}
+
+// Send the result to the daemon
+func (d *daemonConn) sendResult(result Result) error {
+	bytes, err := json.Marshal(message{
+		Result: &result,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing tree worker result: %w", err)
+	}
+	_, err = d.Write(append(bytes, '\n'))
+	if err != nil {
+		return fmt.Errorf("error sending tree worker result: %w", err)
+	}
+	return nil
+}
+
+// Generate a rewards tree in this process for the daemon that spawned it, sending it the log entries and the result
+// over the socket in the output folder
+func RunWorker(c *cli.Context, mode string) error {
+
+	// Connect to the daemon
+	folder := c.String("output")
+	conn, err := net.Dial("unix", filepath.Join(folder, socketFilename))
+	if err != nil {
+		return fmt.Errorf("error connecting to the tree worker socket: %w", err)
+	}
+	defer conn.Close()
+	daemon := &daemonConn{
+		conn: conn,
+	}
+	if err := services.ConfigureWorkerLogging(c, daemon); err != nil {
+		return daemon.sendResult(Result{
+			Error: err.Error(),
+		})
+	}
+
+	// Stop if the daemon goes away, since nothing would use the result
+	go func() {
+		_, _ = io.Copy(io.Discard, conn)
+		os.Exit(1)
+	}()
+
+	// Generate the tree and save it where the daemon will read it
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return daemon.sendResult(Result{
+			Error: err.Error(),
+		})
+	}
+	profiler := rprewards.NewProfiler(cfg, c.Uint64("index"))
+	logger := log.NewModuleLogger(log.ModuleRewards, log.LevelInfo, color.Reset)
+	result := Result{}
+	var rewardsFile *rprewards.RewardsFile
+	switch mode {
+	case Mode_Interval:
+		rewardsFile, result.CanonicalMerkleRoot, err = generateInterval(c, logger, profiler)
+	case Mode_Snapshot:
+		rewardsFile, err = generateSnapshot(c, logger, profiler)
+	default:
+		err = fmt.Errorf("unknown tree worker mode '%s'", mode)
+	}
+	if err == nil {
+		err = saveOutput(folder, rewardsFile)
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.Profile = profiler.GetReport()
+	return daemon.sendResult(result)
+
+}
+
+// Regenerate the rewards file for an interval that has already been submitted, returning the canonical Merkle root
+func generateInterval(c *cli.Context, logger log.ColorLogger, profiler *rprewards.Profiler) (*rprewards.RewardsFile, string, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, "", err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, "", err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, "", err
+	}
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &logger)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating network state manager: %w", err)
+	}
+
+	rewardsFile, rewardsEvent, err := rprewards.GenerateRewardsFileForInterval(logger, c.String("log-prefix"), rp, cfg, bc, m, c.Uint64("index"), profiler)
+	if err != nil {
+		return nil, "", err
+	}
+	return rewardsFile, rewardsEvent.MerkleRoot.Hex(), nil
+}
+
+// Generate the rewards file for an interval that has just ended from the snapshot the daemon picked
+func generateSnapshot(c *cli.Context, logger log.ColorLogger, profiler *rprewards.Profiler) (*rprewards.RewardsFile, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	logPrefix := c.String("log-prefix")
+
+	// Get a client that has the state for the snapshot's EL block
+	elBlockNumber := big.NewInt(0).SetUint64(c.Uint64("execution-block"))
+	client, err := eth1.GetBestApiClient(rp, cfg, func(message string) {
+		logger.Printlnf("%s %s", logPrefix, message)
+	}, elBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	elBlockHeader, err := client.Client.HeaderByNumber(context.Background(), elBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting header for EL block %s: %w", elBlockNumber.String(), err)
+	}
+
+	return rprewards.GenerateRewardsFileForSnapshot(
+		logger,
+		logPrefix,
+		client,
+		cfg,
+		bc,
+		c.Uint64("index"),
+		time.Unix(c.Int64("start-time"), 0),
+		time.Unix(c.Int64("end-time"), 0),
+		c.Uint64("consensus-block"),
+		elBlockHeader,
+		c.Uint64("intervals-passed"),
+		profiler,
+	)
+}
+
+// Save the generated files for the daemon to read
+func saveOutput(folder string, rewardsFile *rprewards.RewardsFile) error {
+	if err := writeJson(filepath.Join(folder, rewardsFilename), rewardsFile); err != nil {
+		return err
+	}
+	return writeJson(filepath.Join(folder, performanceFilename), &rewardsFile.MinipoolPerformanceFile)
+}
+
+// Write a value to a JSON file
+func writeJson(path string, value interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	err = json.NewEncoder(file).Encode(value)
+	closeErr := file.Close()
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("error closing %s: %w", path, closeErr)
+	}
+	return nil
+}
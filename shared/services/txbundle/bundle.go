@@ -0,0 +1,145 @@
+package txbundle
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// A transaction in a bundle
+type Step struct {
+	// What the transaction does, shown when the bundle is confirmed and submitted
+	Description string
+
+	// Check that the transaction can be submitted and get its gas estimate.
+	// This runs right before the transaction is submitted, once the ones before it have been included,
+	// so it can depend on their effects (such as an approval).
+	Check func() (rocketpool.GasInfo, error)
+
+	// Submit the transaction
+	Submit func() (common.Hash, error)
+}
+
+// An ordered set of transactions that make up one operation, such as approving RPL and then staking it.
+// The whole bundle is confirmed once and uses the same fees for every transaction. Each transaction is only
+// submitted once the one before it has been included, since later steps usually depend on the earlier ones;
+// if a private relay is enabled, each of them goes through it.
+type Bundle struct {
+	rp    *rpsvc.Client
+	steps []Step
+}
+
+// Create a new, empty bundle
+func NewBundle(rp *rpsvc.Client) *Bundle {
+	return &Bundle{
+		rp: rp,
+	}
+}
+
+// Add a transaction to the end of the bundle
+func (b *Bundle) Add(step Step) {
+	b.steps = append(b.steps, step)
+}
+
+// Get the number of transactions in the bundle
+func (b *Bundle) Len() int {
+	return len(b.steps)
+}
+
+// Print the bundle, ask for its fees and a single confirmation, then submit each transaction in order.
+// Returns false if the user cancelled it.
+func (b *Bundle) Run(c *cli.Context, confirmation string) (bool, error) {
+	if len(b.steps) == 0 {
+		return true, nil
+	}
+
+	// Queued transactions run on their own, so the later steps couldn't wait for the earlier ones to be included
+	if whenGasBelow, _ := b.rp.GetTxQueueSettings(); whenGasBelow > 0 && len(b.steps) > 1 {
+		return false, fmt.Errorf("--when-gas-below can't be used for an operation that takes %d transactions, since each one must wait for the one before it; run it again without --when-gas-below, or complete the earlier steps first", len(b.steps))
+	}
+
+	// Only the first transaction's gas can be estimated up front
+	gasInfo, err := b.steps[0].Check()
+	if err != nil {
+		return false, err
+	}
+
+	// Print the bundle
+	if len(b.steps) > 1 {
+		fmt.Printf("This will submit %d transactions, each one once the one before it has been included in a block:\n", len(b.steps))
+		for i, step := range b.steps {
+			fmt.Printf("\t%d. %s\n", i+1, step.Description)
+		}
+		fmt.Println("The fees below apply to every transaction. The cost shown is for the first one; the others' gas will be estimated right before they're submitted.")
+		fmt.Println()
+		if c.GlobalUint64("nonce") != 0 {
+			cliutils.PrintMultiTransactionNonceWarning()
+		}
+	}
+
+	// Assign the fees once for the whole bundle
+	err = gas.AssignMaxFeeAndLimit(gasInfo, b.rp, c.Bool("yes"))
+	if err != nil {
+		return false, err
+	}
+	maxFee, maxPriorityFee, gasLimit := b.rp.GetGasSettings()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(confirmation)) {
+		fmt.Println("Cancelled.")
+		return false, nil
+	}
+
+	// Submit each transaction in order
+	for i, step := range b.steps {
+		if i > 0 {
+			if _, err := step.Check(); err != nil {
+				return false, b.getStepError(i, err)
+			}
+		}
+
+		// The client only keeps the fees for one transaction, so they're reassigned for each one
+		b.rp.AssignGasSettings(maxFee, maxPriorityFee, gasLimit)
+		hash, err := step.Submit()
+		if err != nil {
+			return false, b.getStepError(i, err)
+		}
+
+		if len(b.steps) > 1 {
+			fmt.Printf("%s (%d/%d)...\n", step.Description, i+1, len(b.steps))
+		} else {
+			fmt.Printf("%s...\n", step.Description)
+		}
+		if i < len(b.steps)-1 {
+			cliutils.PrintTransactionHashNoCancel(b.rp, hash)
+		} else {
+			cliutils.PrintTransactionHash(b.rp, hash)
+		}
+		if _, err = b.rp.WaitForTransaction(hash); err != nil {
+			return false, b.getStepError(i, err)
+		}
+
+		// If a custom nonce is set, increment it for the next transaction
+		if c.GlobalUint64("nonce") != 0 {
+			b.rp.IncrementCustomNonce()
+		}
+	}
+	return true, nil
+}
+
+// Describe a transaction in the bundle failing, along with how far the bundle got
+func (b *Bundle) getStepError(index int, err error) error {
+	if len(b.steps) == 1 {
+		return err
+	}
+	if index == 0 {
+		return fmt.Errorf("transaction 1 of %d (%s) failed, so none of them were completed: %w", len(b.steps), b.steps[index].Description, err)
+	}
+	return fmt.Errorf("transaction %d of %d (%s) failed after the first %d were completed: %w", index+1, len(b.steps), b.steps[index].Description, index, err)
+}
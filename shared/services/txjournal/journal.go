@@ -0,0 +1,270 @@
+package txjournal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Transaction statuses
+type Status string
+
+const (
+	Status_Pending Status = "pending"
+	Status_Success Status = "success"
+	Status_Failed  Status = "failed"
+)
+
+// Transaction categories, used to break down how much the node spends on gas
+const (
+	Category_Minipool   string = "minipool"
+	Category_Claims     string = "claims"
+	Category_Watchtower string = "watchtower"
+	Category_Other      string = "other"
+)
+
+// A record of a transaction submitted by the daemon
+type Entry struct {
+	Hash           common.Hash `json:"hash"`
+	Source         string      `json:"source"`
+	Module         string      `json:"module"`
+	Command        string      `json:"command"`
+	SubmittedAt    time.Time   `json:"submittedAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+	Nonce          uint64      `json:"nonce"`
+	GasLimit       uint64      `json:"gasLimit"`
+	MaxFee         *big.Int    `json:"maxFee"`
+	MaxPriorityFee *big.Int    `json:"maxPriorityFee"`
+	Status         Status      `json:"status"`
+	BlockNumber    uint64      `json:"blockNumber,omitempty"`
+	GasUsed        uint64      `json:"gasUsed,omitempty"`
+	GasCost        *big.Int    `json:"gasCost,omitempty"`
+}
+
+// Get the category of the command that submitted the transaction
+func (e *Entry) Category() string {
+	switch {
+	case e.Source == "watchtower":
+		return Category_Watchtower
+	case strings.Contains(e.Command, "claim"):
+		return Category_Claims
+	case e.Module == "minipool":
+		return Category_Minipool
+	default:
+		return Category_Other
+	}
+}
+
+// A journal of the transactions the daemon has submitted, stored as one JSON entry per line.
+// Updates are appended as new entries, so the last entry for a hash is its current state.
+type Journal struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a new journal
+func NewJournal(path string) *Journal {
+	return &Journal{
+		path: path,
+	}
+}
+
+// Record a newly submitted transaction, filling in its nonce and gas settings from the client
+func (j *Journal) RecordSubmission(ec rocketpool.ExecutionClient, source string, module string, command string, hash common.Hash) error {
+	entry := Entry{
+		Hash:        hash,
+		Source:      source,
+		Module:      module,
+		Command:     command,
+		SubmittedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		Status:      Status_Pending,
+	}
+	tx, _, err := ec.TransactionByHash(context.Background(), hash)
+	if err != nil {
+		return fmt.Errorf("error getting transaction %s: %w", hash.Hex(), err)
+	}
+	entry.Nonce = tx.Nonce()
+	entry.GasLimit = tx.Gas()
+	entry.MaxFee = tx.GasFeeCap()
+	entry.MaxPriorityFee = tx.GasTipCap()
+	return j.append(entry)
+}
+
+// Get the current state of a transaction, checking the chain for its receipt if it's still pending
+func (j *Journal) GetStatus(ec rocketpool.ExecutionClient, hash common.Hash) (*Entry, error) {
+	entries, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	entry, exists := entries[hash]
+	if !exists {
+		return nil, nil
+	}
+	if entry.Status != Status_Pending {
+		return &entry, nil
+	}
+
+	// Update the entry if the transaction has been mined
+	receipt, err := ec.TransactionReceipt(context.Background(), hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return &entry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting receipt for transaction %s: %w", hash.Hex(), err)
+	}
+	entry.UpdatedAt = time.Now()
+	entry.BlockNumber = receipt.BlockNumber.Uint64()
+	entry.GasUsed = receipt.GasUsed
+	entry.GasCost, err = getGasCost(ec, &entry, receipt)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		entry.Status = Status_Success
+	} else {
+		entry.Status = Status_Failed
+	}
+	if err := j.append(entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Get the current state of the most recently submitted transactions, newest first (limit of 0 returns all of them)
+func (j *Journal) GetHistory(limit int) ([]Entry, error) {
+	entries, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	history := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		history = append(history, entry)
+	}
+	sort.Slice(history, func(a, b int) bool {
+		return history[a].SubmittedAt.After(history[b].SubmittedAt)
+	})
+	if limit > 0 && len(history) > limit {
+		history = history[:limit]
+	}
+	return history, nil
+}
+
+// Get the transactions that are still pending, checking the chain for any that have been mined since, ordered by nonce
+func (j *Journal) GetPending(ec rocketpool.ExecutionClient) ([]Entry, error) {
+	entries, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	pending := []Entry{}
+	for hash, entry := range entries {
+		if entry.Status != Status_Pending {
+			continue
+		}
+		current, err := j.GetStatus(ec, hash)
+		if err != nil {
+			return nil, err
+		}
+		if current.Status == Status_Pending {
+			pending = append(pending, *current)
+		}
+	}
+	sort.Slice(pending, func(a, b int) bool {
+		if pending[a].Nonce == pending[b].Nonce {
+			return pending[a].SubmittedAt.Before(pending[b].SubmittedAt)
+		}
+		return pending[a].Nonce < pending[b].Nonce
+	})
+	return pending, nil
+}
+
+// Get the gas spent on a mined transaction, in wei
+func getGasCost(ec rocketpool.ExecutionClient, entry *Entry, receipt *types.Receipt) (*big.Int, error) {
+	if entry.MaxFee == nil || entry.MaxPriorityFee == nil {
+		return nil, nil
+	}
+	header, err := ec.HeaderByNumber(context.Background(), receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting header for block %s: %w", receipt.BlockNumber.String(), err)
+	}
+
+	// The price paid is the base fee plus the priority fee, capped at the max fee
+	gasPrice := new(big.Int).Set(entry.MaxFee)
+	if header.BaseFee != nil {
+		price := new(big.Int).Add(header.BaseFee, entry.MaxPriorityFee)
+		if price.Cmp(gasPrice) < 0 {
+			gasPrice = price
+		}
+	}
+	return gasPrice.Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed)), nil
+}
+
+// Append an entry to the journal
+func (j *Journal) append(entry Entry) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error serializing journal entry: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(j.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating transaction journal directory: %w", err)
+	}
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening transaction journal %s: %w", j.path, err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing to transaction journal %s: %w", j.path, err)
+	}
+	return nil
+}
+
+// Load the current state of every transaction in the journal
+func (j *Journal) load() (map[common.Hash]Entry, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	entries := map[common.Hash]Entry{}
+	file, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening transaction journal %s: %w", j.path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// Skip lines that were only partially written
+			continue
+		}
+		entries[entry.Hash] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading transaction journal %s: %w", j.path, err)
+	}
+	return entries, nil
+}
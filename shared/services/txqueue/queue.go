@@ -0,0 +1,223 @@
+package txqueue
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Intent statuses
+type Status string
+
+const (
+	Status_Queued    Status = "queued"
+	Status_Running   Status = "running"
+	Status_Submitted Status = "submitted"
+	Status_Failed    Status = "failed"
+	Status_Expired   Status = "expired"
+	Status_Cancelled Status = "cancelled"
+)
+
+// A state-changing API command queued to run once the base fee drops below a threshold
+type Intent struct {
+	ID          uint64      `json:"id"`
+	Module      string      `json:"module"`
+	Command     string      `json:"command"`
+	Args        []string    `json:"args"`
+	MaxBaseFee  float64     `json:"maxBaseFee"`
+	PriorityFee float64     `json:"priorityFee"`
+	GasLimit    uint64      `json:"gasLimit"`
+	QueuedAt    time.Time   `json:"queuedAt"`
+	Deadline    time.Time   `json:"deadline"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+	Status      Status      `json:"status"`
+	TxHash      common.Hash `json:"txHash,omitempty"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// Get the API command line the intent runs, such as "node stake-rpl 100"
+func (i *Intent) CommandLine() string {
+	return strings.Join(append([]string{i.Module, i.Command}, i.Args...), " ")
+}
+
+// A queue of transaction intents, stored as one JSON entry per line.
+// Updates are appended as new entries, so the last entry for an ID is its current state.
+type Queue struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a new queue
+func NewQueue(path string) *Queue {
+	return &Queue{
+		path: path,
+	}
+}
+
+// Add an intent to the queue
+func (q *Queue) Add(module string, command string, args []string, maxBaseFee float64, priorityFee float64, gasLimit uint64, deadline time.Time) (Intent, error) {
+	intents, err := q.load()
+	if err != nil {
+		return Intent{}, err
+	}
+	var id uint64 = 1
+	for existingID := range intents {
+		if existingID >= id {
+			id = existingID + 1
+		}
+	}
+
+	intent := Intent{
+		ID:          id,
+		Module:      module,
+		Command:     command,
+		Args:        args,
+		MaxBaseFee:  maxBaseFee,
+		PriorityFee: priorityFee,
+		GasLimit:    gasLimit,
+		QueuedAt:    time.Now(),
+		Deadline:    deadline,
+		UpdatedAt:   time.Now(),
+		Status:      Status_Queued,
+	}
+	if err := q.append(intent); err != nil {
+		return Intent{}, err
+	}
+	return intent, nil
+}
+
+// Get the current state of an intent, or nil if it isn't in the queue
+func (q *Queue) Get(id uint64) (*Intent, error) {
+	intents, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	intent, exists := intents[id]
+	if !exists {
+		return nil, nil
+	}
+	return &intent, nil
+}
+
+// Get the current state of every intent, newest first
+func (q *Queue) List() ([]Intent, error) {
+	intents, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Intent, 0, len(intents))
+	for _, intent := range intents {
+		list = append(list, intent)
+	}
+	sort.Slice(list, func(a, b int) bool {
+		return list[a].ID > list[b].ID
+	})
+	return list, nil
+}
+
+// Get the intents that are still waiting to run, oldest first
+func (q *Queue) GetQueued() ([]Intent, error) {
+	intents, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	queued := []Intent{}
+	for _, intent := range intents {
+		if intent.Status == Status_Queued {
+			queued = append(queued, intent)
+		}
+	}
+	sort.Slice(queued, func(a, b int) bool {
+		return queued[a].ID < queued[b].ID
+	})
+	return queued, nil
+}
+
+// Record a new state for an intent
+func (q *Queue) Update(intent Intent) error {
+	intent.UpdatedAt = time.Now()
+	return q.append(intent)
+}
+
+// Cancel an intent that hasn't run yet
+func (q *Queue) Cancel(id uint64) (*Intent, error) {
+	intent, err := q.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if intent == nil {
+		return nil, fmt.Errorf("there is no queued transaction with ID %d", id)
+	}
+	if intent.Status != Status_Queued {
+		return nil, fmt.Errorf("queued transaction %d can't be cancelled because it is %s", id, intent.Status)
+	}
+	intent.Status = Status_Cancelled
+	if err := q.Update(*intent); err != nil {
+		return nil, err
+	}
+	return intent, nil
+}
+
+// Append an entry to the queue
+func (q *Queue) append(intent Intent) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	bytes, err := json.Marshal(intent)
+	if err != nil {
+		return fmt.Errorf("error serializing queued transaction: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(q.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating transaction queue directory: %w", err)
+	}
+	file, err := os.OpenFile(q.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening transaction queue %s: %w", q.path, err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing to transaction queue %s: %w", q.path, err)
+	}
+	return nil
+}
+
+// Load the current state of every intent in the queue
+func (q *Queue) load() (map[uint64]Intent, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	intents := map[uint64]Intent{}
+	file, err := os.Open(q.path)
+	if os.IsNotExist(err) {
+		return intents, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening transaction queue %s: %w", q.path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var intent Intent
+		if err := json.Unmarshal(scanner.Bytes(), &intent); err != nil {
+			// Skip lines that were only partially written
+			continue
+		}
+		intents[intent.ID] = intent
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading transaction queue %s: %w", q.path, err)
+	}
+	return intents, nil
+}
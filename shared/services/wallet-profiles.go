@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Config
+const (
+	WalletProfileDirMode     = 0700
+	WalletProfileFileMode    = 0600
+	ValidatorKeychainDirMode = 0775
+)
+
+// Get the names of the wallet profiles, starting with the default one
+func GetWalletProfiles(c *cli.Context) ([]string, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(os.ExpandEnv(cfg.Smartnode.GetWalletProfilesPath()))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading wallet profiles: %w", err)
+	}
+	profiles := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != config.DefaultWalletProfile && config.IsValidWalletProfileName(entry.Name()) {
+			profiles = append(profiles, entry.Name())
+		}
+	}
+	sort.Strings(profiles)
+	return append([]string{config.DefaultWalletProfile}, profiles...), nil
+}
+
+// Make a wallet profile the active one, creating it if it doesn't exist yet.
+// The wallet services of the calling process switch to it right away; other processes switch the next time they load the wallet.
+func SetActiveWalletProfile(c *cli.Context, profile string) error {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+	if !config.IsValidWalletProfileName(profile) {
+		return fmt.Errorf("Invalid wallet profile name '%s'; it can only contain letters, numbers, dashes, and underscores, and must be at most 32 characters long", profile)
+	}
+
+	// Create the profile's folder
+	profilePath := os.ExpandEnv(cfg.Smartnode.GetWalletProfilePath(profile))
+	if err := os.MkdirAll(profilePath, WalletProfileDirMode); err != nil {
+		return fmt.Errorf("error creating folder for wallet profile '%s': %w", profile, err)
+	}
+
+	// Move its validator keychain into the folder the validator clients load keys from
+	activePath := os.ExpandEnv(cfg.Smartnode.GetActiveWalletProfilePath())
+	if err := os.MkdirAll(filepath.Dir(activePath), WalletProfileDirMode); err != nil {
+		return fmt.Errorf("error creating wallet profiles folder: %w", err)
+	}
+	previousProfile := cfg.Smartnode.GetActiveWalletProfile()
+	if err := switchValidatorKeychain(cfg, previousProfile, profile); err != nil {
+		return err
+	}
+
+	// Record it as the active profile
+	if err := os.WriteFile(activePath, []byte(profile), WalletProfileFileMode); err != nil {
+		if rollbackErr := switchValidatorKeychain(cfg, profile, previousProfile); rollbackErr != nil {
+			return fmt.Errorf("error saving the active wallet profile: %w (restoring the validator keychain of '%s' also failed: %s)", err, previousProfile, rollbackErr.Error())
+		}
+		return fmt.Errorf("error saving the active wallet profile: %w", err)
+	}
+
+	// Switch this process over to it
+	pm := getPasswordManager(cfg)
+	_, err = getWallet(c, cfg, pm)
+	return err
+}
+
+// Swap the validator keychain in the data folder for the one belonging to another profile. The validator clients always
+// load keys from the keychain in the data folder, so the active profile's keychain lives there and every other profile's
+// is kept in its own profile folder until it's switched back to.
+func switchValidatorKeychain(cfg *config.RocketPoolConfig, oldProfile string, newProfile string) error {
+	if oldProfile == newProfile {
+		return nil
+	}
+	activePath := os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())
+	oldPath := os.ExpandEnv(cfg.Smartnode.GetInactiveValidatorKeychainPath(oldProfile))
+	newPath := os.ExpandEnv(cfg.Smartnode.GetInactiveValidatorKeychainPath(newProfile))
+
+	// Park the old profile's keychain
+	if _, err := os.Stat(oldPath); err == nil {
+		return fmt.Errorf("can't switch away from wallet profile '%s' because it already has a stored validator keychain at %s", oldProfile, oldPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(oldPath), WalletProfileDirMode); err != nil {
+		return fmt.Errorf("error creating folder for wallet profile '%s': %w", oldProfile, err)
+	}
+	if err := os.Rename(activePath, oldPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error storing the validator keychain of wallet profile '%s': %w", oldProfile, err)
+	}
+
+	// Move the new profile's keychain into place, or start an empty one
+	err := os.Rename(newPath, activePath)
+	if os.IsNotExist(err) {
+		err = os.Mkdir(activePath, ValidatorKeychainDirMode)
+	}
+	if err != nil {
+		if rollbackErr := os.Rename(oldPath, activePath); rollbackErr != nil && !os.IsNotExist(rollbackErr) {
+			return fmt.Errorf("error loading the validator keychain of wallet profile '%s': %w (restoring the keychain of '%s' from %s also failed: %s)", newProfile, err, oldProfile, oldPath, rollbackErr.Error())
+		}
+		return fmt.Errorf("error loading the validator keychain of wallet profile '%s': %w", newProfile, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"os"
+	"testing"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
+)
+
+// A validator key saved while a profile is active must be found in the keychain the validator clients load, and must
+// follow that profile when switching away from it and back
+func TestValidatorKeysFollowWalletProfile(t *testing.T) {
+	dataPath := t.TempDir()
+	cfg := config.NewRocketPoolConfig(dataPath, true)
+	cfg.Smartnode.DataPath.Value = dataPath
+	pm := passwords.NewPasswordManager("")
+
+	if err := eth2types.InitBLS(); err != nil {
+		t.Fatalf("error initializing BLS: %s", err.Error())
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatalf("error generating validator key: %s", err.Error())
+	}
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Save a key under profile X through the wallet's keystores
+	if err := switchValidatorKeychain(cfg, config.DefaultWalletProfile, "x"); err != nil {
+		t.Fatalf("error switching to profile x: %s", err.Error())
+	}
+	if err := getKeystores(cfg, pm)["lighthouse"].StoreValidatorKey(key, "m/12381/3600/0/0/0"); err != nil {
+		t.Fatalf("error saving validator key: %s", err.Error())
+	}
+
+	// The validator client's keychain has it
+	vcKeystore := lhkeystore.NewKeystore(cfg.Smartnode.GetValidatorKeychainPath(), pm)
+	if loaded, err := vcKeystore.LoadValidatorKey(pubkey); err != nil || loaded == nil {
+		t.Fatalf("key saved under profile x wasn't found in the validator client's keychain (error: %v)", err)
+	}
+
+	// It's moved out when switching to another profile
+	if err := switchValidatorKeychain(cfg, "x", config.DefaultWalletProfile); err != nil {
+		t.Fatalf("error switching to the default profile: %s", err.Error())
+	}
+	if loaded, _ := vcKeystore.LoadValidatorKey(pubkey); loaded != nil {
+		t.Fatal("key saved under profile x was still in the validator client's keychain after switching to the default profile")
+	}
+	if _, err := os.Stat(cfg.Smartnode.GetInactiveValidatorKeychainPath("x")); err != nil {
+		t.Fatalf("profile x's keychain wasn't stored in its profile folder: %s", err.Error())
+	}
+
+	// And moved back when switching to it again
+	if err := switchValidatorKeychain(cfg, config.DefaultWalletProfile, "x"); err != nil {
+		t.Fatalf("error switching back to profile x: %s", err.Error())
+	}
+	if loaded, err := vcKeystore.LoadValidatorKey(pubkey); err != nil || loaded == nil {
+		t.Fatalf("key saved under profile x wasn't found in the validator client's keychain after switching back (error: %v)", err)
+	}
+}
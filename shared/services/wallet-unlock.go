@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	WalletUnlockPath          string        = "/unlock"
+	WalletUnlockSocketMode                  = 0600
+	WalletUnlockTimeout       time.Duration = 30 * time.Second
+	MaxWalletUnlockBodyLength int64         = 1024
+)
+
+// Unlock the node wallet in this process with its password, for the prompt password provider.
+// The password is checked by decrypting the wallet, and forgotten again if that fails.
+func UnlockWallet(c *cli.Context, password string) error {
+	pm, err := GetPasswordManager(c)
+	if err != nil {
+		return err
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if err := pm.Unlock(password); err != nil {
+		return err
+	}
+	if err := w.Reload(); err != nil {
+		pm.Lock()
+		_ = w.Reload()
+		return fmt.Errorf("Could not unlock the node wallet: %w", err)
+	}
+	return nil
+}
+
+// Listen on the process's unlock socket so the wallet can be unlocked while the daemon runs.
+// This only runs when the wallet password is kept in memory, since otherwise there's nothing to unlock.
+func ServeWalletUnlock(c *cli.Context, process string, logger *log.ColorLogger) error {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return err
+	}
+	if cfg.Smartnode.WalletPasswordProvider.Value.(cfgtypes.WalletPasswordProvider) != cfgtypes.WalletPasswordProvider_Prompt {
+		return nil
+	}
+
+	// Replace any socket left over from a previous run
+	socketPath := os.ExpandEnv(cfg.Smartnode.GetWalletUnlockSocketPath(process))
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing old wallet unlock socket: %w", err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("error listening on wallet unlock socket: %w", err)
+	}
+	defer os.Remove(socketPath)
+	if err := os.Chmod(socketPath, WalletUnlockSocketMode); err != nil {
+		listener.Close()
+		return fmt.Errorf("error setting wallet unlock socket permissions: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(WalletUnlockPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		password, err := io.ReadAll(io.LimitReader(r.Body, MaxWalletUnlockBodyLength))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := UnlockWallet(c, string(password)); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		logger.Println("The node wallet was unlocked.")
+	})
+	logger.Printlnf("The node wallet is locked; waiting for it to be unlocked with 'rocketpool wallet unlock'.")
+	err = http.Serve(listener, mux)
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		return fmt.Errorf("Error running wallet unlock server: %w", err)
+	}
+	return nil
+}
+
+// Unlock the node wallet in a daemon through its unlock socket.
+// Returns false if the daemon isn't listening for it, such as when it isn't running.
+func UnlockDaemonWallet(c *cli.Context, process string, password string) (bool, error) {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return false, err
+	}
+	socketPath := os.ExpandEnv(cfg.Smartnode.GetWalletUnlockSocketPath(process))
+	if _, err := os.Stat(socketPath); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	client := &http.Client{
+		Timeout: WalletUnlockTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	response, err := client.Post("http://unix"+WalletUnlockPath, "text/plain", strings.NewReader(password))
+	if err != nil {
+		// A socket nobody is listening on was left behind by a daemon that stopped
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return false, nil
+		}
+		return false, fmt.Errorf("error unlocking the %s daemon: %w", process, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return false, fmt.Errorf("the %s daemon couldn't be unlocked: %s", process, strings.TrimSpace(string(body)))
+	}
+	return true, nil
+}
@@ -0,0 +1,185 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Config
+const (
+	// Clef waits for the user to approve each request unless it has a rule for it, so give them time to do that
+	ExternalSignerTimeout time.Duration = 5 * time.Minute
+)
+
+// The result of Clef's account_signTransaction
+type clefSignTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// Signs for the node account with a remote signer, such as Clef or Web3Signer, so the node never holds its key.
+// The node account comes from the config, so commands that don't sign anything work without the signer.
+type ExternalNodeSigner struct {
+	signerType config.NodeKeySigner
+	url        string
+	account    accounts.Account
+	client     *rpc.Client
+	lock       sync.Mutex
+}
+
+// Create a new external signer
+func NewExternalNodeSigner(signerType config.NodeKeySigner, url string, address string) (*ExternalNodeSigner, error) {
+	if signerType != config.NodeKeySigner_Clef && signerType != config.NodeKeySigner_Web3Signer {
+		return nil, fmt.Errorf("Unsupported external signer type '%s'", signerType)
+	}
+	if url == "" {
+		return nil, fmt.Errorf("The %s URL must be set to use it as the node key signer", signerType)
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("The node account address for the %s ('%s') is not a valid address", signerType, address)
+	}
+	return &ExternalNodeSigner{
+		signerType: signerType,
+		url:        url,
+		account: accounts.Account{
+			Address: common.HexToAddress(address),
+			URL: accounts.URL{
+				Scheme: string(signerType),
+				Path:   url,
+			},
+		},
+	}, nil
+}
+
+// Get the node account
+func (s *ExternalNodeSigner) GetAccount() (accounts.Account, error) {
+	return s.account, nil
+}
+
+// Sign a transaction with the remote signer
+func (s *ExternalNodeSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+
+	// Build the request
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.account.Address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	default:
+		return nil, fmt.Errorf("Transaction type %d can't be signed by the %s", tx.Type(), s.signerType)
+	}
+
+	// Sign it
+	var raw hexutil.Bytes
+	switch s.signerType {
+	case config.NodeKeySigner_Clef:
+		var result clefSignTxResult
+		if err := s.call(&result, "account_signTransaction", args); err != nil {
+			return nil, err
+		}
+		raw = result.Raw
+	case config.NodeKeySigner_Web3Signer:
+		if err := s.call(&raw, "eth_signTransaction", args); err != nil {
+			return nil, err
+		}
+	}
+
+	// Decode it and make sure it's what was requested
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("Error decoding the transaction signed by the %s: %w", s.signerType, err)
+	}
+	sender, err := types.Sender(types.NewLondonSigner(chainID), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking the transaction signed by the %s: %w", s.signerType, err)
+	}
+	if sender != s.account.Address {
+		return nil, fmt.Errorf("The %s signed the transaction with %s instead of the node account %s", s.signerType, sender.Hex(), s.account.Address.Hex())
+	}
+	if signedTx.Nonce() != tx.Nonce() || signedTx.Gas() != tx.Gas() || signedTx.Value().Cmp(tx.Value()) != 0 {
+		return nil, fmt.Errorf("The transaction signed by the %s doesn't match the one that was requested", s.signerType)
+	}
+	return signedTx, nil
+
+}
+
+// Sign a personal_sign message with the remote signer
+func (s *ExternalNodeSigner) SignText(text []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	var err error
+	switch s.signerType {
+	case config.NodeKeySigner_Clef:
+		address := common.NewMixedcaseAddress(s.account.Address)
+		err = s.call(&signature, "account_signData", accounts.MimetypeTextPlain, &address, hexutil.Encode(text))
+	case config.NodeKeySigner_Web3Signer:
+		err = s.call(&signature, "eth_sign", s.account.Address, hexutil.Encode(text))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return normalizeSignatureV(signature), nil
+}
+
+// Sign EIP-712 typed data with the remote signer
+func (s *ExternalNodeSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	var signature hexutil.Bytes
+	var err error
+	switch s.signerType {
+	case config.NodeKeySigner_Clef:
+		address := common.NewMixedcaseAddress(s.account.Address)
+		err = s.call(&signature, "account_signTypedData", &address, typedData)
+	case config.NodeKeySigner_Web3Signer:
+		err = s.call(&signature, "eth_signTypedData", s.account.Address, typedData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return normalizeSignatureV(signature), nil
+}
+
+// Call a method on the remote signer, connecting to it if necessary
+func (s *ExternalNodeSigner) call(result interface{}, method string, args ...interface{}) error {
+	s.lock.Lock()
+	if s.client == nil {
+		client, err := rpc.Dial(s.url)
+		if err != nil {
+			s.lock.Unlock()
+			return fmt.Errorf("Could not connect to the %s at %s: %w", s.signerType, s.url, err)
+		}
+		s.client = client
+	}
+	client := s.client
+	s.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), ExternalSignerTimeout)
+	defer cancel()
+	if err := client.CallContext(ctx, result, method, args...); err != nil {
+		return fmt.Errorf("Error calling %s on the %s: %w", method, s.signerType, err)
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The node account on a hardware wallet, saved so it's known without the device
+type hardwareWalletAccount struct {
+	Type           config.NodeKeySigner `json:"type"`
+	DerivationPath string               `json:"derivationPath"`
+	Address        common.Address       `json:"address"`
+}
+
+// Signs for the node account with a key on a USB hardware wallet.
+// The node account is saved the first time the device is opened, so commands that don't sign anything work without it.
+type HardwareNodeSigner struct {
+	signerType     config.NodeKeySigner
+	derivationPath string
+	accountPath    string
+	account        *accounts.Account
+	device         accounts.Wallet
+	getBaseFee     func() (*big.Int, error)
+	lock           sync.Mutex
+}
+
+// Create a new hardware wallet signer.
+// getBaseFee gets the current base fee, which is used to price dynamic fee transactions that the device can't sign.
+func NewHardwareNodeSigner(signerType config.NodeKeySigner, derivationPath string, accountPath string, getBaseFee func() (*big.Int, error)) (*HardwareNodeSigner, error) {
+	if signerType != config.NodeKeySigner_Ledger && signerType != config.NodeKeySigner_Trezor {
+		return nil, fmt.Errorf("Unsupported hardware wallet type '%s'", signerType)
+	}
+	if _, err := accounts.ParseDerivationPath(derivationPath); err != nil {
+		return nil, fmt.Errorf("Invalid hardware wallet derivation path '%s': %w", derivationPath, err)
+	}
+	return &HardwareNodeSigner{
+		signerType:     signerType,
+		derivationPath: derivationPath,
+		accountPath:    accountPath,
+		getBaseFee:     getBaseFee,
+	}, nil
+}
+
+// Get the node account, opening the device if it hasn't been saved yet
+func (s *HardwareNodeSigner) GetAccount() (accounts.Account, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.account != nil {
+		return *s.account, nil
+	}
+
+	saved, err := s.loadAccount()
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	if saved != nil {
+		s.account = &accounts.Account{
+			Address: saved.Address,
+			URL: accounts.URL{
+				Scheme: string(s.signerType),
+				Path:   s.derivationPath,
+			},
+		}
+		return *s.account, nil
+	}
+
+	if err := s.open(); err != nil {
+		return accounts.Account{}, err
+	}
+	return *s.account, nil
+}
+
+// Sign a transaction on the device.
+// Ledgers sign dynamic fee transactions natively. This version of go-ethereum can only sign legacy transactions on a
+// Trezor, so there they're converted to legacy ones that pay the current base fee plus their priority fee.
+func (s *HardwareNodeSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	if tx.Type() == types.DynamicFeeTxType && s.signerType == config.NodeKeySigner_Ledger {
+		return s.signLedgerDynamicFeeTx(tx, chainID)
+	}
+	if tx.Type() != types.LegacyTxType {
+		legacyTx, err := s.getLegacyTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		tx = legacyTx
+	}
+	signedTx, err := s.device.SignTx(*s.account, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing transaction on the %s: %w", s.signerType, err)
+	}
+	return signedTx, nil
+}
+
+// Sign a dynamic fee transaction on a Ledger.
+// go-ethereum's driver holds the device open and can't sign it, so the driver is closed while the transaction is sent to
+// the device directly; it's reopened the next time it's needed.
+func (s *HardwareNodeSigner) signLedgerDynamicFeeTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	path, err := accounts.ParseDerivationPath(s.derivationPath)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid hardware wallet derivation path '%s': %w", s.derivationPath, err)
+	}
+	s.device.Close()
+	s.device = nil
+
+	signedTx, err := signLedgerDynamicFeeTx(path, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing transaction on the %s: %w", s.signerType, err)
+	}
+	sender, err := types.Sender(types.NewLondonSigner(chainID), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the sender of the transaction signed on the %s: %w", s.signerType, err)
+	}
+	if sender != s.account.Address {
+		return nil, fmt.Errorf("The %s signed the transaction with account %s instead of the node account %s", s.signerType, sender.Hex(), s.account.Address.Hex())
+	}
+	return signedTx, nil
+}
+
+// Convert a dynamic fee transaction to a legacy one, for a device that can't sign dynamic fee transactions.
+// Legacy transactions pay their whole gas price, so it's the current base fee plus the priority fee (capped at the max
+// fee) rather than the max fee. If the base fee can't be retrieved, the transaction isn't signed.
+func (s *HardwareNodeSigner) getLegacyTx(tx *types.Transaction) (*types.Transaction, error) {
+	if s.getBaseFee == nil {
+		return nil, fmt.Errorf("The %s can't sign EIP-1559 transactions, and the current base fee isn't available to send it as a legacy transaction instead", s.signerType)
+	}
+	baseFee, err := s.getBaseFee()
+	if err != nil {
+		return nil, fmt.Errorf("The %s can't sign EIP-1559 transactions, and the current base fee to send it as a legacy transaction instead couldn't be retrieved: %w", s.signerType, err)
+	}
+	gasPrice := big.NewInt(0).Add(baseFee, tx.GasTipCap())
+	if gasPrice.Cmp(tx.GasFeeCap()) > 0 {
+		gasPrice = tx.GasFeeCap()
+	}
+	fmt.Fprintf(os.Stderr, "WARNING: the %s can't sign EIP-1559 transactions, so this transaction will be sent as a legacy transaction with a gas price of %.6f gwei (the current base fee plus the priority fee). If the base fee rises before it's included, it will be stuck until it's replaced with a higher gas price.\n", s.signerType, eth.WeiToGwei(gasPrice))
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    tx.Nonce(),
+		GasPrice: gasPrice,
+		Gas:      tx.Gas(),
+		To:       tx.To(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	}), nil
+}
+
+// Sign a personal_sign message on the device
+func (s *HardwareNodeSigner) SignText(text []byte) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	signature, err := s.device.SignText(*s.account, text)
+	if errors.Is(err, accounts.ErrNotSupported) {
+		return nil, fmt.Errorf("Signing messages isn't supported on the %s", s.signerType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error signing message on the %s: %w", s.signerType, err)
+	}
+	return normalizeSignatureV(signature), nil
+}
+
+// Sign EIP-712 typed data on the device
+func (s *HardwareNodeSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, rawData, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error hashing typed data: %w", err)
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	signature, err := s.device.SignData(*s.account, accounts.MimetypeTypedData, []byte(rawData))
+	if errors.Is(err, accounts.ErrNotSupported) {
+		return nil, fmt.Errorf("Signing typed data isn't supported on the %s", s.signerType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error signing typed data on the %s: %w", s.signerType, err)
+	}
+	return normalizeSignatureV(signature), nil
+}
+
+// Open the device and derive the node account on it, if that hasn't been done yet
+func (s *HardwareNodeSigner) open() error {
+
+	if s.device != nil {
+		return nil
+	}
+
+	// Find the device
+	devices, err := s.findDevices()
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return fmt.Errorf("No %s was found; make sure it's connected, unlocked, and (for a Ledger) running the Ethereum app", s.signerType)
+	}
+	device := devices[0]
+	if err := device.Open(""); err != nil {
+		return fmt.Errorf("Could not open the %s; make sure it's unlocked: %w", s.signerType, err)
+	}
+
+	// Derive the node account
+	path, err := accounts.ParseDerivationPath(s.derivationPath)
+	if err != nil {
+		device.Close()
+		return fmt.Errorf("Invalid hardware wallet derivation path '%s': %w", s.derivationPath, err)
+	}
+	account, err := device.Derive(path, true)
+	if err != nil {
+		device.Close()
+		return fmt.Errorf("Could not derive the node account on the %s: %w", s.signerType, err)
+	}
+
+	// Make sure it's the same account that was saved, so a different device can't silently change the node address
+	saved, err := s.loadAccount()
+	if err != nil {
+		device.Close()
+		return err
+	}
+	if saved != nil && saved.Address != account.Address {
+		device.Close()
+		return fmt.Errorf("The %s has account %s at %s, but the node account is %s; connect the original device or delete %s to switch accounts", s.signerType, account.Address.Hex(), s.derivationPath, saved.Address.Hex(), s.accountPath)
+	}
+	if saved == nil {
+		if err := s.saveAccount(account.Address); err != nil {
+			device.Close()
+			return err
+		}
+	}
+
+	s.device = device
+	s.account = &account
+	return nil
+
+}
+
+// Get the connected devices of the signer's type
+func (s *HardwareNodeSigner) findDevices() ([]accounts.Wallet, error) {
+	switch s.signerType {
+	case config.NodeKeySigner_Ledger:
+		hub, err := usbwallet.NewLedgerHub()
+		if err != nil {
+			return nil, fmt.Errorf("Could not search for a Ledger: %w", err)
+		}
+		return hub.Wallets(), nil
+
+	case config.NodeKeySigner_Trezor:
+		// Newer Trezors use WebUSB and older ones use HID
+		devices := []accounts.Wallet{}
+		webUsbHub, err := usbwallet.NewTrezorHubWithWebUSB()
+		if err != nil {
+			return nil, fmt.Errorf("Could not search for a Trezor: %w", err)
+		}
+		devices = append(devices, webUsbHub.Wallets()...)
+		hidHub, err := usbwallet.NewTrezorHubWithHID()
+		if err != nil {
+			return nil, fmt.Errorf("Could not search for a Trezor: %w", err)
+		}
+		devices = append(devices, hidHub.Wallets()...)
+		return devices, nil
+	}
+	return nil, fmt.Errorf("Unsupported hardware wallet type '%s'", s.signerType)
+}
+
+// Load the saved node account; returns nil if there isn't one for the current device type and derivation path
+func (s *HardwareNodeSigner) loadAccount() (*hardwareWalletAccount, error) {
+	bytes, err := os.ReadFile(s.accountPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read hardware wallet account file %s: %w", s.accountPath, err)
+	}
+	var saved hardwareWalletAccount
+	if err := json.Unmarshal(bytes, &saved); err != nil {
+		return nil, fmt.Errorf("Could not decode hardware wallet account file %s: %w", s.accountPath, err)
+	}
+	if saved.Type != s.signerType || saved.DerivationPath != s.derivationPath {
+		return nil, nil
+	}
+	return &saved, nil
+}
+
+// Save the node account so it's known without the device
+func (s *HardwareNodeSigner) saveAccount(address common.Address) error {
+	bytes, err := json.Marshal(hardwareWalletAccount{
+		Type:           s.signerType,
+		DerivationPath: s.derivationPath,
+		Address:        address,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode hardware wallet account: %w", err)
+	}
+	if err := os.WriteFile(s.accountPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write hardware wallet account file %s: %w", s.accountPath, err)
+	}
+	return nil
+}
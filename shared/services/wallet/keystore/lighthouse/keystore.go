@@ -181,3 +181,23 @@ func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.B
 	return privateKey, nil
 
 }
+
+// Delete a validator key
+func (ks *Keystore) DeleteValidatorKey(pubkey types.ValidatorPubkey) error {
+
+	// Remove the key folder
+	keyDirPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.RemoveAll(keyDirPath); err != nil {
+		return fmt.Errorf("Could not delete the Lighthouse keystore for pubkey %s: %w", pubkey.Hex(), err)
+	}
+
+	// Remove the secret
+	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.Remove(secretFilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not delete the Lighthouse secret for pubkey %s: %w", pubkey.Hex(), err)
+	}
+
+	// Return
+	return nil
+
+}
@@ -90,6 +90,40 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	ks.as.PrivateKeys = append(ks.as.PrivateKeys, key.Marshal())
 	ks.as.PublicKeys = append(ks.as.PublicKeys, key.PublicKey().Marshal())
 
+	// Save account store
+	if err := ks.saveAccountStore(); err != nil {
+		return err
+	}
+
+	// Get wallet config file path
+	configFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, ConfigFileName)
+
+	// Return if wallet config file exists
+	if _, err := os.Stat(configFilePath); !os.IsNotExist(err) {
+		return nil
+	}
+
+	// Create & encode wallet config
+	configBytes, err := json.Marshal(walletConfig{
+		DirectEIPVersion: DirectEIPVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode wallet config: %w", err)
+	}
+
+	// Write wallet config to disk
+	if err := os.WriteFile(configFilePath, configBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write wallet config to disk: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Encrypt the account store and write it to disk
+func (ks *Keystore) saveAccountStore() error {
+
 	// Encode account store
 	asBytes, err := json.Marshal(ks.as)
 	if err != nil {
@@ -124,9 +158,8 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 		return fmt.Errorf("Could not encode validator keystore: %w", err)
 	}
 
-	// Get file paths
+	// Get keystore file path
 	keystoreFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName)
-	configFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, ConfigFileName)
 
 	// Create keystore dir
 	if err := os.MkdirAll(filepath.Dir(keystoreFilePath), DirMode); err != nil {
@@ -138,25 +171,29 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 		return fmt.Errorf("Could not write keystore to disk: %w", err)
 	}
 
-	// Return if wallet config file exists
-	if _, err := os.Stat(configFilePath); !os.IsNotExist(err) {
-		return nil
-	}
+	// Return
+	return nil
 
-	// Create & encode wallet config
-	configBytes, err := json.Marshal(walletConfig{
-		DirectEIPVersion: DirectEIPVersion,
-	})
-	if err != nil {
-		return fmt.Errorf("Could not encode wallet config: %w", err)
+}
+
+// Delete a validator key
+func (ks *Keystore) DeleteValidatorKey(pubkey types.ValidatorPubkey) error {
+
+	// Initialize the account store
+	if err := ks.initialize(); err != nil {
+		return err
 	}
 
-	// Write wallet config to disk
-	if err := os.WriteFile(configFilePath, configBytes, FileMode); err != nil {
-		return fmt.Errorf("Could not write wallet config to disk: %w", err)
+	// Remove the validator key from the account store
+	for ki := 0; ki < len(ks.as.PublicKeys); ki++ {
+		if bytes.Equal(pubkey.Bytes(), ks.as.PublicKeys[ki]) {
+			ks.as.PrivateKeys = append(ks.as.PrivateKeys[:ki], ks.as.PrivateKeys[ki+1:]...)
+			ks.as.PublicKeys = append(ks.as.PublicKeys[:ki], ks.as.PublicKeys[ki+1:]...)
+			return ks.saveAccountStore()
+		}
 	}
 
-	// Return
+	// Return if the key wasn't in the account store
 	return nil
 
 }
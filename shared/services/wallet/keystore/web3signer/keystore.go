@@ -0,0 +1,263 @@
+package web3signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	KeystoreDir              = "web3signer"
+	SlashingProtectionFormat = "slashing-protection-%d.json"
+	KeystoresPath            = "/eth/v1/keystores"
+	RequestTimeout           = 1 * time.Minute
+	DirMode                  = 0770
+	FileMode                 = 0640
+)
+
+// Web3Signer keystore, which imports validator keys into a Web3Signer instance through its keymanager API instead of
+// saving them to disk. Web3Signer never releases the keys, so they can't be loaded back out of it.
+type Keystore struct {
+	keystorePath string
+	url          string
+	client       *http.Client
+	encryptor    *eth2ks.Encryptor
+}
+
+// An EIP-2335 keystore, as the keymanager API expects it
+type validatorKey struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Version uint                   `json:"version"`
+	UUID    uuid.UUID              `json:"uuid"`
+	Path    string                 `json:"path"`
+	Pubkey  string                 `json:"pubkey"`
+}
+
+// Keymanager API requests and responses
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+type keystoreResult struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+type keystoreResultsResponse struct {
+	Data               []keystoreResult `json:"data"`
+	SlashingProtection string           `json:"slashing_protection"`
+}
+type listKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+		DerivationPath   string `json:"derivation_path"`
+		ReadOnly         bool   `json:"readonly"`
+	} `json:"data"`
+}
+
+// A key held by Web3Signer
+type RemoteKey struct {
+	Pubkey         rptypes.ValidatorPubkey `json:"pubkey"`
+	DerivationPath string                  `json:"derivationPath"`
+	ReadOnly       bool                    `json:"readOnly"`
+}
+
+// Create new Web3Signer keystore
+func NewKeystore(keystorePath string, url string) *Keystore {
+	return &Keystore{
+		keystorePath: keystorePath,
+		url:          strings.TrimSuffix(url, "/"),
+		client:       &http.Client{Timeout: RequestTimeout},
+		encryptor:    eth2ks.New(),
+	}
+}
+
+// Get the keystore directory, which holds the slashing protection data exported when keys are removed from Web3Signer
+func (ks *Keystore) GetKeystoreDir() string {
+	return filepath.Join(ks.keystorePath, KeystoreDir)
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	return ks.ImportValidatorKey(key, derivationPath, "")
+}
+
+// Import a validator key into Web3Signer, along with the EIP-3076 slashing protection data for it if there is any
+func (ks *Keystore) ImportValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, slashingProtection string) error {
+
+	// Get validator pubkey
+	pubkey := rptypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a new password; Web3Signer stores it with the key, so it doesn't need to be saved here
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(validatorKey{
+		Crypto:  encryptedKey,
+		Version: ks.encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  pubkey.Hex(),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Import it
+	var response keystoreResultsResponse
+	err = ks.request(http.MethodPost, importKeystoresRequest{
+		Keystores:          []string{string(keyStoreBytes)},
+		Passwords:          []string{password},
+		SlashingProtection: slashingProtection,
+	}, &response)
+	if err != nil {
+		return fmt.Errorf("Could not import validator key %s into Web3Signer: %w", pubkey.Hex(), err)
+	}
+	if len(response.Data) != 1 {
+		return fmt.Errorf("Web3Signer returned %d results for importing validator key %s", len(response.Data), pubkey.Hex())
+	}
+	switch response.Data[0].Status {
+	case "imported", "duplicate":
+		return nil
+	default:
+		return fmt.Errorf("Web3Signer couldn't import validator key %s: %s (%s)", pubkey.Hex(), response.Data[0].Status, response.Data[0].Message)
+	}
+
+}
+
+// Load a private key; Web3Signer doesn't release keys, so this never finds one
+func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
+
+// Delete a validator key from Web3Signer, keeping the slashing protection data it exports for it
+func (ks *Keystore) DeleteValidatorKey(pubkey types.ValidatorPubkey) error {
+	_, err := ks.RemoveValidatorKeys([]rptypes.ValidatorPubkey{pubkey})
+	return err
+}
+
+// Get the keys held by Web3Signer
+func (ks *Keystore) GetRemoteKeys() ([]RemoteKey, error) {
+	var response listKeystoresResponse
+	if err := ks.request(http.MethodGet, nil, &response); err != nil {
+		return nil, fmt.Errorf("Could not get the validator keys in Web3Signer: %w", err)
+	}
+	keys := make([]RemoteKey, 0, len(response.Data))
+	for _, data := range response.Data {
+		pubkey, err := rptypes.HexToValidatorPubkey(hexutil.RemovePrefix(data.ValidatingPubkey))
+		if err != nil {
+			return nil, fmt.Errorf("Web3Signer returned an invalid validator key: %w", err)
+		}
+		keys = append(keys, RemoteKey{
+			Pubkey:         pubkey,
+			DerivationPath: data.DerivationPath,
+			ReadOnly:       data.ReadOnly,
+		})
+	}
+	return keys, nil
+}
+
+// Remove validator keys from Web3Signer, saving the slashing protection data it exports for them so it can be imported
+// into whatever signs for the keys next. Returns the path of the saved slashing protection data.
+func (ks *Keystore) RemoveValidatorKeys(pubkeys []rptypes.ValidatorPubkey) (string, error) {
+
+	// Remove the keys
+	request := deleteKeystoresRequest{
+		Pubkeys: make([]string, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.Pubkeys[i] = hexutil.AddPrefix(pubkey.Hex())
+	}
+	var response keystoreResultsResponse
+	if err := ks.request(http.MethodDelete, request, &response); err != nil {
+		return "", fmt.Errorf("Could not remove validator keys from Web3Signer: %w", err)
+	}
+
+	// Save the slashing protection data before checking the results, so it isn't lost if only some keys failed
+	slashingProtectionPath := ""
+	if response.SlashingProtection != "" {
+		slashingProtectionPath = filepath.Join(ks.GetKeystoreDir(), fmt.Sprintf(SlashingProtectionFormat, time.Now().Unix()))
+		if err := os.MkdirAll(filepath.Dir(slashingProtectionPath), DirMode); err != nil {
+			return "", fmt.Errorf("Could not create slashing protection folder: %w", err)
+		}
+		if err := os.WriteFile(slashingProtectionPath, []byte(response.SlashingProtection), FileMode); err != nil {
+			return "", fmt.Errorf("Could not save slashing protection data: %w", err)
+		}
+	}
+
+	// Check the results
+	if len(response.Data) != len(pubkeys) {
+		return slashingProtectionPath, fmt.Errorf("Web3Signer returned %d results for removing %d validator keys", len(response.Data), len(pubkeys))
+	}
+	for i, result := range response.Data {
+		switch result.Status {
+		case "deleted", "not_active", "not_found":
+		default:
+			return slashingProtectionPath, fmt.Errorf("Web3Signer couldn't remove validator key %s: %s (%s)", pubkeys[i].Hex(), result.Status, result.Message)
+		}
+	}
+	return slashingProtectionPath, nil
+
+}
+
+// Send a request to Web3Signer's keymanager API
+func (ks *Keystore) request(method string, body interface{}, response interface{}) error {
+	var requestBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error encoding request: %w", err)
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+	request, err := http.NewRequest(method, ks.url+KeystoresPath, requestBody)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := ks.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error reaching Web3Signer at %s: %w", ks.url, err)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("Web3Signer returned %s: %s", httpResponse.Status, strings.TrimSpace(string(responseBytes)))
+	}
+	if err := json.Unmarshal(responseBytes, response); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package wallet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/karalabe/usb"
+)
+
+// Ledger USB and APDU settings
+const (
+	ledgerVendorID              uint16 = 0x2c97
+	ledgerUsagePage             uint16 = 0xffa0
+	ledgerInterface             int    = 0
+	ledgerOpSignTransaction     byte   = 0x04
+	ledgerP1InitTransactionData byte   = 0x00
+	ledgerP1ContTransactionData byte   = 0x80
+	ledgerMaxChunkSize          int    = 255
+	ledgerStatusOk              uint16 = 0x9000
+	ledgerStatusDenied          uint16 = 0x6985
+)
+
+// Sign a dynamic fee transaction on a Ledger.
+// go-ethereum's Ledger driver only sends legacy transactions to the device, so this sends the transaction to the
+// Ethereum app directly; the app has supported EIP-1559 transactions since version 1.9.
+// The device must not be open in the driver at the same time.
+func signLedgerDynamicFeeTx(derivationPath []uint32, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+
+	// Open the device
+	infos, err := usb.Enumerate(ledgerVendorID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Could not search for a Ledger: %w", err)
+	}
+	var device usb.Device
+	for _, info := range infos {
+		// Windows and macOS identify the Ethereum app's transport by usage page, Linux by interface
+		if info.UsagePage != ledgerUsagePage && info.Interface != ledgerInterface {
+			continue
+		}
+		device, err = info.Open()
+		if err != nil {
+			return nil, fmt.Errorf("Could not open the Ledger; make sure it's unlocked: %w", err)
+		}
+		break
+	}
+	if device == nil {
+		return nil, errors.New("No Ledger was found; make sure it's connected, unlocked, and running the Ethereum app")
+	}
+	defer device.Close()
+
+	// The payload is the derivation path, followed by the transaction type and the RLP of the fields that are signed
+	payload := make([]byte, 1+4*len(derivationPath))
+	payload[0] = byte(len(derivationPath))
+	for i, component := range derivationPath {
+		binary.BigEndian.PutUint32(payload[1+4*i:], component)
+	}
+	txRlp, err := rlp.EncodeToBytes([]interface{}{chainID, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList()})
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding transaction: %w", err)
+	}
+	payload = append(payload, types.DynamicFeeTxType)
+	payload = append(payload, txRlp...)
+
+	// Send it in chunks; the reply to the last one is the signature
+	var reply []byte
+	p1 := ledgerP1InitTransactionData
+	for len(payload) > 0 {
+		chunkSize := len(payload)
+		if chunkSize > ledgerMaxChunkSize {
+			chunkSize = ledgerMaxChunkSize
+		}
+		reply, err = ledgerExchange(device, ledgerOpSignTransaction, p1, payload[:chunkSize])
+		if err != nil {
+			return nil, err
+		}
+		payload = payload[chunkSize:]
+		p1 = ledgerP1ContTransactionData
+	}
+
+	// The signature is returned as V, R, S; V is the y parity, though some versions of the app add 27 to it
+	if len(reply) != crypto.SignatureLength {
+		return nil, fmt.Errorf("The Ledger returned a signature of %d bytes instead of %d", len(reply), crypto.SignatureLength)
+	}
+	v := reply[0]
+	if v >= 27 {
+		v -= 27
+	}
+	signature := append(reply[1:], v)
+	signedTx, err := tx.WithSignature(types.NewLondonSigner(chainID), signature)
+	if err != nil {
+		return nil, fmt.Errorf("Error applying the Ledger's signature to the transaction: %w", err)
+	}
+	return signedTx, nil
+
+}
+
+// Send an APDU to a Ledger's Ethereum app and return its reply, following the framing of go-ethereum's Ledger driver
+func ledgerExchange(device usb.Device, opcode byte, p1 byte, data []byte) ([]byte, error) {
+
+	// Build the APDU, prefixed with its length
+	apdu := make([]byte, 2, 7+len(data))
+	binary.BigEndian.PutUint16(apdu, uint16(5+len(data)))
+	apdu = append(apdu, 0xe0, opcode, p1, 0x00, byte(len(data)))
+	apdu = append(apdu, data...)
+
+	// Send it in 64 byte reports, each with the channel, command tag, and sequence number
+	header := []byte{0x01, 0x01, 0x05, 0x00, 0x00}
+	space := 64 - len(header)
+	for i := 0; len(apdu) > 0; i++ {
+		chunk := append([]byte{}, header...)
+		binary.BigEndian.PutUint16(chunk[3:], uint16(i))
+		if len(apdu) > space {
+			chunk = append(chunk, apdu[:space]...)
+			apdu = apdu[space:]
+		} else {
+			chunk = append(chunk, apdu...)
+			apdu = nil
+		}
+		if _, err := device.Write(chunk); err != nil {
+			return nil, fmt.Errorf("Error sending data to the Ledger: %w", err)
+		}
+	}
+
+	// Read the reply, which starts with its length
+	var reply []byte
+	chunk := make([]byte, 64)
+	for {
+		if _, err := io.ReadFull(device, chunk); err != nil {
+			return nil, fmt.Errorf("Error reading the Ledger's reply: %w", err)
+		}
+		if chunk[0] != 0x01 || chunk[1] != 0x01 || chunk[2] != 0x05 {
+			return nil, errors.New("The Ledger's reply had an invalid header")
+		}
+		var data []byte
+		if chunk[3] == 0x00 && chunk[4] == 0x00 {
+			reply = make([]byte, 0, int(binary.BigEndian.Uint16(chunk[5:7])))
+			data = chunk[7:]
+		} else {
+			data = chunk[5:]
+		}
+		if left := cap(reply) - len(reply); left > len(data) {
+			reply = append(reply, data...)
+		} else {
+			reply = append(reply, data[:left]...)
+			break
+		}
+	}
+
+	// The reply ends with its status word
+	if len(reply) < 2 {
+		return nil, errors.New("The Ledger's reply was missing its status")
+	}
+	switch status := binary.BigEndian.Uint16(reply[len(reply)-2:]); status {
+	case ledgerStatusOk:
+		return reply[:len(reply)-2], nil
+	case ledgerStatusDenied:
+		return nil, errors.New("The transaction was rejected on the Ledger")
+	default:
+		return nil, fmt.Errorf("The Ledger returned status 0x%04x; make sure the Ethereum app is open and up to date, and that blind signing is enabled in its settings", status)
+	}
+
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -21,6 +23,11 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
 	}
 
+	// Use the external signer's account if there is one
+	if w.nodeSigner != nil {
+		return w.nodeSigner.GetAccount()
+	}
+
 	// Get private key
 	privateKey, path, err := w.getNodePrivateKey()
 	if err != nil {
@@ -53,6 +60,11 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// Use the external signer if there is one
+	if w.nodeSigner != nil {
+		return w.getNodeSignerTransactor()
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -61,11 +73,17 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
 	// Create & return transactor
 	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+	if err != nil {
+		return nil, err
+	}
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+	if w.txPolicy != nil {
+		transactor.Signer = w.txPolicy.wrapSigner(transactor)
+	}
+	return transactor, nil
 
 }
 
@@ -77,6 +95,11 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
+	// The key isn't available if it's held by an external signer
+	if w.nodeSigner != nil {
+		return nil, errors.New("The node account's private key is held by an external signer and can't be exported")
+	}
+
 	// Get private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -88,6 +111,36 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
 }
 
+// Get a transactor for the node account that signs with the external signer
+func (w *Wallet) getNodeSignerTransactor() (*bind.TransactOpts, error) {
+
+	// Get the node account
+	account, err := w.nodeSigner.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create & return transactor; the signer is only used when a transaction is sent, so simulations don't need it
+	transactor := &bind.TransactOpts{
+		From: account.Address,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != account.Address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return w.nodeSigner.SignTx(tx, w.chainID)
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+	}
+	if w.txPolicy != nil {
+		transactor.Signer = w.txPolicy.wrapSigner(transactor)
+	}
+	return transactor, nil
+
+}
+
 // Get the node private key
 func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 
@@ -0,0 +1,178 @@
+package wallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Config
+const (
+	SpendWindow time.Duration = 24 * time.Hour
+)
+
+// Limits on the transactions the node account will sign, guarding against bugs or misconfiguration draining it.
+// A nil limit isn't enforced.
+type TxPolicy struct {
+	// The most ETH a single transaction can send
+	MaxValue *big.Int
+
+	// The highest max fee per gas a transaction can pay
+	MaxFee *big.Int
+
+	// The most ETH that can be spent on values and max gas costs across the last 24 hours
+	DailySpendCap *big.Int
+
+	// Where signed transactions are logged for the daily spend cap; it's shared by every process that uses the wallet
+	spendLogPath string
+	lock         sync.Mutex
+}
+
+// A transaction counted towards the daily spend cap
+type spendRecord struct {
+	Hash     common.Hash `json:"hash"`
+	SignedAt time.Time   `json:"signedAt"`
+	Cost     *big.Int    `json:"cost"`
+}
+
+// Create a new transaction policy
+func NewTxPolicy(maxValue *big.Int, maxFee *big.Int, dailySpendCap *big.Int, spendLogPath string) *TxPolicy {
+	return &TxPolicy{
+		MaxValue:      maxValue,
+		MaxFee:        maxFee,
+		DailySpendCap: dailySpendCap,
+		spendLogPath:  spendLogPath,
+	}
+}
+
+// Set the transaction policy for the node account
+func (w *Wallet) SetTxPolicy(policy *TxPolicy) {
+	w.txPolicy = policy
+}
+
+// Get the transaction policy for the node account, or nil if there isn't one
+func (w *Wallet) GetTxPolicy() *TxPolicy {
+	return w.txPolicy
+}
+
+// Check a transaction against the policy's limits
+func (p *TxPolicy) Check(tx *types.Transaction) error {
+	if p.MaxValue != nil && tx.Value().Cmp(p.MaxValue) > 0 {
+		return fmt.Errorf("transaction refused by the wallet policy: its value of %.6f ETH is above the limit of %.6f ETH per transaction", eth.WeiToEth(tx.Value()), eth.WeiToEth(p.MaxValue))
+	}
+	if p.MaxFee != nil && tx.GasFeeCap().Cmp(p.MaxFee) > 0 {
+		return fmt.Errorf("transaction refused by the wallet policy: its max fee of %.2f gwei is above the limit of %.2f gwei", eth.WeiToGwei(tx.GasFeeCap()), eth.WeiToGwei(p.MaxFee))
+	}
+	if p.DailySpendCap != nil {
+		spent, err := p.GetDailySpend()
+		if err != nil {
+			return err
+		}
+		total := big.NewInt(0).Add(spent, tx.Cost())
+		if total.Cmp(p.DailySpendCap) > 0 {
+			return fmt.Errorf("transaction refused by the wallet policy: it could cost up to %.6f ETH, and %.6f ETH has already been spent in the last 24 hours, which would exceed the daily cap of %.6f ETH", eth.WeiToEth(tx.Cost()), eth.WeiToEth(spent), eth.WeiToEth(p.DailySpendCap))
+		}
+	}
+	return nil
+}
+
+// Count a signed transaction towards the daily spend cap, using its value plus its max gas cost
+func (p *TxPolicy) Record(tx *types.Transaction) error {
+	if p.DailySpendCap == nil {
+		return nil
+	}
+	record := spendRecord{
+		Hash:     tx.Hash(),
+		SignedAt: time.Now(),
+		Cost:     tx.Cost(),
+	}
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error serializing spend record: %w", err)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	err = os.MkdirAll(filepath.Dir(p.spendLogPath), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating wallet spend log directory: %w", err)
+	}
+	file, err := os.OpenFile(p.spendLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, FileMode)
+	if err != nil {
+		return fmt.Errorf("error opening wallet spend log %s: %w", p.spendLogPath, err)
+	}
+	defer file.Close()
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing to wallet spend log %s: %w", p.spendLogPath, err)
+	}
+	return nil
+}
+
+// Get how much has been spent in the last 24 hours
+func (p *TxPolicy) GetDailySpend() (*big.Int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	spent := big.NewInt(0)
+	file, err := os.Open(p.spendLogPath)
+	if os.IsNotExist(err) {
+		return spent, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening wallet spend log %s: %w", p.spendLogPath, err)
+	}
+	defer file.Close()
+
+	// A transaction signed more than once, such as when it is resubmitted, is only counted once
+	counted := map[common.Hash]bool{}
+	cutoff := time.Now().Add(-SpendWindow)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record spendRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Cost == nil {
+			// Skip lines that were only partially written
+			continue
+		}
+		if record.SignedAt.Before(cutoff) || counted[record.Hash] {
+			continue
+		}
+		counted[record.Hash] = true
+		spent.Add(spent, record.Cost)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading wallet spend log %s: %w", p.spendLogPath, err)
+	}
+	return spent, nil
+}
+
+// Wrap a transactor's signer so it only signs transactions the policy allows.
+// Transactions signed for simulations (when NoSend is set at signing time) aren't counted towards the daily cap.
+func (p *TxPolicy) wrapSigner(opts *bind.TransactOpts) bind.SignerFn {
+	signer := opts.Signer
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if err := p.Check(tx); err != nil {
+			return nil, err
+		}
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			return nil, err
+		}
+		if !opts.NoSend {
+			if err := p.Record(signedTx); err != nil {
+				return nil, err
+			}
+		}
+		return signedTx, nil
+	}
+}
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// A signer that holds the node key outside of the wallet, such as a hardware wallet
+type NodeSigner interface {
+	// Get the node account; this shouldn't need the signer itself, so read-only commands work without it
+	GetAccount() (accounts.Account, error)
+
+	// Sign a transaction for the node account
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// Sign a personal_sign message, returning a signature with a V of 27 or 28
+	SignText(text []byte) ([]byte, error)
+
+	// Sign EIP-712 typed data, returning a signature with a V of 27 or 28
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+}
+
+// Set the signer for the node account, replacing the key derived from the wallet's seed
+func (w *Wallet) SetNodeSigner(signer NodeSigner) {
+	w.nodeSigner = signer
+}
+
+// Check if the node account is signed for by an external signer
+func (w *Wallet) HasNodeSigner() bool {
+	return w.nodeSigner != nil
+}
+
+// Make sure a signature's V is 27 or 28, which is what the local key produces
+func normalizeSignatureV(signature []byte) []byte {
+	if len(signature) == 65 && signature[64] < 27 {
+		signature[64] += 27
+	}
+	return signature
+}
@@ -136,6 +136,20 @@ func (w *Wallet) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSP
 
 }
 
+// Deletes a validator key from all of the wallet's keystores
+func (w *Wallet) DeleteValidatorKey(pubkey types.ValidatorPubkey) error {
+
+	for name := range w.keystores {
+		if err := w.keystores[name].DeleteValidatorKey(pubkey); err != nil {
+			return fmt.Errorf("Could not delete %s validator key: %w", name, err)
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
 // Deletes all of the keystore directories and persistent VC storage
 func (w *Wallet) DeleteValidatorStores() error {
 
@@ -2,6 +2,8 @@ package wallet
 
 import (
 	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/google/uuid"
 	"github.com/tyler-smith/go-bip39"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -25,6 +28,7 @@ import (
 // Config
 const (
 	EntropyBits              = 256
+	ImportedSeedDomain       = "rocketpool imported node key"
 	FileMode                 = 0600
 	DefaultNodeKeyPath       = "m/44'/60'/0'/0/%d"
 	LedgerLiveNodeKeyPath    = "m/44'/60'/%d/0/0"
@@ -51,6 +55,12 @@ type Wallet struct {
 	nodeKey     *ecdsa.PrivateKey
 	nodeKeyPath string
 
+	// Signer for the node account when its key isn't derived from the seed
+	nodeSigner NodeSigner
+
+	// Limits on the transactions the node account will sign
+	txPolicy *TxPolicy
+
 	// Validator key caches
 	validatorKeys map[uint]*eth2types.BLSPrivateKey
 
@@ -72,6 +82,9 @@ type walletStore struct {
 	DerivationPath string                 `json:"derivationPath,omitempty"`
 	WalletIndex    uint                   `json:"walletIndex,omitempty"`
 	NextAccount    uint                   `json:"next_account"`
+
+	// Set when the wallet was imported from a node private key instead of a mnemonic, so the crypto holds the key
+	ImportedNodeKey bool `json:"importedNodeKey,omitempty"`
 }
 
 // Create new wallet
@@ -106,6 +119,12 @@ func (w *Wallet) GetChainID() *big.Int {
 	return copy
 }
 
+// Sets the max fee and max priority fee used by the wallet's transactors
+func (w *Wallet) SetGasSettings(maxFee *big.Int, maxPriorityFee *big.Int) {
+	w.maxFee = maxFee
+	w.maxPriorityFee = maxPriorityFee
+}
+
 // Add a keystore to the wallet
 func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
@@ -196,6 +215,52 @@ func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic strin
 
 }
 
+// Initialize the wallet from an existing node private key instead of a mnemonic.
+// Validator keys are derived from a seed generated from the private key, so the private key alone is enough to recover them.
+func (w *Wallet) ImportNodeKey(privateKey *ecdsa.PrivateKey) error {
+
+	// Check wallet is not initialized
+	if w.IsInitialized() {
+		return errors.New("Wallet is already initialized")
+	}
+
+	// Get wallet password
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return fmt.Errorf("Could not get wallet password: %w", err)
+	}
+
+	// Encrypt private key
+	encryptedKey, err := w.encryptor.Encrypt(crypto.FromECDSA(privateKey), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt node private key: %w", err)
+	}
+
+	// Set up the keys
+	if err := w.setImportedNodeKey(privateKey); err != nil {
+		return err
+	}
+
+	// Create wallet store
+	w.ws = &walletStore{
+		Crypto:          encryptedKey,
+		Name:            w.encryptor.Name(),
+		Version:         w.encryptor.Version(),
+		UUID:            uuid.New(),
+		NextAccount:     0,
+		ImportedNodeKey: true,
+	}
+
+	// Return
+	return nil
+
+}
+
+// Check if the wallet was imported from a node private key rather than created from a mnemonic
+func (w *Wallet) IsImportedNodeKey() bool {
+	return w.ws != nil && w.ws.ImportedNodeKey
+}
+
 // Recover a wallet from a mnemonic - only used for testing mnemonics
 func (w *Wallet) TestRecovery(derivationPath string, walletIndex uint, mnemonic string) error {
 
@@ -272,22 +337,43 @@ func (w *Wallet) Delete() error {
 
 // Signs a serialized TX using the wallet's private key
 func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
-	}
-
 	tx := types.Transaction{}
-	err = tx.UnmarshalBinary(serializedTx)
+	err := tx.UnmarshalBinary(serializedTx)
 	if err != nil {
 		return nil, fmt.Errorf("Error unmarshalling TX: %w", err)
 	}
 
-	signer := types.NewLondonSigner(w.chainID)
-	signedTx, err := types.SignTx(&tx, signer, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("Error signing TX: %w", err)
+	// Check the wallet policy; the signed transaction could be sent by anyone, so it counts towards the daily cap
+	if w.txPolicy != nil {
+		if err := w.txPolicy.Check(&tx); err != nil {
+			return nil, err
+		}
+	}
+
+	var signedTx *types.Transaction
+	if w.nodeSigner != nil {
+		signedTx, err = w.nodeSigner.SignTx(&tx, w.chainID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Get private key
+		privateKey, _, err := w.getNodePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		signer := types.NewLondonSigner(w.chainID)
+		signedTx, err = types.SignTx(&tx, signer, privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("Error signing TX: %w", err)
+		}
+	}
+
+	if w.txPolicy != nil {
+		if err := w.txPolicy.Record(signedTx); err != nil {
+			return nil, err
+		}
 	}
 
 	signedData, err := signedTx.MarshalBinary()
@@ -300,6 +386,10 @@ func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
 
 // Signs an arbitrary message using the wallet's private key
 func (w *Wallet) SignMessage(message string) ([]byte, error) {
+	if w.nodeSigner != nil {
+		return w.nodeSigner.SignText([]byte(message))
+	}
+
 	// Get the wallet's private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -317,6 +407,47 @@ func (w *Wallet) SignMessage(message string) ([]byte, error) {
 	return signedMessage, nil
 }
 
+// Signs an EIP-712 typed data payload using the wallet's private key
+func (w *Wallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	if w.nodeSigner != nil {
+		return w.nodeSigner.SignTypedData(typedData)
+	}
+
+	// Get the wallet's private key
+	privateKey, _, err := w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	dataHash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error hashing typed data: %w", err)
+	}
+	signedData, err := crypto.Sign(dataHash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing typed data: %w", err)
+	}
+
+	// fix the ECDSA 'v', same as for personal_sign messages
+	signedData[crypto.RecoveryIDOffset] += 27
+	return signedData, nil
+}
+
+// Switch the wallet to the store and keystores of another wallet profile, dropping everything cached from the current one.
+// The password manager must already point at the new profile's password.
+func (w *Wallet) SwitchProfile(walletPath string, keystores map[string]keystore.Keystore) error {
+	w.walletPath = walletPath
+	w.keystores = keystores
+	w.ws = nil
+	w.seed = nil
+	w.mk = nil
+	w.nodeKey = nil
+	w.nodeKeyPath = ""
+	w.validatorKeys = map[uint]*eth2types.BLSPrivateKey{}
+	_, err := w.loadStore()
+	return err
+}
+
 // Reloads wallet from disk
 func (w *Wallet) Reload() error {
 	_, err := w.loadStore()
@@ -339,21 +470,43 @@ func (w *Wallet) loadStore() (bool, error) {
 	}
 
 	// Upgrade legacy wallets to include derivation paths
-	if w.ws.DerivationPath == "" {
+	if w.ws.DerivationPath == "" && !w.ws.ImportedNodeKey {
 		w.ws.DerivationPath = DefaultNodeKeyPath
 	}
 
-	// Get wallet password
+	// Get wallet password; a locked wallet is treated as uninitialized until it's unlocked
 	password, err := w.pm.GetPassword()
+	if errors.Is(err, passwords.ErrPasswordLocked) {
+		w.ws = nil
+		return false, nil
+	}
 	if err != nil {
 		return false, fmt.Errorf("Could not get wallet password: %w", err)
 	}
 
+	// Wallets imported from a private key store the key instead of a seed
+	if w.ws.ImportedNodeKey {
+		keyBytes, err := w.encryptor.Decrypt(w.ws.Crypto, password)
+		if err != nil {
+			return false, fmt.Errorf("Could not decrypt node private key: %w", err)
+		}
+		privateKey, err := crypto.ToECDSA(keyBytes)
+		if err != nil {
+			return false, fmt.Errorf("Could not decode node private key: %w", err)
+		}
+		if err := w.setImportedNodeKey(privateKey); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
 	// Decrypt seed
 	w.seed, err = w.encryptor.Decrypt(w.ws.Crypto, password)
 	if err != nil {
 		return false, fmt.Errorf("Could not decrypt wallet seed: %w", err)
 	}
+	w.nodeKey = nil
+	w.nodeKeyPath = ""
 
 	// Create master key
 	w.mk, err = hdkeychain.NewMaster(w.seed, &chaincfg.MainNetParams)
@@ -406,3 +559,27 @@ func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemon
 	return nil
 
 }
+
+// Use an imported node private key, generating the seed for validator keys from it
+func (w *Wallet) setImportedNodeKey(privateKey *ecdsa.PrivateKey) error {
+
+	// Generate seed
+	mac := hmac.New(sha512.New, []byte(ImportedSeedDomain))
+	mac.Write(crypto.FromECDSA(privateKey))
+	w.seed = mac.Sum(nil)
+
+	// Create master key; it isn't used for the node key, but marks the wallet as initialized
+	var err error
+	w.mk, err = hdkeychain.NewMaster(w.seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return fmt.Errorf("Could not create wallet master key: %w", err)
+	}
+
+	// Cache node key
+	w.nodeKey = privateKey
+	w.nodeKeyPath = ""
+
+	// Return
+	return nil
+
+}
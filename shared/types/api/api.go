@@ -1,6 +1,30 @@
 package api
 
+import "encoding/json"
+
+// The version of the API response envelope (the status and error fields, batch responses, and the event stream); this is
+// bumped whenever those change in a way that isn't backwards compatible. Individual commands are versioned separately
+// (see GetCommandVersion).
+const ResponseSchemaVersion uint64 = 1
+
+// The header the API server reports the response schema version in
+const ResponseSchemaVersionHeader string = "X-Smartnode-Schema-Version"
+
 type APIResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type ApiVersionResponse struct {
+	Status           string            `json:"status"`
+	Error            string            `json:"error"`
+	SchemaVersion    uint64            `json:"schemaVersion"`
+	SmartnodeVersion string            `json:"smartnodeVersion"`
+	CommandVersions  map[string]uint64 `json:"commandVersions"`
+}
+
+type BatchResponse struct {
+	Status    string            `json:"status"`
+	Error     string            `json:"error"`
+	Responses []json.RawMessage `json:"responses"`
+}
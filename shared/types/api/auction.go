@@ -41,6 +41,7 @@ type CanCreateLotResponse struct {
 	CanCreate           bool               `json:"canCreate"`
 	InsufficientBalance bool               `json:"insufficientBalance"`
 	CreateLotDisabled   bool               `json:"createLotDisabled"`
+	RevertReason        string             `json:"revertReason"`
 	GasInfo             rocketpool.GasInfo `json:"gasInfo"`
 }
 type CreateLotResponse struct {
@@ -58,6 +59,7 @@ type CanBidOnLotResponse struct {
 	BiddingEnded     bool               `json:"biddingEnded"`
 	RPLExhausted     bool               `json:"rplExhausted"`
 	BidOnLotDisabled bool               `json:"bidOnLotDisabled"`
+	RevertReason     string             `json:"revertReason"`
 	GasInfo          rocketpool.GasInfo `json:"gasInfo"`
 }
 type BidOnLotResponse struct {
@@ -73,6 +75,7 @@ type CanClaimFromLotResponse struct {
 	DoesNotExist     bool               `json:"doesNotExist"`
 	NoBidFromAddress bool               `json:"noBidFromAddress"`
 	NotCleared       bool               `json:"notCleared"`
+	RevertReason     string             `json:"revertReason"`
 	GasInfo          rocketpool.GasInfo `json:"gasInfo"`
 }
 type ClaimFromLotResponse struct {
@@ -89,6 +92,7 @@ type CanRecoverRPLFromLotResponse struct {
 	BiddingNotEnded     bool               `json:"biddingNotEnded"`
 	NoUnclaimedRPL      bool               `json:"noUnclaimedRpl"`
 	RPLAlreadyRecovered bool               `json:"rplAlreadyRecovered"`
+	RevertReason        string             `json:"revertReason"`
 	GasInfo             rocketpool.GasInfo `json:"gasInfo"`
 }
 type RecoverRPLFromLotResponse struct {
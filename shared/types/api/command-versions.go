@@ -0,0 +1,23 @@
+package api
+
+import "strings"
+
+// The version every API command's request and response types start at
+const DefaultCommandVersion uint64 = 1
+
+// The header the API server reports a command's version in
+const CommandVersionHeader string = "X-Smartnode-Command-Version"
+
+// The versions of the API commands whose args, flags, or response type have changed in a way that isn't backwards
+// compatible, by their full path (without aliases). Bump a command's entry (adding it at 2 if it isn't listed) whenever
+// that happens; commands that aren't listed are at DefaultCommandVersion.
+var commandVersions = map[string]uint64{}
+
+// Get the version of an API command's request and response types by its full path
+func GetCommandVersion(path []string) uint64 {
+	version, exists := commandVersions[strings.Join(path, " ")]
+	if !exists {
+		return DefaultCommandVersion
+	}
+	return version
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Event types pushed to API server event stream subscribers
+type EventType string
+
+const (
+	EventType_MinipoolStatus       EventType = "minipool-status"
+	EventType_RewardsInterval      EventType = "rewards-interval"
+	EventType_TransactionConfirmed EventType = "transaction-confirmed"
+	EventType_SyncStatus           EventType = "sync-status"
+)
+
+// An event pushed to API server event stream subscribers
+type Event struct {
+	Type EventType   `json:"type"`
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data"`
+}
+
+type MinipoolStatusEvent struct {
+	Address        common.Address `json:"address"`
+	PreviousStatus string         `json:"previousStatus"`
+	Status         string         `json:"status"`
+}
+
+type RewardsIntervalEvent struct {
+	PreviousIndex uint64 `json:"previousIndex"`
+	Index         uint64 `json:"index"`
+}
+
+type TransactionConfirmedEvent struct {
+	TxHash      common.Hash `json:"txHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+	Success     bool        `json:"success"`
+	Error       string      `json:"error"`
+}
+
+type SyncStatusEvent struct {
+	EcStatus ClientManagerStatus `json:"ecStatus"`
+	BcStatus ClientManagerStatus `json:"bcStatus"`
+}
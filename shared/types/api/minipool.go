@@ -70,6 +70,7 @@ type CanRefundMinipoolResponse struct {
 	Error                     string             `json:"error"`
 	CanRefund                 bool               `json:"canRefund"`
 	InsufficientRefundBalance bool               `json:"insufficientRefundBalance"`
+	RevertReason              string             `json:"revertReason"`
 	GasInfo                   rocketpool.GasInfo `json:"gasInfo"`
 }
 type RefundMinipoolResponse struct {
@@ -83,6 +84,7 @@ type CanDissolveMinipoolResponse struct {
 	Error         string             `json:"error"`
 	CanDissolve   bool               `json:"canDissolve"`
 	InvalidStatus bool               `json:"invalidStatus"`
+	RevertReason  string             `json:"revertReason"`
 	GasInfo       rocketpool.GasInfo `json:"gasInfo"`
 }
 type DissolveMinipoolResponse struct {
@@ -112,6 +114,29 @@ type ChangeWithdrawalCredentialsResponse struct {
 	Error  string `json:"error"`
 }
 
+type BlsCredsMinipool struct {
+	Address               common.Address        `json:"address"`
+	Pubkey                types.ValidatorPubkey `json:"pubkey"`
+	ValidatorIndex        uint64                `json:"validatorIndex"`
+	WithdrawalCredentials common.Hash           `json:"withdrawalCredentials"`
+}
+type GetBlsCredsMinipoolsResponse struct {
+	Status    string             `json:"status"`
+	Error     string             `json:"error"`
+	Minipools []BlsCredsMinipool `json:"minipools"`
+}
+type WithdrawalCredsRotation struct {
+	Address       common.Address        `json:"address"`
+	Pubkey        types.ValidatorPubkey `json:"pubkey"`
+	Rotated       bool                  `json:"rotated"`
+	FailureReason string                `json:"failureReason"`
+}
+type RotateWithdrawalCredsResponse struct {
+	Status    string                    `json:"status"`
+	Error     string                    `json:"error"`
+	Rotations []WithdrawalCredsRotation `json:"rotations"`
+}
+
 type ImportKeyResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
@@ -213,6 +238,7 @@ type CanDelegateUpgradeResponse struct {
 	Status                string             `json:"status"`
 	Error                 string             `json:"error"`
 	LatestDelegateAddress common.Address     `json:"latestDelegateAddress"`
+	RevertReason          string             `json:"revertReason"`
 	GasInfo               rocketpool.GasInfo `json:"gasInfo"`
 }
 type DelegateUpgradeResponse struct {
@@ -225,6 +251,7 @@ type CanDelegateRollbackResponse struct {
 	Status          string             `json:"status"`
 	Error           string             `json:"error"`
 	RollbackAddress common.Address     `json:"rollbackAddress"`
+	RevertReason    string             `json:"revertReason"`
 	GasInfo         rocketpool.GasInfo `json:"gasInfo"`
 }
 type DelegateRollbackResponse struct {
@@ -234,9 +261,10 @@ type DelegateRollbackResponse struct {
 }
 
 type CanSetUseLatestDelegateResponse struct {
-	Status  string             `json:"status"`
-	Error   string             `json:"error"`
-	GasInfo rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type SetUseLatestDelegateResponse struct {
 	Status string      `json:"status"`
@@ -245,10 +273,11 @@ type SetUseLatestDelegateResponse struct {
 }
 
 type CanStakeMinipoolResponse struct {
-	Status   string             `json:"status"`
-	Error    string             `json:"error"`
-	CanStake bool               `json:"canStake"`
-	GasInfo  rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	CanStake     bool               `json:"canStake"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type StakeMinipoolResponse struct {
 	Status string      `json:"status"`
@@ -257,10 +286,11 @@ type StakeMinipoolResponse struct {
 }
 
 type CanPromoteMinipoolResponse struct {
-	Status     string             `json:"status"`
-	Error      string             `json:"error"`
-	CanPromote bool               `json:"canPromote"`
-	GasInfo    rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	CanPromote   bool               `json:"canPromote"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type PromoteMinipoolResponse struct {
 	Status string      `json:"status"`
@@ -311,6 +341,7 @@ type CanBeginReduceBondAmountResponse struct {
 	BeaconState           beacon.ValidatorState `json:"beaconState"`
 	InvalidBeaconState    bool                  `json:"invalidBeaconState"`
 	CanReduce             bool                  `json:"canReduce"`
+	RevertReason          string                `json:"revertReason"`
 	GasInfo               rocketpool.GasInfo    `json:"gasInfo"`
 }
 type BeginReduceBondAmountResponse struct {
@@ -324,6 +355,7 @@ type CanReduceBondAmountResponse struct {
 	Error           string             `json:"error"`
 	MinipoolVersion uint8              `json:"minipoolVersion"`
 	CanReduce       bool               `json:"canReduce"`
+	RevertReason    string             `json:"revertReason"`
 	GasInfo         rocketpool.GasInfo `json:"gasInfo"`
 }
 type ReduceBondAmountResponse struct {
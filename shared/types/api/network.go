@@ -2,8 +2,11 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/gas/oracle"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
 )
 
 type NodeFeeResponse struct {
@@ -27,27 +30,35 @@ type RplPriceResponse struct {
 }
 
 type NetworkStatsResponse struct {
-	Status                    string         `json:"status"`
-	Error                     string         `json:"error"`
-	TotalValueLocked          float64        `json:"totalValueLocked"`
-	DepositPoolBalance        float64        `json:"depositPoolBalance"`
-	MinipoolCapacity          float64        `json:"minipoolCapacity"`
-	StakerUtilization         float64        `json:"stakerUtilization"`
-	NodeFee                   float64        `json:"nodeFee"`
-	NodeCount                 uint64         `json:"nodeCount"`
-	InitializedMinipoolCount  uint64         `json:"initializedMinipoolCount"`
-	PrelaunchMinipoolCount    uint64         `json:"prelaunchMinipoolCount"`
-	StakingMinipoolCount      uint64         `json:"stakingMinipoolCount"`
-	WithdrawableMinipoolCount uint64         `json:"withdrawableMinipoolCount"`
-	DissolvedMinipoolCount    uint64         `json:"dissolvedMinipoolCount"`
-	FinalizedMinipoolCount    uint64         `json:"finalizedMinipoolCount"`
-	RplPrice                  float64        `json:"rplPrice"`
-	TotalRplStaked            float64        `json:"totalRplStaked"`
-	EffectiveRplStaked        float64        `json:"effectiveRplStaked"`
-	RethPrice                 float64        `json:"rethPrice"`
-	SmoothingPoolNodes        uint64         `json:"smoothingPoolNodes"`
-	SmoothingPoolAddress      common.Address `json:"SmoothingPoolAddress"`
-	SmoothingPoolBalance      float64        `json:"smoothingPoolBalance"`
+	Status                    string            `json:"status"`
+	Error                     string            `json:"error"`
+	TotalValueLocked          float64           `json:"totalValueLocked"`
+	DepositPoolBalance        float64           `json:"depositPoolBalance"`
+	MinipoolCapacity          float64           `json:"minipoolCapacity"`
+	StakerUtilization         float64           `json:"stakerUtilization"`
+	NodeFee                   float64           `json:"nodeFee"`
+	NodeCount                 uint64            `json:"nodeCount"`
+	InitializedMinipoolCount  uint64            `json:"initializedMinipoolCount"`
+	PrelaunchMinipoolCount    uint64            `json:"prelaunchMinipoolCount"`
+	StakingMinipoolCount      uint64            `json:"stakingMinipoolCount"`
+	WithdrawableMinipoolCount uint64            `json:"withdrawableMinipoolCount"`
+	DissolvedMinipoolCount    uint64            `json:"dissolvedMinipoolCount"`
+	FinalizedMinipoolCount    uint64            `json:"finalizedMinipoolCount"`
+	RplPrice                  float64           `json:"rplPrice"`
+	TotalRplStaked            float64           `json:"totalRplStaked"`
+	EffectiveRplStaked        float64           `json:"effectiveRplStaked"`
+	RethPrice                 float64           `json:"rethPrice"`
+	RethMarketPrices          []RethMarketPrice `json:"rethMarketPrices"`
+	SmoothingPoolNodes        uint64            `json:"smoothingPoolNodes"`
+	SmoothingPoolAddress      common.Address    `json:"SmoothingPoolAddress"`
+	SmoothingPoolBalance      float64           `json:"smoothingPoolBalance"`
+}
+
+type RethMarketPrice struct {
+	Source  string  `json:"source"`
+	Price   float64 `json:"price"`
+	Premium float64 `json:"premium"`
+	Error   string  `json:"error"`
 }
 
 type NetworkTimezonesResponse struct {
@@ -63,13 +74,65 @@ type CanNetworkGenerateRewardsTreeResponse struct {
 	Error          string `json:"error"`
 	CurrentIndex   uint64 `json:"currentIndex"`
 	TreeFileExists bool   `json:"treeFileExists"`
+	SnapshotBlock  uint64 `json:"snapshotBlock"`
+
+	// Set when neither the primary EC nor the archive EC has the state for the snapshot block
+	ArchiveRequired      bool   `json:"archiveRequired"`
+	ArchiveEcUrl         string `json:"archiveEcUrl"`
+	PrimaryEarliestBlock uint64 `json:"primaryEarliestBlock"`
+	ArchiveEarliestBlock uint64 `json:"archiveEarliestBlock"`
+	EarliestBlockError   string `json:"earliestBlockError"`
+}
+
+type NetworkRequestRewardsTreeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
 }
 
 type NetworkGenerateRewardsTreeResponse struct {
+	Status              string      `json:"status"`
+	Error               string      `json:"error"`
+	TreeFilePath        string      `json:"treeFilePath"`
+	GeneratedMerkleRoot common.Hash `json:"generatedMerkleRoot"`
+	CanonicalMerkleRoot common.Hash `json:"canonicalMerkleRoot"`
+	Matches             bool        `json:"matches"`
+}
+
+type CanNetworkVerifyRewardsTreeResponse struct {
+	Status                string                           `json:"status"`
+	Error                 string                           `json:"error"`
+	CurrentIndex          uint64                           `json:"currentIndex"`
+	VerificationRequested bool                             `json:"verificationRequested"`
+	Verification          *rewards.RewardsTreeVerification `json:"verification"`
+}
+
+type NetworkVerifyRewardsTreeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
 
+type NetworkMinipoolPerformanceResponse struct {
+	Status               string                                    `json:"status"`
+	Error                string                                    `json:"error"`
+	Index                uint64                                    `json:"index"`
+	StartTime            time.Time                                 `json:"startTime"`
+	EndTime              time.Time                                 `json:"endTime"`
+	Downloaded           bool                                      `json:"downloaded"`
+	Minipool             common.Address                            `json:"minipool"`
+	MinipoolFound        bool                                      `json:"minipoolFound"`
+	Performance          *rewards.SmoothingPoolMinipoolPerformance `json:"performance"`
+	NodeAddress          common.Address                            `json:"nodeAddress"`
+	NodeRewardsFound     bool                                      `json:"nodeRewardsFound"`
+	NodeEligibilityRate  float64                                   `json:"nodeEligibilityRate"`
+	NodeSmoothingPoolEth *big.Int                                  `json:"nodeSmoothingPoolEth"`
+}
+
+type NetworkGasOracleResponse struct {
+	Status   string              `json:"status"`
+	Error    string              `json:"error"`
+	Estimate *oracle.FeeEstimate `json:"estimate"`
+}
+
 type NetworkDAOProposalsResponse struct {
 	Status                  string                 `json:"status"`
 	Error                   string                 `json:"error"`
@@ -95,3 +158,114 @@ type GetLatestDelegateResponse struct {
 	Error   string         `json:"error"`
 	Address common.Address `json:"address"`
 }
+
+type NetworkQueueStatusResponse struct {
+	Status                    string                     `json:"status"`
+	Error                     string                     `json:"error"`
+	DepositPoolBalance        *big.Int                   `json:"depositPoolBalance"`
+	DepositPoolExcessBalance  *big.Int                   `json:"depositPoolExcessBalance"`
+	AssignDepositsEnabled     bool                       `json:"assignDepositsEnabled"`
+	MaximumDepositAssignments uint64                     `json:"maximumDepositAssignments"`
+	QueueLengths              MinipoolQueueLengths       `json:"queueLengths"`
+	RethExchangeRate          float64                    `json:"rethExchangeRate"`
+	RethExchangeRateHistory   []RethExchangeRateSnapshot `json:"rethExchangeRateHistory"`
+}
+type MinipoolQueueLengths struct {
+	Total           uint64 `json:"total"`
+	FullDeposit     uint64 `json:"fullDeposit"`
+	HalfDeposit     uint64 `json:"halfDeposit"`
+	EmptyDeposit    uint64 `json:"emptyDeposit"`
+	VariableDeposit uint64 `json:"variableDeposit"`
+}
+type RethExchangeRateSnapshot struct {
+	Block        uint64    `json:"block"`
+	Time         time.Time `json:"time"`
+	ExchangeRate float64   `json:"exchangeRate"`
+}
+
+type StateSnapshotsResponse struct {
+	Status    string   `json:"status"`
+	Error     string   `json:"error"`
+	Snapshots []string `json:"snapshots"`
+}
+
+type StateSnapshotDiffResponse struct {
+	Status            string                     `json:"status"`
+	Error             string                     `json:"error"`
+	From              StateSnapshotSummary       `json:"from"`
+	To                StateSnapshotSummary       `json:"to"`
+	NewNodes          []common.Address           `json:"newNodes"`
+	RemovedNodes      []common.Address           `json:"removedNodes"`
+	NewMinipools      []common.Address           `json:"newMinipools"`
+	RemovedMinipools  []common.Address           `json:"removedMinipools"`
+	StatusTransitions []MinipoolStatusTransition `json:"statusTransitions"`
+	RplStakeChanges   []NodeRplStakeChange       `json:"rplStakeChanges"`
+}
+type StateSnapshotSummary struct {
+	Name             string         `json:"name"`
+	Scope            string         `json:"scope"`
+	Time             time.Time      `json:"time"`
+	ElBlockNumber    uint64         `json:"elBlockNumber"`
+	BeaconSlotNumber uint64         `json:"beaconSlotNumber"`
+	NodeCount        int            `json:"nodeCount"`
+	MinipoolCount    int            `json:"minipoolCount"`
+	MinipoolStatuses map[string]int `json:"minipoolStatuses"`
+	TotalRplStake    *big.Int       `json:"totalRplStake"`
+	TotalEthMatched  *big.Int       `json:"totalEthMatched"`
+}
+type MinipoolStatusTransition struct {
+	From      string           `json:"from"`
+	To        string           `json:"to"`
+	Minipools []common.Address `json:"minipools"`
+}
+type NodeRplStakeChange struct {
+	Node common.Address `json:"node"`
+	From *big.Int       `json:"from"`
+	To   *big.Int       `json:"to"`
+}
+
+// How a deployment contract compares to the deployment
+type DeploymentContractStatus string
+
+const (
+	DeploymentContractStatus_Ok         DeploymentContractStatus = "ok"
+	DeploymentContractStatus_Unverified DeploymentContractStatus = "unverified"
+	DeploymentContractStatus_Mismatch   DeploymentContractStatus = "mismatch"
+	DeploymentContractStatus_Missing    DeploymentContractStatus = "missing"
+)
+
+type DeploymentContract struct {
+	Name              string                   `json:"name"`
+	Address           common.Address           `json:"address"`
+	DeploymentAddress common.Address           `json:"deploymentAddress"`
+	HasCode           bool                     `json:"hasCode"`
+	CodeHash          common.Hash              `json:"codeHash"`
+	ExpectedCodeHash  common.Hash              `json:"expectedCodeHash"`
+	HasVersion        bool                     `json:"hasVersion"`
+	Version           uint8                    `json:"version"`
+	ExpectedVersion   uint8                    `json:"expectedVersion"`
+	ContractStatus    DeploymentContractStatus `json:"contractStatus"`
+	Problems          []string                 `json:"problems"`
+}
+
+type DeploymentCheck struct {
+	Name     string `json:"name"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Matches  bool   `json:"matches"`
+	Error    string `json:"error"`
+}
+
+type NetworkDeploymentStatusResponse struct {
+	Status           string               `json:"status"`
+	Error            string               `json:"error"`
+	Network          string               `json:"network"`
+	DeploymentFile   string               `json:"deploymentFile"`
+	ChainID          uint                 `json:"chainId"`
+	ExecutionChainID uint                 `json:"executionChainId"`
+	StorageAddress   common.Address       `json:"storageAddress"`
+	IsCustom         bool                 `json:"isCustom"`
+	GenesisChecks    []DeploymentCheck    `json:"genesisChecks"`
+	Contracts        []DeploymentContract `json:"contracts"`
+	Verified         bool                 `json:"verified"`
+}
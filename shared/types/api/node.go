@@ -5,11 +5,14 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/txjournal"
+	"github.com/rocket-pool/smartnode/shared/services/txqueue"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -70,8 +73,22 @@ type CanRegisterNodeResponse struct {
 	CanRegister          bool               `json:"canRegister"`
 	AlreadyRegistered    bool               `json:"alreadyRegistered"`
 	RegistrationDisabled bool               `json:"registrationDisabled"`
+	RevertReason         string             `json:"revertReason"`
 	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
 }
+type NodePreflightResponse struct {
+	Status  string               `json:"status"`
+	Error   string               `json:"error"`
+	Passed  bool                 `json:"passed"`
+	Checks  []NodePreflightCheck `json:"checks"`
+	GasInfo rocketpool.GasInfo   `json:"gasInfo"`
+}
+type NodePreflightCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
 type RegisterNodeResponse struct {
 	Status string      `json:"status"`
 	Error  string      `json:"error"`
@@ -79,10 +96,11 @@ type RegisterNodeResponse struct {
 }
 
 type CanSetNodeWithdrawalAddressResponse struct {
-	Status  string             `json:"status"`
-	Error   string             `json:"error"`
-	CanSet  bool               ` json:"canSet"`
-	GasInfo rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	CanSet       bool               ` json:"canSet"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type SetNodeWithdrawalAddressResponse struct {
 	Status string      `json:"status"`
@@ -91,10 +109,11 @@ type SetNodeWithdrawalAddressResponse struct {
 }
 
 type CanConfirmNodeWithdrawalAddressResponse struct {
-	Status     string             `json:"status"`
-	Error      string             `json:"error"`
-	CanConfirm bool               `json:"canConfirm"`
-	GasInfo    rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	CanConfirm   bool               `json:"canConfirm"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type ConfirmNodeWithdrawalAddressResponse struct {
 	Status string      `json:"status"`
@@ -115,10 +134,11 @@ type GetNodePendingWithdrawalAddressResponse struct {
 }
 
 type CanSetNodeTimezoneResponse struct {
-	Status  string             `json:"status"`
-	Error   string             `json:"error"`
-	CanSet  bool               `json:"canSet"`
-	GasInfo rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	CanSet       bool               `json:"canSet"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type SetNodeTimezoneResponse struct {
 	Status string      `json:"status"`
@@ -131,6 +151,7 @@ type CanNodeSwapRplResponse struct {
 	Error               string             `json:"error"`
 	CanSwap             bool               `json:"canSwap"`
 	InsufficientBalance bool               `json:"insufficientBalance"`
+	RevertReason        string             `json:"revertReason"`
 	GasInfo             rocketpool.GasInfo `json:"GasInfo"`
 }
 type NodeSwapRplApproveGasResponse struct {
@@ -161,6 +182,7 @@ type CanNodeStakeRplResponse struct {
 	InsufficientBalance bool               `json:"insufficientBalance"`
 	IsAtlasDeployed     bool               `json:"isAtlasDeployed"`
 	InConsensus         bool               `json:"inConsensus"`
+	RevertReason        string             `json:"revertReason"`
 	GasInfo             rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeStakeRplApproveGasResponse struct {
@@ -193,6 +215,7 @@ type CanNodeWithdrawRplResponse struct {
 	WithdrawalDelayActive        bool               `json:"withdrawalDelayActive"`
 	InConsensus                  bool               `json:"inConsensus"`
 	IsAtlasDeployed              bool               `json:"isAtlasDeployed"`
+	RevertReason                 string             `json:"revertReason"`
 	GasInfo                      rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeWithdrawRplResponse struct {
@@ -218,6 +241,7 @@ type CanNodeDepositResponse struct {
 	InConsensus                      bool               `json:"inConsensus"`
 	IsAtlasDeployed                  bool               `json:"isAtlasDeployed"`
 	MinipoolAddress                  common.Address     `json:"minipoolAddress"`
+	RevertReason                     string             `json:"revertReason"`
 	GasInfo                          rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeDepositResponse struct {
@@ -237,6 +261,7 @@ type CanCreateVacantMinipoolResponse struct {
 	InvalidAmount        bool               `json:"invalidAmount"`
 	DepositDisabled      bool               `json:"depositDisabled"`
 	MinipoolAddress      common.Address     `json:"minipoolAddress"`
+	RevertReason         string             `json:"revertReason"`
 	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
 }
 type CreateVacantMinipoolResponse struct {
@@ -253,6 +278,7 @@ type CanNodeSendResponse struct {
 	Error               string             `json:"error"`
 	CanSend             bool               `json:"canSend"`
 	InsufficientBalance bool               `json:"insufficientBalance"`
+	RevertReason        string             `json:"revertReason"`
 	GasInfo             rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeSendResponse struct {
@@ -261,12 +287,46 @@ type NodeSendResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type CanNodeSweepResponse struct {
+	Status                  string             `json:"status"`
+	Error                   string             `json:"error"`
+	CanSweep                bool               `json:"canSweep"`
+	InsufficientBalance     bool               `json:"insufficientBalance"`
+	WithdrawalAddressIsNode bool               `json:"withdrawalAddressIsNode"`
+	WithdrawalAddress       common.Address     `json:"withdrawalAddress"`
+	Balance                 *big.Int           `json:"balance"`
+	Reserve                 *big.Int           `json:"reserve"`
+	Amount                  *big.Int           `json:"amount"`
+	RevertReason            string             `json:"revertReason"`
+	GasInfo                 rocketpool.GasInfo `json:"gasInfo"`
+}
+type NodeSweepResponse struct {
+	Status            string         `json:"status"`
+	Error             string         `json:"error"`
+	WithdrawalAddress common.Address `json:"withdrawalAddress"`
+	Amount            *big.Int       `json:"amount"`
+	TxHash            common.Hash    `json:"txHash"`
+}
+
+type NodeSendRawTransactionResponse struct {
+	Status string          `json:"status"`
+	Error  string          `json:"error"`
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to"`
+	Nonce  uint64          `json:"nonce"`
+	Value  *big.Int        `json:"value"`
+	TxHash common.Hash     `json:"txHash"`
+}
+
 type CanNodeBurnResponse struct {
 	Status                 string             `json:"status"`
 	Error                  string             `json:"error"`
 	CanBurn                bool               `json:"canBurn"`
 	InsufficientBalance    bool               `json:"insufficientBalance"`
 	InsufficientCollateral bool               `json:"insufficientCollateral"`
+	EthValue               *big.Int           `json:"ethValue"`
+	AvailableLiquidity     *big.Int           `json:"availableLiquidity"`
+	RevertReason           string             `json:"revertReason"`
 	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeBurnResponse struct {
@@ -357,6 +417,22 @@ type ClearSnapshotDelegateResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type GetSnapshotDelegateResponse struct {
+	Status            string         `json:"status"`
+	Error             string         `json:"error"`
+	Delegate          common.Address `json:"delegate"`
+	DelegateFormatted string         `json:"delegateFormatted"`
+	HasDelegate       bool           `json:"hasDelegate"`
+}
+
+type SignSnapshotVoteResponse struct {
+	Status    string             `json:"status"`
+	Error     string             `json:"error"`
+	Address   common.Address     `json:"address"`
+	Signature string             `json:"signature"`
+	TypedData apitypes.TypedData `json:"typedData"`
+}
+
 type NodeIsFeeDistributorInitializedResponse struct {
 	Status        string `json:"status"`
 	Error         string `json:"error"`
@@ -378,6 +454,7 @@ type NodeCanDistributeResponse struct {
 	Error          string             `json:"error"`
 	Balance        *big.Int           `json:"balance"`
 	AverageNodeFee float64            `json:"averageNodeFee"`
+	RevertReason   string             `json:"revertReason"`
 	GasInfo        rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeDistributeResponse struct {
@@ -408,9 +485,10 @@ type NodeGetRewardsInfoResponse struct {
 }
 
 type CanNodeClaimRewardsResponse struct {
-	Status  string             `json:"status"`
-	Error   string             `json:"error"`
-	GasInfo rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeClaimRewardsResponse struct {
 	Status string      `json:"status"`
@@ -419,9 +497,10 @@ type NodeClaimRewardsResponse struct {
 }
 
 type CanNodeClaimAndStakeRewardsResponse struct {
-	Status  string             `json:"status"`
-	Error   string             `json:"error"`
-	GasInfo rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeClaimAndStakeRewardsResponse struct {
 	Status string      `json:"status"`
@@ -436,9 +515,10 @@ type GetSmoothingPoolRegistrationStatusResponse struct {
 	TimeLeftUntilChangeable time.Duration `json:"timeLeftUntilChangeable"`
 }
 type CanSetSmoothingPoolRegistrationStatusResponse struct {
-	Status  string             `json:"status"`
-	Error   string             `json:"error"`
-	GasInfo rocketpool.GasInfo `json:"gasInfo"`
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	RevertReason string             `json:"revertReason"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type SetSmoothingPoolRegistrationStatusResponse struct {
 	Status string      `json:"status"`
@@ -510,8 +590,81 @@ type CheckCollateralResponse struct {
 	InsufficientCollateral bool     `json:"insufficientCollateral"`
 }
 
+type NodeCollateralInfoResponse struct {
+	Status                  string             `json:"status"`
+	Error                   string             `json:"error"`
+	ActiveMinipools         uint64             `json:"activeMinipools"`
+	BorrowedEth             *big.Int           `json:"borrowedEth"`
+	BondedEth               *big.Int           `json:"bondedEth"`
+	RplStake                *big.Int           `json:"rplStake"`
+	EffectiveRplStake       *big.Int           `json:"effectiveRplStake"`
+	RplPrice                *big.Int           `json:"rplPrice"`
+	BorrowedCollateralRatio float64            `json:"borrowedCollateralRatio"`
+	BondedCollateralRatio   float64            `json:"bondedCollateralRatio"`
+	Targets                 []CollateralTarget `json:"targets"`
+}
+type CollateralTarget struct {
+	TargetPercent       float64  `json:"targetPercent"`
+	RequiredRplStake    *big.Int `json:"requiredRplStake"`
+	AdditionalRplNeeded *big.Int `json:"additionalRplNeeded"`
+}
+
 type NodeEthBalanceResponse struct {
 	Status  string   `json:"status"`
 	Error   string   `json:"error"`
 	Balance *big.Int `json:"balance"`
 }
+
+type NodeTxHistoryResponse struct {
+	Status       string            `json:"status"`
+	Error        string            `json:"error"`
+	Transactions []txjournal.Entry `json:"transactions"`
+}
+
+type NodeTxStatusResponse struct {
+	Status      string           `json:"status"`
+	Error       string           `json:"error"`
+	Found       bool             `json:"found"`
+	Transaction *txjournal.Entry `json:"transaction"`
+}
+
+type NodeQueueTxResponse struct {
+	Status string         `json:"status"`
+	Error  string         `json:"error"`
+	Intent txqueue.Intent `json:"intent"`
+}
+
+type NodeTxQueueResponse struct {
+	Status  string           `json:"status"`
+	Error   string           `json:"error"`
+	Intents []txqueue.Intent `json:"intents"`
+}
+
+type NodeCancelQueuedTxResponse struct {
+	Status string         `json:"status"`
+	Error  string         `json:"error"`
+	Intent txqueue.Intent `json:"intent"`
+}
+
+type NodeRewardsReportResponse struct {
+	Status       string                      `json:"status"`
+	Error        string                      `json:"error"`
+	FiatCurrency string                      `json:"fiatCurrency"`
+	Intervals    []NodeRewardsReportInterval `json:"intervals"`
+}
+type NodeRewardsReportInterval struct {
+	Index          uint64      `json:"index"`
+	StartTime      time.Time   `json:"startTime"`
+	EndTime        time.Time   `json:"endTime"`
+	TreeFileExists bool        `json:"treeFileExists"`
+	RplAmount      *big.Int    `json:"rplAmount"`
+	EthAmount      *big.Int    `json:"ethAmount"`
+	Claimed        bool        `json:"claimed"`
+	ClaimTxHash    common.Hash `json:"claimTxHash"`
+	ClaimTime      time.Time   `json:"claimTime"`
+	RplPriceFiat   float64     `json:"rplPriceFiat"`
+	EthPriceFiat   float64     `json:"ethPriceFiat"`
+	RplValueFiat   float64     `json:"rplValueFiat"`
+	EthValueFiat   float64     `json:"ethValueFiat"`
+	PriceError     string      `json:"priceError"`
+}
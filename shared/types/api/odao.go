@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
@@ -9,6 +10,70 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 )
 
+// The status of a single watchtower duty, as recorded by the watchtower daemon
+type WatchtowerDutyStatus struct {
+	Name              string    `json:"name"`
+	LastRunTime       time.Time `json:"lastRunTime"`
+	LastSuccessTime   time.Time `json:"lastSuccessTime"`
+	LastError         string    `json:"lastError"`
+	LastErrorTime     time.Time `json:"lastErrorTime"`
+	SubmissionPending bool      `json:"submissionPending"`
+}
+
+// The watchtower duty status file
+type WatchtowerDutyStatusFile struct {
+	UpdatedTime time.Time              `json:"updatedTime"`
+	Duties      []WatchtowerDutyStatus `json:"duties"`
+}
+
+type TNDAODutyStatusResponse struct {
+	Status           string                 `json:"status"`
+	Error            string                 `json:"error"`
+	StatusFileExists bool                   `json:"statusFileExists"`
+	UpdatedTime      time.Time              `json:"updatedTime"`
+	Duties           []WatchtowerDutyStatus `json:"duties"`
+}
+
+// A value the watchtower submitted for a period (a block or rewards interval), as recorded by the watchtower daemon
+type WatchtowerSubmissionRecord struct {
+	Duty          string      `json:"duty"`
+	Period        uint64      `json:"period"`
+	Value         string      `json:"value"`
+	ValueHash     common.Hash `json:"valueHash"`
+	TxHash        common.Hash `json:"txHash"`
+	SubmittedTime time.Time   `json:"submittedTime"`
+}
+
+// A value the watchtower calculated for a period that differs from the one it already submitted for it
+type WatchtowerSubmissionDiscrepancy struct {
+	Duty              string      `json:"duty"`
+	Period            uint64      `json:"period"`
+	SubmittedValue    string      `json:"submittedValue"`
+	SubmittedTxHash   common.Hash `json:"submittedTxHash"`
+	CalculatedValue   string      `json:"calculatedValue"`
+	DetectedTime      time.Time   `json:"detectedTime"`
+	SubmissionBlocked bool        `json:"submissionBlocked"`
+}
+
+// The watchtower submission record file
+type WatchtowerSubmissionRecordFile struct {
+	Records       []WatchtowerSubmissionRecord      `json:"records"`
+	Discrepancies []WatchtowerSubmissionDiscrepancy `json:"discrepancies"`
+}
+
+type TNDAOSubmissionRecordsResponse struct {
+	Status        string                            `json:"status"`
+	Error         string                            `json:"error"`
+	Records       []WatchtowerSubmissionRecord      `json:"records"`
+	Discrepancies []WatchtowerSubmissionDiscrepancy `json:"discrepancies"`
+}
+
+type TNDAOForceBalanceSubmissionResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Block  uint64 `json:"block"`
+}
+
 type TNDAOStatusResponse struct {
 	Status         string `json:"status"`
 	Error          string `json:"error"`
@@ -53,6 +118,7 @@ type CanProposeTNDAOInviteResponse struct {
 	CanPropose             bool               `json:"canPropose"`
 	ProposalCooldownActive bool               `json:"proposalCooldownActive"`
 	MemberAlreadyExists    bool               `json:"memberAlreadyExists"`
+	RevertReason           string             `json:"revertReason"`
 	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
 }
 type ProposeTNDAOInviteResponse struct {
@@ -68,6 +134,7 @@ type CanProposeTNDAOLeaveResponse struct {
 	CanPropose             bool               `json:"canPropose"`
 	ProposalCooldownActive bool               `json:"proposalCooldownActive"`
 	InsufficientMembers    bool               `json:"insufficientMembers"`
+	RevertReason           string             `json:"revertReason"`
 	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
 }
 type ProposeTNDAOLeaveResponse struct {
@@ -83,6 +150,7 @@ type CanProposeTNDAOReplaceResponse struct {
 	CanPropose             bool               `json:"canPropose"`
 	ProposalCooldownActive bool               `json:"proposalCooldownActive"`
 	MemberAlreadyExists    bool               `json:"memberAlreadyExists"`
+	RevertReason           string             `json:"revertReason"`
 	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
 }
 type ProposeTNDAOReplaceResponse struct {
@@ -98,6 +166,7 @@ type CanProposeTNDAOKickResponse struct {
 	CanPropose             bool               `json:"canPropose"`
 	ProposalCooldownActive bool               `json:"proposalCooldownActive"`
 	InsufficientRplBond    bool               `json:"insufficientRplBond"`
+	RevertReason           string             `json:"revertReason"`
 	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
 }
 type ProposeTNDAOKickResponse struct {
@@ -114,6 +183,7 @@ type CanCancelTNDAOProposalResponse struct {
 	DoesNotExist    bool               `json:"doesNotExist"`
 	InvalidState    bool               `json:"invalidState"`
 	InvalidProposer bool               `json:"invalidProposer"`
+	RevertReason    string             `json:"revertReason"`
 	GasInfo         rocketpool.GasInfo `json:"gasInfo"`
 }
 type CancelTNDAOProposalResponse struct {
@@ -130,6 +200,7 @@ type CanVoteOnTNDAOProposalResponse struct {
 	InvalidState       bool               `json:"invalidState"`
 	JoinedAfterCreated bool               `json:"joinedAfterCreated"`
 	AlreadyVoted       bool               `json:"alreadyVoted"`
+	RevertReason       string             `json:"revertReason"`
 	GasInfo            rocketpool.GasInfo `json:"gasInfo"`
 }
 type VoteOnTNDAOProposalResponse struct {
@@ -144,6 +215,7 @@ type CanExecuteTNDAOProposalResponse struct {
 	CanExecute   bool               `json:"canExecute"`
 	DoesNotExist bool               `json:"doesNotExist"`
 	InvalidState bool               `json:"invalidState"`
+	RevertReason string             `json:"revertReason"`
 	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
 }
 type ExecuteTNDAOProposalResponse struct {
@@ -178,6 +250,7 @@ type CanLeaveTNDAOResponse struct {
 	CanLeave            bool               `json:"canLeave"`
 	ProposalExpired     bool               `json:"proposalExpired"`
 	InsufficientMembers bool               `json:"insufficientMembers"`
+	RevertReason        string             `json:"revertReason"`
 	GasInfo             rocketpool.GasInfo `json:"gasInfo"`
 }
 type LeaveTNDAOResponse struct {
@@ -205,6 +278,7 @@ type CanProposeTNDAOSettingResponse struct {
 	Error                  string             `json:"error"`
 	CanPropose             bool               `json:"canPropose"`
 	ProposalCooldownActive bool               `json:"proposalCooldownActive"`
+	RevertReason           string             `json:"revertReason"`
 	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
 }
 type ProposeTNDAOSettingMembersQuorumResponse struct {
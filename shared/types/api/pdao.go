@@ -0,0 +1,113 @@
+package api
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/pdao"
+)
+
+type PDAOStatusResponse struct {
+	Status            string         `json:"status"`
+	Error             string         `json:"error"`
+	VotingInitialized bool           `json:"votingInitialized"`
+	BlockNumber       uint32         `json:"blockNumber"`
+	VotingPower       *big.Int       `json:"votingPower"`
+	Delegate          common.Address `json:"delegate"`
+}
+
+type PDAOProposalsResponse struct {
+	Status    string                 `json:"status"`
+	Error     string                 `json:"error"`
+	Proposals []pdao.ProposalDetails `json:"proposals"`
+}
+
+type PDAOProposalResponse struct {
+	Status   string               `json:"status"`
+	Error    string               `json:"error"`
+	Proposal pdao.ProposalDetails `json:"proposal"`
+}
+
+type CanInitializePDAOVotingResponse struct {
+	Status             string             `json:"status"`
+	Error              string             `json:"error"`
+	CanInitialize      bool               `json:"canInitialize"`
+	AlreadyInitialized bool               `json:"alreadyInitialized"`
+	GasInfo            rocketpool.GasInfo `json:"gasInfo"`
+}
+type InitializePDAOVotingResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanProposePDAOSettingResponse struct {
+	Status               string             `json:"status"`
+	Error                string             `json:"error"`
+	CanPropose           bool               `json:"canPropose"`
+	VotingNotInitialized bool               `json:"votingNotInitialized"`
+	InsufficientRpl      bool               `json:"insufficientRpl"`
+	ProposalBond         *big.Int           `json:"proposalBond"`
+	BlockNumber          uint32             `json:"blockNumber"`
+	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
+}
+type ProposePDAOSettingResponse struct {
+	Status      string      `json:"status"`
+	Error       string      `json:"error"`
+	ProposalId  uint64      `json:"proposalId"`
+	BlockNumber uint32      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+}
+
+type CanVoteOnPDAOProposalResponse struct {
+	Status            string             `json:"status"`
+	Error             string             `json:"error"`
+	CanVote           bool               `json:"canVote"`
+	DoesNotExist      bool               `json:"doesNotExist"`
+	InvalidState      bool               `json:"invalidState"`
+	AlreadyVoted      bool               `json:"alreadyVoted"`
+	InsufficientPower bool               `json:"insufficientPower"`
+	VotingPower       *big.Int           `json:"votingPower"`
+	GasInfo           rocketpool.GasInfo `json:"gasInfo"`
+}
+type VoteOnPDAOProposalResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanOverrideDelegateVoteOnPDAOProposalResponse struct {
+	Status            string             `json:"status"`
+	Error             string             `json:"error"`
+	CanOverride       bool               `json:"canOverride"`
+	DoesNotExist      bool               `json:"doesNotExist"`
+	InvalidState      bool               `json:"invalidState"`
+	AlreadyVoted      bool               `json:"alreadyVoted"`
+	NotDelegated      bool               `json:"notDelegated"`
+	InsufficientPower bool               `json:"insufficientPower"`
+	Delegate          common.Address     `json:"delegate"`
+	DelegateVote      pdao.VoteDirection `json:"delegateVote"`
+	VotingPower       *big.Int           `json:"votingPower"`
+	GasInfo           rocketpool.GasInfo `json:"gasInfo"`
+}
+type OverrideDelegateVoteOnPDAOProposalResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanExecutePDAOProposalResponse struct {
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	CanExecute   bool               `json:"canExecute"`
+	DoesNotExist bool               `json:"doesNotExist"`
+	InvalidState bool               `json:"invalidState"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
+}
+type ExecutePDAOProposalResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
@@ -23,6 +23,7 @@ type CanProcessQueueResponse struct {
 	NoMinipoolsAvailable       bool               `json:"noMinipoolsAvailable"`
 	InsufficientDepositBalance bool               `json:"insufficientDepositBalance"`
 	IsAtlasDeployed            bool               `json:"isAtlasDeployed"`
+	RevertReason               string             `json:"revertReason"`
 	GasInfo                    rocketpool.GasInfo `json:"gasInfo"`
 }
 type ProcessQueueResponse struct {
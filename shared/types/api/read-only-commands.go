@@ -0,0 +1,182 @@
+package api
+
+import "strings"
+
+// The API commands that only read state, by their full path (without aliases).
+// The API server's monitor role can only run these, and they're never queued as transactions; any command that isn't
+// listed is treated as one that changes state, so new read-only commands must be added here.
+var readOnlyCommands = map[string]bool{
+	"wait": true,
+
+	// auction
+	"auction status":          true,
+	"auction lots":            true,
+	"auction can-create-lot":  true,
+	"auction can-bid-lot":     true,
+	"auction can-claim-lot":   true,
+	"auction can-recover-lot": true,
+
+	// faucet
+	"faucet status":           true,
+	"faucet can-withdraw-rpl": true,
+
+	// minipool
+	"minipool status":                              true,
+	"minipool can-stake":                           true,
+	"minipool can-promote":                         true,
+	"minipool can-refund":                          true,
+	"minipool can-dissolve":                        true,
+	"minipool can-exit":                            true,
+	"minipool get-minipool-close-details-for-node": true,
+	"minipool can-delegate-upgrade":                true,
+	"minipool can-delegate-rollback":               true,
+	"minipool can-set-use-latest-delegate":         true,
+	"minipool get-use-latest-delegate":             true,
+	"minipool get-delegate":                        true,
+	"minipool get-previous-delegate":               true,
+	"minipool get-effective-delegate":              true,
+	"minipool get-vanity-artifacts":                true,
+	"minipool can-begin-reduce-bond-amount":        true,
+	"minipool can-reduce-bond-amount":              true,
+	"minipool get-distribute-balance-details":      true,
+	"minipool can-change-withdrawal-creds":         true,
+	"minipool get-bls-creds-minipools":             true,
+
+	// network
+	"network node-fee":                  true,
+	"network rpl-price":                 true,
+	"network stats":                     true,
+	"network queue-status":              true,
+	"network deployment-status":         true,
+	"network gas-oracle":                true,
+	"network timezone-map":              true,
+	"network can-generate-rewards-tree": true,
+	"network can-verify-rewards-tree":   true,
+	"network get-minipool-performance":  true,
+	"network dao-proposals":             true,
+	"network is-atlas-deployed":         true,
+	"network latest-delegate":           true,
+	"network state-snapshots":           true,
+	"network diff-state-snapshots":      true,
+
+	// node
+	"node status":                                 true,
+	"node sync":                                   true,
+	"node tx-history":                             true,
+	"node get-tx-queue":                           true,
+	"node tx-status":                              true,
+	"node preflight":                              true,
+	"node can-register":                           true,
+	"node can-set-withdrawal-address":             true,
+	"node can-confirm-withdrawal-address":         true,
+	"node can-set-timezone":                       true,
+	"node can-swap-rpl":                           true,
+	"node get-swap-rpl-approval-gas":              true,
+	"node swap-rpl-allowance":                     true,
+	"node can-stake-rpl":                          true,
+	"node get-stake-rpl-approval-gas":             true,
+	"node stake-rpl-allowance":                    true,
+	"node can-withdraw-rpl":                       true,
+	"node can-deposit":                            true,
+	"node can-send":                               true,
+	"node can-sweep":                              true,
+	"node can-burn":                               true,
+	"node can-claim-rpl-rewards":                  true,
+	"node rewards":                                true,
+	"node rewards-report":                         true,
+	"node deposit-contract-info":                  true,
+	"node estimate-set-snapshot-delegate-gas":     true,
+	"node estimate-clear-snapshot-delegate-gas":   true,
+	"node get-snapshot-delegate":                  true,
+	"node is-fee-distributor-initialized":         true,
+	"node get-initialize-fee-distributor-gas":     true,
+	"node can-distribute":                         true,
+	"node get-rewards-info":                       true,
+	"node can-claim-rewards":                      true,
+	"node can-claim-and-stake-rewards":            true,
+	"node get-smoothing-pool-registration-status": true,
+	"node can-set-smoothing-pool-status":          true,
+	"node resolve-ens-name":                       true,
+	"node reverse-resolve-ens-name":               true,
+	"node can-create-vacant-minipool":             true,
+	"node check-collateral":                       true,
+	"node get-collateral-info":                    true,
+	"node get-eth-balance":                        true,
+
+	// odao
+	"odao status":                                    true,
+	"odao duty-status":                               true,
+	"odao submission-records":                        true,
+	"odao members":                                   true,
+	"odao proposals":                                 true,
+	"odao proposal-details":                          true,
+	"odao can-propose-invite":                        true,
+	"odao can-propose-leave":                         true,
+	"odao can-propose-replace":                       true,
+	"odao can-propose-kick":                          true,
+	"odao can-cancel-proposal":                       true,
+	"odao can-vote-proposal":                         true,
+	"odao can-execute-proposal":                      true,
+	"odao can-join":                                  true,
+	"odao can-leave":                                 true,
+	"odao can-propose-members-quorum":                true,
+	"odao can-propose-members-rplbond":               true,
+	"odao can-propose-members-minipool-unbonded-max": true,
+	"odao can-propose-proposal-cooldown":             true,
+	"odao can-propose-proposal-vote-timespan":        true,
+	"odao can-propose-proposal-vote-delay-timespan":  true,
+	"odao can-propose-proposal-execute-timespan":     true,
+	"odao can-propose-proposal-action-timespan":      true,
+	"odao can-propose-scrub-period":                  true,
+	"odao can-propose-promotion-scrub-period":        true,
+	"odao can-propose-scrub-penalty-enabled":         true,
+	"odao can-propose-bond-reduction-window-start":   true,
+	"odao can-propose-bond-reduction-window-length":  true,
+	"odao get-member-settings":                       true,
+	"odao get-proposal-settings":                     true,
+	"odao get-minipool-settings":                     true,
+
+	// pdao
+	"pdao status":                true,
+	"pdao proposals":             true,
+	"pdao proposal-details":      true,
+	"pdao can-initialize-voting": true,
+	"pdao can-propose-setting":   true,
+	"pdao can-vote-proposal":     true,
+	"pdao can-override-vote":     true,
+	"pdao can-execute-proposal":  true,
+
+	// queue
+	"queue status":      true,
+	"queue can-process": true,
+
+	// security
+	"security status":                      true,
+	"security proposals":                   true,
+	"security can-join":                    true,
+	"security can-request-leave":           true,
+	"security can-leave":                   true,
+	"security can-propose-disable-setting": true,
+	"security can-vote-proposal":           true,
+	"security can-execute-proposal":        true,
+
+	// service
+	"service get-client-status": true,
+	"service get-log-levels":    true,
+	"service get-call-tracing":  true,
+	"service can-prune-rewards": true,
+
+	// wallet
+	"wallet status":                    true,
+	"wallet profiles":                  true,
+	"wallet web3signer-keys":           true,
+	"wallet estimate-gas-set-ens-name": true,
+	"wallet pending":                   true,
+	"wallet can-clear-stuck":           true,
+	"wallet can-delete-validator-key":  true,
+}
+
+// Check if the API command with the given path only reads state
+func IsReadOnlyCommand(path []string) bool {
+	return readOnlyCommands[strings.Join(path, " ")]
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+type SecurityStatusResponse struct {
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+	IsMember     bool   `json:"isMember"`
+	IsInvited    bool   `json:"isInvited"`
+	TotalMembers uint64 `json:"totalMembers"`
+}
+
+type SecurityProposalsResponse struct {
+	Status    string                `json:"status"`
+	Error     string                `json:"error"`
+	Proposals []dao.ProposalDetails `json:"proposals"`
+}
+
+type CanJoinSecurityCouncilResponse struct {
+	Status        string             `json:"status"`
+	Error         string             `json:"error"`
+	CanJoin       bool               `json:"canJoin"`
+	AlreadyMember bool               `json:"alreadyMember"`
+	NotInvited    bool               `json:"notInvited"`
+	GasInfo       rocketpool.GasInfo `json:"gasInfo"`
+}
+type JoinSecurityCouncilResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanRequestLeaveSecurityCouncilResponse struct {
+	Status          string             `json:"status"`
+	Error           string             `json:"error"`
+	CanRequestLeave bool               `json:"canRequestLeave"`
+	GasInfo         rocketpool.GasInfo `json:"gasInfo"`
+}
+type RequestLeaveSecurityCouncilResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanLeaveSecurityCouncilResponse struct {
+	Status   string             `json:"status"`
+	Error    string             `json:"error"`
+	CanLeave bool               `json:"canLeave"`
+	GasInfo  rocketpool.GasInfo `json:"gasInfo"`
+}
+type LeaveSecurityCouncilResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanProposeSecurityDisableSettingResponse struct {
+	Status     string             `json:"status"`
+	Error      string             `json:"error"`
+	CanPropose bool               `json:"canPropose"`
+	GasInfo    rocketpool.GasInfo `json:"gasInfo"`
+}
+type ProposeSecurityDisableSettingResponse struct {
+	Status     string      `json:"status"`
+	Error      string      `json:"error"`
+	ProposalId uint64      `json:"proposalId"`
+	TxHash     common.Hash `json:"txHash"`
+}
+
+type CanVoteOnSecurityProposalResponse struct {
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	CanVote      bool               `json:"canVote"`
+	DoesNotExist bool               `json:"doesNotExist"`
+	InvalidState bool               `json:"invalidState"`
+	AlreadyVoted bool               `json:"alreadyVoted"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
+}
+type VoteOnSecurityProposalResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanExecuteSecurityProposalResponse struct {
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	CanExecute   bool               `json:"canExecute"`
+	DoesNotExist bool               `json:"doesNotExist"`
+	InvalidState bool               `json:"invalidState"`
+	GasInfo      rocketpool.GasInfo `json:"gasInfo"`
+}
+type ExecuteSecurityProposalResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
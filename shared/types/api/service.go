@@ -1,6 +1,11 @@
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -8,6 +13,21 @@ type TerminateDataFolderResponse struct {
 	FolderExisted bool   `json:"folderExisted"`
 }
 
+type CanPruneRewardsFilesResponse struct {
+	Status       string                        `json:"status"`
+	Error        string                        `json:"error"`
+	CurrentIndex uint64                        `json:"currentIndex"`
+	Files        []rewards.PrunableRewardsFile `json:"files"`
+	TotalSize    uint64                        `json:"totalSize"`
+}
+
+type PruneRewardsFilesResponse struct {
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	PrunedFiles int    `json:"prunedFiles"`
+	PrunedSize  uint64 `json:"prunedSize"`
+}
+
 type CreateFeeRecipientFileResponse struct {
 	Status      string         `json:"status"`
 	Error       string         `json:"error"`
@@ -41,3 +61,95 @@ type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type PrepareShutdownResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type CancelShutdownResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type ReloadConfigResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type VerifyCheckpointSyncResponse struct {
+	Status                string      `json:"status"`
+	Error                 string      `json:"error"`
+	Slot                  uint64      `json:"slot"`
+	BlockRoot             common.Hash `json:"blockRoot"`
+	StateRoot             common.Hash `json:"stateRoot"`
+	VerificationStateRoot common.Hash `json:"verificationStateRoot"`
+	Verified              bool        `json:"verified"`
+}
+
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Process string    `json:"process"`
+	Level   string    `json:"level"`
+	Module  string    `json:"module"`
+	Task    string    `json:"task"`
+	Message string    `json:"msg"`
+}
+
+type QueryLogsResponse struct {
+	Status  string     `json:"status"`
+	Error   string     `json:"error"`
+	Entries []LogEntry `json:"entries"`
+}
+
+type GetLogLevelsResponse struct {
+	Status       string            `json:"status"`
+	Error        string            `json:"error"`
+	Format       string            `json:"format"`
+	DefaultLevel string            `json:"defaultLevel"`
+	ModuleLevels map[string]string `json:"moduleLevels"`
+	Overrides    map[string]string `json:"overrides"`
+	Modules      []string          `json:"modules"`
+}
+
+type SetLogLevelResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type CallTracingStatusResponse struct {
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+	Enabled bool   `json:"enabled"`
+	LogPath string `json:"logPath"`
+}
+
+type ServiceHealthResponse struct {
+	Status                 string        `json:"status"`
+	Error                  string        `json:"error"`
+	Process                string        `json:"process"`
+	Healthy                bool          `json:"healthy"`
+	Ready                  bool          `json:"ready"`
+	Checks                 []HealthCheck `json:"checks"`
+	LastTaskLoopCompletion time.Time     `json:"lastTaskLoopCompletion"`
+}
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+type EffectiveConfigSetting struct {
+	Section  string `json:"section"`
+	ID       string `json:"id"`
+	Value    string `json:"value"`
+	Source   string `json:"source"`
+	EnvVar   string `json:"envVar"`
+	Redacted bool   `json:"redacted"`
+}
+
+type GetEffectiveConfigResponse struct {
+	Status   string                   `json:"status"`
+	Error    string                   `json:"error"`
+	Settings []EffectiveConfigSetting `json:"settings"`
+}
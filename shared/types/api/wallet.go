@@ -5,6 +5,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/txjournal"
+	w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
 )
 
 // Encrypted validator keystore following the EIP-2335 standard
@@ -23,6 +27,28 @@ type WalletStatusResponse struct {
 	PasswordSet       bool           `json:"passwordSet"`
 	WalletInitialized bool           `json:"walletInitialized"`
 	AccountAddress    common.Address `json:"accountAddress"`
+	Profile           string         `json:"profile"`
+	Locked            bool           `json:"locked"`
+}
+
+type WalletProfile struct {
+	Name              string `json:"name"`
+	Active            bool   `json:"active"`
+	PasswordSet       bool   `json:"passwordSet"`
+	WalletInitialized bool   `json:"walletInitialized"`
+}
+type WalletProfilesResponse struct {
+	Status   string          `json:"status"`
+	Error    string          `json:"error"`
+	Profiles []WalletProfile `json:"profiles"`
+}
+
+type SwitchWalletProfileResponse struct {
+	Status            string         `json:"status"`
+	Error             string         `json:"error"`
+	PasswordSet       bool           `json:"passwordSet"`
+	WalletInitialized bool           `json:"walletInitialized"`
+	AccountAddress    common.Address `json:"accountAddress"`
 }
 
 type SetPasswordResponse struct {
@@ -30,6 +56,12 @@ type SetPasswordResponse struct {
 	Error  string `json:"error"`
 }
 
+type UnlockWalletResponse struct {
+	Status          string   `json:"status"`
+	Error           string   `json:"error"`
+	UnlockedDaemons []string `json:"unlockedDaemons"`
+}
+
 type InitWalletResponse struct {
 	Status         string         `json:"status"`
 	Error          string         `json:"error"`
@@ -38,20 +70,30 @@ type InitWalletResponse struct {
 }
 
 type RecoverWalletResponse struct {
-	Status         string                  `json:"status"`
-	Error          string                  `json:"error"`
-	AccountAddress common.Address          `json:"accountAddress"`
-	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	Status               string                  `json:"status"`
+	Error                string                  `json:"error"`
+	AccountAddress       common.Address          `json:"accountAddress"`
+	ValidatorKeys        []types.ValidatorPubkey `json:"validatorKeys"`
+	NodeRegistered       bool                    `json:"nodeRegistered"`
+	MissingValidatorKeys []types.ValidatorPubkey `json:"missingValidatorKeys"`
+}
+
+type ImportNodeKeyResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	AccountAddress common.Address `json:"accountAddress"`
 }
 
 type SearchAndRecoverWalletResponse struct {
-	Status         string                  `json:"status"`
-	Error          string                  `json:"error"`
-	FoundWallet    bool                    `json:"foundWallet"`
-	AccountAddress common.Address          `json:"accountAddress"`
-	DerivationPath string                  `json:"derivationPath"`
-	Index          uint                    `json:"index"`
-	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	Status               string                  `json:"status"`
+	Error                string                  `json:"error"`
+	FoundWallet          bool                    `json:"foundWallet"`
+	AccountAddress       common.Address          `json:"accountAddress"`
+	DerivationPath       string                  `json:"derivationPath"`
+	Index                uint                    `json:"index"`
+	ValidatorKeys        []types.ValidatorPubkey `json:"validatorKeys"`
+	NodeRegistered       bool                    `json:"nodeRegistered"`
+	MissingValidatorKeys []types.ValidatorPubkey `json:"missingValidatorKeys"`
 }
 
 type RebuildWalletResponse struct {
@@ -68,6 +110,24 @@ type ExportWalletResponse struct {
 	AccountPrivateKey string `json:"accountPrivateKey"`
 }
 
+type Web3SignerKeysResponse struct {
+	Status string                  `json:"status"`
+	Error  string                  `json:"error"`
+	Keys   []w3skeystore.RemoteKey `json:"keys"`
+}
+
+type ImportWeb3SignerKeysResponse struct {
+	Status        string                  `json:"status"`
+	Error         string                  `json:"error"`
+	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+}
+
+type RemoveWeb3SignerKeysResponse struct {
+	Status                 string `json:"status"`
+	Error                  string `json:"error"`
+	SlashingProtectionPath string `json:"slashingProtectionPath"`
+}
+
 type SetEnsNameResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -88,3 +148,43 @@ type PurgeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type WalletPendingResponse struct {
+	Status              string            `json:"status"`
+	Error               string            `json:"error"`
+	AccountAddress      common.Address    `json:"accountAddress"`
+	LatestNonce         uint64            `json:"latestNonce"`
+	PendingNonce        uint64            `json:"pendingNonce"`
+	PendingTransactions []txjournal.Entry `json:"pendingTransactions"`
+	NonceGaps           []uint64          `json:"nonceGaps"`
+}
+
+type CanClearStuckNonceResponse struct {
+	Status            string             `json:"status"`
+	Error             string             `json:"error"`
+	CanClear          bool               `json:"canClear"`
+	NonceAlreadyUsed  bool               `json:"nonceAlreadyUsed"`
+	NonceTooHigh      bool               `json:"nonceTooHigh"`
+	StuckTransactions []txjournal.Entry  `json:"stuckTransactions"`
+	GasInfo           rocketpool.GasInfo `json:"gasInfo"`
+}
+
+type ClearStuckNonceResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanDeleteValidatorKeyResponse struct {
+	Status          string                `json:"status"`
+	Error           string                `json:"error"`
+	CanDelete       bool                  `json:"canDelete"`
+	ValidatorExists bool                  `json:"validatorExists"`
+	ValidatorActive bool                  `json:"validatorActive"`
+	BeaconState     beacon.ValidatorState `json:"beaconState"`
+}
+
+type DeleteValidatorKeyResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 // A parameter that can be configured by the user
@@ -21,11 +22,26 @@ type Parameter struct {
 	EnvironmentVariables  []string                `yaml:"environmentVariables,omitempty"`
 	CanBeBlank            bool                    `yaml:"canBeBlank,omitempty"`
 	OverwriteOnUpgrade    bool                    `yaml:"overwriteOnUpgrade,omitempty"`
+	IsSecret              bool                    `yaml:"isSecret,omitempty"`
 	Options               []ParameterOption       `yaml:"options,omitempty"`
 	Value                 interface{}             `yaml:"-"`
 	DescriptionsByNetwork map[Network]string      `yaml:"-"`
 }
 
+// Prefixes of secret parameter values that refer to the real value instead of holding it
+const (
+	SecretPrefix_Encrypted string = "enc:"
+	SecretPrefix_File      string = "file:"
+	SecretPrefix_Env       string = "env:"
+)
+
+// Check if a secret parameter's value refers to the real value instead of holding it
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretPrefix_Encrypted) ||
+		strings.HasPrefix(value, SecretPrefix_File) ||
+		strings.HasPrefix(value, SecretPrefix_Env)
+}
+
 // A single option in a choice parameter
 type ParameterOption struct {
 	Name        string      `yaml:"name,omitempty"`
@@ -91,6 +107,11 @@ func (param *Parameter) Deserialize(serializedParams map[string]string, network
 	case ParameterType_Bool:
 		param.Value, err = strconv.ParseBool(value)
 	case ParameterType_String:
+		if param.IsSecret && IsSecretReference(value) {
+			// References are checked once they've been resolved
+			param.Value = value
+			break
+		}
 		if param.Regex != "" {
 			regex := regexp.MustCompile(param.Regex)
 			if param.Value != "" && !regex.MatchString(value) {
@@ -7,9 +7,21 @@ type ParameterType string
 type ExecutionClient string
 type ConsensusClient string
 type RewardsMode string
+type RewardsStorageMode string
+type RewardsFileFormat string
+type RewardsPruneMode string
+type ApiRole string
+type NodeKeySigner string
+type WalletPasswordProvider string
+type WatchtowerGasMode string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type BeaconRoutingMode string
+type MetricsPushMode string
+type LogFormat string
+type LogLevel string
+type TreeGenProfilingMode string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -83,6 +95,84 @@ const (
 	RewardsMode_Generate RewardsMode = "generate"
 )
 
+// Enum to describe where the Oracle DAO uploads rewards files
+const (
+	RewardsStorageMode_Unknown     RewardsStorageMode = ""
+	RewardsStorageMode_Web3Storage RewardsStorageMode = "web3storage"
+	RewardsStorageMode_Ipfs        RewardsStorageMode = "ipfs"
+	RewardsStorageMode_S3          RewardsStorageMode = "s3"
+	RewardsStorageMode_Local       RewardsStorageMode = "local"
+)
+
+// Enum to describe how rewards files are saved to disk
+const (
+	RewardsFileFormat_Unknown RewardsFileFormat = ""
+	RewardsFileFormat_Json    RewardsFileFormat = "json"
+	RewardsFileFormat_Binary  RewardsFileFormat = "binary"
+)
+
+// Enum to describe what happens to old rewards files when they're pruned
+const (
+	RewardsPruneMode_Unknown RewardsPruneMode = ""
+	RewardsPruneMode_Delete  RewardsPruneMode = "delete"
+	RewardsPruneMode_Archive RewardsPruneMode = "archive"
+)
+
+// Enum to describe how the daemons write their logs
+const (
+	LogFormat_Text LogFormat = "text"
+	LogFormat_Json LogFormat = "json"
+)
+
+// Enum to describe the minimum severity of the log messages the daemons write
+const (
+	LogLevel_Debug LogLevel = "debug"
+	LogLevel_Info  LogLevel = "info"
+	LogLevel_Warn  LogLevel = "warn"
+	LogLevel_Error LogLevel = "error"
+)
+
+// Enum to describe what is captured when profiling rewards tree generation
+const (
+	TreeGenProfilingMode_None   TreeGenProfilingMode = "none"
+	TreeGenProfilingMode_Timing TreeGenProfilingMode = "timing"
+	TreeGenProfilingMode_Cpu    TreeGenProfilingMode = "cpu"
+	TreeGenProfilingMode_Trace  TreeGenProfilingMode = "trace"
+)
+
+// Enum to describe the roles API server clients can be granted
+const (
+	ApiRole_None    ApiRole = "none"
+	ApiRole_Monitor ApiRole = "monitor"
+	ApiRole_Admin   ApiRole = "admin"
+)
+
+// Enum to describe what signs transactions for the node account
+const (
+	NodeKeySigner_Local      NodeKeySigner = "local"
+	NodeKeySigner_Ledger     NodeKeySigner = "ledger"
+	NodeKeySigner_Trezor     NodeKeySigner = "trezor"
+	NodeKeySigner_Clef       NodeKeySigner = "clef"
+	NodeKeySigner_Web3Signer NodeKeySigner = "web3signer"
+)
+
+// Enum to describe where the node wallet's password comes from
+const (
+	WalletPasswordProvider_File        WalletPasswordProvider = "file"
+	WalletPasswordProvider_Environment WalletPasswordProvider = "environment"
+	WalletPasswordProvider_Command     WalletPasswordProvider = "command"
+	WalletPasswordProvider_AwsKms      WalletPasswordProvider = "aws-kms"
+	WalletPasswordProvider_GcpKms      WalletPasswordProvider = "gcp-kms"
+	WalletPasswordProvider_Prompt      WalletPasswordProvider = "prompt"
+)
+
+// Enum to describe how the watchtower chooses the fees for its transactions
+const (
+	WatchtowerGasMode_Unknown WatchtowerGasMode = ""
+	WatchtowerGasMode_Static  WatchtowerGasMode = "static"
+	WatchtowerGasMode_Dynamic WatchtowerGasMode = "dynamic"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
@@ -127,6 +217,8 @@ type LocalConsensusConfig interface {
 // Interface for External Consensus configurations
 type ExternalConsensusConfig interface {
 	GetApiUrl() string
+	GetApiHeaders() string
+	IsRemoteProvider() bool
 }
 
 // A setting that has changed
@@ -146,3 +238,16 @@ type MevRelay struct {
 	Regulated     bool
 	NoSandwiching bool
 }
+
+// Enum to describe how requests are routed between the Beacon nodes
+const (
+	BeaconRoutingMode_Ordered  BeaconRoutingMode = "ordered"
+	BeaconRoutingMode_Balanced BeaconRoutingMode = "balanced"
+)
+
+// Enum to describe where the Smartnode's metrics are pushed to, on top of being served to the local Prometheus
+const (
+	MetricsPushMode_Disabled    MetricsPushMode = "disabled"
+	MetricsPushMode_Pushgateway MetricsPushMode = "pushgateway"
+	MetricsPushMode_RemoteWrite MetricsPushMode = "remoteWrite"
+)
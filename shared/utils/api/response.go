@@ -4,19 +4,41 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
+// Prints API responses to a writer
+type ResponsePrinter struct {
+	writer io.Writer
+}
+
+// Create a printer for API responses
+func NewResponsePrinter(writer io.Writer) *ResponsePrinter {
+	return &ResponsePrinter{writer: writer}
+}
+
+// Get the printer for the responses of the command running in a context.
+// Responses are printed to the writer of the command's app, so commands run on their own app in-process (such as by the
+// API server) each print to their own writer.
+func GetResponsePrinter(c *cli.Context) *ResponsePrinter {
+	return NewResponsePrinter(c.App.Writer)
+}
+
 // Print an API response
 // response must be a pointer to a struct type with Error and Status string fields
-func PrintResponse(response interface{}, responseError error) {
+func (p *ResponsePrinter) PrintResponse(response interface{}, responseError error) {
 
 	// Check response type
 	r := reflect.ValueOf(response)
 	if !(r.Kind() == reflect.Ptr && r.Type().Elem().Kind() == reflect.Struct) {
-		PrintErrorResponse(errors.New("Invalid API response"))
+		p.PrintErrorResponse(errors.New("Invalid API response"))
 		return
 	}
 
@@ -30,7 +52,7 @@ func PrintResponse(response interface{}, responseError error) {
 	sf := r.Elem().FieldByName("Status")
 	ef := r.Elem().FieldByName("Error")
 	if !(sf.IsValid() && sf.CanSet() && sf.Kind() == reflect.String && ef.IsValid() && ef.CanSet() && ef.Kind() == reflect.String) {
-		PrintErrorResponse(errors.New("Invalid API response"))
+		p.PrintErrorResponse(errors.New("Invalid API response"))
 		return
 	}
 
@@ -49,16 +71,36 @@ func PrintResponse(response interface{}, responseError error) {
 	// Encode
 	responseBytes, err := json.Marshal(response)
 	if err != nil {
-		PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+		p.PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
 		return
 	}
 
 	// Print
-	fmt.Println(string(responseBytes))
+	fmt.Fprintln(p.writer, string(responseBytes))
 
 }
 
 // Print an API error response
-func PrintErrorResponse(err error) {
-	PrintResponse(&api.APIResponse{}, err)
+func (p *ResponsePrinter) PrintErrorResponse(err error) {
+	p.PrintResponse(&api.APIResponse{}, err)
+}
+
+// Get the hash of the transaction an API response reports it submitted, if it has one.
+// Most responses report it as txHash, but commands that are one step of a larger operation name it after the step
+// (such as approveTxHash or stakeTxHash), so every top-level field ending in TxHash is checked.
+func GetResponseTxHash(responseBytes []byte) (common.Hash, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(responseBytes, &fields); err != nil {
+		return common.Hash{}, false
+	}
+	for name, value := range fields {
+		if name != "txHash" && !strings.HasSuffix(name, "TxHash") {
+			continue
+		}
+		var hash common.Hash
+		if err := json.Unmarshal(value, &hash); err == nil && hash != (common.Hash{}) {
+			return hash, true
+		}
+	}
+	return common.Hash{}, false
 }
@@ -1,19 +1,23 @@
 package cli
 
 import (
+	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tyler-smith/go-bip39"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
@@ -34,6 +38,14 @@ func ValidateArgCount(c *cli.Context, count int) error {
 	return nil
 }
 
+// Validate that a command has at least a minimum number of arguments
+func ValidateMinArgCount(c *cli.Context, count int) error {
+	if len(c.Args()) < count {
+		return fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText)
+	}
+	return nil
+}
+
 // Validate a big int
 func ValidateBigInt(name, value string) (*big.Int, error) {
 	val, success := big.NewInt(0).SetString(value, 0)
@@ -72,6 +84,20 @@ func ValidateAddress(name, value string) (common.Address, error) {
 	return common.HexToAddress(value), nil
 }
 
+// Validate a comma-separated list of addresses
+func ValidateAddresses(name, value string) ([]common.Address, error) {
+	elements := strings.Split(value, ",")
+	addresses := make([]common.Address, 0, len(elements))
+	for _, element := range elements {
+		address, err := ValidateAddress(name, strings.TrimSpace(element))
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
 // Validate a wei amount
 func ValidateWeiAmount(name, value string) (*big.Int, error) {
 	val := new(big.Int)
@@ -126,6 +152,15 @@ func ValidateProposalType(name, value string) (string, error) {
 	return val, nil
 }
 
+// Validate a rewards prune mode
+func ValidateRewardsPruneMode(name, value string) (cfgtypes.RewardsPruneMode, error) {
+	val := cfgtypes.RewardsPruneMode(strings.ToLower(value))
+	if !(val == cfgtypes.RewardsPruneMode_Delete || val == cfgtypes.RewardsPruneMode_Archive) {
+		return cfgtypes.RewardsPruneMode_Unknown, fmt.Errorf("Invalid %s '%s' - valid modes are 'delete' and 'archive'", name, value)
+	}
+	return val, nil
+}
+
 //
 // Command specific types
 //
@@ -178,6 +213,15 @@ func ValidatePositiveEthAmount(name, value string) (float64, error) {
 	return val, nil
 }
 
+// Validate a positive duration, such as 48h
+func ValidatePositiveDuration(name, value string) (time.Duration, error) {
+	val, err := time.ParseDuration(value)
+	if err != nil || val <= 0 {
+		return 0, fmt.Errorf("Invalid %s '%s' - must be a positive duration such as 48h", name, value)
+	}
+	return val, nil
+}
+
 // Validate a burnable token type
 func ValidateBurnableTokenType(name, value string) (string, error) {
 	val := strings.ToLower(value)
@@ -203,6 +247,15 @@ func ValidateWalletMnemonic(name, value string) (string, error) {
 	return value, nil
 }
 
+// Validate a node private key; the value isn't included in errors since it's sensitive
+func ValidateNodePrivateKey(name, value string) (*ecdsa.PrivateKey, error) {
+	privateKey, err := crypto.HexToECDSA(hexutils.RemovePrefix(strings.TrimSpace(value)))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s: %w", name, err)
+	}
+	return privateKey, nil
+}
+
 // Validate a timezone location
 func ValidateTimezoneLocation(name, value string) (string, error) {
 	if !regexp.MustCompile("^([a-zA-Z_]{2,}\\/)+[a-zA-Z_]{2,}$").MatchString(value) {
@@ -252,3 +305,17 @@ func ValidatePubkey(name, value string) (types.ValidatorPubkey, error) {
 	}
 	return pubkey, nil
 }
+
+// Validate a comma-separated list of validator pubkeys
+func ValidatePubkeys(name, value string) ([]types.ValidatorPubkey, error) {
+	elements := strings.Split(value, ",")
+	pubkeys := make([]types.ValidatorPubkey, 0, len(elements))
+	for _, element := range elements {
+		pubkey, err := ValidatePubkey(name, strings.TrimSpace(element))
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
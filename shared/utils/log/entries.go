@@ -0,0 +1,153 @@
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The largest log line that will be read back from a log file
+const maxEntrySize int = 1024 * 1024
+
+// A structured log entry, as written in JSON format and to log files
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Module  string    `json:"module,omitempty"`
+	Task    string    `json:"task,omitempty"`
+	Message string    `json:"msg"`
+}
+
+// Criteria for the entries to read back from log files; blank fields match everything
+type EntryFilter struct {
+	Module   string
+	Task     string
+	Since    time.Time
+	MinLevel Level
+	Limit    int
+}
+
+// Serializes the JSON output
+var jsonLock sync.Mutex
+
+// The file every entry is also written to, if set
+var fileOutput = struct {
+	lock   sync.Mutex
+	writer io.Writer
+}{}
+
+// Write every entry that passes the level checks to a writer as JSON, in addition to the regular output
+func SetFileOutput(writer io.Writer) {
+	fileOutput.lock.Lock()
+	defer fileOutput.lock.Unlock()
+	fileOutput.writer = writer
+}
+
+// Send every entry that passes the level checks to a writer as JSON instead of printing it.
+// This is used by worker processes, whose entries are printed by the daemon that spawned them.
+func SetWorkerOutput(writer io.Writer) {
+	log.SetOutput(io.Discard)
+	SetFileOutput(writer)
+}
+
+// Create an entry for a message
+func newEntry(module string, task string, level Level, message string) Entry {
+	return Entry{
+		Time:    time.Now().UTC(),
+		Level:   level.String(),
+		Module:  module,
+		Task:    task,
+		Message: strings.TrimRight(message, "\n"),
+	}
+}
+
+// Write an entry as a line of JSON to the regular output
+func writeJson(entry Entry) {
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("error encoding log message: %s", err.Error())
+		return
+	}
+	jsonLock.Lock()
+	defer jsonLock.Unlock()
+	fmt.Fprintln(log.Writer(), string(bytes))
+}
+
+// Write an entry as a line of JSON to the file output, if there is one
+func writeFile(entry Entry) {
+	fileOutput.lock.Lock()
+	defer fileOutput.lock.Unlock()
+	if fileOutput.writer == nil {
+		return
+	}
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	fileOutput.writer.Write(append(bytes, '\n'))
+}
+
+// Check if an entry matches the filter
+func (f EntryFilter) Matches(entry Entry) bool {
+	if f.Module != "" && entry.Module != f.Module {
+		return false
+	}
+	if f.Task != "" && entry.Task != f.Task {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	level, err := ParseLevel(entry.Level)
+	if err == nil && level < f.MinLevel {
+		return false
+	}
+	return true
+}
+
+// Read the entries that match a filter from a set of log files, sorted by time.
+// If the filter has a limit, only the most recent entries are returned. Lines that aren't entries are skipped.
+func ReadEntries(paths []string, filter EntryFilter) ([]Entry, error) {
+	entries := []Entry{}
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error opening log file [%s]: %w", path, err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+		for scanner.Scan() {
+			var entry Entry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				continue
+			}
+			if filter.Matches(entry) {
+				entries = append(entries, entry)
+			}
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading log file [%s]: %w", path, err)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	return entries, nil
+}
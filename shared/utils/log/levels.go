@@ -0,0 +1,258 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The severity of a log message
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// How log messages are written
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJson Format = "json"
+)
+
+// The key for the default level in a map of module levels
+const DefaultModule string = "*"
+
+// The logging settings shared by every logger in the process
+var settings = struct {
+	lock         sync.RWMutex
+	format       Format
+	defaultLevel Level
+	moduleLevels map[string]Level
+	overrides    map[string]Level
+}{
+	format:       FormatText,
+	defaultLevel: LevelInfo,
+	moduleLevels: map[string]Level{},
+	overrides:    map[string]Level{},
+}
+
+// Get the name of a level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// Parse a level from its name
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("invalid log level '%s'; it must be debug, info, warn, or error", name)
+	}
+}
+
+// Parse a comma-separated list of module levels, such as "rewards=debug,node=warn"
+func ParseModuleLevels(list string) (map[string]Level, error) {
+	levels := map[string]Level{}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		module, name, found := strings.Cut(entry, "=")
+		module = strings.TrimSpace(module)
+		if !found || module == "" {
+			return nil, fmt.Errorf("invalid module log level '%s'; it must be in the form module=level", entry)
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid module log level '%s': %w", entry, err)
+		}
+		levels[module] = level
+	}
+	return levels, nil
+}
+
+// Set the format and levels from the config
+func Configure(format Format, defaultLevel Level, moduleLevels map[string]Level) {
+	settings.lock.Lock()
+	defer settings.lock.Unlock()
+	settings.format = format
+	settings.defaultLevel = defaultLevel
+	settings.moduleLevels = moduleLevels
+}
+
+// Set levels that take precedence over the config until they're cleared, keyed by module or DefaultModule
+func SetLevelOverrides(overrides map[string]Level) {
+	settings.lock.Lock()
+	defer settings.lock.Unlock()
+	settings.overrides = overrides
+}
+
+// Get the format log messages are written in
+func GetFormat() Format {
+	settings.lock.RLock()
+	defer settings.lock.RUnlock()
+	return settings.format
+}
+
+// Get the level a module logs at, along with the levels of every module with one of its own, keyed by module or DefaultModule
+func GetLevels() map[string]Level {
+	settings.lock.RLock()
+	defer settings.lock.RUnlock()
+	levels := map[string]Level{DefaultModule: settings.defaultLevel}
+	for module, level := range settings.moduleLevels {
+		levels[module] = level
+	}
+	for module, level := range settings.overrides {
+		levels[module] = level
+	}
+	return levels
+}
+
+// Get the minimum level of the messages a module writes
+func GetLevel(module string) Level {
+	settings.lock.RLock()
+	defer settings.lock.RUnlock()
+	if level, exists := settings.overrides[module]; exists {
+		return level
+	}
+	if level, exists := settings.moduleLevels[module]; exists {
+		return level
+	}
+	if level, exists := settings.overrides[DefaultModule]; exists {
+		return level
+	}
+	return settings.defaultLevel
+}
+
+// Check if a module writes messages of the given level
+func IsEnabled(module string, level Level) bool {
+	return level >= GetLevel(module)
+}
+
+// Format a map of module levels as a comma-separated list, sorted by module
+func FormatModuleLevels(levels map[string]Level) string {
+	modules := []string{}
+	for module := range levels {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	entries := make([]string, len(modules))
+	for i, module := range modules {
+		entries[i] = fmt.Sprintf("%s=%s", module, levels[module])
+	}
+	return strings.Join(entries, ",")
+}
+
+// Load the level overrides saved at runtime; a missing file means there are none
+func LoadLevelOverrides(path string) (map[string]Level, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]Level{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading log level overrides [%s]: %w", path, err)
+	}
+	names := map[string]string{}
+	if err := json.Unmarshal(bytes, &names); err != nil {
+		return nil, fmt.Errorf("error decoding log level overrides [%s]: %w", path, err)
+	}
+	overrides := map[string]Level{}
+	for module, name := range names {
+		level, err := ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding log level overrides [%s]: %w", path, err)
+		}
+		overrides[module] = level
+	}
+	return overrides, nil
+}
+
+// Save level overrides for the daemons to pick up; saving an empty map clears them
+func SaveLevelOverrides(path string, overrides map[string]Level) error {
+	if len(overrides) == 0 {
+		err := os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing log level overrides [%s]: %w", path, err)
+		}
+		return nil
+	}
+	names := map[string]string{}
+	for module, level := range overrides {
+		names[module] = level.String()
+	}
+	bytes, err := json.Marshal(names)
+	if err != nil {
+		return fmt.Errorf("error encoding log level overrides: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating the log level overrides' directory: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving log level overrides [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Poll the level overrides file and apply it whenever it changes, so levels can be changed without restarting the daemon
+func WatchLevelOverrides(path string, interval time.Duration, logger *ColorLogger) {
+	apply := func() {
+		overrides, err := LoadLevelOverrides(path)
+		if err != nil {
+			logger.Printlnf("WARNING: couldn't load the log level overrides: %s", err.Error())
+			return
+		}
+		SetLevelOverrides(overrides)
+		if len(overrides) > 0 {
+			logger.Printlnf("Applied log level overrides: %s", FormatModuleLevels(overrides))
+		}
+	}
+	getModTime := func() time.Time {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}
+		}
+		return info.ModTime()
+	}
+
+	lastModTime := getModTime()
+	apply()
+	go func() {
+		for {
+			time.Sleep(interval)
+			modTime := getModTime()
+			if !modTime.Equal(lastModTime) {
+				lastModTime = modTime
+				apply()
+			}
+		}
+	}()
+}
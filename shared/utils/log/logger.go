@@ -1,6 +1,7 @@
 package log
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/fatih/color"
@@ -11,33 +12,102 @@ type ColorLogger struct {
 	Color       color.Attribute
 	sprintFunc  func(a ...interface{}) string
 	sprintfFunc func(format string, a ...interface{}) string
+
+	// The module the logger belongs to, used to pick its level and included in JSON output
+	module string
+
+	// The level of the messages printed with Print and its variants
+	level Level
+
+	// The daemon task the logger belongs to, if any, included in JSON output so entries can be filtered by task
+	task string
 }
 
 // Create new color logger
 func NewColorLogger(colorAttr color.Attribute) ColorLogger {
+	return NewModuleLogger("", LevelInfo, colorAttr)
+}
+
+// Create a new color logger for a module, whose messages are printed at the given level
+func NewModuleLogger(module string, level Level, colorAttr color.Attribute) ColorLogger {
 	return ColorLogger{
 		Color:       colorAttr,
 		sprintFunc:  color.New(colorAttr).SprintFunc(),
 		sprintfFunc: color.New(colorAttr).SprintfFunc(),
+		module:      module,
+		level:       level,
 	}
 }
 
+// Get the module the logger belongs to
+func (l *ColorLogger) Module() string {
+	return l.module
+}
+
+// Get a copy of the logger whose entries are tagged with a daemon task
+func (l ColorLogger) WithTask(task string) ColorLogger {
+	l.task = task
+	return l
+}
+
+// Get the task the logger belongs to
+func (l *ColorLogger) Task() string {
+	return l.task
+}
+
 // Print values
 func (l *ColorLogger) Print(v ...interface{}) {
-	log.Print(l.sprintFunc(v...))
+	l.write(l.level, false, fmt.Sprint(v...))
 }
 
 // Print values with a newline
 func (l *ColorLogger) Println(v ...interface{}) {
-	log.Println(l.sprintFunc(v...))
+	l.write(l.level, true, fmt.Sprint(v...))
 }
 
 // Print a formatted string
 func (l *ColorLogger) Printf(format string, v ...interface{}) {
-	log.Print(l.sprintfFunc(format, v...))
+	l.write(l.level, false, fmt.Sprintf(format, v...))
 }
 
 // Print a formatted string with a newline
 func (l *ColorLogger) Printlnf(format string, v ...interface{}) {
-	log.Println(l.sprintfFunc(format, v...))
+	l.write(l.level, true, fmt.Sprintf(format, v...))
+}
+
+// Print values with a newline at the debug level
+func (l *ColorLogger) Debug(v ...interface{}) {
+	l.write(LevelDebug, true, fmt.Sprint(v...))
+}
+
+// Print a formatted string with a newline at the debug level
+func (l *ColorLogger) Debugf(format string, v ...interface{}) {
+	l.write(LevelDebug, true, fmt.Sprintf(format, v...))
+}
+
+// Print an entry from a worker process at the level it was logged at
+func (l *ColorLogger) Relay(entry Entry) {
+	level, err := ParseLevel(entry.Level)
+	if err != nil {
+		level = l.level
+	}
+	l.write(level, true, entry.Message)
+}
+
+// Write a message if the module's level allows it
+func (l *ColorLogger) write(level Level, newline bool, message string) {
+	if !IsEnabled(l.module, level) {
+		return
+	}
+	entry := newEntry(l.module, l.task, level, message)
+	writeFile(entry)
+	if GetFormat() == FormatJson {
+		writeJson(entry)
+		return
+	}
+	if newline {
+		log.Println(l.sprintFunc(message))
+	} else {
+		log.Print(l.sprintFunc(message))
+	}
 }
@@ -0,0 +1,61 @@
+package log
+
+// The modules the daemons log as, each of which can have its own level
+const (
+	ModuleNode                 string = "node"
+	ModuleWatchtower           string = "watchtower"
+	ModuleApiServer            string = "api-server"
+	ModuleEcManager            string = "ec-manager"
+	ModuleBcManager            string = "bc-manager"
+	ModuleMetrics              string = "metrics"
+	ModuleRewards              string = "rewards"
+	ModuleFeeRecipient         string = "fee-recipient"
+	ModuleNodeHealth           string = "node-health"
+	ModuleDistributeMinipools  string = "distribute-minipools"
+	ModuleStakeMinipools       string = "stake-minipools"
+	ModulePromoteMinipools     string = "promote-minipools"
+	ModuleReduceBonds          string = "reduce-bonds"
+	ModuleVotingTrees          string = "voting-trees"
+	ModuleNonces               string = "nonces"
+	ModuleRespondChallenges    string = "respond-challenges"
+	ModuleRplPrice             string = "rpl-price"
+	ModuleNetworkBalances      string = "network-balances"
+	ModuleDissolveMinipools    string = "dissolve-minipools"
+	ModuleScrubMinipools       string = "scrub-minipools"
+	ModuleCancelBondReductions string = "cancel-bond-reductions"
+	ModuleSoloMigrations       string = "solo-migrations"
+	ModulePenalties            string = "penalties"
+	ModuleProposalVotes        string = "proposal-votes"
+	ModuleTxQueue              string = "tx-queue"
+)
+
+// Get every module, in the order they're listed to users
+func GetModules() []string {
+	return []string{
+		ModuleNode,
+		ModuleWatchtower,
+		ModuleApiServer,
+		ModuleEcManager,
+		ModuleBcManager,
+		ModuleMetrics,
+		ModuleRewards,
+		ModuleFeeRecipient,
+		ModuleNodeHealth,
+		ModuleDistributeMinipools,
+		ModuleStakeMinipools,
+		ModulePromoteMinipools,
+		ModuleReduceBonds,
+		ModuleVotingTrees,
+		ModuleNonces,
+		ModuleRespondChallenges,
+		ModuleRplPrice,
+		ModuleNetworkBalances,
+		ModuleDissolveMinipools,
+		ModuleScrubMinipools,
+		ModuleCancelBondReductions,
+		ModuleSoloMigrations,
+		ModulePenalties,
+		ModuleProposalVotes,
+		ModuleTxQueue,
+	}
+}
@@ -0,0 +1,168 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The permissions of log files
+const logFileMode = 0644
+
+// A log file that's rotated once it reaches a maximum size or age.
+// Rotated files are kept next to it with a numbered suffix, where .1 is the most recent.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	lock     sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// Open a rotating log file, appending to it if it exists. A max size or age of 0 disables that kind of rotation.
+func NewRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating log folder: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write to the file, rotating it first if it's full or too old
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if (f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize) ||
+		(f.maxAge > 0 && time.Since(f.openedAt) >= f.maxAge) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close the file
+func (f *RotatingFile) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// Open the file for appending. Its age is counted from the last rotation, or from now if it has never been rotated.
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, logFileMode)
+	if err != nil {
+		return fmt.Errorf("error opening log file [%s]: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("error getting log file info [%s]: %w", f.path, err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+	if backupInfo, err := os.Stat(getBackupPath(f.path, 1)); err == nil {
+		f.openedAt = backupInfo.ModTime()
+	}
+	return nil
+}
+
+// Shift the file and its backups down by one, drop the oldest, and start a new file
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("error closing log file [%s]: %w", f.path, err)
+	}
+	f.file = nil
+
+	if f.maxBackups <= 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing log file [%s]: %w", f.path, err)
+		}
+	} else {
+		os.Remove(getBackupPath(f.path, f.maxBackups))
+		for i := f.maxBackups - 1; i >= 1; i-- {
+			if err := os.Rename(getBackupPath(f.path, i), getBackupPath(f.path, i+1)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("error rotating log file [%s]: %w", f.path, err)
+			}
+		}
+		if err := os.Rename(f.path, getBackupPath(f.path, 1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error rotating log file [%s]: %w", f.path, err)
+		}
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, logFileMode)
+	if err != nil {
+		return fmt.Errorf("error creating log file [%s]: %w", f.path, err)
+	}
+	f.file = file
+	f.size = 0
+	f.openedAt = time.Now()
+	return nil
+}
+
+// Get the path of a rotated copy of a log file
+func getBackupPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+// Get a log file and its rotated copies that exist, oldest first
+func GetLogFiles(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("error listing rotated log files for [%s]: %w", path, err)
+	}
+
+	backups := map[int]string{}
+	indices := []int{}
+	for _, match := range matches {
+		index, err := strconv.Atoi(strings.TrimPrefix(match, path+"."))
+		if err != nil || index < 1 {
+			continue
+		}
+		backups[index] = match
+		indices = append(indices, index)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(indices)))
+
+	paths := []string{}
+	for _, index := range indices {
+		paths = append(paths, backups[index])
+	}
+	if _, err := os.Stat(path); err == nil {
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
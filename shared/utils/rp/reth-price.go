@@ -0,0 +1,233 @@
+package rp
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+const (
+	uniswapV3PoolAbi string = `[
+		{"inputs":[],"name":"token0","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+		{"inputs":[],"name":"token1","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+		{"inputs":[],"name":"slot0","outputs":[{"internalType":"uint160","name":"sqrtPriceX96","type":"uint160"},{"internalType":"int24","name":"tick","type":"int24"},{"internalType":"uint16","name":"observationIndex","type":"uint16"},{"internalType":"uint16","name":"observationCardinality","type":"uint16"},{"internalType":"uint16","name":"observationCardinalityNext","type":"uint16"},{"internalType":"uint8","name":"feeProtocol","type":"uint8"},{"internalType":"bool","name":"unlocked","type":"bool"}],"stateMutability":"view","type":"function"}
+	]`
+
+	balancerVaultAbi string = `[
+		{"inputs":[{"internalType":"bytes32","name":"poolId","type":"bytes32"}],"name":"getPoolTokens","outputs":[{"internalType":"contract IERC20[]","name":"tokens","type":"address[]"},{"internalType":"uint256[]","name":"balances","type":"uint256[]"},{"internalType":"uint256","name":"lastChangeBlock","type":"uint256"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"internalType":"enum IVault.SwapKind","name":"kind","type":"uint8"},{"components":[{"internalType":"bytes32","name":"poolId","type":"bytes32"},{"internalType":"uint256","name":"assetInIndex","type":"uint256"},{"internalType":"uint256","name":"assetOutIndex","type":"uint256"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes","name":"userData","type":"bytes"}],"internalType":"struct IVault.BatchSwapStep[]","name":"swaps","type":"tuple[]"},{"internalType":"contract IAsset[]","name":"assets","type":"address[]"},{"components":[{"internalType":"address","name":"sender","type":"address"},{"internalType":"bool","name":"fromInternalBalance","type":"bool"},{"internalType":"address payable","name":"recipient","type":"address"},{"internalType":"bool","name":"toInternalBalance","type":"bool"}],"internalType":"struct IVault.FundManagement","name":"funds","type":"tuple"}],"name":"queryBatchSwap","outputs":[{"internalType":"int256[]","name":"","type":"int256[]"}],"stateMutability":"nonpayable","type":"function"}
+	]`
+
+	rethPriceSource_UniswapV3Prefix string = "uniswapv3:"
+	rethPriceSource_BalancerPrefix  string = "balancer:"
+
+	// Balancer's GIVEN_IN swap kind
+	balancerSwapKindGivenIn uint8 = 0
+)
+
+type uniswapV3Slot0Response struct {
+	SqrtPriceX96               *big.Int `abi:"sqrtPriceX96"`
+	Tick                       *big.Int `abi:"tick"`
+	ObservationIndex           uint16   `abi:"observationIndex"`
+	ObservationCardinality     uint16   `abi:"observationCardinality"`
+	ObservationCardinalityNext uint16   `abi:"observationCardinalityNext"`
+	FeeProtocol                uint8    `abi:"feeProtocol"`
+	Unlocked                   bool     `abi:"unlocked"`
+}
+
+type balancerPoolTokensResponse struct {
+	Tokens          []common.Address `abi:"tokens"`
+	Balances        []*big.Int       `abi:"balances"`
+	LastChangeBlock *big.Int         `abi:"lastChangeBlock"`
+}
+
+type balancerBatchSwapStep struct {
+	PoolId        [32]byte
+	AssetInIndex  *big.Int
+	AssetOutIndex *big.Int
+	Amount        *big.Int
+	UserData      []byte
+}
+
+type balancerFundManagement struct {
+	Sender              common.Address
+	FromInternalBalance bool
+	Recipient           common.Address
+	ToInternalBalance   bool
+}
+
+// A secondary-market source of the rETH/ETH price
+type RethPriceSource struct {
+	Name     string
+	GetPrice func(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, error)
+}
+
+// Get the secondary-market rETH price sources from the Smartnode config
+func GetRethPriceSources(cfg *config.RocketPoolConfig) ([]RethPriceSource, error) {
+	rethAddress := cfg.Smartnode.GetRethAddress()
+	sources := []RethPriceSource{}
+	for _, entry := range strings.Split(cfg.Smartnode.RethPriceSources.Value.(string), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(strings.ToLower(entry), rethPriceSource_UniswapV3Prefix):
+			addressString := strings.TrimSpace(entry[len(rethPriceSource_UniswapV3Prefix):])
+			if !common.IsHexAddress(addressString) {
+				return nil, fmt.Errorf("invalid Uniswap v3 pool address [%s]", addressString)
+			}
+			poolAddress := common.HexToAddress(addressString)
+			sources = append(sources, RethPriceSource{
+				Name: entry,
+				GetPrice: func(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, error) {
+					return getUniswapV3RethPrice(client, poolAddress, rethAddress, opts)
+				},
+			})
+
+		case strings.HasPrefix(strings.ToLower(entry), rethPriceSource_BalancerPrefix):
+			poolIdString := strings.TrimSpace(entry[len(rethPriceSource_BalancerPrefix):])
+			poolIdBytes := common.FromHex(poolIdString)
+			if len(poolIdBytes) != common.HashLength {
+				return nil, fmt.Errorf("invalid Balancer pool ID [%s]", poolIdString)
+			}
+			vaultAddress := cfg.Smartnode.GetBalancerVaultAddress()
+			if vaultAddress == "" {
+				return nil, fmt.Errorf("the Balancer vault is not deployed on this network")
+			}
+			poolId := common.BytesToHash(poolIdBytes)
+			sources = append(sources, RethPriceSource{
+				Name: entry,
+				GetPrice: func(client rocketpool.ExecutionClient, opts *bind.CallOpts) (*big.Int, error) {
+					return getBalancerRethPrice(client, common.HexToAddress(vaultAddress), poolId, rethAddress, opts)
+				},
+			})
+
+		default:
+			return nil, fmt.Errorf("unknown rETH price source [%s]", entry)
+		}
+	}
+	return sources, nil
+}
+
+// Get the premium (or discount, if negative) of a secondary-market rETH price over the protocol's exchange rate, in percent
+func GetRethPremium(marketPrice float64, exchangeRate float64) float64 {
+	if exchangeRate == 0 {
+		return 0
+	}
+	return (marketPrice/exchangeRate - 1) * 100
+}
+
+// Get the spot rETH/ETH price from a Uniswap v3 pool, in wei per rETH
+func getUniswapV3RethPrice(client rocketpool.ExecutionClient, poolAddress common.Address, rethAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	pool, err := newExternalContract(client, poolAddress, uniswapV3PoolAbi)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Uniswap v3 pool ABI: %w", err)
+	}
+
+	// Find out which side of the pool rETH is on
+	token0 := new(common.Address)
+	if err := pool.Call(opts, token0, "token0"); err != nil {
+		return nil, fmt.Errorf("error getting pool token0: %w", err)
+	}
+	token1 := new(common.Address)
+	if err := pool.Call(opts, token1, "token1"); err != nil {
+		return nil, fmt.Errorf("error getting pool token1: %w", err)
+	}
+	if *token0 != rethAddress && *token1 != rethAddress {
+		return nil, fmt.Errorf("pool %s does not contain rETH", poolAddress.Hex())
+	}
+
+	// Get the current price of token0 in token1; both tokens have 18 decimals so no scaling is needed
+	response := uniswapV3Slot0Response{}
+	if err := pool.Call(opts, &response, "slot0"); err != nil {
+		return nil, fmt.Errorf("error getting pool slot0: %w", err)
+	}
+	if response.SqrtPriceX96 == nil || response.SqrtPriceX96.Sign() == 0 {
+		return nil, fmt.Errorf("pool %s has not been initialized", poolAddress.Hex())
+	}
+
+	// price = (sqrtPriceX96 / 2^96)^2
+	one := eth.EthToWei(1)
+	squared := big.NewInt(0).Mul(response.SqrtPriceX96, response.SqrtPriceX96)
+	if *token0 == rethAddress {
+		price := big.NewInt(0).Mul(squared, one)
+		return price.Rsh(price, 192), nil
+	}
+	price := big.NewInt(0).Lsh(one, 192)
+	return price.Div(price, squared), nil
+}
+
+// Get the rETH/ETH price from a Balancer pool by quoting a swap of 1 rETH, in wei per rETH
+func getBalancerRethPrice(client rocketpool.ExecutionClient, vaultAddress common.Address, poolId common.Hash, rethAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	vault, err := newExternalContract(client, vaultAddress, balancerVaultAbi)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Balancer vault ABI: %w", err)
+	}
+
+	// Find rETH and its counterpart in the pool; composable pools also contain their own BPT, which is skipped
+	tokens := balancerPoolTokensResponse{}
+	if err := vault.Call(opts, &tokens, "getPoolTokens", poolId); err != nil {
+		return nil, fmt.Errorf("error getting pool tokens: %w", err)
+	}
+	poolAddress := common.BytesToAddress(poolId[:common.AddressLength])
+	rethIndex := -1
+	otherIndex := -1
+	for i, token := range tokens.Tokens {
+		if token == rethAddress {
+			rethIndex = i
+		} else if token != poolAddress && otherIndex == -1 {
+			otherIndex = i
+		}
+	}
+	if rethIndex == -1 || otherIndex == -1 {
+		return nil, fmt.Errorf("pool %s does not contain rETH and a counterpart token", poolId.Hex())
+	}
+
+	// Quote the swap
+	one := eth.EthToWei(1)
+	swaps := []balancerBatchSwapStep{{
+		PoolId:        poolId,
+		AssetInIndex:  big.NewInt(int64(rethIndex)),
+		AssetOutIndex: big.NewInt(int64(otherIndex)),
+		Amount:        one,
+		UserData:      []byte{},
+	}}
+	funds := balancerFundManagement{
+		Sender:    vaultAddress,
+		Recipient: vaultAddress,
+	}
+	deltas := new([]*big.Int)
+	if err := vault.Call(opts, deltas, "queryBatchSwap", balancerSwapKindGivenIn, swaps, tokens.Tokens, funds); err != nil {
+		return nil, fmt.Errorf("error quoting swap: %w", err)
+	}
+	if len(*deltas) <= otherIndex || (*deltas)[otherIndex] == nil {
+		return nil, fmt.Errorf("swap quote did not include the output token")
+	}
+
+	// The vault reports the amount sent out of it as a negative delta
+	return big.NewInt(0).Neg((*deltas)[otherIndex]), nil
+}
+
+// Create a contract binding for a contract outside of Rocket Pool
+func newExternalContract(client rocketpool.ExecutionClient, address common.Address, abiString string) (*rocketpool.Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiString))
+	if err != nil {
+		return nil, err
+	}
+	contract := bind.NewBoundContract(address, parsed, client, client, client)
+	return &rocketpool.Contract{
+		Contract: contract,
+		Address:  &address,
+		ABI:      &parsed,
+		Client:   client,
+	}, nil
+}
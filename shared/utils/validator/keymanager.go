@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Settings
+const (
+	keymanagerFeeRecipientPath string        = "/eth/v1/validator/%s/feerecipient"
+	keymanagerRequestTimeout   time.Duration = 10 * time.Second
+)
+
+// Returned when the validator client doesn't have a validator's key loaded
+var ErrValidatorNotLoaded = errors.New("validator is not loaded in the validator client")
+
+// A client for the fee recipient endpoints of a validator client's keymanager API
+type KeymanagerClient struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// The keymanager API's response for a validator's fee recipient
+type feeRecipientResponse struct {
+	Data struct {
+		Pubkey     string `json:"pubkey"`
+		EthAddress string `json:"ethaddress"`
+	} `json:"data"`
+}
+
+// Create a new keymanager client, reading its bearer token from the given file
+func NewKeymanagerClient(url string, tokenPath string) (*KeymanagerClient, error) {
+	tokenBytes, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the keymanager API token from %s: %w", tokenPath, err)
+	}
+	return &KeymanagerClient{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  strings.TrimSpace(string(tokenBytes)),
+		client: &http.Client{Timeout: keymanagerRequestTimeout},
+	}, nil
+}
+
+// Get the fee recipient the validator client is actually using for a validator
+func (k *KeymanagerClient) GetFeeRecipient(pubkey types.ValidatorPubkey) (common.Address, error) {
+	request, err := http.NewRequest(http.MethodGet, k.url+fmt.Sprintf(keymanagerFeeRecipientPath, pubkey.Hex()), nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error creating fee recipient request for validator %s: %w", pubkey.Hex(), err)
+	}
+	request.Header.Set("Authorization", "Bearer "+k.token)
+	response, err := k.client.Do(request)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting the fee recipient of validator %s from the keymanager API: %w", pubkey.Hex(), err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error reading the fee recipient of validator %s from the keymanager API: %w", pubkey.Hex(), err)
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return common.Address{}, ErrValidatorNotLoaded
+	}
+	if response.StatusCode != http.StatusOK {
+		return common.Address{}, fmt.Errorf("the keymanager API returned status %d for the fee recipient of validator %s: %s", response.StatusCode, pubkey.Hex(), string(body))
+	}
+
+	var feeRecipient feeRecipientResponse
+	if err := json.Unmarshal(body, &feeRecipient); err != nil {
+		return common.Address{}, fmt.Errorf("error decoding the fee recipient of validator %s: %w", pubkey.Hex(), err)
+	}
+	if !common.IsHexAddress(feeRecipient.Data.EthAddress) {
+		return common.Address{}, fmt.Errorf("the keymanager API returned an invalid fee recipient '%s' for validator %s", feeRecipient.Data.EthAddress, pubkey.Hex())
+	}
+	return common.HexToAddress(feeRecipient.Data.EthAddress), nil
+}
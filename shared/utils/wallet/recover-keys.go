@@ -26,19 +26,58 @@ import (
 const (
 	bucketSize  uint = 20
 	bucketLimit uint = 2000
+
+	// The number of derivation indices searched for validator keys by default
+	DefaultKeySearchDepth uint = bucketLimit
 )
 
 func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, testOnly bool) ([]types.ValidatorPubkey, error) {
+	return RecoverMinipoolKeysWithDepth(c, rp, address, w, DefaultKeySearchDepth, testOnly)
+}
 
-	cfg, err := services.GetConfig(c)
+// Recover the node's minipool validator keys, searching the first searchDepth derivation indices for them
+func RecoverMinipoolKeysWithDepth(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, searchDepth uint, testOnly bool) ([]types.ValidatorPubkey, error) {
+	pubkeys, missingPubkeys, err := recoverMinipoolKeys(c, rp, address, w, searchDepth, testOnly)
 	if err != nil {
 		return nil, err
 	}
+	if len(missingPubkeys) > 0 {
+		return nil, fmt.Errorf("attempt limit exceeded (%d keys)", searchDepth)
+	}
+	return pubkeys, nil
+}
+
+// Check which of the node's minipool validator keys can be regenerated from the wallet (or the custom keys), without saving any of them
+func AuditMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet) ([]types.ValidatorPubkey, []types.ValidatorPubkey, error) {
+	pubkeys, missingPubkeys, err := recoverMinipoolKeys(c, rp, address, w, DefaultKeySearchDepth, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	missingPubkeyMap := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range missingPubkeys {
+		missingPubkeyMap[pubkey] = true
+	}
+	recoveredPubkeys := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if !missingPubkeyMap[pubkey] {
+			recoveredPubkeys = append(recoveredPubkeys, pubkey)
+		}
+	}
+	return recoveredPubkeys, missingPubkeys, nil
+}
+
+// Recover the node's minipool validator keys, returning all of its pubkeys and the ones that couldn't be found within the search limit
+func recoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, searchDepth uint, testOnly bool) ([]types.ValidatorPubkey, []types.ValidatorPubkey, error) {
+
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Get node's validating pubkeys
 	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, address, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Remove zero pubkeys
@@ -51,25 +90,43 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 	}
 	pubkeys = filteredPubkeys
 
+	missingPubkeys, err := recoverValidatorKeys(cfg, pubkeys, w, searchDepth, testOnly)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pubkeys, missingPubkeys, nil
+
+}
+
+// Recover specific validator keys instead of all of the node's minipool keys, searching the custom keys and the first
+// searchDepth derivation indices for them. Returns the pubkeys that couldn't be found.
+func RecoverValidatorKeys(c *cli.Context, pubkeys []types.ValidatorPubkey, w *wallet.Wallet, searchDepth uint, testOnly bool) ([]types.ValidatorPubkey, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return recoverValidatorKeys(cfg, pubkeys, w, searchDepth, testOnly)
+}
+
+// Recover validator keys from the custom keys and the wallet, returning the pubkeys that couldn't be found
+func recoverValidatorKeys(cfg *config.RocketPoolConfig, pubkeys []types.ValidatorPubkey, w *wallet.Wallet, searchDepth uint, testOnly bool) ([]types.ValidatorPubkey, error) {
+
 	pubkeyMap := map[types.ValidatorPubkey]bool{}
 	for _, pubkey := range pubkeys {
 		pubkeyMap[pubkey] = true
 	}
 
-	pubkeyMap, err = CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, testOnly)
+	pubkeyMap, err := CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, testOnly)
 	if err != nil {
 		return nil, fmt.Errorf("error checking for or recovering custom validator keys: %w", err)
 	}
 
 	// Recover conventionally generated keys
 	bucketStart := uint(0)
-	for {
-		if bucketStart >= bucketLimit {
-			return nil, fmt.Errorf("attempt limit exceeded (%d keys)", bucketLimit)
-		}
+	for len(pubkeyMap) > 0 && bucketStart < searchDepth {
 		bucketEnd := bucketStart + bucketSize
-		if bucketEnd > bucketLimit {
-			bucketEnd = bucketLimit
+		if bucketEnd > searchDepth {
+			bucketEnd = searchDepth
 		}
 
 		// Get the keys for this bucket
@@ -91,16 +148,20 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 			}
 		}
 
-		if len(pubkeyMap) == 0 {
-			// All keys recovered!
-			break
-		}
-
 		// Run another iteration with the next bucket
 		bucketStart = bucketEnd
 	}
 
-	return pubkeys, nil
+	// Whatever is left couldn't be found
+	missingPubkeys := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if pubkeyMap[pubkey] {
+			missingPubkeys = append(missingPubkeys, pubkey)
+			delete(pubkeyMap, pubkey)
+		}
+	}
+
+	return missingPubkeys, nil
 
 }
 